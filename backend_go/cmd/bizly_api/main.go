@@ -2,10 +2,19 @@ package main
 
 import (
 	"log"
+	"net/http"
 
 	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/config"
 	httpDelivery "github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/caldav"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/mail"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/messaging"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/nfse"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/payment"
 	gormPersistence "github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/persistence/gorm"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/push"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/webhook"
 	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
 
 	"github.com/gin-contrib/cors" // <<< ADICIONE ESTE IMPORT
@@ -20,7 +29,66 @@ func main() {
 	if err != nil {
 		log.Fatalf("Falha ao conectar ao banco de dados: %v", err)
 	}
-	err = db.AutoMigrate(&gormPersistence.UserGormModel{}, &gormPersistence.AppointmentGormModel{}, &gormPersistence.ClientGormModel{})
+	err = db.AutoMigrate(
+		&gormPersistence.UserGormModel{},
+		&gormPersistence.AppointmentGormModel{},
+		&gormPersistence.ClientGormModel{},
+		&gormPersistence.PaymentGormModel{},
+		&gormPersistence.PaymentWebhookEventGormModel{},
+		&gormPersistence.FinancialEntryGormModel{},
+		&gormPersistence.ExpenseCategoryGormModel{},
+		&gormPersistence.RecurringExpenseGormModel{},
+		&gormPersistence.BudgetAlertGormModel{},
+		&gormPersistence.TaxProfileGormModel{},
+		&gormPersistence.RevenueLimitAlertGormModel{},
+		&gormPersistence.ServiceGormModel{},
+		&gormPersistence.ProfessionalGormModel{},
+		&gormPersistence.CommissionRuleGormModel{},
+		&gormPersistence.CommissionGormModel{},
+		&gormPersistence.CommissionPayoutGormModel{},
+		&gormPersistence.ServicePackageGormModel{},
+		&gormPersistence.ClientPackageGormModel{},
+		&gormPersistence.PackageCreditUsageGormModel{},
+		&gormPersistence.MembershipPlanGormModel{},
+		&gormPersistence.MembershipSubscriptionGormModel{},
+		&gormPersistence.MembershipCycleGormModel{},
+		&gormPersistence.MembershipUsageGormModel{},
+		&gormPersistence.CouponGormModel{},
+		&gormPersistence.CouponRedemptionGormModel{},
+		&gormPersistence.GiftCardGormModel{},
+		&gormPersistence.GiftCardTransactionGormModel{},
+		&gormPersistence.QuoteGormModel{},
+		&gormPersistence.QuoteItemGormModel{},
+		&gormPersistence.IncomeForecastGormModel{},
+		&gormPersistence.InvoiceGormModel{},
+		&gormPersistence.InvoiceSettingsGormModel{},
+		&gormPersistence.CheckoutGormModel{},
+		&gormPersistence.CheckoutProductGormModel{},
+		&gormPersistence.ProductGormModel{},
+		&gormPersistence.StockMovementGormModel{},
+		&gormPersistence.LowStockAlertGormModel{},
+		&gormPersistence.SaleGormModel{},
+		&gormPersistence.SaleItemGormModel{},
+		&gormPersistence.CashRegisterGormModel{},
+		&gormPersistence.WorkingHoursGormModel{},
+		&gormPersistence.ReminderRuleGormModel{},
+		&gormPersistence.ReminderGormModel{},
+		&gormPersistence.WhatsAppMessageGormModel{},
+		&gormPersistence.EmailSettingsGormModel{},
+		&gormPersistence.OutboundEmailGormModel{},
+		&gormPersistence.DomainEventGormModel{},
+		&gormPersistence.EventDeliveryGormModel{},
+		&gormPersistence.WebhookSubscriptionGormModel{},
+		&gormPersistence.WebhookDeliveryGormModel{},
+		&gormPersistence.WebhookAttemptGormModel{},
+		&gormPersistence.PushDeviceGormModel{},
+		&gormPersistence.PushPreferenceGormModel{},
+		&gormPersistence.NotificationGormModel{},
+		&gormPersistence.CalendarFeedGormModel{},
+		&gormPersistence.CalendarConnectionGormModel{},
+		&gormPersistence.CalendarBusyBlockGormModel{},
+		&gormPersistence.CalendarEventLinkGormModel{},
+	)
 	if err != nil {
 		log.Fatalf("Falha ao rodar AutoMigrate: %v", err)
 	}
@@ -28,14 +96,334 @@ func main() {
 	userGormRepo := gormPersistence.NewGormUserRepository(db)
 	appointmentGormRepo := gormPersistence.NewGormAppointmentRepository(db)
 	clientGormRepo := gormPersistence.NewGormClientRepository(db) // Adicionado
+	paymentGormRepo := gormPersistence.NewGormPaymentRepository(db)
+	financialEntryGormRepo := gormPersistence.NewGormFinancialEntryRepository(db)
+	expenseCategoryGormRepo := gormPersistence.NewGormExpenseCategoryRepository(db)
+	recurringExpenseGormRepo := gormPersistence.NewGormRecurringExpenseRepository(db)
+	budgetAlertGormRepo := gormPersistence.NewGormBudgetAlertRepository(db)
+	taxProfileGormRepo := gormPersistence.NewGormTaxProfileRepository(db)
+	revenueLimitAlertGormRepo := gormPersistence.NewGormRevenueLimitAlertRepository(db)
+	revenueGormRepo := gormPersistence.NewGormRevenueRepository(db)
+	serviceGormRepo := gormPersistence.NewGormServiceRepository(db)
+	professionalGormRepo := gormPersistence.NewGormProfessionalRepository(db)
+	commissionRuleGormRepo := gormPersistence.NewGormCommissionRuleRepository(db)
+	commissionGormRepo := gormPersistence.NewGormCommissionRepository(db)
+	commissionPayoutGormRepo := gormPersistence.NewGormCommissionPayoutRepository(db)
+	servicePackageGormRepo := gormPersistence.NewGormServicePackageRepository(db)
+	clientPackageGormRepo := gormPersistence.NewGormClientPackageRepository(db)
+	packageCreditUsageGormRepo := gormPersistence.NewGormPackageCreditUsageRepository(db)
+	membershipPlanGormRepo := gormPersistence.NewGormMembershipPlanRepository(db)
+	membershipSubscriptionGormRepo := gormPersistence.NewGormMembershipSubscriptionRepository(db)
+	membershipCycleGormRepo := gormPersistence.NewGormMembershipCycleRepository(db)
+	membershipUsageGormRepo := gormPersistence.NewGormMembershipUsageRepository(db)
+	couponGormRepo := gormPersistence.NewGormCouponRepository(db)
+	couponRedemptionGormRepo := gormPersistence.NewGormCouponRedemptionRepository(db)
+	giftCardGormRepo := gormPersistence.NewGormGiftCardRepository(db)
+	giftCardTransactionGormRepo := gormPersistence.NewGormGiftCardTransactionRepository(db)
+	quoteGormRepo := gormPersistence.NewGormQuoteRepository(db)
+	incomeForecastGormRepo := gormPersistence.NewGormIncomeForecastRepository(db)
+	invoiceGormRepo := gormPersistence.NewGormInvoiceRepository(db)
+	invoiceSettingsGormRepo := gormPersistence.NewGormInvoiceSettingsRepository(db)
+	checkoutGormRepo := gormPersistence.NewGormCheckoutRepository(db)
+	productGormRepo := gormPersistence.NewGormProductRepository(db)
+	lowStockAlertGormRepo := gormPersistence.NewGormLowStockAlertRepository(db)
+	saleGormRepo := gormPersistence.NewGormSaleRepository(db)
+	cashRegisterGormRepo := gormPersistence.NewGormCashRegisterRepository(db)
+	dashboardGormRepo := gormPersistence.NewGormDashboardRepository(db)
+	workingHoursGormRepo := gormPersistence.NewGormWorkingHoursRepository(db)
+	reminderRuleGormRepo := gormPersistence.NewGormReminderRuleRepository(db)
+	reminderGormRepo := gormPersistence.NewGormReminderRepository(db)
+	whatsAppMessageGormRepo := gormPersistence.NewGormWhatsAppMessageRepository(db)
+	emailSettingsGormRepo := gormPersistence.NewGormEmailSettingsRepository(db)
+	emailOutboxGormRepo := gormPersistence.NewGormEmailOutboxRepository(db)
+	domainEventGormRepo := gormPersistence.NewGormDomainEventRepository(db)
+	eventDeliveryGormRepo := gormPersistence.NewGormEventDeliveryRepository(db)
+	webhookSubscriptionGormRepo := gormPersistence.NewGormWebhookSubscriptionRepository(db)
+	webhookDeliveryGormRepo := gormPersistence.NewGormWebhookDeliveryRepository(db)
+	pushDeviceGormRepo := gormPersistence.NewGormPushDeviceRepository(db)
+	pushPreferenceGormRepo := gormPersistence.NewGormPushPreferenceRepository(db)
+	notificationGormRepo := gormPersistence.NewGormNotificationRepository(db)
+	calendarFeedGormRepo := gormPersistence.NewGormCalendarFeedRepository(db)
+	calendarConnectionGormRepo := gormPersistence.NewGormCalendarConnectionRepository(db)
+	calendarBusyBlockGormRepo := gormPersistence.NewGormCalendarBusyBlockRepository(db)
+	calendarEventLinkGormRepo := gormPersistence.NewGormCalendarEventLinkRepository(db)
+	unitOfWork := gormPersistence.NewGormUnitOfWork(db)
+
+	// Provedores de pagamento disponíveis. O "fake" permite testar o fluxo completo offline.
+	paymentProviders := payment.NewRegistry(payment.NewFakeProvider(cfg.PaymentWebhookSecret))
+	if err := paymentProviders.SetDefault(cfg.PaymentProvider); err != nil {
+		log.Fatalf("Provedor de pagamento '%s' inválido: %v", cfg.PaymentProvider, err)
+	}
+
+	// Provedores de NFS-e disponíveis. O "mock" simula a prefeitura sem serviços externos.
+	nfseProviders := nfse.NewRegistry(nfse.NewMockProvider())
+	if err := nfseProviders.SetDefault(cfg.NFSeProvider); err != nil {
+		log.Fatalf("Provedor de NFS-e '%s' inválido: %v", cfg.NFSeProvider, err)
+	}
+
+	// WhatsApp Business. O provedor "fake", apenas para desenvolvimento, expõe uma imitação
+	// local da Cloud API, que entrega as respostas simuladas no próprio webhook da aplicação.
+	whatsAppConfig := messaging.WhatsAppConfig{
+		BaseURL:       cfg.WhatsAppAPIBaseURL,
+		PhoneNumberID: cfg.WhatsAppPhoneNumberID,
+		AccessToken:   cfg.WhatsAppAccessToken,
+		AppSecret:     cfg.WhatsAppAppSecret,
+		UseTemplates:  cfg.WhatsAppUseTemplates,
+	}
+	var whatsAppFake http.Handler
+	switch cfg.WhatsAppProvider {
+	case "fake":
+		log.Println("Aviso: WhatsApp usando o servidor fake em /api/v1/fake/whatsapp; não use em produção")
+		whatsAppFake = messaging.NewWhatsAppFakeServer(cfg.WhatsAppAccessToken, cfg.WhatsAppAppSecret, cfg.PublicBaseURL+"/api/v1/webhooks/whatsapp")
+		whatsAppConfig.BaseURL = cfg.PublicBaseURL + "/api/v1/fake/whatsapp"
+	case "cloud":
+	default:
+		log.Fatalf("Provedor de WhatsApp '%s' inválido", cfg.WhatsAppProvider)
+	}
+	whatsAppChannel := messaging.NewWhatsAppChannel(whatsAppConfig)
+
+	// Notificações push no app. O provedor "fake", apenas para desenvolvimento, expõe uma
+	// imitação local do FCM.
+	fcmConfig := push.FCMConfig{
+		BaseURL:     cfg.FCMAPIBaseURL,
+		ProjectID:   cfg.FCMProjectID,
+		AccessToken: cfg.FCMAccessToken,
+	}
+	var pushFake http.Handler
+	switch cfg.PushProvider {
+	case "fake":
+		log.Println("Aviso: push usando o servidor fake em /api/v1/fake/push; não use em produção")
+		pushFake = push.NewFCMFakeServer(cfg.FCMProjectID, cfg.FCMAccessToken)
+		fcmConfig.BaseURL = cfg.PublicBaseURL + "/api/v1/fake/push"
+	case "fcm":
+	default:
+		log.Fatalf("Provedor de push '%s' inválido", cfg.PushProvider)
+	}
+
+	// Calendários externos (CalDAV). O servidor fake, apenas para desenvolvimento, guarda
+	// os calendários em memória.
+	var calDAVFake http.Handler
+	if cfg.CalDAVFakeServer {
+		log.Println("Aviso: servidor CalDAV fake exposto em /api/v1/fake/caldav; não use em produção")
+		calDAVFake = caldav.NewFakeServer("/api/v1/fake/caldav")
+	}
+
+	// E-mails aos clientes. O provedor "log" grava as mensagens em disco em vez de enviá-las.
+	var mailer mail.Mailer
+	switch cfg.MailProvider {
+	case "log":
+		mailer = mail.NewLogMailer(cfg.MailFrom, cfg.MailLogDir)
+	case "smtp":
+		mailer = mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+			PoolSize: cfg.SMTPPoolSize,
+		})
+	default:
+		log.Fatalf("Provedor de e-mail '%s' inválido", cfg.MailProvider)
+	}
+
+	// Canais de envio dos lembretes. Enquanto não há provedor de SMS configurado, o
+	// canal local apenas registra as mensagens no log (e no arquivo, se informado).
+	messageChannels := messaging.NewRegistry(
+		mail.NewChannel(mailer),
+		messaging.NewLogChannel(string(entity.ReminderChannelSMS), cfg.ReminderLogFile),
+		whatsAppChannel,
+	)
+
+	// Distribui aos assinantes os eventos de domínio gravados na outbox; cada transação
+	// confirmada acorda o distribuidor para que os eventos saiam sem esperar o próximo ciclo.
+	eventDispatcher := usecase.NewEventDispatcher(domainEventGormRepo, eventDeliveryGormRepo)
+	unitOfWork.AfterCommit(eventDispatcher.Wake)
 
 	userUC := usecase.NewUserUseCase(userGormRepo, cfg.JWTSecret, cfg.JWTExpirationHours)
-	appointmentUC := usecase.NewAppointmentUseCase(appointmentGormRepo, userGormRepo)
-	clientUC := usecase.NewClientUseCase(clientGormRepo, userGormRepo) // Adicionado
+	appointmentUC := usecase.NewAppointmentUseCase(appointmentGormRepo, userGormRepo, serviceGormRepo, professionalGormRepo, clientGormRepo, unitOfWork)
+	clientUC := usecase.NewClientUseCase(clientGormRepo, userGormRepo, unitOfWork) // Adicionado
+	giftCardUC := usecase.NewGiftCardUseCase(giftCardGormRepo, giftCardTransactionGormRepo, unitOfWork)
+	paymentUC := usecase.NewPaymentUseCase(paymentGormRepo, appointmentGormRepo, paymentProviders, giftCardUC, unitOfWork)
+	financeUC := usecase.NewFinanceUseCase(financialEntryGormRepo, expenseCategoryGormRepo, recurringExpenseGormRepo, budgetAlertGormRepo, incomeForecastGormRepo, unitOfWork)
+	taxUC := usecase.NewTaxUseCase(taxProfileGormRepo, revenueLimitAlertGormRepo, revenueGormRepo)
+	reportUC := usecase.NewReportUseCase(revenueGormRepo, taxProfileGormRepo, userGormRepo)
+	catalogUC := usecase.NewCatalogUseCase(serviceGormRepo, professionalGormRepo)
+	commissionUC := usecase.NewCommissionUseCase(commissionRuleGormRepo, commissionGormRepo, commissionPayoutGormRepo, professionalGormRepo, serviceGormRepo, financialEntryGormRepo)
+	packageUC := usecase.NewPackageUseCase(servicePackageGormRepo, clientPackageGormRepo, packageCreditUsageGormRepo, serviceGormRepo, clientGormRepo, paymentGormRepo, unitOfWork)
+	membershipUC := usecase.NewMembershipUseCase(membershipPlanGormRepo, membershipSubscriptionGormRepo, membershipCycleGormRepo, membershipUsageGormRepo, serviceGormRepo, clientGormRepo, unitOfWork)
+	couponUC := usecase.NewCouponUseCase(couponGormRepo, couponRedemptionGormRepo, serviceGormRepo)
+	invoiceUC := usecase.NewInvoiceUseCase(invoiceGormRepo, invoiceSettingsGormRepo, appointmentGormRepo, taxProfileGormRepo, nfseProviders)
+	productUC := usecase.NewProductUseCase(productGormRepo, lowStockAlertGormRepo, financialEntryGormRepo)
+	checkoutUC := usecase.NewCheckoutUseCase(checkoutGormRepo, appointmentGormRepo, paymentGormRepo, productGormRepo, giftCardUC, appointmentUC, productUC, commissionUC, packageUC, unitOfWork)
+	saleUC := usecase.NewSaleUseCase(saleGormRepo, cashRegisterGormRepo, paymentGormRepo, productGormRepo, serviceGormRepo, professionalGormRepo, clientGormRepo, userGormRepo, packageUC, productUC, commissionUC)
+	dashboardUC := usecase.NewDashboardUseCase(dashboardGormRepo, revenueGormRepo, workingHoursGormRepo, serviceGormRepo, clientGormRepo, professionalGormRepo)
+	reminderUC := usecase.NewReminderUseCase(reminderGormRepo, reminderRuleGormRepo, appointmentGormRepo, userGormRepo, messageChannels, cfg.ReminderDefaultChannel)
+	whatsAppUC := usecase.NewWhatsAppUseCase(whatsAppMessageGormRepo, reminderGormRepo, appointmentGormRepo, userGormRepo, appointmentUC, whatsAppChannel)
+	emailUC := usecase.NewEmailNotificationUseCase(emailSettingsGormRepo, emailOutboxGormRepo, userGormRepo, mailer)
+	webhookUC := usecase.NewWebhookUseCase(webhookSubscriptionGormRepo, webhookDeliveryGormRepo, webhook.NewSender(0, cfg.WebhookAllowPrivateNetworks))
+	pushUC := usecase.NewPushUseCase(pushDeviceGormRepo, pushPreferenceGormRepo, push.NewFCMSender(fcmConfig))
+	notificationUC := usecase.NewNotificationUseCase(notificationGormRepo)
+	agendaStreamUC := usecase.NewAgendaStreamUseCase(domainEventGormRepo)
+	calendarUC := usecase.NewCalendarUseCase(calendarFeedGormRepo, appointmentGormRepo, userGormRepo, cfg.PublicBaseURL)
+	calendarSyncUC := usecase.NewCalendarSyncUseCase(calendarConnectionGormRepo, calendarBusyBlockGormRepo, calendarEventLinkGormRepo, appointmentGormRepo, userGormRepo, appointmentUC, webhook.NewHTTPClient(0, cfg.CalDAVAllowPrivateNetworks))
+	quoteUC := usecase.NewQuoteUseCase(quoteGormRepo, serviceGormRepo, clientGormRepo, userGormRepo, appointmentUC, unitOfWork, cfg.PublicBaseURL)
+
+	// Apura a comissão do profissional na transação que conclui o atendimento.
+	appointmentUC.AddCompletionPolicy(commissionUC)
+	// Consome o crédito do pacote do cliente; registrado depois da comissão para
+	// que ela seja apurada sobre o preço do serviço antes de ser zerado.
+	appointmentUC.AddCompletionPolicy(packageUC)
+	// Emite a NFS-e automaticamente, se habilitado, depois da conclusão confirmada;
+	// atendimentos cobertos por crédito (preço zerado) não geram nota.
+	appointmentUC.AddCompletionListener(invoiceUC)
+	// Agenda, reagenda ou cancela os lembretes quando o agendamento muda.
+	appointmentUC.AddChangeListener(reminderUC)
+	// Pede ao cliente, pelo WhatsApp, a confirmação dos novos agendamentos.
+	appointmentUC.AddChangeListener(whatsAppUC)
+	// Avisa o cliente por e-mail da criação, remarcação e cancelamento do agendamento.
+	appointmentUC.AddChangeListener(emailUC)
+	// Envia os eventos às URLs cadastradas pelos usuários para suas integrações.
+	eventDispatcher.Subscribe(webhookUC)
+	// Avisa a equipe no app dos novos agendamentos e cancelamentos, conforme as preferências.
+	eventDispatcher.Subscribe(pushUC)
+	// Registra os eventos na caixa de avisos do app.
+	eventDispatcher.Subscribe(notificationUC)
+	// Atualiza a agenda nos apps abertos quando um agendamento muda.
+	eventDispatcher.Subscribe(agendaStreamUC, usecase.AgendaStreamEventTypes...)
+	// Publica os agendamentos no calendário externo conectado pelo usuário.
+	eventDispatcher.Subscribe(calendarSyncUC, usecase.CalendarSyncEventTypes...)
+	// Recusa agendamentos em horários ocupados no calendário externo.
+	appointmentUC.AddAvailabilityCheck(calendarSyncUC)
+	// Aplica os benefícios da assinatura do cliente ao preço dos novos agendamentos.
+	appointmentUC.AddPricingPolicy(membershipUC)
+	// Aplica os cupons de desconto informados na criação do agendamento.
+	appointmentUC.SetCouponRedeemer(couponUC)
 
 	userHandler := httpDelivery.NewUserHandler(userUC)
 	appointmentHandler := httpDelivery.NewAppointmentHandler(appointmentUC)
 	clientHandler := httpDelivery.NewClientHandler(clientUC) // Adicionado
+	paymentHandler := httpDelivery.NewPaymentHandler(paymentUC)
+	financeHandler := httpDelivery.NewFinanceHandler(financeUC)
+	taxHandler := httpDelivery.NewTaxHandler(taxUC)
+	reportHandler := httpDelivery.NewReportHandler(reportUC)
+	catalogHandler := httpDelivery.NewCatalogHandler(catalogUC)
+	commissionHandler := httpDelivery.NewCommissionHandler(commissionUC)
+	packageHandler := httpDelivery.NewPackageHandler(packageUC)
+	membershipHandler := httpDelivery.NewMembershipHandler(membershipUC)
+	couponHandler := httpDelivery.NewCouponHandler(couponUC)
+	giftCardHandler := httpDelivery.NewGiftCardHandler(giftCardUC)
+	quoteHandler := httpDelivery.NewQuoteHandler(quoteUC)
+	invoiceHandler := httpDelivery.NewInvoiceHandler(invoiceUC)
+	checkoutHandler := httpDelivery.NewCheckoutHandler(checkoutUC)
+	productHandler := httpDelivery.NewProductHandler(productUC)
+	saleHandler := httpDelivery.NewSaleHandler(saleUC)
+	dashboardHandler := httpDelivery.NewDashboardHandler(dashboardUC)
+	reminderHandler := httpDelivery.NewReminderHandler(reminderUC)
+	whatsAppHandler := httpDelivery.NewWhatsAppHandler(whatsAppUC, cfg.WhatsAppVerifyToken, whatsAppFake)
+	emailHandler := httpDelivery.NewEmailHandler(emailUC)
+	eventHandler := httpDelivery.NewEventHandler(eventDispatcher)
+	webhookHandler := httpDelivery.NewWebhookHandler(webhookUC)
+	pushHandler := httpDelivery.NewPushHandler(pushUC, pushFake)
+	notificationHandler := httpDelivery.NewNotificationHandler(notificationUC)
+	agendaStreamHandler := httpDelivery.NewAgendaStreamHandler(agendaStreamUC)
+	calendarHandler := httpDelivery.NewCalendarHandler(calendarUC)
+	calendarSyncHandler := httpDelivery.NewCalendarSyncHandler(calendarSyncUC, calDAVFake)
+
+	// Gera periodicamente os lançamentos das despesas recorrentes vencidas, renova as
+	// assinaturas dos clientes, vence os orçamentos sem resposta e verifica os alertas de teto.
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for ; ; <-ticker.C {
+			created, err := financeUC.GenerateDueRecurringExpenses(time.Now())
+			if err != nil {
+				log.Printf("Erro ao gerar despesas recorrentes: %v", err)
+			} else if created > 0 {
+				log.Printf("%d lançamento(s) de despesas recorrentes gerado(s)", created)
+			}
+
+			renewed, err := membershipUC.RenewDueSubscriptions(time.Now())
+			if err != nil {
+				log.Printf("Erro ao renovar assinaturas: %v", err)
+			} else if renewed > 0 {
+				log.Printf("%d ciclo(s) de assinatura renovado(s)", renewed)
+			}
+
+			expired, err := quoteUC.ExpireOverdueQuotes(time.Now())
+			if err != nil {
+				log.Printf("Erro ao vencer orçamentos: %v", err)
+			} else if expired > 0 {
+				log.Printf("%d orçamento(s) vencido(s)", expired)
+			}
+
+			// Verifica o teto de faturamento depois de lançar as despesas do período.
+			alerts, err := taxUC.CheckRevenueLimits(time.Now())
+			if err != nil {
+				log.Printf("Erro ao verificar teto de faturamento: %v", err)
+			} else if alerts > 0 {
+				log.Printf("%d alerta(s) de teto de faturamento gerado(s)", alerts)
+			}
+		}
+	}()
+
+	// Envia os lembretes de agendamento cujo horário chegou.
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for ; ; <-ticker.C {
+			sent, failed, err := reminderUC.DispatchDueReminders(time.Now())
+			if err != nil {
+				log.Printf("Erro ao enviar lembretes: %v", err)
+			} else if sent > 0 || failed > 0 {
+				log.Printf("%d lembrete(s) enviado(s), %d com falha definitiva", sent, failed)
+			}
+		}
+	}()
+
+	// Reenvia os e-mails da fila que falharam e cujo horário de nova tentativa chegou.
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for ; ; <-ticker.C {
+			sent, failed, err := emailUC.DispatchOutbox(time.Now())
+			if err != nil {
+				log.Printf("Erro ao enviar e-mails da fila: %v", err)
+			} else if sent > 0 || failed > 0 {
+				log.Printf("%d e-mail(s) enviado(s), %d com falha definitiva", sent, failed)
+			}
+		}
+	}()
+
+	// Faz as novas tentativas dos webhooks que falharam.
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for ; ; <-ticker.C {
+			sent, failed, err := webhookUC.DispatchDue(time.Now())
+			if err != nil {
+				log.Printf("Erro ao enviar webhooks: %v", err)
+			} else if sent > 0 || failed > 0 {
+				log.Printf("%d webhook(s) enviado(s), %d com falha definitiva", sent, failed)
+			}
+		}
+	}()
+
+	// Sincroniza os calendários externos: importa os compromissos e traz as remarcações.
+	go func() {
+		ticker := time.NewTicker(15 * time.Minute)
+		defer ticker.Stop()
+		for ; ; <-ticker.C {
+			synced, failed, err := calendarSyncUC.SyncAll(time.Now())
+			if err != nil {
+				log.Printf("Erro ao sincronizar calendários: %v", err)
+			} else if failed > 0 {
+				log.Printf("%d calendário(s) sincronizado(s), %d com falha", synced, failed)
+			}
+		}
+	}()
+
+	// Entrega os eventos de domínio aos assinantes e tenta novamente as entregas que falharam.
+	go eventDispatcher.Run(30 * time.Second)
 
 	// gin.SetMode(gin.ReleaseMode) // Descomente para produção
 	router := gin.Default() // gin.Default() já inclui logger e recovery
@@ -52,7 +440,7 @@ func main() {
 		// AllowAllOrigins: true,
 
 		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization", "Last-Event-ID"}, // Last-Event-ID: retomada do stream da agenda
 		// ExposeHeaders permite que o cliente acesse certos cabeçalhos da resposta
 		// ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true, // Se você precisar enviar cookies ou cabeçalhos de autenticação
@@ -61,7 +449,7 @@ func main() {
 	router.Use(cors.New(corsConfig))
 	// --- FIM DA CONFIGURAÇÃO DO CORS ---
 
-	httpDelivery.SetupRoutes(router, cfg, userHandler, appointmentHandler, clientHandler)
+	httpDelivery.SetupRoutes(router, cfg, userHandler, appointmentHandler, clientHandler, paymentHandler, financeHandler, taxHandler, reportHandler, catalogHandler, commissionHandler, packageHandler, membershipHandler, couponHandler, giftCardHandler, quoteHandler, invoiceHandler, checkoutHandler, productHandler, saleHandler, dashboardHandler, reminderHandler, whatsAppHandler, emailHandler, eventHandler, webhookHandler, pushHandler, notificationHandler, agendaStreamHandler, calendarHandler, calendarSyncHandler)
 
 	log.Printf("Servidor Bizly iniciando na porta %s", cfg.ServerPort)
 	if err := router.Run(":" + cfg.ServerPort); err != nil {
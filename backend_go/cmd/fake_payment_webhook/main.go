@@ -0,0 +1,57 @@
+// Command fake_payment_webhook simula o provedor de pagamento "fake", enviando para a API
+// local um webhook assinado com o mesmo segredo configurado em PAYMENT_WEBHOOK_SECRET.
+//
+// Uso:
+//
+//	go run ./cmd/fake_payment_webhook -charge fake_ch_... -amount 80
+//	go run ./cmd/fake_payment_webhook -charge fake_ch_... -type payment.failed
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/config"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/payment"
+)
+
+func main() {
+	cfg := config.LoadConfig()
+
+	apiURL := flag.String("api", "http://localhost:"+cfg.ServerPort, "URL base da API Bizly")
+	chargeID := flag.String("charge", "", "ID da cobrança retornado em providerChargeId (obrigatório)")
+	amount := flag.Float64("amount", 0, "Valor pago (0 para não validar o valor)")
+	eventType := flag.String("type", payment.EventTypePaymentPaid, "Tipo do evento: payment.paid, payment.failed ou payment.refunded")
+	eventID := flag.String("event", "", "ID do evento; repita o mesmo valor para testar a idempotência")
+	flag.Parse()
+
+	if *chargeID == "" {
+		log.Fatal("O parâmetro -charge é obrigatório")
+	}
+
+	provider := payment.NewFakeProvider(cfg.PaymentWebhookSecret)
+	body, signature, err := provider.BuildWebhook(*eventID, *eventType, *chargeID, *amount)
+	if err != nil {
+		log.Fatalf("Falha ao montar webhook: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, *apiURL+"/api/v1/webhooks/payments/"+payment.FakeProviderName, bytes.NewReader(body))
+	if err != nil {
+		log.Fatalf("Falha ao criar requisição: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(payment.FakeSignatureHeader, signature)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatalf("Falha ao enviar webhook: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	log.Printf("Webhook enviado: %s", body)
+	log.Printf("Resposta da API (%d): %s", resp.StatusCode, respBody)
+}
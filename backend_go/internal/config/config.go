@@ -18,6 +18,34 @@ type Config struct {
 	ServerPort        string // Porta em que o servidor HTTP vai rodar
 	JWTSecret         string // Segredo usado para assinar e verificar tokens JWT
 	JWTExpirationHours int    // Tempo de expiração para tokens JWT em horas
+	PaymentProvider       string // Provedor de pagamento padrão para novas cobranças (ex: "fake")
+	PaymentWebhookSecret  string // Segredo HMAC usado para validar os webhooks de pagamento
+	PublicBaseURL         string // Endereço público da API, usado nos links enviados aos clientes
+	NFSeProvider          string // Provedor de NFS-e usado na emissão de notas (ex: "mock")
+	ReminderDefaultChannel string // Canal das regras de lembrete padrão (EMAIL, SMS ou WHATSAPP)
+	ReminderLogFile        string // Arquivo onde o canal local registra as mensagens enviadas; vazio usa só o log
+	WhatsAppProvider       string // "cloud" (API do WhatsApp Business) ou "fake" (servidor local em /api/v1/fake/whatsapp, apenas em desenvolvimento)
+	WhatsAppAPIBaseURL     string // Endereço da Cloud API (ex: https://graph.facebook.com/v19.0)
+	WhatsAppPhoneNumberID  string // ID do número remetente
+	WhatsAppAccessToken    string // Token de acesso da Cloud API
+	WhatsAppAppSecret      string // Segredo do app, usado para validar a assinatura dos webhooks
+	WhatsAppVerifyToken    string // Token informado na assinatura do webhook
+	WhatsAppUseTemplates   bool   // Envia pedidos de confirmação e lembretes como templates pré-aprovados
+	MailProvider           string // "log" (registra no log e em MAIL_LOG_DIR) ou "smtp"
+	MailFrom               string // Endereço remetente dos e-mails aos clientes
+	MailLogDir             string // Diretório onde o provedor "log" grava os e-mails (.eml); vazio usa só o log
+	SMTPHost               string
+	SMTPPort               int
+	SMTPUsername           string
+	SMTPPassword           string
+	SMTPPoolSize           int // Conexões SMTP mantidas abertas para reaproveitamento
+	WebhookAllowPrivateNetworks bool // Permite webhooks para endereços internos (ex: localhost), apenas em desenvolvimento
+	PushProvider           string // "fcm" (Firebase Cloud Messaging) ou "fake" (servidor local em /api/v1/fake/push, apenas em desenvolvimento)
+	FCMAPIBaseURL          string // Endereço da API HTTP v1 do FCM
+	FCMProjectID           string // ID do projeto do Firebase
+	FCMAccessToken         string // Token OAuth 2.0 da conta de serviço (escopo firebase.messaging)
+	CalDAVFakeServer       bool   // Expõe um servidor CalDAV local em /api/v1/fake/caldav, para testar a sincronização de calendário (apenas em desenvolvimento)
+	CalDAVAllowPrivateNetworks bool // Permite calendários em endereços internos (ex: o servidor fake), apenas em desenvolvimento
 	// Adicione outras configurações que sua aplicação possa precisar aqui
 	// Ex: LogLevel string, ApiKeyExterna string, etc.
 }
@@ -80,6 +108,34 @@ func LoadConfig(customEnvPath ...string) *Config {
 		ServerPort: getEnv("SERVER_PORT", "8080"),
 		JWTSecret:  getEnv("JWT_SECRET", "seu-jwt-segredo-muito-secreto-e-longo-e-aleatorio"),
 		JWTExpirationHours: getEnvAsInt("JWT_EXPIRATION_HOURS", 72), // Padrão de 72 horas (3 dias)
+		PaymentProvider:      getEnv("PAYMENT_PROVIDER", "fake"),
+		PaymentWebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", "segredo-de-webhook-de-desenvolvimento"),
+		PublicBaseURL:        getEnv("PUBLIC_BASE_URL", "http://localhost:8080"),
+		NFSeProvider:         getEnv("NFSE_PROVIDER", "mock"),
+		ReminderDefaultChannel: getEnv("REMINDER_DEFAULT_CHANNEL", "EMAIL"),
+		ReminderLogFile:        getEnv("REMINDER_LOG_FILE", ""),
+		WhatsAppProvider:       getEnv("WHATSAPP_PROVIDER", "cloud"),
+		WhatsAppAPIBaseURL:     getEnv("WHATSAPP_API_BASE_URL", "https://graph.facebook.com/v19.0"),
+		WhatsAppPhoneNumberID:  getEnv("WHATSAPP_PHONE_NUMBER_ID", "fake-phone-number-id"),
+		WhatsAppAccessToken:    getEnv("WHATSAPP_ACCESS_TOKEN", "token-de-desenvolvimento"),
+		WhatsAppAppSecret:      getEnv("WHATSAPP_APP_SECRET", "segredo-de-webhook-de-desenvolvimento"),
+		WhatsAppVerifyToken:    getEnv("WHATSAPP_VERIFY_TOKEN", "token-de-verificacao-de-desenvolvimento"),
+		WhatsAppUseTemplates:   getEnvAsBool("WHATSAPP_USE_TEMPLATES", true),
+		MailProvider:           getEnv("MAIL_PROVIDER", "log"),
+		MailFrom:               getEnv("MAIL_FROM", "Bizly <nao-responda@bizly.local>"),
+		MailLogDir:             getEnv("MAIL_LOG_DIR", ""),
+		SMTPHost:               getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:               getEnvAsInt("SMTP_PORT", 587),
+		SMTPUsername:           getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:           getEnv("SMTP_PASSWORD", ""),
+		SMTPPoolSize:           getEnvAsInt("SMTP_POOL_SIZE", 4),
+		WebhookAllowPrivateNetworks: getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		PushProvider:           getEnv("PUSH_PROVIDER", "fcm"),
+		FCMAPIBaseURL:          getEnv("FCM_API_BASE_URL", "https://fcm.googleapis.com"),
+		FCMProjectID:           getEnv("FCM_PROJECT_ID", "fake-project"),
+		FCMAccessToken:         getEnv("FCM_ACCESS_TOKEN", "token-de-desenvolvimento"),
+		CalDAVFakeServer:       getEnvAsBool("CALDAV_FAKE_SERVER", false),
+		CalDAVAllowPrivateNetworks: getEnvAsBool("CALDAV_ALLOW_PRIVATE_NETWORKS", false),
 		// Adicione aqui a leitura de outras variáveis de ambiente
 	}
 
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// agendaStreamHeartbeat é o intervalo dos comentários enviados para manter a conexão
+	// aberta em proxies que encerram conexões ociosas.
+	agendaStreamHeartbeat = 25 * time.Second
+	// agendaStreamRetryMs é o intervalo de reconexão sugerido ao cliente.
+	agendaStreamRetryMs = 3000
+)
+
+// --- DTOs para a Agenda em Tempo Real ---
+
+// AgendaStreamEventResponse define o JSON enviado no campo data de cada evento do stream.
+type AgendaStreamEventResponse struct {
+	ID         uuid.UUID       `json:"id"` // Igual ao campo id do evento SSE, usado em Last-Event-ID
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// --- AgendaStreamHandler ---
+type AgendaStreamHandler struct {
+	agendaStreamUseCase *usecase.AgendaStreamUseCase
+}
+
+func NewAgendaStreamHandler(uc *usecase.AgendaStreamUseCase) *AgendaStreamHandler {
+	return &AgendaStreamHandler{agendaStreamUseCase: uc}
+}
+
+// StreamAgenda godoc
+// @Summary      Recebe as alterações da agenda em tempo real
+// @Description  Abre um stream Server-Sent Events com os eventos de agendamento (appointment.created, appointment.cancelled etc.) do negócio do usuário autenticado. Cada evento SSE tem o tipo em "event", o ID em "id" e o AgendaStreamEventResponse em "data". Um comentário é enviado a cada 25s para manter a conexão. Ao reconectar, informe o último ID recebido no cabeçalho Last-Event-ID (ou no parâmetro lastEventId) para receber os eventos perdidos; se não for possível, o evento "reset" indica que a agenda deve ser recarregada.
+// @Tags         appointments
+// @Security     BearerAuth
+// @Produce      text/event-stream
+// @Param        Last-Event-ID header string false "ID do último evento recebido"
+// @Param        lastEventId   query  string false "Alternativa ao cabeçalho Last-Event-ID"
+// @Success      200  {object} AgendaStreamEventResponse
+// @Failure      400  {object} map[string]string "Last-Event-ID inválido"
+// @Failure      429  {object} map[string]string "Conexões demais abertas"
+// @Router       /agenda/stream [get]
+func (h *AgendaStreamHandler) StreamAgenda(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var lastEventID uuid.UUID
+	if s := c.GetHeader("Last-Event-ID"); s != "" || c.Query("lastEventId") != "" {
+		if s == "" {
+			s = c.Query("lastEventId")
+		}
+		parsed, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Last-Event-ID inválido"})
+			return
+		}
+		lastEventID = parsed
+	}
+
+	// A conexão é aberta antes de buscar os eventos perdidos, para que nenhum evento
+	// ocorrido entre a busca e a inscrição se perca; os repetidos são descartados.
+	stream, err := h.agendaStreamUseCase.Open(requestingUserID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrTooManyAgendaStreams) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao abrir conexão: " + err.Error()})
+		}
+		return
+	}
+	defer stream.Close()
+
+	var backlog []*entity.DomainEvent
+	reset := false
+	if lastEventID != uuid.Nil {
+		backlog, reset, err = h.agendaStreamUseCase.Backlog(requestingUserID, lastEventID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao retomar conexão: " + err.Error()})
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Desativa o buffer de proxies como o nginx
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", agendaStreamRetryMs)
+	if reset {
+		fmt.Fprint(c.Writer, "event: reset\ndata: {}\n\n")
+	}
+	sent := make(map[uuid.UUID]bool, len(backlog))
+	for _, event := range backlog {
+		writeAgendaStreamEvent(c, event)
+		sent[event.ID] = true
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(agendaStreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-stream.Events():
+			if !ok {
+				return // Encerrada pelo hub; o cliente reconecta com Last-Event-ID
+			}
+			if sent[event.ID] {
+				delete(sent, event.ID)
+				continue
+			}
+			writeAgendaStreamEvent(c, event)
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeAgendaStreamEvent escreve o evento no formato Server-Sent Events.
+func writeAgendaStreamEvent(c *gin.Context, event *entity.DomainEvent) {
+	data, err := json.Marshal(AgendaStreamEventResponse{
+		ID:         event.ID,
+		Type:       string(event.Type),
+		OccurredAt: event.OccurredAt,
+		Data:       json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
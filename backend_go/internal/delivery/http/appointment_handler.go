@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"time"
@@ -19,20 +20,25 @@ import (
 type CreateAppointmentRequest struct {
 	// UserID não é necessário no request, pois será pego do token do usuário autenticado.
 	ClientID          *string   `json:"clientId"` // string UUID ou nulo
-	ClientName        string    `json:"clientName" binding:"required_without=ClientID,omitempty,min=2"`
+	CustomerID        *uuid.UUID `json:"customerId"` // Opcional: cliente do cadastro de clientes
+	ClientName        string    `json:"clientName" binding:"required_without_all=ClientID CustomerID,omitempty,min=2"`
 	ClientEmail       string    `json:"clientEmail" binding:"omitempty,email"`
 	ClientPhone       string    `json:"clientPhone"`
-	ServiceDescription string    `json:"serviceDescription" binding:"required"`
+	ServiceDescription string    `json:"serviceDescription" binding:"required_without=ServiceID"`
+	ServiceID         *uuid.UUID `json:"serviceId"`      // Opcional: serviço do catálogo
+	ProfessionalID    *uuid.UUID `json:"professionalId"` // Opcional: profissional que realiza o atendimento
 	StartTime         time.Time `json:"startTime" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"` // RFC3339
 	EndTime           time.Time `json:"endTime" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`   // RFC3339
 	Notes             string    `json:"notes"`
 	Price             float64   `json:"price"`
+	CouponCode        string    `json:"couponCode"` // Opcional: cupom de desconto
 }
 
 // UpdateAppointmentRequest define o JSON para atualizar um agendamento.
 // Todos os campos são opcionais (ponteiros).
 type UpdateAppointmentRequest struct {
 	ClientID          *string   `json:"clientId"`
+	CustomerID        *uuid.UUID `json:"customerId"`
 	ClientName        *string   `json:"clientName"`
 	ClientEmail       *string   `json:"clientEmail"`
 	ClientPhone       *string   `json:"clientPhone"`
@@ -42,6 +48,9 @@ type UpdateAppointmentRequest struct {
 	Status            *string   `json:"status"` // String para o status (PENDING, CONFIRMED, etc.)
 	Notes             *string   `json:"notes"`
 	Price             *float64  `json:"price"`
+	Invoiced          *bool     `json:"invoiced"`
+	ServiceID         *uuid.UUID `json:"serviceId"`
+	ProfessionalID    *uuid.UUID `json:"professionalId"`
 }
 
 // AppointmentResponse define o JSON retornado para um agendamento.
@@ -49,15 +58,22 @@ type AppointmentResponse struct {
 	ID                uuid.UUID  `json:"id"`
 	UserID            uuid.UUID  `json:"userId"`
 	ClientID          *uuid.UUID `json:"clientId,omitempty"`
+	CustomerID        *uuid.UUID `json:"customerId,omitempty"`
 	ClientName        string     `json:"clientName"`
 	ClientEmail       string     `json:"clientEmail"`
 	ClientPhone       string     `json:"clientPhone"`
 	ServiceDescription string     `json:"serviceDescription"`
+	ServiceID         *uuid.UUID `json:"serviceId,omitempty"`
+	ProfessionalID    *uuid.UUID `json:"professionalId,omitempty"`
 	StartTime         time.Time  `json:"startTime"`
 	EndTime           time.Time  `json:"endTime"`
 	Status            string     `json:"status"` // Status como string
 	Notes             string     `json:"notes"`
 	Price             float64    `json:"price"`
+	OriginalPrice     float64    `json:"originalPrice"`
+	DiscountAmount    float64    `json:"discountAmount"`
+	CouponID          *uuid.UUID `json:"couponId,omitempty"`
+	Invoiced          bool       `json:"invoiced"`
 	CreatedAt         time.Time  `json:"createdAt"`
 	UpdatedAt         time.Time  `json:"updatedAt"`
 	// User *UserResponse `json:"user,omitempty"` // Opcional: incluir dados do profissional
@@ -79,15 +95,22 @@ func mapAppointmentEntityToResponse(appEntity *entity.Appointment) AppointmentRe
 		ID:                appEntity.ID,
 		UserID:            appEntity.UserID,
 		ClientID:          appEntity.ClientID,
+		CustomerID:        appEntity.CustomerID,
 		ClientName:        appEntity.ClientName,
 		ClientEmail:       appEntity.ClientEmail,
 		ClientPhone:       appEntity.ClientPhone,
 		ServiceDescription: appEntity.ServiceDescription,
+		ServiceID:         appEntity.ServiceID,
+		ProfessionalID:    appEntity.ProfessionalID,
 		StartTime:         appEntity.StartTime,
 		EndTime:           appEntity.EndTime,
 		Status:            string(appEntity.Status),
 		Notes:             appEntity.Notes,
 		Price:             appEntity.Price,
+		OriginalPrice:     appEntity.OriginalPrice,
+		DiscountAmount:    appEntity.DiscountAmount,
+		CouponID:          appEntity.CouponID,
+		Invoiced:          appEntity.Invoiced,
 		CreatedAt:         appEntity.CreatedAt,
 		UpdatedAt:         appEntity.UpdatedAt,
 	}
@@ -102,8 +125,9 @@ func mapAppointmentEntityToResponse(appEntity *entity.Appointment) AppointmentRe
 // @Produce      json
 // @Param        appointment body CreateAppointmentRequest true "Dados do Agendamento"
 // @Success      201  {object} AppointmentResponse "Agendamento criado"
-// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      400  {object} map[string]string "Dados inválidos ou cupom inválido"
 // @Failure      401  {object} map[string]string "Não autorizado"
+// @Failure      409  {object} map[string]string "Horário indisponível"
 // @Failure      500  {object} map[string]string "Erro interno"
 // @Router       /appointments [post]
 func (h *AppointmentHandler) CreateAppointment(c *gin.Context) {
@@ -135,19 +159,31 @@ func (h *AppointmentHandler) CreateAppointment(c *gin.Context) {
 	inputDTO := usecase.CreateAppointmentInputDTO{
 		UserID:            requestingUserID,
 		ClientID:          clientIDPtr,
+		CustomerID:        req.CustomerID,
 		ClientName:        req.ClientName,
 		ClientEmail:       req.ClientEmail,
 		ClientPhone:       req.ClientPhone,
 		ServiceDescription: req.ServiceDescription,
+		ServiceID:         req.ServiceID,
+		ProfessionalID:    req.ProfessionalID,
 		StartTime:         req.StartTime,
 		EndTime:           req.EndTime,
 		Notes:             req.Notes,
 		Price:             req.Price,
+		CouponCode:        req.CouponCode,
 	}
 
 	appointmentEntity, err := h.appointmentUseCase.CreateAppointment(inputDTO)
 	if err != nil {
-		// Tratar erros específicos do caso de uso (ex: conflito de horário, etc.)
+		if errors.Is(err, usecase.ErrInvalidCoupon) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrScheduleConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		// Tratar erros específicos do caso de uso
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao criar agendamento: " + err.Error()})
 		return
 	}
@@ -259,6 +295,7 @@ func (h *AppointmentHandler) ListUserAppointments(c *gin.Context) {
 // @Failure      400  {object} map[string]string "ID ou dados inválidos"
 // @Failure      401  {object} map[string]string "Não autorizado"
 // @Failure      404  {object} map[string]string "Agendamento não encontrado"
+// @Failure      409  {object} map[string]string "Horário indisponível ou mudança de status inválida"
 // @Failure      500  {object} map[string]string "Erro interno"
 // @Router       /appointments/{id} [put]
 func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
@@ -296,6 +333,7 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
             updateDTO.ClientID = &parsedClientID
         }
 	}
+	updateDTO.CustomerID = req.CustomerID
 	updateDTO.ClientName = req.ClientName
 	updateDTO.ClientEmail = req.ClientEmail
     updateDTO.ClientPhone = req.ClientPhone
@@ -313,10 +351,17 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
 	}
 	updateDTO.Notes = req.Notes
 	updateDTO.Price = req.Price
+	updateDTO.Invoiced = req.Invoiced
+	updateDTO.ServiceID = req.ServiceID
+	updateDTO.ProfessionalID = req.ProfessionalID
 
 	updatedAppointmentEntity, err := h.appointmentUseCase.UpdateAppointment(appointmentID, requestingUserID, updateDTO)
 	if err != nil {
 		// Tratar erros do caso de uso
+		if errors.Is(err, usecase.ErrScheduleConflict) || errors.Is(err, usecase.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar agendamento: " + err.Error()})
 		return
 	}
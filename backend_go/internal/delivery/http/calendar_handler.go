@@ -0,0 +1,148 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/ical"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para o Calendário ---
+
+// CalendarFeedResponse define o JSON retornado para o feed de calendário do usuário.
+type CalendarFeedResponse struct {
+	URL       string     `json:"url"` // Secreta: quem a tiver vê a agenda
+	RotatedAt *time.Time `json:"rotatedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// --- CalendarHandler ---
+type CalendarHandler struct {
+	calendarUseCase *usecase.CalendarUseCase
+}
+
+func NewCalendarHandler(uc *usecase.CalendarUseCase) *CalendarHandler {
+	return &CalendarHandler{calendarUseCase: uc}
+}
+
+func (h *CalendarHandler) mapCalendarFeedToResponse(feed *entity.CalendarFeed) CalendarFeedResponse {
+	return CalendarFeedResponse{
+		URL:       h.calendarUseCase.FeedURL(feed),
+		RotatedAt: feed.RotatedAt,
+		CreatedAt: feed.CreatedAt,
+	}
+}
+
+// GetCalendarFeed godoc
+// @Summary      Obtém a URL do feed de calendário
+// @Description  Retorna a URL secreta do feed iCalendar (.ics) da agenda do usuário autenticado, para assinar no calendário do celular. O feed é criado no primeiro acesso.
+// @Tags         calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} CalendarFeedResponse
+// @Router       /calendar/feed [get]
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	feed, err := h.calendarUseCase.GetFeed(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar feed de calendário: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, h.mapCalendarFeedToResponse(feed))
+}
+
+// RotateCalendarFeed godoc
+// @Summary      Gera uma nova URL para o feed de calendário
+// @Description  Troca o token do feed. A URL anterior deixa de funcionar imediatamente; use quando ela tiver sido compartilhada indevidamente.
+// @Tags         calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} CalendarFeedResponse
+// @Router       /calendar/feed/rotate [post]
+func (h *CalendarHandler) RotateCalendarFeed(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	feed, err := h.calendarUseCase.RotateToken(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao gerar nova URL do feed: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, h.mapCalendarFeedToResponse(feed))
+}
+
+// GetPublicCalendarFeed godoc
+// @Summary      Baixa o feed de calendário pela URL secreta
+// @Description  Retorna a agenda no formato iCalendar (RFC 5545), com os agendamentos dos últimos 90 dias em diante. Os cancelados são mantidos com STATUS:CANCELLED.
+// @Tags         public
+// @Produce      text/calendar
+// @Param        token path string true "Token do feed, com ou sem a extensão .ics"
+// @Success      200  {file}   file
+// @Failure      404  {object} map[string]string "Feed não encontrado"
+// @Router       /public/calendar/{token} [get]
+func (h *CalendarHandler) GetPublicCalendarFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	data, err := h.calendarUseCase.RenderFeed(token)
+	if err != nil {
+		if err.Error() == "feed não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar feed de calendário: " + err.Error()})
+		}
+		return
+	}
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("Content-Disposition", `inline; filename="agenda.ics"`)
+	c.Data(http.StatusOK, ical.ContentType, data)
+}
+
+// DownloadAppointmentICS godoc
+// @Summary      Baixa o agendamento como arquivo .ics
+// @Tags         appointments
+// @Security     BearerAuth
+// @Produce      text/calendar
+// @Param        id   path      string  true  "ID do Agendamento"
+// @Success      200  {file}   file
+// @Failure      400  {object} map[string]string "ID inválido"
+// @Failure      404  {object} map[string]string "Agendamento não encontrado"
+// @Router       /appointments/{id}/ics [get]
+func (h *CalendarHandler) DownloadAppointmentICS(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do agendamento inválido"})
+		return
+	}
+
+	data, err := h.calendarUseCase.AppointmentICS(appointmentID, requestingUserID)
+	if err != nil {
+		if err.Error() == "agendamento não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar arquivo do agendamento: " + err.Error()})
+		}
+		return
+	}
+	filename := fmt.Sprintf("agendamento-%s.ics", appointmentID)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, ical.ContentType, data)
+}
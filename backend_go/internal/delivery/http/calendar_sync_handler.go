@@ -0,0 +1,263 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para a Sincronização de Calendário ---
+
+// ConnectCalendarRequest define o JSON esperado para conectar um calendário externo.
+type ConnectCalendarRequest struct {
+	CalendarURL string `json:"calendarUrl" binding:"required"` // Endereço da coleção no servidor CalDAV
+	Username    string `json:"username" binding:"required"`
+	Password    string `json:"password"` // Senha de app; obrigatória na primeira conexão
+}
+
+// CalendarConnectionResponse define o JSON retornado para a conexão de calendário.
+// A senha nunca é retornada.
+type CalendarConnectionResponse struct {
+	CalendarURL string     `json:"calendarUrl"`
+	Username    string     `json:"username"`
+	Enabled     bool       `json:"enabled"`
+	LastSyncAt  *time.Time `json:"lastSyncAt,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// CalendarSyncResponse define o JSON retornado com o resumo de uma sincronização.
+type CalendarSyncResponse struct {
+	BusyBlocks int    `json:"busyBlocks"`
+	Published  int    `json:"published"`
+	Imported   int    `json:"imported"`
+	Conflicts  int    `json:"conflicts"`
+	Removed    int    `json:"removed"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ConnectCalendarResponse define o JSON retornado ao conectar um calendário externo.
+type ConnectCalendarResponse struct {
+	Connection CalendarConnectionResponse `json:"connection"`
+	Sync       CalendarSyncResponse       `json:"sync"`
+}
+
+// CalendarBusyBlockResponse define o JSON retornado para um período ocupado importado.
+type CalendarBusyBlockResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Summary   string    `json:"summary,omitempty"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// --- CalendarSyncHandler ---
+type CalendarSyncHandler struct {
+	calendarSyncUseCase *usecase.CalendarSyncUseCase
+	fake                http.Handler // Servidor CalDAV fake; nil quando desabilitado
+}
+
+// NewCalendarSyncHandler cria o handler. fake, se informado, é exposto em /fake/caldav para testes offline.
+func NewCalendarSyncHandler(uc *usecase.CalendarSyncUseCase, fake http.Handler) *CalendarSyncHandler {
+	return &CalendarSyncHandler{calendarSyncUseCase: uc, fake: fake}
+}
+
+func mapCalendarConnectionToResponse(connection *entity.CalendarConnection) CalendarConnectionResponse {
+	return CalendarConnectionResponse{
+		CalendarURL: connection.CalendarURL,
+		Username:    connection.Username,
+		Enabled:     connection.Enabled,
+		LastSyncAt:  connection.LastSyncAt,
+		LastError:   connection.LastError,
+		CreatedAt:   connection.CreatedAt,
+	}
+}
+
+func mapCalendarSyncResultToResponse(result *usecase.CalendarSyncResult, err error) CalendarSyncResponse {
+	response := CalendarSyncResponse{}
+	if result != nil {
+		response.BusyBlocks = result.BusyBlocks
+		response.Published = result.Published
+		response.Imported = result.Imported
+		response.Conflicts = result.Conflicts
+		response.Removed = result.Removed
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	return response
+}
+
+// calendarSyncErrorStatus mapeia os erros do CalendarSyncUseCase para o status HTTP.
+func calendarSyncErrorStatus(err error) int {
+	switch {
+	case err.Error() == "conexão de calendário não encontrada":
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidCalendarConnection):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// GetCalendarConnection godoc
+// @Summary      Obtém a conexão com o calendário externo
+// @Description  Retorna o calendário CalDAV conectado e o resultado da última sincronização.
+// @Tags         calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} CalendarConnectionResponse
+// @Failure      404  {object} map[string]string "Nenhum calendário conectado"
+// @Router       /calendar/connection [get]
+func (h *CalendarSyncHandler) GetCalendarConnection(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	connection, err := h.calendarSyncUseCase.GetConnection(requestingUserID)
+	if err != nil {
+		respondError(c, calendarSyncErrorStatus, "Erro ao buscar conexão de calendário: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapCalendarConnectionToResponse(connection))
+}
+
+// ConnectCalendar godoc
+// @Summary      Conecta um calendário externo (CalDAV)
+// @Description  Conecta o calendário informado (ex: iCloud, Nextcloud, Fastmail) e faz a primeira sincronização. Os agendamentos passam a ser publicados nele e os demais compromissos dele bloqueiam a agenda. Informar outro endereço substitui o calendário conectado; sem senha, a atual é mantida.
+// @Tags         calendar
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        connection body ConnectCalendarRequest true "Dados de Acesso ao Calendário"
+// @Success      200  {object} ConnectCalendarResponse
+// @Failure      400  {object} map[string]string "Dados inválidos ou acesso recusado pelo servidor"
+// @Router       /calendar/connection [put]
+func (h *CalendarSyncHandler) ConnectCalendar(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req ConnectCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	connection, result, err := h.calendarSyncUseCase.Connect(requestingUserID, usecase.ConnectCalendarInputDTO{
+		CalendarURL: req.CalendarURL,
+		Username:    req.Username,
+		Password:    req.Password,
+	})
+	if err != nil {
+		respondError(c, calendarSyncErrorStatus, "Falha ao conectar calendário: ", err)
+		return
+	}
+	var syncErr error
+	if connection.LastError != "" {
+		syncErr = errors.New(connection.LastError)
+	}
+	c.JSON(http.StatusOK, ConnectCalendarResponse{
+		Connection: mapCalendarConnectionToResponse(connection),
+		Sync:       mapCalendarSyncResultToResponse(result, syncErr),
+	})
+}
+
+// DisconnectCalendar godoc
+// @Summary      Desconecta o calendário externo
+// @Description  Remove a conexão e os períodos ocupados importados. Os eventos já publicados permanecem no calendário externo.
+// @Tags         calendar
+// @Security     BearerAuth
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Nenhum calendário conectado"
+// @Router       /calendar/connection [delete]
+func (h *CalendarSyncHandler) DisconnectCalendar(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	if err := h.calendarSyncUseCase.Disconnect(requestingUserID); err != nil {
+		respondError(c, calendarSyncErrorStatus, "Falha ao desconectar calendário: ", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SyncCalendar godoc
+// @Summary      Sincroniza o calendário externo agora
+// @Description  Importa os compromissos do calendário externo e publica os agendamentos sem esperar a sincronização periódica. Falhas da sincronização são retornadas em "error" e ficam registradas na conexão.
+// @Tags         calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} CalendarSyncResponse
+// @Failure      404  {object} map[string]string "Nenhum calendário conectado"
+// @Router       /calendar/connection/sync [post]
+func (h *CalendarSyncHandler) SyncCalendar(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	result, err := h.calendarSyncUseCase.SyncNow(requestingUserID)
+	if result == nil {
+		respondError(c, calendarSyncErrorStatus, "Falha ao sincronizar calendário: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapCalendarSyncResultToResponse(result, err))
+}
+
+// ListCalendarBusyBlocks godoc
+// @Summary      Lista os períodos ocupados no calendário externo
+// @Description  Lista os compromissos importados do calendário externo que se sobrepõem ao período. Eles bloqueiam novos agendamentos e remarcações.
+// @Tags         calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Param        start query string true "Início do período (RFC3339)"
+// @Param        end   query string true "Fim do período (RFC3339)"
+// @Success      200  {array}  CalendarBusyBlockResponse
+// @Failure      400  {object} map[string]string "Período inválido"
+// @Router       /calendar/busy [get]
+func (h *CalendarSyncHandler) ListCalendarBusyBlocks(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	start, errStart := time.Parse(time.RFC3339, c.Query("start"))
+	end, errEnd := time.Parse(time.RFC3339, c.Query("end"))
+	if errStart != nil || errEnd != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Informe start e end no formato RFC3339"})
+		return
+	}
+
+	blocks, err := h.calendarSyncUseCase.ListBusyBlocks(requestingUserID, start, end)
+	if err != nil {
+		respondError(c, calendarSyncErrorStatus, "Erro ao buscar períodos ocupados: ", err)
+		return
+	}
+	responses := make([]CalendarBusyBlockResponse, len(blocks))
+	for i, b := range blocks {
+		responses[i] = CalendarBusyBlockResponse{ID: b.ID, Summary: b.Summary, StartTime: b.StartTime, EndTime: b.EndTime}
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// ServeFakeCalDAV encaminha as requisições ao servidor CalDAV fake. Conecte-o com o
+// endereço {PUBLIC_BASE_URL}/api/v1/fake/caldav/calendars/{nome}/ e qualquer usuário e
+// senha; compromissos pessoais podem ser criados com PUT de arquivos .ics no calendário.
+func (h *CalendarSyncHandler) ServeFakeCalDAV(c *gin.Context) {
+	c.Request.URL.Path = c.Param("path")
+	h.fake.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,469 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Catalog ---
+
+// CreateServiceRequest define o JSON esperado para cadastrar um serviço.
+type CreateServiceRequest struct {
+	Name            string  `json:"name" binding:"required"`
+	Description     string  `json:"description"`
+	Price           float64 `json:"price" binding:"gte=0"`
+	DurationMinutes int     `json:"durationMinutes" binding:"gte=0"`
+}
+
+// UpdateServiceRequest define o JSON para atualizar um serviço.
+type UpdateServiceRequest struct {
+	Name            *string  `json:"name"`
+	Description     *string  `json:"description"`
+	Price           *float64 `json:"price"`
+	DurationMinutes *int     `json:"durationMinutes"`
+	Active          *bool    `json:"active"`
+}
+
+// ServiceResponse define o JSON retornado para um serviço.
+type ServiceResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Price           float64   `json:"price"`
+	DurationMinutes int       `json:"durationMinutes"`
+	Active          bool      `json:"active"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// CreateProfessionalRequest define o JSON esperado para cadastrar um profissional.
+type CreateProfessionalRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"omitempty,email"`
+	Phone string `json:"phone"`
+}
+
+// UpdateProfessionalRequest define o JSON para atualizar um profissional.
+type UpdateProfessionalRequest struct {
+	Name   *string `json:"name"`
+	Email  *string `json:"email"`
+	Phone  *string `json:"phone"`
+	Active *bool   `json:"active"`
+}
+
+// ProfessionalResponse define o JSON retornado para um profissional.
+type ProfessionalResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// --- CatalogHandler ---
+type CatalogHandler struct {
+	catalogUseCase *usecase.CatalogUseCase
+}
+
+func NewCatalogHandler(uc *usecase.CatalogUseCase) *CatalogHandler {
+	return &CatalogHandler{catalogUseCase: uc}
+}
+
+func mapServiceToResponse(s *entity.Service) ServiceResponse {
+	return ServiceResponse{
+		ID:              s.ID,
+		Name:            s.Name,
+		Description:     s.Description,
+		Price:           s.Price,
+		DurationMinutes: s.DurationMinutes,
+		Active:          s.Active,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+}
+
+func mapProfessionalToResponse(p *entity.Professional) ProfessionalResponse {
+	return ProfessionalResponse{
+		ID:        p.ID,
+		Name:      p.Name,
+		Email:     p.Email,
+		Phone:     p.Phone,
+		Active:    p.Active,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// CreateService godoc
+// @Summary      Cadastra um serviço no catálogo
+// @Tags         services
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        service body CreateServiceRequest true "Dados do Serviço"
+// @Success      201  {object} ServiceResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /services [post]
+func (h *CatalogHandler) CreateService(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	service, err := h.catalogUseCase.CreateService(usecase.CreateServiceInputDTO{
+		UserID:          requestingUserID,
+		Name:            req.Name,
+		Description:     req.Description,
+		Price:           req.Price,
+		DurationMinutes: req.DurationMinutes,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao cadastrar serviço: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapServiceToResponse(service))
+}
+
+// ListServices godoc
+// @Summary      Lista o catálogo de serviços
+// @Tags         services
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  ServiceResponse
+// @Router       /services [get]
+func (h *CatalogHandler) ListServices(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	services, err := h.catalogUseCase.ListServices(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar serviços: " + err.Error()})
+		return
+	}
+
+	responses := make([]ServiceResponse, len(services))
+	for i, s := range services {
+		responses[i] = mapServiceToResponse(s)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetServiceByID godoc
+// @Summary      Busca um serviço do catálogo
+// @Tags         services
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Serviço (UUID)"
+// @Success      200  {object} ServiceResponse
+// @Failure      404  {object} map[string]string "Serviço não encontrado"
+// @Router       /services/{id} [get]
+func (h *CatalogHandler) GetServiceByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do serviço inválido"})
+		return
+	}
+
+	service, err := h.catalogUseCase.GetServiceByID(serviceID, requestingUserID)
+	if err != nil {
+		if err.Error() == "serviço não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar serviço: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapServiceToResponse(service))
+}
+
+// UpdateService godoc
+// @Summary      Atualiza um serviço do catálogo
+// @Tags         services
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Serviço (UUID)"
+// @Param        service body UpdateServiceRequest true "Dados para Atualização"
+// @Success      200  {object} ServiceResponse
+// @Failure      400  {object} map[string]string "ID ou dados inválidos"
+// @Failure      404  {object} map[string]string "Serviço não encontrado"
+// @Router       /services/{id} [put]
+func (h *CatalogHandler) UpdateService(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do serviço inválido"})
+		return
+	}
+
+	var req UpdateServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	service, err := h.catalogUseCase.UpdateService(serviceID, requestingUserID, usecase.UpdateServiceInputDTO{
+		Name:            req.Name,
+		Description:     req.Description,
+		Price:           req.Price,
+		DurationMinutes: req.DurationMinutes,
+		Active:          req.Active,
+	})
+	if err != nil {
+		if err.Error() == "serviço não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar serviço: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapServiceToResponse(service))
+}
+
+// DeleteService godoc
+// @Summary      Exclui um serviço do catálogo
+// @Tags         services
+// @Security     BearerAuth
+// @Param        id path string true "ID do Serviço (UUID)"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Serviço não encontrado"
+// @Router       /services/{id} [delete]
+func (h *CatalogHandler) DeleteService(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do serviço inválido"})
+		return
+	}
+
+	if err := h.catalogUseCase.DeleteService(serviceID, requestingUserID); err != nil {
+		if err.Error() == "serviço não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir serviço: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateProfessional godoc
+// @Summary      Cadastra um profissional na equipe
+// @Tags         professionals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        professional body CreateProfessionalRequest true "Dados do Profissional"
+// @Success      201  {object} ProfessionalResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /professionals [post]
+func (h *CatalogHandler) CreateProfessional(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateProfessionalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	professional, err := h.catalogUseCase.CreateProfessional(usecase.CreateProfessionalInputDTO{
+		UserID: requestingUserID,
+		Name:   req.Name,
+		Email:  req.Email,
+		Phone:  req.Phone,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao cadastrar profissional: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapProfessionalToResponse(professional))
+}
+
+// ListProfessionals godoc
+// @Summary      Lista os profissionais da equipe
+// @Tags         professionals
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  ProfessionalResponse
+// @Router       /professionals [get]
+func (h *CatalogHandler) ListProfessionals(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	professionals, err := h.catalogUseCase.ListProfessionals(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar profissionais: " + err.Error()})
+		return
+	}
+
+	responses := make([]ProfessionalResponse, len(professionals))
+	for i, p := range professionals {
+		responses[i] = mapProfessionalToResponse(p)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetProfessionalByID godoc
+// @Summary      Busca um profissional da equipe
+// @Tags         professionals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Profissional (UUID)"
+// @Success      200  {object} ProfessionalResponse
+// @Failure      404  {object} map[string]string "Profissional não encontrado"
+// @Router       /professionals/{id} [get]
+func (h *CatalogHandler) GetProfessionalByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	professionalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do profissional inválido"})
+		return
+	}
+
+	professional, err := h.catalogUseCase.GetProfessionalByID(professionalID, requestingUserID)
+	if err != nil {
+		if err.Error() == "profissional não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar profissional: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapProfessionalToResponse(professional))
+}
+
+// UpdateProfessional godoc
+// @Summary      Atualiza um profissional da equipe
+// @Tags         professionals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Profissional (UUID)"
+// @Param        professional body UpdateProfessionalRequest true "Dados para Atualização"
+// @Success      200  {object} ProfessionalResponse
+// @Failure      400  {object} map[string]string "ID ou dados inválidos"
+// @Failure      404  {object} map[string]string "Profissional não encontrado"
+// @Router       /professionals/{id} [put]
+func (h *CatalogHandler) UpdateProfessional(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	professionalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do profissional inválido"})
+		return
+	}
+
+	var req UpdateProfessionalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	professional, err := h.catalogUseCase.UpdateProfessional(professionalID, requestingUserID, usecase.UpdateProfessionalInputDTO{
+		Name:   req.Name,
+		Email:  req.Email,
+		Phone:  req.Phone,
+		Active: req.Active,
+	})
+	if err != nil {
+		if err.Error() == "profissional não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar profissional: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapProfessionalToResponse(professional))
+}
+
+// DeleteProfessional godoc
+// @Summary      Exclui um profissional da equipe
+// @Tags         professionals
+// @Security     BearerAuth
+// @Param        id path string true "ID do Profissional (UUID)"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Profissional não encontrado"
+// @Router       /professionals/{id} [delete]
+func (h *CatalogHandler) DeleteProfessional(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	professionalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do profissional inválido"})
+		return
+	}
+
+	if err := h.catalogUseCase.DeleteProfessional(professionalID, requestingUserID); err != nil {
+		if err.Error() == "profissional não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir profissional: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
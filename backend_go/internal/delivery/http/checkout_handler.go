@@ -0,0 +1,212 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Checkout ---
+
+// CheckoutPaymentRequest define uma forma de pagamento do fechamento.
+type CheckoutPaymentRequest struct {
+	Method       string  `json:"method" binding:"required,oneof=PIX CARD CASH GIFT_CARD"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+	GiftCardCode string  `json:"giftCardCode" binding:"required_if=Method GIFT_CARD"`
+}
+
+// CheckoutProductRequest define um produto vendido no fechamento.
+type CheckoutProductRequest struct {
+	ProductID uuid.UUID `json:"productId" binding:"required"`
+	Quantity  int       `json:"quantity" binding:"required,gt=0"`
+	UnitPrice float64   `json:"unitPrice" binding:"gte=0"` // Se omitido, usa o preço de venda cadastrado
+}
+
+// CheckoutRequest define o JSON esperado para fechar um atendimento.
+type CheckoutRequest struct {
+	Products  []CheckoutProductRequest `json:"products" binding:"dive"`
+	Payments  []CheckoutPaymentRequest `json:"payments" binding:"dive"`
+	TipAmount float64                  `json:"tipAmount" binding:"gte=0"`
+}
+
+// CheckoutProductResponse define uma linha de produto do fechamento.
+type CheckoutProductResponse struct {
+	ProductID   uuid.UUID `json:"productId"`
+	ProductName string    `json:"productName"`
+	Quantity    int       `json:"quantity"`
+	UnitPrice   float64   `json:"unitPrice"`
+	Total       float64   `json:"total"`
+}
+
+// CheckoutResponse define o JSON retornado para o fechamento de um atendimento.
+type CheckoutResponse struct {
+	ID              uuid.UUID                 `json:"id"`
+	AppointmentID   uuid.UUID                 `json:"appointmentId"`
+	ProfessionalID  *uuid.UUID                `json:"professionalId,omitempty"`
+	ServiceAmount   float64                   `json:"serviceAmount"`
+	PreviouslyPaid  float64                   `json:"previouslyPaid"`
+	ProductsAmount  float64                   `json:"productsAmount"`
+	TipAmount       float64                   `json:"tipAmount"`
+	TotalPaid       float64                   `json:"totalPaid"`
+	TipCommissionID *uuid.UUID                `json:"tipCommissionId,omitempty"`
+	Products        []CheckoutProductResponse `json:"products"`
+	Payments        []PaymentResponse         `json:"payments"`
+	CompletedAt     time.Time                 `json:"completedAt"`
+}
+
+// --- CheckoutHandler ---
+type CheckoutHandler struct {
+	checkoutUseCase *usecase.CheckoutUseCase
+}
+
+func NewCheckoutHandler(uc *usecase.CheckoutUseCase) *CheckoutHandler {
+	return &CheckoutHandler{checkoutUseCase: uc}
+}
+
+func mapCheckoutToResponse(co *entity.Checkout) CheckoutResponse {
+	payments := make([]PaymentResponse, len(co.Payments))
+	for i, p := range co.Payments {
+		payments[i] = mapPaymentEntityToResponse(p)
+	}
+	products := make([]CheckoutProductResponse, len(co.Products))
+	for i, line := range co.Products {
+		products[i] = CheckoutProductResponse{
+			ProductID:   line.ProductID,
+			ProductName: line.ProductName,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Total:       line.Total(),
+		}
+	}
+	return CheckoutResponse{
+		ID:              co.ID,
+		AppointmentID:   co.AppointmentID,
+		ProfessionalID:  co.ProfessionalID,
+		ServiceAmount:   co.ServiceAmount,
+		PreviouslyPaid:  co.PreviouslyPaid,
+		ProductsAmount:  co.ProductsAmount,
+		TipAmount:       co.TipAmount,
+		TotalPaid:       co.TotalPaid,
+		TipCommissionID: co.TipCommissionID,
+		Products:        products,
+		Payments:        payments,
+		CompletedAt:     co.CompletedAt,
+	}
+}
+
+// CheckoutAppointment godoc
+// @Summary      Fecha um atendimento no balcão
+// @Description  Registra os produtos vendidos (baixando o estoque), o pagamento dividido em várias formas (PIX, cartão, dinheiro, vale-presente) e a gorjeta do profissional, e conclui o agendamento em uma única operação. A soma dos pagamentos deve ser igual ao valor em aberto mais os produtos e a gorjeta; vale-presente não paga produtos.
+// @Tags         appointments
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Agendamento (UUID)"
+// @Param        checkout body CheckoutRequest true "Produtos, Pagamentos e Gorjeta"
+// @Success      201  {object} CheckoutResponse
+// @Failure      400  {object} map[string]string "Pagamentos não conferem com o total, vale inválido ou estoque insuficiente"
+// @Failure      404  {object} map[string]string "Agendamento não encontrado"
+// @Failure      409  {object} map[string]string "Agendamento já concluído ou cancelado"
+// @Router       /appointments/{id}/checkout [post]
+func (h *CheckoutHandler) CheckoutAppointment(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do agendamento inválido"})
+		return
+	}
+
+	var req CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	payments := make([]usecase.CheckoutPaymentInputDTO, len(req.Payments))
+	for i, p := range req.Payments {
+		payments[i] = usecase.CheckoutPaymentInputDTO{
+			Method:       entity.PaymentMethod(p.Method),
+			Amount:       p.Amount,
+			GiftCardCode: p.GiftCardCode,
+		}
+	}
+
+	products := make([]usecase.CheckoutProductInputDTO, len(req.Products))
+	for i, p := range req.Products {
+		products[i] = usecase.CheckoutProductInputDTO{
+			ProductID: p.ProductID,
+			Quantity:  p.Quantity,
+			UnitPrice: p.UnitPrice,
+		}
+	}
+
+	checkout, err := h.checkoutUseCase.Checkout(usecase.CheckoutInputDTO{
+		UserID:        requestingUserID,
+		AppointmentID: appointmentID,
+		Products:      products,
+		Payments:      payments,
+		TipAmount:     req.TipAmount,
+	})
+	if err != nil {
+		switch {
+		case err.Error() == "agendamento não encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrCheckoutStatus):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrInvalidCheckout), errors.Is(err, usecase.ErrInvalidGiftCard),
+			err.Error() == "produto não encontrado":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao fechar atendimento: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapCheckoutToResponse(checkout))
+}
+
+// GetAppointmentCheckout godoc
+// @Summary      Busca o fechamento de um atendimento
+// @Tags         appointments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Agendamento (UUID)"
+// @Success      200  {object} CheckoutResponse
+// @Failure      404  {object} map[string]string "Fechamento não encontrado"
+// @Router       /appointments/{id}/checkout [get]
+func (h *CheckoutHandler) GetAppointmentCheckout(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do agendamento inválido"})
+		return
+	}
+
+	checkout, err := h.checkoutUseCase.GetCheckout(appointmentID, requestingUserID)
+	if err != nil {
+		if err.Error() == "fechamento não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar fechamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapCheckoutToResponse(checkout))
+}
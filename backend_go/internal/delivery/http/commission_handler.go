@@ -0,0 +1,491 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Commission ---
+
+// CreateCommissionRuleRequest define o JSON esperado para cadastrar uma regra de comissão.
+type CreateCommissionRuleRequest struct {
+	ProfessionalID *uuid.UUID `json:"professionalId"` // Omitido vale para todos os profissionais
+	ServiceID      *uuid.UUID `json:"serviceId"`      // Omitido vale para todos os serviços
+	Source         string     `json:"source" binding:"required,oneof=SERVICE PRODUCT"`
+	Type           string     `json:"type" binding:"required,oneof=PERCENTAGE FIXED"`
+	Value          float64    `json:"value" binding:"required,gt=0"`
+}
+
+// UpdateCommissionRuleRequest define o JSON para atualizar uma regra de comissão.
+type UpdateCommissionRuleRequest struct {
+	ProfessionalID *string  `json:"professionalId"` // "" aplica a todos os profissionais
+	ServiceID      *string  `json:"serviceId"`      // "" aplica a todos os serviços
+	Type           *string  `json:"type" binding:"omitempty,oneof=PERCENTAGE FIXED"`
+	Value          *float64 `json:"value"`
+}
+
+// CommissionRuleResponse define o JSON retornado para uma regra de comissão.
+type CommissionRuleResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ProfessionalID *uuid.UUID `json:"professionalId,omitempty"`
+	ServiceID      *uuid.UUID `json:"serviceId,omitempty"`
+	Source         string     `json:"source"`
+	Type           string     `json:"type"`
+	Value          float64    `json:"value"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// CommissionResponse define o JSON de uma comissão apurada.
+type CommissionResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	AppointmentID *uuid.UUID `json:"appointmentId,omitempty"`
+	Source        string     `json:"source"`
+	Description   string     `json:"description"`
+	BaseAmount    float64    `json:"baseAmount"`
+	Amount        float64    `json:"amount"`
+	Status        string     `json:"status"`
+	PayoutID      *uuid.UUID `json:"payoutId,omitempty"`
+	EarnedAt      time.Time  `json:"earnedAt"`
+}
+
+// CommissionStatementResponse define o JSON do extrato de comissões de um profissional.
+type CommissionStatementResponse struct {
+	ProfessionalID   uuid.UUID            `json:"professionalId"`
+	ProfessionalName string               `json:"professionalName"`
+	From             time.Time            `json:"from"`
+	To               time.Time            `json:"to"` // Exclusivo
+	Commissions      []CommissionResponse `json:"commissions"`
+	TotalEarned      float64              `json:"totalEarned"`
+	TotalPending     float64              `json:"totalPending"`
+	TotalPaid        float64              `json:"totalPaid"`
+	TotalTips        float64              `json:"totalTips"` // Gorjetas, já incluídas em totalEarned
+}
+
+// PayCommissionsRequest define o JSON para pagar as comissões pendentes de um período.
+type PayCommissionsRequest struct {
+	From string `json:"from"` // AAAA-MM-DD; padrão: primeiro dia do mês atual
+	To   string `json:"to"`   // AAAA-MM-DD inclusivo; padrão: último dia do mês atual
+}
+
+// CommissionPayoutResponse define o JSON de um repasse de comissões.
+type CommissionPayoutResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	ProfessionalID   uuid.UUID  `json:"professionalId"`
+	PeriodStart      time.Time  `json:"periodStart"`
+	PeriodEnd        time.Time  `json:"periodEnd"` // Exclusivo
+	Amount           float64    `json:"amount"`
+	CommissionCount  int        `json:"commissionCount"`
+	FinancialEntryID *uuid.UUID `json:"financialEntryId,omitempty"`
+	PaidAt           time.Time  `json:"paidAt"`
+}
+
+// --- CommissionHandler ---
+type CommissionHandler struct {
+	commissionUseCase *usecase.CommissionUseCase
+}
+
+func NewCommissionHandler(uc *usecase.CommissionUseCase) *CommissionHandler {
+	return &CommissionHandler{commissionUseCase: uc}
+}
+
+func mapCommissionRuleToResponse(r *entity.CommissionRule) CommissionRuleResponse {
+	return CommissionRuleResponse{
+		ID:             r.ID,
+		ProfessionalID: r.ProfessionalID,
+		ServiceID:      r.ServiceID,
+		Source:         string(r.Source),
+		Type:           string(r.Type),
+		Value:          r.Value,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
+
+func mapCommissionPayoutToResponse(p *entity.CommissionPayout) CommissionPayoutResponse {
+	return CommissionPayoutResponse{
+		ID:               p.ID,
+		ProfessionalID:   p.ProfessionalID,
+		PeriodStart:      p.PeriodStart,
+		PeriodEnd:        p.PeriodEnd,
+		Amount:           p.Amount,
+		CommissionCount:  p.CommissionCount,
+		FinancialEntryID: p.FinancialEntryID,
+		PaidAt:           p.PaidAt,
+	}
+}
+
+// isCommissionNotFound indica se o erro do caso de uso corresponde a um recurso inexistente.
+func isCommissionNotFound(err error) bool {
+	switch err.Error() {
+	case "regra de comissão não encontrada", "profissional não encontrado", "serviço não encontrado":
+		return true
+	}
+	return false
+}
+
+// parseCommissionPeriod converte datas AAAA-MM-DD (fim inclusivo) no intervalo [from, to).
+// Sem datas, usa o mês atual.
+func parseCommissionPeriod(fromStr, toStr string) (time.Time, time.Time, error) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, 0)
+	if fromStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromStr, time.Local)
+		if err != nil {
+			return from, to, errors.New("formato de from inválido, use AAAA-MM-DD")
+		}
+		from = parsed
+	}
+	if toStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toStr, time.Local)
+		if err != nil {
+			return from, to, errors.New("formato de to inválido, use AAAA-MM-DD")
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+	return from, to, nil
+}
+
+// CreateRule godoc
+// @Summary      Cadastra uma regra de comissão
+// @Tags         commissions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        rule body CreateCommissionRuleRequest true "Dados da Regra"
+// @Success      201  {object} CommissionRuleResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /commission-rules [post]
+func (h *CommissionHandler) CreateRule(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateCommissionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	rule, err := h.commissionUseCase.CreateRule(usecase.CreateCommissionRuleInputDTO{
+		UserID:         requestingUserID,
+		ProfessionalID: req.ProfessionalID,
+		ServiceID:      req.ServiceID,
+		Source:         entity.CommissionSource(req.Source),
+		Type:           entity.CommissionType(req.Type),
+		Value:          req.Value,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Falha ao cadastrar regra de comissão: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapCommissionRuleToResponse(rule))
+}
+
+// ListRules godoc
+// @Summary      Lista as regras de comissão
+// @Tags         commissions
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  CommissionRuleResponse
+// @Router       /commission-rules [get]
+func (h *CommissionHandler) ListRules(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	rules, err := h.commissionUseCase.ListRules(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar regras de comissão: " + err.Error()})
+		return
+	}
+
+	responses := make([]CommissionRuleResponse, len(rules))
+	for i, r := range rules {
+		responses[i] = mapCommissionRuleToResponse(r)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// UpdateRule godoc
+// @Summary      Atualiza uma regra de comissão
+// @Tags         commissions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID da Regra (UUID)"
+// @Param        rule body UpdateCommissionRuleRequest true "Dados para Atualização"
+// @Success      200  {object} CommissionRuleResponse
+// @Failure      400  {object} map[string]string "ID ou dados inválidos"
+// @Failure      404  {object} map[string]string "Regra não encontrada"
+// @Router       /commission-rules/{id} [put]
+func (h *CommissionHandler) UpdateRule(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da regra inválido"})
+		return
+	}
+
+	var req UpdateCommissionRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	input := usecase.UpdateCommissionRuleInputDTO{Value: req.Value}
+	if req.Type != nil {
+		commissionType := entity.CommissionType(*req.Type)
+		input.Type = &commissionType
+	}
+	if req.ProfessionalID != nil {
+		if *req.ProfessionalID == "" {
+			input.ClearProfessional = true
+		} else {
+			professionalID, err := uuid.Parse(*req.ProfessionalID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "professionalId inválido, deve ser um UUID ou vazio"})
+				return
+			}
+			input.ProfessionalID = &professionalID
+		}
+	}
+	if req.ServiceID != nil {
+		if *req.ServiceID == "" {
+			input.ClearService = true
+		} else {
+			serviceID, err := uuid.Parse(*req.ServiceID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "serviceId inválido, deve ser um UUID ou vazio"})
+				return
+			}
+			input.ServiceID = &serviceID
+		}
+	}
+
+	rule, err := h.commissionUseCase.UpdateRule(ruleID, requestingUserID, input)
+	if err != nil {
+		if err.Error() == "regra de comissão não encontrada" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Falha ao atualizar regra de comissão: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapCommissionRuleToResponse(rule))
+}
+
+// DeleteRule godoc
+// @Summary      Exclui uma regra de comissão
+// @Tags         commissions
+// @Security     BearerAuth
+// @Param        id path string true "ID da Regra (UUID)"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Regra não encontrada"
+// @Router       /commission-rules/{id} [delete]
+func (h *CommissionHandler) DeleteRule(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	ruleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da regra inválido"})
+		return
+	}
+
+	if err := h.commissionUseCase.DeleteRule(ruleID, requestingUserID); err != nil {
+		if err.Error() == "regra de comissão não encontrada" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir regra de comissão: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetStatement godoc
+// @Summary      Extrato de comissões de um profissional
+// @Tags         commissions
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Profissional (UUID)"
+// @Param        from query string false "Data inicial AAAA-MM-DD (padrão: início do mês atual)"
+// @Param        to query string false "Data final inclusiva AAAA-MM-DD (padrão: fim do mês atual)"
+// @Success      200  {object} CommissionStatementResponse
+// @Failure      400  {object} map[string]string "Período inválido"
+// @Failure      404  {object} map[string]string "Profissional não encontrado"
+// @Router       /professionals/{id}/commissions [get]
+func (h *CommissionHandler) GetStatement(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	professionalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do profissional inválido"})
+		return
+	}
+	from, to, err := parseCommissionPeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	statement, err := h.commissionUseCase.GetStatement(professionalID, requestingUserID, from, to)
+	if err != nil {
+		if isCommissionNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao gerar extrato: " + err.Error()})
+		return
+	}
+
+	commissions := make([]CommissionResponse, len(statement.Commissions))
+	for i, cm := range statement.Commissions {
+		commissions[i] = CommissionResponse{
+			ID:            cm.ID,
+			AppointmentID: cm.AppointmentID,
+			Source:        string(cm.Source),
+			Description:   cm.Description,
+			BaseAmount:    cm.BaseAmount,
+			Amount:        cm.Amount,
+			Status:        string(cm.Status),
+			PayoutID:      cm.PayoutID,
+			EarnedAt:      cm.EarnedAt,
+		}
+	}
+	c.JSON(http.StatusOK, CommissionStatementResponse{
+		ProfessionalID:   statement.Professional.ID,
+		ProfessionalName: statement.Professional.Name,
+		From:             statement.From,
+		To:               statement.To,
+		Commissions:      commissions,
+		TotalEarned:      statement.TotalEarned,
+		TotalPending:     statement.TotalPending,
+		TotalPaid:        statement.TotalPaid,
+		TotalTips:        statement.TotalTips,
+	})
+}
+
+// PayCommissions godoc
+// @Summary      Marca como pagas as comissões pendentes do período
+// @Description  Gera um repasse com as comissões pendentes e lança o total como saída no livro-caixa.
+// @Tags         commissions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Profissional (UUID)"
+// @Param        period body PayCommissionsRequest false "Período do repasse"
+// @Success      201  {object} CommissionPayoutResponse
+// @Failure      400  {object} map[string]string "Período inválido ou sem comissões pendentes"
+// @Failure      404  {object} map[string]string "Profissional não encontrado"
+// @Router       /professionals/{id}/commissions/payouts [post]
+func (h *CommissionHandler) PayCommissions(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	professionalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do profissional inválido"})
+		return
+	}
+
+	var req PayCommissionsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+			return
+		}
+	}
+	from, to, err := parseCommissionPeriod(req.From, req.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payout, err := h.commissionUseCase.PayCommissions(professionalID, requestingUserID, from, to)
+	if err != nil {
+		if isCommissionNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "nenhuma comissão pendente no período" || err.Error() == "data final deve ser posterior à data inicial" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao pagar comissões: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapCommissionPayoutToResponse(payout))
+}
+
+// ListPayouts godoc
+// @Summary      Lista os repasses de comissão de um profissional
+// @Tags         commissions
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Profissional (UUID)"
+// @Success      200  {array}  CommissionPayoutResponse
+// @Failure      404  {object} map[string]string "Profissional não encontrado"
+// @Router       /professionals/{id}/commissions/payouts [get]
+func (h *CommissionHandler) ListPayouts(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	professionalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do profissional inválido"})
+		return
+	}
+
+	payouts, err := h.commissionUseCase.ListPayouts(professionalID, requestingUserID)
+	if err != nil {
+		if isCommissionNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar repasses: " + err.Error()})
+		return
+	}
+
+	responses := make([]CommissionPayoutResponse, len(payouts))
+	for i, p := range payouts {
+		responses[i] = mapCommissionPayoutToResponse(p)
+	}
+	c.JSON(http.StatusOK, responses)
+}
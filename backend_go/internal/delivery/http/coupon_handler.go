@@ -0,0 +1,367 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Coupon ---
+
+// CreateCouponRequest define o JSON esperado para cadastrar um cupom de desconto.
+type CreateCouponRequest struct {
+	Code               string      `json:"code" binding:"required,max=50"`
+	Description        string      `json:"description"`
+	DiscountType       string      `json:"discountType" binding:"required,oneof=PERCENTAGE FIXED"`
+	Value              float64     `json:"value" binding:"required,gt=0"`
+	ValidFrom          *time.Time  `json:"validFrom"`
+	ValidUntil         *time.Time  `json:"validUntil"`
+	MaxUses            int         `json:"maxUses" binding:"gte=0"`            // Zero significa ilimitado
+	MaxUsesPerCustomer int         `json:"maxUsesPerCustomer" binding:"gte=0"` // Zero significa ilimitado
+	ServiceIDs         []uuid.UUID `json:"serviceIds"`                         // Vazio vale para todos os serviços
+}
+
+// UpdateCouponRequest define o JSON para atualizar um cupom de desconto.
+type UpdateCouponRequest struct {
+	Description        *string     `json:"description"`
+	DiscountType       *string     `json:"discountType" binding:"omitempty,oneof=PERCENTAGE FIXED"`
+	Value              *float64    `json:"value"`
+	ValidFrom          *string     `json:"validFrom"`  // RFC3339; "" remove o início da validade
+	ValidUntil         *string     `json:"validUntil"` // RFC3339; "" remove o fim da validade
+	MaxUses            *int        `json:"maxUses"`
+	MaxUsesPerCustomer *int        `json:"maxUsesPerCustomer"`
+	ServiceIDs         []uuid.UUID `json:"serviceIds"`
+	Active             *bool       `json:"active"`
+}
+
+// CouponResponse define o JSON retornado para um cupom de desconto.
+type CouponResponse struct {
+	ID                 uuid.UUID   `json:"id"`
+	Code               string      `json:"code"`
+	Description        string      `json:"description"`
+	DiscountType       string      `json:"discountType"`
+	Value              float64     `json:"value"`
+	ValidFrom          *time.Time  `json:"validFrom,omitempty"`
+	ValidUntil         *time.Time  `json:"validUntil,omitempty"`
+	MaxUses            int         `json:"maxUses"`
+	MaxUsesPerCustomer int         `json:"maxUsesPerCustomer"`
+	ServiceIDs         []uuid.UUID `json:"serviceIds"`
+	Active             bool        `json:"active"`
+	CreatedAt          time.Time   `json:"createdAt"`
+	UpdatedAt          time.Time   `json:"updatedAt"`
+}
+
+// CouponRedemptionResponse define o JSON retornado para um uso de cupom.
+type CouponRedemptionResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	AppointmentID  uuid.UUID  `json:"appointmentId"`
+	CustomerID     *uuid.UUID `json:"customerId,omitempty"`
+	ClientEmail    string     `json:"clientEmail,omitempty"`
+	DiscountAmount float64    `json:"discountAmount"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// --- CouponHandler ---
+type CouponHandler struct {
+	couponUseCase *usecase.CouponUseCase
+}
+
+func NewCouponHandler(uc *usecase.CouponUseCase) *CouponHandler {
+	return &CouponHandler{couponUseCase: uc}
+}
+
+func mapCouponToResponse(c *entity.Coupon) CouponResponse {
+	return CouponResponse{
+		ID:                 c.ID,
+		Code:               c.Code,
+		Description:        c.Description,
+		DiscountType:       string(c.DiscountType),
+		Value:              c.Value,
+		ValidFrom:          c.ValidFrom,
+		ValidUntil:         c.ValidUntil,
+		MaxUses:            c.MaxUses,
+		MaxUsesPerCustomer: c.MaxUsesPerCustomer,
+		ServiceIDs:         c.ServiceIDs,
+		Active:             c.Active,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
+
+// CreateCoupon godoc
+// @Summary      Cadastra um cupom de desconto
+// @Description  O código é convertido para maiúsculas e deve ser único.
+// @Tags         coupons
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        coupon body CreateCouponRequest true "Dados do Cupom"
+// @Success      201  {object} CouponResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /coupons [post]
+func (h *CouponHandler) CreateCoupon(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	coupon, err := h.couponUseCase.CreateCoupon(usecase.CreateCouponInputDTO{
+		UserID:             requestingUserID,
+		Code:               req.Code,
+		Description:        req.Description,
+		DiscountType:       entity.CouponDiscountType(req.DiscountType),
+		Value:              req.Value,
+		ValidFrom:          req.ValidFrom,
+		ValidUntil:         req.ValidUntil,
+		MaxUses:            req.MaxUses,
+		MaxUsesPerCustomer: req.MaxUsesPerCustomer,
+		ServiceIDs:         req.ServiceIDs,
+	})
+	if err != nil {
+		if err.Error() == "já existe um cupom com este código" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao cadastrar cupom: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapCouponToResponse(coupon))
+}
+
+// ListCoupons godoc
+// @Summary      Lista os cupons de desconto
+// @Tags         coupons
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  CouponResponse
+// @Router       /coupons [get]
+func (h *CouponHandler) ListCoupons(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	coupons, err := h.couponUseCase.ListCoupons(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar cupons: " + err.Error()})
+		return
+	}
+
+	responses := make([]CouponResponse, len(coupons))
+	for i, cp := range coupons {
+		responses[i] = mapCouponToResponse(cp)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetCouponByID godoc
+// @Summary      Busca um cupom de desconto
+// @Tags         coupons
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Cupom (UUID)"
+// @Success      200  {object} CouponResponse
+// @Failure      404  {object} map[string]string "Cupom não encontrado"
+// @Router       /coupons/{id} [get]
+func (h *CouponHandler) GetCouponByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	couponID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cupom inválido"})
+		return
+	}
+
+	coupon, err := h.couponUseCase.GetCouponByID(couponID, requestingUserID)
+	if err != nil {
+		if err.Error() == "cupom não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar cupom: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapCouponToResponse(coupon))
+}
+
+// UpdateCoupon godoc
+// @Summary      Atualiza um cupom de desconto
+// @Description  O código não pode ser alterado.
+// @Tags         coupons
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Cupom (UUID)"
+// @Param        coupon body UpdateCouponRequest true "Dados para Atualização"
+// @Success      200  {object} CouponResponse
+// @Failure      404  {object} map[string]string "Cupom não encontrado"
+// @Router       /coupons/{id} [put]
+func (h *CouponHandler) UpdateCoupon(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	couponID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cupom inválido"})
+		return
+	}
+
+	var req UpdateCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	input := usecase.UpdateCouponInputDTO{
+		Description:        req.Description,
+		Value:              req.Value,
+		MaxUses:            req.MaxUses,
+		MaxUsesPerCustomer: req.MaxUsesPerCustomer,
+		ServiceIDs:         req.ServiceIDs,
+		Active:             req.Active,
+	}
+	if req.DiscountType != nil {
+		discountType := entity.CouponDiscountType(*req.DiscountType)
+		input.DiscountType = &discountType
+	}
+	if req.ValidFrom != nil {
+		if *req.ValidFrom == "" {
+			input.ClearValidFrom = true
+		} else {
+			validFrom, err := time.Parse(time.RFC3339, *req.ValidFrom)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "validFrom inválido, use RFC3339 ou vazio"})
+				return
+			}
+			input.ValidFrom = &validFrom
+		}
+	}
+	if req.ValidUntil != nil {
+		if *req.ValidUntil == "" {
+			input.ClearValidUntil = true
+		} else {
+			validUntil, err := time.Parse(time.RFC3339, *req.ValidUntil)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "validUntil inválido, use RFC3339 ou vazio"})
+				return
+			}
+			input.ValidUntil = &validUntil
+		}
+	}
+
+	coupon, err := h.couponUseCase.UpdateCoupon(couponID, requestingUserID, input)
+	if err != nil {
+		if err.Error() == "cupom não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar cupom: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapCouponToResponse(coupon))
+}
+
+// DeleteCoupon godoc
+// @Summary      Exclui um cupom de desconto
+// @Description  Agendamentos que já usaram o cupom mantêm o desconto.
+// @Tags         coupons
+// @Security     BearerAuth
+// @Param        id path string true "ID do Cupom (UUID)"
+// @Success      204
+// @Failure      404  {object} map[string]string "Cupom não encontrado"
+// @Router       /coupons/{id} [delete]
+func (h *CouponHandler) DeleteCoupon(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	couponID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cupom inválido"})
+		return
+	}
+
+	if err := h.couponUseCase.DeleteCoupon(couponID, requestingUserID); err != nil {
+		if err.Error() == "cupom não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir cupom: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListRedemptions godoc
+// @Summary      Lista os usos de um cupom
+// @Description  Usos em agendamentos cancelados ou excluídos não são listados.
+// @Tags         coupons
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Cupom (UUID)"
+// @Success      200  {array}  CouponRedemptionResponse
+// @Failure      404  {object} map[string]string "Cupom não encontrado"
+// @Router       /coupons/{id}/redemptions [get]
+func (h *CouponHandler) ListRedemptions(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	couponID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cupom inválido"})
+		return
+	}
+
+	redemptions, err := h.couponUseCase.ListRedemptions(couponID, requestingUserID)
+	if err != nil {
+		if err.Error() == "cupom não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar usos do cupom: " + err.Error()})
+		return
+	}
+
+	responses := make([]CouponRedemptionResponse, len(redemptions))
+	for i, r := range redemptions {
+		responses[i] = CouponRedemptionResponse{
+			ID:             r.ID,
+			AppointmentID:  r.AppointmentID,
+			CustomerID:     r.CustomerID,
+			ClientEmail:    r.ClientEmail,
+			DiscountAmount: r.DiscountAmount,
+			CreatedAt:      r.CreatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,376 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para o Painel ---
+
+// DashboardKPIsResponse define o JSON dos indicadores de um período. Taxas em percentual.
+type DashboardKPIsResponse struct {
+	From                  string         `json:"from"`
+	To                    string         `json:"to"` // Inclusivo
+	Revenue               float64        `json:"revenue"`
+	AppointmentsByStatus  map[string]int `json:"appointmentsByStatus"`
+	TotalAppointments     int            `json:"totalAppointments"`
+	CompletedAppointments int            `json:"completedAppointments"`
+	NoShowRate            float64        `json:"noShowRate"`
+	CancellationRate      float64        `json:"cancellationRate"`
+	AverageTicket         float64        `json:"averageTicket"`
+	BookedMinutes         float64        `json:"bookedMinutes"`
+	AvailableMinutes      float64        `json:"availableMinutes"`
+	OccupancyRate         float64        `json:"occupancyRate"`
+}
+
+// DashboardChangesResponse define o JSON da comparação com o período anterior.
+type DashboardChangesResponse struct {
+	Revenue          *float64 `json:"revenue"`          // Variação percentual; null se o anterior for zero
+	Appointments     *float64 `json:"appointments"`     // Variação percentual; null se o anterior for zero
+	AverageTicket    *float64 `json:"averageTicket"`    // Variação percentual; null se o anterior for zero
+	NoShowRate       float64  `json:"noShowRate"`       // Pontos percentuais
+	CancellationRate float64  `json:"cancellationRate"` // Pontos percentuais
+	OccupancyRate    float64  `json:"occupancyRate"`    // Pontos percentuais
+}
+
+// DashboardSummaryResponse define o JSON do resumo do painel.
+type DashboardSummaryResponse struct {
+	Current  DashboardKPIsResponse    `json:"current"`
+	Previous DashboardKPIsResponse    `json:"previous"`
+	Changes  DashboardChangesResponse `json:"changes"`
+}
+
+// RevenuePointResponse define o JSON de um período da série de faturamento.
+type RevenuePointResponse struct {
+	PeriodStart string  `json:"periodStart"`
+	Amount      float64 `json:"amount"`
+}
+
+// RankingRowResponse define o JSON de uma linha dos rankings de serviços e clientes.
+type RankingRowResponse struct {
+	ID           *uuid.UUID `json:"id,omitempty"` // Ausente para nomes informados sem cadastro
+	Name         string     `json:"name"`
+	Appointments int        `json:"appointments"`
+	Revenue      float64    `json:"revenue"`
+}
+
+// WorkingHoursDTO define o horário de funcionamento de um dia da semana.
+type WorkingHoursDTO struct {
+	Weekday  int    `json:"weekday" binding:"gte=0,lte=6"` // 0 = domingo
+	OpensAt  string `json:"opensAt" binding:"required"`    // HH:MM
+	ClosesAt string `json:"closesAt" binding:"required"`   // HH:MM
+}
+
+// SaveWorkingHoursRequest define o JSON esperado para salvar o horário semanal.
+type SaveWorkingHoursRequest struct {
+	Days []WorkingHoursDTO `json:"days" binding:"dive"` // Dias omitidos ficam fechados
+}
+
+// --- DashboardHandler ---
+type DashboardHandler struct {
+	dashboardUseCase *usecase.DashboardUseCase
+}
+
+func NewDashboardHandler(uc *usecase.DashboardUseCase) *DashboardHandler {
+	return &DashboardHandler{dashboardUseCase: uc}
+}
+
+func mapDashboardKPIsToResponse(k *usecase.DashboardKPIs) DashboardKPIsResponse {
+	byStatus := make(map[string]int, len(k.AppointmentsByStatus))
+	for status, count := range k.AppointmentsByStatus {
+		byStatus[string(status)] = count
+	}
+	return DashboardKPIsResponse{
+		From:                  k.From.Format("2006-01-02"),
+		To:                    k.To.AddDate(0, 0, -1).Format("2006-01-02"),
+		Revenue:               k.Revenue,
+		AppointmentsByStatus:  byStatus,
+		TotalAppointments:     k.TotalAppointments,
+		CompletedAppointments: k.CompletedAppointments,
+		NoShowRate:            k.NoShowRate,
+		CancellationRate:      k.CancellationRate,
+		AverageTicket:         k.AverageTicket,
+		BookedMinutes:         k.BookedMinutes,
+		AvailableMinutes:      k.AvailableMinutes,
+		OccupancyRate:         k.OccupancyRate,
+	}
+}
+
+func mapRankingToResponse(rows []repository.RankingRow) []RankingRowResponse {
+	responses := make([]RankingRowResponse, len(rows))
+	for i, row := range rows {
+		responses[i] = RankingRowResponse{
+			ID:           row.ID,
+			Name:         row.Name,
+			Appointments: row.Appointments,
+			Revenue:      row.Revenue,
+		}
+	}
+	return responses
+}
+
+func mapWorkingHoursToResponse(hours []*entity.WorkingHours) []WorkingHoursDTO {
+	responses := make([]WorkingHoursDTO, len(hours))
+	for i, h := range hours {
+		responses[i] = WorkingHoursDTO{
+			Weekday:  int(h.Weekday),
+			OpensAt:  fmt.Sprintf("%02d:%02d", h.OpenMinute/60, h.OpenMinute%60),
+			ClosesAt: fmt.Sprintf("%02d:%02d", h.CloseMinute/60, h.CloseMinute%60),
+		}
+	}
+	return responses
+}
+
+// parseClockMinutes converte "HH:MM" em minutos desde a meia-noite. Aceita "24:00".
+func parseClockMinutes(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.New("horário inválido, use HH:MM: " + s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// dashboardErrorStatus mapeia os erros dos casos de uso do painel para o status HTTP.
+func dashboardErrorStatus(err error) int {
+	if errors.Is(err, usecase.ErrInvalidDashboard) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// dashboardRequest lê o usuário autenticado e o período (padrão: mês atual) da requisição.
+func dashboardRequest(c *gin.Context) (uuid.UUID, time.Time, time.Time, bool) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return uuid.Nil, time.Time{}, time.Time{}, false
+	}
+	from, to, err := parseCommissionPeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return uuid.Nil, time.Time{}, time.Time{}, false
+	}
+	return requestingUserID, from, to, true
+}
+
+// dashboardLimit lê o limite dos rankings (padrão: 10).
+func dashboardLimit(c *gin.Context) (int, bool) {
+	limit := 10
+	if s := c.Query("limit"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit deve ser um número inteiro"})
+			return 0, false
+		}
+		limit = parsed
+	}
+	return limit, true
+}
+
+// GetSummary godoc
+// @Summary      Resumo de indicadores do painel
+// @Description  Faturamento, agendamentos por status, taxas de não comparecimento e cancelamento, ticket médio e taxa de ocupação (agenda sobre horário de funcionamento x profissionais ativos), comparados ao período anterior de mesma duração.
+// @Tags         dashboard
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string false "Data inicial (AAAA-MM-DD); padrão: início do mês atual"
+// @Param        to   query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success      200  {object} DashboardSummaryResponse
+// @Failure      400  {object} map[string]string "Período inválido"
+// @Router       /dashboard/summary [get]
+func (h *DashboardHandler) GetSummary(c *gin.Context) {
+	requestingUserID, from, to, ok := dashboardRequest(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.dashboardUseCase.GetSummary(requestingUserID, from, to)
+	if err != nil {
+		respondError(c, dashboardErrorStatus, "Erro ao calcular indicadores: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DashboardSummaryResponse{
+		Current:  mapDashboardKPIsToResponse(summary.Current),
+		Previous: mapDashboardKPIsToResponse(summary.Previous),
+		Changes: DashboardChangesResponse{
+			Revenue:          summary.Changes.Revenue,
+			Appointments:     summary.Changes.Appointments,
+			AverageTicket:    summary.Changes.AverageTicket,
+			NoShowRate:       summary.Changes.NoShowRate,
+			CancellationRate: summary.Changes.CancellationRate,
+			OccupancyRate:    summary.Changes.OccupancyRate,
+		},
+	})
+}
+
+// GetRevenueSeries godoc
+// @Summary      Faturamento por período
+// @Tags         dashboard
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from    query string false "Data inicial (AAAA-MM-DD); padrão: início do mês atual"
+// @Param        to      query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Param        groupBy query string false "day, week ou month (padrão: day)"
+// @Success      200  {array}  RevenuePointResponse
+// @Failure      400  {object} map[string]string "Parâmetros inválidos"
+// @Router       /dashboard/revenue [get]
+func (h *DashboardHandler) GetRevenueSeries(c *gin.Context) {
+	requestingUserID, from, to, ok := dashboardRequest(c)
+	if !ok {
+		return
+	}
+	groupBy := c.DefaultQuery("groupBy", "day")
+
+	points, err := h.dashboardUseCase.GetRevenueSeries(requestingUserID, from, to, groupBy)
+	if err != nil {
+		respondError(c, dashboardErrorStatus, "Erro ao calcular faturamento: ", err)
+		return
+	}
+
+	responses := make([]RevenuePointResponse, len(points))
+	for i, p := range points {
+		responses[i] = RevenuePointResponse{PeriodStart: p.PeriodStart.Format("2006-01-02"), Amount: p.Amount}
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetTopServices godoc
+// @Summary      Serviços com maior faturamento
+// @Tags         dashboard
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query string false "Data inicial (AAAA-MM-DD); padrão: início do mês atual"
+// @Param        to    query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Param        limit query int    false "Quantidade (1 a 50; padrão: 10)"
+// @Success      200  {array}  RankingRowResponse
+// @Failure      400  {object} map[string]string "Parâmetros inválidos"
+// @Router       /dashboard/top-services [get]
+func (h *DashboardHandler) GetTopServices(c *gin.Context) {
+	requestingUserID, from, to, ok := dashboardRequest(c)
+	if !ok {
+		return
+	}
+	limit, ok := dashboardLimit(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.dashboardUseCase.GetTopServices(requestingUserID, from, to, limit)
+	if err != nil {
+		respondError(c, dashboardErrorStatus, "Erro ao calcular ranking de serviços: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapRankingToResponse(rows))
+}
+
+// GetTopClients godoc
+// @Summary      Clientes com maior faturamento
+// @Tags         dashboard
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query string false "Data inicial (AAAA-MM-DD); padrão: início do mês atual"
+// @Param        to    query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Param        limit query int    false "Quantidade (1 a 50; padrão: 10)"
+// @Success      200  {array}  RankingRowResponse
+// @Failure      400  {object} map[string]string "Parâmetros inválidos"
+// @Router       /dashboard/top-clients [get]
+func (h *DashboardHandler) GetTopClients(c *gin.Context) {
+	requestingUserID, from, to, ok := dashboardRequest(c)
+	if !ok {
+		return
+	}
+	limit, ok := dashboardLimit(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.dashboardUseCase.GetTopClients(requestingUserID, from, to, limit)
+	if err != nil {
+		respondError(c, dashboardErrorStatus, "Erro ao calcular ranking de clientes: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapRankingToResponse(rows))
+}
+
+// GetWorkingHours godoc
+// @Summary      Busca o horário de funcionamento semanal
+// @Tags         dashboard
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  WorkingHoursDTO
+// @Router       /dashboard/working-hours [get]
+func (h *DashboardHandler) GetWorkingHours(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	hours, err := h.dashboardUseCase.GetWorkingHours(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar horário de funcionamento: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mapWorkingHoursToResponse(hours))
+}
+
+// SaveWorkingHours godoc
+// @Summary      Salva o horário de funcionamento semanal
+// @Description  Substitui todo o horário semanal; dias omitidos ficam fechados. Usado no cálculo da taxa de ocupação.
+// @Tags         dashboard
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        hours body SaveWorkingHoursRequest true "Horário Semanal"
+// @Success      200  {array}  WorkingHoursDTO
+// @Failure      400  {object} map[string]string "Horário inválido"
+// @Router       /dashboard/working-hours [put]
+func (h *DashboardHandler) SaveWorkingHours(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req SaveWorkingHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	hours := make([]*entity.WorkingHours, len(req.Days))
+	for i, day := range req.Days {
+		open, err := parseClockMinutes(day.OpensAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		closeMinute, err := parseClockMinutes(day.ClosesAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		hours[i] = &entity.WorkingHours{Weekday: time.Weekday(day.Weekday), OpenMinute: open, CloseMinute: closeMinute}
+	}
+
+	saved, err := h.dashboardUseCase.SaveWorkingHours(requestingUserID, hours)
+	if err != nil {
+		respondError(c, dashboardErrorStatus, "Falha ao salvar horário de funcionamento: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapWorkingHoursToResponse(saved))
+}
@@ -0,0 +1,303 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para E-mails ---
+
+// SaveEmailSettingsRequest define o JSON esperado para salvar as configurações de e-mail.
+type SaveEmailSettingsRequest struct {
+	Enabled      *bool  `json:"enabled"`      // Padrão: true
+	Locale       string `json:"locale"`       // pt-BR (padrão), en-US ou es-ES
+	SenderName   string `json:"senderName"`   // Vazio usa o nome do negócio
+	ReplyTo      string `json:"replyTo"`      // E-mail que recebe as respostas dos clientes
+	LogoURL      string `json:"logoUrl"`      // http(s)
+	PrimaryColor string `json:"primaryColor"` // #RRGGBB
+	FooterText   string `json:"footerText"`
+}
+
+// EmailSettingsResponse define o JSON retornado para as configurações de e-mail.
+type EmailSettingsResponse struct {
+	Enabled      bool   `json:"enabled"`
+	Locale       string `json:"locale"`
+	SenderName   string `json:"senderName,omitempty"`
+	ReplyTo      string `json:"replyTo,omitempty"`
+	LogoURL      string `json:"logoUrl,omitempty"`
+	PrimaryColor string `json:"primaryColor"`
+	FooterText   string `json:"footerText,omitempty"`
+}
+
+// EmailPreviewResponse define o JSON retornado na pré-visualização de um e-mail.
+type EmailPreviewResponse struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+// OutboundEmailResponse define o JSON retornado para um e-mail da fila de envio.
+type OutboundEmailResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	AppointmentID     *uuid.UUID `json:"appointmentId,omitempty"`
+	Event             string     `json:"event"`
+	Recipient         string     `json:"recipient"`
+	Subject           string     `json:"subject"`
+	Status            string     `json:"status"`
+	Attempts          int        `json:"attempts"`
+	NextAttemptAt     *time.Time `json:"nextAttemptAt,omitempty"` // Apenas para e-mails pendentes
+	LastError         string     `json:"lastError,omitempty"`
+	ProviderMessageID string     `json:"providerMessageId,omitempty"`
+	SentAt            *time.Time `json:"sentAt,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+// --- EmailHandler ---
+type EmailHandler struct {
+	emailUseCase *usecase.EmailNotificationUseCase
+}
+
+func NewEmailHandler(uc *usecase.EmailNotificationUseCase) *EmailHandler {
+	return &EmailHandler{emailUseCase: uc}
+}
+
+func mapEmailSettingsToResponse(s *entity.EmailSettings) EmailSettingsResponse {
+	return EmailSettingsResponse{
+		Enabled:      s.Enabled,
+		Locale:       s.Locale,
+		SenderName:   s.SenderName,
+		ReplyTo:      s.ReplyTo,
+		LogoURL:      s.LogoURL,
+		PrimaryColor: s.PrimaryColor,
+		FooterText:   s.FooterText,
+	}
+}
+
+func mapOutboundEmailToResponse(e *entity.OutboundEmail) OutboundEmailResponse {
+	response := OutboundEmailResponse{
+		ID:                e.ID,
+		AppointmentID:     e.AppointmentID,
+		Event:             string(e.Event),
+		Recipient:         e.Recipient,
+		Subject:           e.Subject,
+		Status:            string(e.Status),
+		Attempts:          e.Attempts,
+		LastError:         e.LastError,
+		ProviderMessageID: e.ProviderMessageID,
+		SentAt:            e.SentAt,
+		CreatedAt:         e.CreatedAt,
+	}
+	if e.Status == entity.EmailStatusPending {
+		next := e.NextAttemptAt
+		response.NextAttemptAt = &next
+	}
+	return response
+}
+
+// GetEmailSettings godoc
+// @Summary      Obtém as configurações dos e-mails aos clientes
+// @Description  Retorna idioma e identidade visual dos e-mails ou, se não configurados, os padrões.
+// @Tags         emails
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} EmailSettingsResponse
+// @Router       /emails/settings [get]
+func (h *EmailHandler) GetEmailSettings(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	settings, err := h.emailUseCase.GetSettings(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mapEmailSettingsToResponse(settings))
+}
+
+// SaveEmailSettings godoc
+// @Summary      Salva as configurações dos e-mails aos clientes
+// @Description  Define se os e-mails de criação, remarcação e cancelamento de agendamentos são enviados, o idioma e a identidade visual (logotipo, cor e rodapé).
+// @Tags         emails
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        settings body SaveEmailSettingsRequest true "Configurações de E-mail"
+// @Success      200  {object} EmailSettingsResponse
+// @Failure      400  {object} map[string]string "Configurações inválidas"
+// @Router       /emails/settings [put]
+func (h *EmailHandler) SaveEmailSettings(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req SaveEmailSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	settings, err := h.emailUseCase.SaveSettings(requestingUserID, usecase.SaveEmailSettingsInputDTO{
+		Enabled:      enabled,
+		Locale:       req.Locale,
+		SenderName:   req.SenderName,
+		ReplyTo:      req.ReplyTo,
+		LogoURL:      req.LogoURL,
+		PrimaryColor: req.PrimaryColor,
+		FooterText:   req.FooterText,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidEmailSettings) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao salvar configurações de e-mail: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mapEmailSettingsToResponse(settings))
+}
+
+// PreviewEmail godoc
+// @Summary      Pré-visualiza um e-mail aos clientes
+// @Description  Renderiza o e-mail do evento com as configurações atuais e um agendamento de exemplo. Com format=html devolve apenas o HTML, para abrir no navegador.
+// @Tags         emails
+// @Security     BearerAuth
+// @Produce      json,html
+// @Param        event  query string false "APPOINTMENT_CREATED (padrão), APPOINTMENT_RESCHEDULED ou APPOINTMENT_CANCELLED"
+// @Param        locale query string false "Idioma (padrão: o configurado)"
+// @Param        format query string false "json (padrão) ou html"
+// @Success      200  {object} EmailPreviewResponse
+// @Failure      400  {object} map[string]string "Parâmetros inválidos"
+// @Router       /emails/preview [get]
+func (h *EmailHandler) PreviewEmail(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	event := entity.EmailEvent(strings.ToUpper(c.DefaultQuery("event", string(entity.EmailEventAppointmentCreated))))
+	switch event {
+	case entity.EmailEventAppointmentCreated, entity.EmailEventAppointmentRescheduled, entity.EmailEventAppointmentCancelled:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "evento inválido"})
+		return
+	}
+
+	preview, err := h.emailUseCase.PreviewEmail(requestingUserID, event, c.Query("locale"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidEmailSettings) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao gerar pré-visualização: " + err.Error()})
+		return
+	}
+	if c.Query("format") == "html" {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(preview.HTML))
+		return
+	}
+	c.JSON(http.StatusOK, EmailPreviewResponse{Subject: preview.Subject, HTML: preview.HTML, Text: preview.Text})
+}
+
+// ListOutboundEmails godoc
+// @Summary      Lista os e-mails enviados aos clientes e o status de entrega
+// @Tags         emails
+// @Security     BearerAuth
+// @Produce      json
+// @Param        appointmentId query string false "Filtra pelo agendamento"
+// @Param        status        query string false "Filtra por status (PENDING, SENT, FAILED)"
+// @Success      200  {array}  OutboundEmailResponse
+// @Failure      400  {object} map[string]string "Filtro inválido"
+// @Router       /emails/outbox [get]
+func (h *EmailHandler) ListOutboundEmails(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var appointmentID *uuid.UUID
+	if s := c.Query("appointmentId"); s != "" {
+		parsed, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "appointmentId inválido"})
+			return
+		}
+		appointmentID = &parsed
+	}
+	status := entity.EmailStatus(strings.ToUpper(c.Query("status")))
+	switch status {
+	case "", entity.EmailStatusPending, entity.EmailStatusSent, entity.EmailStatusFailed:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status inválido"})
+		return
+	}
+
+	emails, err := h.emailUseCase.ListOutbox(requestingUserID, appointmentID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar e-mails: " + err.Error()})
+		return
+	}
+
+	responses := make([]OutboundEmailResponse, len(emails))
+	for i, e := range emails {
+		responses[i] = mapOutboundEmailToResponse(e)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// RetryOutboundEmail godoc
+// @Summary      Reenvia um e-mail que falhou
+// @Description  Recoloca na fila um e-mail com status FAILED, zerando as tentativas.
+// @Tags         emails
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do E-mail"
+// @Success      200  {object} OutboundEmailResponse
+// @Failure      404  {object} map[string]string "E-mail não encontrado"
+// @Failure      409  {object} map[string]string "E-mail não está com falha"
+// @Router       /emails/outbox/{id}/retry [post]
+func (h *EmailHandler) RetryOutboundEmail(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	emailID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de e-mail inválido"})
+		return
+	}
+
+	email, err := h.emailUseCase.RetryEmail(emailID, requestingUserID)
+	if err != nil {
+		switch {
+		case err.Error() == "e-mail não encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrEmailStatus):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao reenviar e-mail: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, mapOutboundEmailToResponse(email))
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondError responde o erro de um caso de uso com o status definido por statusOf.
+// Erros internos recebem o prefixo informado; os demais são repassados como estão.
+func respondError(c *gin.Context, statusOf func(error) int, prefix string, err error) {
+	status := statusOf(err)
+	if status == http.StatusInternalServerError {
+		c.JSON(status, gin.H{"error": prefix + err.Error()})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
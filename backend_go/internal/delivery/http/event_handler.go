@@ -0,0 +1,201 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Eventos de Domínio ---
+
+// DomainEventResponse define o JSON retornado para um evento de domínio.
+type DomainEventResponse struct {
+	ID           uuid.UUID       `json:"id"`
+	Type         string          `json:"type"`
+	AggregateID  uuid.UUID       `json:"aggregateId"`
+	Payload      json.RawMessage `json:"payload" swaggertype:"object"`
+	OccurredAt   time.Time       `json:"occurredAt"`
+	Status       string          `json:"status"`
+	DispatchedAt *time.Time      `json:"dispatchedAt,omitempty"`
+}
+
+// EventDeliveryResponse define o JSON retornado para a entrega de um evento a um assinante.
+type EventDeliveryResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	EventID       uuid.UUID  `json:"eventId"`
+	EventType     string     `json:"eventType"`
+	Subscriber    string     `json:"subscriber"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"` // Apenas para entregas pendentes
+	LastError     string     `json:"lastError,omitempty"`
+	DeliveredAt   *time.Time `json:"deliveredAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// --- EventHandler ---
+type EventHandler struct {
+	dispatcher *usecase.EventDispatcher
+}
+
+func NewEventHandler(dispatcher *usecase.EventDispatcher) *EventHandler {
+	return &EventHandler{dispatcher: dispatcher}
+}
+
+func mapDomainEventToResponse(e *entity.DomainEvent) DomainEventResponse {
+	return DomainEventResponse{
+		ID:           e.ID,
+		Type:         string(e.Type),
+		AggregateID:  e.AggregateID,
+		Payload:      json.RawMessage(e.Payload),
+		OccurredAt:   e.OccurredAt,
+		Status:       string(e.Status),
+		DispatchedAt: e.DispatchedAt,
+	}
+}
+
+func mapEventDeliveryToResponse(d *entity.EventDelivery) EventDeliveryResponse {
+	resp := EventDeliveryResponse{
+		ID:          d.ID,
+		EventID:     d.EventID,
+		EventType:   string(d.EventType),
+		Subscriber:  d.Subscriber,
+		Status:      string(d.Status),
+		Attempts:    d.Attempts,
+		LastError:   d.LastError,
+		DeliveredAt: d.DeliveredAt,
+		CreatedAt:   d.CreatedAt,
+	}
+	if d.Status == entity.EventDeliveryStatusPending {
+		next := d.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+	return resp
+}
+
+// ListDomainEvents godoc
+// @Summary      Lista os eventos de domínio
+// @Description  Lista os eventos registrados na outbox para o usuário autenticado, dos mais recentes para os mais antigos.
+// @Tags         events
+// @Security     BearerAuth
+// @Produce      json
+// @Param        type  query string false "Filtra pelo tipo (ex: appointment.created)"
+// @Param        limit query int    false "Número máximo de eventos (padrão 50, máximo 500)"
+// @Success      200  {array}  DomainEventResponse
+// @Failure      400  {object} map[string]string "Filtro inválido"
+// @Router       /events [get]
+func (h *EventHandler) ListDomainEvents(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	limit := 0
+	if s := c.Query("limit"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil || parsed <= 0 || parsed > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit deve ser um número entre 1 e 500"})
+			return
+		}
+		limit = parsed
+	}
+
+	events, err := h.dispatcher.ListEvents(requestingUserID, entity.DomainEventType(strings.ToLower(c.Query("type"))), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar eventos: " + err.Error()})
+		return
+	}
+
+	responses := make([]DomainEventResponse, len(events))
+	for i, e := range events {
+		responses[i] = mapDomainEventToResponse(e)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// ListEventDeliveries godoc
+// @Summary      Lista as entregas de eventos
+// @Description  Lista as entregas dos eventos do usuário autenticado aos assinantes internos, com tentativas e último erro.
+// @Tags         events
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "Filtra por status (PENDING, DELIVERED, DEAD)"
+// @Success      200  {array}  EventDeliveryResponse
+// @Failure      400  {object} map[string]string "Filtro inválido"
+// @Router       /events/deliveries [get]
+func (h *EventHandler) ListEventDeliveries(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	status := entity.EventDeliveryStatus(strings.ToUpper(c.Query("status")))
+	switch status {
+	case "", entity.EventDeliveryStatusPending, entity.EventDeliveryStatusDelivered, entity.EventDeliveryStatusDead:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status inválido"})
+		return
+	}
+
+	deliveries, err := h.dispatcher.ListDeliveries(requestingUserID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar entregas: " + err.Error()})
+		return
+	}
+
+	responses := make([]EventDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		responses[i] = mapEventDeliveryToResponse(d)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// RetryEventDelivery godoc
+// @Summary      Reenvia uma entrega da dead letter
+// @Description  Recoloca na fila uma entrega com status DEAD, zerando as tentativas.
+// @Tags         events
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Entrega"
+// @Success      200  {object} EventDeliveryResponse
+// @Failure      404  {object} map[string]string "Entrega não encontrada"
+// @Failure      409  {object} map[string]string "Entrega não está na dead letter"
+// @Router       /events/deliveries/{id}/retry [post]
+func (h *EventHandler) RetryEventDelivery(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de entrega inválido"})
+		return
+	}
+
+	delivery, err := h.dispatcher.RetryDelivery(deliveryID, requestingUserID)
+	if err != nil {
+		switch {
+		case err.Error() == "entrega não encontrada":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrEventDeliveryStatus):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao reenviar entrega: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, mapEventDeliveryToResponse(delivery))
+}
@@ -0,0 +1,901 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Finance ---
+
+// CreateFinancialEntryRequest define o JSON esperado para criar um lançamento.
+type CreateFinancialEntryRequest struct {
+	Type          string     `json:"type" binding:"required,oneof=INCOME EXPENSE"`
+	Amount        float64    `json:"amount" binding:"required,gt=0"`
+	Description   string     `json:"description"`
+	Date          time.Time  `json:"date"` // RFC3339; se omitido, usa a data atual
+	CategoryID    *uuid.UUID `json:"categoryId"`
+	AppointmentID *uuid.UUID `json:"appointmentId"`
+	RevenueKind   string     `json:"revenueKind" binding:"omitempty,oneof=SERVICES COMMERCE_INDUSTRY"` // Apenas para entradas
+	Invoiced      bool       `json:"invoiced"`
+}
+
+// UpdateFinancialEntryRequest define o JSON para atualizar um lançamento.
+type UpdateFinancialEntryRequest struct {
+	Amount      *float64   `json:"amount"`
+	Description *string    `json:"description"`
+	Date        *time.Time `json:"date"`
+	CategoryID  *string    `json:"categoryId"` // "" remove a categoria
+	RevenueKind *string    `json:"revenueKind" binding:"omitempty,oneof=SERVICES COMMERCE_INDUSTRY"`
+	Invoiced    *bool      `json:"invoiced"`
+}
+
+// FinancialEntryResponse define o JSON retornado para um lançamento.
+type FinancialEntryResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	Type               string     `json:"type"`
+	Amount             float64    `json:"amount"`
+	Description        string     `json:"description"`
+	Date               time.Time  `json:"date"`
+	CategoryID         *uuid.UUID `json:"categoryId,omitempty"`
+	AppointmentID      *uuid.UUID `json:"appointmentId,omitempty"`
+	PaymentID          *uuid.UUID `json:"paymentId,omitempty"`
+	RecurringExpenseID *uuid.UUID `json:"recurringExpenseId,omitempty"`
+	GiftCardID         *uuid.UUID `json:"giftCardId,omitempty"`
+	RevenueKind        string     `json:"revenueKind,omitempty"`
+	Invoiced           bool       `json:"invoiced"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+}
+
+// ExpenseCategoryRequest define o JSON para criar ou atualizar uma categoria de despesa.
+type ExpenseCategoryRequest struct {
+	Name          *string  `json:"name"`
+	MonthlyBudget *float64 `json:"monthlyBudget"`
+}
+
+// ExpenseCategoryResponse define o JSON retornado para uma categoria de despesa.
+type ExpenseCategoryResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	MonthlyBudget float64   `json:"monthlyBudget"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// CreateRecurringExpenseRequest define o JSON para cadastrar uma despesa recorrente.
+type CreateRecurringExpenseRequest struct {
+	CategoryID  *uuid.UUID `json:"categoryId"`
+	Description string     `json:"description" binding:"required"`
+	Amount      float64    `json:"amount" binding:"required,gt=0"`
+	DayOfMonth  int        `json:"dayOfMonth" binding:"required,min=1,max=28"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     *time.Time `json:"endDate"`
+}
+
+// UpdateRecurringExpenseRequest define o JSON para atualizar uma despesa recorrente.
+type UpdateRecurringExpenseRequest struct {
+	CategoryID  *uuid.UUID `json:"categoryId"`
+	Description *string    `json:"description"`
+	Amount      *float64   `json:"amount"`
+	DayOfMonth  *int       `json:"dayOfMonth"`
+	EndDate     *time.Time `json:"endDate"`
+	Active      *bool      `json:"active"`
+}
+
+// RecurringExpenseResponse define o JSON retornado para uma despesa recorrente.
+type RecurringExpenseResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	CategoryID  *uuid.UUID `json:"categoryId,omitempty"`
+	Description string     `json:"description"`
+	Amount      float64    `json:"amount"`
+	DayOfMonth  int        `json:"dayOfMonth"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	NextDueDate time.Time  `json:"nextDueDate"`
+	Active      bool       `json:"active"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// BudgetStatusResponse define o JSON do consumo do orçamento de uma categoria.
+type BudgetStatusResponse struct {
+	CategoryID   uuid.UUID `json:"categoryId"`
+	CategoryName string    `json:"categoryName"`
+	Month        string    `json:"month"`
+	Budget       float64   `json:"budget"`
+	Spent        float64   `json:"spent"`
+	Remaining    float64   `json:"remaining"`
+	Exceeded     bool      `json:"exceeded"`
+}
+
+// BudgetAlertResponse define o JSON de um alerta de orçamento excedido.
+type BudgetAlertResponse struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID uuid.UUID `json:"categoryId"`
+	Month      string    `json:"month"`
+	Budget     float64   `json:"budget"`
+	Spent      float64   `json:"spent"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// IncomeForecastItemResponse define o JSON de uma receita prevista.
+type IncomeForecastItemResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	QuoteID       *uuid.UUID `json:"quoteId,omitempty"`
+	AppointmentID *uuid.UUID `json:"appointmentId,omitempty"`
+	Description   string     `json:"description"`
+	Amount        float64    `json:"amount"`
+	ExpectedDate  time.Time  `json:"expectedDate"`
+}
+
+// IncomeForecastResponse define o JSON da previsão de receitas de um período.
+type IncomeForecastResponse struct {
+	From      string                       `json:"from"`
+	To        string                       `json:"to"`
+	Total     float64                      `json:"total"`
+	Forecasts []IncomeForecastItemResponse `json:"forecasts"`
+}
+
+// --- FinanceHandler ---
+type FinanceHandler struct {
+	financeUseCase *usecase.FinanceUseCase
+}
+
+func NewFinanceHandler(uc *usecase.FinanceUseCase) *FinanceHandler {
+	return &FinanceHandler{financeUseCase: uc}
+}
+
+func mapFinancialEntryToResponse(e *entity.FinancialEntry) FinancialEntryResponse {
+	return FinancialEntryResponse{
+		ID:                 e.ID,
+		Type:               string(e.Type),
+		Amount:             e.Amount,
+		Description:        e.Description,
+		Date:               e.Date,
+		CategoryID:         e.CategoryID,
+		AppointmentID:      e.AppointmentID,
+		PaymentID:          e.PaymentID,
+		RecurringExpenseID: e.RecurringExpenseID,
+		GiftCardID:         e.GiftCardID,
+		RevenueKind:        string(e.RevenueKind),
+		Invoiced:           e.Invoiced,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+func mapExpenseCategoryToResponse(c *entity.ExpenseCategory) ExpenseCategoryResponse {
+	return ExpenseCategoryResponse{
+		ID:            c.ID,
+		Name:          c.Name,
+		MonthlyBudget: c.MonthlyBudget,
+		CreatedAt:     c.CreatedAt,
+		UpdatedAt:     c.UpdatedAt,
+	}
+}
+
+func mapRecurringExpenseToResponse(e *entity.RecurringExpense) RecurringExpenseResponse {
+	return RecurringExpenseResponse{
+		ID:          e.ID,
+		CategoryID:  e.CategoryID,
+		Description: e.Description,
+		Amount:      e.Amount,
+		DayOfMonth:  e.DayOfMonth,
+		StartDate:   e.StartDate,
+		EndDate:     e.EndDate,
+		NextDueDate: e.NextDueDate,
+		Active:      e.Active,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// isFinanceNotFound indica se o erro do caso de uso corresponde a um recurso inexistente.
+func isFinanceNotFound(err error) bool {
+	switch err.Error() {
+	case "lançamento não encontrado", "categoria não encontrada", "despesa recorrente não encontrada":
+		return true
+	}
+	return false
+}
+
+// CreateEntry godoc
+// @Summary      Cria um lançamento no livro-caixa
+// @Tags         finance
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        entry body CreateFinancialEntryRequest true "Dados do Lançamento"
+// @Success      201  {object} FinancialEntryResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Failure      500  {object} map[string]string "Erro interno"
+// @Router       /finance/entries [post]
+func (h *FinanceHandler) CreateEntry(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateFinancialEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	entry, err := h.financeUseCase.CreateEntry(usecase.CreateFinancialEntryInputDTO{
+		UserID:        requestingUserID,
+		Type:          entity.FinancialEntryType(req.Type),
+		Amount:        req.Amount,
+		Description:   req.Description,
+		Date:          req.Date,
+		CategoryID:    req.CategoryID,
+		AppointmentID: req.AppointmentID,
+		RevenueKind:   entity.RevenueKind(req.RevenueKind),
+		Invoiced:      req.Invoiced,
+	})
+	if err != nil {
+		if isFinanceNotFound(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao criar lançamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapFinancialEntryToResponse(entry))
+}
+
+// ListEntries godoc
+// @Summary      Lista os lançamentos do usuário
+// @Tags         finance
+// @Security     BearerAuth
+// @Produce      json
+// @Param        type query string false "INCOME ou EXPENSE"
+// @Param        categoryId query string false "ID da categoria (UUID)"
+// @Param        from query string false "Data inicial inclusiva (RFC3339)"
+// @Param        to query string false "Data final exclusiva (RFC3339)"
+// @Success      200  {array}  FinancialEntryResponse
+// @Failure      400  {object} map[string]string "Filtro inválido"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Failure      500  {object} map[string]string "Erro interno"
+// @Router       /finance/entries [get]
+func (h *FinanceHandler) ListEntries(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var filter repository.FinancialEntryFilter
+	if t := c.Query("type"); t != "" {
+		entryType := entity.FinancialEntryType(t)
+		if entryType != entity.FinancialEntryTypeIncome && entryType != entity.FinancialEntryTypeExpense {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Tipo inválido, use INCOME ou EXPENSE"})
+			return
+		}
+		filter.Type = &entryType
+	}
+	if id := c.Query("categoryId"); id != "" {
+		categoryID, err := uuid.Parse(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "categoryId inválido"})
+			return
+		}
+		filter.CategoryID = &categoryID
+	}
+	if c.Query("from") != "" {
+		from, err := time.Parse(time.RFC3339, c.Query("from"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de from inválido, use RFC3339"})
+			return
+		}
+		filter.From = &from
+	}
+	if c.Query("to") != "" {
+		to, err := time.Parse(time.RFC3339, c.Query("to"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de to inválido, use RFC3339"})
+			return
+		}
+		filter.To = &to
+	}
+
+	entries, err := h.financeUseCase.ListEntries(requestingUserID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar lançamentos: " + err.Error()})
+		return
+	}
+
+	responses := make([]FinancialEntryResponse, len(entries))
+	for i, e := range entries {
+		responses[i] = mapFinancialEntryToResponse(e)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetEntryByID godoc
+// @Summary      Busca um lançamento pelo ID
+// @Tags         finance
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Lançamento (UUID)"
+// @Success      200  {object} FinancialEntryResponse
+// @Failure      400  {object} map[string]string "ID inválido"
+// @Failure      404  {object} map[string]string "Lançamento não encontrado"
+// @Router       /finance/entries/{id} [get]
+func (h *FinanceHandler) GetEntryByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do lançamento inválido"})
+		return
+	}
+
+	entry, err := h.financeUseCase.GetEntryByID(entryID, requestingUserID)
+	if err != nil {
+		if isFinanceNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar lançamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapFinancialEntryToResponse(entry))
+}
+
+// UpdateEntry godoc
+// @Summary      Atualiza um lançamento
+// @Tags         finance
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Lançamento (UUID)"
+// @Param        entry body UpdateFinancialEntryRequest true "Dados para Atualização"
+// @Success      200  {object} FinancialEntryResponse
+// @Failure      400  {object} map[string]string "ID ou dados inválidos"
+// @Failure      404  {object} map[string]string "Lançamento não encontrado"
+// @Router       /finance/entries/{id} [put]
+func (h *FinanceHandler) UpdateEntry(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do lançamento inválido"})
+		return
+	}
+
+	var req UpdateFinancialEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	input := usecase.UpdateFinancialEntryInputDTO{
+		Amount:      req.Amount,
+		Description: req.Description,
+		Date:        req.Date,
+		Invoiced:    req.Invoiced,
+	}
+	if req.RevenueKind != nil {
+		revenueKind := entity.RevenueKind(*req.RevenueKind)
+		input.RevenueKind = &revenueKind
+	}
+	if req.CategoryID != nil {
+		if *req.CategoryID == "" {
+			input.ClearCategory = true
+		} else {
+			categoryID, err := uuid.Parse(*req.CategoryID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "categoryId inválido, deve ser um UUID ou vazio"})
+				return
+			}
+			input.CategoryID = &categoryID
+		}
+	}
+
+	entry, err := h.financeUseCase.UpdateEntry(entryID, requestingUserID, input)
+	if err != nil {
+		if err.Error() == "lançamento não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar lançamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapFinancialEntryToResponse(entry))
+}
+
+// DeleteEntry godoc
+// @Summary      Exclui um lançamento
+// @Tags         finance
+// @Security     BearerAuth
+// @Param        id path string true "ID do Lançamento (UUID)"
+// @Success      204  {string} string "No Content"
+// @Failure      400  {object} map[string]string "ID inválido"
+// @Failure      404  {object} map[string]string "Lançamento não encontrado"
+// @Router       /finance/entries/{id} [delete]
+func (h *FinanceHandler) DeleteEntry(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do lançamento inválido"})
+		return
+	}
+
+	if err := h.financeUseCase.DeleteEntry(entryID, requestingUserID); err != nil {
+		if isFinanceNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir lançamento: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateCategory godoc
+// @Summary      Cria uma categoria de despesa
+// @Tags         finance
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        category body ExpenseCategoryRequest true "Nome e orçamento mensal (opcional)"
+// @Success      201  {object} ExpenseCategoryResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Router       /finance/categories [post]
+func (h *FinanceHandler) CreateCategory(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req ExpenseCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Name == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição: nome é obrigatório"})
+		return
+	}
+	var budget float64
+	if req.MonthlyBudget != nil {
+		budget = *req.MonthlyBudget
+	}
+
+	category, err := h.financeUseCase.CreateCategory(requestingUserID, *req.Name, budget)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Falha ao criar categoria: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapExpenseCategoryToResponse(category))
+}
+
+// ListCategories godoc
+// @Summary      Lista as categorias de despesa do usuário
+// @Tags         finance
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  ExpenseCategoryResponse
+// @Router       /finance/categories [get]
+func (h *FinanceHandler) ListCategories(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	categories, err := h.financeUseCase.ListCategories(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar categorias: " + err.Error()})
+		return
+	}
+
+	responses := make([]ExpenseCategoryResponse, len(categories))
+	for i, category := range categories {
+		responses[i] = mapExpenseCategoryToResponse(category)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// UpdateCategory godoc
+// @Summary      Atualiza uma categoria de despesa
+// @Tags         finance
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID da Categoria (UUID)"
+// @Param        category body ExpenseCategoryRequest true "Dados para Atualização"
+// @Success      200  {object} ExpenseCategoryResponse
+// @Failure      404  {object} map[string]string "Categoria não encontrada"
+// @Router       /finance/categories/{id} [put]
+func (h *FinanceHandler) UpdateCategory(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da categoria inválido"})
+		return
+	}
+
+	var req ExpenseCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	category, err := h.financeUseCase.UpdateCategory(categoryID, requestingUserID, req.Name, req.MonthlyBudget)
+	if err != nil {
+		if isFinanceNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar categoria: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapExpenseCategoryToResponse(category))
+}
+
+// DeleteCategory godoc
+// @Summary      Exclui uma categoria de despesa
+// @Tags         finance
+// @Security     BearerAuth
+// @Param        id path string true "ID da Categoria (UUID)"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Categoria não encontrada"
+// @Router       /finance/categories/{id} [delete]
+func (h *FinanceHandler) DeleteCategory(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da categoria inválido"})
+		return
+	}
+
+	if err := h.financeUseCase.DeleteCategory(categoryID, requestingUserID); err != nil {
+		if isFinanceNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir categoria: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateRecurringExpense godoc
+// @Summary      Cadastra uma despesa recorrente mensal
+// @Description  A cada vencimento um lançamento de saída é gerado automaticamente.
+// @Tags         finance
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        expense body CreateRecurringExpenseRequest true "Dados da Despesa Recorrente"
+// @Success      201  {object} RecurringExpenseResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Router       /finance/recurring-expenses [post]
+func (h *FinanceHandler) CreateRecurringExpense(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateRecurringExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	expense, err := h.financeUseCase.CreateRecurringExpense(usecase.CreateRecurringExpenseInputDTO{
+		UserID:      requestingUserID,
+		CategoryID:  req.CategoryID,
+		Description: req.Description,
+		Amount:      req.Amount,
+		DayOfMonth:  req.DayOfMonth,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Falha ao criar despesa recorrente: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapRecurringExpenseToResponse(expense))
+}
+
+// ListRecurringExpenses godoc
+// @Summary      Lista as despesas recorrentes do usuário
+// @Tags         finance
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  RecurringExpenseResponse
+// @Router       /finance/recurring-expenses [get]
+func (h *FinanceHandler) ListRecurringExpenses(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	expenses, err := h.financeUseCase.ListRecurringExpenses(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar despesas recorrentes: " + err.Error()})
+		return
+	}
+
+	responses := make([]RecurringExpenseResponse, len(expenses))
+	for i, e := range expenses {
+		responses[i] = mapRecurringExpenseToResponse(e)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// UpdateRecurringExpense godoc
+// @Summary      Atualiza uma despesa recorrente
+// @Description  As alterações valem a partir do próximo vencimento.
+// @Tags         finance
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID da Despesa Recorrente (UUID)"
+// @Param        expense body UpdateRecurringExpenseRequest true "Dados para Atualização"
+// @Success      200  {object} RecurringExpenseResponse
+// @Failure      404  {object} map[string]string "Despesa recorrente não encontrada"
+// @Router       /finance/recurring-expenses/{id} [put]
+func (h *FinanceHandler) UpdateRecurringExpense(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	expenseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da despesa recorrente inválido"})
+		return
+	}
+
+	var req UpdateRecurringExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	expense, err := h.financeUseCase.UpdateRecurringExpense(expenseID, requestingUserID, usecase.UpdateRecurringExpenseInputDTO{
+		CategoryID:  req.CategoryID,
+		Description: req.Description,
+		Amount:      req.Amount,
+		DayOfMonth:  req.DayOfMonth,
+		EndDate:     req.EndDate,
+		Active:      req.Active,
+	})
+	if err != nil {
+		if err.Error() == "despesa recorrente não encontrada" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar despesa recorrente: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapRecurringExpenseToResponse(expense))
+}
+
+// DeleteRecurringExpense godoc
+// @Summary      Exclui uma despesa recorrente
+// @Description  Os lançamentos já gerados são mantidos.
+// @Tags         finance
+// @Security     BearerAuth
+// @Param        id path string true "ID da Despesa Recorrente (UUID)"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Despesa recorrente não encontrada"
+// @Router       /finance/recurring-expenses/{id} [delete]
+func (h *FinanceHandler) DeleteRecurringExpense(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	expenseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da despesa recorrente inválido"})
+		return
+	}
+
+	if err := h.financeUseCase.DeleteRecurringExpense(expenseID, requestingUserID); err != nil {
+		if isFinanceNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir despesa recorrente: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetBudgetStatus godoc
+// @Summary      Consumo dos orçamentos por categoria no mês
+// @Tags         finance
+// @Security     BearerAuth
+// @Produce      json
+// @Param        month query string false "Mês no formato AAAA-MM (padrão: mês atual)"
+// @Success      200  {array}  BudgetStatusResponse
+// @Failure      400  {object} map[string]string "Mês inválido"
+// @Router       /finance/budgets [get]
+func (h *FinanceHandler) GetBudgetStatus(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	month := time.Now()
+	if m := c.Query("month"); m != "" {
+		parsed, err := time.ParseInLocation("2006-01", m, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de month inválido, use AAAA-MM"})
+			return
+		}
+		month = parsed
+	}
+
+	statuses, err := h.financeUseCase.GetBudgetStatus(requestingUserID, month)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular orçamentos: " + err.Error()})
+		return
+	}
+
+	responses := make([]BudgetStatusResponse, len(statuses))
+	for i, s := range statuses {
+		responses[i] = BudgetStatusResponse{
+			CategoryID:   s.Category.ID,
+			CategoryName: s.Category.Name,
+			Month:        s.Month,
+			Budget:       s.Budget,
+			Spent:        s.Spent,
+			Remaining:    s.Remaining,
+			Exceeded:     s.Exceeded,
+		}
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// ListBudgetAlerts godoc
+// @Summary      Lista os alertas de orçamento excedido
+// @Tags         finance
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  BudgetAlertResponse
+// @Router       /finance/budget-alerts [get]
+func (h *FinanceHandler) ListBudgetAlerts(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	alerts, err := h.financeUseCase.ListBudgetAlerts(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar alertas: " + err.Error()})
+		return
+	}
+
+	responses := make([]BudgetAlertResponse, len(alerts))
+	for i, a := range alerts {
+		responses[i] = BudgetAlertResponse{
+			ID:         a.ID,
+			CategoryID: a.CategoryID,
+			Month:      a.Month,
+			Budget:     a.Budget,
+			Spent:      a.Spent,
+			CreatedAt:  a.CreatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetIncomeForecast godoc
+// @Summary      Previsão de receitas
+// @Description  Soma as receitas previstas (ex: agendamentos gerados de orçamentos aceitos) que ainda não foram concluídas nem canceladas.
+// @Tags         finance
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string false "Data inicial AAAA-MM-DD (padrão: hoje)"
+// @Param        to   query string false "Data final AAAA-MM-DD, inclusiva (padrão: 30 dias após a inicial)"
+// @Success      200  {object} IncomeForecastResponse
+// @Failure      400  {object} map[string]string "Período inválido"
+// @Router       /finance/forecast [get]
+func (h *FinanceHandler) GetIncomeForecast(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromStr, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de from inválido, use AAAA-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 0, 30)
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toStr, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de to inválido, use AAAA-MM-DD"})
+			return
+		}
+		to = parsed
+	}
+
+	// A data final é inclusiva: a busca vai até o início do dia seguinte.
+	summary, err := h.financeUseCase.GetIncomeForecast(requestingUserID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		if err.Error() == "data final deve ser após a data inicial" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular previsão de receitas: " + err.Error()})
+		return
+	}
+
+	response := IncomeForecastResponse{
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Total:     summary.Total,
+		Forecasts: make([]IncomeForecastItemResponse, len(summary.Forecasts)),
+	}
+	for i, f := range summary.Forecasts {
+		response.Forecasts[i] = IncomeForecastItemResponse{
+			ID:            f.ID,
+			QuoteID:       f.QuoteID,
+			AppointmentID: f.AppointmentID,
+			Description:   f.Description,
+			Amount:        f.Amount,
+			ExpectedDate:  f.ExpectedDate,
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
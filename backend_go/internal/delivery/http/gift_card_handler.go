@@ -0,0 +1,312 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para GiftCard ---
+
+// IssueGiftCardRequest define o JSON esperado para emitir um vale-presente.
+type IssueGiftCardRequest struct {
+	Amount         float64    `json:"amount" binding:"required,gt=0"`
+	PurchaserName  string     `json:"purchaserName"`
+	RecipientName  string     `json:"recipientName"`
+	RecipientEmail string     `json:"recipientEmail" binding:"omitempty,email"`
+	Message        string     `json:"message"`
+	ExpiresAt      *time.Time `json:"expiresAt"` // Omitido significa sem validade
+}
+
+// GiftCardResponse define o JSON retornado para um vale-presente.
+type GiftCardResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Code           string     `json:"code"`
+	InitialAmount  float64    `json:"initialAmount"`
+	Balance        float64    `json:"balance"`
+	PurchaserName  string     `json:"purchaserName,omitempty"`
+	RecipientName  string     `json:"recipientName,omitempty"`
+	RecipientEmail string     `json:"recipientEmail,omitempty"`
+	Message        string     `json:"message,omitempty"`
+	Status         string     `json:"status"`
+	Expired        bool       `json:"expired"`
+	IssuedAt       time.Time  `json:"issuedAt"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// GiftCardTransactionResponse define o JSON retornado para uma movimentação do vale.
+type GiftCardTransactionResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	Type         string     `json:"type"`
+	Amount       float64    `json:"amount"`
+	BalanceAfter float64    `json:"balanceAfter"`
+	PaymentID    *uuid.UUID `json:"paymentId,omitempty"`
+	Description  string     `json:"description"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// --- GiftCardHandler ---
+type GiftCardHandler struct {
+	giftCardUseCase *usecase.GiftCardUseCase
+}
+
+func NewGiftCardHandler(uc *usecase.GiftCardUseCase) *GiftCardHandler {
+	return &GiftCardHandler{giftCardUseCase: uc}
+}
+
+func mapGiftCardToResponse(g *entity.GiftCard) GiftCardResponse {
+	return GiftCardResponse{
+		ID:             g.ID,
+		Code:           g.Code,
+		InitialAmount:  g.InitialAmount,
+		Balance:        g.Balance,
+		PurchaserName:  g.PurchaserName,
+		RecipientName:  g.RecipientName,
+		RecipientEmail: g.RecipientEmail,
+		Message:        g.Message,
+		Status:         string(g.Status),
+		Expired:        g.IsExpired(time.Now()),
+		IssuedAt:       g.IssuedAt,
+		ExpiresAt:      g.ExpiresAt,
+		CreatedAt:      g.CreatedAt,
+		UpdatedAt:      g.UpdatedAt,
+	}
+}
+
+// IssueGiftCard godoc
+// @Summary      Emite um vale-presente
+// @Description  Gera um código único e lança a venda no livro-caixa. O valor só conta como faturamento quando o vale é usado.
+// @Tags         gift-cards
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        giftCard body IssueGiftCardRequest true "Dados do Vale-Presente"
+// @Success      201  {object} GiftCardResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /gift-cards [post]
+func (h *GiftCardHandler) IssueGiftCard(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req IssueGiftCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	giftCard, err := h.giftCardUseCase.IssueGiftCard(usecase.IssueGiftCardInputDTO{
+		UserID:         requestingUserID,
+		Amount:         req.Amount,
+		PurchaserName:  req.PurchaserName,
+		RecipientName:  req.RecipientName,
+		RecipientEmail: req.RecipientEmail,
+		Message:        req.Message,
+		ExpiresAt:      req.ExpiresAt,
+	})
+	if err != nil {
+		if err.Error() == "validade do vale-presente deve ser uma data futura" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao emitir vale-presente: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapGiftCardToResponse(giftCard))
+}
+
+// ListGiftCards godoc
+// @Summary      Lista os vales-presente emitidos
+// @Tags         gift-cards
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  GiftCardResponse
+// @Router       /gift-cards [get]
+func (h *GiftCardHandler) ListGiftCards(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	giftCards, err := h.giftCardUseCase.ListGiftCards(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar vales-presente: " + err.Error()})
+		return
+	}
+
+	responses := make([]GiftCardResponse, len(giftCards))
+	for i, g := range giftCards {
+		responses[i] = mapGiftCardToResponse(g)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// LookupGiftCard godoc
+// @Summary      Consulta um vale-presente pelo código
+// @Description  Usado no balcão para conferir o saldo antes do pagamento.
+// @Tags         gift-cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        code query string true "Código do Vale"
+// @Success      200  {object} GiftCardResponse
+// @Failure      404  {object} map[string]string "Vale-presente não encontrado"
+// @Router       /gift-cards/lookup [get]
+func (h *GiftCardHandler) LookupGiftCard(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro code é obrigatório"})
+		return
+	}
+
+	giftCard, err := h.giftCardUseCase.GetGiftCardByCode(code, requestingUserID)
+	if err != nil {
+		if err.Error() == "vale-presente não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar vale-presente: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapGiftCardToResponse(giftCard))
+}
+
+// GetGiftCardByID godoc
+// @Summary      Busca um vale-presente
+// @Tags         gift-cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Vale-Presente (UUID)"
+// @Success      200  {object} GiftCardResponse
+// @Failure      404  {object} map[string]string "Vale-presente não encontrado"
+// @Router       /gift-cards/{id} [get]
+func (h *GiftCardHandler) GetGiftCardByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	giftCardID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do vale-presente inválido"})
+		return
+	}
+
+	giftCard, err := h.giftCardUseCase.GetGiftCardByID(giftCardID, requestingUserID)
+	if err != nil {
+		if err.Error() == "vale-presente não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar vale-presente: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapGiftCardToResponse(giftCard))
+}
+
+// ListGiftCardTransactions godoc
+// @Summary      Lista o extrato de um vale-presente
+// @Tags         gift-cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Vale-Presente (UUID)"
+// @Success      200  {array}  GiftCardTransactionResponse
+// @Failure      404  {object} map[string]string "Vale-presente não encontrado"
+// @Router       /gift-cards/{id}/transactions [get]
+func (h *GiftCardHandler) ListGiftCardTransactions(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	giftCardID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do vale-presente inválido"})
+		return
+	}
+
+	transactions, err := h.giftCardUseCase.ListTransactions(giftCardID, requestingUserID)
+	if err != nil {
+		if err.Error() == "vale-presente não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar extrato do vale-presente: " + err.Error()})
+		return
+	}
+
+	responses := make([]GiftCardTransactionResponse, len(transactions))
+	for i, t := range transactions {
+		responses[i] = GiftCardTransactionResponse{
+			ID:           t.ID,
+			Type:         string(t.Type),
+			Amount:       t.Amount,
+			BalanceAfter: t.BalanceAfter,
+			PaymentID:    t.PaymentID,
+			Description:  t.Description,
+			CreatedAt:    t.CreatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// CancelGiftCard godoc
+// @Summary      Cancela um vale-presente
+// @Description  Zera o saldo restante. A devolução ao comprador deve ser lançada à parte no livro-caixa.
+// @Tags         gift-cards
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Vale-Presente (UUID)"
+// @Success      200  {object} GiftCardResponse
+// @Failure      404  {object} map[string]string "Vale-presente não encontrado"
+// @Failure      409  {object} map[string]string "Vale-presente já cancelado"
+// @Router       /gift-cards/{id}/cancel [patch]
+func (h *GiftCardHandler) CancelGiftCard(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	giftCardID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do vale-presente inválido"})
+		return
+	}
+
+	giftCard, err := h.giftCardUseCase.CancelGiftCard(giftCardID, requestingUserID)
+	if err != nil {
+		switch err.Error() {
+		case "vale-presente não encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "vale-presente já está cancelado":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao cancelar vale-presente: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, mapGiftCardToResponse(giftCard))
+}
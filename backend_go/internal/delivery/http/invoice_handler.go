@@ -0,0 +1,442 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Invoice ---
+
+// InvoiceSettingsRequest define o JSON esperado para salvar os dados fiscais.
+type InvoiceSettingsRequest struct {
+	Document              string  `json:"document" binding:"required"`
+	MunicipalRegistration string  `json:"municipalRegistration"`
+	MunicipalityCode      string  `json:"municipalityCode" binding:"required"`
+	ServiceItemCode       string  `json:"serviceItemCode" binding:"required"`
+	ISSRate               float64 `json:"issRate" binding:"gte=0,lte=5"`
+	RPSSeries             string  `json:"rpsSeries"`
+	NextRPSNumber         int     `json:"nextRpsNumber" binding:"gte=0"`
+	AutoIssue             bool    `json:"autoIssue"`
+}
+
+// InvoiceSettingsResponse define o JSON retornado para os dados fiscais.
+type InvoiceSettingsResponse struct {
+	Document              string    `json:"document"`
+	MunicipalRegistration string    `json:"municipalRegistration,omitempty"`
+	MunicipalityCode      string    `json:"municipalityCode"`
+	ServiceItemCode       string    `json:"serviceItemCode"`
+	ISSRate               float64   `json:"issRate"`
+	RPSSeries             string    `json:"rpsSeries"`
+	NextRPSNumber         int       `json:"nextRpsNumber"`
+	AutoIssue             bool      `json:"autoIssue"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// IssueInvoiceRequest define o JSON esperado para emitir a NFS-e de um atendimento.
+type IssueInvoiceRequest struct {
+	AppointmentID uuid.UUID `json:"appointmentId" binding:"required"`
+	TakerDocument string    `json:"takerDocument"` // CPF/CNPJ do tomador, opcional
+	Description   string    `json:"description"`   // Se vazio, usa a descrição do serviço
+}
+
+// CancelInvoiceRequest define o JSON esperado para cancelar uma NFS-e.
+type CancelInvoiceRequest struct {
+	CancelCode string `json:"cancelCode" binding:"omitempty,oneof=1 2 3 4 5"` // Código ABRASF do motivo
+	Reason     string `json:"reason" binding:"required"`
+}
+
+// InvoiceResponse define o JSON retornado para uma NFS-e.
+type InvoiceResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	AppointmentID      uuid.UUID  `json:"appointmentId"`
+	Provider           string     `json:"provider"`
+	Status             string     `json:"status"`
+	RPSNumber          int        `json:"rpsNumber"`
+	RPSSeries          string     `json:"rpsSeries"`
+	Number             string     `json:"number,omitempty"`
+	VerificationCode   string     `json:"verificationCode,omitempty"`
+	Amount             float64    `json:"amount"`
+	ISSRate            float64    `json:"issRate"`
+	ISSAmount          float64    `json:"issAmount"`
+	ServiceDescription string     `json:"serviceDescription"`
+	TakerName          string     `json:"takerName"`
+	TakerDocument      string     `json:"takerDocument,omitempty"`
+	TakerEmail         string     `json:"takerEmail,omitempty"`
+	ErrorCode          string     `json:"errorCode,omitempty"`
+	ErrorMessage       string     `json:"errorMessage,omitempty"`
+	IssuedAt           *time.Time `json:"issuedAt,omitempty"`
+	CancelledAt        *time.Time `json:"cancelledAt,omitempty"`
+	CancelReason       string     `json:"cancelReason,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+}
+
+// --- InvoiceHandler ---
+type InvoiceHandler struct {
+	invoiceUseCase *usecase.InvoiceUseCase
+}
+
+func NewInvoiceHandler(uc *usecase.InvoiceUseCase) *InvoiceHandler {
+	return &InvoiceHandler{invoiceUseCase: uc}
+}
+
+func mapInvoiceToResponse(i *entity.Invoice) InvoiceResponse {
+	return InvoiceResponse{
+		ID:                 i.ID,
+		AppointmentID:      i.AppointmentID,
+		Provider:           i.Provider,
+		Status:             string(i.Status),
+		RPSNumber:          i.RPSNumber,
+		RPSSeries:          i.RPSSeries,
+		Number:             i.Number,
+		VerificationCode:   i.VerificationCode,
+		Amount:             i.Amount,
+		ISSRate:            i.ISSRate,
+		ISSAmount:          i.ISSAmount,
+		ServiceDescription: i.ServiceDescription,
+		TakerName:          i.TakerName,
+		TakerDocument:      i.TakerDocument,
+		TakerEmail:         i.TakerEmail,
+		ErrorCode:          i.ErrorCode,
+		ErrorMessage:       i.ErrorMessage,
+		IssuedAt:           i.IssuedAt,
+		CancelledAt:        i.CancelledAt,
+		CancelReason:       i.CancelReason,
+		CreatedAt:          i.CreatedAt,
+		UpdatedAt:          i.UpdatedAt,
+	}
+}
+
+func mapInvoiceSettingsToResponse(s *entity.InvoiceSettings) InvoiceSettingsResponse {
+	return InvoiceSettingsResponse{
+		Document:              s.Document,
+		MunicipalRegistration: s.MunicipalRegistration,
+		MunicipalityCode:      s.MunicipalityCode,
+		ServiceItemCode:       s.ServiceItemCode,
+		ISSRate:               s.ISSRate,
+		RPSSeries:             s.RPSSeries,
+		NextRPSNumber:         s.NextRPSNumber,
+		AutoIssue:             s.AutoIssue,
+		UpdatedAt:             s.UpdatedAt,
+	}
+}
+
+// invoiceErrorStatus mapeia os erros dos casos de uso de NFS-e para o status HTTP.
+func invoiceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrInvoiceStatus):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidInvoice):
+		return http.StatusBadRequest
+	}
+	switch err.Error() {
+	case "nota não encontrada", "agendamento não encontrado":
+		return http.StatusNotFound
+	case "dados fiscais não configurados":
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// parseInvoiceID lê o ID da nota da rota, respondendo 400 se for inválido.
+func parseInvoiceID(c *gin.Context) (uuid.UUID, bool) {
+	invoiceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da nota inválido"})
+		return uuid.Nil, false
+	}
+	return invoiceID, true
+}
+
+// GetInvoiceSettings godoc
+// @Summary      Busca os dados fiscais do prestador
+// @Tags         invoices
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} InvoiceSettingsResponse
+// @Failure      404  {object} map[string]string "Dados fiscais não configurados"
+// @Router       /invoices/settings [get]
+func (h *InvoiceHandler) GetInvoiceSettings(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	settings, err := h.invoiceUseCase.GetSettings(requestingUserID)
+	if err != nil {
+		if err.Error() == "dados fiscais não configurados" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar dados fiscais: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapInvoiceSettingsToResponse(settings))
+}
+
+// SaveInvoiceSettings godoc
+// @Summary      Salva os dados fiscais do prestador
+// @Description  CNPJ/CPF, inscrição municipal, código IBGE do município, item da LC 116 e alíquota do ISS usados na emissão. A numeração do RPS nunca retrocede.
+// @Tags         invoices
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        settings body InvoiceSettingsRequest true "Dados Fiscais"
+// @Success      200  {object} InvoiceSettingsResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Router       /invoices/settings [put]
+func (h *InvoiceHandler) SaveInvoiceSettings(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req InvoiceSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	settings, err := h.invoiceUseCase.SaveSettings(usecase.SaveInvoiceSettingsInputDTO{
+		UserID:                requestingUserID,
+		Document:              req.Document,
+		MunicipalRegistration: req.MunicipalRegistration,
+		MunicipalityCode:      req.MunicipalityCode,
+		ServiceItemCode:       req.ServiceItemCode,
+		ISSRate:               req.ISSRate,
+		RPSSeries:             req.RPSSeries,
+		NextRPSNumber:         req.NextRPSNumber,
+		AutoIssue:             req.AutoIssue,
+	})
+	if err != nil {
+		respondError(c, invoiceErrorStatus, "Falha ao salvar dados fiscais: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapInvoiceSettingsToResponse(settings))
+}
+
+// IssueInvoice godoc
+// @Summary      Emite a NFS-e de um atendimento concluído
+// @Description  Gera o RPS no padrão ABRASF e envia ao provedor configurado. Se a prefeitura recusar, a nota é criada com status ERROR e o motivo, podendo ser reenviada.
+// @Tags         invoices
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        invoice body IssueInvoiceRequest true "Dados da Nota"
+// @Success      201  {object} InvoiceResponse
+// @Failure      400  {object} map[string]string "Dados inválidos ou dados fiscais não configurados"
+// @Failure      404  {object} map[string]string "Agendamento não encontrado"
+// @Failure      409  {object} map[string]string "Atendimento não concluído ou já possui nota"
+// @Router       /invoices [post]
+func (h *InvoiceHandler) IssueInvoice(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req IssueInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.IssueInvoice(usecase.IssueInvoiceInputDTO{
+		UserID:        requestingUserID,
+		AppointmentID: req.AppointmentID,
+		TakerDocument: req.TakerDocument,
+		Description:   req.Description,
+	})
+	if err != nil {
+		respondError(c, invoiceErrorStatus, "Falha ao emitir nota: ", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapInvoiceToResponse(invoice))
+}
+
+// ListInvoices godoc
+// @Summary      Lista as notas fiscais emitidas
+// @Tags         invoices
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "Filtra por status (PENDING, ISSUED, CANCELLED, ERROR)"
+// @Success      200  {array}  InvoiceResponse
+// @Router       /invoices [get]
+func (h *InvoiceHandler) ListInvoices(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	status := entity.InvoiceStatus(c.Query("status"))
+	switch status {
+	case "", entity.InvoiceStatusPending, entity.InvoiceStatusIssued, entity.InvoiceStatusCancelled, entity.InvoiceStatusError:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status inválido"})
+		return
+	}
+
+	invoices, err := h.invoiceUseCase.ListInvoices(requestingUserID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar notas: " + err.Error()})
+		return
+	}
+
+	responses := make([]InvoiceResponse, len(invoices))
+	for i, invoice := range invoices {
+		responses[i] = mapInvoiceToResponse(invoice)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetInvoiceByID godoc
+// @Summary      Busca uma nota fiscal
+// @Tags         invoices
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Nota (UUID)"
+// @Success      200  {object} InvoiceResponse
+// @Failure      404  {object} map[string]string "Nota não encontrada"
+// @Router       /invoices/{id} [get]
+func (h *InvoiceHandler) GetInvoiceByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.GetInvoiceByID(invoiceID, requestingUserID)
+	if err != nil {
+		respondError(c, invoiceErrorStatus, "Erro ao buscar nota: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapInvoiceToResponse(invoice))
+}
+
+// GetInvoiceXML godoc
+// @Summary      Baixa o XML do RPS enviado ao provedor
+// @Tags         invoices
+// @Security     BearerAuth
+// @Produce      application/xml
+// @Param        id path string true "ID da Nota (UUID)"
+// @Success      200  {file} file
+// @Failure      404  {object} map[string]string "Nota não encontrada"
+// @Router       /invoices/{id}/xml [get]
+func (h *InvoiceHandler) GetInvoiceXML(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.GetInvoiceByID(invoiceID, requestingUserID)
+	if err != nil {
+		respondError(c, invoiceErrorStatus, "Erro ao buscar nota: ", err)
+		return
+	}
+	if invoice.RequestXML == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "XML da nota não disponível"})
+		return
+	}
+
+	filename := fmt.Sprintf("rps-%s-%d.xml", invoice.RPSSeries, invoice.RPSNumber)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/xml", []byte(invoice.RequestXML))
+}
+
+// RetryInvoice godoc
+// @Summary      Reenvia uma nota recusada
+// @Description  Reenvia o mesmo RPS ao provedor usando os dados fiscais atuais.
+// @Tags         invoices
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Nota (UUID)"
+// @Success      200  {object} InvoiceResponse
+// @Failure      404  {object} map[string]string "Nota não encontrada"
+// @Failure      409  {object} map[string]string "Nota não está com erro"
+// @Router       /invoices/{id}/retry [post]
+func (h *InvoiceHandler) RetryInvoice(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.RetryInvoice(invoiceID, requestingUserID)
+	if err != nil {
+		respondError(c, invoiceErrorStatus, "Falha ao reenviar nota: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapInvoiceToResponse(invoice))
+}
+
+// CancelInvoice godoc
+// @Summary      Cancela uma NFS-e emitida
+// @Description  Solicita o cancelamento ao provedor. O atendimento volta a contar como faturamento sem nota.
+// @Tags         invoices
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID da Nota (UUID)"
+// @Param        cancel body CancelInvoiceRequest true "Motivo do Cancelamento"
+// @Success      200  {object} InvoiceResponse
+// @Failure      400  {object} map[string]string "Cancelamento recusado pelo provedor"
+// @Failure      404  {object} map[string]string "Nota não encontrada"
+// @Failure      409  {object} map[string]string "Nota não está emitida"
+// @Router       /invoices/{id}/cancel [patch]
+func (h *InvoiceHandler) CancelInvoice(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	var req CancelInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	invoice, err := h.invoiceUseCase.CancelInvoice(invoiceID, requestingUserID, usecase.CancelInvoiceInputDTO{
+		CancelCode: req.CancelCode,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		respondError(c, invoiceErrorStatus, "Falha ao cancelar nota: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapInvoiceToResponse(invoice))
+}
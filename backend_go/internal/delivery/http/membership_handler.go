@@ -0,0 +1,597 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Membership ---
+
+// CreateMembershipPlanRequest define o JSON esperado para cadastrar um plano de assinatura.
+type CreateMembershipPlanRequest struct {
+	Name                 string      `json:"name" binding:"required"`
+	Description          string      `json:"description"`
+	MonthlyPrice         float64     `json:"monthlyPrice" binding:"gte=0"`
+	IncludedServiceIDs   []uuid.UUID `json:"includedServiceIds"`
+	IncludedUsesPerCycle int         `json:"includedUsesPerCycle" binding:"gte=0"` // Zero significa ilimitado
+	DiscountPercent      float64     `json:"discountPercent" binding:"gte=0,lte=100"`
+}
+
+// UpdateMembershipPlanRequest define o JSON para atualizar um plano de assinatura.
+type UpdateMembershipPlanRequest struct {
+	Name                 *string     `json:"name"`
+	Description          *string     `json:"description"`
+	MonthlyPrice         *float64    `json:"monthlyPrice"`
+	IncludedServiceIDs   []uuid.UUID `json:"includedServiceIds"`
+	IncludedUsesPerCycle *int        `json:"includedUsesPerCycle"`
+	DiscountPercent      *float64    `json:"discountPercent"`
+	Active               *bool       `json:"active"`
+}
+
+// MembershipPlanResponse define o JSON retornado para um plano de assinatura.
+type MembershipPlanResponse struct {
+	ID                   uuid.UUID   `json:"id"`
+	Name                 string      `json:"name"`
+	Description          string      `json:"description"`
+	MonthlyPrice         float64     `json:"monthlyPrice"`
+	IncludedServiceIDs   []uuid.UUID `json:"includedServiceIds"`
+	IncludedUsesPerCycle int         `json:"includedUsesPerCycle"`
+	DiscountPercent      float64     `json:"discountPercent"`
+	Active               bool        `json:"active"`
+	CreatedAt            time.Time   `json:"createdAt"`
+	UpdatedAt            time.Time   `json:"updatedAt"`
+}
+
+// SubscribeRequest define o JSON esperado para assinar um plano em nome de um cliente.
+type SubscribeRequest struct {
+	PlanID    uuid.UUID  `json:"planId" binding:"required"`
+	StartDate *time.Time `json:"startDate"` // Se omitido, começa agora
+}
+
+// MembershipSubscriptionResponse define o JSON retornado para uma assinatura.
+type MembershipSubscriptionResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	PlanID            uuid.UUID  `json:"planId"`
+	CustomerID        uuid.UUID  `json:"customerId"`
+	Status            string     `json:"status"`
+	Price             float64    `json:"price"`
+	StartedAt         time.Time  `json:"startedAt"`
+	CurrentCycleStart time.Time  `json:"currentCycleStart"`
+	CurrentCycleEnd   time.Time  `json:"currentCycleEnd"`
+	CancelledAt       *time.Time `json:"cancelledAt,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+// MembershipCycleResponse define o JSON retornado para um ciclo de cobrança.
+type MembershipCycleResponse struct {
+	ID         uuid.UUID `json:"id"`
+	CycleStart time.Time `json:"cycleStart"`
+	CycleEnd   time.Time `json:"cycleEnd"`
+	Amount     float64   `json:"amount"`
+}
+
+// MembershipUsageResponse define o JSON retornado para um uso da assinatura.
+type MembershipUsageResponse struct {
+	ID            uuid.UUID `json:"id"`
+	AppointmentID uuid.UUID `json:"appointmentId"`
+	ServiceID     uuid.UUID `json:"serviceId"`
+	Kind          string    `json:"kind"`
+	OriginalPrice float64   `json:"originalPrice"`
+	ChargedPrice  float64   `json:"chargedPrice"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// MembershipCycleUsageResponse define o JSON retornado para o uso do ciclo atual.
+type MembershipCycleUsageResponse struct {
+	Subscription         MembershipSubscriptionResponse `json:"subscription"`
+	PlanName             string                         `json:"planName"`
+	IncludedUsed         int                            `json:"includedUsed"`
+	IncludedUsesPerCycle int                            `json:"includedUsesPerCycle"` // Zero significa ilimitado
+	Usages               []MembershipUsageResponse      `json:"usages"`
+}
+
+// --- MembershipHandler ---
+type MembershipHandler struct {
+	membershipUseCase *usecase.MembershipUseCase
+}
+
+func NewMembershipHandler(uc *usecase.MembershipUseCase) *MembershipHandler {
+	return &MembershipHandler{membershipUseCase: uc}
+}
+
+func mapMembershipPlanToResponse(p *entity.MembershipPlan) MembershipPlanResponse {
+	return MembershipPlanResponse{
+		ID:                   p.ID,
+		Name:                 p.Name,
+		Description:          p.Description,
+		MonthlyPrice:         p.MonthlyPrice,
+		IncludedServiceIDs:   p.IncludedServiceIDs,
+		IncludedUsesPerCycle: p.IncludedUsesPerCycle,
+		DiscountPercent:      p.DiscountPercent,
+		Active:               p.Active,
+		CreatedAt:            p.CreatedAt,
+		UpdatedAt:            p.UpdatedAt,
+	}
+}
+
+func mapMembershipSubscriptionToResponse(s *entity.MembershipSubscription) MembershipSubscriptionResponse {
+	return MembershipSubscriptionResponse{
+		ID:                s.ID,
+		PlanID:            s.PlanID,
+		CustomerID:        s.CustomerID,
+		Status:            string(s.Status),
+		Price:             s.Price,
+		StartedAt:         s.StartedAt,
+		CurrentCycleStart: s.CurrentCycleStart,
+		CurrentCycleEnd:   s.CurrentCycleEnd,
+		CancelledAt:       s.CancelledAt,
+		CreatedAt:         s.CreatedAt,
+		UpdatedAt:         s.UpdatedAt,
+	}
+}
+
+// CreatePlan godoc
+// @Summary      Cadastra um plano de assinatura
+// @Tags         memberships
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        plan body CreateMembershipPlanRequest true "Dados do Plano"
+// @Success      201  {object} MembershipPlanResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /membership-plans [post]
+func (h *MembershipHandler) CreatePlan(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateMembershipPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	plan, err := h.membershipUseCase.CreatePlan(usecase.CreateMembershipPlanInputDTO{
+		UserID:               requestingUserID,
+		Name:                 req.Name,
+		Description:          req.Description,
+		MonthlyPrice:         req.MonthlyPrice,
+		IncludedServiceIDs:   req.IncludedServiceIDs,
+		IncludedUsesPerCycle: req.IncludedUsesPerCycle,
+		DiscountPercent:      req.DiscountPercent,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao cadastrar plano: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapMembershipPlanToResponse(plan))
+}
+
+// ListPlans godoc
+// @Summary      Lista os planos de assinatura
+// @Tags         memberships
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  MembershipPlanResponse
+// @Router       /membership-plans [get]
+func (h *MembershipHandler) ListPlans(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	plans, err := h.membershipUseCase.ListPlans(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar planos: " + err.Error()})
+		return
+	}
+
+	responses := make([]MembershipPlanResponse, len(plans))
+	for i, p := range plans {
+		responses[i] = mapMembershipPlanToResponse(p)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetPlanByID godoc
+// @Summary      Busca um plano de assinatura
+// @Tags         memberships
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Plano (UUID)"
+// @Success      200  {object} MembershipPlanResponse
+// @Failure      404  {object} map[string]string "Plano não encontrado"
+// @Router       /membership-plans/{id} [get]
+func (h *MembershipHandler) GetPlanByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do plano inválido"})
+		return
+	}
+
+	plan, err := h.membershipUseCase.GetPlanByID(planID, requestingUserID)
+	if err != nil {
+		if err.Error() == "plano não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar plano: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapMembershipPlanToResponse(plan))
+}
+
+// UpdatePlan godoc
+// @Summary      Atualiza um plano de assinatura
+// @Description  O novo preço vale para novas assinaturas; os benefícios valem para todas.
+// @Tags         memberships
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Plano (UUID)"
+// @Param        plan body UpdateMembershipPlanRequest true "Dados para Atualização"
+// @Success      200  {object} MembershipPlanResponse
+// @Failure      404  {object} map[string]string "Plano não encontrado"
+// @Router       /membership-plans/{id} [put]
+func (h *MembershipHandler) UpdatePlan(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do plano inválido"})
+		return
+	}
+
+	var req UpdateMembershipPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	plan, err := h.membershipUseCase.UpdatePlan(planID, requestingUserID, usecase.UpdateMembershipPlanInputDTO{
+		Name:                 req.Name,
+		Description:          req.Description,
+		MonthlyPrice:         req.MonthlyPrice,
+		IncludedServiceIDs:   req.IncludedServiceIDs,
+		IncludedUsesPerCycle: req.IncludedUsesPerCycle,
+		DiscountPercent:      req.DiscountPercent,
+		Active:               req.Active,
+	})
+	if err != nil {
+		if err.Error() == "plano não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar plano: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapMembershipPlanToResponse(plan))
+}
+
+// DeletePlan godoc
+// @Summary      Exclui um plano de assinatura sem assinantes
+// @Tags         memberships
+// @Security     BearerAuth
+// @Param        id path string true "ID do Plano (UUID)"
+// @Success      204
+// @Failure      404  {object} map[string]string "Plano não encontrado"
+// @Failure      409  {object} map[string]string "Plano possui assinaturas"
+// @Router       /membership-plans/{id} [delete]
+func (h *MembershipHandler) DeletePlan(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do plano inválido"})
+		return
+	}
+
+	if err := h.membershipUseCase.DeletePlan(planID, requestingUserID); err != nil {
+		switch err.Error() {
+		case "plano não encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "plano possui assinaturas; desative-o em vez de excluir":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir plano: " + err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Subscribe godoc
+// @Summary      Assina um plano em nome de um cliente
+// @Description  Cobra o primeiro ciclo e lança a mensalidade no livro-caixa. Os ciclos seguintes são renovados automaticamente.
+// @Tags         memberships
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Cliente (UUID)"
+// @Param        subscription body SubscribeRequest true "Dados da Assinatura"
+// @Success      201  {object} MembershipSubscriptionResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      404  {object} map[string]string "Cliente ou plano não encontrado"
+// @Router       /clients/{id}/memberships [post]
+func (h *MembershipHandler) Subscribe(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cliente inválido"})
+		return
+	}
+
+	var req SubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	input := usecase.SubscribeInputDTO{
+		UserID:     requestingUserID,
+		CustomerID: customerID,
+		PlanID:     req.PlanID,
+	}
+	if req.StartDate != nil {
+		input.StartDate = *req.StartDate
+	}
+
+	subscription, err := h.membershipUseCase.Subscribe(input)
+	if err != nil {
+		switch err.Error() {
+		case "cliente não encontrado", "plano não encontrado":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "cliente já possui uma assinatura ativa":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao criar assinatura: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapMembershipSubscriptionToResponse(subscription))
+}
+
+// ListCustomerSubscriptions godoc
+// @Summary      Lista as assinaturas de um cliente
+// @Tags         memberships
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Cliente (UUID)"
+// @Success      200  {array}  MembershipSubscriptionResponse
+// @Failure      404  {object} map[string]string "Cliente não encontrado"
+// @Router       /clients/{id}/memberships [get]
+func (h *MembershipHandler) ListCustomerSubscriptions(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cliente inválido"})
+		return
+	}
+
+	subscriptions, err := h.membershipUseCase.ListCustomerSubscriptions(customerID, requestingUserID)
+	if err != nil {
+		if err.Error() == "cliente não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar assinaturas: " + err.Error()})
+		return
+	}
+
+	responses := make([]MembershipSubscriptionResponse, len(subscriptions))
+	for i, s := range subscriptions {
+		responses[i] = mapMembershipSubscriptionToResponse(s)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetSubscriptionByID godoc
+// @Summary      Busca uma assinatura
+// @Tags         memberships
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Assinatura (UUID)"
+// @Success      200  {object} MembershipSubscriptionResponse
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /memberships/{id} [get]
+func (h *MembershipHandler) GetSubscriptionByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da assinatura inválido"})
+		return
+	}
+
+	subscription, err := h.membershipUseCase.GetSubscriptionByID(subscriptionID, requestingUserID)
+	if err != nil {
+		if err.Error() == "assinatura não encontrada" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar assinatura: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapMembershipSubscriptionToResponse(subscription))
+}
+
+// CancelSubscription godoc
+// @Summary      Cancela a renovação de uma assinatura
+// @Description  Os benefícios continuam valendo até o fim do ciclo já pago.
+// @Tags         memberships
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Assinatura (UUID)"
+// @Success      200  {object} MembershipSubscriptionResponse
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /memberships/{id}/cancel [patch]
+func (h *MembershipHandler) CancelSubscription(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da assinatura inválido"})
+		return
+	}
+
+	subscription, err := h.membershipUseCase.CancelSubscription(subscriptionID, requestingUserID)
+	if err != nil {
+		switch err.Error() {
+		case "assinatura não encontrada":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "assinatura já está cancelada":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao cancelar assinatura: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, mapMembershipSubscriptionToResponse(subscription))
+}
+
+// GetCurrentUsage godoc
+// @Summary      Uso da assinatura no ciclo atual
+// @Tags         memberships
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Assinatura (UUID)"
+// @Success      200  {object} MembershipCycleUsageResponse
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /memberships/{id}/usage [get]
+func (h *MembershipHandler) GetCurrentUsage(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da assinatura inválido"})
+		return
+	}
+
+	usage, err := h.membershipUseCase.GetCurrentUsage(subscriptionID, requestingUserID)
+	if err != nil {
+		if err.Error() == "assinatura não encontrada" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar uso da assinatura: " + err.Error()})
+		return
+	}
+
+	usages := make([]MembershipUsageResponse, len(usage.Usages))
+	for i, u := range usage.Usages {
+		usages[i] = MembershipUsageResponse{
+			ID:            u.ID,
+			AppointmentID: u.AppointmentID,
+			ServiceID:     u.ServiceID,
+			Kind:          string(u.Kind),
+			OriginalPrice: u.OriginalPrice,
+			ChargedPrice:  u.ChargedPrice,
+			CreatedAt:     u.CreatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, MembershipCycleUsageResponse{
+		Subscription:         mapMembershipSubscriptionToResponse(usage.Subscription),
+		PlanName:             usage.Plan.Name,
+		IncludedUsed:         usage.IncludedUsed,
+		IncludedUsesPerCycle: usage.IncludedUsesPerCycle,
+		Usages:               usages,
+	})
+}
+
+// ListCycles godoc
+// @Summary      Lista os ciclos de cobrança de uma assinatura
+// @Tags         memberships
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Assinatura (UUID)"
+// @Success      200  {array}  MembershipCycleResponse
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /memberships/{id}/cycles [get]
+func (h *MembershipHandler) ListCycles(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da assinatura inválido"})
+		return
+	}
+
+	cycles, err := h.membershipUseCase.ListCycles(subscriptionID, requestingUserID)
+	if err != nil {
+		if err.Error() == "assinatura não encontrada" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar ciclos da assinatura: " + err.Error()})
+		return
+	}
+
+	responses := make([]MembershipCycleResponse, len(cycles))
+	for i, cy := range cycles {
+		responses[i] = MembershipCycleResponse{
+			ID:         cy.ID,
+			CycleStart: cy.CycleStart,
+			CycleEnd:   cy.CycleEnd,
+			Amount:     cy.Amount,
+		}
+	}
+	c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,205 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Avisos ---
+
+// NotificationResponse define o JSON retornado para um aviso da caixa de entrada.
+type NotificationResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Type          string     `json:"type"`
+	Title         string     `json:"title"`
+	Body          string     `json:"body"`
+	AppointmentID *uuid.UUID `json:"appointmentId,omitempty"`
+	ClientID      *uuid.UUID `json:"clientId,omitempty"`
+	Read          bool       `json:"read"`
+	ReadAt        *time.Time `json:"readAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// NotificationListResponse define o JSON retornado para uma página da caixa de entrada.
+type NotificationListResponse struct {
+	Items       []NotificationResponse `json:"items"`
+	Page        int                    `json:"page"`
+	PageSize    int                    `json:"pageSize"`
+	Total       int64                  `json:"total"`
+	UnreadCount int64                  `json:"unreadCount"`
+}
+
+// --- NotificationHandler ---
+type NotificationHandler struct {
+	notificationUseCase *usecase.NotificationUseCase
+}
+
+func NewNotificationHandler(uc *usecase.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{notificationUseCase: uc}
+}
+
+func mapNotificationToResponse(n *entity.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:            n.ID,
+		Type:          string(n.Type),
+		Title:         n.Title,
+		Body:          n.Body,
+		AppointmentID: n.AppointmentID,
+		ClientID:      n.ClientID,
+		Read:          n.IsRead(),
+		ReadAt:        n.ReadAt,
+		CreatedAt:     n.CreatedAt,
+	}
+}
+
+// ListNotifications godoc
+// @Summary      Lista os avisos
+// @Description  Lista os avisos da caixa de entrada do usuário autenticado, dos mais recentes para os mais antigos, com o total de não lidos.
+// @Tags         notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page       query int  false "Página, a partir de 1 (padrão 1)"
+// @Param        pageSize   query int  false "Avisos por página (padrão 20, máximo 100)"
+// @Param        unreadOnly query bool false "Lista apenas os não lidos"
+// @Success      200  {object} NotificationListResponse
+// @Failure      400  {object} map[string]string "Parâmetros inválidos"
+// @Router       /notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	page := 1
+	if s := c.Query("page"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "page deve ser um número a partir de 1"})
+			return
+		}
+		page = parsed
+	}
+	pageSize := 0
+	if s := c.Query("pageSize"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil || parsed < 1 || parsed > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "pageSize deve ser um número entre 1 e 100"})
+			return
+		}
+		pageSize = parsed
+	}
+	unreadOnly := false
+	if s := c.Query("unreadOnly"); s != "" {
+		parsed, err := strconv.ParseBool(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unreadOnly deve ser true ou false"})
+			return
+		}
+		unreadOnly = parsed
+	}
+
+	result, err := h.notificationUseCase.ListNotifications(requestingUserID, unreadOnly, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar avisos: " + err.Error()})
+		return
+	}
+
+	items := make([]NotificationResponse, len(result.Items))
+	for i, n := range result.Items {
+		items[i] = mapNotificationToResponse(n)
+	}
+	c.JSON(http.StatusOK, NotificationListResponse{
+		Items:       items,
+		Page:        result.Page,
+		PageSize:    result.PageSize,
+		Total:       result.Total,
+		UnreadCount: result.UnreadCount,
+	})
+}
+
+// GetUnreadNotificationCount godoc
+// @Summary      Conta os avisos não lidos
+// @Description  Retorna o número de avisos não lidos do usuário autenticado (ex: para o contador do ícone).
+// @Tags         notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} map[string]int64 "unreadCount"
+// @Router       /notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadNotificationCount(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	count, err := h.notificationUseCase.UnreadCount(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao contar avisos: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unreadCount": count})
+}
+
+// MarkNotificationRead godoc
+// @Summary      Marca um aviso como lido
+// @Tags         notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Aviso"
+// @Success      200  {object} NotificationResponse
+// @Failure      404  {object} map[string]string "Aviso não encontrado"
+// @Router       /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de aviso inválido"})
+		return
+	}
+
+	notification, err := h.notificationUseCase.MarkRead(notificationID, requestingUserID)
+	if err != nil {
+		if err.Error() == "aviso não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao marcar aviso: " + err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, mapNotificationToResponse(notification))
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary      Marca todos os avisos como lidos
+// @Tags         notifications
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} map[string]int64 "Número de avisos marcados"
+// @Router       /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	marked, err := h.notificationUseCase.MarkAllRead(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao marcar avisos: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"marked": marked})
+}
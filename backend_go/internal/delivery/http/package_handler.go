@@ -0,0 +1,397 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Package ---
+
+// CreatePackageRequest define o JSON esperado para cadastrar um pacote de sessões.
+type CreatePackageRequest struct {
+	Name         string      `json:"name" binding:"required"`
+	Description  string      `json:"description"`
+	Price        float64     `json:"price" binding:"gte=0"`
+	Credits      int         `json:"credits" binding:"required,gt=0"`
+	ValidityDays int         `json:"validityDays" binding:"gte=0"` // Zero significa sem validade
+	ServiceIDs   []uuid.UUID `json:"serviceIds" binding:"required,min=1"`
+}
+
+// UpdatePackageRequest define o JSON para atualizar um pacote de sessões.
+type UpdatePackageRequest struct {
+	Name         *string     `json:"name"`
+	Description  *string     `json:"description"`
+	Price        *float64    `json:"price"`
+	Credits      *int        `json:"credits"`
+	ValidityDays *int        `json:"validityDays"`
+	ServiceIDs   []uuid.UUID `json:"serviceIds"`
+	Active       *bool       `json:"active"`
+}
+
+// PackageResponse define o JSON retornado para um pacote de sessões.
+type PackageResponse struct {
+	ID           uuid.UUID   `json:"id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Price        float64     `json:"price"`
+	Credits      int         `json:"credits"`
+	ValidityDays int         `json:"validityDays"`
+	ServiceIDs   []uuid.UUID `json:"serviceIds"`
+	Active       bool        `json:"active"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	UpdatedAt    time.Time   `json:"updatedAt"`
+}
+
+// SellPackageRequest define o JSON esperado para vender um pacote a um cliente.
+type SellPackageRequest struct {
+	PackageID   uuid.UUID  `json:"packageId" binding:"required"`
+	Price       *float64   `json:"price"`       // Se omitido, usa o preço do pacote
+	PurchasedAt *time.Time `json:"purchasedAt"` // Se omitido, usa o momento atual
+}
+
+// ClientPackageResponse define o JSON retornado para um pacote vendido, com o saldo de sessões.
+type ClientPackageResponse struct {
+	ID               uuid.UUID   `json:"id"`
+	PackageID        uuid.UUID   `json:"packageId"`
+	CustomerID       uuid.UUID   `json:"customerId"`
+	Name             string      `json:"name"`
+	ServiceIDs       []uuid.UUID `json:"serviceIds"`
+	TotalCredits     int         `json:"totalCredits"`
+	UsedCredits      int         `json:"usedCredits"`
+	RemainingCredits int         `json:"remainingCredits"`
+	Expired          bool        `json:"expired"`
+	Price            float64     `json:"price"`
+	PurchasedAt      time.Time   `json:"purchasedAt"`
+	ExpiresAt        *time.Time  `json:"expiresAt,omitempty"`
+}
+
+// --- PackageHandler ---
+type PackageHandler struct {
+	packageUseCase *usecase.PackageUseCase
+}
+
+func NewPackageHandler(uc *usecase.PackageUseCase) *PackageHandler {
+	return &PackageHandler{packageUseCase: uc}
+}
+
+func mapPackageToResponse(p *entity.ServicePackage) PackageResponse {
+	return PackageResponse{
+		ID:           p.ID,
+		Name:         p.Name,
+		Description:  p.Description,
+		Price:        p.Price,
+		Credits:      p.Credits,
+		ValidityDays: p.ValidityDays,
+		ServiceIDs:   p.ServiceIDs,
+		Active:       p.Active,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
+
+func mapClientPackageToResponse(p *entity.ClientPackage, now time.Time) ClientPackageResponse {
+	return ClientPackageResponse{
+		ID:               p.ID,
+		PackageID:        p.PackageID,
+		CustomerID:       p.CustomerID,
+		Name:             p.Name,
+		ServiceIDs:       p.ServiceIDs,
+		TotalCredits:     p.TotalCredits,
+		UsedCredits:      p.UsedCredits,
+		RemainingCredits: p.RemainingCredits(),
+		Expired:          p.IsExpired(now),
+		Price:            p.Price,
+		PurchasedAt:      p.PurchasedAt,
+		ExpiresAt:        p.ExpiresAt,
+	}
+}
+
+// CreatePackage godoc
+// @Summary      Cadastra um pacote de sessões pré-pagas
+// @Tags         packages
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        package body CreatePackageRequest true "Dados do Pacote"
+// @Success      201  {object} PackageResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /packages [post]
+func (h *PackageHandler) CreatePackage(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreatePackageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	pkg, err := h.packageUseCase.CreatePackage(usecase.CreatePackageInputDTO{
+		UserID:       requestingUserID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Price:        req.Price,
+		Credits:      req.Credits,
+		ValidityDays: req.ValidityDays,
+		ServiceIDs:   req.ServiceIDs,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao cadastrar pacote: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapPackageToResponse(pkg))
+}
+
+// ListPackages godoc
+// @Summary      Lista os pacotes de sessões
+// @Tags         packages
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  PackageResponse
+// @Router       /packages [get]
+func (h *PackageHandler) ListPackages(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	packages, err := h.packageUseCase.ListPackages(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar pacotes: " + err.Error()})
+		return
+	}
+
+	responses := make([]PackageResponse, len(packages))
+	for i, p := range packages {
+		responses[i] = mapPackageToResponse(p)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetPackageByID godoc
+// @Summary      Busca um pacote de sessões
+// @Tags         packages
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Pacote (UUID)"
+// @Success      200  {object} PackageResponse
+// @Failure      404  {object} map[string]string "Pacote não encontrado"
+// @Router       /packages/{id} [get]
+func (h *PackageHandler) GetPackageByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	packageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pacote inválido"})
+		return
+	}
+
+	pkg, err := h.packageUseCase.GetPackageByID(packageID, requestingUserID)
+	if err != nil {
+		if err.Error() == "pacote não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar pacote: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapPackageToResponse(pkg))
+}
+
+// UpdatePackage godoc
+// @Summary      Atualiza um pacote de sessões
+// @Description  Pacotes já vendidos mantêm as condições da venda.
+// @Tags         packages
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Pacote (UUID)"
+// @Param        package body UpdatePackageRequest true "Dados para Atualização"
+// @Success      200  {object} PackageResponse
+// @Failure      404  {object} map[string]string "Pacote não encontrado"
+// @Router       /packages/{id} [put]
+func (h *PackageHandler) UpdatePackage(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	packageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pacote inválido"})
+		return
+	}
+
+	var req UpdatePackageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	pkg, err := h.packageUseCase.UpdatePackage(packageID, requestingUserID, usecase.UpdatePackageInputDTO{
+		Name:         req.Name,
+		Description:  req.Description,
+		Price:        req.Price,
+		Credits:      req.Credits,
+		ValidityDays: req.ValidityDays,
+		ServiceIDs:   req.ServiceIDs,
+		Active:       req.Active,
+	})
+	if err != nil {
+		if err.Error() == "pacote não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao atualizar pacote: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapPackageToResponse(pkg))
+}
+
+// DeletePackage godoc
+// @Summary      Exclui um pacote de sessões
+// @Description  Pacotes já vendidos continuam válidos.
+// @Tags         packages
+// @Security     BearerAuth
+// @Param        id path string true "ID do Pacote (UUID)"
+// @Success      204
+// @Failure      404  {object} map[string]string "Pacote não encontrado"
+// @Router       /packages/{id} [delete]
+func (h *PackageHandler) DeletePackage(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	packageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pacote inválido"})
+		return
+	}
+
+	if err := h.packageUseCase.DeletePackage(packageID, requestingUserID); err != nil {
+		if err.Error() == "pacote não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao excluir pacote: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SellPackage godoc
+// @Summary      Vende um pacote de sessões a um cliente
+// @Description  Lança o valor da venda como receita no livro-caixa.
+// @Tags         packages
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Cliente (UUID)"
+// @Param        sale body SellPackageRequest true "Dados da Venda"
+// @Success      201  {object} ClientPackageResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      404  {object} map[string]string "Cliente ou pacote não encontrado"
+// @Router       /clients/{id}/packages [post]
+func (h *PackageHandler) SellPackage(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cliente inválido"})
+		return
+	}
+
+	var req SellPackageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	input := usecase.SellPackageInputDTO{
+		UserID:     requestingUserID,
+		CustomerID: customerID,
+		PackageID:  req.PackageID,
+		Price:      req.Price,
+	}
+	if req.PurchasedAt != nil {
+		input.PurchasedAt = *req.PurchasedAt
+	}
+
+	clientPackage, err := h.packageUseCase.SellPackage(input)
+	if err != nil {
+		if err.Error() == "cliente não encontrado" || err.Error() == "pacote não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao vender pacote: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapClientPackageToResponse(clientPackage, time.Now()))
+}
+
+// ListClientPackages godoc
+// @Summary      Lista os pacotes de um cliente com o saldo de sessões
+// @Tags         packages
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Cliente (UUID)"
+// @Success      200  {array}  ClientPackageResponse
+// @Failure      404  {object} map[string]string "Cliente não encontrado"
+// @Router       /clients/{id}/packages [get]
+func (h *PackageHandler) ListClientPackages(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	customerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cliente inválido"})
+		return
+	}
+
+	clientPackages, err := h.packageUseCase.ListClientPackages(customerID, requestingUserID)
+	if err != nil {
+		if err.Error() == "cliente não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar pacotes do cliente: " + err.Error()})
+		return
+	}
+
+	now := time.Now()
+	responses := make([]ClientPackageResponse, len(clientPackages))
+	for i, p := range clientPackages {
+		responses[i] = mapClientPackageToResponse(p, now)
+	}
+	c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/payment"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxWebhookBodySize limita o tamanho do corpo aceito nos webhooks de pagamento.
+const maxWebhookBodySize = 1 << 20 // 1 MiB
+
+// --- DTOs para Payment ---
+
+// CreatePaymentRequest define o JSON esperado para cobrar um agendamento.
+type CreatePaymentRequest struct {
+	Amount       float64 `json:"amount" binding:"omitempty,gt=0"` // Se omitido, usa o preço do agendamento
+	Method       string  `json:"method" binding:"required,oneof=PIX CARD CASH GIFT_CARD"`
+	GiftCardCode string  `json:"giftCardCode" binding:"required_if=Method GIFT_CARD"`
+}
+
+// PaymentResponse define o JSON retornado para um pagamento.
+type PaymentResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	AppointmentID    *uuid.UUID `json:"appointmentId,omitempty"`
+	Amount           float64    `json:"amount"`
+	Method           string     `json:"method"`
+	Status           string     `json:"status"`
+	Provider         string     `json:"provider,omitempty"`
+	ProviderChargeID string     `json:"providerChargeId,omitempty"`
+	PaymentURL       string     `json:"paymentUrl,omitempty"`
+	GiftCardID       *uuid.UUID `json:"giftCardId,omitempty"`
+	CheckoutID       *uuid.UUID `json:"checkoutId,omitempty"`
+	SaleID           *uuid.UUID `json:"saleId,omitempty"`
+	PaidAt           *time.Time `json:"paidAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+// --- PaymentHandler ---
+type PaymentHandler struct {
+	paymentUseCase *usecase.PaymentUseCase
+}
+
+func NewPaymentHandler(uc *usecase.PaymentUseCase) *PaymentHandler {
+	return &PaymentHandler{paymentUseCase: uc}
+}
+
+func mapPaymentEntityToResponse(p *entity.Payment) PaymentResponse {
+	return PaymentResponse{
+		ID:               p.ID,
+		AppointmentID:    p.AppointmentID,
+		Amount:           p.Amount,
+		Method:           string(p.Method),
+		Status:           string(p.Status),
+		Provider:         p.Provider,
+		ProviderChargeID: p.ProviderChargeID,
+		PaymentURL:       p.PaymentURL,
+		GiftCardID:       p.GiftCardID,
+		CheckoutID:       p.CheckoutID,
+		SaleID:           p.SaleID,
+		PaidAt:           p.PaidAt,
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
+
+// CreateAppointmentPayment godoc
+// @Summary      Cria uma cobrança para um agendamento
+// @Description  PIX e cartão geram uma cobrança no provedor e ficam pendentes até o webhook de confirmação. Dinheiro e vale-presente são registrados como pagos.
+// @Tags         payments
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Agendamento (UUID)"
+// @Param        payment body CreatePaymentRequest true "Dados do Pagamento"
+// @Success      201  {object} PaymentResponse "Pagamento criado"
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Failure      404  {object} map[string]string "Agendamento não encontrado"
+// @Failure      500  {object} map[string]string "Erro interno"
+// @Router       /appointments/{id}/payments [post]
+func (h *PaymentHandler) CreateAppointmentPayment(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do agendamento inválido"})
+		return
+	}
+
+	var req CreatePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	paymentEntity, err := h.paymentUseCase.CreatePayment(usecase.CreatePaymentInputDTO{
+		UserID:        requestingUserID,
+		AppointmentID: appointmentID,
+		Amount:        req.Amount,
+		Method:        entity.PaymentMethod(req.Method),
+		GiftCardCode:  req.GiftCardCode,
+	})
+	if err != nil {
+		if err.Error() == "agendamento não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidGiftCard) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao criar pagamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapPaymentEntityToResponse(paymentEntity))
+}
+
+// ListAppointmentPayments godoc
+// @Summary      Lista os pagamentos de um agendamento
+// @Tags         payments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Agendamento (UUID)"
+// @Success      200  {array}  PaymentResponse
+// @Failure      400  {object} map[string]string "ID inválido"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Failure      404  {object} map[string]string "Agendamento não encontrado"
+// @Failure      500  {object} map[string]string "Erro interno"
+// @Router       /appointments/{id}/payments [get]
+func (h *PaymentHandler) ListAppointmentPayments(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do agendamento inválido"})
+		return
+	}
+
+	paymentEntities, err := h.paymentUseCase.ListAppointmentPayments(appointmentID, requestingUserID)
+	if err != nil {
+		if err.Error() == "agendamento não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar pagamentos: " + err.Error()})
+		return
+	}
+
+	responses := make([]PaymentResponse, len(paymentEntities))
+	for i, p := range paymentEntities {
+		responses[i] = mapPaymentEntityToResponse(p)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetPaymentByID godoc
+// @Summary      Busca um pagamento pelo ID
+// @Tags         payments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Pagamento (UUID)"
+// @Success      200  {object} PaymentResponse
+// @Failure      400  {object} map[string]string "ID inválido"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Failure      404  {object} map[string]string "Pagamento não encontrado"
+// @Router       /payments/{id} [get]
+func (h *PaymentHandler) GetPaymentByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	paymentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pagamento inválido"})
+		return
+	}
+
+	paymentEntity, err := h.paymentUseCase.GetPaymentByID(paymentID, requestingUserID)
+	if err != nil {
+		if err.Error() == "pagamento não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar pagamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapPaymentEntityToResponse(paymentEntity))
+}
+
+// HandlePaymentWebhook godoc
+// @Summary      Recebe webhooks dos provedores de pagamento
+// @Description  Rota pública. A autenticidade é garantida pela assinatura HMAC do provedor. Entregas repetidas do mesmo evento são ignoradas.
+// @Tags         payments
+// @Accept       json
+// @Produce      json
+// @Param        provider path string true "Nome do provedor (ex: fake)"
+// @Success      200  {object} map[string]string "Evento recebido"
+// @Failure      400  {object} map[string]string "Payload inválido"
+// @Failure      401  {object} map[string]string "Assinatura inválida"
+// @Failure      404  {object} map[string]string "Provedor desconhecido"
+// @Failure      500  {object} map[string]string "Erro interno"
+// @Router       /webhooks/payments/{provider} [post]
+func (h *PaymentHandler) HandlePaymentWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Falha ao ler o corpo da requisição"})
+		return
+	}
+
+	err = h.paymentUseCase.HandleWebhook(c.Param("provider"), c.Request.Header, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, payment.ErrProviderNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, payment.ErrInvalidSignature):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, payment.ErrInvalidPayload):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Printf("Erro ao processar webhook de pagamento: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao processar webhook: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "received"})
+}
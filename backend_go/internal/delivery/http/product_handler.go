@@ -0,0 +1,466 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Product ---
+
+// CreateProductRequest define o JSON esperado para cadastrar um produto.
+type CreateProductRequest struct {
+	Name         string  `json:"name" binding:"required"`
+	SKU          string  `json:"sku"`
+	Description  string  `json:"description"`
+	CostPrice    float64 `json:"costPrice" binding:"gte=0"`
+	SalePrice    float64 `json:"salePrice" binding:"gte=0"`
+	InitialStock int     `json:"initialStock" binding:"gte=0"`
+	MinStock     int     `json:"minStock" binding:"gte=0"`
+}
+
+// UpdateProductRequest define o JSON para atualizar um produto. O estoque só muda por movimentações.
+type UpdateProductRequest struct {
+	Name        *string  `json:"name"`
+	SKU         *string  `json:"sku"`
+	Description *string  `json:"description"`
+	CostPrice   *float64 `json:"costPrice"`
+	SalePrice   *float64 `json:"salePrice"`
+	MinStock    *int     `json:"minStock"`
+	Active      *bool    `json:"active"`
+}
+
+// ProductResponse define o JSON retornado para um produto.
+type ProductResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	SKU           string    `json:"sku,omitempty"`
+	Description   string    `json:"description,omitempty"`
+	CostPrice     float64   `json:"costPrice"`
+	SalePrice     float64   `json:"salePrice"`
+	StockQuantity int       `json:"stockQuantity"`
+	MinStock      int       `json:"minStock"`
+	LowStock      bool      `json:"lowStock"`
+	Active        bool      `json:"active"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// StockMovementRequest define o JSON esperado para registrar uma movimentação de estoque.
+type StockMovementRequest struct {
+	Type     string  `json:"type" binding:"required,oneof=PURCHASE ADJUSTMENT LOSS"`
+	Quantity int     `json:"quantity" binding:"required"` // Positivo; em ADJUSTMENT pode ser negativo
+	UnitCost float64 `json:"unitCost" binding:"gte=0"`    // Apenas para PURCHASE; se omitido, usa o custo cadastrado
+	Notes    string  `json:"notes"`
+}
+
+// StockMovementResponse define o JSON retornado para uma movimentação de estoque.
+type StockMovementResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	ProductID     uuid.UUID  `json:"productId"`
+	Type          string     `json:"type"`
+	Quantity      int        `json:"quantity"`
+	UnitPrice     float64    `json:"unitPrice"`
+	StockAfter    int        `json:"stockAfter"`
+	AppointmentID *uuid.UUID `json:"appointmentId,omitempty"`
+	CheckoutID    *uuid.UUID `json:"checkoutId,omitempty"`
+	SaleID        *uuid.UUID `json:"saleId,omitempty"`
+	Notes         string     `json:"notes,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// LowStockAlertResponse define o JSON retornado para um alerta de estoque baixo.
+type LowStockAlertResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	ProductID     uuid.UUID  `json:"productId"`
+	StockQuantity int        `json:"stockQuantity"`
+	MinStock      int        `json:"minStock"`
+	ResolvedAt    *time.Time `json:"resolvedAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// --- ProductHandler ---
+type ProductHandler struct {
+	productUseCase *usecase.ProductUseCase
+}
+
+func NewProductHandler(uc *usecase.ProductUseCase) *ProductHandler {
+	return &ProductHandler{productUseCase: uc}
+}
+
+func mapProductToResponse(p *entity.Product) ProductResponse {
+	return ProductResponse{
+		ID:            p.ID,
+		Name:          p.Name,
+		SKU:           p.SKU,
+		Description:   p.Description,
+		CostPrice:     p.CostPrice,
+		SalePrice:     p.SalePrice,
+		StockQuantity: p.StockQuantity,
+		MinStock:      p.MinStock,
+		LowStock:      p.IsLowStock(),
+		Active:        p.Active,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
+
+func mapProductsToResponse(products []*entity.Product) []ProductResponse {
+	responses := make([]ProductResponse, len(products))
+	for i, p := range products {
+		responses[i] = mapProductToResponse(p)
+	}
+	return responses
+}
+
+func mapStockMovementToResponse(m *entity.StockMovement) StockMovementResponse {
+	return StockMovementResponse{
+		ID:            m.ID,
+		ProductID:     m.ProductID,
+		Type:          string(m.Type),
+		Quantity:      m.Quantity,
+		UnitPrice:     m.UnitPrice,
+		StockAfter:    m.StockAfter,
+		AppointmentID: m.AppointmentID,
+		CheckoutID:    m.CheckoutID,
+		SaleID:        m.SaleID,
+		Notes:         m.Notes,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// productErrorStatus mapeia os erros dos casos de uso de produto para o status HTTP.
+func productErrorStatus(err error) int {
+	switch err.Error() {
+	case "produto não encontrado":
+		return http.StatusNotFound
+	case "já existe um produto com este SKU":
+		return http.StatusConflict
+	case "nome do produto é obrigatório", "preços do produto não podem ser negativos",
+		"estoque mínimo não pode ser negativo", "estoque inicial não pode ser negativo",
+		"quantidade comprada deve ser maior que zero", "quantidade perdida deve ser maior que zero",
+		"quantidade do ajuste não pode ser zero", "custo unitário não pode ser negativo":
+		return http.StatusBadRequest
+	}
+	if strings.HasPrefix(err.Error(), "estoque insuficiente") || strings.HasPrefix(err.Error(), "tipo de movimentação inválido") {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// parseProductID lê o ID do produto da rota, respondendo 400 se for inválido.
+func parseProductID(c *gin.Context) (uuid.UUID, bool) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do produto inválido"})
+		return uuid.Nil, false
+	}
+	return productID, true
+}
+
+// CreateProduct godoc
+// @Summary      Cadastra um produto
+// @Description  O estoque inicial, se informado, é registrado como ajuste de estoque.
+// @Tags         products
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        product body CreateProductRequest true "Dados do Produto"
+// @Success      201  {object} ProductResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      409  {object} map[string]string "SKU já cadastrado"
+// @Router       /products [post]
+func (h *ProductHandler) CreateProduct(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	product, err := h.productUseCase.CreateProduct(usecase.CreateProductInputDTO{
+		UserID:       requestingUserID,
+		Name:         req.Name,
+		SKU:          req.SKU,
+		Description:  req.Description,
+		CostPrice:    req.CostPrice,
+		SalePrice:    req.SalePrice,
+		InitialStock: req.InitialStock,
+		MinStock:     req.MinStock,
+	})
+	if err != nil {
+		respondError(c, productErrorStatus, "Falha ao cadastrar produto: ", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapProductToResponse(product))
+}
+
+// ListProducts godoc
+// @Summary      Lista os produtos
+// @Tags         products
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  ProductResponse
+// @Router       /products [get]
+func (h *ProductHandler) ListProducts(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	products, err := h.productUseCase.ListProducts(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar produtos: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mapProductsToResponse(products))
+}
+
+// ListLowStockProducts godoc
+// @Summary      Lista os produtos com estoque no mínimo ou abaixo
+// @Tags         products
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  ProductResponse
+// @Router       /products/low-stock [get]
+func (h *ProductHandler) ListLowStockProducts(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	products, err := h.productUseCase.ListLowStockProducts(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar produtos: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mapProductsToResponse(products))
+}
+
+// ListLowStockAlerts godoc
+// @Summary      Lista os alertas de estoque baixo
+// @Tags         products
+// @Security     BearerAuth
+// @Produce      json
+// @Param        open query bool false "Apenas alertas em aberto"
+// @Success      200  {array}  LowStockAlertResponse
+// @Router       /products/alerts [get]
+func (h *ProductHandler) ListLowStockAlerts(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	alerts, err := h.productUseCase.ListLowStockAlerts(requestingUserID, c.Query("open") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar alertas de estoque: " + err.Error()})
+		return
+	}
+
+	responses := make([]LowStockAlertResponse, len(alerts))
+	for i, a := range alerts {
+		responses[i] = LowStockAlertResponse{
+			ID:            a.ID,
+			ProductID:     a.ProductID,
+			StockQuantity: a.StockQuantity,
+			MinStock:      a.MinStock,
+			ResolvedAt:    a.ResolvedAt,
+			CreatedAt:     a.CreatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetProductByID godoc
+// @Summary      Busca um produto
+// @Tags         products
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Produto (UUID)"
+// @Success      200  {object} ProductResponse
+// @Failure      404  {object} map[string]string "Produto não encontrado"
+// @Router       /products/{id} [get]
+func (h *ProductHandler) GetProductByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	productID, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.productUseCase.GetProductByID(productID, requestingUserID)
+	if err != nil {
+		respondError(c, productErrorStatus, "Erro ao buscar produto: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapProductToResponse(product))
+}
+
+// UpdateProduct godoc
+// @Summary      Atualiza um produto
+// @Description  Não altera o estoque; use as movimentações de estoque.
+// @Tags         products
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Produto (UUID)"
+// @Param        product body UpdateProductRequest true "Dados para Atualizar"
+// @Success      200  {object} ProductResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      404  {object} map[string]string "Produto não encontrado"
+// @Failure      409  {object} map[string]string "SKU já cadastrado"
+// @Router       /products/{id} [put]
+func (h *ProductHandler) UpdateProduct(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	productID, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	product, err := h.productUseCase.UpdateProduct(productID, requestingUserID, usecase.UpdateProductInputDTO{
+		Name:        req.Name,
+		SKU:         req.SKU,
+		Description: req.Description,
+		CostPrice:   req.CostPrice,
+		SalePrice:   req.SalePrice,
+		MinStock:    req.MinStock,
+		Active:      req.Active,
+	})
+	if err != nil {
+		respondError(c, productErrorStatus, "Falha ao atualizar produto: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapProductToResponse(product))
+}
+
+// DeleteProduct godoc
+// @Summary      Exclui um produto
+// @Tags         products
+// @Security     BearerAuth
+// @Param        id path string true "ID do Produto (UUID)"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Produto não encontrado"
+// @Router       /products/{id} [delete]
+func (h *ProductHandler) DeleteProduct(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	productID, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.productUseCase.DeleteProduct(productID, requestingUserID); err != nil {
+		respondError(c, productErrorStatus, "Falha ao excluir produto: ", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RecordStockMovement godoc
+// @Summary      Registra uma movimentação de estoque
+// @Description  Compra (entrada, lançada como despesa quando há custo), ajuste após contagem (positivo ou negativo) ou perda (saída). Vendas são registradas no fechamento do atendimento.
+// @Tags         products
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Produto (UUID)"
+// @Param        movement body StockMovementRequest true "Dados da Movimentação"
+// @Success      201  {object} StockMovementResponse
+// @Failure      400  {object} map[string]string "Dados inválidos ou estoque insuficiente"
+// @Failure      404  {object} map[string]string "Produto não encontrado"
+// @Router       /products/{id}/movements [post]
+func (h *ProductHandler) RecordStockMovement(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	productID, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+
+	var req StockMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	movement, err := h.productUseCase.RecordStockMovement(usecase.RecordStockMovementInputDTO{
+		UserID:    requestingUserID,
+		ProductID: productID,
+		Type:      entity.StockMovementType(req.Type),
+		Quantity:  req.Quantity,
+		UnitCost:  req.UnitCost,
+		Notes:     req.Notes,
+	})
+	if err != nil {
+		respondError(c, productErrorStatus, "Falha ao registrar movimentação: ", err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapStockMovementToResponse(movement))
+}
+
+// ListStockMovements godoc
+// @Summary      Lista as movimentações de estoque de um produto
+// @Tags         products
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Produto (UUID)"
+// @Success      200  {array}  StockMovementResponse
+// @Failure      404  {object} map[string]string "Produto não encontrado"
+// @Router       /products/{id}/movements [get]
+func (h *ProductHandler) ListStockMovements(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	productID, ok := parseProductID(c)
+	if !ok {
+		return
+	}
+
+	movements, err := h.productUseCase.ListStockMovements(productID, requestingUserID)
+	if err != nil {
+		respondError(c, productErrorStatus, "Erro ao listar movimentações: ", err)
+		return
+	}
+
+	responses := make([]StockMovementResponse, len(movements))
+	for i, m := range movements {
+		responses[i] = mapStockMovementToResponse(m)
+	}
+	c.JSON(http.StatusOK, responses)
+}
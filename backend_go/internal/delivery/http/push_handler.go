@@ -0,0 +1,302 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Notificações Push ---
+
+// RegisterPushDeviceRequest define o JSON esperado para registrar um dispositivo.
+type RegisterPushDeviceRequest struct {
+	Token      string `json:"token" binding:"required"`
+	Platform   string `json:"platform" binding:"required"` // ANDROID, IOS ou WEB
+	DeviceName string `json:"deviceName"`
+	AppVersion string `json:"appVersion"`
+}
+
+// UnregisterPushDeviceRequest define o JSON esperado para cancelar o registro de um dispositivo.
+type UnregisterPushDeviceRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// PushDeviceResponse define o JSON retornado para um dispositivo registrado.
+type PushDeviceResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Platform   string    `json:"platform"`
+	DeviceName string    `json:"deviceName,omitempty"`
+	AppVersion string    `json:"appVersion,omitempty"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// UpdatePushPreferencesRequest define o JSON esperado para alterar as preferências de push.
+type UpdatePushPreferencesRequest struct {
+	Preferences map[string]bool `json:"preferences" binding:"required"` // Tipo de evento -> habilitado (ex: {"appointment.created": true})
+}
+
+// PushPreferenceResponse define o JSON retornado para a preferência de um tipo de evento.
+type PushPreferenceResponse struct {
+	EventType string `json:"eventType"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// --- PushHandler ---
+type PushHandler struct {
+	pushUseCase *usecase.PushUseCase
+	fake        http.Handler // Servidor fake do FCM; nil quando o provedor real é usado
+}
+
+// NewPushHandler cria o handler. fake, se informado, é exposto em /fake/push para testes offline.
+func NewPushHandler(uc *usecase.PushUseCase, fake http.Handler) *PushHandler {
+	return &PushHandler{pushUseCase: uc, fake: fake}
+}
+
+func mapPushDeviceToResponse(d *entity.PushDevice) PushDeviceResponse {
+	return PushDeviceResponse{
+		ID:         d.ID,
+		Platform:   string(d.Platform),
+		DeviceName: d.DeviceName,
+		AppVersion: d.AppVersion,
+		LastSeenAt: d.LastSeenAt,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func mapPushPreferencesToResponse(preferences []*entity.PushPreference) []PushPreferenceResponse {
+	responses := make([]PushPreferenceResponse, len(preferences))
+	for i, p := range preferences {
+		responses[i] = PushPreferenceResponse{EventType: string(p.EventType), Enabled: p.Enabled}
+	}
+	return responses
+}
+
+// pushErrorStatus mapeia os erros do PushUseCase para o status HTTP.
+func pushErrorStatus(err error) int {
+	switch {
+	case err.Error() == "dispositivo não encontrado":
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidPushDevice), errors.Is(err, usecase.ErrInvalidPushPreference):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// RegisterPushDevice godoc
+// @Summary      Registra um dispositivo para notificações push
+// @Description  Registra o token do dispositivo (ex: token do FCM) para o usuário autenticado. O app deve chamá-lo a cada login e sempre que o token for renovado; registrar de novo um token existente apenas atualiza os dados.
+// @Tags         push
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        device body RegisterPushDeviceRequest true "Dados do Dispositivo"
+// @Success      200  {object} PushDeviceResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Router       /push/devices [post]
+func (h *PushHandler) RegisterPushDevice(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req RegisterPushDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	device, err := h.pushUseCase.RegisterDevice(requestingUserID, usecase.RegisterPushDeviceInputDTO{
+		Token:      req.Token,
+		Platform:   req.Platform,
+		DeviceName: req.DeviceName,
+		AppVersion: req.AppVersion,
+	})
+	if err != nil {
+		respondError(c, pushErrorStatus, "Falha ao registrar dispositivo: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapPushDeviceToResponse(device))
+}
+
+// UnregisterPushDevice godoc
+// @Summary      Cancela o registro de um dispositivo
+// @Description  Cancela o registro do token informado (ex: no logout). Não falha se o token já não estiver registrado.
+// @Tags         push
+// @Security     BearerAuth
+// @Accept       json
+// @Param        device body UnregisterPushDeviceRequest true "Token do Dispositivo"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Dispositivo não encontrado"
+// @Router       /push/devices/unregister [post]
+func (h *PushHandler) UnregisterPushDevice(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req UnregisterPushDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	if err := h.pushUseCase.UnregisterDevice(requestingUserID, req.Token); err != nil {
+		respondError(c, pushErrorStatus, "Falha ao cancelar registro: ", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListPushDevices godoc
+// @Summary      Lista os dispositivos registrados
+// @Description  Lista os dispositivos do usuário autenticado, dos usados mais recentemente para os mais antigos.
+// @Tags         push
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  PushDeviceResponse
+// @Router       /push/devices [get]
+func (h *PushHandler) ListPushDevices(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	devices, err := h.pushUseCase.ListDevices(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar dispositivos: " + err.Error()})
+		return
+	}
+
+	responses := make([]PushDeviceResponse, len(devices))
+	for i, d := range devices {
+		responses[i] = mapPushDeviceToResponse(d)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// DeletePushDevice godoc
+// @Summary      Remove um dispositivo
+// @Description  Remove um dispositivo do usuário pelo ID (ex: aparelho perdido).
+// @Tags         push
+// @Security     BearerAuth
+// @Param        id path string true "ID do Dispositivo"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Dispositivo não encontrado"
+// @Router       /push/devices/{id} [delete]
+func (h *PushHandler) DeletePushDevice(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de dispositivo inválido"})
+		return
+	}
+
+	if err := h.pushUseCase.DeleteDevice(deviceID, requestingUserID); err != nil {
+		respondError(c, pushErrorStatus, "Falha ao remover dispositivo: ", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetPushPreferences godoc
+// @Summary      Obtém as preferências de notificação push
+// @Description  Retorna, para cada tipo de evento, se o usuário recebe notificações push. Por padrão, apenas novos agendamentos e cancelamentos são notificados.
+// @Tags         push
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  PushPreferenceResponse
+// @Router       /push/preferences [get]
+func (h *PushHandler) GetPushPreferences(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	preferences, err := h.pushUseCase.GetPreferences(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar preferências: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mapPushPreferencesToResponse(preferences))
+}
+
+// UpdatePushPreferences godoc
+// @Summary      Altera as preferências de notificação push
+// @Description  Habilita ou desabilita as notificações dos tipos de evento informados; os demais permanecem como estão.
+// @Tags         push
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        preferences body UpdatePushPreferencesRequest true "Preferências por Tipo de Evento"
+// @Success      200  {array}  PushPreferenceResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Router       /push/preferences [put]
+func (h *PushHandler) UpdatePushPreferences(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req UpdatePushPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	preferences, err := h.pushUseCase.UpdatePreferences(requestingUserID, req.Preferences)
+	if err != nil {
+		respondError(c, pushErrorStatus, "Falha ao salvar preferências: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapPushPreferencesToResponse(preferences))
+}
+
+// SendTestPush godoc
+// @Summary      Envia uma notificação de teste
+// @Description  Envia uma notificação de teste a todos os dispositivos do usuário autenticado.
+// @Tags         push
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} map[string]int "Número de dispositivos notificados"
+// @Failure      400  {object} map[string]string "Nenhum dispositivo registrado"
+// @Router       /push/test [post]
+func (h *PushHandler) SendTestPush(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	sent, err := h.pushUseCase.SendTest(requestingUserID)
+	if err != nil {
+		respondError(c, pushErrorStatus, "Falha ao enviar notificação de teste: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sent": sent})
+}
+
+// ServeFakePush encaminha as requisições ao servidor fake do FCM:
+// POST /fake/push/v1/projects/{projectID}/messages:send (envios), GET /fake/push/messages
+// (notificações enviadas) e POST /fake/push/unregister (simula a desinstalação do app).
+// Todas exigem o cabeçalho Authorization: Bearer {FCM_ACCESS_TOKEN}.
+func (h *PushHandler) ServeFakePush(c *gin.Context) {
+	c.Request.URL.Path = c.Param("path")
+	h.fake.ServeHTTP(c.Writer, c.Request)
+}
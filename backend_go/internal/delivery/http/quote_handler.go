@@ -0,0 +1,727 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Quote ---
+
+// QuoteItemRequest define uma linha do orçamento.
+type QuoteItemRequest struct {
+	ServiceID   *uuid.UUID `json:"serviceId"` // Se informado, preenche descrição e preço vazios
+	Description string     `json:"description" binding:"max=255"`
+	Quantity    int        `json:"quantity" binding:"gte=0"` // Zero significa 1
+	UnitPrice   float64    `json:"unitPrice" binding:"gte=0"`
+}
+
+// CreateQuoteRequest define o JSON esperado para criar um orçamento.
+type CreateQuoteRequest struct {
+	CustomerID  *uuid.UUID         `json:"customerId"`
+	ClientName  string             `json:"clientName"`
+	ClientEmail string             `json:"clientEmail" binding:"omitempty,email"`
+	ClientPhone string             `json:"clientPhone"`
+	Title       string             `json:"title" binding:"required,max=255"`
+	Notes       string             `json:"notes"`
+	ValidUntil  string             `json:"validUntil"` // AAAA-MM-DD; vazio vale por 15 dias
+	Items       []QuoteItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// UpdateQuoteRequest define o JSON para atualizar um orçamento.
+type UpdateQuoteRequest struct {
+	CustomerID  *string            `json:"customerId"` // "" remove o vínculo com o cadastro
+	ClientName  *string            `json:"clientName"`
+	ClientEmail *string            `json:"clientEmail" binding:"omitempty,email"`
+	ClientPhone *string            `json:"clientPhone"`
+	Title       *string            `json:"title" binding:"omitempty,max=255"`
+	Notes       *string            `json:"notes"`
+	ValidUntil  *string            `json:"validUntil"`                           // AAAA-MM-DD
+	Items       []QuoteItemRequest `json:"items" binding:"omitempty,min=1,dive"` // Omitido mantém os itens atuais
+}
+
+// RejectQuoteRequest define o JSON opcional da recusa de um orçamento.
+type RejectQuoteRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ConvertQuoteAppointmentRequest define um agendamento gerado na conversão do orçamento.
+type ConvertQuoteAppointmentRequest struct {
+	ItemIDs        []uuid.UUID `json:"itemIds"` // Vazio, com um único agendamento, inclui todos os itens
+	StartTime      time.Time   `json:"startTime" binding:"required"`
+	EndTime        *time.Time  `json:"endTime"` // Omitido usa a duração dos serviços do catálogo
+	ProfessionalID *uuid.UUID  `json:"professionalId"`
+}
+
+// ConvertQuoteRequest define o JSON da conversão de um orçamento em agendamentos.
+type ConvertQuoteRequest struct {
+	Appointments []ConvertQuoteAppointmentRequest `json:"appointments" binding:"required,min=1,dive"`
+}
+
+// QuoteItemResponse define o JSON retornado para uma linha do orçamento.
+type QuoteItemResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ServiceID   *uuid.UUID `json:"serviceId,omitempty"`
+	Description string     `json:"description"`
+	Quantity    int        `json:"quantity"`
+	UnitPrice   float64    `json:"unitPrice"`
+	Total       float64    `json:"total"`
+}
+
+// QuoteResponse define o JSON retornado para um orçamento.
+type QuoteResponse struct {
+	ID             uuid.UUID           `json:"id"`
+	Number         int                 `json:"number"`
+	CustomerID     *uuid.UUID          `json:"customerId,omitempty"`
+	ClientName     string              `json:"clientName"`
+	ClientEmail    string              `json:"clientEmail,omitempty"`
+	ClientPhone    string              `json:"clientPhone,omitempty"`
+	Title          string              `json:"title"`
+	Notes          string              `json:"notes,omitempty"`
+	Items          []QuoteItemResponse `json:"items"`
+	Total          float64             `json:"total"`
+	ValidUntil     string              `json:"validUntil"`
+	Status         string              `json:"status"`
+	PublicURL      string              `json:"publicUrl"`
+	SentAt         *time.Time          `json:"sentAt,omitempty"`
+	RespondedAt    *time.Time          `json:"respondedAt,omitempty"`
+	RejectReason   string              `json:"rejectReason,omitempty"`
+	ConvertedAt    *time.Time          `json:"convertedAt,omitempty"`
+	AppointmentIDs []uuid.UUID         `json:"appointmentIds,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt"`
+	UpdatedAt      time.Time           `json:"updatedAt"`
+}
+
+// PublicQuoteResponse define o JSON exibido ao cliente no link público do orçamento.
+type PublicQuoteResponse struct {
+	Number      int                 `json:"number"`
+	ClientName  string              `json:"clientName"`
+	Title       string              `json:"title"`
+	Notes       string              `json:"notes,omitempty"`
+	Items       []QuoteItemResponse `json:"items"`
+	Total       float64             `json:"total"`
+	ValidUntil  string              `json:"validUntil"`
+	Status      string              `json:"status"`
+	RespondedAt *time.Time          `json:"respondedAt,omitempty"`
+}
+
+// ConvertQuoteResponse define o JSON retornado pela conversão do orçamento.
+type ConvertQuoteResponse struct {
+	Quote        QuoteResponse         `json:"quote"`
+	Appointments []AppointmentResponse `json:"appointments"`
+}
+
+// --- QuoteHandler ---
+type QuoteHandler struct {
+	quoteUseCase *usecase.QuoteUseCase
+}
+
+func NewQuoteHandler(uc *usecase.QuoteUseCase) *QuoteHandler {
+	return &QuoteHandler{quoteUseCase: uc}
+}
+
+func mapQuoteItemsToResponse(items []entity.QuoteItem) []QuoteItemResponse {
+	responses := make([]QuoteItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = QuoteItemResponse{
+			ID:          item.ID,
+			ServiceID:   item.ServiceID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Total:       item.Total(),
+		}
+	}
+	return responses
+}
+
+func (h *QuoteHandler) mapQuoteToResponse(q *entity.Quote) QuoteResponse {
+	return QuoteResponse{
+		ID:             q.ID,
+		Number:         q.Number,
+		CustomerID:     q.CustomerID,
+		ClientName:     q.ClientName,
+		ClientEmail:    q.ClientEmail,
+		ClientPhone:    q.ClientPhone,
+		Title:          q.Title,
+		Notes:          q.Notes,
+		Items:          mapQuoteItemsToResponse(q.Items),
+		Total:          q.Total(),
+		ValidUntil:     q.ValidUntil.Format("2006-01-02"),
+		Status:         string(q.Status),
+		PublicURL:      h.quoteUseCase.PublicURL(q),
+		SentAt:         q.SentAt,
+		RespondedAt:    q.RespondedAt,
+		RejectReason:   q.RejectReason,
+		ConvertedAt:    q.ConvertedAt,
+		AppointmentIDs: q.AppointmentIDs,
+		CreatedAt:      q.CreatedAt,
+		UpdatedAt:      q.UpdatedAt,
+	}
+}
+
+func mapPublicQuoteToResponse(q *entity.Quote) PublicQuoteResponse {
+	return PublicQuoteResponse{
+		Number:      q.Number,
+		ClientName:  q.ClientName,
+		Title:       q.Title,
+		Notes:       q.Notes,
+		Items:       mapQuoteItemsToResponse(q.Items),
+		Total:       q.Total(),
+		ValidUntil:  q.ValidUntil.Format("2006-01-02"),
+		Status:      string(q.Status),
+		RespondedAt: q.RespondedAt,
+	}
+}
+
+// mapQuoteItemsToInput converte as linhas da requisição para o caso de uso.
+func mapQuoteItemsToInput(items []QuoteItemRequest) []usecase.QuoteItemInputDTO {
+	if items == nil {
+		return nil
+	}
+	inputs := make([]usecase.QuoteItemInputDTO, len(items))
+	for i, item := range items {
+		inputs[i] = usecase.QuoteItemInputDTO{
+			ServiceID:   item.ServiceID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+		}
+	}
+	return inputs
+}
+
+// quoteErrorStatus mapeia os erros dos casos de uso de orçamento para o status HTTP.
+func quoteErrorStatus(err error) int {
+	switch {
+	case err.Error() == "orçamento não encontrado":
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrQuoteStatus):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidQuote), errors.Is(err, usecase.ErrInvalidCoupon):
+		return http.StatusBadRequest
+	}
+	switch err.Error() {
+	case "serviço não encontrado", "cliente não encontrado", "profissional não encontrado":
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// parseQuoteID lê o ID do orçamento da rota, respondendo 400 se for inválido.
+func parseQuoteID(c *gin.Context) (uuid.UUID, bool) {
+	quoteID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do orçamento inválido"})
+		return uuid.Nil, false
+	}
+	return quoteID, true
+}
+
+// CreateQuote godoc
+// @Summary      Cria um orçamento
+// @Description  O orçamento é criado em rascunho (DRAFT) com numeração sequencial. Itens com serviço do catálogo herdam descrição e preço se não forem informados.
+// @Tags         quotes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        quote body CreateQuoteRequest true "Dados do Orçamento"
+// @Success      201  {object} QuoteResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /quotes [post]
+func (h *QuoteHandler) CreateQuote(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	input := usecase.CreateQuoteInputDTO{
+		UserID:      requestingUserID,
+		CustomerID:  req.CustomerID,
+		ClientName:  req.ClientName,
+		ClientEmail: req.ClientEmail,
+		ClientPhone: req.ClientPhone,
+		Title:       req.Title,
+		Notes:       req.Notes,
+		Items:       mapQuoteItemsToInput(req.Items),
+	}
+	if req.ValidUntil != "" {
+		validUntil, err := time.ParseInLocation("2006-01-02", req.ValidUntil, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validUntil inválido, use AAAA-MM-DD"})
+			return
+		}
+		input.ValidUntil = validUntil
+	}
+
+	quote, err := h.quoteUseCase.CreateQuote(input)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao criar orçamento: ", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapQuoteToResponse(quote))
+}
+
+// ListQuotes godoc
+// @Summary      Lista os orçamentos
+// @Tags         quotes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "Filtra por status (DRAFT, SENT, ACCEPTED, REJECTED, EXPIRED)"
+// @Success      200  {array}  QuoteResponse
+// @Router       /quotes [get]
+func (h *QuoteHandler) ListQuotes(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	status := entity.QuoteStatus(c.Query("status"))
+	switch status {
+	case "", entity.QuoteStatusDraft, entity.QuoteStatusSent, entity.QuoteStatusAccepted,
+		entity.QuoteStatusRejected, entity.QuoteStatusExpired:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status inválido"})
+		return
+	}
+
+	quotes, err := h.quoteUseCase.ListQuotes(requestingUserID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar orçamentos: " + err.Error()})
+		return
+	}
+
+	responses := make([]QuoteResponse, len(quotes))
+	for i, q := range quotes {
+		responses[i] = h.mapQuoteToResponse(q)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetQuoteByID godoc
+// @Summary      Busca um orçamento
+// @Tags         quotes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Orçamento (UUID)"
+// @Success      200  {object} QuoteResponse
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Router       /quotes/{id} [get]
+func (h *QuoteHandler) GetQuoteByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	quoteID, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	quote, err := h.quoteUseCase.GetQuoteByID(quoteID, requestingUserID)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Erro ao buscar orçamento: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapQuoteToResponse(quote))
+}
+
+// UpdateQuote godoc
+// @Summary      Atualiza um orçamento
+// @Description  Apenas orçamentos em rascunho ou enviados podem ser alterados. Informar items substitui todas as linhas.
+// @Tags         quotes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Orçamento (UUID)"
+// @Param        quote body UpdateQuoteRequest true "Dados para Atualização"
+// @Success      200  {object} QuoteResponse
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Failure      409  {object} map[string]string "Orçamento já respondido"
+// @Router       /quotes/{id} [put]
+func (h *QuoteHandler) UpdateQuote(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	quoteID, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	input := usecase.UpdateQuoteInputDTO{
+		ClientName:  req.ClientName,
+		ClientEmail: req.ClientEmail,
+		ClientPhone: req.ClientPhone,
+		Title:       req.Title,
+		Notes:       req.Notes,
+		Items:       mapQuoteItemsToInput(req.Items),
+	}
+	if req.CustomerID != nil {
+		if *req.CustomerID == "" {
+			input.ClearCustomer = true
+		} else {
+			customerID, err := uuid.Parse(*req.CustomerID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "customerId inválido"})
+				return
+			}
+			input.CustomerID = &customerID
+		}
+	}
+	if req.ValidUntil != nil {
+		validUntil, err := time.ParseInLocation("2006-01-02", *req.ValidUntil, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "validUntil inválido, use AAAA-MM-DD"})
+			return
+		}
+		input.ValidUntil = &validUntil
+	}
+
+	quote, err := h.quoteUseCase.UpdateQuote(quoteID, requestingUserID, input)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao atualizar orçamento: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapQuoteToResponse(quote))
+}
+
+// DeleteQuote godoc
+// @Summary      Exclui um orçamento
+// @Description  Orçamentos já convertidos em agendamentos não podem ser excluídos.
+// @Tags         quotes
+// @Security     BearerAuth
+// @Param        id path string true "ID do Orçamento (UUID)"
+// @Success      204
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Failure      409  {object} map[string]string "Orçamento já convertido"
+// @Router       /quotes/{id} [delete]
+func (h *QuoteHandler) DeleteQuote(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	quoteID, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.quoteUseCase.DeleteQuote(quoteID, requestingUserID); err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao excluir orçamento: ", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SendQuote godoc
+// @Summary      Marca um orçamento como enviado
+// @Description  Libera o link público (publicUrl) para o cliente aceitar ou recusar. Pode ser repetido para reenviar.
+// @Tags         quotes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Orçamento (UUID)"
+// @Success      200  {object} QuoteResponse
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Failure      409  {object} map[string]string "Orçamento já respondido"
+// @Router       /quotes/{id}/send [patch]
+func (h *QuoteHandler) SendQuote(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	quoteID, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	quote, err := h.quoteUseCase.SendQuote(quoteID, requestingUserID)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao enviar orçamento: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapQuoteToResponse(quote))
+}
+
+// AcceptQuote godoc
+// @Summary      Registra o aceite de um orçamento
+// @Description  Para aceites recebidos fora do link público (ex: por telefone).
+// @Tags         quotes
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Orçamento (UUID)"
+// @Success      200  {object} QuoteResponse
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Failure      409  {object} map[string]string "Orçamento não enviado, vencido ou já respondido"
+// @Router       /quotes/{id}/accept [patch]
+func (h *QuoteHandler) AcceptQuote(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	quoteID, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	quote, err := h.quoteUseCase.AcceptQuote(quoteID, requestingUserID)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao aceitar orçamento: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapQuoteToResponse(quote))
+}
+
+// RejectQuote godoc
+// @Summary      Registra a recusa de um orçamento
+// @Description  Para recusas recebidas fora do link público.
+// @Tags         quotes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Orçamento (UUID)"
+// @Param        reject body RejectQuoteRequest false "Motivo da recusa"
+// @Success      200  {object} QuoteResponse
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Failure      409  {object} map[string]string "Orçamento não enviado, vencido ou já respondido"
+// @Router       /quotes/{id}/reject [patch]
+func (h *QuoteHandler) RejectQuote(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	quoteID, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	var req RejectQuoteRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+			return
+		}
+	}
+
+	quote, err := h.quoteUseCase.RejectQuote(quoteID, requestingUserID, req.Reason)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao recusar orçamento: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapQuoteToResponse(quote))
+}
+
+// GetQuotePDF godoc
+// @Summary      Exporta o orçamento em PDF
+// @Tags         quotes
+// @Security     BearerAuth
+// @Produce      application/pdf
+// @Param        id path string true "ID do Orçamento (UUID)"
+// @Success      200  {file}   file
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Router       /quotes/{id}/pdf [get]
+func (h *QuoteHandler) GetQuotePDF(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	quoteID, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	quote, err := h.quoteUseCase.GetQuoteByID(quoteID, requestingUserID)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Erro ao buscar orçamento: ", err)
+		return
+	}
+	h.writeQuotePDF(c, quote)
+}
+
+// ConvertQuote godoc
+// @Summary      Converte um orçamento aceito em agendamentos
+// @Description  Cada item do orçamento deve estar em exatamente um agendamento; com um único agendamento sem itemIds, todos os itens são incluídos. Cada agendamento gera uma previsão de receita.
+// @Tags         quotes
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Orçamento (UUID)"
+// @Param        conversion body ConvertQuoteRequest true "Agendamentos a gerar"
+// @Success      201  {object} ConvertQuoteResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Failure      409  {object} map[string]string "Orçamento não aceito ou já convertido"
+// @Router       /quotes/{id}/convert [post]
+func (h *QuoteHandler) ConvertQuote(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+	quoteID, ok := parseQuoteID(c)
+	if !ok {
+		return
+	}
+
+	var req ConvertQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	slots := make([]usecase.QuoteAppointmentInputDTO, len(req.Appointments))
+	for i, a := range req.Appointments {
+		slots[i] = usecase.QuoteAppointmentInputDTO{
+			ItemIDs:        a.ItemIDs,
+			StartTime:      a.StartTime,
+			ProfessionalID: a.ProfessionalID,
+		}
+		if a.EndTime != nil {
+			slots[i].EndTime = *a.EndTime
+		}
+	}
+
+	quote, appointments, err := h.quoteUseCase.ConvertQuote(quoteID, requestingUserID, slots)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao converter orçamento: ", err)
+		return
+	}
+
+	response := ConvertQuoteResponse{
+		Quote:        h.mapQuoteToResponse(quote),
+		Appointments: make([]AppointmentResponse, len(appointments)),
+	}
+	for i, a := range appointments {
+		response.Appointments[i] = mapAppointmentEntityToResponse(a)
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// --- Link público ---
+
+// GetPublicQuote godoc
+// @Summary      Exibe um orçamento pelo link público
+// @Description  Não exige autenticação; o token do link identifica o orçamento.
+// @Tags         public
+// @Produce      json
+// @Param        token path string true "Token do link público"
+// @Success      200  {object} PublicQuoteResponse
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Router       /public/quotes/{token} [get]
+func (h *QuoteHandler) GetPublicQuote(c *gin.Context) {
+	quote, err := h.quoteUseCase.GetPublicQuote(c.Param("token"))
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Erro ao buscar orçamento: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapPublicQuoteToResponse(quote))
+}
+
+// GetPublicQuotePDF godoc
+// @Summary      Baixa o PDF de um orçamento pelo link público
+// @Tags         public
+// @Produce      application/pdf
+// @Param        token path string true "Token do link público"
+// @Success      200  {file}   file
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Router       /public/quotes/{token}/pdf [get]
+func (h *QuoteHandler) GetPublicQuotePDF(c *gin.Context) {
+	quote, err := h.quoteUseCase.GetPublicQuote(c.Param("token"))
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Erro ao buscar orçamento: ", err)
+		return
+	}
+	h.writeQuotePDF(c, quote)
+}
+
+// AcceptPublicQuote godoc
+// @Summary      Aceita um orçamento pelo link público
+// @Tags         public
+// @Produce      json
+// @Param        token path string true "Token do link público"
+// @Success      200  {object} PublicQuoteResponse
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Failure      409  {object} map[string]string "Orçamento vencido ou já respondido"
+// @Router       /public/quotes/{token}/accept [post]
+func (h *QuoteHandler) AcceptPublicQuote(c *gin.Context) {
+	quote, err := h.quoteUseCase.AcceptPublicQuote(c.Param("token"))
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao aceitar orçamento: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapPublicQuoteToResponse(quote))
+}
+
+// RejectPublicQuote godoc
+// @Summary      Recusa um orçamento pelo link público
+// @Tags         public
+// @Accept       json
+// @Produce      json
+// @Param        token path string true "Token do link público"
+// @Param        reject body RejectQuoteRequest false "Motivo da recusa"
+// @Success      200  {object} PublicQuoteResponse
+// @Failure      404  {object} map[string]string "Orçamento não encontrado"
+// @Failure      409  {object} map[string]string "Orçamento vencido ou já respondido"
+// @Router       /public/quotes/{token}/reject [post]
+func (h *QuoteHandler) RejectPublicQuote(c *gin.Context) {
+	var req RejectQuoteRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+			return
+		}
+	}
+
+	quote, err := h.quoteUseCase.RejectPublicQuote(c.Param("token"), req.Reason)
+	if err != nil {
+		respondError(c, quoteErrorStatus, "Falha ao recusar orçamento: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapPublicQuoteToResponse(quote))
+}
+
+// writeQuotePDF responde o PDF do orçamento para download.
+func (h *QuoteHandler) writeQuotePDF(c *gin.Context, quote *entity.Quote) {
+	data, err := h.quoteUseCase.RenderQuotePDF(quote)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao gerar PDF do orçamento: " + err.Error()})
+		return
+	}
+	filename := fmt.Sprintf("orcamento-%d.pdf", quote.Number)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/pdf", data)
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Lembretes ---
+
+// ReminderRuleRequest define uma regra de lembrete no JSON de entrada.
+type ReminderRuleRequest struct {
+	OffsetMinutes int    `json:"offsetMinutes" binding:"required,gt=0"` // Antecedência (ex: 1440 = 24h)
+	Channel       string `json:"channel" binding:"required"`            // EMAIL, SMS ou WHATSAPP
+	Subject       string `json:"subject"`                               // Template do assunto; vazio usa o padrão
+	Template      string `json:"template"`                              // Template da mensagem; vazio usa o padrão
+	Active        *bool  `json:"active"`                                // Padrão: true
+}
+
+// SaveReminderRulesRequest define o JSON esperado para salvar as regras de lembrete.
+type SaveReminderRulesRequest struct {
+	Rules []ReminderRuleRequest `json:"rules" binding:"required,dive"`
+}
+
+// ReminderRuleResponse define o JSON retornado para uma regra de lembrete.
+type ReminderRuleResponse struct {
+	ID            *uuid.UUID `json:"id,omitempty"` // Ausente nas regras padrão
+	OffsetMinutes int        `json:"offsetMinutes"`
+	Channel       string     `json:"channel"`
+	Subject       string     `json:"subject,omitempty"`
+	Template      string     `json:"template,omitempty"`
+	Active        bool       `json:"active"`
+}
+
+// ReminderResponse define o JSON retornado para um lembrete agendado.
+type ReminderResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	AppointmentID     uuid.UUID  `json:"appointmentId"`
+	Channel           string     `json:"channel"`
+	OffsetMinutes     int        `json:"offsetMinutes"`
+	Recipient         string     `json:"recipient"`
+	ScheduledFor      time.Time  `json:"scheduledFor"`
+	Status            string     `json:"status"`
+	Attempts          int        `json:"attempts"`
+	Subject           string     `json:"subject,omitempty"`
+	Message           string     `json:"message,omitempty"`
+	ProviderMessageID string     `json:"providerMessageId,omitempty"`
+	LastError         string     `json:"lastError,omitempty"`
+	SentAt            *time.Time `json:"sentAt,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+// --- ReminderHandler ---
+type ReminderHandler struct {
+	reminderUseCase *usecase.ReminderUseCase
+}
+
+func NewReminderHandler(uc *usecase.ReminderUseCase) *ReminderHandler {
+	return &ReminderHandler{reminderUseCase: uc}
+}
+
+func mapReminderRulesToResponse(rules []*entity.ReminderRule) []ReminderRuleResponse {
+	responses := make([]ReminderRuleResponse, len(rules))
+	for i, rule := range rules {
+		var id *uuid.UUID
+		if rule.ID != uuid.Nil {
+			ruleID := rule.ID
+			id = &ruleID
+		}
+		responses[i] = ReminderRuleResponse{
+			ID:            id,
+			OffsetMinutes: rule.OffsetMinutes,
+			Channel:       string(rule.Channel),
+			Subject:       rule.Subject,
+			Template:      rule.Template,
+			Active:        rule.Active,
+		}
+	}
+	return responses
+}
+
+func mapReminderToResponse(r *entity.Reminder) ReminderResponse {
+	return ReminderResponse{
+		ID:                r.ID,
+		AppointmentID:     r.AppointmentID,
+		Channel:           string(r.Channel),
+		OffsetMinutes:     r.OffsetMinutes,
+		Recipient:         r.Recipient,
+		ScheduledFor:      r.ScheduledFor,
+		Status:            string(r.Status),
+		Attempts:          r.Attempts,
+		Subject:           r.Subject,
+		Message:           r.Message,
+		ProviderMessageID: r.ProviderMessageID,
+		LastError:         r.LastError,
+		SentAt:            r.SentAt,
+		CreatedAt:         r.CreatedAt,
+		UpdatedAt:         r.UpdatedAt,
+	}
+}
+
+// GetReminderRules godoc
+// @Summary      Lista as regras de lembrete
+// @Description  Retorna as regras configuradas ou, se não houver nenhuma, as regras padrão (24h e 2h antes do agendamento).
+// @Tags         reminders
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  ReminderRuleResponse
+// @Router       /reminders/rules [get]
+func (h *ReminderHandler) GetReminderRules(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	rules, err := h.reminderUseCase.GetRules(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mapReminderRulesToResponse(rules))
+}
+
+// SaveReminderRules godoc
+// @Summary      Salva as regras de lembrete
+// @Description  Substitui todas as regras e reagenda os lembretes dos agendamentos futuros. Os templates usam a sintaxe do Go (text/template) com os campos {{.ClientName}}, {{.Service}}, {{.BusinessName}}, {{.Date}} e {{.Time}}.
+// @Tags         reminders
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        rules body SaveReminderRulesRequest true "Regras de Lembrete"
+// @Success      200  {array}  ReminderRuleResponse
+// @Failure      400  {object} map[string]string "Regras inválidas"
+// @Router       /reminders/rules [put]
+func (h *ReminderHandler) SaveReminderRules(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req SaveReminderRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	inputs := make([]usecase.SaveReminderRuleInputDTO, len(req.Rules))
+	for i, rule := range req.Rules {
+		active := true
+		if rule.Active != nil {
+			active = *rule.Active
+		}
+		inputs[i] = usecase.SaveReminderRuleInputDTO{
+			OffsetMinutes: rule.OffsetMinutes,
+			Channel:       entity.ReminderChannel(rule.Channel),
+			Subject:       rule.Subject,
+			Template:      rule.Template,
+			Active:        active,
+		}
+	}
+
+	rules, err := h.reminderUseCase.SaveRules(requestingUserID, inputs)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidReminderRule) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao salvar regras de lembrete: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, mapReminderRulesToResponse(rules))
+}
+
+// ListReminders godoc
+// @Summary      Lista os lembretes agendados e o status de entrega
+// @Tags         reminders
+// @Security     BearerAuth
+// @Produce      json
+// @Param        appointmentId query string false "Filtra pelo agendamento"
+// @Param        status        query string false "Filtra por status (SCHEDULED, SENT, FAILED, CANCELLED)"
+// @Success      200  {array}  ReminderResponse
+// @Failure      400  {object} map[string]string "Filtro inválido"
+// @Router       /reminders [get]
+func (h *ReminderHandler) ListReminders(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var appointmentID *uuid.UUID
+	if s := c.Query("appointmentId"); s != "" {
+		parsed, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "appointmentId inválido"})
+			return
+		}
+		appointmentID = &parsed
+	}
+	status := entity.ReminderStatus(strings.ToUpper(c.Query("status")))
+	switch status {
+	case "", entity.ReminderStatusScheduled, entity.ReminderStatusSent, entity.ReminderStatusFailed, entity.ReminderStatusCancelled:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status inválido"})
+		return
+	}
+
+	reminders, err := h.reminderUseCase.ListReminders(requestingUserID, appointmentID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar lembretes: " + err.Error()})
+		return
+	}
+
+	responses := make([]ReminderResponse, len(reminders))
+	for i, r := range reminders {
+		responses[i] = mapReminderToResponse(r)
+	}
+	c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// --- DTOs para Reports ---
+
+// MonthlyRevenueResponse define o JSON do faturamento de um mês.
+type MonthlyRevenueResponse struct {
+	Month               int     `json:"month"`
+	ServicesInvoiced    float64 `json:"servicesInvoiced"`
+	ServicesNotInvoiced float64 `json:"servicesNotInvoiced"`
+	Services            float64 `json:"services"`
+	CommerceInvoiced    float64 `json:"commerceInvoiced"`
+	CommerceNotInvoiced float64 `json:"commerceNotInvoiced"`
+	Commerce            float64 `json:"commerce"`
+	Total               float64 `json:"total"`
+}
+
+// AnnualRevenueReportResponse define o JSON do relatório anual de faturamento.
+type AnnualRevenueReportResponse struct {
+	Year         int                      `json:"year"`
+	BusinessName string                   `json:"businessName"`
+	Regime       string                   `json:"regime,omitempty"`
+	Months       []MonthlyRevenueResponse `json:"months"`
+	Totals       MonthlyRevenueResponse   `json:"totals"`
+	GeneratedAt  time.Time                `json:"generatedAt"`
+}
+
+// --- ReportHandler ---
+type ReportHandler struct {
+	reportUseCase *usecase.ReportUseCase
+}
+
+func NewReportHandler(uc *usecase.ReportUseCase) *ReportHandler {
+	return &ReportHandler{reportUseCase: uc}
+}
+
+func mapMonthlyRevenueToResponse(m usecase.MonthlyRevenue) MonthlyRevenueResponse {
+	return MonthlyRevenueResponse{
+		Month:               int(m.Month),
+		ServicesInvoiced:    m.ServicesInvoiced,
+		ServicesNotInvoiced: m.ServicesNotInvoiced,
+		Services:            m.Services(),
+		CommerceInvoiced:    m.CommerceInvoiced,
+		CommerceNotInvoiced: m.CommerceNotInvoiced,
+		Commerce:            m.Commerce(),
+		Total:               m.Total(),
+	}
+}
+
+// GetAnnualRevenueReport godoc
+// @Summary      Relatório anual de faturamento (DASN-SIMEI)
+// @Description  Faturamento por mês, separado entre serviços e comércio/indústria e entre receita com e sem nota fiscal.
+// @Tags         reports
+// @Security     BearerAuth
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/pdf
+// @Param        year query int false "Ano (padrão: ano anterior, o da declaração)"
+// @Param        format query string false "json (padrão), csv ou pdf"
+// @Success      200  {object} AnnualRevenueReportResponse
+// @Failure      400  {object} map[string]string "Parâmetros inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Failure      500  {object} map[string]string "Erro interno"
+// @Router       /reports/annual-revenue [get]
+func (h *ReportHandler) GetAnnualRevenueReport(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	year := time.Now().Year() - 1
+	if y := c.Query("year"); y != "" {
+		parsed, err := strconv.Atoi(y)
+		if err != nil || parsed < 2000 || parsed > 9999 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de year inválido, use AAAA"})
+			return
+		}
+		year = parsed
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Formato inválido, use json, csv ou pdf"})
+		return
+	}
+
+	report, err := h.reportUseCase.GetAnnualRevenueReport(requestingUserID, year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar relatório: " + err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("faturamento-%d.%s", year, format)
+	switch format {
+	case "csv":
+		data, err := usecase.AnnualRevenueReportCSV(report)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao exportar relatório: " + err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+	case "pdf":
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "application/pdf", usecase.AnnualRevenueReportPDF(report))
+	default:
+		months := make([]MonthlyRevenueResponse, len(report.Months))
+		for i, m := range report.Months {
+			months[i] = mapMonthlyRevenueToResponse(m)
+		}
+		c.JSON(http.StatusOK, AnnualRevenueReportResponse{
+			Year:         report.Year,
+			BusinessName: report.BusinessName,
+			Regime:       string(report.Regime),
+			Months:       months,
+			Totals:       mapMonthlyRevenueToResponse(report.Totals),
+			GeneratedAt:  report.GeneratedAt,
+		})
+	}
+}
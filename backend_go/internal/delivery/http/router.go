@@ -13,6 +13,32 @@ func SetupRoutes(
 	userHandler *UserHandler,
 	appointmentHandler *AppointmentHandler,
 	clientHandler *ClientHandler, // Adicionado
+	paymentHandler *PaymentHandler,
+	financeHandler *FinanceHandler,
+	taxHandler *TaxHandler,
+	reportHandler *ReportHandler,
+	catalogHandler *CatalogHandler,
+	commissionHandler *CommissionHandler,
+	packageHandler *PackageHandler,
+	membershipHandler *MembershipHandler,
+	couponHandler *CouponHandler,
+	giftCardHandler *GiftCardHandler,
+	quoteHandler *QuoteHandler,
+	invoiceHandler *InvoiceHandler,
+	checkoutHandler *CheckoutHandler,
+	productHandler *ProductHandler,
+	saleHandler *SaleHandler,
+	dashboardHandler *DashboardHandler,
+	reminderHandler *ReminderHandler,
+	whatsAppHandler *WhatsAppHandler,
+	emailHandler *EmailHandler,
+	eventHandler *EventHandler,
+	webhookHandler *WebhookHandler,
+	pushHandler *PushHandler,
+	notificationHandler *NotificationHandler,
+	agendaStreamHandler *AgendaStreamHandler,
+	calendarHandler *CalendarHandler,
+	calendarSyncHandler *CalendarSyncHandler,
 ) {
 	authMW := middleware.AuthMiddleware(cfg)
 
@@ -44,6 +70,11 @@ func SetupRoutes(
 			appointmentRoutes.PUT("/:id", appointmentHandler.UpdateAppointment)
 			appointmentRoutes.PATCH("/:id/cancel", appointmentHandler.CancelAppointment) // Usando PATCH para mudança de status
 			appointmentRoutes.DELETE("/:id", appointmentHandler.DeleteAppointment) // Adicionado rota DELETE
+			appointmentRoutes.POST("/:id/payments", paymentHandler.CreateAppointmentPayment)
+			appointmentRoutes.GET("/:id/payments", paymentHandler.ListAppointmentPayments)
+			appointmentRoutes.POST("/:id/checkout", checkoutHandler.CheckoutAppointment)
+			appointmentRoutes.GET("/:id/checkout", checkoutHandler.GetAppointmentCheckout)
+			appointmentRoutes.GET("/:id/ics", calendarHandler.DownloadAppointmentICS)
 		}
 
 		// Rotas de Cliente (todas protegidas)
@@ -55,6 +86,354 @@ func SetupRoutes(
 			clientRoutes.GET("/:id", clientHandler.GetClientByID)
 			clientRoutes.PUT("/:id", clientHandler.UpdateClient)
 			clientRoutes.DELETE("/:id", clientHandler.DeleteClient)
+			clientRoutes.POST("/:id/packages", packageHandler.SellPackage)
+			clientRoutes.GET("/:id/packages", packageHandler.ListClientPackages)
+			clientRoutes.POST("/:id/memberships", membershipHandler.Subscribe)
+			clientRoutes.GET("/:id/memberships", membershipHandler.ListCustomerSubscriptions)
+		}
+
+		// Rotas de Pagamento (protegidas)
+		paymentRoutes := apiV1.Group("/payments")
+		paymentRoutes.Use(authMW)
+		{
+			paymentRoutes.GET("/:id", paymentHandler.GetPaymentByID)
+		}
+
+		// Rotas Financeiras (livro-caixa, categorias, despesas recorrentes e orçamentos)
+		financeRoutes := apiV1.Group("/finance")
+		financeRoutes.Use(authMW)
+		{
+			financeRoutes.POST("/entries", financeHandler.CreateEntry)
+			financeRoutes.GET("/entries", financeHandler.ListEntries)
+			financeRoutes.GET("/entries/:id", financeHandler.GetEntryByID)
+			financeRoutes.PUT("/entries/:id", financeHandler.UpdateEntry)
+			financeRoutes.DELETE("/entries/:id", financeHandler.DeleteEntry)
+
+			financeRoutes.POST("/categories", financeHandler.CreateCategory)
+			financeRoutes.GET("/categories", financeHandler.ListCategories)
+			financeRoutes.PUT("/categories/:id", financeHandler.UpdateCategory)
+			financeRoutes.DELETE("/categories/:id", financeHandler.DeleteCategory)
+
+			financeRoutes.POST("/recurring-expenses", financeHandler.CreateRecurringExpense)
+			financeRoutes.GET("/recurring-expenses", financeHandler.ListRecurringExpenses)
+			financeRoutes.PUT("/recurring-expenses/:id", financeHandler.UpdateRecurringExpense)
+			financeRoutes.DELETE("/recurring-expenses/:id", financeHandler.DeleteRecurringExpense)
+
+			financeRoutes.GET("/budgets", financeHandler.GetBudgetStatus)
+			financeRoutes.GET("/budget-alerts", financeHandler.ListBudgetAlerts)
+
+			financeRoutes.GET("/forecast", financeHandler.GetIncomeForecast)
+		}
+
+		// Rotas Tributárias (perfil e teto de faturamento)
+		taxRoutes := apiV1.Group("/tax")
+		taxRoutes.Use(authMW)
+		{
+			taxRoutes.GET("/profile", taxHandler.GetTaxProfile)
+			taxRoutes.PUT("/profile", taxHandler.SaveTaxProfile)
+			taxRoutes.GET("/revenue-limit", taxHandler.GetRevenueLimitStatus)
+			taxRoutes.GET("/alerts", taxHandler.ListRevenueLimitAlerts)
+		}
+
+		// Rotas do Catálogo de Serviços
+		serviceRoutes := apiV1.Group("/services")
+		serviceRoutes.Use(authMW)
+		{
+			serviceRoutes.POST("", catalogHandler.CreateService)
+			serviceRoutes.GET("", catalogHandler.ListServices)
+			serviceRoutes.GET("/:id", catalogHandler.GetServiceByID)
+			serviceRoutes.PUT("/:id", catalogHandler.UpdateService)
+			serviceRoutes.DELETE("/:id", catalogHandler.DeleteService)
+		}
+
+		// Rotas de Profissionais e suas comissões
+		professionalRoutes := apiV1.Group("/professionals")
+		professionalRoutes.Use(authMW)
+		{
+			professionalRoutes.POST("", catalogHandler.CreateProfessional)
+			professionalRoutes.GET("", catalogHandler.ListProfessionals)
+			professionalRoutes.GET("/:id", catalogHandler.GetProfessionalByID)
+			professionalRoutes.PUT("/:id", catalogHandler.UpdateProfessional)
+			professionalRoutes.DELETE("/:id", catalogHandler.DeleteProfessional)
+			professionalRoutes.GET("/:id/commissions", commissionHandler.GetStatement)
+			professionalRoutes.POST("/:id/commissions/payouts", commissionHandler.PayCommissions)
+			professionalRoutes.GET("/:id/commissions/payouts", commissionHandler.ListPayouts)
+		}
+
+		// Rotas de Regras de Comissão
+		commissionRuleRoutes := apiV1.Group("/commission-rules")
+		commissionRuleRoutes.Use(authMW)
+		{
+			commissionRuleRoutes.POST("", commissionHandler.CreateRule)
+			commissionRuleRoutes.GET("", commissionHandler.ListRules)
+			commissionRuleRoutes.PUT("/:id", commissionHandler.UpdateRule)
+			commissionRuleRoutes.DELETE("/:id", commissionHandler.DeleteRule)
+		}
+
+		// Rotas de Pacotes de Sessões
+		packageRoutes := apiV1.Group("/packages")
+		packageRoutes.Use(authMW)
+		{
+			packageRoutes.POST("", packageHandler.CreatePackage)
+			packageRoutes.GET("", packageHandler.ListPackages)
+			packageRoutes.GET("/:id", packageHandler.GetPackageByID)
+			packageRoutes.PUT("/:id", packageHandler.UpdatePackage)
+			packageRoutes.DELETE("/:id", packageHandler.DeletePackage)
+		}
+
+		// Rotas de Planos de Assinatura e assinaturas dos clientes
+		membershipPlanRoutes := apiV1.Group("/membership-plans")
+		membershipPlanRoutes.Use(authMW)
+		{
+			membershipPlanRoutes.POST("", membershipHandler.CreatePlan)
+			membershipPlanRoutes.GET("", membershipHandler.ListPlans)
+			membershipPlanRoutes.GET("/:id", membershipHandler.GetPlanByID)
+			membershipPlanRoutes.PUT("/:id", membershipHandler.UpdatePlan)
+			membershipPlanRoutes.DELETE("/:id", membershipHandler.DeletePlan)
+		}
+
+		membershipRoutes := apiV1.Group("/memberships")
+		membershipRoutes.Use(authMW)
+		{
+			membershipRoutes.GET("/:id", membershipHandler.GetSubscriptionByID)
+			membershipRoutes.PATCH("/:id/cancel", membershipHandler.CancelSubscription)
+			membershipRoutes.GET("/:id/usage", membershipHandler.GetCurrentUsage)
+			membershipRoutes.GET("/:id/cycles", membershipHandler.ListCycles)
+		}
+
+		// Rotas de Cupons de Desconto
+		couponRoutes := apiV1.Group("/coupons")
+		couponRoutes.Use(authMW)
+		{
+			couponRoutes.POST("", couponHandler.CreateCoupon)
+			couponRoutes.GET("", couponHandler.ListCoupons)
+			couponRoutes.GET("/:id", couponHandler.GetCouponByID)
+			couponRoutes.PUT("/:id", couponHandler.UpdateCoupon)
+			couponRoutes.DELETE("/:id", couponHandler.DeleteCoupon)
+			couponRoutes.GET("/:id/redemptions", couponHandler.ListRedemptions)
+		}
+
+		// Rotas de Vales-Presente
+		giftCardRoutes := apiV1.Group("/gift-cards")
+		giftCardRoutes.Use(authMW)
+		{
+			giftCardRoutes.POST("", giftCardHandler.IssueGiftCard)
+			giftCardRoutes.GET("", giftCardHandler.ListGiftCards)
+			giftCardRoutes.GET("/lookup", giftCardHandler.LookupGiftCard)
+			giftCardRoutes.GET("/:id", giftCardHandler.GetGiftCardByID)
+			giftCardRoutes.GET("/:id/transactions", giftCardHandler.ListGiftCardTransactions)
+			giftCardRoutes.PATCH("/:id/cancel", giftCardHandler.CancelGiftCard)
+		}
+
+		// Rotas de Orçamentos
+		quoteRoutes := apiV1.Group("/quotes")
+		quoteRoutes.Use(authMW)
+		{
+			quoteRoutes.POST("", quoteHandler.CreateQuote)
+			quoteRoutes.GET("", quoteHandler.ListQuotes)
+			quoteRoutes.GET("/:id", quoteHandler.GetQuoteByID)
+			quoteRoutes.PUT("/:id", quoteHandler.UpdateQuote)
+			quoteRoutes.DELETE("/:id", quoteHandler.DeleteQuote)
+			quoteRoutes.PATCH("/:id/send", quoteHandler.SendQuote)
+			quoteRoutes.PATCH("/:id/accept", quoteHandler.AcceptQuote)
+			quoteRoutes.PATCH("/:id/reject", quoteHandler.RejectQuote)
+			quoteRoutes.GET("/:id/pdf", quoteHandler.GetQuotePDF)
+			quoteRoutes.POST("/:id/convert", quoteHandler.ConvertQuote)
+		}
+
+		// Rotas de Notas Fiscais (NFS-e)
+		invoiceRoutes := apiV1.Group("/invoices")
+		invoiceRoutes.Use(authMW)
+		{
+			invoiceRoutes.GET("/settings", invoiceHandler.GetInvoiceSettings)
+			invoiceRoutes.PUT("/settings", invoiceHandler.SaveInvoiceSettings)
+			invoiceRoutes.POST("", invoiceHandler.IssueInvoice)
+			invoiceRoutes.GET("", invoiceHandler.ListInvoices)
+			invoiceRoutes.GET("/:id", invoiceHandler.GetInvoiceByID)
+			invoiceRoutes.GET("/:id/xml", invoiceHandler.GetInvoiceXML)
+			invoiceRoutes.POST("/:id/retry", invoiceHandler.RetryInvoice)
+			invoiceRoutes.PATCH("/:id/cancel", invoiceHandler.CancelInvoice)
+		}
+
+		// Rotas de Produtos e Estoque
+		productRoutes := apiV1.Group("/products")
+		productRoutes.Use(authMW)
+		{
+			productRoutes.POST("", productHandler.CreateProduct)
+			productRoutes.GET("", productHandler.ListProducts)
+			productRoutes.GET("/low-stock", productHandler.ListLowStockProducts)
+			productRoutes.GET("/alerts", productHandler.ListLowStockAlerts)
+			productRoutes.GET("/:id", productHandler.GetProductByID)
+			productRoutes.PUT("/:id", productHandler.UpdateProduct)
+			productRoutes.DELETE("/:id", productHandler.DeleteProduct)
+			productRoutes.POST("/:id/movements", productHandler.RecordStockMovement)
+			productRoutes.GET("/:id/movements", productHandler.ListStockMovements)
+		}
+
+		// Rotas de Vendas de Balcão
+		saleRoutes := apiV1.Group("/sales")
+		saleRoutes.Use(authMW)
+		{
+			saleRoutes.POST("", saleHandler.CreateSale)
+			saleRoutes.GET("", saleHandler.ListSales)
+			saleRoutes.GET("/:id", saleHandler.GetSaleByID)
+			saleRoutes.GET("/:id/receipt", saleHandler.GetSaleReceipt)
+		}
+
+		// Rotas de Caixa
+		cashRegisterRoutes := apiV1.Group("/cash-registers")
+		cashRegisterRoutes.Use(authMW)
+		{
+			cashRegisterRoutes.POST("", saleHandler.OpenCashRegister)
+			cashRegisterRoutes.GET("", saleHandler.ListCashRegisters)
+			cashRegisterRoutes.GET("/current", saleHandler.GetCurrentCashRegister)
+			cashRegisterRoutes.GET("/:id", saleHandler.GetCashRegisterByID)
+			cashRegisterRoutes.POST("/:id/close", saleHandler.CloseCashRegister)
+		}
+
+		// Rotas do Painel de Indicadores
+		dashboardRoutes := apiV1.Group("/dashboard")
+		dashboardRoutes.Use(authMW)
+		{
+			dashboardRoutes.GET("/summary", dashboardHandler.GetSummary)
+			dashboardRoutes.GET("/revenue", dashboardHandler.GetRevenueSeries)
+			dashboardRoutes.GET("/top-services", dashboardHandler.GetTopServices)
+			dashboardRoutes.GET("/top-clients", dashboardHandler.GetTopClients)
+			dashboardRoutes.GET("/working-hours", dashboardHandler.GetWorkingHours)
+			dashboardRoutes.PUT("/working-hours", dashboardHandler.SaveWorkingHours)
+		}
+
+		// Rotas de Lembretes de Agendamento
+		reminderRoutes := apiV1.Group("/reminders")
+		reminderRoutes.Use(authMW)
+		{
+			reminderRoutes.GET("", reminderHandler.ListReminders)
+			reminderRoutes.GET("/rules", reminderHandler.GetReminderRules)
+			reminderRoutes.PUT("/rules", reminderHandler.SaveReminderRules)
+		}
+
+		// Rotas de E-mails aos Clientes
+		emailRoutes := apiV1.Group("/emails")
+		emailRoutes.Use(authMW)
+		{
+			emailRoutes.GET("/settings", emailHandler.GetEmailSettings)
+			emailRoutes.PUT("/settings", emailHandler.SaveEmailSettings)
+			emailRoutes.GET("/preview", emailHandler.PreviewEmail)
+			emailRoutes.GET("/outbox", emailHandler.ListOutboundEmails)
+			emailRoutes.POST("/outbox/:id/retry", emailHandler.RetryOutboundEmail)
+		}
+
+		// Rotas de Eventos de Domínio
+		eventRoutes := apiV1.Group("/events")
+		eventRoutes.Use(authMW)
+		{
+			eventRoutes.GET("", eventHandler.ListDomainEvents)
+			eventRoutes.GET("/deliveries", eventHandler.ListEventDeliveries)
+			eventRoutes.POST("/deliveries/:id/retry", eventHandler.RetryEventDelivery)
+		}
+
+		// Rotas de Webhooks de saída (integrações dos usuários)
+		integrationRoutes := apiV1.Group("/integrations/webhooks")
+		integrationRoutes.Use(authMW)
+		{
+			integrationRoutes.GET("/event-types", webhookHandler.ListWebhookEventTypes)
+			integrationRoutes.POST("", webhookHandler.CreateWebhook)
+			integrationRoutes.GET("", webhookHandler.ListWebhooks)
+			integrationRoutes.GET("/:id", webhookHandler.GetWebhook)
+			integrationRoutes.PUT("/:id", webhookHandler.UpdateWebhook)
+			integrationRoutes.DELETE("/:id", webhookHandler.DeleteWebhook)
+			integrationRoutes.POST("/:id/rotate-secret", webhookHandler.RotateWebhookSecret)
+			integrationRoutes.GET("/:id/deliveries", webhookHandler.ListWebhookDeliveries)
+			integrationRoutes.GET("/:id/deliveries/:deliveryId", webhookHandler.GetWebhookDelivery)
+			integrationRoutes.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverWebhook)
+		}
+
+		// Rotas de Notificações Push
+		pushRoutes := apiV1.Group("/push")
+		pushRoutes.Use(authMW)
+		{
+			pushRoutes.POST("/devices", pushHandler.RegisterPushDevice)
+			pushRoutes.GET("/devices", pushHandler.ListPushDevices)
+			pushRoutes.POST("/devices/unregister", pushHandler.UnregisterPushDevice)
+			pushRoutes.DELETE("/devices/:id", pushHandler.DeletePushDevice)
+			pushRoutes.GET("/preferences", pushHandler.GetPushPreferences)
+			pushRoutes.PUT("/preferences", pushHandler.UpdatePushPreferences)
+			pushRoutes.POST("/test", pushHandler.SendTestPush)
+		}
+		// Servidor fake do FCM, para testar as notificações offline
+		if pushHandler.fake != nil {
+			apiV1.Any("/fake/push/*path", pushHandler.ServeFakePush)
+		}
+
+		// Agenda em tempo real (Server-Sent Events)
+		agendaRoutes := apiV1.Group("/agenda")
+		agendaRoutes.Use(authMW)
+		{
+			agendaRoutes.GET("/stream", agendaStreamHandler.StreamAgenda)
+		}
+
+		// Feed iCalendar da agenda, para assinar no calendário do celular
+		calendarRoutes := apiV1.Group("/calendar")
+		calendarRoutes.Use(authMW)
+		{
+			calendarRoutes.GET("/feed", calendarHandler.GetCalendarFeed)
+			calendarRoutes.POST("/feed/rotate", calendarHandler.RotateCalendarFeed)
+			// Sincronização com o calendário externo (CalDAV)
+			calendarRoutes.GET("/connection", calendarSyncHandler.GetCalendarConnection)
+			calendarRoutes.PUT("/connection", calendarSyncHandler.ConnectCalendar)
+			calendarRoutes.DELETE("/connection", calendarSyncHandler.DisconnectCalendar)
+			calendarRoutes.POST("/connection/sync", calendarSyncHandler.SyncCalendar)
+			calendarRoutes.GET("/busy", calendarSyncHandler.ListCalendarBusyBlocks)
+		}
+		// Servidor CalDAV fake, para testar a sincronização de calendário offline
+		if calendarSyncHandler.fake != nil {
+			apiV1.Any("/fake/caldav/*path", calendarSyncHandler.ServeFakeCalDAV)
+		}
+
+		// Rotas da Caixa de Avisos
+		notificationRoutes := apiV1.Group("/notifications")
+		notificationRoutes.Use(authMW)
+		{
+			notificationRoutes.GET("", notificationHandler.ListNotifications)
+			notificationRoutes.GET("/unread-count", notificationHandler.GetUnreadNotificationCount)
+			notificationRoutes.POST("/read-all", notificationHandler.MarkAllNotificationsRead)
+			notificationRoutes.POST("/:id/read", notificationHandler.MarkNotificationRead)
+		}
+
+		// Rotas de WhatsApp
+		whatsAppRoutes := apiV1.Group("/whatsapp")
+		whatsAppRoutes.Use(authMW)
+		{
+			whatsAppRoutes.GET("/messages", whatsAppHandler.ListWhatsAppMessages)
+		}
+		// Servidor fake da API do WhatsApp, para testar o fluxo completo offline
+		if whatsAppHandler.fake != nil {
+			apiV1.Any("/fake/whatsapp/*path", whatsAppHandler.ServeFakeWhatsApp)
+		}
+
+		// Rotas de Relatórios
+		reportRoutes := apiV1.Group("/reports")
+		reportRoutes.Use(authMW)
+		{
+			reportRoutes.GET("/annual-revenue", reportHandler.GetAnnualRevenueReport)
+		}
+
+		// Webhooks de provedores externos (públicos, autenticados por assinatura HMAC)
+		webhookRoutes := apiV1.Group("/webhooks")
+		{
+			webhookRoutes.POST("/payments/:provider", paymentHandler.HandlePaymentWebhook)
+			webhookRoutes.GET("/whatsapp", whatsAppHandler.VerifyWhatsAppWebhook)
+			webhookRoutes.POST("/whatsapp", whatsAppHandler.HandleWhatsAppWebhook)
+		}
+
+		// Links públicos enviados aos clientes (sem autenticação, identificados por token)
+		publicRoutes := apiV1.Group("/public")
+		{
+			publicRoutes.GET("/quotes/:token", quoteHandler.GetPublicQuote)
+			publicRoutes.GET("/quotes/:token/pdf", quoteHandler.GetPublicQuotePDF)
+			publicRoutes.POST("/quotes/:token/accept", quoteHandler.AcceptPublicQuote)
+			publicRoutes.POST("/quotes/:token/reject", quoteHandler.RejectPublicQuote)
+			publicRoutes.GET("/calendar/:token", calendarHandler.GetPublicCalendarFeed)
 		}
 	}
 
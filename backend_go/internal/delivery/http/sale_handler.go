@@ -0,0 +1,513 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Sale ---
+
+// SaleItemRequest define um item da venda de balcão.
+type SaleItemRequest struct {
+	Kind        string     `json:"kind" binding:"required,oneof=PRODUCT SERVICE PACKAGE"`
+	ProductID   *uuid.UUID `json:"productId" binding:"required_if=Kind PRODUCT"`
+	ServiceID   *uuid.UUID `json:"serviceId"` // Opcional; sem ele, description e unitPrice são obrigatórios
+	PackageID   *uuid.UUID `json:"packageId" binding:"required_if=Kind PACKAGE"`
+	Description string     `json:"description"`
+	Quantity    int        `json:"quantity" binding:"gte=0"`            // Se omitido, 1
+	UnitPrice   *float64   `json:"unitPrice" binding:"omitempty,gte=0"` // Se omitido, usa o preço cadastrado
+}
+
+// SalePaymentRequest define uma forma de pagamento da venda.
+type SalePaymentRequest struct {
+	Method string  `json:"method" binding:"required,oneof=PIX CARD CASH"`
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// CreateSaleRequest define o JSON esperado para registrar uma venda de balcão.
+type CreateSaleRequest struct {
+	ClientID       *uuid.UUID           `json:"clientId"`
+	ProfessionalID *uuid.UUID           `json:"professionalId"`
+	Items          []SaleItemRequest    `json:"items" binding:"required,min=1,dive"`
+	Payments       []SalePaymentRequest `json:"payments" binding:"dive"`
+	Notes          string               `json:"notes"`
+}
+
+// SaleItemResponse define o JSON retornado para um item da venda.
+type SaleItemResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	Kind            string     `json:"kind"`
+	ProductID       *uuid.UUID `json:"productId,omitempty"`
+	ServiceID       *uuid.UUID `json:"serviceId,omitempty"`
+	PackageID       *uuid.UUID `json:"packageId,omitempty"`
+	ClientPackageID *uuid.UUID `json:"clientPackageId,omitempty"`
+	Description     string     `json:"description"`
+	Quantity        int        `json:"quantity"`
+	UnitPrice       float64    `json:"unitPrice"`
+	Total           float64    `json:"total"`
+}
+
+// SaleResponse define o JSON retornado para uma venda de balcão.
+type SaleResponse struct {
+	ID             uuid.UUID          `json:"id"`
+	Number         int                `json:"number"`
+	ClientID       *uuid.UUID         `json:"clientId,omitempty"`
+	ProfessionalID *uuid.UUID         `json:"professionalId,omitempty"`
+	CashRegisterID *uuid.UUID         `json:"cashRegisterId,omitempty"`
+	Total          float64            `json:"total"`
+	Notes          string             `json:"notes,omitempty"`
+	Items          []SaleItemResponse `json:"items"`
+	Payments       []PaymentResponse  `json:"payments"`
+	SoldAt         time.Time          `json:"soldAt"`
+}
+
+// OpenCashRegisterRequest define o JSON esperado para abrir o caixa.
+type OpenCashRegisterRequest struct {
+	OpeningAmount float64 `json:"openingAmount" binding:"gte=0"` // Fundo de troco
+	Notes         string  `json:"notes"`
+}
+
+// CloseCashRegisterRequest define o JSON esperado para fechar o caixa.
+type CloseCashRegisterRequest struct {
+	CountedAmount *float64 `json:"countedAmount" binding:"required,gte=0"` // Dinheiro contado na gaveta
+	Notes         string   `json:"notes"`
+}
+
+// CashRegisterResponse define o JSON retornado para um caixa.
+type CashRegisterResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Status         string     `json:"status"`
+	OpeningAmount  float64    `json:"openingAmount"`
+	CashReceived   float64    `json:"cashReceived"`
+	ExpectedAmount float64    `json:"expectedAmount"`
+	CountedAmount  *float64   `json:"countedAmount,omitempty"`
+	Difference     *float64   `json:"difference,omitempty"` // Negativo indica falta na gaveta
+	OpenedAt       time.Time  `json:"openedAt"`
+	ClosedAt       *time.Time `json:"closedAt,omitempty"`
+	Notes          string     `json:"notes,omitempty"`
+}
+
+// --- SaleHandler ---
+type SaleHandler struct {
+	saleUseCase *usecase.SaleUseCase
+}
+
+func NewSaleHandler(uc *usecase.SaleUseCase) *SaleHandler {
+	return &SaleHandler{saleUseCase: uc}
+}
+
+func mapSaleToResponse(sale *entity.Sale) SaleResponse {
+	items := make([]SaleItemResponse, len(sale.Items))
+	for i, item := range sale.Items {
+		items[i] = SaleItemResponse{
+			ID:              item.ID,
+			Kind:            string(item.Kind),
+			ProductID:       item.ProductID,
+			ServiceID:       item.ServiceID,
+			PackageID:       item.PackageID,
+			ClientPackageID: item.ClientPackageID,
+			Description:     item.Description,
+			Quantity:        item.Quantity,
+			UnitPrice:       item.UnitPrice,
+			Total:           item.Total(),
+		}
+	}
+	payments := make([]PaymentResponse, len(sale.Payments))
+	for i, p := range sale.Payments {
+		payments[i] = mapPaymentEntityToResponse(p)
+	}
+	return SaleResponse{
+		ID:             sale.ID,
+		Number:         sale.Number,
+		ClientID:       sale.ClientID,
+		ProfessionalID: sale.ProfessionalID,
+		CashRegisterID: sale.CashRegisterID,
+		Total:          sale.Total,
+		Notes:          sale.Notes,
+		Items:          items,
+		Payments:       payments,
+		SoldAt:         sale.SoldAt,
+	}
+}
+
+func mapCashRegisterToResponse(r *entity.CashRegister) CashRegisterResponse {
+	return CashRegisterResponse{
+		ID:             r.ID,
+		Status:         string(r.Status),
+		OpeningAmount:  r.OpeningAmount,
+		CashReceived:   r.CashReceived,
+		ExpectedAmount: r.ExpectedAmount,
+		CountedAmount:  r.CountedAmount,
+		Difference:     r.Difference,
+		OpenedAt:       r.OpenedAt,
+		ClosedAt:       r.ClosedAt,
+		Notes:          r.Notes,
+	}
+}
+
+// saleErrorStatus mapeia os erros dos casos de uso de venda e caixa para o status HTTP.
+func saleErrorStatus(err error) int {
+	switch {
+	case err.Error() == "venda não encontrada", err.Error() == "caixa não encontrado":
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrCashRegisterStatus):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidSale):
+		return http.StatusBadRequest
+	}
+	switch err.Error() {
+	case "cliente não encontrado", "profissional não encontrado", "produto não encontrado",
+		"serviço não encontrado", "pacote não encontrado", "pacote inativo não pode ser vendido":
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// parseSalePeriod interpreta o período das listagens de vendas (AAAA-MM-DD, com a data
+// final inclusiva). Sem datas, retorna o dia atual.
+func parseSalePeriod(fromStr, toStr string) (time.Time, time.Time, error) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 1)
+	if fromStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromStr, time.Local)
+		if err != nil {
+			return from, to, errors.New("formato de from inválido, use AAAA-MM-DD")
+		}
+		from = parsed
+		if toStr == "" {
+			to = from.AddDate(0, 0, 1)
+		}
+	}
+	if toStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toStr, time.Local)
+		if err != nil {
+			return from, to, errors.New("formato de to inválido, use AAAA-MM-DD")
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+	return from, to, nil
+}
+
+// CreateSale godoc
+// @Summary      Registra uma venda de balcão
+// @Description  Venda sem agendamento de produtos (baixa o estoque), serviços avulsos e pacotes (exige cliente). Os pagamentos (PIX, cartão ou dinheiro) são registrados como recebidos e devem somar o total da venda. A venda fica vinculada ao caixa aberto, se houver.
+// @Tags         sales
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        sale body CreateSaleRequest true "Itens e Pagamentos"
+// @Success      201  {object} SaleResponse
+// @Failure      400  {object} map[string]string "Dados inválidos, pagamentos não conferem com o total ou estoque insuficiente"
+// @Router       /sales [post]
+func (h *SaleHandler) CreateSale(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	items := make([]usecase.SaleItemInputDTO, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = usecase.SaleItemInputDTO{
+			Kind:        entity.SaleItemKind(item.Kind),
+			ProductID:   item.ProductID,
+			ServiceID:   item.ServiceID,
+			PackageID:   item.PackageID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+		}
+	}
+	payments := make([]usecase.SalePaymentInputDTO, len(req.Payments))
+	for i, p := range req.Payments {
+		payments[i] = usecase.SalePaymentInputDTO{
+			Method: entity.PaymentMethod(p.Method),
+			Amount: p.Amount,
+		}
+	}
+
+	sale, err := h.saleUseCase.CreateSale(usecase.CreateSaleInputDTO{
+		UserID:         requestingUserID,
+		ClientID:       req.ClientID,
+		ProfessionalID: req.ProfessionalID,
+		Items:          items,
+		Payments:       payments,
+		Notes:          req.Notes,
+	})
+	if err != nil {
+		respondError(c, saleErrorStatus, "Falha ao registrar venda: ", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapSaleToResponse(sale))
+}
+
+// ListSales godoc
+// @Summary      Lista as vendas de balcão
+// @Tags         sales
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string false "Data inicial (AAAA-MM-DD); padrão: hoje"
+// @Param        to   query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success      200  {array}  SaleResponse
+// @Router       /sales [get]
+func (h *SaleHandler) ListSales(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	from, to, err := parseSalePeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sales, err := h.saleUseCase.ListSales(requestingUserID, from, to)
+	if err != nil {
+		respondError(c, saleErrorStatus, "Erro ao listar vendas: ", err)
+		return
+	}
+
+	responses := make([]SaleResponse, len(sales))
+	for i, sale := range sales {
+		responses[i] = mapSaleToResponse(sale)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetSaleByID godoc
+// @Summary      Busca uma venda de balcão
+// @Tags         sales
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Venda (UUID)"
+// @Success      200  {object} SaleResponse
+// @Failure      404  {object} map[string]string "Venda não encontrada"
+// @Router       /sales/{id} [get]
+func (h *SaleHandler) GetSaleByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da venda inválido"})
+		return
+	}
+
+	sale, err := h.saleUseCase.GetSaleByID(saleID, requestingUserID)
+	if err != nil {
+		respondError(c, saleErrorStatus, "Erro ao buscar venda: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapSaleToResponse(sale))
+}
+
+// GetSaleReceipt godoc
+// @Summary      Exporta o recibo da venda em PDF
+// @Tags         sales
+// @Security     BearerAuth
+// @Produce      application/pdf
+// @Param        id path string true "ID da Venda (UUID)"
+// @Success      200  {file}   file
+// @Failure      404  {object} map[string]string "Venda não encontrada"
+// @Router       /sales/{id}/receipt [get]
+func (h *SaleHandler) GetSaleReceipt(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	saleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da venda inválido"})
+		return
+	}
+
+	sale, err := h.saleUseCase.GetSaleByID(saleID, requestingUserID)
+	if err != nil {
+		respondError(c, saleErrorStatus, "Erro ao buscar venda: ", err)
+		return
+	}
+	data, err := h.saleUseCase.RenderSaleReceipt(sale)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao gerar recibo: " + err.Error()})
+		return
+	}
+	filename := fmt.Sprintf("recibo-%d.pdf", sale.Number)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// OpenCashRegister godoc
+// @Summary      Abre o caixa do dia
+// @Description  Só pode haver um caixa aberto por vez.
+// @Tags         cash-registers
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        register body OpenCashRegisterRequest true "Fundo de Troco"
+// @Success      201  {object} CashRegisterResponse
+// @Failure      409  {object} map[string]string "Já existe um caixa aberto"
+// @Router       /cash-registers [post]
+func (h *SaleHandler) OpenCashRegister(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req OpenCashRegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	register, err := h.saleUseCase.OpenCashRegister(requestingUserID, req.OpeningAmount, req.Notes)
+	if err != nil {
+		respondError(c, saleErrorStatus, "Falha ao abrir caixa: ", err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapCashRegisterToResponse(register))
+}
+
+// ListCashRegisters godoc
+// @Summary      Lista os caixas abertos e fechados
+// @Tags         cash-registers
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  CashRegisterResponse
+// @Router       /cash-registers [get]
+func (h *SaleHandler) ListCashRegisters(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	registers, err := h.saleUseCase.ListCashRegisters(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar caixas: " + err.Error()})
+		return
+	}
+
+	responses := make([]CashRegisterResponse, len(registers))
+	for i, r := range registers {
+		responses[i] = mapCashRegisterToResponse(r)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetCurrentCashRegister godoc
+// @Summary      Busca o caixa aberto
+// @Description  Inclui o dinheiro recebido desde a abertura e o valor esperado na gaveta até o momento.
+// @Tags         cash-registers
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} CashRegisterResponse
+// @Failure      404  {object} map[string]string "Nenhum caixa aberto"
+// @Router       /cash-registers/current [get]
+func (h *SaleHandler) GetCurrentCashRegister(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	register, err := h.saleUseCase.GetCurrentCashRegister(requestingUserID)
+	if err != nil {
+		respondError(c, saleErrorStatus, "Erro ao buscar caixa: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapCashRegisterToResponse(register))
+}
+
+// GetCashRegisterByID godoc
+// @Summary      Busca um caixa
+// @Tags         cash-registers
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID do Caixa (UUID)"
+// @Success      200  {object} CashRegisterResponse
+// @Failure      404  {object} map[string]string "Caixa não encontrado"
+// @Router       /cash-registers/{id} [get]
+func (h *SaleHandler) GetCashRegisterByID(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	registerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do caixa inválido"})
+		return
+	}
+
+	register, err := h.saleUseCase.GetCashRegisterByID(registerID, requestingUserID)
+	if err != nil {
+		respondError(c, saleErrorStatus, "Erro ao buscar caixa: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapCashRegisterToResponse(register))
+}
+
+// CloseCashRegister godoc
+// @Summary      Fecha o caixa com a conferência do dinheiro
+// @Description  Compara o dinheiro contado com o esperado (fundo de troco mais os pagamentos em dinheiro recebidos com o caixa aberto) e registra a diferença.
+// @Tags         cash-registers
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID do Caixa (UUID)"
+// @Param        closing body CloseCashRegisterRequest true "Dinheiro Contado"
+// @Success      200  {object} CashRegisterResponse
+// @Failure      404  {object} map[string]string "Caixa não encontrado"
+// @Failure      409  {object} map[string]string "Caixa já fechado"
+// @Router       /cash-registers/{id}/close [post]
+func (h *SaleHandler) CloseCashRegister(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	registerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do caixa inválido"})
+		return
+	}
+
+	var req CloseCashRegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	register, err := h.saleUseCase.CloseCashRegister(registerID, requestingUserID, *req.CountedAmount, req.Notes)
+	if err != nil {
+		respondError(c, saleErrorStatus, "Falha ao fechar caixa: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapCashRegisterToResponse(register))
+}
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Tax ---
+
+// SaveTaxProfileRequest define o JSON para cadastrar ou atualizar o perfil tributário.
+type SaveTaxProfileRequest struct {
+	Regime             string   `json:"regime" binding:"required,oneof=MEI SIMPLES_NACIONAL AUTONOMO"`
+	AnnualRevenueLimit *float64 `json:"annualRevenueLimit"` // Se omitido, usa o teto padrão do regime
+	AlertThresholds    []int    `json:"alertThresholds"`    // Percentuais do teto (ex: [70, 90, 100])
+}
+
+// TaxProfileResponse define o JSON retornado para o perfil tributário.
+type TaxProfileResponse struct {
+	ID                 uuid.UUID `json:"id"`
+	Regime             string    `json:"regime"`
+	AnnualRevenueLimit float64   `json:"annualRevenueLimit"`
+	AlertThresholds    []int     `json:"alertThresholds"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+// RevenueLimitStatusResponse define o JSON do acompanhamento do teto de faturamento.
+type RevenueLimitStatusResponse struct {
+	Year              int      `json:"year"`
+	Regime            string   `json:"regime"`
+	Limit             float64  `json:"limit"`
+	Revenue           float64  `json:"revenue"`
+	Percent           float64  `json:"percent"`
+	ProjectedRevenue  float64  `json:"projectedRevenue"`
+	ProjectedPercent  float64  `json:"projectedPercent"`
+	ReachedThresholds []int    `json:"reachedThresholds"`
+	ProjectedToExceed bool     `json:"projectedToExceed"`
+	ExceedsTolerance  bool     `json:"exceedsTolerance"`
+	Warnings          []string `json:"warnings"`
+}
+
+// RevenueLimitAlertResponse define o JSON de um alerta de teto de faturamento.
+type RevenueLimitAlertResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Year      int       `json:"year"`
+	Threshold int       `json:"threshold"`
+	Revenue   float64   `json:"revenue"`
+	Limit     float64   `json:"limit"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// --- TaxHandler ---
+type TaxHandler struct {
+	taxUseCase *usecase.TaxUseCase
+}
+
+func NewTaxHandler(uc *usecase.TaxUseCase) *TaxHandler {
+	return &TaxHandler{taxUseCase: uc}
+}
+
+func mapTaxProfileToResponse(p *entity.TaxProfile) TaxProfileResponse {
+	return TaxProfileResponse{
+		ID:                 p.ID,
+		Regime:             string(p.Regime),
+		AnnualRevenueLimit: p.AnnualRevenueLimit,
+		AlertThresholds:    p.AlertThresholds,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
+
+// GetTaxProfile godoc
+// @Summary      Retorna o perfil tributário do usuário
+// @Tags         tax
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object} TaxProfileResponse
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Failure      404  {object} map[string]string "Perfil não cadastrado"
+// @Router       /tax/profile [get]
+func (h *TaxHandler) GetTaxProfile(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	profile, err := h.taxUseCase.GetProfile(requestingUserID)
+	if err != nil {
+		if err.Error() == "perfil tributário não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar perfil tributário: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapTaxProfileToResponse(profile))
+}
+
+// SaveTaxProfile godoc
+// @Summary      Cadastra ou atualiza o perfil tributário do usuário
+// @Tags         tax
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        profile body SaveTaxProfileRequest true "Dados do Perfil Tributário"
+// @Success      200  {object} TaxProfileResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      401  {object} map[string]string "Não autorizado"
+// @Router       /tax/profile [put]
+func (h *TaxHandler) SaveTaxProfile(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req SaveTaxProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	profile, err := h.taxUseCase.SaveProfile(usecase.SaveTaxProfileInputDTO{
+		UserID:             requestingUserID,
+		Regime:             entity.TaxRegime(req.Regime),
+		AnnualRevenueLimit: req.AnnualRevenueLimit,
+		AlertThresholds:    req.AlertThresholds,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Falha ao salvar perfil tributário: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapTaxProfileToResponse(profile))
+}
+
+// GetRevenueLimitStatus godoc
+// @Summary      Faturamento do ano, projeção e avisos de teto
+// @Tags         tax
+// @Security     BearerAuth
+// @Produce      json
+// @Param        year query int false "Ano (padrão: ano atual)"
+// @Success      200  {object} RevenueLimitStatusResponse
+// @Failure      400  {object} map[string]string "Ano inválido"
+// @Failure      404  {object} map[string]string "Perfil não cadastrado"
+// @Router       /tax/revenue-limit [get]
+func (h *TaxHandler) GetRevenueLimitStatus(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	now := time.Now()
+	year := now.Year()
+	if y := c.Query("year"); y != "" {
+		parsed, err := strconv.Atoi(y)
+		if err != nil || parsed < 2000 || parsed > 9999 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de year inválido, use AAAA"})
+			return
+		}
+		year = parsed
+	}
+
+	status, err := h.taxUseCase.GetRevenueLimitStatus(requestingUserID, year, now)
+	if err != nil {
+		if err.Error() == "perfil tributário não encontrado" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular faturamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, RevenueLimitStatusResponse{
+		Year:              status.Year,
+		Regime:            string(status.Regime),
+		Limit:             status.Limit,
+		Revenue:           status.Revenue,
+		Percent:           status.Percent,
+		ProjectedRevenue:  status.ProjectedRevenue,
+		ProjectedPercent:  status.ProjectedPercent,
+		ReachedThresholds: status.ReachedThresholds,
+		ProjectedToExceed: status.ProjectedToExceed,
+		ExceedsTolerance:  status.ExceedsTolerance,
+		Warnings:          status.Warnings,
+	})
+}
+
+// ListRevenueLimitAlerts godoc
+// @Summary      Lista os alertas de teto de faturamento
+// @Tags         tax
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  RevenueLimitAlertResponse
+// @Router       /tax/alerts [get]
+func (h *TaxHandler) ListRevenueLimitAlerts(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	alerts, err := h.taxUseCase.ListAlerts(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar alertas: " + err.Error()})
+		return
+	}
+
+	responses := make([]RevenueLimitAlertResponse, len(alerts))
+	for i, a := range alerts {
+		responses[i] = RevenueLimitAlertResponse{
+			ID:        a.ID,
+			Year:      a.Year,
+			Threshold: a.Threshold,
+			Revenue:   a.Revenue,
+			Limit:     a.Limit,
+			CreatedAt: a.CreatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, responses)
+}
@@ -0,0 +1,507 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para Webhooks ---
+
+// CreateWebhookRequest define o JSON esperado para cadastrar uma assinatura de webhook.
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	Description string   `json:"description"`
+	EventTypes  []string `json:"eventTypes"` // Vazio: todos os eventos (ver GET /integrations/webhooks/event-types)
+	Secret      string   `json:"secret"`     // Mínimo de 16 caracteres; se vazio, é gerado
+}
+
+// UpdateWebhookRequest define o JSON esperado para alterar uma assinatura de webhook.
+type UpdateWebhookRequest struct {
+	URL         *string   `json:"url"`
+	Description *string   `json:"description"`
+	EventTypes  *[]string `json:"eventTypes"`
+	Active      *bool     `json:"active"` // true reativa uma assinatura desativada automaticamente
+}
+
+// WebhookSubscriptionResponse define o JSON retornado para uma assinatura de webhook.
+type WebhookSubscriptionResponse struct {
+	ID                  uuid.UUID  `json:"id"`
+	URL                 string     `json:"url"`
+	Description         string     `json:"description,omitempty"`
+	EventTypes          []string   `json:"eventTypes"`
+	Secret              string     `json:"secret,omitempty"` // Apenas no cadastro e na troca de segredo
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+	DisabledReason      string     `json:"disabledReason,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+// WebhookDeliveryResponse define o JSON retornado para um envio de webhook.
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	EventID        uuid.UUID  `json:"eventId"`
+	EventType      string     `json:"eventType"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty"` // Apenas para envios pendentes
+	LastStatusCode int        `json:"lastStatusCode,omitempty"`
+	LastError      string     `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+	RedeliveryOf   *uuid.UUID `json:"redeliveryOf,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// WebhookAttemptResponse define o JSON retornado para uma tentativa de envio.
+type WebhookAttemptResponse struct {
+	AttemptedAt  time.Time `json:"attemptedAt"`
+	StatusCode   int       `json:"statusCode,omitempty"`
+	ResponseBody string    `json:"responseBody,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	DurationMs   int64     `json:"durationMs"`
+}
+
+// WebhookDeliveryDetailResponse define o JSON retornado para um envio com o corpo
+// enviado e o histórico de tentativas.
+type WebhookDeliveryDetailResponse struct {
+	WebhookDeliveryResponse
+	Body     json.RawMessage          `json:"body" swaggertype:"object"`
+	Attempts []WebhookAttemptResponse `json:"attemptLog"`
+}
+
+// --- WebhookHandler ---
+type WebhookHandler struct {
+	webhookUseCase *usecase.WebhookUseCase
+}
+
+func NewWebhookHandler(uc *usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{webhookUseCase: uc}
+}
+
+func mapWebhookSubscriptionToResponse(s *entity.WebhookSubscription, withSecret bool) WebhookSubscriptionResponse {
+	eventTypes := make([]string, len(s.EventTypes))
+	for i, t := range s.EventTypes {
+		eventTypes[i] = string(t)
+	}
+	resp := WebhookSubscriptionResponse{
+		ID:                  s.ID,
+		URL:                 s.URL,
+		Description:         s.Description,
+		EventTypes:          eventTypes,
+		Active:              s.Active,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+		DisabledReason:      s.DisabledReason,
+		CreatedAt:           s.CreatedAt,
+		UpdatedAt:           s.UpdatedAt,
+	}
+	if withSecret {
+		resp.Secret = s.Secret
+	}
+	return resp
+}
+
+func mapWebhookDeliveryToResponse(d *entity.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		RedeliveryOf:   d.RedeliveryOf,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == entity.WebhookDeliveryStatusPending {
+		next := d.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+	return resp
+}
+
+// webhookErrorStatus mapeia os erros do WebhookUseCase para o status HTTP.
+func webhookErrorStatus(err error) int {
+	switch {
+	case err.Error() == "assinatura não encontrada" || err.Error() == "envio não encontrado":
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidWebhook):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrWebhookDeliveryStatus):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// ListWebhookEventTypes godoc
+// @Summary      Lista os tipos de evento
+// @Description  Lista os tipos de evento que podem ser assinados por webhook.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  string
+// @Router       /integrations/webhooks/event-types [get]
+func (h *WebhookHandler) ListWebhookEventTypes(c *gin.Context) {
+	types := make([]string, len(entity.DomainEventTypes))
+	for i, t := range entity.DomainEventTypes {
+		types[i] = string(t)
+	}
+	c.JSON(http.StatusOK, types)
+}
+
+// CreateWebhook godoc
+// @Summary      Cadastra uma assinatura de webhook
+// @Description  Cadastra uma URL para receber os eventos via POST. Cada envio traz o cabeçalho X-Bizly-Signature ("t=<timestamp>,v1=<hex>"), onde v1 é o HMAC-SHA256 de "<timestamp>.<corpo>" com o segredo da assinatura, retornado apenas nesta resposta.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        webhook body CreateWebhookRequest true "Dados da Assinatura"
+// @Success      201  {object} WebhookSubscriptionResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Router       /integrations/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	subscription, err := h.webhookUseCase.CreateSubscription(requestingUserID, usecase.CreateWebhookInputDTO{
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  req.EventTypes,
+		Secret:      req.Secret,
+	})
+	if err != nil {
+		respondError(c, webhookErrorStatus, "Falha ao cadastrar webhook: ", err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapWebhookSubscriptionToResponse(subscription, true))
+}
+
+// ListWebhooks godoc
+// @Summary      Lista as assinaturas de webhook
+// @Description  Lista as assinaturas de webhook do usuário autenticado.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {array}  WebhookSubscriptionResponse
+// @Router       /integrations/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptions, err := h.webhookUseCase.ListSubscriptions(requestingUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar webhooks: " + err.Error()})
+		return
+	}
+
+	responses := make([]WebhookSubscriptionResponse, len(subscriptions))
+	for i, s := range subscriptions {
+		responses[i] = mapWebhookSubscriptionToResponse(s, false)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetWebhook godoc
+// @Summary      Busca uma assinatura de webhook
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Assinatura"
+// @Success      200  {object} WebhookSubscriptionResponse
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /integrations/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de assinatura inválido"})
+		return
+	}
+
+	subscription, err := h.webhookUseCase.GetSubscription(subscriptionID, requestingUserID)
+	if err != nil {
+		respondError(c, webhookErrorStatus, "Erro ao buscar webhook: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapWebhookSubscriptionToResponse(subscription, false))
+}
+
+// UpdateWebhook godoc
+// @Summary      Altera uma assinatura de webhook
+// @Description  Altera URL, descrição, tipos de evento ou o status. Reativar uma assinatura desativada automaticamente zera o contador de falhas; desativá-la encerra os envios pendentes.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID da Assinatura"
+// @Param        webhook body UpdateWebhookRequest true "Dados para Atualização"
+// @Success      200  {object} WebhookSubscriptionResponse
+// @Failure      400  {object} map[string]string "Dados inválidos"
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /integrations/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de assinatura inválido"})
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos na requisição", "details": err.Error()})
+		return
+	}
+
+	subscription, err := h.webhookUseCase.UpdateSubscription(subscriptionID, requestingUserID, usecase.UpdateWebhookInputDTO{
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  req.EventTypes,
+		Active:      req.Active,
+	})
+	if err != nil {
+		respondError(c, webhookErrorStatus, "Falha ao atualizar webhook: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapWebhookSubscriptionToResponse(subscription, false))
+}
+
+// RotateWebhookSecret godoc
+// @Summary      Troca o segredo de uma assinatura de webhook
+// @Description  Gera um novo segredo, retornado apenas nesta resposta. Os envios seguintes passam a ser assinados com ele.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "ID da Assinatura"
+// @Success      200  {object} WebhookSubscriptionResponse
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /integrations/webhooks/{id}/rotate-secret [post]
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de assinatura inválido"})
+		return
+	}
+
+	subscription, err := h.webhookUseCase.RotateSecret(subscriptionID, requestingUserID)
+	if err != nil {
+		respondError(c, webhookErrorStatus, "Falha ao trocar segredo: ", err)
+		return
+	}
+	c.JSON(http.StatusOK, mapWebhookSubscriptionToResponse(subscription, true))
+}
+
+// DeleteWebhook godoc
+// @Summary      Exclui uma assinatura de webhook
+// @Description  Exclui a assinatura com seu histórico de envios.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Param        id path string true "ID da Assinatura"
+// @Success      204  {string} string "No Content"
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /integrations/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de assinatura inválido"})
+		return
+	}
+
+	if err := h.webhookUseCase.DeleteSubscription(subscriptionID, requestingUserID); err != nil {
+		respondError(c, webhookErrorStatus, "Falha ao excluir webhook: ", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      Lista os envios de uma assinatura
+// @Description  Lista os envios da assinatura, dos mais recentes para os mais antigos.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id     path  string true  "ID da Assinatura"
+// @Param        status query string false "Filtra por status (PENDING, SUCCEEDED, FAILED)"
+// @Param        limit  query int    false "Número máximo de envios (padrão 50, máximo 500)"
+// @Success      200  {array}  WebhookDeliveryResponse
+// @Failure      400  {object} map[string]string "Filtro inválido"
+// @Failure      404  {object} map[string]string "Assinatura não encontrada"
+// @Router       /integrations/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de assinatura inválido"})
+		return
+	}
+	status := entity.WebhookDeliveryStatus(strings.ToUpper(c.Query("status")))
+	switch status {
+	case "", entity.WebhookDeliveryStatusPending, entity.WebhookDeliveryStatusSucceeded, entity.WebhookDeliveryStatusFailed:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status inválido"})
+		return
+	}
+	limit := 0
+	if s := c.Query("limit"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil || parsed <= 0 || parsed > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit deve ser um número entre 1 e 500"})
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(subscriptionID, requestingUserID, status, limit)
+	if err != nil {
+		respondError(c, webhookErrorStatus, "Erro ao listar envios: ", err)
+		return
+	}
+
+	responses := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		responses[i] = mapWebhookDeliveryToResponse(d)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetWebhookDelivery godoc
+// @Summary      Busca um envio de webhook
+// @Description  Retorna o envio com o corpo enviado e o histórico de tentativas (status HTTP, resposta e duração).
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id         path string true "ID da Assinatura"
+// @Param        deliveryId path string true "ID do Envio"
+// @Success      200  {object} WebhookDeliveryDetailResponse
+// @Failure      404  {object} map[string]string "Envio não encontrado"
+// @Router       /integrations/webhooks/{id}/deliveries/{deliveryId} [get]
+func (h *WebhookHandler) GetWebhookDelivery(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de assinatura inválido"})
+		return
+	}
+	deliveryID, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de envio inválido"})
+		return
+	}
+
+	delivery, attempts, err := h.webhookUseCase.GetDelivery(subscriptionID, deliveryID, requestingUserID)
+	if err != nil {
+		respondError(c, webhookErrorStatus, "Erro ao buscar envio: ", err)
+		return
+	}
+
+	resp := WebhookDeliveryDetailResponse{
+		WebhookDeliveryResponse: mapWebhookDeliveryToResponse(delivery),
+		Body:                    json.RawMessage(delivery.Body),
+		Attempts:                make([]WebhookAttemptResponse, len(attempts)),
+	}
+	for i, a := range attempts {
+		resp.Attempts[i] = WebhookAttemptResponse{
+			AttemptedAt:  a.AttemptedAt,
+			StatusCode:   a.StatusCode,
+			ResponseBody: a.ResponseBody,
+			Error:        a.Error,
+			DurationMs:   a.DurationMs,
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RedeliverWebhook godoc
+// @Summary      Reenvia um envio de webhook
+// @Description  Cria um novo envio com o mesmo corpo e o envia imediatamente. O ID do evento no corpo é mantido, para que o destino possa descartar duplicados.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id         path string true "ID da Assinatura"
+// @Param        deliveryId path string true "ID do Envio"
+// @Success      202  {object} WebhookDeliveryResponse
+// @Failure      404  {object} map[string]string "Envio não encontrado"
+// @Failure      409  {object} map[string]string "Envio ainda pendente ou assinatura desativada"
+// @Router       /integrations/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	subscriptionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de assinatura inválido"})
+		return
+	}
+	deliveryID, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de envio inválido"})
+		return
+	}
+
+	delivery, err := h.webhookUseCase.Redeliver(subscriptionID, deliveryID, requestingUserID)
+	if err != nil {
+		respondError(c, webhookErrorStatus, "Falha ao reenviar: ", err)
+		return
+	}
+	c.JSON(http.StatusAccepted, mapWebhookDeliveryToResponse(delivery))
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/delivery/http/middleware"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/messaging"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// --- DTOs para WhatsApp ---
+
+// WhatsAppMessageResponse define o JSON retornado para uma mensagem de WhatsApp.
+type WhatsAppMessageResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	AppointmentID     *uuid.UUID `json:"appointmentId,omitempty"`
+	Direction         string     `json:"direction"`
+	Kind              string     `json:"kind"`
+	Phone             string     `json:"phone"`
+	ProviderMessageID string     `json:"providerMessageId,omitempty"`
+	Body              string     `json:"body"`
+	Action            string     `json:"action,omitempty"`
+	Error             string     `json:"error,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+}
+
+// --- WhatsAppHandler ---
+type WhatsAppHandler struct {
+	whatsAppUseCase *usecase.WhatsAppUseCase
+	verifyToken     string
+	fake            http.Handler // Servidor fake da API; nil quando o provedor real é usado
+}
+
+// NewWhatsAppHandler cria o handler. verifyToken é o token configurado na assinatura do
+// webhook; fake, se informado, é exposto em /fake/whatsapp para testes offline.
+func NewWhatsAppHandler(uc *usecase.WhatsAppUseCase, verifyToken string, fake http.Handler) *WhatsAppHandler {
+	return &WhatsAppHandler{whatsAppUseCase: uc, verifyToken: verifyToken, fake: fake}
+}
+
+func mapWhatsAppMessageToResponse(m *entity.WhatsAppMessage) WhatsAppMessageResponse {
+	return WhatsAppMessageResponse{
+		ID:                m.ID,
+		AppointmentID:     m.AppointmentID,
+		Direction:         string(m.Direction),
+		Kind:              string(m.Kind),
+		Phone:             m.Phone,
+		ProviderMessageID: m.ProviderMessageID,
+		Body:              m.Body,
+		Action:            string(m.Action),
+		Error:             m.Error,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+// VerifyWhatsAppWebhook godoc
+// @Summary      Verificação da assinatura do webhook do WhatsApp
+// @Description  Rota pública chamada pela Meta ao cadastrar o webhook. Devolve hub.challenge se o token conferir.
+// @Tags         whatsapp
+// @Produce      plain
+// @Param        hub.mode         query string true "subscribe"
+// @Param        hub.verify_token query string true "Token de verificação configurado"
+// @Param        hub.challenge    query string true "Valor a ser devolvido"
+// @Success      200  {string} string "hub.challenge"
+// @Failure      403  {object} map[string]string "Token inválido"
+// @Router       /webhooks/whatsapp [get]
+func (h *WhatsAppHandler) VerifyWhatsAppWebhook(c *gin.Context) {
+	if h.verifyToken == "" || c.Query("hub.mode") != "subscribe" || c.Query("hub.verify_token") != h.verifyToken {
+		c.JSON(http.StatusForbidden, gin.H{"error": "token de verificação inválido"})
+		return
+	}
+	c.String(http.StatusOK, c.Query("hub.challenge"))
+}
+
+// HandleWhatsAppWebhook godoc
+// @Summary      Recebe as mensagens dos clientes pelo WhatsApp
+// @Description  Rota pública autenticada pela assinatura X-Hub-Signature-256. Respostas "1 - Confirmar" e "2 - Cancelar" confirmam ou cancelam o agendamento; mensagens repetidas são ignoradas.
+// @Tags         whatsapp
+// @Accept       json
+// @Produce      json
+// @Success      200  {object} map[string]string "Mensagens recebidas"
+// @Failure      400  {object} map[string]string "Payload inválido"
+// @Failure      401  {object} map[string]string "Assinatura inválida"
+// @Router       /webhooks/whatsapp [post]
+func (h *WhatsAppHandler) HandleWhatsAppWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Falha ao ler o corpo da requisição"})
+		return
+	}
+
+	if err := h.whatsAppUseCase.HandleWebhook(c.Request.Header, body); err != nil {
+		switch {
+		case errors.Is(err, messaging.ErrInvalidSignature):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, messaging.ErrInvalidPayload):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Printf("Erro ao processar webhook do WhatsApp: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Falha ao processar webhook: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "received"})
+}
+
+// ListWhatsAppMessages godoc
+// @Summary      Lista as mensagens de WhatsApp trocadas com os clientes
+// @Tags         whatsapp
+// @Security     BearerAuth
+// @Produce      json
+// @Param        appointmentId query string false "Filtra pelo agendamento"
+// @Success      200  {array}  WhatsAppMessageResponse
+// @Failure      400  {object} map[string]string "Filtro inválido"
+// @Router       /whatsapp/messages [get]
+func (h *WhatsAppHandler) ListWhatsAppMessages(c *gin.Context) {
+	requestingUserID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		return
+	}
+
+	var appointmentID *uuid.UUID
+	if s := c.Query("appointmentId"); s != "" {
+		parsed, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "appointmentId inválido"})
+			return
+		}
+		appointmentID = &parsed
+	}
+
+	messages, err := h.whatsAppUseCase.ListMessages(requestingUserID, appointmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar mensagens: " + err.Error()})
+		return
+	}
+
+	responses := make([]WhatsAppMessageResponse, len(messages))
+	for i, m := range messages {
+		responses[i] = mapWhatsAppMessageToResponse(m)
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// ServeFakeWhatsApp encaminha as requisições ao servidor fake da API do WhatsApp:
+// POST /fake/whatsapp/{phoneNumberID}/messages (envios), GET /fake/whatsapp/messages
+// (mensagens enviadas) e POST /fake/whatsapp/reply (simula a resposta de um cliente).
+// Todas exigem o cabeçalho Authorization: Bearer {WHATSAPP_ACCESS_TOKEN}.
+func (h *WhatsAppHandler) ServeFakeWhatsApp(c *gin.Context) {
+	c.Request.URL.Path = c.Param("path")
+	h.fake.ServeHTTP(c.Writer, c.Request)
+}
@@ -13,6 +13,7 @@ const (
 	AppointmentStatusConfirmed AppointmentStatus = "CONFIRMED"
 	AppointmentStatusCancelled AppointmentStatus = "CANCELLED"
 	AppointmentStatusCompleted AppointmentStatus = "COMPLETED"
+	AppointmentStatusNoShow    AppointmentStatus = "NO_SHOW" // Cliente não compareceu
 	// Adicione outros status conforme necessário
 )
 
@@ -21,15 +22,23 @@ type Appointment struct {
 	ID                uuid.UUID // Chave primária do agendamento
 	UserID            uuid.UUID // Chave estrangeira para o usuário (o profissional/MEI)
 	ClientID          *uuid.UUID // Opcional: Se o cliente também for um usuário registrado no sistema
+	CustomerID        *uuid.UUID // Opcional: cliente do cadastro de clientes do negócio
 	ClientName        string    // Nome do cliente (se não for um usuário registrado)
 	ClientEmail       string    // Email do cliente (para contato/notificações)
 	ClientPhone       string    // Telefone do cliente
 	ServiceDescription string    // Descrição do serviço a ser realizado
+	ServiceID         *uuid.UUID // Opcional: serviço do catálogo
+	ProfessionalID    *uuid.UUID // Opcional: profissional da equipe que realiza o atendimento
 	StartTime         time.Time // Data e hora de início do agendamento
 	EndTime           time.Time // Data e hora de término do agendamento
 	Status            AppointmentStatus // Status do agendamento (PENDING, CONFIRMED, etc.)
 	Notes             string    // Observações adicionais sobre o agendamento
 	Price             float64   // Preço do serviço (opcional, pode ser gerenciado em outro lugar)
+	OriginalPrice     float64    // Preço antes de descontos (assinatura, cupom)
+	DiscountAmount    float64    // Total de descontos aplicados na criação
+	CouponID          *uuid.UUID // Opcional: cupom de desconto usado no agendamento
+	Invoiced          bool      // Indica se foi emitida nota fiscal para o atendimento
+	Sequence          int       // Revisão do agendamento, incrementada a cada alteração (SEQUENCE do iCalendar)
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed é o feed iCalendar da agenda do usuário, assinado pelo calendário do
+// celular. Token é o segredo que compõe a URL do feed; trocá-lo invalida as URLs
+// distribuídas anteriormente.
+type CalendarFeed struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Token     string
+	RotatedAt *time.Time // Última troca do token; nil se nunca trocado
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarConnection é a conexão do usuário com um calendário externo via CalDAV (ex:
+// iCloud, Nextcloud, Fastmail). Os agendamentos são publicados nele e os compromissos
+// dele bloqueiam os horários da agenda.
+type CalendarConnection struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	CalendarURL string // Endereço da coleção do calendário no servidor CalDAV
+	Username    string
+	Password    string // Senha de app; nunca retornada pela API
+	Enabled     bool
+	LastSyncAt  *time.Time
+	LastError   string // Erro da última sincronização; vazio se ela foi concluída
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// CalendarBusyBlock é um período ocupado importado do calendário externo. Os blocos de
+// uma conexão são substituídos a cada sincronização.
+type CalendarBusyBlock struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	ConnectionID uuid.UUID
+	ExternalUID  string // UID do evento externo (com a ocorrência, nos recorrentes)
+	Summary      string
+	StartTime    time.Time
+	EndTime      time.Time
+	CreatedAt    time.Time
+}
+
+// CalendarEventLink liga um agendamento ao evento publicado no calendário externo e
+// guarda a versão publicada, para detectar alterações feitas dos dois lados.
+type CalendarEventLink struct {
+	ConnectionID  uuid.UUID
+	AppointmentID uuid.UUID
+	Href          string // Caminho do evento no servidor
+	ETag          string // ETag do evento após a última publicação ou importação
+	Sequence      int    // Revisão do agendamento publicada
+	StartTime     time.Time
+	EndTime       time.Time
+	SyncedAt      time.Time
+}
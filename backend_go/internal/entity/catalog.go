@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Service é um serviço do catálogo do negócio (ex: Corte masculino, Manicure).
+type Service struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID // Dono do negócio
+	Name            string
+	Description     string
+	Price           float64 // Preço padrão, usado quando o agendamento não informa outro
+	DurationMinutes int
+	Active          bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Professional é um membro da equipe que realiza atendimentos (ex: barbeiro, manicure).
+type Professional struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID // Dono do negócio
+	Name      string
+	Email     string
+	Phone     string
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
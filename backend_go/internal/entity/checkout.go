@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Checkout registra o fechamento de um atendimento no balcão: os produtos vendidos junto,
+// as formas de pagamento usadas (podendo dividir entre PIX, cartão, dinheiro e
+// vale-presente) e a gorjeta destinada ao profissional.
+type Checkout struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	AppointmentID   uuid.UUID
+	ProfessionalID  *uuid.UUID // Profissional que recebe a gorjeta
+	ServiceAmount   float64    // Preço do atendimento no fechamento
+	ProductsAmount  float64    // Total dos produtos vendidos no fechamento
+	PreviouslyPaid  float64    // Pagamentos já confirmados antes do fechamento (ex: sinal via PIX)
+	TipAmount       float64
+	TotalPaid       float64           // Soma das formas de pagamento do fechamento (inclui a gorjeta)
+	TipCommissionID *uuid.UUID        // Comissão gerada para a gorjeta
+	Products        []CheckoutProduct // Produtos vendidos junto com o atendimento
+	Payments        []*Payment        // Formas de pagamento usadas no fechamento
+	CompletedAt     time.Time
+	CreatedAt       time.Time
+}
+
+// CheckoutProduct é um produto vendido no fechamento de um atendimento.
+type CheckoutProduct struct {
+	ID          uuid.UUID
+	CheckoutID  uuid.UUID
+	ProductID   uuid.UUID
+	ProductName string // Nome do produto no momento da venda
+	Quantity    int
+	UnitPrice   float64
+}
+
+// Total retorna o valor da linha de produto.
+func (p CheckoutProduct) Total() float64 {
+	return float64(p.Quantity) * p.UnitPrice
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommissionSource define sobre o que a comissão incide.
+type CommissionSource string
+
+const (
+	CommissionSourceService CommissionSource = "SERVICE"
+	CommissionSourceProduct CommissionSource = "PRODUCT"
+	CommissionSourceTip     CommissionSource = "TIP" // Gorjeta repassada integralmente ao profissional
+)
+
+// CommissionType define como o valor da comissão é calculado.
+type CommissionType string
+
+const (
+	CommissionTypePercentage CommissionType = "PERCENTAGE" // Percentual sobre o valor do item
+	CommissionTypeFixed      CommissionType = "FIXED"      // Valor fixo por item
+)
+
+// CommissionStatus define a situação de pagamento de uma comissão.
+type CommissionStatus string
+
+const (
+	CommissionStatusPending CommissionStatus = "PENDING"
+	CommissionStatusPaid    CommissionStatus = "PAID"
+)
+
+// CommissionRule define quanto um profissional recebe por serviço ou produto.
+// Quando várias regras se aplicam, vale a mais específica: profissional e serviço,
+// depois só profissional, depois só serviço e por fim a regra geral.
+type CommissionRule struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	ProfessionalID *uuid.UUID // Nil vale para todos os profissionais
+	ServiceID      *uuid.UUID // Nil vale para todos os serviços (apenas para SERVICE)
+	Source         CommissionSource
+	Type           CommissionType
+	Value          float64 // Percentual (0-100) ou valor fixo, conforme Type
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Commission é a comissão devida a um profissional por um atendimento ou venda.
+type Commission struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	ProfessionalID uuid.UUID
+	RuleID         *uuid.UUID
+	AppointmentID  *uuid.UUID // Atendimento de origem (comissões de serviço)
+	Source         CommissionSource
+	Description    string
+	BaseAmount     float64 // Valor do item sobre o qual a comissão foi calculada
+	Amount         float64
+	Status         CommissionStatus
+	PayoutID       *uuid.UUID // Repasse em que a comissão foi paga
+	EarnedAt       time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// CommissionPayout registra o pagamento das comissões de um profissional em um período.
+type CommissionPayout struct {
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	ProfessionalID   uuid.UUID
+	PeriodStart      time.Time
+	PeriodEnd        time.Time
+	Amount           float64
+	CommissionCount  int
+	FinancialEntryID *uuid.UUID // Lançamento de saída gerado no livro-caixa
+	PaidAt           time.Time
+	CreatedAt        time.Time
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CouponDiscountType define como o desconto do cupom é calculado.
+type CouponDiscountType string
+
+const (
+	CouponDiscountTypePercentage CouponDiscountType = "PERCENTAGE" // Percentual sobre o preço
+	CouponDiscountTypeFixed      CouponDiscountType = "FIXED"      // Valor fixo em reais
+)
+
+// Coupon é um código promocional aplicado na criação de agendamentos.
+type Coupon struct {
+	ID                 uuid.UUID
+	UserID             uuid.UUID
+	Code               string // Armazenado em maiúsculas; único por usuário
+	Description        string
+	DiscountType       CouponDiscountType
+	Value              float64
+	ValidFrom          *time.Time
+	ValidUntil         *time.Time
+	MaxUses            int         // Limite total de usos; zero significa ilimitado
+	MaxUsesPerCustomer int         // Limite de usos por cliente; zero significa ilimitado
+	ServiceIDs         []uuid.UUID // Serviços em que o cupom vale; vazio significa todos
+	Active             bool
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// IsValidAt indica se a data está dentro da janela de validade do cupom.
+func (c *Coupon) IsValidAt(at time.Time) bool {
+	if c.ValidFrom != nil && at.Before(*c.ValidFrom) {
+		return false
+	}
+	if c.ValidUntil != nil && at.After(*c.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+// AppliesToService indica se o cupom vale para o serviço informado.
+func (c *Coupon) AppliesToService(serviceID *uuid.UUID) bool {
+	if len(c.ServiceIDs) == 0 {
+		return true
+	}
+	return serviceID != nil && containsUUID(c.ServiceIDs, *serviceID)
+}
+
+// CouponRedemption registra o uso de um cupom por um agendamento.
+type CouponRedemption struct {
+	ID             uuid.UUID
+	CouponID       uuid.UUID
+	AppointmentID  uuid.UUID
+	CustomerID     *uuid.UUID
+	ClientEmail    string // Identifica o cliente quando ele não está no cadastro
+	DiscountAmount float64
+	CreatedAt      time.Time
+}
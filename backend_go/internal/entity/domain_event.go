@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DomainEventType define os tipos de evento de domínio publicados pela aplicação.
+type DomainEventType string
+
+const (
+	DomainEventAppointmentCreated     DomainEventType = "appointment.created"
+	DomainEventAppointmentUpdated     DomainEventType = "appointment.updated" // Alteração sem mudança de horário ou status
+	DomainEventAppointmentRescheduled DomainEventType = "appointment.rescheduled"
+	DomainEventAppointmentConfirmed   DomainEventType = "appointment.confirmed"
+	DomainEventAppointmentCancelled   DomainEventType = "appointment.cancelled"
+	DomainEventAppointmentCompleted   DomainEventType = "appointment.completed"
+	DomainEventAppointmentDeleted     DomainEventType = "appointment.deleted"
+	DomainEventClientCreated          DomainEventType = "client.created"
+	DomainEventClientUpdated          DomainEventType = "client.updated"
+	DomainEventClientDeleted          DomainEventType = "client.deleted"
+	DomainEventPaymentReceived        DomainEventType = "payment.received"
+	DomainEventPaymentRefunded        DomainEventType = "payment.refunded"
+)
+
+// DomainEventTypes lista todos os tipos de evento publicados, na ordem de exibição.
+var DomainEventTypes = []DomainEventType{
+	DomainEventAppointmentCreated,
+	DomainEventAppointmentUpdated,
+	DomainEventAppointmentRescheduled,
+	DomainEventAppointmentConfirmed,
+	DomainEventAppointmentCancelled,
+	DomainEventAppointmentCompleted,
+	DomainEventAppointmentDeleted,
+	DomainEventClientCreated,
+	DomainEventClientUpdated,
+	DomainEventClientDeleted,
+	DomainEventPaymentReceived,
+	DomainEventPaymentRefunded,
+}
+
+// IsValid indica se o tipo de evento é publicado pela aplicação.
+func (t DomainEventType) IsValid() bool {
+	for _, known := range DomainEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DomainEventStatus define os possíveis status de um evento na outbox.
+type DomainEventStatus string
+
+const (
+	DomainEventStatusPending    DomainEventStatus = "PENDING"    // Aguardando distribuição aos assinantes
+	DomainEventStatusDispatched DomainEventStatus = "DISPATCHED" // Entregas criadas para os assinantes
+)
+
+// DomainEvent é um fato ocorrido no domínio, gravado na outbox na mesma transação da
+// alteração de estado que o originou. Payload é o JSON com os dados do evento.
+type DomainEvent struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID // Dono do negócio a que o evento se refere
+	Type         DomainEventType
+	AggregateID  uuid.UUID // ID do agendamento, cliente ou pagamento
+	Payload      string
+	OccurredAt   time.Time
+	Status       DomainEventStatus
+	DispatchedAt *time.Time
+	CreatedAt    time.Time
+}
+
+// NewDomainEvent cria um evento pendente com o payload serializado em JSON.
+func NewDomainEvent(userID uuid.UUID, eventType DomainEventType, aggregateID uuid.UUID, payload any) (*DomainEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &DomainEvent{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     string(data),
+		OccurredAt:  time.Now(),
+		Status:      DomainEventStatusPending,
+	}, nil
+}
+
+// EventDeliveryStatus define os possíveis status da entrega de um evento a um assinante.
+type EventDeliveryStatus string
+
+const (
+	EventDeliveryStatusPending   EventDeliveryStatus = "PENDING"   // Aguardando entrega ou nova tentativa
+	EventDeliveryStatusDelivered EventDeliveryStatus = "DELIVERED" // Processada pelo assinante
+	EventDeliveryStatusDead      EventDeliveryStatus = "DEAD"      // Esgotou as tentativas (dead letter)
+)
+
+// EventDelivery acompanha a entrega de um evento a um assinante. Cada assinante tem sua
+// própria entrega, para que a falha de um não repita o evento para os demais.
+type EventDelivery struct {
+	ID            uuid.UUID
+	EventID       uuid.UUID
+	UserID        uuid.UUID
+	EventType     DomainEventType
+	Subscriber    string
+	Status        EventDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// AppointmentEventPayload são os dados dos eventos de agendamento.
+type AppointmentEventPayload struct {
+	AppointmentID      uuid.UUID         `json:"appointmentId"`
+	CustomerID         *uuid.UUID        `json:"customerId,omitempty"`
+	ServiceID          *uuid.UUID        `json:"serviceId,omitempty"`
+	ProfessionalID     *uuid.UUID        `json:"professionalId,omitempty"`
+	ClientName         string            `json:"clientName"`
+	ClientEmail        string            `json:"clientEmail,omitempty"`
+	ClientPhone        string            `json:"clientPhone,omitempty"`
+	ServiceDescription string            `json:"serviceDescription"`
+	StartTime          time.Time         `json:"startTime"`
+	EndTime            time.Time         `json:"endTime"`
+	Status             AppointmentStatus `json:"status"`
+	Price              float64           `json:"price"`
+	PreviousStartTime  *time.Time        `json:"previousStartTime,omitempty"`
+	PreviousEndTime    *time.Time        `json:"previousEndTime,omitempty"`
+	PreviousStatus     AppointmentStatus `json:"previousStatus,omitempty"`
+}
+
+// ClientEventPayload são os dados dos eventos de cliente.
+type ClientEventPayload struct {
+	ClientID uuid.UUID `json:"clientId"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email,omitempty"`
+	Phone    string    `json:"phone,omitempty"`
+}
+
+// PaymentEventPayload são os dados dos eventos de pagamento.
+type PaymentEventPayload struct {
+	PaymentID     uuid.UUID     `json:"paymentId"`
+	AppointmentID *uuid.UUID    `json:"appointmentId,omitempty"`
+	CheckoutID    *uuid.UUID    `json:"checkoutId,omitempty"`
+	SaleID        *uuid.UUID    `json:"saleId,omitempty"`
+	Amount        float64       `json:"amount"`
+	Method        PaymentMethod `json:"method"`
+	Provider      string        `json:"provider,omitempty"`
+	Status        PaymentStatus `json:"status"`
+	PaidAt        *time.Time    `json:"paidAt,omitempty"`
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailEvent define os eventos de agendamento que geram e-mails ao cliente.
+type EmailEvent string
+
+const (
+	EmailEventAppointmentCreated     EmailEvent = "APPOINTMENT_CREATED"
+	EmailEventAppointmentRescheduled EmailEvent = "APPOINTMENT_RESCHEDULED"
+	EmailEventAppointmentCancelled   EmailEvent = "APPOINTMENT_CANCELLED"
+)
+
+// EmailStatus define os possíveis status de um e-mail na fila de envio (outbox).
+type EmailStatus string
+
+const (
+	EmailStatusPending EmailStatus = "PENDING" // Aguardando envio ou nova tentativa
+	EmailStatusSent    EmailStatus = "SENT"    // Aceito pelo servidor SMTP
+	EmailStatusFailed  EmailStatus = "FAILED"  // Esgotou as tentativas ou foi recusado definitivamente
+)
+
+// EmailSettings define a identidade visual e o idioma dos e-mails enviados aos clientes
+// do negócio.
+type EmailSettings struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Enabled      bool   // Envia os e-mails de criação, reagendamento e cancelamento
+	Locale       string // Idioma dos e-mails (pt-BR, en-US ou es-ES)
+	SenderName   string // Nome exibido como remetente; vazio usa o nome do negócio
+	ReplyTo      string // E-mail que recebe as respostas dos clientes
+	LogoURL      string
+	PrimaryColor string // Cor de destaque no formato #RRGGBB
+	FooterText   string // Texto livre no rodapé (ex: endereço, telefone)
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// OutboundEmail é um e-mail na fila de envio. O conteúdo é renderizado ao enfileirar,
+// para que as novas tentativas enviem exatamente a mesma mensagem.
+type OutboundEmail struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	AppointmentID     *uuid.UUID
+	Event             EmailEvent
+	Recipient         string
+	FromName          string
+	ReplyTo           string
+	Subject           string
+	HTMLBody          string
+	TextBody          string
+	ICSAttachment     string // Convite .ics do agendamento anexado ao e-mail; vazio se não houver
+	Status            EmailStatus
+	Attempts          int
+	NextAttemptAt     time.Time
+	LastError         string
+	ProviderMessageID string // Message-ID atribuído no envio
+	SentAt            *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FinancialEntryType define se um lançamento é uma entrada ou uma saída.
+type FinancialEntryType string
+
+const (
+	FinancialEntryTypeIncome  FinancialEntryType = "INCOME"
+	FinancialEntryTypeExpense FinancialEntryType = "EXPENSE"
+)
+
+// RevenueKind classifica a receita conforme a declaração anual do MEI (DASN-SIMEI).
+type RevenueKind string
+
+const (
+	RevenueKindServices         RevenueKind = "SERVICES"          // Prestação de serviços
+	RevenueKindCommerceIndustry RevenueKind = "COMMERCE_INDUSTRY" // Comércio e indústria
+)
+
+// FinancialEntry representa um lançamento no livro-caixa do usuário.
+type FinancialEntry struct {
+	ID                 uuid.UUID
+	UserID             uuid.UUID
+	Type               FinancialEntryType
+	Amount             float64 // Sempre positivo; o sentido é dado por Type
+	Description        string
+	Date               time.Time   // Data de competência do lançamento
+	CategoryID         *uuid.UUID  // Categoria de despesa (apenas para saídas)
+	AppointmentID      *uuid.UUID  // Agendamento de origem, se houver
+	PaymentID          *uuid.UUID  // Pagamento de origem, se houver
+	RecurringExpenseID *uuid.UUID  // Despesa recorrente que gerou o lançamento, se houver
+	GiftCardID         *uuid.UUID  // Venda de vale-presente; não conta como faturamento até o uso
+	RevenueKind        RevenueKind // Natureza da receita (apenas para entradas)
+	Invoiced           bool        // Indica se foi emitida nota fiscal para a entrada
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// ExpenseCategory é uma categoria de despesa definida pelo usuário (ex: Aluguel, Insumos).
+type ExpenseCategory struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	Name          string
+	MonthlyBudget float64 // Orçamento mensal; zero significa sem orçamento
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// RecurringExpense é uma despesa que se repete todo mês (aluguel, assinaturas de software).
+// A cada vencimento um FinancialEntry de saída é gerado automaticamente.
+type RecurringExpense struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	CategoryID  *uuid.UUID
+	Description string
+	Amount      float64
+	DayOfMonth  int        // Dia do vencimento (1-28, para existir em todos os meses)
+	StartDate   time.Time  // Primeiro mês em que a despesa é gerada
+	EndDate     *time.Time // Opcional: após esta data nenhum lançamento é gerado
+	NextDueDate time.Time  // Próximo vencimento ainda não lançado
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BudgetAlert registra que uma categoria ultrapassou o orçamento em um mês.
+// É gerado no máximo um alerta por categoria por mês.
+type BudgetAlert struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	CategoryID uuid.UUID
+	Month      string // Formato AAAA-MM
+	Budget     float64
+	Spent      float64
+	CreatedAt  time.Time
+}
+
+// IncomeForecast é uma receita prevista, ainda não realizada (ex: agendamentos gerados
+// a partir de um orçamento aceito). Deixa de contar na previsão quando o agendamento
+// é concluído, cancelado ou excluído.
+type IncomeForecast struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	QuoteID       *uuid.UUID // Orçamento de origem, se houver
+	AppointmentID *uuid.UUID // Agendamento que realizará a receita, se houver
+	Description   string
+	Amount        float64
+	ExpectedDate  time.Time
+	CreatedAt     time.Time
+}
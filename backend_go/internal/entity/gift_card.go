@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GiftCardStatus define os possíveis status de um vale-presente.
+type GiftCardStatus string
+
+const (
+	GiftCardStatusActive    GiftCardStatus = "ACTIVE"
+	GiftCardStatusCancelled GiftCardStatus = "CANCELLED"
+)
+
+// GiftCard é um vale-presente com saldo, usado como forma de pagamento em um ou
+// mais atendimentos até o saldo acabar ou o vale vencer.
+type GiftCard struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	Code           string // Código único apresentado pelo cliente
+	InitialAmount  float64
+	Balance        float64
+	PurchaserName  string
+	RecipientName  string
+	RecipientEmail string
+	Message        string
+	Status         GiftCardStatus
+	IssuedAt       time.Time
+	ExpiresAt      *time.Time // Nil significa sem validade
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsExpired indica se o vale já venceu na data informada.
+func (g *GiftCard) IsExpired(at time.Time) bool {
+	return g.ExpiresAt != nil && at.After(*g.ExpiresAt)
+}
+
+// GiftCardTransactionType define os tipos de movimentação de um vale-presente.
+type GiftCardTransactionType string
+
+const (
+	GiftCardTransactionTypeIssue  GiftCardTransactionType = "ISSUE"  // Emissão com o valor inicial
+	GiftCardTransactionTypeRedeem GiftCardTransactionType = "REDEEM" // Uso como pagamento
+	GiftCardTransactionTypeRefund GiftCardTransactionType = "REFUND" // Devolução de um uso não concluído
+	GiftCardTransactionTypeCancel GiftCardTransactionType = "CANCEL" // Cancelamento do saldo restante
+)
+
+// GiftCardTransaction registra uma movimentação do saldo de um vale-presente.
+type GiftCardTransaction struct {
+	ID           uuid.UUID
+	GiftCardID   uuid.UUID
+	Type         GiftCardTransactionType
+	Amount       float64 // Positivo para créditos, negativo para débitos
+	BalanceAfter float64
+	PaymentID    *uuid.UUID // Pagamento em que o vale foi usado, se houver
+	Description  string
+	CreatedAt    time.Time
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceStatus define os possíveis status de uma NFS-e.
+type InvoiceStatus string
+
+const (
+	InvoiceStatusPending   InvoiceStatus = "PENDING"   // Enviada ao provedor, aguardando retorno
+	InvoiceStatusIssued    InvoiceStatus = "ISSUED"    // Emitida pela prefeitura
+	InvoiceStatusCancelled InvoiceStatus = "CANCELLED" // Cancelada após a emissão
+	InvoiceStatusError     InvoiceStatus = "ERROR"     // Recusada ou falha de comunicação; pode ser reenviada
+)
+
+// Invoice é uma nota fiscal de serviço eletrônica (NFS-e) emitida a partir de um
+// atendimento concluído.
+type Invoice struct {
+	ID                 uuid.UUID
+	UserID             uuid.UUID
+	AppointmentID      uuid.UUID
+	Provider           string // Provedor usado na emissão (ex: mock)
+	Status             InvoiceStatus
+	RPSNumber          int    // Número do RPS, sequencial por usuário
+	RPSSeries          string // Série do RPS
+	Number             string // Número da NFS-e atribuído pela prefeitura
+	VerificationCode   string
+	Amount             float64
+	ISSRate            float64 // Alíquota do ISS em percentual
+	ISSAmount          float64
+	ServiceDescription string
+	TakerName          string
+	TakerDocument      string // CPF ou CNPJ do tomador, apenas dígitos
+	TakerEmail         string
+	RequestXML         string // XML ABRASF enviado ao provedor
+	ErrorCode          string
+	ErrorMessage       string
+	IssuedAt           *time.Time
+	CancelledAt        *time.Time
+	CancelReason       string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// InvoiceSettings guarda os dados fiscais do prestador usados na emissão de NFS-e.
+type InvoiceSettings struct {
+	ID                    uuid.UUID
+	UserID                uuid.UUID
+	Document              string // CNPJ (ou CPF do autônomo), apenas dígitos
+	MunicipalRegistration string // Inscrição municipal
+	MunicipalityCode      string // Código IBGE do município (7 dígitos)
+	ServiceItemCode       string // Item padrão da lista de serviços da LC 116 (ex: 06.01)
+	ISSRate               float64
+	RPSSeries             string
+	NextRPSNumber         int
+	AutoIssue             bool // Emite a nota automaticamente quando o atendimento é concluído
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MembershipPlan é um plano de assinatura mensal ("clube") vendido aos clientes.
+// Os serviços incluídos saem sem custo até o limite do ciclo; os demais
+// serviços do catálogo recebem o desconto do plano.
+type MembershipPlan struct {
+	ID                   uuid.UUID
+	UserID               uuid.UUID
+	Name                 string
+	Description          string
+	MonthlyPrice         float64
+	IncludedServiceIDs   []uuid.UUID
+	IncludedUsesPerCycle int     // Quantidade de serviços incluídos por ciclo; zero significa ilimitado
+	DiscountPercent      float64 // Desconto nos serviços não incluídos (0 a 100)
+	Active               bool
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// IncludesService indica se o serviço faz parte dos serviços incluídos no plano.
+func (p *MembershipPlan) IncludesService(serviceID uuid.UUID) bool {
+	return containsUUID(p.IncludedServiceIDs, serviceID)
+}
+
+// MembershipStatus define os possíveis status de uma assinatura.
+type MembershipStatus string
+
+const (
+	MembershipStatusActive    MembershipStatus = "ACTIVE"
+	MembershipStatusCancelled MembershipStatus = "CANCELLED" // Não renova; benefícios valem até o fim do ciclo
+)
+
+// MembershipSubscription é a assinatura de um plano por um cliente.
+// O valor é fixado na assinatura; os benefícios seguem o plano vigente.
+type MembershipSubscription struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	PlanID            uuid.UUID
+	CustomerID        uuid.UUID
+	Status            MembershipStatus
+	Price             float64 // Valor cobrado por ciclo
+	StartedAt         time.Time
+	CurrentCycleID    uuid.UUID
+	CurrentCycleStart time.Time
+	CurrentCycleEnd   time.Time // Exclusivo: o próximo ciclo começa neste instante
+	CancelledAt       *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// CoversDate indica se os benefícios da assinatura valem na data informada.
+func (s *MembershipSubscription) CoversDate(at time.Time) bool {
+	return !at.Before(s.CurrentCycleStart) && at.Before(s.CurrentCycleEnd)
+}
+
+// MembershipCycle é um ciclo de cobrança de uma assinatura.
+type MembershipCycle struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	CycleStart     time.Time
+	CycleEnd       time.Time
+	Amount         float64
+	CreatedAt      time.Time
+}
+
+// MembershipUsageKind define como um atendimento usou a assinatura.
+type MembershipUsageKind string
+
+const (
+	MembershipUsageKindIncluded   MembershipUsageKind = "INCLUDED"   // Serviço incluído, sem custo
+	MembershipUsageKindDiscounted MembershipUsageKind = "DISCOUNTED" // Serviço com o desconto do plano
+)
+
+// MembershipUsage registra o uso da assinatura por um agendamento em um ciclo.
+type MembershipUsage struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	CycleID        uuid.UUID
+	AppointmentID  uuid.UUID
+	ServiceID      uuid.UUID
+	Kind           MembershipUsageKind
+	OriginalPrice  float64
+	ChargedPrice   float64
+	CreatedAt      time.Time
+}
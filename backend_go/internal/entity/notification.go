@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification é um aviso da caixa de entrada do app, gerado a partir de um evento de
+// domínio, para que o usuário veja o que aconteceu enquanto esteve ausente.
+type Notification struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	EventID       uuid.UUID // Evento que gerou o aviso; cada evento gera no máximo um aviso
+	Type          DomainEventType
+	Title         string
+	Body          string
+	AppointmentID *uuid.UUID // Opcional: agendamento aberto ao tocar no aviso
+	ClientID      *uuid.UUID // Opcional: cliente aberto ao tocar no aviso
+	ReadAt        *time.Time // Nil enquanto não lido
+	CreatedAt     time.Time
+}
+
+// IsRead indica se o aviso já foi lido.
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
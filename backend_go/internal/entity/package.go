@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServicePackage é um pacote de sessões pré-pagas (ex: "10 sessões de massagem").
+// Ao ser vendido, concede Credits créditos válidos para os serviços cobertos.
+type ServicePackage struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Name         string
+	Description  string
+	Price        float64
+	Credits      int         // Quantidade de sessões concedidas
+	ValidityDays int         // Validade dos créditos a partir da compra; zero significa sem validade
+	ServiceIDs   []uuid.UUID // Serviços em que os créditos podem ser usados
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// CoversService indica se o pacote pode ser usado no serviço informado.
+func (p *ServicePackage) CoversService(serviceID uuid.UUID) bool {
+	return containsUUID(p.ServiceIDs, serviceID)
+}
+
+// ClientPackage é a venda de um pacote a um cliente, com o saldo de créditos.
+// Nome, serviços e créditos são copiados do pacote no momento da venda.
+type ClientPackage struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	PackageID    uuid.UUID
+	CustomerID   uuid.UUID // Cliente do cadastro de clientes
+	Name         string
+	ServiceIDs   []uuid.UUID
+	TotalCredits int
+	UsedCredits  int
+	Price        float64
+	PurchasedAt  time.Time
+	ExpiresAt    *time.Time // Nil significa sem validade
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RemainingCredits retorna a quantidade de sessões ainda disponíveis.
+func (p *ClientPackage) RemainingCredits() int {
+	return p.TotalCredits - p.UsedCredits
+}
+
+// IsExpired indica se os créditos já venceram na data informada.
+func (p *ClientPackage) IsExpired(at time.Time) bool {
+	return p.ExpiresAt != nil && at.After(*p.ExpiresAt)
+}
+
+// CanCover indica se o pacote tem saldo válido para o serviço na data informada.
+func (p *ClientPackage) CanCover(serviceID uuid.UUID, at time.Time) bool {
+	return p.RemainingCredits() > 0 && !p.IsExpired(at) && containsUUID(p.ServiceIDs, serviceID)
+}
+
+// PackageCreditUsage registra o consumo de um crédito por um atendimento.
+type PackageCreditUsage struct {
+	ID              uuid.UUID
+	ClientPackageID uuid.UUID
+	AppointmentID   uuid.UUID
+	UsedAt          time.Time
+}
+
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
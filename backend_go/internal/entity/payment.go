@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentStatus define os possíveis status de um pagamento.
+type PaymentStatus string
+
+const (
+	PaymentStatusPending  PaymentStatus = "PENDING"
+	PaymentStatusPaid     PaymentStatus = "PAID"
+	PaymentStatusFailed   PaymentStatus = "FAILED"
+	PaymentStatusRefunded PaymentStatus = "REFUNDED"
+)
+
+// PaymentMethod define as formas de pagamento aceitas.
+type PaymentMethod string
+
+const (
+	PaymentMethodPix      PaymentMethod = "PIX"
+	PaymentMethodCard     PaymentMethod = "CARD"
+	PaymentMethodCash     PaymentMethod = "CASH"
+	PaymentMethodGiftCard PaymentMethod = "GIFT_CARD"
+)
+
+// Payment representa uma cobrança vinculada a um agendamento ou a uma venda de balcão.
+type Payment struct {
+	ID               uuid.UUID
+	UserID           uuid.UUID  // Profissional/MEI que recebe o pagamento
+	AppointmentID    *uuid.UUID // Agendamento cobrado; nil em vendas de balcão
+	Amount           float64
+	Method           PaymentMethod
+	Status           PaymentStatus
+	Provider         string     // Nome do provedor de pagamento (ex: "fake"); vazio para dinheiro
+	ProviderChargeID string     // ID da cobrança no provedor, usado na conciliação via webhook
+	PaymentURL       string     // Link de pagamento ou código PIX "copia e cola" retornado pelo provedor
+	GiftCardID       *uuid.UUID // Vale-presente usado, quando Method é GIFT_CARD
+	CheckoutID       *uuid.UUID // Fechamento do atendimento em que o pagamento foi recebido
+	SaleID           *uuid.UUID // Venda de balcão paga
+	PaidAt           *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// PaymentWebhookEvent registra um evento de webhook já processado.
+// A combinação Provider + EventID é única e garante o processamento idempotente.
+type PaymentWebhookEvent struct {
+	ID               uuid.UUID
+	Provider         string
+	EventID          string
+	EventType        string
+	ProviderChargeID string
+	PaymentID        *uuid.UUID // Pagamento conciliado, se encontrado
+	Status           string     // PROCESSED, IGNORED ou FAILED
+	Error            string
+	Payload          string
+	CreatedAt        time.Time
+}
+
+const (
+	WebhookEventStatusProcessed = "PROCESSED"
+	WebhookEventStatusIgnored   = "IGNORED"
+	WebhookEventStatusFailed    = "FAILED"
+)
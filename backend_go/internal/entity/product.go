@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Product é um produto vendido pelo negócio (ex: shampoo, pomada).
+type Product struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID // Dono do negócio
+	Name          string
+	SKU           string // Código do produto, único por usuário quando informado
+	Description   string
+	CostPrice     float64 // Custo unitário da última compra
+	SalePrice     float64
+	StockQuantity int // Alterado apenas por movimentações de estoque
+	MinStock      int // Estoque mínimo; abaixo ou igual a ele gera alerta de reposição
+	Active        bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// IsLowStock indica se o estoque atingiu o mínimo de reposição.
+func (p *Product) IsLowStock() bool {
+	return p.MinStock > 0 && p.StockQuantity <= p.MinStock
+}
+
+// StockMovementType define os tipos de movimentação de estoque.
+type StockMovementType string
+
+const (
+	StockMovementTypePurchase   StockMovementType = "PURCHASE"   // Entrada por compra do fornecedor
+	StockMovementTypeSale       StockMovementType = "SALE"       // Saída por venda ao cliente
+	StockMovementTypeAdjustment StockMovementType = "ADJUSTMENT" // Correção após contagem (positiva ou negativa)
+	StockMovementTypeLoss       StockMovementType = "LOSS"       // Saída por perda, quebra ou vencimento
+)
+
+// StockMovement registra uma entrada ou saída de estoque de um produto.
+type StockMovement struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	ProductID     uuid.UUID
+	Type          StockMovementType
+	Quantity      int        // Positivo para entradas, negativo para saídas
+	UnitPrice     float64    // Custo unitário (compra) ou preço de venda (venda)
+	StockAfter    int        // Estoque do produto após a movimentação
+	AppointmentID *uuid.UUID // Atendimento em que o produto foi vendido, se houver
+	CheckoutID    *uuid.UUID // Fechamento em que o produto foi vendido, se houver
+	SaleID        *uuid.UUID // Venda de balcão em que o produto foi vendido, se houver
+	Notes         string
+	CreatedAt     time.Time
+}
+
+// LowStockAlert registra que um produto atingiu o estoque mínimo. Fica em aberto até
+// o estoque voltar a ficar acima do mínimo; há no máximo um alerta aberto por produto.
+type LowStockAlert struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	ProductID     uuid.UUID
+	StockQuantity int // Estoque no momento do alerta
+	MinStock      int
+	ResolvedAt    *time.Time
+	CreatedAt     time.Time
+}
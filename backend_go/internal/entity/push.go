@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PushPlatform define as plataformas dos dispositivos que recebem notificações push.
+type PushPlatform string
+
+const (
+	PushPlatformAndroid PushPlatform = "ANDROID"
+	PushPlatformIOS     PushPlatform = "IOS"
+	PushPlatformWeb     PushPlatform = "WEB"
+)
+
+// PushDevice é um dispositivo do app registrado para receber notificações push do
+// usuário. Token é o identificador atribuído pelo provedor (ex: FCM) e é único: ao ser
+// registrado por outro usuário (ex: troca de conta no mesmo aparelho), passa a ele.
+type PushDevice struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Token      string
+	Platform   PushPlatform
+	DeviceName string // Opcional: nome exibido na lista de dispositivos (ex: "iPhone da recepção")
+	AppVersion string
+	LastSeenAt time.Time // Último registro do token pelo app
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// PushPreference indica se o usuário recebe notificações push de um tipo de evento.
+// Apenas as preferências alteradas pelo usuário são gravadas; os demais tipos seguem o
+// padrão da aplicação.
+type PushPreference struct {
+	UserID    uuid.UUID
+	EventType DomainEventType
+	Enabled   bool
+	UpdatedAt time.Time
+}
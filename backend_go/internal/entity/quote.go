@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuoteStatus define os possíveis status de um orçamento.
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft    QuoteStatus = "DRAFT"    // Em elaboração, ainda não enviado ao cliente
+	QuoteStatusSent     QuoteStatus = "SENT"     // Enviado e aguardando resposta
+	QuoteStatusAccepted QuoteStatus = "ACCEPTED" // Aceito pelo cliente
+	QuoteStatusRejected QuoteStatus = "REJECTED" // Recusado pelo cliente
+	QuoteStatusExpired  QuoteStatus = "EXPIRED"  // Validade vencida sem resposta
+)
+
+// Quote é um orçamento enviado ao cliente antes do agendamento. Depois de aceito,
+// pode ser convertido em um ou mais agendamentos.
+type Quote struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	Number         int        // Numeração sequencial por usuário
+	CustomerID     *uuid.UUID // Opcional: cliente do cadastro de clientes do negócio
+	ClientName     string
+	ClientEmail    string
+	ClientPhone    string
+	Title          string
+	Notes          string
+	Items          []QuoteItem
+	ValidUntil     time.Time
+	Status         QuoteStatus
+	PublicToken    string // Token do link público de aceite
+	SentAt         *time.Time
+	RespondedAt    *time.Time // Data do aceite ou da recusa
+	RejectReason   string
+	ConvertedAt    *time.Time
+	AppointmentIDs []uuid.UUID // Agendamentos gerados na conversão
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Total retorna a soma dos itens do orçamento.
+func (q *Quote) Total() float64 {
+	total := 0.0
+	for _, item := range q.Items {
+		total += item.Total()
+	}
+	return math.Round(total*100) / 100
+}
+
+// IsExpired indica se a validade do orçamento já passou na data informada.
+// O orçamento vale até o fim do dia de ValidUntil.
+func (q *Quote) IsExpired(at time.Time) bool {
+	y, m, d := q.ValidUntil.Date()
+	endOfDay := time.Date(y, m, d, 0, 0, 0, 0, q.ValidUntil.Location()).AddDate(0, 0, 1)
+	return !at.Before(endOfDay)
+}
+
+// QuoteItem é uma linha do orçamento (serviço, peça ou material).
+type QuoteItem struct {
+	ID          uuid.UUID
+	QuoteID     uuid.UUID
+	ServiceID   *uuid.UUID // Opcional: serviço do catálogo
+	Description string
+	Quantity    int
+	UnitPrice   float64
+	Position    int // Ordem de exibição
+}
+
+// Total retorna o valor da linha (quantidade x preço unitário).
+func (i QuoteItem) Total() float64 {
+	return math.Round(float64(i.Quantity)*i.UnitPrice*100) / 100
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReminderChannel define os canais de envio de lembretes.
+type ReminderChannel string
+
+const (
+	ReminderChannelEmail    ReminderChannel = "EMAIL"
+	ReminderChannelSMS      ReminderChannel = "SMS"
+	ReminderChannelWhatsApp ReminderChannel = "WHATSAPP"
+)
+
+// ReminderStatus define os possíveis status de entrega de um lembrete.
+type ReminderStatus string
+
+const (
+	ReminderStatusScheduled ReminderStatus = "SCHEDULED" // Aguardando o horário de envio (ou nova tentativa)
+	ReminderStatusSent      ReminderStatus = "SENT"      // Entregue ao canal
+	ReminderStatusFailed    ReminderStatus = "FAILED"    // Esgotou as tentativas de envio
+	ReminderStatusCancelled ReminderStatus = "CANCELLED" // Agendamento alterado, cancelado ou excluído
+)
+
+// ReminderRule define quando e por qual canal o cliente é lembrado de um agendamento.
+// Assunto e mensagem são templates (text/template) com os campos de ReminderTemplateData.
+type ReminderRule struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	OffsetMinutes int // Antecedência em relação ao início do agendamento (ex: 1440 = 24h)
+	Channel       ReminderChannel
+	Subject       string // Vazio usa o assunto padrão
+	Template      string // Vazio usa a mensagem padrão
+	Active        bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Reminder é um lembrete agendado para um agendamento, com o acompanhamento da entrega.
+type Reminder struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	AppointmentID     uuid.UUID
+	Channel           ReminderChannel
+	OffsetMinutes     int
+	Recipient         string // E-mail ou telefone do cliente
+	ScheduledFor      time.Time
+	Status            ReminderStatus
+	Attempts          int
+	Subject           string // Preenchido no envio
+	Message           string // Preenchido no envio
+	ProviderMessageID string
+	LastError         string
+	SentAt            *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// ReminderTemplateData são os campos disponíveis nos templates dos lembretes.
+type ReminderTemplateData struct {
+	ClientName   string
+	Service      string
+	BusinessName string
+	Date         string // DD/MM/AAAA
+	Time         string // HH:MM
+}
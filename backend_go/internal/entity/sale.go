@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SaleItemKind define o tipo de item de uma venda de balcão.
+type SaleItemKind string
+
+const (
+	SaleItemKindProduct SaleItemKind = "PRODUCT" // Produto do estoque
+	SaleItemKindService SaleItemKind = "SERVICE" // Serviço avulso, sem agendamento
+	SaleItemKindPackage SaleItemKind = "PACKAGE" // Pacote de sessões vendido ao cliente
+)
+
+// Sale é uma venda de balcão não vinculada a um agendamento (ex: cliente que passa
+// apenas para comprar um produto). Cada venda recebe um número sequencial usado no recibo.
+type Sale struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	Number         int        // Número sequencial do recibo, por usuário
+	ClientID       *uuid.UUID // Cliente do cadastro; obrigatório para vender pacotes
+	ProfessionalID *uuid.UUID // Profissional que comissiona sobre a venda, se houver
+	CashRegisterID *uuid.UUID // Caixa aberto no momento da venda, se houver
+	Total          float64
+	Notes          string
+	Items          []SaleItem
+	Payments       []*Payment
+	SoldAt         time.Time
+	CreatedAt      time.Time
+}
+
+// TotalByKind soma os itens da venda de um tipo.
+func (s *Sale) TotalByKind(kind SaleItemKind) float64 {
+	var total float64
+	for _, item := range s.Items {
+		if item.Kind == kind {
+			total += item.Total()
+		}
+	}
+	return total
+}
+
+// SaleItem é uma linha da venda de balcão.
+type SaleItem struct {
+	ID              uuid.UUID
+	SaleID          uuid.UUID
+	Kind            SaleItemKind
+	ProductID       *uuid.UUID // Para PRODUCT
+	ServiceID       *uuid.UUID // Para SERVICE, quando vem do catálogo
+	PackageID       *uuid.UUID // Para PACKAGE
+	ClientPackageID *uuid.UUID // Pacote gerado para o cliente, para PACKAGE
+	Description     string
+	Quantity        int
+	UnitPrice       float64
+}
+
+// Total retorna o valor da linha.
+func (i SaleItem) Total() float64 {
+	return float64(i.Quantity) * i.UnitPrice
+}
+
+// CashRegisterStatus define os possíveis status de um caixa.
+type CashRegisterStatus string
+
+const (
+	CashRegisterStatusOpen   CashRegisterStatus = "OPEN"
+	CashRegisterStatusClosed CashRegisterStatus = "CLOSED"
+)
+
+// CashRegister é o caixa do dia: aberto com um fundo de troco e fechado com a contagem
+// do dinheiro na gaveta, que é conferida com o valor esperado (fundo de troco mais os
+// pagamentos em dinheiro recebidos com o caixa aberto).
+type CashRegister struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	Status         CashRegisterStatus
+	OpeningAmount  float64  // Fundo de troco
+	CashReceived   float64  // Pagamentos em dinheiro recebidos com o caixa aberto
+	ExpectedAmount float64  // OpeningAmount + CashReceived
+	CountedAmount  *float64 // Dinheiro contado no fechamento
+	Difference     *float64 // CountedAmount - ExpectedAmount; negativo indica falta
+	OpenedAt       time.Time
+	ClosedAt       *time.Time
+	Notes          string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaxRegime define o enquadramento tributário do negócio.
+type TaxRegime string
+
+const (
+	TaxRegimeMEI             TaxRegime = "MEI"
+	TaxRegimeSimplesNacional TaxRegime = "SIMPLES_NACIONAL"
+	TaxRegimeAutonomous      TaxRegime = "AUTONOMO"
+)
+
+// Tetos anuais de faturamento bruto usados como padrão para cada regime.
+const (
+	MEIAnnualRevenueLimit             = 81000.00
+	SimplesNacionalAnnualRevenueLimit = 4800000.00
+	// MEIExcessTolerance é o percentual de excesso (20%) acima do qual o MEI
+	// é desenquadrado retroativamente a janeiro.
+	MEIExcessTolerance = 0.20
+)
+
+// DefaultRevenueAlertThresholds são os percentuais do teto que geram alertas por padrão.
+var DefaultRevenueAlertThresholds = []int{70, 90, 100}
+
+// TaxProfile guarda o enquadramento tributário e os parâmetros de alerta do usuário.
+type TaxProfile struct {
+	ID                 uuid.UUID
+	UserID             uuid.UUID
+	Regime             TaxRegime
+	AnnualRevenueLimit float64 // Teto anual; zero significa sem teto (ex: autônomo)
+	AlertThresholds    []int   // Percentuais do teto que geram alertas (ex: 70, 90, 100)
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// DefaultAnnualRevenueLimit retorna o teto padrão de faturamento para o regime.
+func DefaultAnnualRevenueLimit(regime TaxRegime) float64 {
+	switch regime {
+	case TaxRegimeMEI:
+		return MEIAnnualRevenueLimit
+	case TaxRegimeSimplesNacional:
+		return SimplesNacionalAnnualRevenueLimit
+	}
+	return 0
+}
+
+// RevenueLimitAlert registra que o faturamento do ano atingiu um dos percentuais
+// configurados do teto. É gerado no máximo um alerta por percentual por ano.
+type RevenueLimitAlert struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Year      int
+	Threshold int // Percentual do teto atingido
+	Revenue   float64
+	Limit     float64
+	CreatedAt time.Time
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription é uma URL cadastrada pelo usuário para receber os eventos de
+// domínio (ex: para integrar planilhas e ferramentas de automação).
+type WebhookSubscription struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	URL                 string
+	Description         string
+	EventTypes          []DomainEventType // Vazio: todos os eventos
+	Secret              string            // Chave da assinatura HMAC-SHA256 dos envios
+	Active              bool
+	ConsecutiveFailures int        // Tentativas com falha seguidas; zera a cada sucesso
+	DisabledAt          *time.Time // Preenchido quando a assinatura é desativada automaticamente
+	DisabledReason      string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// Accepts indica se a assinatura recebe eventos do tipo informado.
+func (s *WebhookSubscription) Accepts(eventType DomainEventType) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus define os possíveis status do envio de um evento a uma assinatura.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"   // Aguardando envio ou nova tentativa
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED" // Destino respondeu 2xx
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"    // Esgotou as tentativas ou assinatura desativada
+)
+
+// WebhookDelivery é o envio de um evento a uma assinatura. Body é o JSON enviado,
+// congelado na criação para que as novas tentativas e reenvios mandem o mesmo conteúdo.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	EventID        uuid.UUID
+	EventType      DomainEventType
+	Body           string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int // Zero quando não houve resposta
+	LastError      string
+	DeliveredAt    *time.Time
+	RedeliveryOf   *uuid.UUID // Entrega original, quando criada por reenvio manual
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookAttempt registra uma tentativa de envio, para o histórico de entregas.
+type WebhookAttempt struct {
+	ID           uuid.UUID
+	DeliveryID   uuid.UUID
+	AttemptedAt  time.Time
+	StatusCode   int // Zero quando não houve resposta
+	ResponseBody string
+	Error        string
+	DurationMs   int64
+}
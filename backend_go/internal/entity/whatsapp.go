@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WhatsAppDirection indica se a mensagem foi enviada ao cliente ou recebida dele.
+type WhatsAppDirection string
+
+const (
+	WhatsAppDirectionInbound  WhatsAppDirection = "INBOUND"
+	WhatsAppDirectionOutbound WhatsAppDirection = "OUTBOUND"
+)
+
+// WhatsAppMessageKind define o papel da mensagem na conversa.
+type WhatsAppMessageKind string
+
+const (
+	WhatsAppKindConfirmationRequest WhatsAppMessageKind = "CONFIRMATION_REQUEST" // Pedido de confirmação do agendamento
+	WhatsAppKindReply               WhatsAppMessageKind = "REPLY"                // Resposta do cliente
+	WhatsAppKindAck                 WhatsAppMessageKind = "ACK"                  // Resposta automática à mensagem do cliente
+)
+
+// WhatsAppReplyAction é a ação interpretada da resposta do cliente.
+type WhatsAppReplyAction string
+
+const (
+	WhatsAppReplyConfirm WhatsAppReplyAction = "CONFIRM" // "1 - Confirmar"
+	WhatsAppReplyCancel  WhatsAppReplyAction = "CANCEL"  // "2 - Cancelar"
+	WhatsAppReplyUnknown WhatsAppReplyAction = "UNKNOWN"
+)
+
+// WhatsAppMessage registra uma mensagem trocada com o cliente pelo WhatsApp. Lembretes
+// enviados por WhatsApp ficam registrados em Reminder.
+type WhatsAppMessage struct {
+	ID                uuid.UUID
+	UserID            *uuid.UUID // Ausente nas mensagens recebidas sem agendamento identificado
+	AppointmentID     *uuid.UUID
+	Direction         WhatsAppDirection
+	Kind              WhatsAppMessageKind
+	Phone             string // Apenas dígitos, com DDI
+	ProviderMessageID string
+	Body              string
+	Action            WhatsAppReplyAction // Apenas nas respostas do cliente
+	Error             string              // Falha de envio ou ao aplicar a resposta
+	CreatedAt         time.Time
+}
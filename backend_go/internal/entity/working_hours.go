@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkingHours é o horário de funcionamento do negócio em um dia da semana. Dias sem
+// registro são considerados fechados. Usado no cálculo da taxa de ocupação da agenda.
+type WorkingHours struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Weekday     time.Weekday
+	OpenMinute  int // Minutos desde a meia-noite (ex: 540 = 09:00)
+	CloseMinute int // Minutos desde a meia-noite; deve ser maior que OpenMinute
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Minutes retorna a duração do expediente em minutos.
+func (w WorkingHours) Minutes() int {
+	return w.CloseMinute - w.OpenMinute
+}
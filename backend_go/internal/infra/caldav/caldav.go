@@ -0,0 +1,252 @@
+// Package caldav implementa um cliente CalDAV (RFC 4791) para sincronizar a agenda com
+// calendários externos, e um servidor local que o imita para testes offline.
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnauthorized indica que o servidor recusou as credenciais.
+	ErrUnauthorized = errors.New("credenciais recusadas pelo servidor de calendário")
+	// ErrNotFound indica que o evento ou o calendário não existe no servidor.
+	ErrNotFound = errors.New("não encontrado no servidor de calendário")
+	// ErrPreconditionFailed indica que o evento foi alterado (ou criado) no servidor desde
+	// a última leitura: a ETag informada não confere mais.
+	ErrPreconditionFailed = errors.New("evento alterado no servidor de calendário")
+)
+
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+
+	// maxResponseSize limita o tamanho das respostas lidas do servidor.
+	maxResponseSize = 10 << 20
+
+	calendarContentType = "text/calendar; charset=utf-8"
+	timeRangeLayout     = "20060102T150405Z"
+)
+
+// Config contém os dados de acesso a um calendário.
+type Config struct {
+	CalendarURL string // Endereço da coleção do calendário (ex: https://caldav.exemplo.com/calendars/ana/agenda/)
+	Username    string
+	Password    string
+}
+
+// Object é um recurso do calendário (um arquivo .ics com um ou mais eventos).
+type Object struct {
+	Href string // Caminho do recurso no servidor, já codificado para URL
+	ETag string
+	Data []byte
+}
+
+// Client acessa um calendário via CalDAV com autenticação Basic.
+type Client struct {
+	cfg        Config
+	base       *url.URL
+	httpClient *http.Client
+}
+
+// NewClient cria um Client. httpClient deve restringir os destinos, já que o endereço é
+// informado pelo usuário (ver webhook.NewHTTPClient).
+func NewClient(httpClient *http.Client, cfg Config) (*Client, error) {
+	if !strings.HasSuffix(cfg.CalendarURL, "/") {
+		cfg.CalendarURL += "/"
+	}
+	base, err := url.Parse(cfg.CalendarURL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("endereço do calendário inválido: %q", cfg.CalendarURL)
+	}
+	return &Client{cfg: cfg, base: base, httpClient: httpClient}, nil
+}
+
+// EventHref retorna o caminho do recurso de um evento criado pela aplicação, derivado
+// do UID para que o mesmo evento seja sempre gravado no mesmo endereço.
+func (c *Client) EventHref(uid string) string {
+	return c.base.EscapedPath() + url.PathEscape(uid) + ".ics"
+}
+
+// calendarQuery é o corpo do REPORT calendar-query que busca os eventos do período.
+// O elemento expand pede ao servidor as ocorrências dos eventos recorrentes.
+const calendarQuery = `<?xml version="1.0" encoding="utf-8"?>
+<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop>
+    <D:getetag/>
+    <C:calendar-data>
+      <C:expand start="%[1]s" end="%[2]s"/>
+    </C:calendar-data>
+  </D:prop>
+  <C:filter>
+    <C:comp-filter name="VCALENDAR">
+      <C:comp-filter name="VEVENT">
+        <C:time-range start="%[1]s" end="%[2]s"/>
+      </C:comp-filter>
+    </C:comp-filter>
+  </C:filter>
+</C:calendar-query>`
+
+// multistatus é a resposta 207 dos REPORTs.
+type multistatus struct {
+	XMLName   xml.Name `xml:"DAV: multistatus"`
+	Responses []struct {
+		Href     string `xml:"DAV: href"`
+		Propstat []struct {
+			Prop struct {
+				ETag         string `xml:"DAV: getetag"`
+				CalendarData string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+			} `xml:"DAV: prop"`
+			Status string `xml:"DAV: status"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// ListEvents retorna os recursos com eventos no período [start, end).
+func (c *Client) ListEvents(start, end time.Time) ([]Object, error) {
+	body := fmt.Sprintf(calendarQuery, start.UTC().Format(timeRangeLayout), end.UTC().Format(timeRangeLayout))
+	resp, err := c.do("REPORT", c.base.String(), []byte(body), map[string]string{
+		"Content-Type": "application/xml; charset=utf-8",
+		"Depth":        "1",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, http.StatusMultiStatus); err != nil {
+		return nil, err
+	}
+
+	var ms multistatus
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("resposta do calendário inválida: %w", err)
+	}
+	var objects []Object
+	for _, r := range ms.Responses {
+		for _, ps := range r.Propstat {
+			if ps.Prop.CalendarData == "" || !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			objects = append(objects, Object{
+				Href: c.resolve(r.Href).EscapedPath(),
+				ETag: ps.Prop.ETag,
+				Data: []byte(ps.Prop.CalendarData),
+			})
+		}
+	}
+	return objects, nil
+}
+
+// GetEvent busca um recurso pelo caminho.
+func (c *Client) GetEvent(href string) (*Object, error) {
+	target := c.resolve(href)
+	resp, err := c.do(http.MethodGet, target.String(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	return &Object{Href: target.EscapedPath(), ETag: resp.Header.Get("ETag"), Data: data}, nil
+}
+
+// PutEvent grava o recurso e retorna a nova ETag. Com etag vazia, o recurso só é criado
+// se ainda não existir; caso contrário, só é gravado se não tiver sido alterado desde a
+// leitura. Nos dois casos, a violação retorna ErrPreconditionFailed.
+func (c *Client) PutEvent(href string, data []byte, etag string) (string, error) {
+	headers := map[string]string{"Content-Type": calendarContentType}
+	if etag == "" {
+		headers["If-None-Match"] = "*"
+	} else {
+		headers["If-Match"] = etag
+	}
+	resp, err := c.do(http.MethodPut, c.resolve(href).String(), data, headers)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, http.StatusCreated, http.StatusNoContent, http.StatusOK); err != nil {
+		return "", err
+	}
+	// Servidores que alteram o conteúdo recebido não devolvem a ETag; ela é lida em
+	// seguida para que a próxima gravação possa ser condicionada a ela.
+	if newETag := resp.Header.Get("ETag"); newETag != "" {
+		return newETag, nil
+	}
+	object, err := c.GetEvent(href)
+	if err != nil {
+		return "", err
+	}
+	return object.ETag, nil
+}
+
+// DeleteEvent exclui o recurso. Com etag informada, só exclui se ele não tiver sido
+// alterado. Um recurso já inexistente não é considerado erro.
+func (c *Client) DeleteEvent(href, etag string) error {
+	headers := map[string]string{}
+	if etag != "" {
+		headers["If-Match"] = etag
+	}
+	resp, err := c.do(http.MethodDelete, c.resolve(href).String(), nil, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	err = checkStatus(resp, http.StatusNoContent, http.StatusOK)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) resolve(href string) *url.URL {
+	ref, err := url.Parse(href)
+	if err != nil {
+		return c.base
+	}
+	return c.base.ResolveReference(ref)
+}
+
+func (c *Client) do(method, target string, body []byte, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	req.Header.Set("User-Agent", "Bizly-CalDAV/1.0")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return c.httpClient.Do(req)
+}
+
+// checkStatus converte as respostas fora dos status esperados em erro.
+func checkStatus(resp *http.Response, expected ...int) error {
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("servidor de calendário respondeu %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
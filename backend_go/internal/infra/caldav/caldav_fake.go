@@ -0,0 +1,272 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/ical"
+)
+
+const (
+	// fakeMaxObjectSize limita o tamanho de cada evento gravado no servidor fake.
+	fakeMaxObjectSize = 256 << 10
+	// fakeMaxStorageSize limita o total guardado em memória pelo servidor fake.
+	fakeMaxStorageSize = 16 << 20
+	// fakeMaxObjects limita a quantidade de eventos guardados pelo servidor fake.
+	fakeMaxObjects = 10000
+)
+
+// fakeObject é um recurso guardado pelo servidor fake.
+type fakeObject struct {
+	data []byte
+	etag string
+}
+
+// FakeServer simula localmente um servidor CalDAV para que a sincronização possa ser
+// testada offline. Os calendários ficam em memória e são criados no primeiro acesso;
+// qualquer usuário e senha não vazios são aceitos. Rotas (relativas ao endereço em que
+// o servidor é exposto):
+//
+//	REPORT /calendars/{nome}/          calendar-query com filtro de período
+//	GET    /calendars/{nome}/{arquivo}  lê um evento
+//	PUT    /calendars/{nome}/{arquivo}  grava um evento (ex: um compromisso pessoal com curl)
+//	DELETE /calendars/{nome}/{arquivo}  exclui um evento
+//
+// PUT e DELETE respeitam If-Match e If-None-Match. Eventos recorrentes não são expandidos.
+// O espaço em memória é limitado: acima de fakeMaxStorageSize ou fakeMaxObjects, novas
+// gravações recebem 507 Insufficient Storage.
+type FakeServer struct {
+	basePath string
+	mu       sync.Mutex
+	objects  map[string]*fakeObject // Por caminho, relativo ao endereço do servidor
+	size     int                    // Total de bytes guardados em objects
+	started  int64                  // Distingue as ETags de execuções diferentes, já que os dados não persistem
+	version  int
+}
+
+// NewFakeServer cria um FakeServer vazio. basePath é o caminho em que ele é exposto
+// (ex: /api/v1/fake/caldav), usado nos endereços dos eventos nas respostas.
+func NewFakeServer(basePath string) *FakeServer {
+	return &FakeServer{
+		basePath: strings.TrimRight(basePath, "/"),
+		objects:  make(map[string]*fakeObject),
+		started:  time.Now().UnixNano(),
+	}
+}
+
+// ServeHTTP implementa http.Handler.
+func (s *FakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user == "" || pass == "" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="Bizly CalDAV fake"`)
+		http.Error(w, "autenticação obrigatória", http.StatusUnauthorized)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/calendars/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case "REPORT":
+		s.handleReport(w, r)
+	case http.MethodGet:
+		s.handleGet(w, r)
+	case http.MethodPut:
+		s.handlePut(w, r)
+	case http.MethodDelete:
+		s.handleDelete(w, r)
+	default:
+		w.Header().Set("Allow", "REPORT, GET, PUT, DELETE")
+		http.Error(w, "método não suportado", http.StatusMethodNotAllowed)
+	}
+}
+
+// fakeTimeRange é o filtro de período do calendar-query.
+type fakeTimeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+func (s *FakeServer) handleReport(w http.ResponseWriter, r *http.Request) {
+	collection := r.URL.Path
+	if !strings.HasSuffix(collection, "/") {
+		collection += "/"
+	}
+	start, end, err := readTimeRange(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	paths := make([]string, 0, len(s.objects))
+	for path := range s.objects {
+		if strings.HasPrefix(path, collection) && !strings.Contains(path[len(collection):], "/") {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var buf strings.Builder
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, `<D:multistatus xmlns:D="%s" xmlns:C="%s">`, nsDAV, nsCalDAV)
+	for _, path := range paths {
+		object := s.objects[path]
+		if !overlaps(object.data, start, end) {
+			continue
+		}
+		buf.WriteString("<D:response><D:href>")
+		xml.EscapeText(&buf, []byte(s.basePath+path))
+		buf.WriteString("</D:href><D:propstat><D:prop><D:getetag>")
+		xml.EscapeText(&buf, []byte(object.etag))
+		buf.WriteString("</D:getetag><C:calendar-data>")
+		xml.EscapeText(&buf, object.data)
+		buf.WriteString("</C:calendar-data></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>")
+	}
+	buf.WriteString("</D:multistatus>")
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	_, _ = io.WriteString(w, buf.String())
+}
+
+// readTimeRange lê o primeiro filtro time-range do corpo do REPORT; sem filtro, o
+// período é ilimitado.
+func readTimeRange(body io.Reader) (time.Time, time.Time, error) {
+	decoder := xml.NewDecoder(body)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return time.Time{}, time.Time{}, nil
+		}
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("corpo inválido: %v", err)
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Space != nsCalDAV || element.Name.Local != "time-range" {
+			continue
+		}
+		var tr fakeTimeRange
+		if err := decoder.DecodeElement(&tr, &element); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("time-range inválido: %v", err)
+		}
+		var start, end time.Time
+		if tr.Start != "" {
+			if start, err = time.Parse(timeRangeLayout, tr.Start); err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("time-range inválido: %v", err)
+			}
+		}
+		if tr.End != "" {
+			if end, err = time.Parse(timeRangeLayout, tr.End); err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("time-range inválido: %v", err)
+			}
+		}
+		return start, end, nil
+	}
+}
+
+// overlaps indica se algum evento do recurso ocorre no período.
+func overlaps(data []byte, start, end time.Time) bool {
+	events, err := ical.Parse(data)
+	if err != nil {
+		return false
+	}
+	for _, e := range events {
+		if (end.IsZero() || e.Start.Before(end)) && (start.IsZero() || e.End.After(start) || (e.End.Equal(e.Start) && !e.Start.Before(start))) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *FakeServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	object, ok := s.objects[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", calendarContentType)
+	w.Header().Set("ETag", object.etag)
+	_, _ = w.Write(object.data)
+}
+
+func (s *FakeServer) handlePut(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	if strings.HasSuffix(path, "/") {
+		http.Error(w, "informe o arquivo do evento", http.StatusBadRequest)
+		return
+	}
+	existing, exists := s.objects[path]
+	if !preconditionsMet(r, existing, exists) {
+		http.Error(w, "evento alterado", http.StatusPreconditionFailed)
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, fakeMaxObjectSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(data) > fakeMaxObjectSize {
+		http.Error(w, "evento grande demais", http.StatusRequestEntityTooLarge)
+		return
+	}
+	size := s.size + len(data)
+	if exists {
+		size -= len(existing.data)
+	} else if len(s.objects) >= fakeMaxObjects {
+		http.Error(w, "limite de eventos do servidor fake atingido", http.StatusInsufficientStorage)
+		return
+	}
+	if size > fakeMaxStorageSize {
+		http.Error(w, "limite de armazenamento do servidor fake atingido", http.StatusInsufficientStorage)
+		return
+	}
+	if _, err := ical.Parse(data); err != nil {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	s.version++
+	object := &fakeObject{data: data, etag: fmt.Sprintf(`"%x-%d"`, s.started, s.version)}
+	s.objects[path] = object
+	s.size = size
+	w.Header().Set("ETag", object.etag)
+	if exists {
+		w.WriteHeader(http.StatusNoContent)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (s *FakeServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	existing, exists := s.objects[r.URL.Path]
+	if !exists {
+		http.NotFound(w, r)
+		return
+	}
+	if !preconditionsMet(r, existing, exists) {
+		http.Error(w, "evento alterado", http.StatusPreconditionFailed)
+		return
+	}
+	delete(s.objects, r.URL.Path)
+	s.size -= len(existing.data)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// preconditionsMet avalia os cabeçalhos If-Match e If-None-Match.
+func preconditionsMet(r *http.Request, existing *fakeObject, exists bool) bool {
+	if match := r.Header.Get("If-Match"); match != "" {
+		return exists && (match == "*" || match == existing.etag)
+	}
+	if r.Header.Get("If-None-Match") == "*" {
+		return !exists
+	}
+	return true
+}
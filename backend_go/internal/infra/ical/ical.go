@@ -0,0 +1,166 @@
+// Package ical gera e lê calendários no formato iCalendar (RFC 5545), usados no feed de
+// agenda assinado pelos calendários dos celulares, nos anexos .ics dos e-mails e na
+// sincronização com calendários externos via CalDAV.
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status é o status de um evento (propriedade STATUS).
+type Status string
+
+const (
+	StatusTentative Status = "TENTATIVE"
+	StatusConfirmed Status = "CONFIRMED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+// MethodPublish é o método (propriedade METHOD) de um calendário enviado como anexo que
+// apenas publica os eventos, sem pedir resposta aos destinatários.
+const MethodPublish = "PUBLISH"
+
+// ContentType é o tipo MIME dos arquivos .ics.
+const ContentType = "text/calendar; charset=utf-8"
+
+// Event é um evento (VEVENT). UID deve ser estável e Sequence deve crescer a cada
+// alteração, para que os calendários substituam a versão anterior do evento.
+type Event struct {
+	UID          string
+	Sequence     int
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Location     string
+	Status       Status
+	Created      time.Time
+	LastModified time.Time
+	// RecurrenceID identifica a ocorrência de um evento recorrente; zero nos eventos simples.
+	RecurrenceID time.Time
+	// Transparent indica que o evento não ocupa o horário (TRANSP:TRANSPARENT).
+	Transparent bool
+	// AllDay indica que Start e End são datas (VALUE=DATE), no fuso local.
+	AllDay bool
+}
+
+// Calendar é um calendário (VCALENDAR) com seus eventos.
+type Calendar struct {
+	Name   string // Nome exibido pelos calendários que o suportam (X-WR-CALNAME)
+	Method string // Opcional: ex: MethodPublish
+	// RefreshInterval sugere de quanto em quanto tempo o feed deve ser atualizado.
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// Encode serializa o calendário, com linhas terminadas em CRLF e dobradas em 75 octetos.
+func (c *Calendar) Encode(now time.Time) []byte {
+	w := &writer{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//Bizly//Agenda//PT-BR")
+	w.line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		w.line("METHOD", c.Method)
+	}
+	if c.Name != "" {
+		w.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		w.line("REFRESH-INTERVAL;VALUE=DURATION", formatDuration(c.RefreshInterval))
+		w.line("X-PUBLISHED-TTL", formatDuration(c.RefreshInterval))
+	}
+	for _, e := range c.Events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", e.UID)
+		w.line("DTSTAMP", formatTime(now))
+		if !e.RecurrenceID.IsZero() {
+			w.line("RECURRENCE-ID", formatTime(e.RecurrenceID))
+		}
+		if e.AllDay {
+			w.line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+			w.line("DTEND;VALUE=DATE", e.End.Format(dateLayout))
+		} else {
+			w.line("DTSTART", formatTime(e.Start))
+			w.line("DTEND", formatTime(e.End))
+		}
+		w.line("SEQUENCE", strconv.Itoa(e.Sequence))
+		w.line("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			w.line("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.Location != "" {
+			w.line("LOCATION", escapeText(e.Location))
+		}
+		if e.Status != "" {
+			w.line("STATUS", string(e.Status))
+		}
+		if e.Transparent {
+			w.line("TRANSP", "TRANSPARENT")
+		}
+		if !e.Created.IsZero() {
+			w.line("CREATED", formatTime(e.Created))
+		}
+		if !e.LastModified.IsZero() {
+			w.line("LAST-MODIFIED", formatTime(e.LastModified))
+		}
+		w.line("END", "VEVENT")
+	}
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line escreve uma propriedade, dobrando-a em linhas de até 75 octetos sem partir
+// caracteres UTF-8 (RFC 5545, seção 3.1).
+func (w *writer) line(name, value string) {
+	content := name + ":" + value
+	limit := 75
+	for len(content) > limit {
+		cut := limit
+		for !isRuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		limit = 74 // As linhas de continuação começam com um espaço, que conta no limite
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// escapeText escapa um valor do tipo TEXT.
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+const (
+	utcLayout   = "20060102T150405Z"
+	localLayout = "20060102T150405"
+	dateLayout  = "20060102"
+)
+
+// formatTime formata o instante em UTC (ex: 20240115T143000Z).
+func formatTime(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+// formatDuration formata a duração no formato DURATION (ex: PT1H, PT15M).
+func formatDuration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return "PT" + strconv.Itoa(int(d/time.Hour)) + "H"
+	}
+	return "PT" + strconv.Itoa(int(d/time.Minute)) + "M"
+}
@@ -0,0 +1,221 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCalendar indica que o conteúdo não é um calendário iCalendar válido.
+var ErrInvalidCalendar = errors.New("calendário iCalendar inválido")
+
+// Parse lê os eventos (VEVENT) do calendário. Horários com TZID são convertidos pelo
+// banco de fusos do sistema (ou tratados como locais, se o fuso for desconhecido) e
+// horários sem fuso são considerados locais. Regras de recorrência (RRULE) não são
+// expandidas: peça ao servidor as ocorrências já expandidas.
+func Parse(data []byte) ([]Event, error) {
+	lines := unfold(data)
+	if len(lines) == 0 || !strings.EqualFold(strings.TrimSpace(lines[0]), "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("%w: BEGIN:VCALENDAR ausente", ErrInvalidCalendar)
+	}
+
+	var events []Event
+	var current *Event
+	var duration time.Duration
+	var hasEnd bool
+	depth := 0 // Componentes aninhados dentro do VEVENT (ex: VALARM) são ignorados
+	for n, line := range lines {
+		if line == "" {
+			continue
+		}
+		name, params, value, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: linha %d: %v", ErrInvalidCalendar, n+1, err)
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT") && current == nil:
+			current = &Event{}
+			duration, hasEnd, depth = 0, false, 0
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT") && current != nil && depth == 0:
+			if current.UID == "" || current.Start.IsZero() {
+				return nil, fmt.Errorf("%w: evento sem UID ou DTSTART", ErrInvalidCalendar)
+			}
+			if !hasEnd {
+				switch {
+				case duration > 0:
+					current.End = current.Start.Add(duration)
+				case current.AllDay:
+					current.End = current.Start.AddDate(0, 0, 1)
+				default:
+					current.End = current.Start
+				}
+			}
+			events = append(events, *current)
+			current = nil
+			continue
+		case current == nil:
+			continue
+		case name == "BEGIN":
+			depth++
+			continue
+		case name == "END":
+			depth--
+			continue
+		case depth > 0:
+			continue
+		}
+
+		switch name {
+		case "UID":
+			current.UID = value
+		case "SEQUENCE":
+			current.Sequence, _ = strconv.Atoi(value)
+		case "SUMMARY":
+			current.Summary = unescapeText(value)
+		case "DESCRIPTION":
+			current.Description = unescapeText(value)
+		case "LOCATION":
+			current.Location = unescapeText(value)
+		case "STATUS":
+			current.Status = Status(strings.ToUpper(value))
+		case "TRANSP":
+			current.Transparent = strings.EqualFold(value, "TRANSPARENT")
+		case "DTSTART":
+			current.Start, current.AllDay, err = parseDateTime(value, params)
+		case "DTEND":
+			current.End, _, err = parseDateTime(value, params)
+			hasEnd = true
+		case "DURATION":
+			duration, err = parseDuration(value)
+		case "RECURRENCE-ID":
+			current.RecurrenceID, _, err = parseDateTime(value, params)
+		case "CREATED":
+			current.Created, _, err = parseDateTime(value, params)
+		case "LAST-MODIFIED":
+			current.LastModified, _, err = parseDateTime(value, params)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: linha %d: %s: %v", ErrInvalidCalendar, n+1, name, err)
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("%w: END:VEVENT ausente", ErrInvalidCalendar)
+	}
+	return events, nil
+}
+
+// unfold desfaz a dobra das linhas (RFC 5545, seção 3.1). Aceita também linhas
+// terminadas apenas em LF, geradas por alguns servidores.
+func unfold(data []byte) []string {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseLine separa o nome, os parâmetros e o valor de uma propriedade
+// (ex: DTSTART;TZID=America/Sao_Paulo:20240115T143000).
+func parseLine(line string) (string, map[string]string, string, error) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", errors.New("propriedade sem valor")
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], nil
+}
+
+// parseDateTime lê um valor DATE-TIME ou DATE. Retorna também se o valor é uma data.
+func parseDateTime(value string, params map[string]string) (time.Time, bool, error) {
+	loc := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, time.Local)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcLayout, value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation(localLayout, value, loc)
+	return t, false, err
+}
+
+// parseDuration lê um valor DURATION positivo (ex: PT1H30M, P1D, P2W).
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.TrimPrefix(value, "+")
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("duração inválida: %q", value)
+	}
+	s = s[1:]
+	var total time.Duration
+	inTime := false
+	number := ""
+	for _, r := range s {
+		switch {
+		case r == 'T':
+			inTime = true
+		case r >= '0' && r <= '9':
+			number += string(r)
+		default:
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, fmt.Errorf("duração inválida: %q", value)
+			}
+			number = ""
+			switch {
+			case r == 'W' && !inTime:
+				total += time.Duration(n) * 7 * 24 * time.Hour
+			case r == 'D' && !inTime:
+				total += time.Duration(n) * 24 * time.Hour
+			case r == 'H' && inTime:
+				total += time.Duration(n) * time.Hour
+			case r == 'M' && inTime:
+				total += time.Duration(n) * time.Minute
+			case r == 'S' && inTime:
+				total += time.Duration(n) * time.Second
+			default:
+				return 0, fmt.Errorf("duração inválida: %q", value)
+			}
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("duração inválida: %q", value)
+	}
+	return total, nil
+}
+
+// unescapeText desfaz o escape de um valor do tipo TEXT.
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"html"
+	"strings"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/messaging"
+)
+
+// ChannelName é o nome com que o canal de e-mail é registrado nos lembretes.
+const ChannelName = "EMAIL"
+
+// Channel adapta um Mailer ao contrato messaging.Channel, para que os lembretes de
+// agendamento também sejam enviados pelo servidor de e-mail configurado.
+type Channel struct {
+	mailer Mailer
+}
+
+// NewChannel cria o canal de e-mail a partir do mailer.
+func NewChannel(mailer Mailer) *Channel {
+	return &Channel{mailer: mailer}
+}
+
+// Name retorna o identificador do canal.
+func (c *Channel) Name() string {
+	return ChannelName
+}
+
+// Send envia a mensagem como e-mail, com o corpo em texto e em HTML simples.
+func (c *Channel) Send(msg messaging.Message) (string, error) {
+	body := strings.ReplaceAll(html.EscapeString(msg.Body), "\n", "<br>\n")
+	return c.mailer.Send(Email{
+		To:      msg.To,
+		Subject: msg.Subject,
+		Text:    msg.Body,
+		HTML:    "<!DOCTYPE html>\n<html><body>" + body + "</body></html>",
+	})
+}
@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LogMailer substitui o servidor SMTP em desenvolvimento: registra os e-mails no log e,
+// se um diretório for informado, grava cada mensagem como um arquivo .eml que pode ser
+// aberto em qualquer cliente de e-mail.
+type LogMailer struct {
+	from string
+	dir  string
+}
+
+// NewLogMailer cria um LogMailer com o remetente informado. dir vazio registra apenas no log.
+func NewLogMailer(from, dir string) *LogMailer {
+	return &LogMailer{from: from, dir: dir}
+}
+
+// Send registra o e-mail e retorna o Message-ID gerado.
+func (m *LogMailer) Send(email Email) (string, error) {
+	msg, messageID, err := buildMessage(m.from, email, time.Now())
+	if err != nil {
+		return "", err
+	}
+	log.Printf("[EMAIL] mensagem %s para %s: %s", messageID, email.To, email.Subject)
+	if m.dir == "" {
+		return messageID, nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405"), strings.Trim(messageID, "<>"))
+	if err := os.WriteFile(filepath.Join(m.dir, name), msg, 0o644); err != nil {
+		return "", err
+	}
+	return messageID, nil
+}
@@ -0,0 +1,177 @@
+// Package mail implementa o envio de e-mails aos clientes: o adaptador SMTP com pool
+// de conexões, um mailer local para desenvolvimento e os templates HTML/texto.
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidEmail indica que o e-mail não pode ser enviado como informado (ex: sem
+// destinatário ou com endereço inválido). Não adianta tentar novamente.
+var ErrInvalidEmail = errors.New("e-mail inválido")
+
+// Email é uma mensagem com as versões HTML e texto do mesmo conteúdo.
+type Email struct {
+	FromName    string // Nome exibido; o endereço é o remetente configurado no mailer
+	To          string
+	ReplyTo     string
+	Subject     string
+	HTML        string
+	Text        string
+	Attachments []Attachment
+}
+
+// Attachment é um arquivo anexado ao e-mail (ex: o convite .ics do agendamento).
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Mailer define o contrato de um adaptador de envio de e-mails.
+type Mailer interface {
+	// Send envia o e-mail e retorna o Message-ID atribuído.
+	Send(email Email) (string, error)
+}
+
+// IsPermanent indica se o erro de envio é definitivo (e-mail inválido ou recusado pelo
+// servidor com código 5xx), caso em que novas tentativas não adiantam.
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrInvalidEmail) {
+		return true
+	}
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr) && protoErr.Code >= 500
+}
+
+// buildMessage monta a mensagem MIME multipart/alternative (texto e HTML) a partir do
+// e-mail; com anexos, ela é envolvida em um multipart/mixed. Retorna a mensagem e o
+// Message-ID gerado.
+func buildMessage(from string, email Email, now time.Time) ([]byte, string, error) {
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: destinatário %q: %v", ErrInvalidEmail, email.To, err)
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: remetente %q: %v", ErrInvalidEmail, from, err)
+	}
+	if email.FromName != "" {
+		sender.Name = email.FromName
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndex(sender.Address, "@"); at >= 0 {
+		domain = sender.Address[at+1:]
+	}
+	messageID := fmt.Sprintf("<%s@%s>", uuid.NewString(), domain)
+
+	// O corpo multipart/alternative é montado à parte para poder ser aninhado no
+	// multipart/mixed quando há anexos.
+	var body bytes.Buffer
+	alternative := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", email.Text},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		if part.body == "" {
+			continue
+		}
+		w, err := alternative.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, "", err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := alternative.Close(); err != nil {
+		return nil, "", err
+	}
+	contentType := "multipart/alternative; boundary=" + alternative.Boundary()
+
+	if len(email.Attachments) > 0 {
+		var mixedBody bytes.Buffer
+		mixed := multipart.NewWriter(&mixedBody)
+		w, err := mixed.CreatePart(textproto.MIMEHeader{"Content-Type": {contentType}})
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := w.Write(body.Bytes()); err != nil {
+			return nil, "", err
+		}
+		for _, attachment := range email.Attachments {
+			w, err := mixed.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {attachment.ContentType},
+				"Content-Transfer-Encoding": {"base64"},
+				"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			if err := writeBase64Lines(w, attachment.Data); err != nil {
+				return nil, "", err
+			}
+		}
+		if err := mixed.Close(); err != nil {
+			return nil, "", err
+		}
+		body = mixedBody
+		contentType = "multipart/mixed; boundary=" + mixed.Boundary()
+	}
+
+	headers := []string{
+		"From: " + sender.String(),
+		"To: " + to.String(),
+		"Subject: " + mime.QEncoding.Encode("utf-8", email.Subject),
+		"Date: " + now.Format(time.RFC1123Z),
+		"Message-ID: " + messageID,
+		"MIME-Version: 1.0",
+		"Content-Type: " + contentType,
+	}
+	if email.ReplyTo != "" {
+		replyTo, err := mail.ParseAddress(email.ReplyTo)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: responder para %q: %v", ErrInvalidEmail, email.ReplyTo, err)
+		}
+		headers = append(headers, "Reply-To: "+replyTo.String())
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+	buf.Write(body.Bytes())
+	return buf.Bytes(), messageID, nil
+}
+
+// writeBase64Lines escreve os dados em base64 com linhas de 76 caracteres (RFC 2045).
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(w, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := io.WriteString(w, encoded+"\r\n")
+	return err
+}
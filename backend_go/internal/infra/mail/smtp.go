@@ -0,0 +1,180 @@
+package mail
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPConfig reúne os dados de acesso ao servidor SMTP.
+type SMTPConfig struct {
+	Host     string
+	Port     int // 465 usa TLS implícito; nas demais portas o STARTTLS é usado se oferecido
+	Username string
+	Password string
+	From     string // Endereço remetente (ex: nao-responda@bizly.com.br)
+	PoolSize int    // Número máximo de conexões ociosas mantidas abertas
+	Retries  int    // Tentativas por envio antes de devolver o erro
+	Timeout  time.Duration
+}
+
+// SMTPMailer envia e-mails por SMTP reaproveitando conexões autenticadas. Conexões que
+// falham são descartadas e o envio é repetido em uma nova conexão, até Retries vezes.
+type SMTPMailer struct {
+	cfg  SMTPConfig
+	pool chan *smtp.Client
+}
+
+// NewSMTPMailer cria um SMTPMailer. As conexões são abertas sob demanda.
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 2
+	}
+	if cfg.Retries <= 0 {
+		cfg.Retries = 3
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	return &SMTPMailer{cfg: cfg, pool: make(chan *smtp.Client, cfg.PoolSize)}
+}
+
+// Send envia o e-mail. Erros definitivos (ver IsPermanent) não são repetidos.
+func (m *SMTPMailer) Send(email Email) (string, error) {
+	msg, messageID, err := buildMessage(m.cfg.From, email, time.Now())
+	if err != nil {
+		return "", err
+	}
+	from, _ := mail.ParseAddress(m.cfg.From) // Já validado em buildMessage
+	to, _ := mail.ParseAddress(email.To)
+
+	for attempt := 1; ; attempt++ {
+		err = m.sendOnce(from.Address, to.Address, msg)
+		if err == nil {
+			return messageID, nil
+		}
+		if IsPermanent(err) || attempt >= m.cfg.Retries {
+			return "", err
+		}
+		log.Printf("Falha ao enviar e-mail para %s (tentativa %d): %v", to.Address, attempt, err)
+		time.Sleep(time.Duration(attempt) * 500 * time.Millisecond)
+	}
+}
+
+func (m *SMTPMailer) sendOnce(from, to string, msg []byte) error {
+	client, err := m.acquire()
+	if err != nil {
+		return err
+	}
+	if err := deliver(client, from, to, msg); err != nil {
+		// A conexão pode ter ficado em estado indefinido; descarta em vez de devolver ao pool.
+		client.Close()
+		return err
+	}
+	m.release(client)
+	return nil
+}
+
+func deliver(client *smtp.Client, from, to string, msg []byte) error {
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// acquire retorna uma conexão ociosa do pool que ainda responde ou abre uma nova.
+func (m *SMTPMailer) acquire() (*smtp.Client, error) {
+	for {
+		select {
+		case client := <-m.pool:
+			if err := client.Reset(); err == nil {
+				return client, nil
+			}
+			client.Close()
+		default:
+			return m.dial()
+		}
+	}
+}
+
+// release devolve a conexão ao pool ou a encerra se o pool estiver cheio.
+func (m *SMTPMailer) release(client *smtp.Client) {
+	select {
+	case m.pool <- client:
+	default:
+		client.Quit()
+	}
+}
+
+func (m *SMTPMailer) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: m.cfg.Timeout}
+	tlsConfig := &tls.Config{ServerName: m.cfg.Host}
+
+	var conn net.Conn
+	var err error
+	if m.cfg.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao conectar ao servidor SMTP %s: %w", addr, err)
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("falha ao iniciar sessão SMTP: %v", err)
+	}
+	if m.cfg.Port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("falha no STARTTLS: %v", err)
+			}
+		}
+	}
+	if m.cfg.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			client.Close()
+			return nil, errors.New("servidor SMTP não aceita autenticação")
+		}
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			client.Close()
+			// %v: recusas da autenticação são de configuração, não da mensagem, e não
+			// devem ser tratadas como definitivas pelo IsPermanent.
+			return nil, fmt.Errorf("falha na autenticação SMTP: %v", err)
+		}
+	}
+	return client, nil
+}
+
+// Close encerra as conexões ociosas do pool.
+func (m *SMTPMailer) Close() {
+	for {
+		select {
+		case client := <-m.pool:
+			client.Quit()
+		default:
+			return
+		}
+	}
+}
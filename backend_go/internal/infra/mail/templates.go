@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*
+var templateFiles embed.FS
+
+var (
+	appointmentHTMLTemplate = htmltemplate.Must(htmltemplate.ParseFS(templateFiles, "templates/appointment.html"))
+	appointmentTextTemplate = texttemplate.Must(texttemplate.ParseFS(templateFiles, "templates/appointment.txt"))
+)
+
+// Branding reúne a identidade visual do negócio aplicada aos e-mails.
+type Branding struct {
+	BusinessName string
+	LogoURL      string
+	PrimaryColor string // #RRGGBB
+	FooterText   string
+}
+
+// Detail é uma linha do quadro de detalhes (ex: "Data" / "12/05/2025").
+type Detail struct {
+	Label string
+	Value string
+}
+
+// Content é o conteúdo, já traduzido, de um e-mail. O mesmo conteúdo gera as versões
+// HTML e texto.
+type Content struct {
+	Lang       string // Atributo lang do HTML (ex: pt-BR)
+	Branding   Branding
+	Preheader  string // Resumo exibido pelos clientes de e-mail ao lado do assunto
+	Title      string
+	Greeting   string
+	Paragraphs []string
+	Details    []Detail
+	Closing    string
+	FooterNote string
+}
+
+// Render gera as versões HTML e texto do e-mail. No HTML todo o conteúdo é escapado.
+func Render(content Content) (string, string, error) {
+	var htmlBuf, textBuf bytes.Buffer
+	if err := appointmentHTMLTemplate.Execute(&htmlBuf, content); err != nil {
+		return "", "", err
+	}
+	if err := appointmentTextTemplate.Execute(&textBuf, content); err != nil {
+		return "", "", err
+	}
+	return htmlBuf.String(), textBuf.String(), nil
+}
@@ -0,0 +1,64 @@
+// Package messaging define o contrato dos canais de envio de mensagens aos clientes
+// (e-mail, SMS, WhatsApp) usados pelos lembretes de agendamento.
+package messaging
+
+import (
+	"errors"
+)
+
+var (
+	// ErrChannelNotFound indica que não há canal registrado com o nome informado.
+	ErrChannelNotFound = errors.New("canal de mensagens não encontrado")
+)
+
+// Message é uma mensagem a ser enviada a um destinatário.
+type Message struct {
+	To       string // E-mail ou telefone, conforme o canal
+	Subject  string // Usado apenas pelos canais que suportam assunto (ex: e-mail)
+	Body     string
+	Template *Template // Opcional: template pré-aprovado, usado pelos canais que o exigem (ex: WhatsApp)
+}
+
+// Template referencia um template de mensagem cadastrado no provedor. Body continua
+// sendo o texto equivalente, usado pelos canais sem suporte a templates.
+type Template struct {
+	Name     string
+	Language string   // Ex: pt_BR
+	Params   []string // Parâmetros do corpo, na ordem ({{1}}, {{2}}, ...)
+}
+
+// Channel define o contrato de um adaptador de envio de mensagens.
+type Channel interface {
+	// Name retorna o identificador do canal (ex: EMAIL, SMS, WHATSAPP).
+	Name() string
+	// Send envia a mensagem e retorna o ID atribuído pelo provedor, se houver.
+	Send(msg Message) (string, error)
+}
+
+// Registry mantém os canais disponíveis indexados pelo nome.
+type Registry struct {
+	channels map[string]Channel
+}
+
+// NewRegistry cria um Registry com os canais informados.
+func NewRegistry(channels ...Channel) *Registry {
+	registry := &Registry{channels: make(map[string]Channel)}
+	for _, c := range channels {
+		registry.Register(c)
+	}
+	return registry
+}
+
+// Register adiciona um canal, substituindo o registrado anteriormente com o mesmo nome.
+func (r *Registry) Register(channel Channel) {
+	r.channels[channel.Name()] = channel
+}
+
+// Get retorna o canal com o nome informado.
+func (r *Registry) Get(name string) (Channel, error) {
+	c, ok := r.channels[name]
+	if !ok {
+		return nil, ErrChannelNotFound
+	}
+	return c, nil
+}
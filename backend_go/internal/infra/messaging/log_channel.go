@@ -0,0 +1,58 @@
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogChannel é um canal local que apenas registra as mensagens no log do servidor e,
+// opcionalmente, as acrescenta a um arquivo. Substitui os provedores reais em
+// desenvolvimento e testes.
+type LogChannel struct {
+	name string
+	path string // Arquivo de saída; vazio registra apenas no log
+	mu   sync.Mutex
+}
+
+// NewLogChannel cria um LogChannel que se apresenta com o nome informado (ex: EMAIL).
+func NewLogChannel(name, path string) *LogChannel {
+	return &LogChannel{name: name, path: path}
+}
+
+// Name retorna o identificador do canal.
+func (c *LogChannel) Name() string {
+	return c.name
+}
+
+// Send registra a mensagem e retorna um ID local.
+func (c *LogChannel) Send(msg Message) (string, error) {
+	if strings.TrimSpace(msg.To) == "" {
+		return "", errors.New("destinatário não informado")
+	}
+	id := "log-" + uuid.NewString()
+	log.Printf("[%s] mensagem %s para %s: %s", c.name, id, msg.To, msg.Body)
+	if c.path == "" {
+		return id, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	entry := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\n",
+		time.Now().Format(time.RFC3339), c.name, id, msg.To, msg.Subject, strings.ReplaceAll(msg.Body, "\n", " "))
+	if _, err := f.WriteString(entry); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,317 @@
+package messaging
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WhatsAppChannelName é o nome do canal de WhatsApp no Registry.
+const WhatsAppChannelName = "WHATSAPP"
+
+// WhatsAppSignatureHeader é o cabeçalho onde a API do WhatsApp envia a assinatura
+// HMAC-SHA256 do corpo dos webhooks ("sha256=<hex>").
+const WhatsAppSignatureHeader = "X-Hub-Signature-256"
+
+var (
+	// ErrInvalidSignature indica que a assinatura HMAC do webhook não confere.
+	ErrInvalidSignature = errors.New("assinatura do webhook inválida")
+	// ErrInvalidPayload indica que o corpo do webhook não pôde ser interpretado.
+	ErrInvalidPayload = errors.New("payload do webhook inválido")
+)
+
+// WhatsAppConfig contém os dados de acesso à API do WhatsApp Business (Cloud API).
+type WhatsAppConfig struct {
+	BaseURL       string // Ex: https://graph.facebook.com/v19.0 ou o endereço do servidor fake
+	PhoneNumberID string // ID do número remetente
+	AccessToken   string
+	AppSecret     string // Segredo usado para validar a assinatura dos webhooks
+	UseTemplates  bool   // Envia os templates pré-aprovados; se false, envia apenas texto
+	Timeout       time.Duration
+}
+
+// InboundMessage é uma mensagem recebida de um cliente via webhook.
+type InboundMessage struct {
+	ID        string // ID da mensagem no provedor (chave de idempotência)
+	From      string // Telefone do cliente, apenas dígitos com DDI
+	Text      string // Texto digitado ou o identificador do botão de resposta
+	ContextID string // ID da mensagem respondida, quando o cliente usa "responder"
+	Timestamp time.Time
+}
+
+// WhatsAppChannel envia mensagens pela API do WhatsApp Business e interpreta os
+// webhooks de mensagens recebidas.
+type WhatsAppChannel struct {
+	cfg    WhatsAppConfig
+	client *http.Client
+}
+
+// NewWhatsAppChannel cria um WhatsAppChannel.
+func NewWhatsAppChannel(cfg WhatsAppConfig) *WhatsAppChannel {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &WhatsAppChannel{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// Name retorna o identificador do canal.
+func (c *WhatsAppChannel) Name() string {
+	return WhatsAppChannelName
+}
+
+// whatsAppSendRequest é o corpo de envio de mensagens da Cloud API.
+type whatsAppSendRequest struct {
+	MessagingProduct string            `json:"messaging_product"`
+	To               string            `json:"to"`
+	Type             string            `json:"type"`
+	Text             *whatsAppText     `json:"text,omitempty"`
+	Template         *whatsAppTemplate `json:"template,omitempty"`
+}
+
+type whatsAppText struct {
+	Body string `json:"body"`
+}
+
+type whatsAppTemplate struct {
+	Name       string                      `json:"name"`
+	Language   whatsAppTemplateLanguage    `json:"language"`
+	Components []whatsAppTemplateComponent `json:"components,omitempty"`
+}
+
+type whatsAppTemplateLanguage struct {
+	Code string `json:"code"`
+}
+
+type whatsAppTemplateComponent struct {
+	Type       string                      `json:"type"`
+	Parameters []whatsAppTemplateParameter `json:"parameters"`
+}
+
+type whatsAppTemplateParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// whatsAppSendResponse é a resposta de envio da Cloud API.
+type whatsAppSendResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+	Error *struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+// Send envia a mensagem como template (se informado e habilitado) ou como texto.
+func (c *WhatsAppChannel) Send(msg Message) (string, error) {
+	to := NormalizePhone(msg.To)
+	if to == "" {
+		return "", errors.New("telefone do destinatário inválido")
+	}
+
+	req := whatsAppSendRequest{MessagingProduct: "whatsapp", To: to}
+	if msg.Template != nil && c.cfg.UseTemplates {
+		params := make([]whatsAppTemplateParameter, len(msg.Template.Params))
+		for i, p := range msg.Template.Params {
+			params[i] = whatsAppTemplateParameter{Type: "text", Text: p}
+		}
+		req.Type = "template"
+		req.Template = &whatsAppTemplate{
+			Name:     msg.Template.Name,
+			Language: whatsAppTemplateLanguage{Code: msg.Template.Language},
+		}
+		if len(params) > 0 {
+			req.Template.Components = []whatsAppTemplateComponent{{Type: "body", Parameters: params}}
+		}
+	} else {
+		req.Type = "text"
+		req.Text = &whatsAppText{Body: msg.Body}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%s/messages", c.cfg.BaseURL, c.cfg.PhoneNumberID), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.cfg.AccessToken)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("falha ao chamar a API do WhatsApp: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out whatsAppSendResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out); err != nil {
+		return "", fmt.Errorf("resposta inválida da API do WhatsApp (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode >= 300 || out.Error != nil {
+		if out.Error != nil {
+			return "", fmt.Errorf("API do WhatsApp recusou a mensagem (%d): %s", out.Error.Code, out.Error.Message)
+		}
+		return "", fmt.Errorf("API do WhatsApp retornou HTTP %d", resp.StatusCode)
+	}
+	if len(out.Messages) == 0 || out.Messages[0].ID == "" {
+		return "", errors.New("API do WhatsApp não retornou o ID da mensagem")
+	}
+	return out.Messages[0].ID, nil
+}
+
+// whatsAppWebhookPayload é o formato dos webhooks de mensagens da Cloud API.
+type whatsAppWebhookPayload struct {
+	Object string `json:"object"`
+	Entry  []struct {
+		Changes []struct {
+			Field string `json:"field"`
+			Value struct {
+				Messages []whatsAppInbound `json:"messages"`
+			} `json:"value"`
+		} `json:"changes"`
+	} `json:"entry"`
+}
+
+type whatsAppInbound struct {
+	From      string `json:"from"`
+	ID        string `json:"id"`
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"`
+	Text      *struct {
+		Body string `json:"body"`
+	} `json:"text,omitempty"`
+	Button *struct {
+		Payload string `json:"payload"`
+		Text    string `json:"text"`
+	} `json:"button,omitempty"`
+	Interactive *struct {
+		ButtonReply *struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"button_reply,omitempty"`
+	} `json:"interactive,omitempty"`
+	Context *struct {
+		ID string `json:"id"`
+	} `json:"context,omitempty"`
+}
+
+// ParseWebhook valida a assinatura e extrai as mensagens recebidas. Notificações de
+// status (entregue, lida) e mensagens sem texto são ignoradas.
+func (c *WhatsAppChannel) ParseWebhook(headers http.Header, body []byte) ([]InboundMessage, error) {
+	if !verifyHubSignature(c.cfg.AppSecret, body, headers.Get(WhatsAppSignatureHeader)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload whatsAppWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	var messages []InboundMessage
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			for _, m := range change.Value.Messages {
+				inbound := InboundMessage{ID: m.ID, From: NormalizePhone(m.From)}
+				switch {
+				case m.Text != nil:
+					inbound.Text = m.Text.Body
+				case m.Button != nil:
+					inbound.Text = firstNonEmpty(m.Button.Payload, m.Button.Text)
+				case m.Interactive != nil && m.Interactive.ButtonReply != nil:
+					inbound.Text = firstNonEmpty(m.Interactive.ButtonReply.ID, m.Interactive.ButtonReply.Title)
+				}
+				if m.ID == "" || inbound.From == "" || strings.TrimSpace(inbound.Text) == "" {
+					continue
+				}
+				if m.Context != nil {
+					inbound.ContextID = m.Context.ID
+				}
+				if seconds, err := strconv.ParseInt(m.Timestamp, 10, 64); err == nil {
+					inbound.Timestamp = time.Unix(seconds, 0)
+				} else {
+					inbound.Timestamp = time.Now()
+				}
+				messages = append(messages, inbound)
+			}
+		}
+	}
+	return messages, nil
+}
+
+// NormalizePhone mantém apenas os dígitos do telefone e acrescenta o DDI do Brasil
+// (55) aos números nacionais com DDD (10 ou 11 dígitos).
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := strings.TrimLeft(b.String(), "0")
+	if len(digits) == 10 || len(digits) == 11 {
+		digits = "55" + digits
+	}
+	if len(digits) < 10 {
+		return ""
+	}
+	return digits
+}
+
+// PhoneVariants retorna o número normalizado e as outras formas com que o mesmo celular
+// pode aparecer: no Brasil (DDI 55), o WhatsApp informa alguns números sem o nono dígito.
+// Retorna nil se o número for inválido.
+func PhoneVariants(phone string) []string {
+	phone = NormalizePhone(phone)
+	if phone == "" {
+		return nil
+	}
+	variants := []string{phone}
+	if strings.HasPrefix(phone, "55") {
+		areaCode, local := phone[2:4], phone[4:]
+		switch {
+		case len(local) == 9 && local[0] == '9' && local[1] >= '6':
+			variants = append(variants, "55"+areaCode+local[1:])
+		case len(local) == 8 && local[0] >= '6':
+			variants = append(variants, "55"+areaCode+"9"+local)
+		}
+	}
+	return variants
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// signHubPayload calcula a assinatura no formato do cabeçalho X-Hub-Signature-256.
+func signHubPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyHubSignature compara, em tempo constante, a assinatura recebida com a esperada.
+func verifyHubSignature(secret string, payload []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	expected := signHubPayload(secret, payload)
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(strings.TrimSpace(signature))))
+}
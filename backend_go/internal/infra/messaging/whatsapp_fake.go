@@ -0,0 +1,233 @@
+package messaging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeMaxMessages limita as mensagens guardadas em memória pelo servidor fake; acima
+// dele, as mais antigas são descartadas.
+const fakeMaxMessages = 1000
+
+// FakeWhatsAppMessage é uma mensagem recebida pelo servidor fake, como o cliente a veria.
+type FakeWhatsAppMessage struct {
+	ID       string    `json:"id"`
+	To       string    `json:"to"`
+	Type     string    `json:"type"` // text ou template
+	Text     string    `json:"text,omitempty"`
+	Template string    `json:"template,omitempty"`
+	Params   []string  `json:"params,omitempty"`
+	SentAt   time.Time `json:"sentAt"`
+}
+
+// fakeReplyRequest é o corpo aceito por POST /reply.
+type fakeReplyRequest struct {
+	From      string `json:"from"`
+	Text      string `json:"text"`
+	ContextID string `json:"contextId"` // Opcional: ID da mensagem respondida
+}
+
+// WhatsAppFakeServer simula localmente a Cloud API do WhatsApp para que todo o fluxo
+// (envio, resposta do cliente e webhook assinado) possa ser testado offline. Todas as
+// rotas exigem o token de acesso no cabeçalho Authorization, como a API real:
+//
+//	POST /{phoneNumberID}/messages  recebe os envios do WhatsAppChannel
+//	GET  /messages?to=              lista as mensagens enviadas
+//	POST /reply                     simula a resposta de um cliente e entrega o webhook
+type WhatsAppFakeServer struct {
+	accessToken string
+	appSecret   string
+	webhookURL  string
+	client      *http.Client
+	mu          sync.Mutex
+	sent        []FakeWhatsAppMessage
+	mux         *http.ServeMux
+}
+
+// NewWhatsAppFakeServer cria um WhatsAppFakeServer que exige o token informado em
+// todas as requisições e entrega as respostas simuladas em webhookURL, assinadas com appSecret.
+func NewWhatsAppFakeServer(accessToken, appSecret, webhookURL string) *WhatsAppFakeServer {
+	s := &WhatsAppFakeServer{
+		accessToken: accessToken,
+		appSecret:   appSecret,
+		webhookURL:  webhookURL,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		mux:         http.NewServeMux(),
+	}
+	s.mux.HandleFunc("POST /{phoneNumberID}/messages", s.handleSend)
+	s.mux.HandleFunc("GET /messages", s.handleList)
+	s.mux.HandleFunc("POST /reply", s.handleReply)
+	return s
+}
+
+// ServeHTTP implementa http.Handler.
+func (s *WhatsAppFakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func fakeError(w http.ResponseWriter, status, code int, message string) {
+	writeFakeJSON(w, status, map[string]any{"error": map[string]any{"message": message, "code": code}})
+}
+
+// authorized confere o token de acesso da requisição e responde 401 quando ele não confere.
+func (s *WhatsAppFakeServer) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("Authorization") != "Bearer "+s.accessToken {
+		fakeError(w, http.StatusUnauthorized, 190, "token de acesso inválido")
+		return false
+	}
+	return true
+}
+
+func (s *WhatsAppFakeServer) handleSend(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+	var req whatsAppSendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		fakeError(w, http.StatusBadRequest, 100, "corpo inválido: "+err.Error())
+		return
+	}
+	if req.MessagingProduct != "whatsapp" || req.To == "" {
+		fakeError(w, http.StatusBadRequest, 100, "messaging_product e to são obrigatórios")
+		return
+	}
+
+	msg := FakeWhatsAppMessage{ID: "wamid.fake." + uuid.NewString(), To: req.To, Type: req.Type, SentAt: time.Now()}
+	switch req.Type {
+	case "text":
+		if req.Text == nil || strings.TrimSpace(req.Text.Body) == "" {
+			fakeError(w, http.StatusBadRequest, 100, "text.body é obrigatório")
+			return
+		}
+		msg.Text = req.Text.Body
+	case "template":
+		if req.Template == nil || req.Template.Name == "" {
+			fakeError(w, http.StatusBadRequest, 132000, "template.name é obrigatório")
+			return
+		}
+		msg.Template = req.Template.Name
+		for _, component := range req.Template.Components {
+			for _, p := range component.Parameters {
+				msg.Params = append(msg.Params, p.Text)
+			}
+		}
+	default:
+		fakeError(w, http.StatusBadRequest, 100, "tipo de mensagem não suportado: "+req.Type)
+		return
+	}
+
+	s.mu.Lock()
+	if len(s.sent) >= fakeMaxMessages {
+		s.sent = s.sent[len(s.sent)-fakeMaxMessages+1:]
+	}
+	s.sent = append(s.sent, msg)
+	s.mu.Unlock()
+
+	writeFakeJSON(w, http.StatusOK, map[string]any{
+		"messaging_product": "whatsapp",
+		"contacts":          []map[string]string{{"input": req.To, "wa_id": req.To}},
+		"messages":          []map[string]string{{"id": msg.ID}},
+	})
+}
+
+func (s *WhatsAppFakeServer) handleList(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+	to := NormalizePhone(r.URL.Query().Get("to"))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := make([]FakeWhatsAppMessage, 0, len(s.sent))
+	for _, m := range s.sent {
+		if to == "" || m.To == to {
+			messages = append(messages, m)
+		}
+	}
+	writeFakeJSON(w, http.StatusOK, messages)
+}
+
+func (s *WhatsAppFakeServer) handleReply(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+	var req fakeReplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		fakeError(w, http.StatusBadRequest, 100, "corpo inválido: "+err.Error())
+		return
+	}
+	from := NormalizePhone(req.From)
+	if from == "" || strings.TrimSpace(req.Text) == "" {
+		fakeError(w, http.StatusBadRequest, 100, "from e text são obrigatórios")
+		return
+	}
+
+	messageID := "wamid.fake.in." + uuid.NewString()
+	body, signature, err := s.BuildReplyWebhook(messageID, from, req.Text, req.ContextID)
+	if err != nil {
+		fakeError(w, http.StatusInternalServerError, 1, err.Error())
+		return
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		fakeError(w, http.StatusInternalServerError, 1, err.Error())
+		return
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(WhatsAppSignatureHeader, signature)
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		fakeError(w, http.StatusBadGateway, 1, "falha ao entregar o webhook: "+err.Error())
+		return
+	}
+	resp.Body.Close()
+
+	writeFakeJSON(w, http.StatusOK, map[string]any{"messageId": messageID, "webhookStatus": resp.StatusCode})
+}
+
+// BuildReplyWebhook monta um webhook de mensagem recebida no formato da Cloud API,
+// assinado como o provedor real faria. Retorna o corpo e o valor do cabeçalho
+// WhatsAppSignatureHeader.
+func (s *WhatsAppFakeServer) BuildReplyWebhook(messageID, from, text, contextID string) ([]byte, string, error) {
+	message := map[string]any{
+		"from":      from,
+		"id":        messageID,
+		"timestamp": strconv.FormatInt(time.Now().Unix(), 10),
+		"type":      "text",
+		"text":      map[string]string{"body": text},
+	}
+	if contextID != "" {
+		message["context"] = map[string]string{"id": contextID}
+	}
+	payload := map[string]any{
+		"object": "whatsapp_business_account",
+		"entry": []any{map[string]any{
+			"id": "fake-waba",
+			"changes": []any{map[string]any{
+				"field": "messages",
+				"value": map[string]any{
+					"messaging_product": "whatsapp",
+					"messages":          []any{message},
+				},
+			}},
+		}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("falha ao montar webhook: %w", err)
+	}
+	return body, signHubPayload(s.appSecret, body), nil
+}
@@ -0,0 +1,233 @@
+package nfse
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// abrasfNamespace é o namespace do schema nacional ABRASF (versão 2.04).
+const abrasfNamespace = "http://www.abrasf.org.br/nfse.xsd"
+
+// Party identifica o prestador ou o tomador do serviço.
+type Party struct {
+	Document              string // CPF (11 dígitos) ou CNPJ (14 dígitos); outros caracteres são ignorados
+	MunicipalRegistration string // Inscrição municipal (apenas prestador)
+	Name                  string
+	Email                 string
+	Phone                 string
+}
+
+// Document é o RPS (Recibo Provisório de Serviços) que será convertido em NFS-e.
+type Document struct {
+	RPSNumber        int
+	RPSSeries        string
+	IssueDate        time.Time
+	Competence       time.Time // Data de prestação do serviço
+	Provider         Party     // Prestador
+	Taker            Party     // Tomador; documento vazio indica consumidor não identificado
+	ServiceAmount    float64
+	ISSRate          float64 // Alíquota do ISS em percentual (ex: 2 para 2%)
+	ISSWithheld      bool    // ISS retido pelo tomador
+	ServiceItemCode  string  // Item da lista de serviços da LC 116 (ex: 06.01)
+	MunicipalityCode string  // Código IBGE do município de prestação
+	Description      string  // Discriminação do serviço
+	SimplesNacional  bool    // Prestador optante pelo Simples Nacional (inclui MEI)
+}
+
+// ISSAmount retorna o valor do ISS calculado sobre o valor do serviço.
+func (d *Document) ISSAmount() float64 {
+	return math.Round(d.ServiceAmount*d.ISSRate) / 100
+}
+
+// --- Estrutura XML ABRASF (GerarNfseEnvio) ---
+
+type xmlGerarNfseEnvio struct {
+	XMLName xml.Name `xml:"GerarNfseEnvio"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Rps     xmlRps   `xml:"Rps"`
+}
+
+type xmlRps struct {
+	Declaracao xmlDeclaracao `xml:"InfDeclaracaoPrestacaoServico"`
+}
+
+type xmlDeclaracao struct {
+	ID              string       `xml:"Id,attr"`
+	Rps             xmlInfRps    `xml:"Rps"`
+	Competencia     string       `xml:"Competencia"`
+	Servico         xmlServico   `xml:"Servico"`
+	Prestador       xmlPrestador `xml:"Prestador"`
+	Tomador         *xmlTomador  `xml:"TomadorServico,omitempty"`
+	OptanteSimples  int          `xml:"OptanteSimplesNacional"`
+	IncentivoFiscal int          `xml:"IncentivoFiscal"`
+}
+
+type xmlInfRps struct {
+	Identificacao xmlIdentificacaoRps `xml:"IdentificacaoRps"`
+	DataEmissao   string              `xml:"DataEmissao"`
+	Status        int                 `xml:"Status"`
+}
+
+type xmlIdentificacaoRps struct {
+	Numero int    `xml:"Numero"`
+	Serie  string `xml:"Serie"`
+	Tipo   int    `xml:"Tipo"`
+}
+
+type xmlServico struct {
+	Valores          xmlValores `xml:"Valores"`
+	IssRetido        int        `xml:"IssRetido"`
+	ItemListaServico string     `xml:"ItemListaServico"`
+	Discriminacao    string     `xml:"Discriminacao"`
+	CodigoMunicipio  string     `xml:"CodigoMunicipio"`
+	ExigibilidadeISS int        `xml:"ExigibilidadeISS"`
+}
+
+type xmlValores struct {
+	ValorServicos string `xml:"ValorServicos"`
+	ValorIss      string `xml:"ValorIss"`
+	Aliquota      string `xml:"Aliquota"`
+}
+
+type xmlCpfCnpj struct {
+	Cpf  string `xml:"Cpf,omitempty"`
+	Cnpj string `xml:"Cnpj,omitempty"`
+}
+
+type xmlPrestador struct {
+	CpfCnpj            xmlCpfCnpj `xml:"CpfCnpj"`
+	InscricaoMunicipal string     `xml:"InscricaoMunicipal,omitempty"`
+}
+
+type xmlTomador struct {
+	Identificacao *xmlIdentificacaoTomador `xml:"IdentificacaoTomador,omitempty"`
+	RazaoSocial   string                   `xml:"RazaoSocial,omitempty"`
+	Contato       *xmlContato              `xml:"Contato,omitempty"`
+}
+
+type xmlIdentificacaoTomador struct {
+	CpfCnpj xmlCpfCnpj `xml:"CpfCnpj"`
+}
+
+type xmlContato struct {
+	Telefone string `xml:"Telefone,omitempty"`
+	Email    string `xml:"Email,omitempty"`
+}
+
+// BuildGerarNfseXML monta o XML de envio do RPS (GerarNfseEnvio) no padrão ABRASF 2.04,
+// sem assinatura digital; a assinatura, quando exigida, é responsabilidade do provedor.
+func BuildGerarNfseXML(doc *Document) ([]byte, error) {
+	prestador := xmlPrestador{
+		CpfCnpj:            cpfCnpj(doc.Provider.Document),
+		InscricaoMunicipal: doc.Provider.MunicipalRegistration,
+	}
+
+	var tomador *xmlTomador
+	if doc.Taker.Name != "" || doc.Taker.Document != "" {
+		tomador = &xmlTomador{RazaoSocial: doc.Taker.Name}
+		if digits := OnlyDigits(doc.Taker.Document); digits != "" {
+			tomador.Identificacao = &xmlIdentificacaoTomador{CpfCnpj: cpfCnpj(digits)}
+		}
+		if doc.Taker.Email != "" || doc.Taker.Phone != "" {
+			tomador.Contato = &xmlContato{Telefone: OnlyDigits(doc.Taker.Phone), Email: doc.Taker.Email}
+		}
+	}
+
+	issWithheld := 2 // 1 = sim, 2 = não
+	if doc.ISSWithheld {
+		issWithheld = 1
+	}
+	simples := 2
+	if doc.SimplesNacional {
+		simples = 1
+	}
+
+	envio := xmlGerarNfseEnvio{
+		Xmlns: abrasfNamespace,
+		Rps: xmlRps{Declaracao: xmlDeclaracao{
+			ID: fmt.Sprintf("rps%d%s", doc.RPSNumber, doc.RPSSeries),
+			Rps: xmlInfRps{
+				Identificacao: xmlIdentificacaoRps{Numero: doc.RPSNumber, Serie: doc.RPSSeries, Tipo: 1},
+				DataEmissao:   doc.IssueDate.Format("2006-01-02"),
+				Status:        1, // Normal
+			},
+			Competencia: doc.Competence.Format("2006-01-02"),
+			Servico: xmlServico{
+				Valores: xmlValores{
+					ValorServicos: fmt.Sprintf("%.2f", doc.ServiceAmount),
+					ValorIss:      fmt.Sprintf("%.2f", doc.ISSAmount()),
+					Aliquota:      fmt.Sprintf("%.2f", doc.ISSRate),
+				},
+				IssRetido:        issWithheld,
+				ItemListaServico: doc.ServiceItemCode,
+				Discriminacao:    doc.Description,
+				CodigoMunicipio:  doc.MunicipalityCode,
+				ExigibilidadeISS: 1, // Exigível
+			},
+			Prestador:       prestador,
+			Tomador:         tomador,
+			OptanteSimples:  simples,
+			IncentivoFiscal: 2,
+		}},
+	}
+
+	body, err := xml.MarshalIndent(envio, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// ParseGerarNfseXML lê de volta os campos principais de um XML GerarNfseEnvio.
+// Usado pelo provedor mock para validar o documento recebido como um provedor real faria.
+func ParseGerarNfseXML(data []byte) (*Document, error) {
+	var envio xmlGerarNfseEnvio
+	if err := xml.Unmarshal(data, &envio); err != nil {
+		return nil, err
+	}
+	d := envio.Rps.Declaracao
+	doc := &Document{
+		RPSNumber:        d.Rps.Identificacao.Numero,
+		RPSSeries:        d.Rps.Identificacao.Serie,
+		ServiceItemCode:  d.Servico.ItemListaServico,
+		MunicipalityCode: d.Servico.CodigoMunicipio,
+		Description:      d.Servico.Discriminacao,
+		Provider: Party{
+			Document:              d.Prestador.CpfCnpj.Cpf + d.Prestador.CpfCnpj.Cnpj,
+			MunicipalRegistration: d.Prestador.InscricaoMunicipal,
+		},
+		SimplesNacional: d.OptanteSimples == 1,
+		ISSWithheld:     d.Servico.IssRetido == 1,
+	}
+	fmt.Sscanf(d.Servico.Valores.ValorServicos, "%f", &doc.ServiceAmount)
+	fmt.Sscanf(d.Servico.Valores.Aliquota, "%f", &doc.ISSRate)
+	if d.Tomador != nil {
+		doc.Taker.Name = d.Tomador.RazaoSocial
+		if d.Tomador.Identificacao != nil {
+			doc.Taker.Document = d.Tomador.Identificacao.CpfCnpj.Cpf + d.Tomador.Identificacao.CpfCnpj.Cnpj
+		}
+	}
+	return doc, nil
+}
+
+// cpfCnpj classifica o documento como CPF ou CNPJ pelo número de dígitos.
+func cpfCnpj(document string) xmlCpfCnpj {
+	digits := OnlyDigits(document)
+	if len(digits) == 11 {
+		return xmlCpfCnpj{Cpf: digits}
+	}
+	return xmlCpfCnpj{Cnpj: digits}
+}
+
+// OnlyDigits remove tudo que não for dígito (pontos, barras e traços de CPF/CNPJ).
+func OnlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,83 @@
+package nfse
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockProviderName é o nome do provedor local usado em desenvolvimento e testes.
+const MockProviderName = "mock"
+
+// MockProvider é um provedor de NFS-e local, sem nenhuma chamada a serviços do governo.
+// Valida o XML recebido como uma prefeitura faria e numera as notas a partir do ano
+// e do número do RPS, de modo que a numeração se mantém após reiniciar o servidor.
+type MockProvider struct {
+	mu        sync.Mutex
+	cancelled map[string]bool // Código de verificação das notas canceladas nesta execução
+}
+
+// NewMockProvider cria um MockProvider.
+func NewMockProvider() *MockProvider {
+	return &MockProvider{cancelled: make(map[string]bool)}
+}
+
+// Name retorna o identificador do provedor.
+func (p *MockProvider) Name() string {
+	return MockProviderName
+}
+
+// Issue valida o XML do RPS e gera uma NFS-e fictícia.
+func (p *MockProvider) Issue(doc *Document, xml []byte) (*IssueResult, error) {
+	received, err := ParseGerarNfseXML(xml)
+	if err != nil {
+		return nil, &RejectionError{Code: "E1", Message: "XML fora do padrão ABRASF: " + err.Error()}
+	}
+	if n := len(OnlyDigits(received.Provider.Document)); n != 11 && n != 14 {
+		return nil, &RejectionError{Code: "E46", Message: "CPF/CNPJ do prestador inválido"}
+	}
+	if n := len(OnlyDigits(received.Taker.Document)); n != 0 && n != 11 && n != 14 {
+		return nil, &RejectionError{Code: "E45", Message: "CPF/CNPJ do tomador inválido"}
+	}
+	if strings.TrimSpace(received.ServiceItemCode) == "" {
+		return nil, &RejectionError{Code: "E31", Message: "item da lista de serviços não informado"}
+	}
+	if len(received.MunicipalityCode) != 7 {
+		return nil, &RejectionError{Code: "E38", Message: "código do município inválido"}
+	}
+	if received.ServiceAmount <= 0 {
+		return nil, &RejectionError{Code: "E40", Message: "valor dos serviços deve ser maior que zero"}
+	}
+	if strings.TrimSpace(received.Description) == "" {
+		return nil, &RejectionError{Code: "E41", Message: "discriminação do serviço não informada"}
+	}
+
+	code := make([]byte, 4)
+	if _, err := rand.Read(code); err != nil {
+		return nil, err
+	}
+
+	issuedAt := time.Now()
+	return &IssueResult{
+		Number:           fmt.Sprintf("%d%06d", issuedAt.Year(), received.RPSNumber),
+		VerificationCode: strings.ToUpper(hex.EncodeToString(code)),
+		IssuedAt:         issuedAt,
+	}, nil
+}
+
+// Cancel cancela uma NFS-e. Qualquer nota com número e código de verificação é aceita.
+func (p *MockProvider) Cancel(req CancelRequest) error {
+	if req.Number == "" || req.VerificationCode == "" {
+		return &RejectionError{Code: "E79", Message: "NFS-e não encontrada"}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancelled[req.VerificationCode] {
+		return &RejectionError{Code: "E78", Message: "NFS-e já cancelada"}
+	}
+	p.cancelled[req.VerificationCode] = true
+	return nil
+}
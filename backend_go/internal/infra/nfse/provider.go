@@ -0,0 +1,93 @@
+// Package nfse define o contrato dos provedores de emissão de NFS-e (prefeituras ou
+// agregadores) e a montagem do XML no padrão ABRASF.
+package nfse
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrProviderNotFound indica que não há adaptador registrado com o nome informado.
+	ErrProviderNotFound = errors.New("provedor de NFS-e não encontrado")
+)
+
+// RejectionError indica que o provedor recebeu o documento mas o recusou
+// (ex: dados do prestador inválidos). Reenviar o mesmo documento não resolve.
+type RejectionError struct {
+	Code    string // Código do erro retornado pelo provedor (ex: E160)
+	Message string
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("NFS-e rejeitada (%s): %s", e.Code, e.Message)
+}
+
+// IssueResult é o retorno de uma emissão bem-sucedida.
+type IssueResult struct {
+	Number           string // Número da NFS-e atribuído pela prefeitura
+	VerificationCode string // Código de verificação para consulta da nota
+	IssuedAt         time.Time
+}
+
+// CancelRequest contém os dados para cancelar uma NFS-e emitida.
+type CancelRequest struct {
+	Number           string
+	VerificationCode string
+	CancelCode       string // Código de cancelamento ABRASF (1 = erro na emissão, 2 = serviço não prestado, 4 = duplicidade)
+	Reason           string
+}
+
+// Provider define o contrato de um adaptador de emissão de NFS-e.
+type Provider interface {
+	// Name retorna o identificador do provedor.
+	Name() string
+	// Issue envia o RPS e retorna os dados da NFS-e gerada. Recusas do provedor
+	// devem ser retornadas como *RejectionError.
+	Issue(doc *Document, xml []byte) (*IssueResult, error)
+	// Cancel cancela uma NFS-e emitida.
+	Cancel(req CancelRequest) error
+}
+
+// Registry mantém os provedores disponíveis indexados pelo nome.
+type Registry struct {
+	providers       map[string]Provider
+	defaultProvider string
+}
+
+// NewRegistry cria um Registry. O primeiro provedor informado é usado como padrão
+// para novas emissões.
+func NewRegistry(providers ...Provider) *Registry {
+	registry := &Registry{providers: make(map[string]Provider)}
+	for _, p := range providers {
+		if registry.defaultProvider == "" {
+			registry.defaultProvider = p.Name()
+		}
+		registry.providers[p.Name()] = p
+	}
+	return registry
+}
+
+// Get retorna o provedor com o nome informado.
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return p, nil
+}
+
+// Default retorna o provedor padrão para novas emissões.
+func (r *Registry) Default() (Provider, error) {
+	return r.Get(r.defaultProvider)
+}
+
+// SetDefault altera o provedor padrão, se ele estiver registrado.
+func (r *Registry) SetDefault(name string) error {
+	if _, ok := r.providers[name]; !ok {
+		return ErrProviderNotFound
+	}
+	r.defaultProvider = name
+	return nil
+}
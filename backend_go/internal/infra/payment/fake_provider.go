@@ -0,0 +1,97 @@
+package payment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FakeProviderName é o nome do provedor local usado em desenvolvimento e testes.
+const FakeProviderName = "fake"
+
+// FakeSignatureHeader é o cabeçalho onde o provedor fake envia a assinatura HMAC.
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// fakeWebhookPayload é o formato JSON dos eventos enviados pelo provedor fake.
+type fakeWebhookPayload struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	ChargeID   string    `json:"chargeId"`
+	Amount     float64   `json:"amount"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// FakeProvider é um provedor de pagamento local, sem nenhuma chamada externa.
+// As cobranças são geradas em memória e os webhooks podem ser simulados com BuildWebhook,
+// permitindo testar todo o fluxo de conciliação offline.
+type FakeProvider struct {
+	secret string
+}
+
+// NewFakeProvider cria um FakeProvider que assina os webhooks com o segredo informado.
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{secret: secret}
+}
+
+// Name retorna o identificador do provedor.
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+// CreateCharge gera uma cobrança fictícia com um código PIX de exemplo.
+func (p *FakeProvider) CreateCharge(input ChargeInput) (*Charge, error) {
+	if input.Amount <= 0 {
+		return nil, errors.New("valor da cobrança deve ser maior que zero")
+	}
+	chargeID := "fake_ch_" + uuid.NewString()
+	return &Charge{
+		ID:         chargeID,
+		PaymentURL: fmt.Sprintf("00020126FAKEPIX%s5204000053039865406%.2f", chargeID, input.Amount),
+	}, nil
+}
+
+// ParseWebhook valida a assinatura HMAC e converte o payload em um WebhookEvent.
+func (p *FakeProvider) ParseWebhook(headers http.Header, body []byte) (*WebhookEvent, error) {
+	if !VerifySignature(p.secret, body, headers.Get(FakeSignatureHeader)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload fakeWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if payload.ID == "" || payload.ChargeID == "" {
+		return nil, fmt.Errorf("%w: id do evento ou da cobrança ausente", ErrInvalidPayload)
+	}
+
+	return &WebhookEvent{
+		ID:         payload.ID,
+		Type:       payload.Type,
+		ChargeID:   payload.ChargeID,
+		Amount:     payload.Amount,
+		OccurredAt: payload.OccurredAt,
+	}, nil
+}
+
+// BuildWebhook monta um corpo de webhook assinado, como o provedor real enviaria.
+// Retorna o corpo e a assinatura a ser enviada no cabeçalho FakeSignatureHeader.
+func (p *FakeProvider) BuildWebhook(eventID, eventType, chargeID string, amount float64) ([]byte, string, error) {
+	if eventID == "" {
+		eventID = "fake_evt_" + uuid.NewString()
+	}
+	body, err := json.Marshal(fakeWebhookPayload{
+		ID:         eventID,
+		Type:       eventType,
+		ChargeID:   chargeID,
+		Amount:     amount,
+		OccurredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return body, SignPayload(p.secret, body), nil
+}
@@ -0,0 +1,122 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de evento normalizados que os adaptadores devem produzir.
+const (
+	EventTypePaymentPaid     = "payment.paid"
+	EventTypePaymentFailed   = "payment.failed"
+	EventTypePaymentRefunded = "payment.refunded"
+)
+
+var (
+	// ErrInvalidSignature indica que a assinatura HMAC do webhook não confere.
+	ErrInvalidSignature = errors.New("assinatura do webhook inválida")
+	// ErrProviderNotFound indica que não há adaptador registrado com o nome informado.
+	ErrProviderNotFound = errors.New("provedor de pagamento não encontrado")
+	// ErrInvalidPayload indica que o corpo do webhook não pôde ser interpretado.
+	ErrInvalidPayload = errors.New("payload do webhook inválido")
+)
+
+// ChargeInput contém os dados para criar uma cobrança no provedor.
+type ChargeInput struct {
+	PaymentID   uuid.UUID
+	Amount      float64
+	Method      string // PIX ou CARD
+	Description string
+}
+
+// Charge é a cobrança criada no provedor.
+type Charge struct {
+	ID         string // ID da cobrança no provedor
+	PaymentURL string // Link de pagamento ou código PIX "copia e cola"
+}
+
+// WebhookEvent é a representação normalizada de um evento recebido via webhook.
+type WebhookEvent struct {
+	ID         string // ID do evento no provedor (chave de idempotência)
+	Type       string // Um dos EventType* acima
+	ChargeID   string
+	Amount     float64
+	OccurredAt time.Time
+}
+
+// Provider define o contrato de um adaptador de gateway de pagamento (PIX/cartão).
+type Provider interface {
+	// Name retorna o identificador do provedor, usado na rota do webhook.
+	Name() string
+	// CreateCharge cria uma cobrança no provedor.
+	CreateCharge(input ChargeInput) (*Charge, error)
+	// ParseWebhook valida a assinatura e converte o corpo recebido em um WebhookEvent.
+	ParseWebhook(headers http.Header, body []byte) (*WebhookEvent, error)
+}
+
+// Registry mantém os provedores disponíveis indexados pelo nome.
+type Registry struct {
+	providers       map[string]Provider
+	defaultProvider string
+}
+
+// NewRegistry cria um Registry. O primeiro provedor informado é usado como padrão
+// para novas cobranças.
+func NewRegistry(providers ...Provider) *Registry {
+	registry := &Registry{providers: make(map[string]Provider)}
+	for _, p := range providers {
+		if registry.defaultProvider == "" {
+			registry.defaultProvider = p.Name()
+		}
+		registry.providers[p.Name()] = p
+	}
+	return registry
+}
+
+// Get retorna o provedor com o nome informado.
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return p, nil
+}
+
+// Default retorna o provedor padrão para novas cobranças.
+func (r *Registry) Default() (Provider, error) {
+	return r.Get(r.defaultProvider)
+}
+
+// SetDefault altera o provedor padrão, se ele estiver registrado.
+func (r *Registry) SetDefault(name string) error {
+	if _, ok := r.providers[name]; !ok {
+		return ErrProviderNotFound
+	}
+	r.defaultProvider = name
+	return nil
+}
+
+// SignPayload calcula a assinatura HMAC-SHA256 (hex) de um payload.
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature compara, em tempo constante, a assinatura recebida com a esperada.
+// Aceita o formato "sha256=<hex>" usado por vários provedores.
+func VerifySignature(secret string, payload []byte, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	expected := SignPayload(secret, payload)
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
 	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
 	"gorm.io/gorm"
-	// "gorm.io/gorm/clause" // Para Preload e outras cláusulas
+	"gorm.io/gorm/clause"
 )
 
 type gormAppointmentRepository struct {
@@ -45,6 +45,19 @@ func (r *gormAppointmentRepository) FindByID(id uuid.UUID) (*entity.Appointment,
 	return appointmentGorm.ToEntity(), nil
 }
 
+// LockByID busca um agendamento com SELECT ... FOR UPDATE.
+func (r *gormAppointmentRepository) LockByID(id uuid.UUID) (*entity.Appointment, error) {
+	var appointmentGorm AppointmentGormModel
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&appointmentGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return appointmentGorm.ToEntity(), nil
+}
+
 func (r *gormAppointmentRepository) FindByUserID(userID uuid.UUID, startTimeFilter, endTimeFilter *time.Time) ([]*entity.Appointment, error) {
 	var appointmentsGorm []AppointmentGormModel
 	query := r.db.Preload("User").Preload("ClientUser").Where("user_id = ?", userID)
@@ -82,7 +95,8 @@ func (r *gormAppointmentRepository) Update(appointmentEntity *entity.Appointment
 	// Ou usar .Model(&AppointmentGormModel{ID: appointmentGorm.ID}).Updates(appointmentGorm)
 	// que só atualiza campos não-zero. Para nosso caso, vamos assumir que todos os campos
 	// da entidade são os desejados para atualização.
-	result := r.db.Model(&AppointmentGormModel{}).Where("id = ?", appointmentGorm.ID).Updates(appointmentGorm)
+	// Select("*") permite gravar valores zero (ex: Invoiced = false); as associações são omitidas.
+	result := r.db.Model(&AppointmentGormModel{}).Where("id = ?", appointmentGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt", "User", "ClientUser").Updates(appointmentGorm)
 
 	// Se você quer que "UpdatedAt" seja atualizado mesmo se nenhum outro campo mudou:
 	// result := r.db.Save(appointmentGorm)
@@ -120,4 +134,5 @@ func (r *gormAppointmentRepository) Delete(id uuid.UUID) error {
 		return errors.New("agendamento não encontrado para deleção")
 	}
 	return nil
-}
\ No newline at end of file
+}
+
@@ -0,0 +1,106 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CalendarFeedGormModel representa o feed de calendário do usuário para o GORM.
+type CalendarFeedGormModel struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Token     string    `gorm:"size:100;not null;uniqueIndex"`
+	RotatedAt *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CalendarFeedGormModel) TableName() string {
+	return "calendar_feeds"
+}
+
+// ToEntity converte um CalendarFeedGormModel para uma entidade CalendarFeed.
+func (m *CalendarFeedGormModel) ToEntity() *entity.CalendarFeed {
+	return &entity.CalendarFeed{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Token:     m.Token,
+		RotatedAt: m.RotatedAt,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// CalendarFeedFromEntity converte uma entidade CalendarFeed para o modelo GORM.
+func CalendarFeedFromEntity(e *entity.CalendarFeed) *CalendarFeedGormModel {
+	return &CalendarFeedGormModel{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Token:     e.Token,
+		RotatedAt: e.RotatedAt,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+type gormCalendarFeedRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCalendarFeedRepository cria uma nova instância do repositório de feeds de calendário.
+func NewGormCalendarFeedRepository(db *gorm.DB) repository.CalendarFeedRepository {
+	return &gormCalendarFeedRepository{db: db}
+}
+
+func (r *gormCalendarFeedRepository) FindByUserID(userID uuid.UUID) (*entity.CalendarFeed, error) {
+	var feedGorm CalendarFeedGormModel
+	result := r.db.Where("user_id = ?", userID).First(&feedGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return feedGorm.ToEntity(), nil
+}
+
+func (r *gormCalendarFeedRepository) FindByToken(token string) (*entity.CalendarFeed, error) {
+	var feedGorm CalendarFeedGormModel
+	result := r.db.Where("token = ?", token).First(&feedGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return feedGorm.ToEntity(), nil
+}
+
+func (r *gormCalendarFeedRepository) Save(feed *entity.CalendarFeed) error {
+	var existing CalendarFeedGormModel
+	result := r.db.Where("user_id = ?", feed.UserID).First(&existing)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return result.Error
+	}
+
+	feedGorm := CalendarFeedFromEntity(feed)
+	if result.Error == nil {
+		feedGorm.ID = existing.ID
+		feedGorm.CreatedAt = existing.CreatedAt
+	} else if feedGorm.ID == uuid.Nil {
+		feedGorm.ID = uuid.New()
+	}
+	if err := r.db.Save(feedGorm).Error; err != nil {
+		return err
+	}
+	feed.ID = feedGorm.ID
+	feed.CreatedAt = feedGorm.CreatedAt
+	feed.UpdatedAt = feedGorm.UpdatedAt
+	return nil
+}
@@ -0,0 +1,329 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// -----------------------------------------------------------------------------
+// CalendarConnectionGormModel
+// -----------------------------------------------------------------------------
+
+// CalendarConnectionGormModel representa a conexão com um calendário externo para o GORM.
+type CalendarConnectionGormModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	CalendarURL string    `gorm:"size:500;not null"`
+	Username    string    `gorm:"size:255;not null"`
+	Password    string    `gorm:"size:255;not null"`
+	Enabled     bool      `gorm:"not null;index"`
+	LastSyncAt  *time.Time
+	LastError   string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CalendarConnectionGormModel) TableName() string {
+	return "calendar_connections"
+}
+
+// ToEntity converte um CalendarConnectionGormModel para uma entidade CalendarConnection.
+func (m *CalendarConnectionGormModel) ToEntity() *entity.CalendarConnection {
+	return &entity.CalendarConnection{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		CalendarURL: m.CalendarURL,
+		Username:    m.Username,
+		Password:    m.Password,
+		Enabled:     m.Enabled,
+		LastSyncAt:  m.LastSyncAt,
+		LastError:   m.LastError,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// CalendarConnectionFromEntity converte uma entidade CalendarConnection para o modelo GORM.
+func CalendarConnectionFromEntity(e *entity.CalendarConnection) *CalendarConnectionGormModel {
+	return &CalendarConnectionGormModel{
+		ID:          e.ID,
+		UserID:      e.UserID,
+		CalendarURL: e.CalendarURL,
+		Username:    e.Username,
+		Password:    e.Password,
+		Enabled:     e.Enabled,
+		LastSyncAt:  e.LastSyncAt,
+		LastError:   e.LastError,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+type gormCalendarConnectionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCalendarConnectionRepository cria uma nova instância do repositório de conexões de calendário.
+func NewGormCalendarConnectionRepository(db *gorm.DB) repository.CalendarConnectionRepository {
+	return &gormCalendarConnectionRepository{db: db}
+}
+
+func (r *gormCalendarConnectionRepository) FindByUserID(userID uuid.UUID) (*entity.CalendarConnection, error) {
+	var connectionGorm CalendarConnectionGormModel
+	result := r.db.Where("user_id = ?", userID).First(&connectionGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return connectionGorm.ToEntity(), nil
+}
+
+func (r *gormCalendarConnectionRepository) FindEnabled() ([]*entity.CalendarConnection, error) {
+	var connectionsGorm []CalendarConnectionGormModel
+	if err := r.db.Where("enabled = ?", true).Order("last_sync_at NULLS FIRST").Find(&connectionsGorm).Error; err != nil {
+		return nil, err
+	}
+	connections := make([]*entity.CalendarConnection, len(connectionsGorm))
+	for i := range connectionsGorm {
+		connections[i] = connectionsGorm[i].ToEntity()
+	}
+	return connections, nil
+}
+
+func (r *gormCalendarConnectionRepository) Save(connection *entity.CalendarConnection) error {
+	var existing CalendarConnectionGormModel
+	result := r.db.Where("user_id = ?", connection.UserID).First(&existing)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return result.Error
+	}
+
+	connectionGorm := CalendarConnectionFromEntity(connection)
+	if result.Error == nil {
+		connectionGorm.ID = existing.ID
+		connectionGorm.CreatedAt = existing.CreatedAt
+	} else if connectionGorm.ID == uuid.Nil {
+		connectionGorm.ID = uuid.New()
+	}
+	if err := r.db.Save(connectionGorm).Error; err != nil {
+		return err
+	}
+	connection.ID = connectionGorm.ID
+	connection.CreatedAt = connectionGorm.CreatedAt
+	connection.UpdatedAt = connectionGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormCalendarConnectionRepository) Delete(userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var connectionGorm CalendarConnectionGormModel
+		if err := tx.Where("user_id = ?", userID).First(&connectionGorm).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err := tx.Where("connection_id = ?", connectionGorm.ID).Delete(&CalendarBusyBlockGormModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("connection_id = ?", connectionGorm.ID).Delete(&CalendarEventLinkGormModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&connectionGorm).Error
+	})
+}
+
+// -----------------------------------------------------------------------------
+// CalendarBusyBlockGormModel
+// -----------------------------------------------------------------------------
+
+// CalendarBusyBlockGormModel representa um período ocupado importado para o GORM.
+type CalendarBusyBlockGormModel struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index:idx_calendar_busy_user_time,priority:1"`
+	ConnectionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	ExternalUID  string    `gorm:"size:500;not null"`
+	Summary      string    `gorm:"size:255"`
+	StartTime    time.Time `gorm:"not null;index:idx_calendar_busy_user_time,priority:2"`
+	EndTime      time.Time `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CalendarBusyBlockGormModel) TableName() string {
+	return "calendar_busy_blocks"
+}
+
+// ToEntity converte um CalendarBusyBlockGormModel para uma entidade CalendarBusyBlock.
+func (m *CalendarBusyBlockGormModel) ToEntity() *entity.CalendarBusyBlock {
+	return &entity.CalendarBusyBlock{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		ConnectionID: m.ConnectionID,
+		ExternalUID:  m.ExternalUID,
+		Summary:      m.Summary,
+		StartTime:    m.StartTime,
+		EndTime:      m.EndTime,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// CalendarBusyBlockFromEntity converte uma entidade CalendarBusyBlock para o modelo GORM.
+func CalendarBusyBlockFromEntity(e *entity.CalendarBusyBlock) *CalendarBusyBlockGormModel {
+	return &CalendarBusyBlockGormModel{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		ConnectionID: e.ConnectionID,
+		ExternalUID:  e.ExternalUID,
+		Summary:      e.Summary,
+		StartTime:    e.StartTime,
+		EndTime:      e.EndTime,
+		CreatedAt:    e.CreatedAt,
+	}
+}
+
+type gormCalendarBusyBlockRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCalendarBusyBlockRepository cria uma nova instância do repositório de períodos ocupados.
+func NewGormCalendarBusyBlockRepository(db *gorm.DB) repository.CalendarBusyBlockRepository {
+	return &gormCalendarBusyBlockRepository{db: db}
+}
+
+func (r *gormCalendarBusyBlockRepository) ReplaceForConnection(connectionID uuid.UUID, blocks []*entity.CalendarBusyBlock) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("connection_id = ?", connectionID).Delete(&CalendarBusyBlockGormModel{}).Error; err != nil {
+			return err
+		}
+		if len(blocks) == 0 {
+			return nil
+		}
+		blocksGorm := make([]*CalendarBusyBlockGormModel, len(blocks))
+		for i, block := range blocks {
+			if block.ID == uuid.Nil {
+				block.ID = uuid.New()
+			}
+			block.ConnectionID = connectionID
+			blocksGorm[i] = CalendarBusyBlockFromEntity(block)
+		}
+		return tx.CreateInBatches(blocksGorm, 100).Error
+	})
+}
+
+func (r *gormCalendarBusyBlockRepository) FindOverlapping(userID uuid.UUID, start, end time.Time) ([]*entity.CalendarBusyBlock, error) {
+	var blocksGorm []CalendarBusyBlockGormModel
+	err := r.db.Where("user_id = ? AND start_time < ? AND end_time > ?", userID, end, start).
+		Order("start_time ASC").
+		Find(&blocksGorm).Error
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]*entity.CalendarBusyBlock, len(blocksGorm))
+	for i := range blocksGorm {
+		blocks[i] = blocksGorm[i].ToEntity()
+	}
+	return blocks, nil
+}
+
+// -----------------------------------------------------------------------------
+// CalendarEventLinkGormModel
+// -----------------------------------------------------------------------------
+
+// CalendarEventLinkGormModel representa o vínculo entre agendamento e evento externo para o GORM.
+type CalendarEventLinkGormModel struct {
+	ConnectionID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	AppointmentID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Href          string    `gorm:"size:500;not null"`
+	ETag          string    `gorm:"column:etag;size:255"`
+	Sequence      int       `gorm:"not null;default:0"`
+	StartTime     time.Time `gorm:"not null"`
+	EndTime       time.Time `gorm:"not null"`
+	SyncedAt      time.Time `gorm:"not null"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CalendarEventLinkGormModel) TableName() string {
+	return "calendar_event_links"
+}
+
+// ToEntity converte um CalendarEventLinkGormModel para uma entidade CalendarEventLink.
+func (m *CalendarEventLinkGormModel) ToEntity() *entity.CalendarEventLink {
+	return &entity.CalendarEventLink{
+		ConnectionID:  m.ConnectionID,
+		AppointmentID: m.AppointmentID,
+		Href:          m.Href,
+		ETag:          m.ETag,
+		Sequence:      m.Sequence,
+		StartTime:     m.StartTime,
+		EndTime:       m.EndTime,
+		SyncedAt:      m.SyncedAt,
+	}
+}
+
+// CalendarEventLinkFromEntity converte uma entidade CalendarEventLink para o modelo GORM.
+func CalendarEventLinkFromEntity(e *entity.CalendarEventLink) *CalendarEventLinkGormModel {
+	return &CalendarEventLinkGormModel{
+		ConnectionID:  e.ConnectionID,
+		AppointmentID: e.AppointmentID,
+		Href:          e.Href,
+		ETag:          e.ETag,
+		Sequence:      e.Sequence,
+		StartTime:     e.StartTime,
+		EndTime:       e.EndTime,
+		SyncedAt:      e.SyncedAt,
+	}
+}
+
+type gormCalendarEventLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCalendarEventLinkRepository cria uma nova instância do repositório de vínculos de eventos.
+func NewGormCalendarEventLinkRepository(db *gorm.DB) repository.CalendarEventLinkRepository {
+	return &gormCalendarEventLinkRepository{db: db}
+}
+
+func (r *gormCalendarEventLinkRepository) Find(connectionID, appointmentID uuid.UUID) (*entity.CalendarEventLink, error) {
+	var linkGorm CalendarEventLinkGormModel
+	result := r.db.Where("connection_id = ? AND appointment_id = ?", connectionID, appointmentID).First(&linkGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return linkGorm.ToEntity(), nil
+}
+
+func (r *gormCalendarEventLinkRepository) FindByConnectionID(connectionID uuid.UUID) ([]*entity.CalendarEventLink, error) {
+	var linksGorm []CalendarEventLinkGormModel
+	if err := r.db.Where("connection_id = ?", connectionID).Find(&linksGorm).Error; err != nil {
+		return nil, err
+	}
+	links := make([]*entity.CalendarEventLink, len(linksGorm))
+	for i := range linksGorm {
+		links[i] = linksGorm[i].ToEntity()
+	}
+	return links, nil
+}
+
+func (r *gormCalendarEventLinkRepository) Save(link *entity.CalendarEventLink) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "connection_id"}, {Name: "appointment_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"href", "etag", "sequence", "start_time", "end_time", "synced_at"}),
+	}).Create(CalendarEventLinkFromEntity(link)).Error
+}
+
+func (r *gormCalendarEventLinkRepository) Delete(connectionID, appointmentID uuid.UUID) error {
+	return r.db.Where("connection_id = ? AND appointment_id = ?", connectionID, appointmentID).
+		Delete(&CalendarEventLinkGormModel{}).Error
+}
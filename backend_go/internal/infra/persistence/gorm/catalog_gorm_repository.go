@@ -0,0 +1,264 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// ServiceGormModel
+// -----------------------------------------------------------------------------
+
+// ServiceGormModel representa um serviço do catálogo para o GORM.
+type ServiceGormModel struct {
+	ID              uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID          uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name            string         `gorm:"size:150;not null"`
+	Description     string         `gorm:"type:text"`
+	Price           float64        `gorm:"not null;default:0"`
+	DurationMinutes int            `gorm:"not null;default:0"`
+	Active          bool           `gorm:"not null"`
+	CreatedAt       time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (ServiceGormModel) TableName() string {
+	return "services"
+}
+
+// ToEntity converte um ServiceGormModel para uma entidade Service.
+func (m *ServiceGormModel) ToEntity() *entity.Service {
+	return &entity.Service{
+		ID:              m.ID,
+		UserID:          m.UserID,
+		Name:            m.Name,
+		Description:     m.Description,
+		Price:           m.Price,
+		DurationMinutes: m.DurationMinutes,
+		Active:          m.Active,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// ServiceFromEntity converte uma entidade Service para o modelo GORM.
+func ServiceFromEntity(e *entity.Service) *ServiceGormModel {
+	return &ServiceGormModel{
+		ID:              e.ID,
+		UserID:          e.UserID,
+		Name:            e.Name,
+		Description:     e.Description,
+		Price:           e.Price,
+		DurationMinutes: e.DurationMinutes,
+		Active:          e.Active,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+	}
+}
+
+type gormServiceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormServiceRepository cria uma nova instância do repositório de serviços.
+func NewGormServiceRepository(db *gorm.DB) repository.ServiceRepository {
+	return &gormServiceRepository{db: db}
+}
+
+func (r *gormServiceRepository) Create(serviceEntity *entity.Service) error {
+	serviceGorm := ServiceFromEntity(serviceEntity)
+	if err := r.db.Create(serviceGorm).Error; err != nil {
+		return err
+	}
+	serviceEntity.ID = serviceGorm.ID
+	serviceEntity.CreatedAt = serviceGorm.CreatedAt
+	serviceEntity.UpdatedAt = serviceGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormServiceRepository) FindByID(id uuid.UUID) (*entity.Service, error) {
+	var serviceGorm ServiceGormModel
+	result := r.db.First(&serviceGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return serviceGorm.ToEntity(), nil
+}
+
+func (r *gormServiceRepository) FindByUserID(userID uuid.UUID) ([]*entity.Service, error) {
+	var servicesGorm []ServiceGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("name asc").Find(&servicesGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var serviceEntities []*entity.Service
+	for _, sg := range servicesGorm {
+		serviceEntities = append(serviceEntities, sg.ToEntity())
+	}
+	return serviceEntities, nil
+}
+
+func (r *gormServiceRepository) Update(serviceEntity *entity.Service) error {
+	if serviceEntity.ID == uuid.Nil {
+		return errors.New("ID do serviço não pode ser nulo para atualização")
+	}
+	serviceGorm := ServiceFromEntity(serviceEntity)
+	// Select("*") para permitir desativar o serviço (Active = false)
+	result := r.db.Model(&ServiceGormModel{}).Where("id = ?", serviceGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(serviceGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("serviço não encontrado para atualização")
+	}
+	return nil
+}
+
+func (r *gormServiceRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do serviço não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&ServiceGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("serviço não encontrado para deleção")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// ProfessionalGormModel
+// -----------------------------------------------------------------------------
+
+// ProfessionalGormModel representa um profissional da equipe para o GORM.
+type ProfessionalGormModel struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name      string         `gorm:"size:150;not null"`
+	Email     string         `gorm:"size:255"`
+	Phone     string         `gorm:"size:50"`
+	Active    bool           `gorm:"not null"`
+	CreatedAt time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (ProfessionalGormModel) TableName() string {
+	return "professionals"
+}
+
+// ToEntity converte um ProfessionalGormModel para uma entidade Professional.
+func (m *ProfessionalGormModel) ToEntity() *entity.Professional {
+	return &entity.Professional{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Name:      m.Name,
+		Email:     m.Email,
+		Phone:     m.Phone,
+		Active:    m.Active,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// ProfessionalFromEntity converte uma entidade Professional para o modelo GORM.
+func ProfessionalFromEntity(e *entity.Professional) *ProfessionalGormModel {
+	return &ProfessionalGormModel{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Name:      e.Name,
+		Email:     e.Email,
+		Phone:     e.Phone,
+		Active:    e.Active,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+type gormProfessionalRepository struct {
+	db *gorm.DB
+}
+
+// NewGormProfessionalRepository cria uma nova instância do repositório de profissionais.
+func NewGormProfessionalRepository(db *gorm.DB) repository.ProfessionalRepository {
+	return &gormProfessionalRepository{db: db}
+}
+
+func (r *gormProfessionalRepository) Create(professionalEntity *entity.Professional) error {
+	professionalGorm := ProfessionalFromEntity(professionalEntity)
+	if err := r.db.Create(professionalGorm).Error; err != nil {
+		return err
+	}
+	professionalEntity.ID = professionalGorm.ID
+	professionalEntity.CreatedAt = professionalGorm.CreatedAt
+	professionalEntity.UpdatedAt = professionalGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormProfessionalRepository) FindByID(id uuid.UUID) (*entity.Professional, error) {
+	var professionalGorm ProfessionalGormModel
+	result := r.db.First(&professionalGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return professionalGorm.ToEntity(), nil
+}
+
+func (r *gormProfessionalRepository) FindByUserID(userID uuid.UUID) ([]*entity.Professional, error) {
+	var professionalsGorm []ProfessionalGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("name asc").Find(&professionalsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var professionalEntities []*entity.Professional
+	for _, pg := range professionalsGorm {
+		professionalEntities = append(professionalEntities, pg.ToEntity())
+	}
+	return professionalEntities, nil
+}
+
+func (r *gormProfessionalRepository) Update(professionalEntity *entity.Professional) error {
+	if professionalEntity.ID == uuid.Nil {
+		return errors.New("ID do profissional não pode ser nulo para atualização")
+	}
+	professionalGorm := ProfessionalFromEntity(professionalEntity)
+	// Select("*") para permitir desativar o profissional (Active = false)
+	result := r.db.Model(&ProfessionalGormModel{}).Where("id = ?", professionalGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(professionalGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("profissional não encontrado para atualização")
+	}
+	return nil
+}
+
+func (r *gormProfessionalRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do profissional não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&ProfessionalGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("profissional não encontrado para deleção")
+	}
+	return nil
+}
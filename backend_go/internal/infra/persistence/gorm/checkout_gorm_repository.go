@@ -0,0 +1,213 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CheckoutGormModel representa o fechamento de um atendimento para o GORM.
+type CheckoutGormModel struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index"`
+	AppointmentID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	ProfessionalID  *uuid.UUID `gorm:"type:uuid;index"`
+	ServiceAmount   float64    `gorm:"not null"`
+	ProductsAmount  float64    `gorm:"not null;default:0"`
+	PreviouslyPaid  float64    `gorm:"not null;default:0"`
+	TipAmount       float64    `gorm:"not null;default:0"`
+	TotalPaid       float64    `gorm:"not null"`
+	TipCommissionID *uuid.UUID `gorm:"type:uuid"`
+	CompletedAt     time.Time  `gorm:"not null"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CheckoutGormModel) TableName() string {
+	return "checkouts"
+}
+
+// ToEntity converte um CheckoutGormModel para uma entidade Checkout (sem produtos e pagamentos).
+func (m *CheckoutGormModel) ToEntity() *entity.Checkout {
+	return &entity.Checkout{
+		ID:              m.ID,
+		UserID:          m.UserID,
+		AppointmentID:   m.AppointmentID,
+		ProfessionalID:  m.ProfessionalID,
+		ServiceAmount:   m.ServiceAmount,
+		ProductsAmount:  m.ProductsAmount,
+		PreviouslyPaid:  m.PreviouslyPaid,
+		TipAmount:       m.TipAmount,
+		TotalPaid:       m.TotalPaid,
+		TipCommissionID: m.TipCommissionID,
+		CompletedAt:     m.CompletedAt,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+// CheckoutFromEntity converte uma entidade Checkout para CheckoutGormModel.
+func CheckoutFromEntity(e *entity.Checkout) *CheckoutGormModel {
+	return &CheckoutGormModel{
+		ID:              e.ID,
+		UserID:          e.UserID,
+		AppointmentID:   e.AppointmentID,
+		ProfessionalID:  e.ProfessionalID,
+		ServiceAmount:   e.ServiceAmount,
+		ProductsAmount:  e.ProductsAmount,
+		PreviouslyPaid:  e.PreviouslyPaid,
+		TipAmount:       e.TipAmount,
+		TotalPaid:       e.TotalPaid,
+		TipCommissionID: e.TipCommissionID,
+		CompletedAt:     e.CompletedAt,
+		CreatedAt:       e.CreatedAt,
+	}
+}
+
+// CheckoutProductGormModel representa um produto vendido no fechamento para o GORM.
+type CheckoutProductGormModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	CheckoutID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	ProductID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	ProductName string    `gorm:"size:150;not null"`
+	Quantity    int       `gorm:"not null"`
+	UnitPrice   float64   `gorm:"not null"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CheckoutProductGormModel) TableName() string {
+	return "checkout_products"
+}
+
+// ToEntity converte um CheckoutProductGormModel para uma entidade CheckoutProduct.
+func (m *CheckoutProductGormModel) ToEntity() entity.CheckoutProduct {
+	return entity.CheckoutProduct{
+		ID:          m.ID,
+		CheckoutID:  m.CheckoutID,
+		ProductID:   m.ProductID,
+		ProductName: m.ProductName,
+		Quantity:    m.Quantity,
+		UnitPrice:   m.UnitPrice,
+	}
+}
+
+// CheckoutProductFromEntity converte uma entidade CheckoutProduct para o modelo GORM.
+func CheckoutProductFromEntity(e entity.CheckoutProduct) *CheckoutProductGormModel {
+	return &CheckoutProductGormModel{
+		ID:          e.ID,
+		CheckoutID:  e.CheckoutID,
+		ProductID:   e.ProductID,
+		ProductName: e.ProductName,
+		Quantity:    e.Quantity,
+		UnitPrice:   e.UnitPrice,
+	}
+}
+
+type gormCheckoutRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCheckoutRepository cria uma nova instância do repositório de fechamentos.
+func NewGormCheckoutRepository(db *gorm.DB) repository.CheckoutRepository {
+	return &gormCheckoutRepository{db: db}
+}
+
+func (r *gormCheckoutRepository) Complete(checkoutEntity *entity.Checkout, appointment *entity.Appointment, movements []*entity.StockMovement, entries []*entity.FinancialEntry, commissions []*entity.Commission, events []*entity.DomainEvent) (bool, error) {
+	checkoutGorm := CheckoutFromEntity(checkoutEntity)
+	completed := false
+	var appointmentGorm AppointmentGormModel
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Conclui o agendamento apenas se ainda estiver em aberto, evitando fechamentos em
+		// dobro, e incrementa a revisão para que os calendários substituam o evento
+		result := tx.Model(&appointmentGorm).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "sequence"}, {Name: "updated_at"}}}).
+			Where("id = ? AND status NOT IN ?", checkoutEntity.AppointmentID,
+				[]string{string(entity.AppointmentStatusCompleted), string(entity.AppointmentStatusCancelled)}).
+			Updates(map[string]any{
+				"status":   string(entity.AppointmentStatusCompleted),
+				"price":    appointment.Price,
+				"sequence": gorm.Expr("sequence + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(checkoutGorm).Error; err != nil {
+			return err
+		}
+		for _, item := range checkoutEntity.Products {
+			if err := tx.Create(CheckoutProductFromEntity(item)).Error; err != nil {
+				return err
+			}
+		}
+		for _, m := range movements {
+			if err := applyStockMovement(tx, m); err != nil {
+				return err
+			}
+		}
+		for _, p := range checkoutEntity.Payments {
+			if err := tx.Create(PaymentFromEntity(p)).Error; err != nil {
+				return err
+			}
+		}
+		for _, e := range entries {
+			if err := tx.Create(FinancialEntryFromEntity(e)).Error; err != nil {
+				return err
+			}
+		}
+		for _, c := range commissions {
+			if err := tx.Create(CommissionFromEntity(c)).Error; err != nil {
+				return err
+			}
+		}
+		if err := appendDomainEvents(tx, events); err != nil {
+			return err
+		}
+		completed = true
+		return nil
+	})
+	if err != nil || !completed {
+		return false, err
+	}
+	checkoutEntity.CreatedAt = checkoutGorm.CreatedAt
+	appointment.Status = entity.AppointmentStatusCompleted
+	appointment.Sequence = appointmentGorm.Sequence
+	appointment.UpdatedAt = appointmentGorm.UpdatedAt
+	return true, nil
+}
+
+func (r *gormCheckoutRepository) FindByAppointmentID(appointmentID uuid.UUID) (*entity.Checkout, error) {
+	var checkoutGorm CheckoutGormModel
+	result := r.db.Where("appointment_id = ?", appointmentID).First(&checkoutGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+
+	var productsGorm []CheckoutProductGormModel
+	if err := r.db.Where("checkout_id = ?", checkoutGorm.ID).Find(&productsGorm).Error; err != nil {
+		return nil, err
+	}
+	var paymentsGorm []PaymentGormModel
+	if err := r.db.Where("checkout_id = ?", checkoutGorm.ID).Order("created_at asc").Find(&paymentsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	checkoutEntity := checkoutGorm.ToEntity()
+	for _, pg := range productsGorm {
+		checkoutEntity.Products = append(checkoutEntity.Products, pg.ToEntity())
+	}
+	for _, pg := range paymentsGorm {
+		checkoutEntity.Payments = append(checkoutEntity.Payments, pg.ToEntity())
+	}
+	return checkoutEntity, nil
+}
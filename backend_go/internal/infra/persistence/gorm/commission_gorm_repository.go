@@ -0,0 +1,356 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// CommissionRuleGormModel
+// -----------------------------------------------------------------------------
+
+// CommissionRuleGormModel representa uma regra de comissão para o GORM.
+type CommissionRuleGormModel struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID         uuid.UUID      `gorm:"type:uuid;not null;index"`
+	ProfessionalID *uuid.UUID     `gorm:"type:uuid;index"`
+	ServiceID      *uuid.UUID     `gorm:"type:uuid;index"`
+	Source         string         `gorm:"size:20;not null"`
+	Type           string         `gorm:"size:20;not null"`
+	Value          float64        `gorm:"not null"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CommissionRuleGormModel) TableName() string {
+	return "commission_rules"
+}
+
+// ToEntity converte um CommissionRuleGormModel para uma entidade CommissionRule.
+func (m *CommissionRuleGormModel) ToEntity() *entity.CommissionRule {
+	return &entity.CommissionRule{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		ProfessionalID: m.ProfessionalID,
+		ServiceID:      m.ServiceID,
+		Source:         entity.CommissionSource(m.Source),
+		Type:           entity.CommissionType(m.Type),
+		Value:          m.Value,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// CommissionRuleFromEntity converte uma entidade CommissionRule para o modelo GORM.
+func CommissionRuleFromEntity(e *entity.CommissionRule) *CommissionRuleGormModel {
+	return &CommissionRuleGormModel{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		ProfessionalID: e.ProfessionalID,
+		ServiceID:      e.ServiceID,
+		Source:         string(e.Source),
+		Type:           string(e.Type),
+		Value:          e.Value,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+type gormCommissionRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCommissionRuleRepository cria uma nova instância do repositório de regras de comissão.
+func NewGormCommissionRuleRepository(db *gorm.DB) repository.CommissionRuleRepository {
+	return &gormCommissionRuleRepository{db: db}
+}
+
+func (r *gormCommissionRuleRepository) Create(ruleEntity *entity.CommissionRule) error {
+	ruleGorm := CommissionRuleFromEntity(ruleEntity)
+	if err := r.db.Create(ruleGorm).Error; err != nil {
+		return err
+	}
+	ruleEntity.ID = ruleGorm.ID
+	ruleEntity.CreatedAt = ruleGorm.CreatedAt
+	ruleEntity.UpdatedAt = ruleGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormCommissionRuleRepository) FindByID(id uuid.UUID) (*entity.CommissionRule, error) {
+	var ruleGorm CommissionRuleGormModel
+	result := r.db.First(&ruleGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return ruleGorm.ToEntity(), nil
+}
+
+func (r *gormCommissionRuleRepository) FindByUserID(userID uuid.UUID) ([]*entity.CommissionRule, error) {
+	var rulesGorm []CommissionRuleGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("created_at asc").Find(&rulesGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var ruleEntities []*entity.CommissionRule
+	for _, rg := range rulesGorm {
+		ruleEntities = append(ruleEntities, rg.ToEntity())
+	}
+	return ruleEntities, nil
+}
+
+func (r *gormCommissionRuleRepository) Update(ruleEntity *entity.CommissionRule) error {
+	if ruleEntity.ID == uuid.Nil {
+		return errors.New("ID da regra não pode ser nulo para atualização")
+	}
+	ruleGorm := CommissionRuleFromEntity(ruleEntity)
+	// Select("*") para permitir limpar o profissional ou o serviço (valor nulo)
+	result := r.db.Model(&CommissionRuleGormModel{}).Where("id = ?", ruleGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(ruleGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("regra de comissão não encontrada para atualização")
+	}
+	return nil
+}
+
+func (r *gormCommissionRuleRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID da regra não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&CommissionRuleGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("regra de comissão não encontrada para deleção")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CommissionGormModel
+// -----------------------------------------------------------------------------
+
+// CommissionGormModel representa uma comissão apurada para o GORM.
+type CommissionGormModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ProfessionalID uuid.UUID  `gorm:"type:uuid;not null;index:idx_commission_professional_earned"`
+	RuleID         *uuid.UUID `gorm:"type:uuid"`
+	AppointmentID  *uuid.UUID `gorm:"type:uuid;index"`
+	Source         string     `gorm:"size:20;not null"`
+	Description    string     `gorm:"type:text"`
+	BaseAmount     float64    `gorm:"not null"`
+	Amount         float64    `gorm:"not null"`
+	Status         string     `gorm:"size:20;not null;default:'PENDING'"`
+	PayoutID       *uuid.UUID `gorm:"type:uuid;index"`
+	EarnedAt       time.Time  `gorm:"not null;index:idx_commission_professional_earned"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CommissionGormModel) TableName() string {
+	return "commissions"
+}
+
+// ToEntity converte um CommissionGormModel para uma entidade Commission.
+func (m *CommissionGormModel) ToEntity() *entity.Commission {
+	return &entity.Commission{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		ProfessionalID: m.ProfessionalID,
+		RuleID:         m.RuleID,
+		AppointmentID:  m.AppointmentID,
+		Source:         entity.CommissionSource(m.Source),
+		Description:    m.Description,
+		BaseAmount:     m.BaseAmount,
+		Amount:         m.Amount,
+		Status:         entity.CommissionStatus(m.Status),
+		PayoutID:       m.PayoutID,
+		EarnedAt:       m.EarnedAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// CommissionFromEntity converte uma entidade Commission para o modelo GORM.
+func CommissionFromEntity(e *entity.Commission) *CommissionGormModel {
+	return &CommissionGormModel{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		ProfessionalID: e.ProfessionalID,
+		RuleID:         e.RuleID,
+		AppointmentID:  e.AppointmentID,
+		Source:         string(e.Source),
+		Description:    e.Description,
+		BaseAmount:     e.BaseAmount,
+		Amount:         e.Amount,
+		Status:         string(e.Status),
+		PayoutID:       e.PayoutID,
+		EarnedAt:       e.EarnedAt,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+type gormCommissionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCommissionRepository cria uma nova instância do repositório de comissões.
+func NewGormCommissionRepository(db *gorm.DB) repository.CommissionRepository {
+	return &gormCommissionRepository{db: db}
+}
+
+func (r *gormCommissionRepository) Create(commissionEntity *entity.Commission) error {
+	commissionGorm := CommissionFromEntity(commissionEntity)
+	if err := r.db.Create(commissionGorm).Error; err != nil {
+		return err
+	}
+	commissionEntity.ID = commissionGorm.ID
+	commissionEntity.CreatedAt = commissionGorm.CreatedAt
+	commissionEntity.UpdatedAt = commissionGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormCommissionRepository) FindByAppointmentID(appointmentID uuid.UUID) (*entity.Commission, error) {
+	var commissionGorm CommissionGormModel
+	// Apenas a comissão do serviço; a gorjeta do fechamento é registrada à parte
+	result := r.db.Where("appointment_id = ? AND source = ?", appointmentID, string(entity.CommissionSourceService)).First(&commissionGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return commissionGorm.ToEntity(), nil
+}
+
+func (r *gormCommissionRepository) FindByProfessionalID(professionalID uuid.UUID, from, to time.Time) ([]*entity.Commission, error) {
+	var commissionsGorm []CommissionGormModel
+	result := r.db.Where("professional_id = ? AND earned_at >= ? AND earned_at < ?", professionalID, from, to).
+		Order("earned_at asc").
+		Find(&commissionsGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var commissionEntities []*entity.Commission
+	for _, cg := range commissionsGorm {
+		commissionEntities = append(commissionEntities, cg.ToEntity())
+	}
+	return commissionEntities, nil
+}
+
+// MarkPaid marca as comissões como pagas no repasse informado. Apenas comissões
+// ainda pendentes são alteradas, evitando pagar a mesma comissão duas vezes.
+func (r *gormCommissionRepository) MarkPaid(ids []uuid.UUID, payoutID uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&CommissionGormModel{}).
+		Where("id IN ? AND status = ?", ids, string(entity.CommissionStatusPending)).
+		Updates(map[string]interface{}{
+			"status":    string(entity.CommissionStatusPaid),
+			"payout_id": payoutID,
+		}).Error
+}
+
+// -----------------------------------------------------------------------------
+// CommissionPayoutGormModel
+// -----------------------------------------------------------------------------
+
+// CommissionPayoutGormModel representa um repasse de comissões para o GORM.
+type CommissionPayoutGormModel struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ProfessionalID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	PeriodStart      time.Time  `gorm:"not null"`
+	PeriodEnd        time.Time  `gorm:"not null"`
+	Amount           float64    `gorm:"not null"`
+	CommissionCount  int        `gorm:"not null"`
+	FinancialEntryID *uuid.UUID `gorm:"type:uuid"`
+	PaidAt           time.Time  `gorm:"not null"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CommissionPayoutGormModel) TableName() string {
+	return "commission_payouts"
+}
+
+// ToEntity converte um CommissionPayoutGormModel para uma entidade CommissionPayout.
+func (m *CommissionPayoutGormModel) ToEntity() *entity.CommissionPayout {
+	return &entity.CommissionPayout{
+		ID:               m.ID,
+		UserID:           m.UserID,
+		ProfessionalID:   m.ProfessionalID,
+		PeriodStart:      m.PeriodStart,
+		PeriodEnd:        m.PeriodEnd,
+		Amount:           m.Amount,
+		CommissionCount:  m.CommissionCount,
+		FinancialEntryID: m.FinancialEntryID,
+		PaidAt:           m.PaidAt,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// CommissionPayoutFromEntity converte uma entidade CommissionPayout para o modelo GORM.
+func CommissionPayoutFromEntity(e *entity.CommissionPayout) *CommissionPayoutGormModel {
+	return &CommissionPayoutGormModel{
+		ID:               e.ID,
+		UserID:           e.UserID,
+		ProfessionalID:   e.ProfessionalID,
+		PeriodStart:      e.PeriodStart,
+		PeriodEnd:        e.PeriodEnd,
+		Amount:           e.Amount,
+		CommissionCount:  e.CommissionCount,
+		FinancialEntryID: e.FinancialEntryID,
+		PaidAt:           e.PaidAt,
+		CreatedAt:        e.CreatedAt,
+	}
+}
+
+type gormCommissionPayoutRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCommissionPayoutRepository cria uma nova instância do repositório de repasses.
+func NewGormCommissionPayoutRepository(db *gorm.DB) repository.CommissionPayoutRepository {
+	return &gormCommissionPayoutRepository{db: db}
+}
+
+func (r *gormCommissionPayoutRepository) Create(payoutEntity *entity.CommissionPayout) error {
+	payoutGorm := CommissionPayoutFromEntity(payoutEntity)
+	if err := r.db.Create(payoutGorm).Error; err != nil {
+		return err
+	}
+	payoutEntity.ID = payoutGorm.ID
+	payoutEntity.CreatedAt = payoutGorm.CreatedAt
+	return nil
+}
+
+func (r *gormCommissionPayoutRepository) FindByProfessionalID(professionalID uuid.UUID) ([]*entity.CommissionPayout, error) {
+	var payoutsGorm []CommissionPayoutGormModel
+	if err := r.db.Where("professional_id = ?", professionalID).Order("paid_at desc").Find(&payoutsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var payoutEntities []*entity.CommissionPayout
+	for _, pg := range payoutsGorm {
+		payoutEntities = append(payoutEntities, pg.ToEntity())
+	}
+	return payoutEntities, nil
+}
@@ -0,0 +1,291 @@
+package gorm
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// -----------------------------------------------------------------------------
+// CouponGormModel
+// -----------------------------------------------------------------------------
+
+// CouponGormModel representa um cupom de desconto para o GORM.
+type CouponGormModel struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID             uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_coupon_user_code"`
+	Code               string    `gorm:"size:50;not null;uniqueIndex:idx_coupon_user_code"`
+	Description        string    `gorm:"type:text"`
+	DiscountType       string    `gorm:"size:20;not null"`
+	Value              float64   `gorm:"not null"`
+	ValidFrom          *time.Time
+	ValidUntil         *time.Time
+	MaxUses            int            `gorm:"not null;default:0"`
+	MaxUsesPerCustomer int            `gorm:"not null;default:0"`
+	ServiceIDs         string         `gorm:"type:text"` // UUIDs separados por vírgula
+	Active             bool           `gorm:"not null"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CouponGormModel) TableName() string {
+	return "coupons"
+}
+
+// ToEntity converte um CouponGormModel para uma entidade Coupon.
+func (m *CouponGormModel) ToEntity() *entity.Coupon {
+	return &entity.Coupon{
+		ID:                 m.ID,
+		UserID:             m.UserID,
+		Code:               m.Code,
+		Description:        m.Description,
+		DiscountType:       entity.CouponDiscountType(m.DiscountType),
+		Value:              m.Value,
+		ValidFrom:          m.ValidFrom,
+		ValidUntil:         m.ValidUntil,
+		MaxUses:            m.MaxUses,
+		MaxUsesPerCustomer: m.MaxUsesPerCustomer,
+		ServiceIDs:         splitUUIDs(m.ServiceIDs),
+		Active:             m.Active,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// CouponFromEntity converte uma entidade Coupon para o modelo GORM.
+func CouponFromEntity(e *entity.Coupon) *CouponGormModel {
+	return &CouponGormModel{
+		ID:                 e.ID,
+		UserID:             e.UserID,
+		Code:               e.Code,
+		Description:        e.Description,
+		DiscountType:       string(e.DiscountType),
+		Value:              e.Value,
+		ValidFrom:          e.ValidFrom,
+		ValidUntil:         e.ValidUntil,
+		MaxUses:            e.MaxUses,
+		MaxUsesPerCustomer: e.MaxUsesPerCustomer,
+		ServiceIDs:         joinUUIDs(e.ServiceIDs),
+		Active:             e.Active,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+type gormCouponRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCouponRepository cria uma nova instância do repositório de cupons.
+func NewGormCouponRepository(db *gorm.DB) repository.CouponRepository {
+	return &gormCouponRepository{db: db}
+}
+
+func (r *gormCouponRepository) Create(couponEntity *entity.Coupon) error {
+	couponGorm := CouponFromEntity(couponEntity)
+	if err := r.db.Create(couponGorm).Error; err != nil {
+		return err
+	}
+	couponEntity.ID = couponGorm.ID
+	couponEntity.CreatedAt = couponGorm.CreatedAt
+	couponEntity.UpdatedAt = couponGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormCouponRepository) FindByID(id uuid.UUID) (*entity.Coupon, error) {
+	var couponGorm CouponGormModel
+	result := r.db.First(&couponGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return couponGorm.ToEntity(), nil
+}
+
+// LockByID busca um cupom com SELECT ... FOR UPDATE.
+func (r *gormCouponRepository) LockByID(id uuid.UUID) (*entity.Coupon, error) {
+	var couponGorm CouponGormModel
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&couponGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return couponGorm.ToEntity(), nil
+}
+
+func (r *gormCouponRepository) FindByCode(userID uuid.UUID, code string) (*entity.Coupon, error) {
+	var couponGorm CouponGormModel
+	result := r.db.Where("user_id = ? AND code = ?", userID, strings.ToUpper(code)).First(&couponGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return couponGorm.ToEntity(), nil
+}
+
+func (r *gormCouponRepository) FindByUserID(userID uuid.UUID) ([]*entity.Coupon, error) {
+	var couponsGorm []CouponGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("created_at desc").Find(&couponsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var couponEntities []*entity.Coupon
+	for _, cg := range couponsGorm {
+		couponEntities = append(couponEntities, cg.ToEntity())
+	}
+	return couponEntities, nil
+}
+
+func (r *gormCouponRepository) Update(couponEntity *entity.Coupon) error {
+	if couponEntity.ID == uuid.Nil {
+		return errors.New("ID do cupom não pode ser nulo para atualização")
+	}
+	couponGorm := CouponFromEntity(couponEntity)
+	// Select("*") para permitir limpar a validade e os limites de uso
+	result := r.db.Model(&CouponGormModel{}).Where("id = ?", couponGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(couponGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("cupom não encontrado para atualização")
+	}
+	return nil
+}
+
+func (r *gormCouponRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do cupom não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&CouponGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("cupom não encontrado para deleção")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CouponRedemptionGormModel
+// -----------------------------------------------------------------------------
+
+// CouponRedemptionGormModel representa o uso de um cupom para o GORM.
+type CouponRedemptionGormModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	CouponID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	AppointmentID  uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	CustomerID     *uuid.UUID `gorm:"type:uuid;index"`
+	ClientEmail    string     `gorm:"size:255;index"`
+	DiscountAmount float64    `gorm:"not null"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CouponRedemptionGormModel) TableName() string {
+	return "coupon_redemptions"
+}
+
+// ToEntity converte um CouponRedemptionGormModel para uma entidade CouponRedemption.
+func (m *CouponRedemptionGormModel) ToEntity() *entity.CouponRedemption {
+	return &entity.CouponRedemption{
+		ID:             m.ID,
+		CouponID:       m.CouponID,
+		AppointmentID:  m.AppointmentID,
+		CustomerID:     m.CustomerID,
+		ClientEmail:    m.ClientEmail,
+		DiscountAmount: m.DiscountAmount,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// CouponRedemptionFromEntity converte uma entidade CouponRedemption para o modelo GORM.
+func CouponRedemptionFromEntity(e *entity.CouponRedemption) *CouponRedemptionGormModel {
+	return &CouponRedemptionGormModel{
+		ID:             e.ID,
+		CouponID:       e.CouponID,
+		AppointmentID:  e.AppointmentID,
+		CustomerID:     e.CustomerID,
+		ClientEmail:    strings.ToLower(e.ClientEmail),
+		DiscountAmount: e.DiscountAmount,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+type gormCouponRedemptionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCouponRedemptionRepository cria uma nova instância do repositório de usos de cupons.
+func NewGormCouponRedemptionRepository(db *gorm.DB) repository.CouponRedemptionRepository {
+	return &gormCouponRedemptionRepository{db: db}
+}
+
+func (r *gormCouponRedemptionRepository) Create(redemptionEntity *entity.CouponRedemption) error {
+	redemptionGorm := CouponRedemptionFromEntity(redemptionEntity)
+	if err := r.db.Create(redemptionGorm).Error; err != nil {
+		return err
+	}
+	redemptionEntity.ID = redemptionGorm.ID
+	redemptionEntity.CreatedAt = redemptionGorm.CreatedAt
+	return nil
+}
+
+// activeRedemptions restringe a consulta aos usos de agendamentos existentes e não
+// cancelados, de modo que cancelar ou excluir um agendamento devolve o uso do cupom.
+func (r *gormCouponRedemptionRepository) activeRedemptions(couponID uuid.UUID) *gorm.DB {
+	activeAppointments := r.db.Model(&AppointmentGormModel{}).
+		Select("id").
+		Where("status <> ?", string(entity.AppointmentStatusCancelled))
+	return r.db.Model(&CouponRedemptionGormModel{}).
+		Where("coupon_id = ? AND appointment_id IN (?)", couponID, activeAppointments)
+}
+
+func (r *gormCouponRedemptionRepository) FindByCouponID(couponID uuid.UUID) ([]*entity.CouponRedemption, error) {
+	var redemptionsGorm []CouponRedemptionGormModel
+	if err := r.activeRedemptions(couponID).Order("created_at desc").Find(&redemptionsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var redemptionEntities []*entity.CouponRedemption
+	for _, rg := range redemptionsGorm {
+		redemptionEntities = append(redemptionEntities, rg.ToEntity())
+	}
+	return redemptionEntities, nil
+}
+
+func (r *gormCouponRedemptionRepository) CountByCouponID(couponID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.activeRedemptions(couponID).Count(&count).Error
+	return count, err
+}
+
+func (r *gormCouponRedemptionRepository) CountByCustomer(couponID uuid.UUID, customerID *uuid.UUID, clientEmail string) (int64, error) {
+	query := r.activeRedemptions(couponID)
+	switch {
+	case customerID != nil && clientEmail != "":
+		query = query.Where("customer_id = ? OR client_email = ?", *customerID, strings.ToLower(clientEmail))
+	case customerID != nil:
+		query = query.Where("customer_id = ?", *customerID)
+	default:
+		query = query.Where("client_email = ?", strings.ToLower(clientEmail))
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,253 @@
+package gorm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// gormDashboardRepository calcula os indicadores do painel diretamente no banco.
+type gormDashboardRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDashboardRepository cria uma nova instância do repositório do painel.
+func NewGormDashboardRepository(db *gorm.DB) repository.DashboardRepository {
+	return &gormDashboardRepository{db: db}
+}
+
+// statusCountRow recebe o resultado de CountAppointmentsByStatus.
+type statusCountRow struct {
+	Status string
+	Count  int
+}
+
+func (r *gormDashboardRepository) CountAppointmentsByStatus(userID uuid.UUID, from, to time.Time) (map[entity.AppointmentStatus]int, error) {
+	var rows []statusCountRow
+	err := r.db.Model(&AppointmentGormModel{}).
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ? AND start_time >= ? AND start_time < ?", userID, from, to).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[entity.AppointmentStatus]int, len(rows))
+	for _, row := range rows {
+		counts[entity.AppointmentStatus(row.Status)] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *gormDashboardRepository) CompletedAppointmentsRevenue(userID uuid.UUID, from, to time.Time) (float64, int, error) {
+	var row struct {
+		Total float64
+		Count int
+	}
+	err := r.db.Model(&AppointmentGormModel{}).
+		Select("COALESCE(SUM(price), 0) AS total, COUNT(*) AS count").
+		Where("user_id = ? AND status = ? AND start_time >= ? AND start_time < ?",
+			userID, string(entity.AppointmentStatusCompleted), from, to).
+		Scan(&row).Error
+	return row.Total, row.Count, err
+}
+
+// revenuePointRow recebe o resultado das consultas de RevenueSeries.
+type revenuePointRow struct {
+	PeriodStart time.Time
+	Amount      float64
+}
+
+// RevenueSeries segue a mesma regra de SumRevenue: agendamentos concluídos mais as
+// entradas não vinculadas a agendamentos nem a vendas de vale-presente.
+func (r *gormDashboardRepository) RevenueSeries(userID uuid.UUID, from, to time.Time, unit string) ([]repository.RevenuePoint, error) {
+	switch unit {
+	case "day", "week", "month":
+	default:
+		return nil, fmt.Errorf("unidade de agrupamento inválida: %s", unit)
+	}
+
+	var appointmentRows []revenuePointRow
+	err := r.db.Model(&AppointmentGormModel{}).
+		Select(fmt.Sprintf("date_trunc('%s', start_time) AS period_start, COALESCE(SUM(price), 0) AS amount", unit)).
+		Where("user_id = ? AND status = ? AND start_time >= ? AND start_time < ?",
+			userID, string(entity.AppointmentStatusCompleted), from, to).
+		Group("period_start").
+		Scan(&appointmentRows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var entryRows []revenuePointRow
+	err = r.db.Model(&FinancialEntryGormModel{}).
+		Select(fmt.Sprintf("date_trunc('%s', date) AS period_start, COALESCE(SUM(amount), 0) AS amount", unit)).
+		Where("user_id = ? AND type = ? AND appointment_id IS NULL AND gift_card_id IS NULL AND date >= ? AND date < ?",
+			userID, string(entity.FinancialEntryTypeIncome), from, to).
+		Group("period_start").
+		Scan(&entryRows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byPeriod := make(map[int64]*repository.RevenuePoint)
+	for _, row := range append(appointmentRows, entryRows...) {
+		key := row.PeriodStart.Unix()
+		if point, ok := byPeriod[key]; ok {
+			point.Amount += row.Amount
+			continue
+		}
+		byPeriod[key] = &repository.RevenuePoint{PeriodStart: row.PeriodStart, Amount: row.Amount}
+	}
+	points := make([]repository.RevenuePoint, 0, len(byPeriod))
+	for _, point := range byPeriod {
+		points = append(points, *point)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].PeriodStart.Before(points[j].PeriodStart) })
+	return points, nil
+}
+
+// rankingRow recebe o resultado de TopServices e TopClients.
+type rankingRow struct {
+	ID           *uuid.UUID
+	Name         string
+	Appointments int
+	Revenue      float64
+}
+
+// TopServices agrupa pelo serviço do catálogo ou, sem ele, pela descrição do agendamento.
+// Os nomes dos serviços do catálogo são preenchidos pelo caso de uso.
+func (r *gormDashboardRepository) TopServices(userID uuid.UUID, from, to time.Time, limit int) ([]repository.RankingRow, error) {
+	const name = "CASE WHEN service_id IS NULL THEN service_description ELSE '' END"
+	var rows []rankingRow
+	err := r.db.Model(&AppointmentGormModel{}).
+		Select("service_id AS id, "+name+" AS name, COUNT(*) AS appointments, COALESCE(SUM(price), 0) AS revenue").
+		Where("user_id = ? AND status = ? AND start_time >= ? AND start_time < ?",
+			userID, string(entity.AppointmentStatusCompleted), from, to).
+		Group("service_id, " + name).
+		Order("revenue DESC, appointments DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return toRankingRows(rows), err
+}
+
+// TopClients agrupa pelo cliente do cadastro ou, sem ele, pelo nome informado no
+// agendamento. Os nomes dos clientes do cadastro são preenchidos pelo caso de uso.
+func (r *gormDashboardRepository) TopClients(userID uuid.UUID, from, to time.Time, limit int) ([]repository.RankingRow, error) {
+	const name = "CASE WHEN customer_id IS NULL THEN client_name ELSE '' END"
+	var rows []rankingRow
+	err := r.db.Model(&AppointmentGormModel{}).
+		Select("customer_id AS id, "+name+" AS name, COUNT(*) AS appointments, COALESCE(SUM(price), 0) AS revenue").
+		Where("user_id = ? AND status = ? AND start_time >= ? AND start_time < ? AND (customer_id IS NOT NULL OR client_name <> '')",
+			userID, string(entity.AppointmentStatusCompleted), from, to).
+		Group("customer_id, " + name).
+		Order("revenue DESC, appointments DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return toRankingRows(rows), err
+}
+
+func toRankingRows(rows []rankingRow) []repository.RankingRow {
+	ranking := make([]repository.RankingRow, len(rows))
+	for i, row := range rows {
+		ranking[i] = repository.RankingRow(row)
+	}
+	return ranking
+}
+
+func (r *gormDashboardRepository) BookedMinutes(userID uuid.UUID, from, to time.Time) (float64, error) {
+	var minutes float64
+	err := r.db.Model(&AppointmentGormModel{}).
+		Select("COALESCE(SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60), 0)").
+		Where("user_id = ? AND status <> ? AND start_time >= ? AND start_time < ?",
+			userID, string(entity.AppointmentStatusCancelled), from, to).
+		Scan(&minutes).Error
+	return minutes, err
+}
+
+// WorkingHoursGormModel representa o horário de funcionamento de um dia da semana para o GORM.
+type WorkingHoursGormModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_working_hours_user_weekday"`
+	Weekday     int       `gorm:"not null;uniqueIndex:idx_working_hours_user_weekday"`
+	OpenMinute  int       `gorm:"not null"`
+	CloseMinute int       `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (WorkingHoursGormModel) TableName() string {
+	return "working_hours"
+}
+
+// ToEntity converte um WorkingHoursGormModel para uma entidade WorkingHours.
+func (m *WorkingHoursGormModel) ToEntity() *entity.WorkingHours {
+	return &entity.WorkingHours{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Weekday:     time.Weekday(m.Weekday),
+		OpenMinute:  m.OpenMinute,
+		CloseMinute: m.CloseMinute,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// WorkingHoursFromEntity converte uma entidade WorkingHours para WorkingHoursGormModel.
+func WorkingHoursFromEntity(e *entity.WorkingHours) *WorkingHoursGormModel {
+	return &WorkingHoursGormModel{
+		ID:          e.ID,
+		UserID:      e.UserID,
+		Weekday:     int(e.Weekday),
+		OpenMinute:  e.OpenMinute,
+		CloseMinute: e.CloseMinute,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+type gormWorkingHoursRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWorkingHoursRepository cria uma nova instância do repositório de horário de funcionamento.
+func NewGormWorkingHoursRepository(db *gorm.DB) repository.WorkingHoursRepository {
+	return &gormWorkingHoursRepository{db: db}
+}
+
+func (r *gormWorkingHoursRepository) FindByUserID(userID uuid.UUID) ([]*entity.WorkingHours, error) {
+	var hoursGorm []WorkingHoursGormModel
+	result := r.db.Where("user_id = ?", userID).Order("weekday asc").Find(&hoursGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var hours []*entity.WorkingHours
+	for _, hg := range hoursGorm {
+		hours = append(hours, hg.ToEntity())
+	}
+	return hours, nil
+}
+
+func (r *gormWorkingHoursRepository) ReplaceForUser(userID uuid.UUID, hours []*entity.WorkingHours) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&WorkingHoursGormModel{}).Error; err != nil {
+			return err
+		}
+		for _, h := range hours {
+			hoursGorm := WorkingHoursFromEntity(h)
+			if err := tx.Create(hoursGorm).Error; err != nil {
+				return err
+			}
+			h.ID = hoursGorm.ID
+			h.CreatedAt = hoursGorm.CreatedAt
+			h.UpdatedAt = hoursGorm.UpdatedAt
+		}
+		return nil
+	})
+}
@@ -0,0 +1,322 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// DomainEventGormModel
+// -----------------------------------------------------------------------------
+
+// DomainEventGormModel representa um evento da outbox para o GORM.
+type DomainEventGormModel struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	Type         string    `gorm:"size:50;not null;index"`
+	AggregateID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Payload      string    `gorm:"type:text;not null"`
+	OccurredAt   time.Time `gorm:"not null;index:idx_domain_event_pending,priority:2"`
+	Status       string    `gorm:"size:20;not null;index:idx_domain_event_pending,priority:1"`
+	DispatchedAt *time.Time
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (DomainEventGormModel) TableName() string {
+	return "domain_events"
+}
+
+// ToEntity converte um DomainEventGormModel para uma entidade DomainEvent.
+func (m *DomainEventGormModel) ToEntity() *entity.DomainEvent {
+	return &entity.DomainEvent{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		Type:         entity.DomainEventType(m.Type),
+		AggregateID:  m.AggregateID,
+		Payload:      m.Payload,
+		OccurredAt:   m.OccurredAt,
+		Status:       entity.DomainEventStatus(m.Status),
+		DispatchedAt: m.DispatchedAt,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// DomainEventFromEntity converte uma entidade DomainEvent para o modelo GORM.
+func DomainEventFromEntity(e *entity.DomainEvent) *DomainEventGormModel {
+	return &DomainEventGormModel{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		Type:         string(e.Type),
+		AggregateID:  e.AggregateID,
+		Payload:      e.Payload,
+		OccurredAt:   e.OccurredAt,
+		Status:       string(e.Status),
+		DispatchedAt: e.DispatchedAt,
+		CreatedAt:    e.CreatedAt,
+	}
+}
+
+// appendDomainEvents grava os eventos na outbox usando a transação informada.
+func appendDomainEvents(tx *gorm.DB, events []*entity.DomainEvent) error {
+	for _, event := range events {
+		eventGorm := DomainEventFromEntity(event)
+		if err := tx.Create(eventGorm).Error; err != nil {
+			return err
+		}
+		event.ID = eventGorm.ID
+		event.CreatedAt = eventGorm.CreatedAt
+	}
+	return nil
+}
+
+type gormDomainEventRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDomainEventRepository cria uma nova instância do repositório da outbox de eventos.
+func NewGormDomainEventRepository(db *gorm.DB) repository.DomainEventRepository {
+	return &gormDomainEventRepository{db: db}
+}
+
+func (r *gormDomainEventRepository) Append(events ...*entity.DomainEvent) error {
+	return appendDomainEvents(r.db, events)
+}
+
+func (r *gormDomainEventRepository) FindByID(id uuid.UUID) (*entity.DomainEvent, error) {
+	var eventGorm DomainEventGormModel
+	result := r.db.First(&eventGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return eventGorm.ToEntity(), nil
+}
+
+func (r *gormDomainEventRepository) FindPending(limit int) ([]*entity.DomainEvent, error) {
+	var eventsGorm []DomainEventGormModel
+	result := r.db.Where("status = ?", string(entity.DomainEventStatusPending)).
+		Order("occurred_at asc").
+		Limit(limit).
+		Find(&eventsGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toDomainEventEntities(eventsGorm), nil
+}
+
+func (r *gormDomainEventRepository) MarkDispatched(event *entity.DomainEvent, deliveries []*entity.EventDelivery) (bool, error) {
+	dispatched := false
+	now := time.Now()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// A atualização condicional garante que duas execuções concorrentes não criem
+		// entregas em dobro para o mesmo evento.
+		result := tx.Model(&DomainEventGormModel{}).
+			Where("id = ? AND status = ?", event.ID, string(entity.DomainEventStatusPending)).
+			Updates(map[string]any{"status": string(entity.DomainEventStatusDispatched), "dispatched_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		for _, delivery := range deliveries {
+			deliveryGorm := EventDeliveryFromEntity(delivery)
+			if err := tx.Create(deliveryGorm).Error; err != nil {
+				return err
+			}
+			delivery.ID = deliveryGorm.ID
+			delivery.CreatedAt = deliveryGorm.CreatedAt
+			delivery.UpdatedAt = deliveryGorm.UpdatedAt
+		}
+		dispatched = true
+		return nil
+	})
+	if err != nil || !dispatched {
+		return false, err
+	}
+	event.Status = entity.DomainEventStatusDispatched
+	event.DispatchedAt = &now
+	return true, nil
+}
+
+func (r *gormDomainEventRepository) FindByUserID(userID uuid.UUID, eventType entity.DomainEventType, limit int) ([]*entity.DomainEvent, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if eventType != "" {
+		query = query.Where("type = ?", string(eventType))
+	}
+
+	var eventsGorm []DomainEventGormModel
+	if err := query.Order("occurred_at desc").Limit(limit).Find(&eventsGorm).Error; err != nil {
+		return nil, err
+	}
+	return toDomainEventEntities(eventsGorm), nil
+}
+
+func (r *gormDomainEventRepository) FindByUserIDAfter(userID uuid.UUID, types []entity.DomainEventType, after *entity.DomainEvent, limit int) ([]*entity.DomainEvent, error) {
+	typeNames := make([]string, len(types))
+	for i, t := range types {
+		typeNames[i] = string(t)
+	}
+
+	var eventsGorm []DomainEventGormModel
+	err := r.db.
+		Where("user_id = ? AND type IN ?", userID, typeNames).
+		Where("occurred_at > ? OR (occurred_at = ? AND id > ?)", after.OccurredAt, after.OccurredAt, after.ID).
+		Order("occurred_at asc, id asc").
+		Limit(limit).
+		Find(&eventsGorm).Error
+	if err != nil {
+		return nil, err
+	}
+	return toDomainEventEntities(eventsGorm), nil
+}
+
+func toDomainEventEntities(eventsGorm []DomainEventGormModel) []*entity.DomainEvent {
+	var events []*entity.DomainEvent
+	for _, eg := range eventsGorm {
+		events = append(events, eg.ToEntity())
+	}
+	return events
+}
+
+// -----------------------------------------------------------------------------
+// EventDeliveryGormModel
+// -----------------------------------------------------------------------------
+
+// EventDeliveryGormModel representa a entrega de um evento a um assinante para o GORM.
+type EventDeliveryGormModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	EventID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_event_delivery_subscriber,priority:1"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	EventType     string    `gorm:"size:50;not null"`
+	Subscriber    string    `gorm:"size:100;not null;uniqueIndex:idx_event_delivery_subscriber,priority:2"`
+	Status        string    `gorm:"size:20;not null;index:idx_event_delivery_due,priority:1"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null;index:idx_event_delivery_due,priority:2"`
+	LastError     string    `gorm:"type:text"`
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (EventDeliveryGormModel) TableName() string {
+	return "event_deliveries"
+}
+
+// ToEntity converte um EventDeliveryGormModel para uma entidade EventDelivery.
+func (m *EventDeliveryGormModel) ToEntity() *entity.EventDelivery {
+	return &entity.EventDelivery{
+		ID:            m.ID,
+		EventID:       m.EventID,
+		UserID:        m.UserID,
+		EventType:     entity.DomainEventType(m.EventType),
+		Subscriber:    m.Subscriber,
+		Status:        entity.EventDeliveryStatus(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		DeliveredAt:   m.DeliveredAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// EventDeliveryFromEntity converte uma entidade EventDelivery para o modelo GORM.
+func EventDeliveryFromEntity(e *entity.EventDelivery) *EventDeliveryGormModel {
+	return &EventDeliveryGormModel{
+		ID:            e.ID,
+		EventID:       e.EventID,
+		UserID:        e.UserID,
+		EventType:     string(e.EventType),
+		Subscriber:    e.Subscriber,
+		Status:        string(e.Status),
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		LastError:     e.LastError,
+		DeliveredAt:   e.DeliveredAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+type gormEventDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEventDeliveryRepository cria uma nova instância do repositório de entregas de eventos.
+func NewGormEventDeliveryRepository(db *gorm.DB) repository.EventDeliveryRepository {
+	return &gormEventDeliveryRepository{db: db}
+}
+
+func (r *gormEventDeliveryRepository) Update(delivery *entity.EventDelivery) error {
+	deliveryGorm := EventDeliveryFromEntity(delivery)
+	if err := r.db.Save(deliveryGorm).Error; err != nil {
+		return err
+	}
+	delivery.UpdatedAt = deliveryGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormEventDeliveryRepository) FindByID(id uuid.UUID) (*entity.EventDelivery, error) {
+	var deliveryGorm EventDeliveryGormModel
+	result := r.db.First(&deliveryGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return deliveryGorm.ToEntity(), nil
+}
+
+func (r *gormEventDeliveryRepository) FindDue(now time.Time, limit int) ([]*entity.EventDelivery, error) {
+	var deliveriesGorm []EventDeliveryGormModel
+	result := r.db.Where("status = ? AND next_attempt_at <= ?", string(entity.EventDeliveryStatusPending), now).
+		Order("next_attempt_at asc").
+		Limit(limit).
+		Find(&deliveriesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toEventDeliveryEntities(deliveriesGorm), nil
+}
+
+func (r *gormEventDeliveryRepository) Claim(id uuid.UUID, now, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&EventDeliveryGormModel{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, string(entity.EventDeliveryStatusPending), now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormEventDeliveryRepository) FindByUserID(userID uuid.UUID, status entity.EventDeliveryStatus) ([]*entity.EventDelivery, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var deliveriesGorm []EventDeliveryGormModel
+	if err := query.Order("created_at desc").Find(&deliveriesGorm).Error; err != nil {
+		return nil, err
+	}
+	return toEventDeliveryEntities(deliveriesGorm), nil
+}
+
+func toEventDeliveryEntities(deliveriesGorm []EventDeliveryGormModel) []*entity.EventDelivery {
+	var deliveries []*entity.EventDelivery
+	for _, dg := range deliveriesGorm {
+		deliveries = append(deliveries, dg.ToEntity())
+	}
+	return deliveries
+}
@@ -0,0 +1,282 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// EmailSettingsGormModel
+// -----------------------------------------------------------------------------
+
+// EmailSettingsGormModel representa as configurações de e-mail do usuário para o GORM.
+type EmailSettingsGormModel struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Enabled      bool      `gorm:"not null"`
+	Locale       string    `gorm:"size:10;not null"`
+	SenderName   string    `gorm:"size:100"`
+	ReplyTo      string    `gorm:"size:255"`
+	LogoURL      string    `gorm:"size:500"`
+	PrimaryColor string    `gorm:"size:7;not null"`
+	FooterText   string    `gorm:"type:text"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (EmailSettingsGormModel) TableName() string {
+	return "email_settings"
+}
+
+// ToEntity converte um EmailSettingsGormModel para uma entidade EmailSettings.
+func (m *EmailSettingsGormModel) ToEntity() *entity.EmailSettings {
+	return &entity.EmailSettings{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		Enabled:      m.Enabled,
+		Locale:       m.Locale,
+		SenderName:   m.SenderName,
+		ReplyTo:      m.ReplyTo,
+		LogoURL:      m.LogoURL,
+		PrimaryColor: m.PrimaryColor,
+		FooterText:   m.FooterText,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// EmailSettingsFromEntity converte uma entidade EmailSettings para o modelo GORM.
+func EmailSettingsFromEntity(e *entity.EmailSettings) *EmailSettingsGormModel {
+	return &EmailSettingsGormModel{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		Enabled:      e.Enabled,
+		Locale:       e.Locale,
+		SenderName:   e.SenderName,
+		ReplyTo:      e.ReplyTo,
+		LogoURL:      e.LogoURL,
+		PrimaryColor: e.PrimaryColor,
+		FooterText:   e.FooterText,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
+
+type gormEmailSettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEmailSettingsRepository cria uma nova instância do repositório de configurações de e-mail.
+func NewGormEmailSettingsRepository(db *gorm.DB) repository.EmailSettingsRepository {
+	return &gormEmailSettingsRepository{db: db}
+}
+
+func (r *gormEmailSettingsRepository) FindByUserID(userID uuid.UUID) (*entity.EmailSettings, error) {
+	var settingsGorm EmailSettingsGormModel
+	result := r.db.Where("user_id = ?", userID).First(&settingsGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return settingsGorm.ToEntity(), nil
+}
+
+func (r *gormEmailSettingsRepository) Save(settingsEntity *entity.EmailSettings) error {
+	var existing EmailSettingsGormModel
+	result := r.db.Where("user_id = ?", settingsEntity.UserID).First(&existing)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return result.Error
+	}
+
+	settingsGorm := EmailSettingsFromEntity(settingsEntity)
+	if result.Error == nil {
+		settingsGorm.ID = existing.ID
+		settingsGorm.CreatedAt = existing.CreatedAt
+	} else if settingsGorm.ID == uuid.Nil {
+		settingsGorm.ID = uuid.New()
+	}
+	if err := r.db.Save(settingsGorm).Error; err != nil {
+		return err
+	}
+	settingsEntity.ID = settingsGorm.ID
+	settingsEntity.CreatedAt = settingsGorm.CreatedAt
+	settingsEntity.UpdatedAt = settingsGorm.UpdatedAt
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// OutboundEmailGormModel
+// -----------------------------------------------------------------------------
+
+// OutboundEmailGormModel representa um e-mail da fila de envio para o GORM.
+type OutboundEmailGormModel struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID            uuid.UUID  `gorm:"type:uuid;not null;index"`
+	AppointmentID     *uuid.UUID `gorm:"type:uuid;index"`
+	Event             string     `gorm:"size:30;not null"`
+	Recipient         string     `gorm:"size:255;not null"`
+	FromName          string     `gorm:"size:100"`
+	ReplyTo           string     `gorm:"size:255"`
+	Subject           string     `gorm:"size:255;not null"`
+	HTMLBody          string     `gorm:"type:text;not null"`
+	TextBody          string     `gorm:"type:text;not null"`
+	ICSAttachment     string     `gorm:"type:text"`
+	Status            string     `gorm:"size:20;not null;index:idx_email_outbox_due,priority:1"`
+	Attempts          int        `gorm:"not null;default:0"`
+	NextAttemptAt     time.Time  `gorm:"not null;index:idx_email_outbox_due,priority:2"`
+	LastError         string     `gorm:"type:text"`
+	ProviderMessageID string     `gorm:"size:255"`
+	SentAt            *time.Time
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (OutboundEmailGormModel) TableName() string {
+	return "email_outbox"
+}
+
+// ToEntity converte um OutboundEmailGormModel para uma entidade OutboundEmail.
+func (m *OutboundEmailGormModel) ToEntity() *entity.OutboundEmail {
+	return &entity.OutboundEmail{
+		ID:                m.ID,
+		UserID:            m.UserID,
+		AppointmentID:     m.AppointmentID,
+		Event:             entity.EmailEvent(m.Event),
+		Recipient:         m.Recipient,
+		FromName:          m.FromName,
+		ReplyTo:           m.ReplyTo,
+		Subject:           m.Subject,
+		HTMLBody:          m.HTMLBody,
+		TextBody:          m.TextBody,
+		ICSAttachment:     m.ICSAttachment,
+		Status:            entity.EmailStatus(m.Status),
+		Attempts:          m.Attempts,
+		NextAttemptAt:     m.NextAttemptAt,
+		LastError:         m.LastError,
+		ProviderMessageID: m.ProviderMessageID,
+		SentAt:            m.SentAt,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+}
+
+// OutboundEmailFromEntity converte uma entidade OutboundEmail para o modelo GORM.
+func OutboundEmailFromEntity(e *entity.OutboundEmail) *OutboundEmailGormModel {
+	return &OutboundEmailGormModel{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		AppointmentID:     e.AppointmentID,
+		Event:             string(e.Event),
+		Recipient:         e.Recipient,
+		FromName:          e.FromName,
+		ReplyTo:           e.ReplyTo,
+		Subject:           e.Subject,
+		HTMLBody:          e.HTMLBody,
+		TextBody:          e.TextBody,
+		ICSAttachment:     e.ICSAttachment,
+		Status:            string(e.Status),
+		Attempts:          e.Attempts,
+		NextAttemptAt:     e.NextAttemptAt,
+		LastError:         e.LastError,
+		ProviderMessageID: e.ProviderMessageID,
+		SentAt:            e.SentAt,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
+
+type gormEmailOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEmailOutboxRepository cria uma nova instância do repositório da fila de e-mails.
+func NewGormEmailOutboxRepository(db *gorm.DB) repository.EmailOutboxRepository {
+	return &gormEmailOutboxRepository{db: db}
+}
+
+func (r *gormEmailOutboxRepository) Create(email *entity.OutboundEmail) error {
+	emailGorm := OutboundEmailFromEntity(email)
+	if err := r.db.Create(emailGorm).Error; err != nil {
+		return err
+	}
+	email.ID = emailGorm.ID
+	email.CreatedAt = emailGorm.CreatedAt
+	email.UpdatedAt = emailGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormEmailOutboxRepository) Update(email *entity.OutboundEmail) error {
+	emailGorm := OutboundEmailFromEntity(email)
+	if err := r.db.Save(emailGorm).Error; err != nil {
+		return err
+	}
+	email.UpdatedAt = emailGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormEmailOutboxRepository) FindByID(id uuid.UUID) (*entity.OutboundEmail, error) {
+	var emailGorm OutboundEmailGormModel
+	result := r.db.First(&emailGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return emailGorm.ToEntity(), nil
+}
+
+func (r *gormEmailOutboxRepository) FindByUserID(userID uuid.UUID, appointmentID *uuid.UUID, status entity.EmailStatus) ([]*entity.OutboundEmail, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if appointmentID != nil {
+		query = query.Where("appointment_id = ?", *appointmentID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var emailsGorm []OutboundEmailGormModel
+	if err := query.Order("created_at desc").Find(&emailsGorm).Error; err != nil {
+		return nil, err
+	}
+	return toOutboundEmailEntities(emailsGorm), nil
+}
+
+func (r *gormEmailOutboxRepository) FindDue(now time.Time, limit int) ([]*entity.OutboundEmail, error) {
+	var emailsGorm []OutboundEmailGormModel
+	result := r.db.Where("status = ? AND next_attempt_at <= ?", string(entity.EmailStatusPending), now).
+		Order("next_attempt_at asc").
+		Limit(limit).
+		Find(&emailsGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toOutboundEmailEntities(emailsGorm), nil
+}
+
+func (r *gormEmailOutboxRepository) Claim(id uuid.UUID, now, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&OutboundEmailGormModel{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, string(entity.EmailStatusPending), now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func toOutboundEmailEntities(emailsGorm []OutboundEmailGormModel) []*entity.OutboundEmail {
+	var emails []*entity.OutboundEmail
+	for _, eg := range emailsGorm {
+		emails = append(emails, eg.ToEntity())
+	}
+	return emails
+}
@@ -0,0 +1,667 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// -----------------------------------------------------------------------------
+// FinancialEntryGormModel
+// -----------------------------------------------------------------------------
+
+// FinancialEntryGormModel representa um lançamento financeiro para o GORM.
+type FinancialEntryGormModel struct {
+	ID                 uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID             uuid.UUID      `gorm:"type:uuid;not null;index:idx_financial_entry_user_date"`
+	Type               string         `gorm:"size:20;not null"`
+	Amount             float64        `gorm:"not null"`
+	Description        string         `gorm:"type:text"`
+	Date               time.Time      `gorm:"not null;index:idx_financial_entry_user_date"`
+	CategoryID         *uuid.UUID     `gorm:"type:uuid;index"`
+	AppointmentID      *uuid.UUID     `gorm:"type:uuid;index"`
+	PaymentID          *uuid.UUID     `gorm:"type:uuid;index"`
+	RecurringExpenseID *uuid.UUID     `gorm:"type:uuid;index"`
+	GiftCardID         *uuid.UUID     `gorm:"type:uuid;index"`
+	RevenueKind        string         `gorm:"size:30"`
+	Invoiced           bool           `gorm:"not null;default:false"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (FinancialEntryGormModel) TableName() string {
+	return "financial_entries"
+}
+
+// ToEntity converte um FinancialEntryGormModel para uma entidade FinancialEntry.
+func (m *FinancialEntryGormModel) ToEntity() *entity.FinancialEntry {
+	return &entity.FinancialEntry{
+		ID:                 m.ID,
+		UserID:             m.UserID,
+		Type:               entity.FinancialEntryType(m.Type),
+		Amount:             m.Amount,
+		Description:        m.Description,
+		Date:               m.Date,
+		CategoryID:         m.CategoryID,
+		AppointmentID:      m.AppointmentID,
+		PaymentID:          m.PaymentID,
+		RecurringExpenseID: m.RecurringExpenseID,
+		GiftCardID:         m.GiftCardID,
+		RevenueKind:        entity.RevenueKind(m.RevenueKind),
+		Invoiced:           m.Invoiced,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// FinancialEntryFromEntity converte uma entidade FinancialEntry para o modelo GORM.
+func FinancialEntryFromEntity(e *entity.FinancialEntry) *FinancialEntryGormModel {
+	return &FinancialEntryGormModel{
+		ID:                 e.ID,
+		UserID:             e.UserID,
+		Type:               string(e.Type),
+		Amount:             e.Amount,
+		Description:        e.Description,
+		Date:               e.Date,
+		CategoryID:         e.CategoryID,
+		AppointmentID:      e.AppointmentID,
+		PaymentID:          e.PaymentID,
+		RecurringExpenseID: e.RecurringExpenseID,
+		GiftCardID:         e.GiftCardID,
+		RevenueKind:        string(e.RevenueKind),
+		Invoiced:           e.Invoiced,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+type gormFinancialEntryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormFinancialEntryRepository cria uma nova instância do repositório de lançamentos.
+func NewGormFinancialEntryRepository(db *gorm.DB) repository.FinancialEntryRepository {
+	return &gormFinancialEntryRepository{db: db}
+}
+
+func (r *gormFinancialEntryRepository) Create(entryEntity *entity.FinancialEntry) error {
+	entryGorm := FinancialEntryFromEntity(entryEntity)
+	result := r.db.Create(entryGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	entryEntity.ID = entryGorm.ID
+	entryEntity.CreatedAt = entryGorm.CreatedAt
+	entryEntity.UpdatedAt = entryGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormFinancialEntryRepository) FindByID(id uuid.UUID) (*entity.FinancialEntry, error) {
+	var entryGorm FinancialEntryGormModel
+	result := r.db.First(&entryGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return entryGorm.ToEntity(), nil
+}
+
+func (r *gormFinancialEntryRepository) FindByUserID(userID uuid.UUID, filter repository.FinancialEntryFilter) ([]*entity.FinancialEntry, error) {
+	var entriesGorm []FinancialEntryGormModel
+	query := r.db.Where("user_id = ?", userID)
+
+	if filter.Type != nil {
+		query = query.Where("type = ?", string(*filter.Type))
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("category_id = ?", *filter.CategoryID)
+	}
+	if filter.From != nil {
+		query = query.Where("date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("date < ?", *filter.To)
+	}
+
+	result := query.Order("date desc").Find(&entriesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var entryEntities []*entity.FinancialEntry
+	for _, eg := range entriesGorm {
+		entryEntities = append(entryEntities, eg.ToEntity())
+	}
+	return entryEntities, nil
+}
+
+func (r *gormFinancialEntryRepository) Update(entryEntity *entity.FinancialEntry) error {
+	if entryEntity.ID == uuid.Nil {
+		return errors.New("ID do lançamento não pode ser nulo para atualização")
+	}
+	entryGorm := FinancialEntryFromEntity(entryEntity)
+	// Select("*") para permitir limpar a categoria (valor nulo)
+	result := r.db.Model(&FinancialEntryGormModel{}).Where("id = ?", entryGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(entryGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("lançamento não encontrado para atualização")
+	}
+	return nil
+}
+
+func (r *gormFinancialEntryRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do lançamento não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&FinancialEntryGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("lançamento não encontrado para deleção")
+	}
+	return nil
+}
+
+func (r *gormFinancialEntryRepository) SumExpensesByCategory(userID uuid.UUID, from, to time.Time) (map[uuid.UUID]float64, error) {
+	var rows []struct {
+		CategoryID uuid.UUID
+		Total      float64
+	}
+	result := r.db.Model(&FinancialEntryGormModel{}).
+		Select("category_id, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ? AND type = ? AND category_id IS NOT NULL AND date >= ? AND date < ?",
+			userID, string(entity.FinancialEntryTypeExpense), from, to).
+		Group("category_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	totals := make(map[uuid.UUID]float64, len(rows))
+	for _, row := range rows {
+		totals[row.CategoryID] = row.Total
+	}
+	return totals, nil
+}
+
+// -----------------------------------------------------------------------------
+// ExpenseCategoryGormModel
+// -----------------------------------------------------------------------------
+
+// ExpenseCategoryGormModel representa uma categoria de despesa para o GORM.
+type ExpenseCategoryGormModel struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID        uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name          string         `gorm:"size:100;not null"`
+	MonthlyBudget float64        `gorm:"not null;default:0"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (ExpenseCategoryGormModel) TableName() string {
+	return "expense_categories"
+}
+
+// ToEntity converte um ExpenseCategoryGormModel para uma entidade ExpenseCategory.
+func (m *ExpenseCategoryGormModel) ToEntity() *entity.ExpenseCategory {
+	return &entity.ExpenseCategory{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		Name:          m.Name,
+		MonthlyBudget: m.MonthlyBudget,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// ExpenseCategoryFromEntity converte uma entidade ExpenseCategory para o modelo GORM.
+func ExpenseCategoryFromEntity(e *entity.ExpenseCategory) *ExpenseCategoryGormModel {
+	return &ExpenseCategoryGormModel{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		Name:          e.Name,
+		MonthlyBudget: e.MonthlyBudget,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+type gormExpenseCategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormExpenseCategoryRepository cria uma nova instância do repositório de categorias.
+func NewGormExpenseCategoryRepository(db *gorm.DB) repository.ExpenseCategoryRepository {
+	return &gormExpenseCategoryRepository{db: db}
+}
+
+func (r *gormExpenseCategoryRepository) Create(categoryEntity *entity.ExpenseCategory) error {
+	categoryGorm := ExpenseCategoryFromEntity(categoryEntity)
+	result := r.db.Create(categoryGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	categoryEntity.ID = categoryGorm.ID
+	categoryEntity.CreatedAt = categoryGorm.CreatedAt
+	categoryEntity.UpdatedAt = categoryGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormExpenseCategoryRepository) FindByID(id uuid.UUID) (*entity.ExpenseCategory, error) {
+	var categoryGorm ExpenseCategoryGormModel
+	result := r.db.First(&categoryGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return categoryGorm.ToEntity(), nil
+}
+
+func (r *gormExpenseCategoryRepository) FindByUserID(userID uuid.UUID) ([]*entity.ExpenseCategory, error) {
+	var categoriesGorm []ExpenseCategoryGormModel
+	result := r.db.Where("user_id = ?", userID).Order("name asc").Find(&categoriesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var categoryEntities []*entity.ExpenseCategory
+	for _, cg := range categoriesGorm {
+		categoryEntities = append(categoryEntities, cg.ToEntity())
+	}
+	return categoryEntities, nil
+}
+
+func (r *gormExpenseCategoryRepository) Update(categoryEntity *entity.ExpenseCategory) error {
+	if categoryEntity.ID == uuid.Nil {
+		return errors.New("ID da categoria não pode ser nulo para atualização")
+	}
+	categoryGorm := ExpenseCategoryFromEntity(categoryEntity)
+	// Select("*") para permitir zerar o orçamento
+	result := r.db.Model(&ExpenseCategoryGormModel{}).Where("id = ?", categoryGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(categoryGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("categoria não encontrada para atualização")
+	}
+	return nil
+}
+
+func (r *gormExpenseCategoryRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID da categoria não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&ExpenseCategoryGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("categoria não encontrada para deleção")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// RecurringExpenseGormModel
+// -----------------------------------------------------------------------------
+
+// RecurringExpenseGormModel representa uma despesa recorrente para o GORM.
+type RecurringExpenseGormModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CategoryID  *uuid.UUID `gorm:"type:uuid;index"`
+	Description string     `gorm:"size:255;not null"`
+	Amount      float64    `gorm:"not null"`
+	DayOfMonth  int        `gorm:"not null"`
+	StartDate   time.Time  `gorm:"not null"`
+	EndDate     *time.Time
+	NextDueDate time.Time      `gorm:"not null;index"`
+	Active      bool           `gorm:"not null;default:true"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (RecurringExpenseGormModel) TableName() string {
+	return "recurring_expenses"
+}
+
+// ToEntity converte um RecurringExpenseGormModel para uma entidade RecurringExpense.
+func (m *RecurringExpenseGormModel) ToEntity() *entity.RecurringExpense {
+	return &entity.RecurringExpense{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		CategoryID:  m.CategoryID,
+		Description: m.Description,
+		Amount:      m.Amount,
+		DayOfMonth:  m.DayOfMonth,
+		StartDate:   m.StartDate,
+		EndDate:     m.EndDate,
+		NextDueDate: m.NextDueDate,
+		Active:      m.Active,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// RecurringExpenseFromEntity converte uma entidade RecurringExpense para o modelo GORM.
+func RecurringExpenseFromEntity(e *entity.RecurringExpense) *RecurringExpenseGormModel {
+	return &RecurringExpenseGormModel{
+		ID:          e.ID,
+		UserID:      e.UserID,
+		CategoryID:  e.CategoryID,
+		Description: e.Description,
+		Amount:      e.Amount,
+		DayOfMonth:  e.DayOfMonth,
+		StartDate:   e.StartDate,
+		EndDate:     e.EndDate,
+		NextDueDate: e.NextDueDate,
+		Active:      e.Active,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+type gormRecurringExpenseRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRecurringExpenseRepository cria uma nova instância do repositório de despesas recorrentes.
+func NewGormRecurringExpenseRepository(db *gorm.DB) repository.RecurringExpenseRepository {
+	return &gormRecurringExpenseRepository{db: db}
+}
+
+func (r *gormRecurringExpenseRepository) Create(expenseEntity *entity.RecurringExpense) error {
+	expenseGorm := RecurringExpenseFromEntity(expenseEntity)
+	result := r.db.Create(expenseGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	expenseEntity.ID = expenseGorm.ID
+	expenseEntity.CreatedAt = expenseGorm.CreatedAt
+	expenseEntity.UpdatedAt = expenseGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormRecurringExpenseRepository) FindByID(id uuid.UUID) (*entity.RecurringExpense, error) {
+	var expenseGorm RecurringExpenseGormModel
+	result := r.db.First(&expenseGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return expenseGorm.ToEntity(), nil
+}
+
+// LockByID busca uma despesa recorrente com SELECT ... FOR UPDATE.
+func (r *gormRecurringExpenseRepository) LockByID(id uuid.UUID) (*entity.RecurringExpense, error) {
+	var expenseGorm RecurringExpenseGormModel
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&expenseGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return expenseGorm.ToEntity(), nil
+}
+
+func (r *gormRecurringExpenseRepository) FindByUserID(userID uuid.UUID) ([]*entity.RecurringExpense, error) {
+	var expensesGorm []RecurringExpenseGormModel
+	result := r.db.Where("user_id = ?", userID).Order("day_of_month asc").Find(&expensesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var expenseEntities []*entity.RecurringExpense
+	for _, eg := range expensesGorm {
+		expenseEntities = append(expenseEntities, eg.ToEntity())
+	}
+	return expenseEntities, nil
+}
+
+func (r *gormRecurringExpenseRepository) FindDue(until time.Time) ([]*entity.RecurringExpense, error) {
+	var expensesGorm []RecurringExpenseGormModel
+	result := r.db.Where("active = ? AND next_due_date <= ?", true, until).Find(&expensesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var expenseEntities []*entity.RecurringExpense
+	for _, eg := range expensesGorm {
+		expenseEntities = append(expenseEntities, eg.ToEntity())
+	}
+	return expenseEntities, nil
+}
+
+func (r *gormRecurringExpenseRepository) Update(expenseEntity *entity.RecurringExpense) error {
+	if expenseEntity.ID == uuid.Nil {
+		return errors.New("ID da despesa recorrente não pode ser nulo para atualização")
+	}
+	expenseGorm := RecurringExpenseFromEntity(expenseEntity)
+	// Select("*") para permitir desativar (Active=false) e limpar campos opcionais
+	result := r.db.Model(&RecurringExpenseGormModel{}).Where("id = ?", expenseGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(expenseGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("despesa recorrente não encontrada para atualização")
+	}
+	return nil
+}
+
+func (r *gormRecurringExpenseRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID da despesa recorrente não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&RecurringExpenseGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("despesa recorrente não encontrada para deleção")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// BudgetAlertGormModel
+// -----------------------------------------------------------------------------
+
+// BudgetAlertGormModel representa um alerta de orçamento excedido para o GORM.
+type BudgetAlertGormModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	CategoryID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_budget_alert_category_month"`
+	Month      string    `gorm:"size:7;not null;uniqueIndex:idx_budget_alert_category_month"`
+	Budget     float64   `gorm:"not null"`
+	Spent      float64   `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (BudgetAlertGormModel) TableName() string {
+	return "budget_alerts"
+}
+
+// ToEntity converte um BudgetAlertGormModel para uma entidade BudgetAlert.
+func (m *BudgetAlertGormModel) ToEntity() *entity.BudgetAlert {
+	return &entity.BudgetAlert{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		CategoryID: m.CategoryID,
+		Month:      m.Month,
+		Budget:     m.Budget,
+		Spent:      m.Spent,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// BudgetAlertFromEntity converte uma entidade BudgetAlert para o modelo GORM.
+func BudgetAlertFromEntity(e *entity.BudgetAlert) *BudgetAlertGormModel {
+	return &BudgetAlertGormModel{
+		ID:         e.ID,
+		UserID:     e.UserID,
+		CategoryID: e.CategoryID,
+		Month:      e.Month,
+		Budget:     e.Budget,
+		Spent:      e.Spent,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+type gormBudgetAlertRepository struct {
+	db *gorm.DB
+}
+
+// NewGormBudgetAlertRepository cria uma nova instância do repositório de alertas de orçamento.
+func NewGormBudgetAlertRepository(db *gorm.DB) repository.BudgetAlertRepository {
+	return &gormBudgetAlertRepository{db: db}
+}
+
+func (r *gormBudgetAlertRepository) Create(alertEntity *entity.BudgetAlert) error {
+	alertGorm := BudgetAlertFromEntity(alertEntity)
+	result := r.db.Create(alertGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	alertEntity.ID = alertGorm.ID
+	alertEntity.CreatedAt = alertGorm.CreatedAt
+	return nil
+}
+
+func (r *gormBudgetAlertRepository) FindByUserID(userID uuid.UUID) ([]*entity.BudgetAlert, error) {
+	var alertsGorm []BudgetAlertGormModel
+	result := r.db.Where("user_id = ?", userID).Order("created_at desc").Find(&alertsGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var alertEntities []*entity.BudgetAlert
+	for _, ag := range alertsGorm {
+		alertEntities = append(alertEntities, ag.ToEntity())
+	}
+	return alertEntities, nil
+}
+
+func (r *gormBudgetAlertRepository) FindByCategoryAndMonth(categoryID uuid.UUID, month string) (*entity.BudgetAlert, error) {
+	var alertGorm BudgetAlertGormModel
+	result := r.db.Where("category_id = ? AND month = ?", categoryID, month).First(&alertGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return alertGorm.ToEntity(), nil
+}
+
+// -----------------------------------------------------------------------------
+// IncomeForecastGormModel
+// -----------------------------------------------------------------------------
+
+// IncomeForecastGormModel representa uma receita prevista para o GORM.
+type IncomeForecastGormModel struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index"`
+	QuoteID       *uuid.UUID `gorm:"type:uuid;index"`
+	AppointmentID *uuid.UUID `gorm:"type:uuid;index"`
+	Description   string     `gorm:"size:255"`
+	Amount        float64    `gorm:"not null"`
+	ExpectedDate  time.Time  `gorm:"not null;index"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (IncomeForecastGormModel) TableName() string {
+	return "income_forecasts"
+}
+
+// ToEntity converte um IncomeForecastGormModel para uma entidade IncomeForecast.
+func (m *IncomeForecastGormModel) ToEntity() *entity.IncomeForecast {
+	return &entity.IncomeForecast{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		QuoteID:       m.QuoteID,
+		AppointmentID: m.AppointmentID,
+		Description:   m.Description,
+		Amount:        m.Amount,
+		ExpectedDate:  m.ExpectedDate,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// IncomeForecastFromEntity converte uma entidade IncomeForecast para o modelo GORM.
+func IncomeForecastFromEntity(e *entity.IncomeForecast) *IncomeForecastGormModel {
+	return &IncomeForecastGormModel{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		QuoteID:       e.QuoteID,
+		AppointmentID: e.AppointmentID,
+		Description:   e.Description,
+		Amount:        e.Amount,
+		ExpectedDate:  e.ExpectedDate,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+type gormIncomeForecastRepository struct {
+	db *gorm.DB
+}
+
+// NewGormIncomeForecastRepository cria uma nova instância do repositório de receitas previstas.
+func NewGormIncomeForecastRepository(db *gorm.DB) repository.IncomeForecastRepository {
+	return &gormIncomeForecastRepository{db: db}
+}
+
+func (r *gormIncomeForecastRepository) Create(forecastEntity *entity.IncomeForecast) error {
+	forecastGorm := IncomeForecastFromEntity(forecastEntity)
+	if err := r.db.Create(forecastGorm).Error; err != nil {
+		return err
+	}
+	forecastEntity.ID = forecastGorm.ID
+	forecastEntity.CreatedAt = forecastGorm.CreatedAt
+	return nil
+}
+
+func (r *gormIncomeForecastRepository) FindOpenByUserIDAndPeriod(userID uuid.UUID, from, to time.Time) ([]*entity.IncomeForecast, error) {
+	// Agendamentos concluídos já contam como receita; cancelados ou excluídos não serão realizados.
+	openAppointments := r.db.Model(&AppointmentGormModel{}).
+		Select("id").
+		Where("status IN ?", []string{string(entity.AppointmentStatusPending), string(entity.AppointmentStatusConfirmed)})
+
+	var forecastsGorm []IncomeForecastGormModel
+	result := r.db.
+		Where("user_id = ? AND expected_date >= ? AND expected_date < ?", userID, from, to).
+		Where("appointment_id IS NULL OR appointment_id IN (?)", openAppointments).
+		Order("expected_date asc").
+		Find(&forecastsGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var forecastEntities []*entity.IncomeForecast
+	for _, fg := range forecastsGorm {
+		forecastEntities = append(forecastEntities, fg.ToEntity())
+	}
+	return forecastEntities, nil
+}
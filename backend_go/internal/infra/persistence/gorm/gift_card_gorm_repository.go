@@ -0,0 +1,269 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// GiftCardGormModel
+// -----------------------------------------------------------------------------
+
+// GiftCardGormModel representa um vale-presente para o GORM.
+type GiftCardGormModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_gift_card_user_code"`
+	Code           string     `gorm:"size:30;not null;uniqueIndex:idx_gift_card_user_code"`
+	InitialAmount  float64    `gorm:"not null"`
+	Balance        float64    `gorm:"not null"`
+	PurchaserName  string     `gorm:"size:255"`
+	RecipientName  string     `gorm:"size:255"`
+	RecipientEmail string     `gorm:"size:255"`
+	Message        string     `gorm:"type:text"`
+	Status         string     `gorm:"size:20;not null"`
+	IssuedAt       time.Time  `gorm:"not null"`
+	ExpiresAt      *time.Time `gorm:"index"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (GiftCardGormModel) TableName() string {
+	return "gift_cards"
+}
+
+// ToEntity converte um GiftCardGormModel para uma entidade GiftCard.
+func (m *GiftCardGormModel) ToEntity() *entity.GiftCard {
+	return &entity.GiftCard{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		Code:           m.Code,
+		InitialAmount:  m.InitialAmount,
+		Balance:        m.Balance,
+		PurchaserName:  m.PurchaserName,
+		RecipientName:  m.RecipientName,
+		RecipientEmail: m.RecipientEmail,
+		Message:        m.Message,
+		Status:         entity.GiftCardStatus(m.Status),
+		IssuedAt:       m.IssuedAt,
+		ExpiresAt:      m.ExpiresAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// GiftCardFromEntity converte uma entidade GiftCard para o modelo GORM.
+func GiftCardFromEntity(e *entity.GiftCard) *GiftCardGormModel {
+	return &GiftCardGormModel{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		Code:           e.Code,
+		InitialAmount:  e.InitialAmount,
+		Balance:        e.Balance,
+		PurchaserName:  e.PurchaserName,
+		RecipientName:  e.RecipientName,
+		RecipientEmail: e.RecipientEmail,
+		Message:        e.Message,
+		Status:         string(e.Status),
+		IssuedAt:       e.IssuedAt,
+		ExpiresAt:      e.ExpiresAt,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+type gormGiftCardRepository struct {
+	db *gorm.DB
+}
+
+// NewGormGiftCardRepository cria uma nova instância do repositório de vales-presente.
+func NewGormGiftCardRepository(db *gorm.DB) repository.GiftCardRepository {
+	return &gormGiftCardRepository{db: db}
+}
+
+func (r *gormGiftCardRepository) Create(giftCardEntity *entity.GiftCard) error {
+	giftCardGorm := GiftCardFromEntity(giftCardEntity)
+	if err := r.db.Create(giftCardGorm).Error; err != nil {
+		return err
+	}
+	giftCardEntity.ID = giftCardGorm.ID
+	giftCardEntity.CreatedAt = giftCardGorm.CreatedAt
+	giftCardEntity.UpdatedAt = giftCardGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormGiftCardRepository) FindByID(id uuid.UUID) (*entity.GiftCard, error) {
+	var giftCardGorm GiftCardGormModel
+	result := r.db.First(&giftCardGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return giftCardGorm.ToEntity(), nil
+}
+
+func (r *gormGiftCardRepository) FindByCode(userID uuid.UUID, code string) (*entity.GiftCard, error) {
+	var giftCardGorm GiftCardGormModel
+	result := r.db.Where("user_id = ? AND code = ?", userID, code).First(&giftCardGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return giftCardGorm.ToEntity(), nil
+}
+
+func (r *gormGiftCardRepository) FindByUserID(userID uuid.UUID) ([]*entity.GiftCard, error) {
+	var giftCardsGorm []GiftCardGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("issued_at desc").Find(&giftCardsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var giftCardEntities []*entity.GiftCard
+	for _, gg := range giftCardsGorm {
+		giftCardEntities = append(giftCardEntities, gg.ToEntity())
+	}
+	return giftCardEntities, nil
+}
+
+func (r *gormGiftCardRepository) Update(giftCardEntity *entity.GiftCard) error {
+	if giftCardEntity.ID == uuid.Nil {
+		return errors.New("ID do vale-presente não pode ser nulo para atualização")
+	}
+	giftCardGorm := GiftCardFromEntity(giftCardEntity)
+	// Select("*") para permitir zerar o saldo e limpar a validade
+	result := r.db.Model(&GiftCardGormModel{}).Where("id = ?", giftCardGorm.ID).Select("*").Omit("CreatedAt").Updates(giftCardGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("vale-presente não encontrado para atualização")
+	}
+	return nil
+}
+
+// Debit desconta o saldo em uma única instrução, evitando que dois pagamentos
+// simultâneos usem o mesmo saldo.
+func (r *gormGiftCardRepository) Debit(id uuid.UUID, amount float64) (bool, error) {
+	result := r.db.Model(&GiftCardGormModel{}).
+		Where("id = ? AND balance >= ?", id, amount).
+		Update("balance", gorm.Expr("balance - ?", amount))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *gormGiftCardRepository) Credit(id uuid.UUID, amount float64) (bool, error) {
+	result := r.db.Model(&GiftCardGormModel{}).
+		Where("id = ? AND status = ?", id, string(entity.GiftCardStatusActive)).
+		Update("balance", gorm.Expr("balance + ?", amount))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Cancel cancela o vale em uma única instrução condicional, para que um pagamento
+// simultâneo não debite o saldo que está sendo zerado.
+func (r *gormGiftCardRepository) Cancel(id uuid.UUID, balance float64) (bool, error) {
+	result := r.db.Model(&GiftCardGormModel{}).
+		Where("id = ? AND status = ? AND balance = ?", id, string(entity.GiftCardStatusActive), balance).
+		Updates(map[string]any{
+			"status":  string(entity.GiftCardStatusCancelled),
+			"balance": 0,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// -----------------------------------------------------------------------------
+// GiftCardTransactionGormModel
+// -----------------------------------------------------------------------------
+
+// GiftCardTransactionGormModel representa uma movimentação de vale-presente para o GORM.
+type GiftCardTransactionGormModel struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	GiftCardID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Type         string     `gorm:"size:20;not null"`
+	Amount       float64    `gorm:"not null"`
+	BalanceAfter float64    `gorm:"not null"`
+	PaymentID    *uuid.UUID `gorm:"type:uuid;index"`
+	Description  string     `gorm:"size:255"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (GiftCardTransactionGormModel) TableName() string {
+	return "gift_card_transactions"
+}
+
+// ToEntity converte um GiftCardTransactionGormModel para uma entidade GiftCardTransaction.
+func (m *GiftCardTransactionGormModel) ToEntity() *entity.GiftCardTransaction {
+	return &entity.GiftCardTransaction{
+		ID:           m.ID,
+		GiftCardID:   m.GiftCardID,
+		Type:         entity.GiftCardTransactionType(m.Type),
+		Amount:       m.Amount,
+		BalanceAfter: m.BalanceAfter,
+		PaymentID:    m.PaymentID,
+		Description:  m.Description,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// GiftCardTransactionFromEntity converte uma entidade GiftCardTransaction para o modelo GORM.
+func GiftCardTransactionFromEntity(e *entity.GiftCardTransaction) *GiftCardTransactionGormModel {
+	return &GiftCardTransactionGormModel{
+		ID:           e.ID,
+		GiftCardID:   e.GiftCardID,
+		Type:         string(e.Type),
+		Amount:       e.Amount,
+		BalanceAfter: e.BalanceAfter,
+		PaymentID:    e.PaymentID,
+		Description:  e.Description,
+		CreatedAt:    e.CreatedAt,
+	}
+}
+
+type gormGiftCardTransactionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormGiftCardTransactionRepository cria uma nova instância do repositório de movimentações de vales.
+func NewGormGiftCardTransactionRepository(db *gorm.DB) repository.GiftCardTransactionRepository {
+	return &gormGiftCardTransactionRepository{db: db}
+}
+
+func (r *gormGiftCardTransactionRepository) Create(transactionEntity *entity.GiftCardTransaction) error {
+	transactionGorm := GiftCardTransactionFromEntity(transactionEntity)
+	if err := r.db.Create(transactionGorm).Error; err != nil {
+		return err
+	}
+	transactionEntity.ID = transactionGorm.ID
+	transactionEntity.CreatedAt = transactionGorm.CreatedAt
+	return nil
+}
+
+func (r *gormGiftCardTransactionRepository) FindByGiftCardID(giftCardID uuid.UUID) ([]*entity.GiftCardTransaction, error) {
+	var transactionsGorm []GiftCardTransactionGormModel
+	if err := r.db.Where("gift_card_id = ?", giftCardID).Order("created_at asc").Find(&transactionsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var transactionEntities []*entity.GiftCardTransaction
+	for _, tg := range transactionsGorm {
+		transactionEntities = append(transactionEntities, tg.ToEntity())
+	}
+	return transactionEntities, nil
+}
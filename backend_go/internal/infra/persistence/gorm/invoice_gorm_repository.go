@@ -0,0 +1,316 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// InvoiceGormModel
+// -----------------------------------------------------------------------------
+
+// InvoiceGormModel representa uma NFS-e para o GORM.
+type InvoiceGormModel struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID             uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_invoice_user_rps"`
+	AppointmentID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	Provider           string    `gorm:"size:50;not null"`
+	Status             string    `gorm:"size:20;not null;index"`
+	RPSNumber          int       `gorm:"not null;uniqueIndex:idx_invoice_user_rps"`
+	RPSSeries          string    `gorm:"size:5;not null;uniqueIndex:idx_invoice_user_rps"`
+	Number             string    `gorm:"size:50"`
+	VerificationCode   string    `gorm:"size:50"`
+	Amount             float64   `gorm:"not null"`
+	ISSRate            float64   `gorm:"not null"`
+	ISSAmount          float64   `gorm:"not null"`
+	ServiceDescription string    `gorm:"type:text"`
+	TakerName          string    `gorm:"size:255"`
+	TakerDocument      string    `gorm:"size:14"`
+	TakerEmail         string    `gorm:"size:255"`
+	RequestXML         string    `gorm:"type:text"`
+	ErrorCode          string    `gorm:"size:50"`
+	ErrorMessage       string    `gorm:"type:text"`
+	IssuedAt           *time.Time
+	CancelledAt        *time.Time
+	CancelReason       string    `gorm:"type:text"`
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (InvoiceGormModel) TableName() string {
+	return "invoices"
+}
+
+// ToEntity converte um InvoiceGormModel para uma entidade Invoice.
+func (m *InvoiceGormModel) ToEntity() *entity.Invoice {
+	return &entity.Invoice{
+		ID:                 m.ID,
+		UserID:             m.UserID,
+		AppointmentID:      m.AppointmentID,
+		Provider:           m.Provider,
+		Status:             entity.InvoiceStatus(m.Status),
+		RPSNumber:          m.RPSNumber,
+		RPSSeries:          m.RPSSeries,
+		Number:             m.Number,
+		VerificationCode:   m.VerificationCode,
+		Amount:             m.Amount,
+		ISSRate:            m.ISSRate,
+		ISSAmount:          m.ISSAmount,
+		ServiceDescription: m.ServiceDescription,
+		TakerName:          m.TakerName,
+		TakerDocument:      m.TakerDocument,
+		TakerEmail:         m.TakerEmail,
+		RequestXML:         m.RequestXML,
+		ErrorCode:          m.ErrorCode,
+		ErrorMessage:       m.ErrorMessage,
+		IssuedAt:           m.IssuedAt,
+		CancelledAt:        m.CancelledAt,
+		CancelReason:       m.CancelReason,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// InvoiceFromEntity converte uma entidade Invoice para o modelo GORM.
+func InvoiceFromEntity(e *entity.Invoice) *InvoiceGormModel {
+	return &InvoiceGormModel{
+		ID:                 e.ID,
+		UserID:             e.UserID,
+		AppointmentID:      e.AppointmentID,
+		Provider:           e.Provider,
+		Status:             string(e.Status),
+		RPSNumber:          e.RPSNumber,
+		RPSSeries:          e.RPSSeries,
+		Number:             e.Number,
+		VerificationCode:   e.VerificationCode,
+		Amount:             e.Amount,
+		ISSRate:            e.ISSRate,
+		ISSAmount:          e.ISSAmount,
+		ServiceDescription: e.ServiceDescription,
+		TakerName:          e.TakerName,
+		TakerDocument:      e.TakerDocument,
+		TakerEmail:         e.TakerEmail,
+		RequestXML:         e.RequestXML,
+		ErrorCode:          e.ErrorCode,
+		ErrorMessage:       e.ErrorMessage,
+		IssuedAt:           e.IssuedAt,
+		CancelledAt:        e.CancelledAt,
+		CancelReason:       e.CancelReason,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+type gormInvoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormInvoiceRepository cria uma nova instância do repositório de NFS-e.
+func NewGormInvoiceRepository(db *gorm.DB) repository.InvoiceRepository {
+	return &gormInvoiceRepository{db: db}
+}
+
+func (r *gormInvoiceRepository) Create(invoiceEntity *entity.Invoice) error {
+	invoiceGorm := InvoiceFromEntity(invoiceEntity)
+	if err := r.db.Create(invoiceGorm).Error; err != nil {
+		return err
+	}
+	invoiceEntity.ID = invoiceGorm.ID
+	invoiceEntity.CreatedAt = invoiceGorm.CreatedAt
+	invoiceEntity.UpdatedAt = invoiceGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormInvoiceRepository) FindByID(id uuid.UUID) (*entity.Invoice, error) {
+	var invoiceGorm InvoiceGormModel
+	result := r.db.First(&invoiceGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return invoiceGorm.ToEntity(), nil
+}
+
+func (r *gormInvoiceRepository) FindByUserID(userID uuid.UUID, status entity.InvoiceStatus) ([]*entity.Invoice, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var invoicesGorm []InvoiceGormModel
+	if err := query.Order("created_at desc").Find(&invoicesGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var invoiceEntities []*entity.Invoice
+	for _, ig := range invoicesGorm {
+		invoiceEntities = append(invoiceEntities, ig.ToEntity())
+	}
+	return invoiceEntities, nil
+}
+
+func (r *gormInvoiceRepository) FindActiveByAppointmentID(appointmentID uuid.UUID) (*entity.Invoice, error) {
+	var invoiceGorm InvoiceGormModel
+	result := r.db.
+		Where("appointment_id = ? AND status IN ?", appointmentID,
+			[]string{string(entity.InvoiceStatusPending), string(entity.InvoiceStatusIssued)}).
+		First(&invoiceGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return invoiceGorm.ToEntity(), nil
+}
+
+func (r *gormInvoiceRepository) Update(invoiceEntity *entity.Invoice) error {
+	if invoiceEntity.ID == uuid.Nil {
+		return errors.New("ID da nota não pode ser nulo para atualização")
+	}
+	invoiceGorm := InvoiceFromEntity(invoiceEntity)
+	// Select("*") para permitir limpar o erro de uma tentativa anterior
+	result := r.db.Model(&InvoiceGormModel{}).Where("id = ?", invoiceGorm.ID).Select("*").Omit("CreatedAt").Updates(invoiceGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("nota não encontrada para atualização")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// InvoiceSettingsGormModel
+// -----------------------------------------------------------------------------
+
+// InvoiceSettingsGormModel representa os dados fiscais do prestador para o GORM.
+type InvoiceSettingsGormModel struct {
+	ID                    uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID                uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Document              string    `gorm:"size:14;not null"`
+	MunicipalRegistration string    `gorm:"size:30"`
+	MunicipalityCode      string    `gorm:"size:7;not null"`
+	ServiceItemCode       string    `gorm:"size:10;not null"`
+	ISSRate               float64   `gorm:"not null"`
+	RPSSeries             string    `gorm:"size:5;not null"`
+	NextRPSNumber         int       `gorm:"not null;default:1"`
+	AutoIssue             bool      `gorm:"not null;default:false"`
+	CreatedAt             time.Time `gorm:"autoCreateTime"`
+	UpdatedAt             time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (InvoiceSettingsGormModel) TableName() string {
+	return "invoice_settings"
+}
+
+// ToEntity converte um InvoiceSettingsGormModel para uma entidade InvoiceSettings.
+func (m *InvoiceSettingsGormModel) ToEntity() *entity.InvoiceSettings {
+	return &entity.InvoiceSettings{
+		ID:                    m.ID,
+		UserID:                m.UserID,
+		Document:              m.Document,
+		MunicipalRegistration: m.MunicipalRegistration,
+		MunicipalityCode:      m.MunicipalityCode,
+		ServiceItemCode:       m.ServiceItemCode,
+		ISSRate:               m.ISSRate,
+		RPSSeries:             m.RPSSeries,
+		NextRPSNumber:         m.NextRPSNumber,
+		AutoIssue:             m.AutoIssue,
+		CreatedAt:             m.CreatedAt,
+		UpdatedAt:             m.UpdatedAt,
+	}
+}
+
+// InvoiceSettingsFromEntity converte uma entidade InvoiceSettings para o modelo GORM.
+func InvoiceSettingsFromEntity(e *entity.InvoiceSettings) *InvoiceSettingsGormModel {
+	return &InvoiceSettingsGormModel{
+		ID:                    e.ID,
+		UserID:                e.UserID,
+		Document:              e.Document,
+		MunicipalRegistration: e.MunicipalRegistration,
+		MunicipalityCode:      e.MunicipalityCode,
+		ServiceItemCode:       e.ServiceItemCode,
+		ISSRate:               e.ISSRate,
+		RPSSeries:             e.RPSSeries,
+		NextRPSNumber:         e.NextRPSNumber,
+		AutoIssue:             e.AutoIssue,
+		CreatedAt:             e.CreatedAt,
+		UpdatedAt:             e.UpdatedAt,
+	}
+}
+
+type gormInvoiceSettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewGormInvoiceSettingsRepository cria uma nova instância do repositório de dados fiscais.
+func NewGormInvoiceSettingsRepository(db *gorm.DB) repository.InvoiceSettingsRepository {
+	return &gormInvoiceSettingsRepository{db: db}
+}
+
+func (r *gormInvoiceSettingsRepository) FindByUserID(userID uuid.UUID) (*entity.InvoiceSettings, error) {
+	var settingsGorm InvoiceSettingsGormModel
+	result := r.db.Where("user_id = ?", userID).First(&settingsGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return settingsGorm.ToEntity(), nil
+}
+
+// Save cria os dados fiscais do usuário ou atualiza os existentes. A numeração do RPS
+// só avança: um número menor que o atual é ignorado para não repetir RPS já enviados.
+func (r *gormInvoiceSettingsRepository) Save(settingsEntity *entity.InvoiceSettings) error {
+	var existing InvoiceSettingsGormModel
+	result := r.db.Where("user_id = ?", settingsEntity.UserID).First(&existing)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return result.Error
+	}
+
+	settingsGorm := InvoiceSettingsFromEntity(settingsEntity)
+	if result.Error == nil {
+		settingsGorm.ID = existing.ID
+		settingsGorm.CreatedAt = existing.CreatedAt
+		if settingsGorm.NextRPSNumber < existing.NextRPSNumber {
+			settingsGorm.NextRPSNumber = existing.NextRPSNumber
+		}
+	}
+	if settingsGorm.NextRPSNumber < 1 {
+		settingsGorm.NextRPSNumber = 1
+	}
+	if err := r.db.Save(settingsGorm).Error; err != nil {
+		return err
+	}
+	settingsEntity.ID = settingsGorm.ID
+	settingsEntity.NextRPSNumber = settingsGorm.NextRPSNumber
+	settingsEntity.CreatedAt = settingsGorm.CreatedAt
+	settingsEntity.UpdatedAt = settingsGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormInvoiceSettingsRepository) ReserveRPSNumber(userID uuid.UUID) (int, error) {
+	var reserved []int
+	result := r.db.Raw(
+		"UPDATE invoice_settings SET next_rps_number = next_rps_number + 1, updated_at = ? WHERE user_id = ? RETURNING next_rps_number - 1",
+		time.Now(), userID,
+	).Scan(&reserved)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if len(reserved) == 0 {
+		return 0, errors.New("dados fiscais não configurados")
+	}
+	return reserved[0], nil
+}
@@ -0,0 +1,476 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// -----------------------------------------------------------------------------
+// MembershipPlanGormModel
+// -----------------------------------------------------------------------------
+
+// MembershipPlanGormModel representa um plano de assinatura para o GORM.
+type MembershipPlanGormModel struct {
+	ID                   uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID               uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name                 string         `gorm:"size:150;not null"`
+	Description          string         `gorm:"type:text"`
+	MonthlyPrice         float64        `gorm:"not null"`
+	IncludedServiceIDs   string         `gorm:"type:text"` // UUIDs separados por vírgula
+	IncludedUsesPerCycle int            `gorm:"not null;default:0"`
+	DiscountPercent      float64        `gorm:"not null;default:0"`
+	Active               bool           `gorm:"not null"`
+	CreatedAt            time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt            time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt            gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (MembershipPlanGormModel) TableName() string {
+	return "membership_plans"
+}
+
+// ToEntity converte um MembershipPlanGormModel para uma entidade MembershipPlan.
+func (m *MembershipPlanGormModel) ToEntity() *entity.MembershipPlan {
+	return &entity.MembershipPlan{
+		ID:                   m.ID,
+		UserID:               m.UserID,
+		Name:                 m.Name,
+		Description:          m.Description,
+		MonthlyPrice:         m.MonthlyPrice,
+		IncludedServiceIDs:   splitUUIDs(m.IncludedServiceIDs),
+		IncludedUsesPerCycle: m.IncludedUsesPerCycle,
+		DiscountPercent:      m.DiscountPercent,
+		Active:               m.Active,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
+	}
+}
+
+// MembershipPlanFromEntity converte uma entidade MembershipPlan para o modelo GORM.
+func MembershipPlanFromEntity(e *entity.MembershipPlan) *MembershipPlanGormModel {
+	return &MembershipPlanGormModel{
+		ID:                   e.ID,
+		UserID:               e.UserID,
+		Name:                 e.Name,
+		Description:          e.Description,
+		MonthlyPrice:         e.MonthlyPrice,
+		IncludedServiceIDs:   joinUUIDs(e.IncludedServiceIDs),
+		IncludedUsesPerCycle: e.IncludedUsesPerCycle,
+		DiscountPercent:      e.DiscountPercent,
+		Active:               e.Active,
+		CreatedAt:            e.CreatedAt,
+		UpdatedAt:            e.UpdatedAt,
+	}
+}
+
+type gormMembershipPlanRepository struct {
+	db *gorm.DB
+}
+
+// NewGormMembershipPlanRepository cria uma nova instância do repositório de planos de assinatura.
+func NewGormMembershipPlanRepository(db *gorm.DB) repository.MembershipPlanRepository {
+	return &gormMembershipPlanRepository{db: db}
+}
+
+func (r *gormMembershipPlanRepository) Create(planEntity *entity.MembershipPlan) error {
+	planGorm := MembershipPlanFromEntity(planEntity)
+	if err := r.db.Create(planGorm).Error; err != nil {
+		return err
+	}
+	planEntity.ID = planGorm.ID
+	planEntity.CreatedAt = planGorm.CreatedAt
+	planEntity.UpdatedAt = planGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormMembershipPlanRepository) FindByID(id uuid.UUID) (*entity.MembershipPlan, error) {
+	var planGorm MembershipPlanGormModel
+	result := r.db.First(&planGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return planGorm.ToEntity(), nil
+}
+
+func (r *gormMembershipPlanRepository) FindByUserID(userID uuid.UUID) ([]*entity.MembershipPlan, error) {
+	var plansGorm []MembershipPlanGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("name asc").Find(&plansGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var planEntities []*entity.MembershipPlan
+	for _, pg := range plansGorm {
+		planEntities = append(planEntities, pg.ToEntity())
+	}
+	return planEntities, nil
+}
+
+func (r *gormMembershipPlanRepository) Update(planEntity *entity.MembershipPlan) error {
+	if planEntity.ID == uuid.Nil {
+		return errors.New("ID do plano não pode ser nulo para atualização")
+	}
+	planGorm := MembershipPlanFromEntity(planEntity)
+	// Select("*") para permitir zerar desconto e desativar o plano
+	result := r.db.Model(&MembershipPlanGormModel{}).Where("id = ?", planGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(planGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("plano não encontrado para atualização")
+	}
+	return nil
+}
+
+func (r *gormMembershipPlanRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do plano não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&MembershipPlanGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("plano não encontrado para deleção")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// MembershipSubscriptionGormModel
+// -----------------------------------------------------------------------------
+
+// MembershipSubscriptionGormModel representa a assinatura de um cliente para o GORM.
+type MembershipSubscriptionGormModel struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID            uuid.UUID `gorm:"type:uuid;not null;index"`
+	PlanID            uuid.UUID `gorm:"type:uuid;not null;index"`
+	CustomerID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status            string    `gorm:"size:20;not null;index"`
+	Price             float64   `gorm:"not null"`
+	StartedAt         time.Time `gorm:"not null"`
+	CurrentCycleID    uuid.UUID `gorm:"type:uuid"`
+	CurrentCycleStart time.Time `gorm:"not null"`
+	CurrentCycleEnd   time.Time `gorm:"not null;index"`
+	CancelledAt       *time.Time
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (MembershipSubscriptionGormModel) TableName() string {
+	return "membership_subscriptions"
+}
+
+// ToEntity converte um MembershipSubscriptionGormModel para uma entidade MembershipSubscription.
+func (m *MembershipSubscriptionGormModel) ToEntity() *entity.MembershipSubscription {
+	return &entity.MembershipSubscription{
+		ID:                m.ID,
+		UserID:            m.UserID,
+		PlanID:            m.PlanID,
+		CustomerID:        m.CustomerID,
+		Status:            entity.MembershipStatus(m.Status),
+		Price:             m.Price,
+		StartedAt:         m.StartedAt,
+		CurrentCycleID:    m.CurrentCycleID,
+		CurrentCycleStart: m.CurrentCycleStart,
+		CurrentCycleEnd:   m.CurrentCycleEnd,
+		CancelledAt:       m.CancelledAt,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+}
+
+// MembershipSubscriptionFromEntity converte uma entidade MembershipSubscription para o modelo GORM.
+func MembershipSubscriptionFromEntity(e *entity.MembershipSubscription) *MembershipSubscriptionGormModel {
+	return &MembershipSubscriptionGormModel{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		PlanID:            e.PlanID,
+		CustomerID:        e.CustomerID,
+		Status:            string(e.Status),
+		Price:             e.Price,
+		StartedAt:         e.StartedAt,
+		CurrentCycleID:    e.CurrentCycleID,
+		CurrentCycleStart: e.CurrentCycleStart,
+		CurrentCycleEnd:   e.CurrentCycleEnd,
+		CancelledAt:       e.CancelledAt,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
+
+type gormMembershipSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormMembershipSubscriptionRepository cria uma nova instância do repositório de assinaturas.
+func NewGormMembershipSubscriptionRepository(db *gorm.DB) repository.MembershipSubscriptionRepository {
+	return &gormMembershipSubscriptionRepository{db: db}
+}
+
+func (r *gormMembershipSubscriptionRepository) Create(subEntity *entity.MembershipSubscription) error {
+	subGorm := MembershipSubscriptionFromEntity(subEntity)
+	if err := r.db.Create(subGorm).Error; err != nil {
+		return err
+	}
+	subEntity.ID = subGorm.ID
+	subEntity.CreatedAt = subGorm.CreatedAt
+	subEntity.UpdatedAt = subGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormMembershipSubscriptionRepository) FindByID(id uuid.UUID) (*entity.MembershipSubscription, error) {
+	var subGorm MembershipSubscriptionGormModel
+	result := r.db.First(&subGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return subGorm.ToEntity(), nil
+}
+
+// LockByID busca uma assinatura com SELECT ... FOR UPDATE.
+func (r *gormMembershipSubscriptionRepository) LockByID(id uuid.UUID) (*entity.MembershipSubscription, error) {
+	var subGorm MembershipSubscriptionGormModel
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&subGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return subGorm.ToEntity(), nil
+}
+
+func (r *gormMembershipSubscriptionRepository) FindByCustomerID(customerID uuid.UUID) ([]*entity.MembershipSubscription, error) {
+	var subsGorm []MembershipSubscriptionGormModel
+	if err := r.db.Where("customer_id = ?", customerID).Order("started_at desc").Find(&subsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var subEntities []*entity.MembershipSubscription
+	for _, sg := range subsGorm {
+		subEntities = append(subEntities, sg.ToEntity())
+	}
+	return subEntities, nil
+}
+
+func (r *gormMembershipSubscriptionRepository) CountByPlanID(planID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&MembershipSubscriptionGormModel{}).Where("plan_id = ?", planID).Count(&count).Error
+	return count, err
+}
+
+func (r *gormMembershipSubscriptionRepository) FindDueForRenewal(now time.Time) ([]*entity.MembershipSubscription, error) {
+	var subsGorm []MembershipSubscriptionGormModel
+	result := r.db.Where("status = ? AND current_cycle_end <= ?", string(entity.MembershipStatusActive), now).
+		Order("current_cycle_end asc").
+		Find(&subsGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var subEntities []*entity.MembershipSubscription
+	for _, sg := range subsGorm {
+		subEntities = append(subEntities, sg.ToEntity())
+	}
+	return subEntities, nil
+}
+
+func (r *gormMembershipSubscriptionRepository) Update(subEntity *entity.MembershipSubscription) error {
+	if subEntity.ID == uuid.Nil {
+		return errors.New("ID da assinatura não pode ser nulo para atualização")
+	}
+	subGorm := MembershipSubscriptionFromEntity(subEntity)
+	result := r.db.Model(&MembershipSubscriptionGormModel{}).Where("id = ?", subGorm.ID).Select("*").Omit("CreatedAt").Updates(subGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("assinatura não encontrada para atualização")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// MembershipCycleGormModel
+// -----------------------------------------------------------------------------
+
+// MembershipCycleGormModel representa um ciclo de cobrança para o GORM.
+type MembershipCycleGormModel struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_membership_cycle_start"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	CycleStart     time.Time `gorm:"not null;uniqueIndex:idx_membership_cycle_start"`
+	CycleEnd       time.Time `gorm:"not null"`
+	Amount         float64   `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (MembershipCycleGormModel) TableName() string {
+	return "membership_cycles"
+}
+
+// ToEntity converte um MembershipCycleGormModel para uma entidade MembershipCycle.
+func (m *MembershipCycleGormModel) ToEntity() *entity.MembershipCycle {
+	return &entity.MembershipCycle{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		UserID:         m.UserID,
+		CycleStart:     m.CycleStart,
+		CycleEnd:       m.CycleEnd,
+		Amount:         m.Amount,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// MembershipCycleFromEntity converte uma entidade MembershipCycle para o modelo GORM.
+func MembershipCycleFromEntity(e *entity.MembershipCycle) *MembershipCycleGormModel {
+	return &MembershipCycleGormModel{
+		ID:             e.ID,
+		SubscriptionID: e.SubscriptionID,
+		UserID:         e.UserID,
+		CycleStart:     e.CycleStart,
+		CycleEnd:       e.CycleEnd,
+		Amount:         e.Amount,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+type gormMembershipCycleRepository struct {
+	db *gorm.DB
+}
+
+// NewGormMembershipCycleRepository cria uma nova instância do repositório de ciclos de cobrança.
+func NewGormMembershipCycleRepository(db *gorm.DB) repository.MembershipCycleRepository {
+	return &gormMembershipCycleRepository{db: db}
+}
+
+func (r *gormMembershipCycleRepository) Create(cycleEntity *entity.MembershipCycle) error {
+	cycleGorm := MembershipCycleFromEntity(cycleEntity)
+	if err := r.db.Create(cycleGorm).Error; err != nil {
+		return err
+	}
+	cycleEntity.ID = cycleGorm.ID
+	cycleEntity.CreatedAt = cycleGorm.CreatedAt
+	return nil
+}
+
+func (r *gormMembershipCycleRepository) FindBySubscriptionID(subscriptionID uuid.UUID) ([]*entity.MembershipCycle, error) {
+	var cyclesGorm []MembershipCycleGormModel
+	if err := r.db.Where("subscription_id = ?", subscriptionID).Order("cycle_start desc").Find(&cyclesGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var cycleEntities []*entity.MembershipCycle
+	for _, cg := range cyclesGorm {
+		cycleEntities = append(cycleEntities, cg.ToEntity())
+	}
+	return cycleEntities, nil
+}
+
+// -----------------------------------------------------------------------------
+// MembershipUsageGormModel
+// -----------------------------------------------------------------------------
+
+// MembershipUsageGormModel representa o uso da assinatura por um agendamento para o GORM.
+type MembershipUsageGormModel struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index"`
+	CycleID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	AppointmentID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	ServiceID      uuid.UUID `gorm:"type:uuid;not null"`
+	Kind           string    `gorm:"size:20;not null"`
+	OriginalPrice  float64   `gorm:"not null"`
+	ChargedPrice   float64   `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (MembershipUsageGormModel) TableName() string {
+	return "membership_usages"
+}
+
+// ToEntity converte um MembershipUsageGormModel para uma entidade MembershipUsage.
+func (m *MembershipUsageGormModel) ToEntity() *entity.MembershipUsage {
+	return &entity.MembershipUsage{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		CycleID:        m.CycleID,
+		AppointmentID:  m.AppointmentID,
+		ServiceID:      m.ServiceID,
+		Kind:           entity.MembershipUsageKind(m.Kind),
+		OriginalPrice:  m.OriginalPrice,
+		ChargedPrice:   m.ChargedPrice,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// MembershipUsageFromEntity converte uma entidade MembershipUsage para o modelo GORM.
+func MembershipUsageFromEntity(e *entity.MembershipUsage) *MembershipUsageGormModel {
+	return &MembershipUsageGormModel{
+		ID:             e.ID,
+		SubscriptionID: e.SubscriptionID,
+		CycleID:        e.CycleID,
+		AppointmentID:  e.AppointmentID,
+		ServiceID:      e.ServiceID,
+		Kind:           string(e.Kind),
+		OriginalPrice:  e.OriginalPrice,
+		ChargedPrice:   e.ChargedPrice,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+type gormMembershipUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewGormMembershipUsageRepository cria uma nova instância do repositório de usos da assinatura.
+func NewGormMembershipUsageRepository(db *gorm.DB) repository.MembershipUsageRepository {
+	return &gormMembershipUsageRepository{db: db}
+}
+
+func (r *gormMembershipUsageRepository) Create(usageEntity *entity.MembershipUsage) error {
+	usageGorm := MembershipUsageFromEntity(usageEntity)
+	if err := r.db.Create(usageGorm).Error; err != nil {
+		return err
+	}
+	usageEntity.ID = usageGorm.ID
+	usageEntity.CreatedAt = usageGorm.CreatedAt
+	return nil
+}
+
+// FindByCycleID considera apenas agendamentos existentes e não cancelados, de modo que
+// cancelar ou excluir um agendamento devolve o uso ao ciclo.
+func (r *gormMembershipUsageRepository) FindByCycleID(cycleID uuid.UUID) ([]*entity.MembershipUsage, error) {
+	activeAppointments := r.db.Model(&AppointmentGormModel{}).
+		Select("id").
+		Where("status <> ?", string(entity.AppointmentStatusCancelled))
+
+	var usagesGorm []MembershipUsageGormModel
+	result := r.db.Where("cycle_id = ? AND appointment_id IN (?)", cycleID, activeAppointments).
+		Order("created_at asc").
+		Find(&usagesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var usageEntities []*entity.MembershipUsage
+	for _, ug := range usagesGorm {
+		usageEntities = append(usageEntities, ug.ToEntity())
+	}
+	return usageEntities, nil
+}
@@ -56,15 +56,23 @@ type AppointmentGormModel struct {
 	User              UserGormModel `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // Relacionamento
 	ClientID          *uuid.UUID `gorm:"type:uuid;index"` // Opcional, pode ser nulo
 	ClientUser        *UserGormModel `gorm:"foreignKey:ClientID;constraint:OnUpdate:SET NULL,OnDelete:SET NULL;"` // Relacionamento opcional
+	CustomerID        *uuid.UUID `gorm:"type:uuid;index"` // Cliente do cadastro de clientes (tabela clients)
 	ClientName        string    `gorm:"size:255"`
 	ClientEmail       string    `gorm:"size:255"`
 	ClientPhone       string    `gorm:"size:50"`
 	ServiceDescription string    `gorm:"type:text"`
+	ServiceID         *uuid.UUID `gorm:"type:uuid;index"`
+	ProfessionalID    *uuid.UUID `gorm:"type:uuid;index"`
 	StartTime         time.Time `gorm:"not null;index"`
 	EndTime           time.Time `gorm:"not null"`
 	Status            string    `gorm:"size:50;not null;default:'PENDING'"` // Usando string para status no GORM
 	Notes             string    `gorm:"type:text"`
 	Price             float64
+	OriginalPrice     float64
+	DiscountAmount    float64
+	CouponID          *uuid.UUID `gorm:"type:uuid;index"`
+	Invoiced          bool      `gorm:"not null;default:false"`
+	Sequence          int       `gorm:"not null;default:0"`
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	DeletedAt         gorm.DeletedAt `gorm:"index"`
@@ -76,15 +84,23 @@ func (m *AppointmentGormModel) ToEntity() *entity.Appointment {
 		ID:                m.ID,
 		UserID:            m.UserID,
 		ClientID:          m.ClientID, // Preserva o ponteiro
+		CustomerID:        m.CustomerID,
 		ClientName:        m.ClientName,
 		ClientEmail:       m.ClientEmail,
 		ClientPhone:       m.ClientPhone,
 		ServiceDescription: m.ServiceDescription,
+		ServiceID:         m.ServiceID,
+		ProfessionalID:    m.ProfessionalID,
 		StartTime:         m.StartTime,
 		EndTime:           m.EndTime,
 		Status:            entity.AppointmentStatus(m.Status), // Converte string para o tipo customizado
 		Notes:             m.Notes,
 		Price:             m.Price,
+		OriginalPrice:     m.OriginalPrice,
+		DiscountAmount:    m.DiscountAmount,
+		CouponID:          m.CouponID,
+		Invoiced:          m.Invoiced,
+		Sequence:          m.Sequence,
 		CreatedAt:         m.CreatedAt,
 		UpdatedAt:         m.UpdatedAt,
 	}
@@ -96,15 +112,23 @@ func AppointmentFromEntity(e *entity.Appointment) *AppointmentGormModel {
 		ID:                e.ID, // Se e.ID for uuid.Nil, GORM (com default) irá gerar
 		UserID:            e.UserID,
 		ClientID:          e.ClientID,
+		CustomerID:        e.CustomerID,
 		ClientName:        e.ClientName,
 		ClientEmail:       e.ClientEmail,
 		ClientPhone:       e.ClientPhone,
 		ServiceDescription: e.ServiceDescription,
+		ServiceID:         e.ServiceID,
+		ProfessionalID:    e.ProfessionalID,
 		StartTime:         e.StartTime,
 		EndTime:           e.EndTime,
 		Status:            string(e.Status), // Converte tipo customizado para string
 		Notes:             e.Notes,
 		Price:             e.Price,
+		OriginalPrice:     e.OriginalPrice,
+		DiscountAmount:    e.DiscountAmount,
+		CouponID:          e.CouponID,
+		Invoiced:          e.Invoiced,
+		Sequence:          e.Sequence,
 		CreatedAt:         e.CreatedAt, // GORM pode popular se for zero
 		UpdatedAt:         e.UpdatedAt, // GORM pode popular se for zero
 	}
@@ -0,0 +1,142 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationGormModel representa um aviso da caixa de entrada para o GORM.
+type NotificationGormModel struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_notification_event;index:idx_notification_user_created"`
+	EventID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_notification_event"`
+	Type          string     `gorm:"size:50;not null"`
+	Title         string     `gorm:"size:255;not null"`
+	Body          string     `gorm:"type:text"`
+	AppointmentID *uuid.UUID `gorm:"type:uuid"`
+	ClientID      *uuid.UUID `gorm:"type:uuid"`
+	ReadAt        *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime;index:idx_notification_user_created"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (NotificationGormModel) TableName() string {
+	return "notifications"
+}
+
+// ToEntity converte um NotificationGormModel para uma entidade Notification.
+func (m *NotificationGormModel) ToEntity() *entity.Notification {
+	return &entity.Notification{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		EventID:       m.EventID,
+		Type:          entity.DomainEventType(m.Type),
+		Title:         m.Title,
+		Body:          m.Body,
+		AppointmentID: m.AppointmentID,
+		ClientID:      m.ClientID,
+		ReadAt:        m.ReadAt,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// NotificationFromEntity converte uma entidade Notification para o modelo GORM.
+func NotificationFromEntity(e *entity.Notification) *NotificationGormModel {
+	return &NotificationGormModel{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		EventID:       e.EventID,
+		Type:          string(e.Type),
+		Title:         e.Title,
+		Body:          e.Body,
+		AppointmentID: e.AppointmentID,
+		ClientID:      e.ClientID,
+		ReadAt:        e.ReadAt,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+type gormNotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormNotificationRepository cria uma nova instância do repositório de avisos.
+func NewGormNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &gormNotificationRepository{db: db}
+}
+
+func (r *gormNotificationRepository) CreateIfAbsent(notification *entity.Notification) (bool, error) {
+	notificationGorm := NotificationFromEntity(notification)
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(notificationGorm)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	notification.ID = notificationGorm.ID
+	notification.CreatedAt = notificationGorm.CreatedAt
+	return true, nil
+}
+
+func (r *gormNotificationRepository) FindByID(id uuid.UUID) (*entity.Notification, error) {
+	var notificationGorm NotificationGormModel
+	result := r.db.First(&notificationGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return notificationGorm.ToEntity(), nil
+}
+
+func (r *gormNotificationRepository) userNotifications(userID uuid.UUID, unreadOnly bool) *gorm.DB {
+	query := r.db.Model(&NotificationGormModel{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	return query
+}
+
+func (r *gormNotificationRepository) FindByUserID(userID uuid.UUID, unreadOnly bool, offset, limit int) ([]*entity.Notification, error) {
+	var notificationsGorm []NotificationGormModel
+	err := r.userNotifications(userID, unreadOnly).
+		Order("created_at desc, id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&notificationsGorm).Error
+	if err != nil {
+		return nil, err
+	}
+	var notifications []*entity.Notification
+	for _, ng := range notificationsGorm {
+		notifications = append(notifications, ng.ToEntity())
+	}
+	return notifications, nil
+}
+
+func (r *gormNotificationRepository) CountByUserID(userID uuid.UUID, unreadOnly bool) (int64, error) {
+	var count int64
+	err := r.userNotifications(userID, unreadOnly).Count(&count).Error
+	return count, err
+}
+
+func (r *gormNotificationRepository) MarkRead(id uuid.UUID, readAt time.Time) error {
+	return r.db.Model(&NotificationGormModel{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", readAt).Error
+}
+
+func (r *gormNotificationRepository) MarkAllRead(userID uuid.UUID, readAt time.Time) (int64, error) {
+	result := r.db.Model(&NotificationGormModel{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,369 @@
+package gorm
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// joinUUIDs serializa uma lista de UUIDs separados por vírgula.
+func joinUUIDs(ids []uuid.UUID) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = id.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// splitUUIDs desserializa uma lista de UUIDs separados por vírgula, ignorando valores inválidos.
+func splitUUIDs(s string) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, part := range strings.Split(s, ",") {
+		if id, err := uuid.Parse(strings.TrimSpace(part)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// -----------------------------------------------------------------------------
+// ServicePackageGormModel
+// -----------------------------------------------------------------------------
+
+// ServicePackageGormModel representa um pacote de sessões para o GORM.
+type ServicePackageGormModel struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID       uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name         string         `gorm:"size:150;not null"`
+	Description  string         `gorm:"type:text"`
+	Price        float64        `gorm:"not null"`
+	Credits      int            `gorm:"not null"`
+	ValidityDays int            `gorm:"not null;default:0"`
+	ServiceIDs   string         `gorm:"type:text"` // UUIDs separados por vírgula
+	Active       bool           `gorm:"not null"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (ServicePackageGormModel) TableName() string {
+	return "service_packages"
+}
+
+// ToEntity converte um ServicePackageGormModel para uma entidade ServicePackage.
+func (m *ServicePackageGormModel) ToEntity() *entity.ServicePackage {
+	return &entity.ServicePackage{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		Name:         m.Name,
+		Description:  m.Description,
+		Price:        m.Price,
+		Credits:      m.Credits,
+		ValidityDays: m.ValidityDays,
+		ServiceIDs:   splitUUIDs(m.ServiceIDs),
+		Active:       m.Active,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// ServicePackageFromEntity converte uma entidade ServicePackage para o modelo GORM.
+func ServicePackageFromEntity(e *entity.ServicePackage) *ServicePackageGormModel {
+	return &ServicePackageGormModel{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		Name:         e.Name,
+		Description:  e.Description,
+		Price:        e.Price,
+		Credits:      e.Credits,
+		ValidityDays: e.ValidityDays,
+		ServiceIDs:   joinUUIDs(e.ServiceIDs),
+		Active:       e.Active,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
+
+type gormServicePackageRepository struct {
+	db *gorm.DB
+}
+
+// NewGormServicePackageRepository cria uma nova instância do repositório de pacotes.
+func NewGormServicePackageRepository(db *gorm.DB) repository.ServicePackageRepository {
+	return &gormServicePackageRepository{db: db}
+}
+
+func (r *gormServicePackageRepository) Create(pkgEntity *entity.ServicePackage) error {
+	pkgGorm := ServicePackageFromEntity(pkgEntity)
+	if err := r.db.Create(pkgGorm).Error; err != nil {
+		return err
+	}
+	pkgEntity.ID = pkgGorm.ID
+	pkgEntity.CreatedAt = pkgGorm.CreatedAt
+	pkgEntity.UpdatedAt = pkgGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormServicePackageRepository) FindByID(id uuid.UUID) (*entity.ServicePackage, error) {
+	var pkgGorm ServicePackageGormModel
+	result := r.db.First(&pkgGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return pkgGorm.ToEntity(), nil
+}
+
+func (r *gormServicePackageRepository) FindByUserID(userID uuid.UUID) ([]*entity.ServicePackage, error) {
+	var pkgsGorm []ServicePackageGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("name asc").Find(&pkgsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var pkgEntities []*entity.ServicePackage
+	for _, pg := range pkgsGorm {
+		pkgEntities = append(pkgEntities, pg.ToEntity())
+	}
+	return pkgEntities, nil
+}
+
+func (r *gormServicePackageRepository) Update(pkgEntity *entity.ServicePackage) error {
+	if pkgEntity.ID == uuid.Nil {
+		return errors.New("ID do pacote não pode ser nulo para atualização")
+	}
+	pkgGorm := ServicePackageFromEntity(pkgEntity)
+	// Select("*") para permitir desativar o pacote (Active = false)
+	result := r.db.Model(&ServicePackageGormModel{}).Where("id = ?", pkgGorm.ID).Select("*").Omit("CreatedAt", "DeletedAt").Updates(pkgGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("pacote não encontrado para atualização")
+	}
+	return nil
+}
+
+func (r *gormServicePackageRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do pacote não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&ServicePackageGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("pacote não encontrado para deleção")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// ClientPackageGormModel
+// -----------------------------------------------------------------------------
+
+// ClientPackageGormModel representa um pacote vendido a um cliente para o GORM.
+type ClientPackageGormModel struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	PackageID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CustomerID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name         string     `gorm:"size:150;not null"`
+	ServiceIDs   string     `gorm:"type:text"` // UUIDs separados por vírgula
+	TotalCredits int        `gorm:"not null"`
+	UsedCredits  int        `gorm:"not null;default:0"`
+	Price        float64    `gorm:"not null"`
+	PurchasedAt  time.Time  `gorm:"not null"`
+	ExpiresAt    *time.Time `gorm:"index"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (ClientPackageGormModel) TableName() string {
+	return "client_packages"
+}
+
+// ToEntity converte um ClientPackageGormModel para uma entidade ClientPackage.
+func (m *ClientPackageGormModel) ToEntity() *entity.ClientPackage {
+	return &entity.ClientPackage{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		PackageID:    m.PackageID,
+		CustomerID:   m.CustomerID,
+		Name:         m.Name,
+		ServiceIDs:   splitUUIDs(m.ServiceIDs),
+		TotalCredits: m.TotalCredits,
+		UsedCredits:  m.UsedCredits,
+		Price:        m.Price,
+		PurchasedAt:  m.PurchasedAt,
+		ExpiresAt:    m.ExpiresAt,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// ClientPackageFromEntity converte uma entidade ClientPackage para o modelo GORM.
+func ClientPackageFromEntity(e *entity.ClientPackage) *ClientPackageGormModel {
+	return &ClientPackageGormModel{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		PackageID:    e.PackageID,
+		CustomerID:   e.CustomerID,
+		Name:         e.Name,
+		ServiceIDs:   joinUUIDs(e.ServiceIDs),
+		TotalCredits: e.TotalCredits,
+		UsedCredits:  e.UsedCredits,
+		Price:        e.Price,
+		PurchasedAt:  e.PurchasedAt,
+		ExpiresAt:    e.ExpiresAt,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
+
+type gormClientPackageRepository struct {
+	db *gorm.DB
+}
+
+// NewGormClientPackageRepository cria uma nova instância do repositório de pacotes vendidos.
+func NewGormClientPackageRepository(db *gorm.DB) repository.ClientPackageRepository {
+	return &gormClientPackageRepository{db: db}
+}
+
+func (r *gormClientPackageRepository) Create(cpEntity *entity.ClientPackage) error {
+	cpGorm := ClientPackageFromEntity(cpEntity)
+	if err := r.db.Create(cpGorm).Error; err != nil {
+		return err
+	}
+	cpEntity.ID = cpGorm.ID
+	cpEntity.CreatedAt = cpGorm.CreatedAt
+	cpEntity.UpdatedAt = cpGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormClientPackageRepository) FindByID(id uuid.UUID) (*entity.ClientPackage, error) {
+	var cpGorm ClientPackageGormModel
+	result := r.db.First(&cpGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return cpGorm.ToEntity(), nil
+}
+
+func (r *gormClientPackageRepository) FindByCustomerID(customerID uuid.UUID) ([]*entity.ClientPackage, error) {
+	var cpsGorm []ClientPackageGormModel
+	result := r.db.Where("customer_id = ?", customerID).
+		Order("expires_at asc nulls last, purchased_at asc").
+		Find(&cpsGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var cpEntities []*entity.ClientPackage
+	for _, cg := range cpsGorm {
+		cpEntities = append(cpEntities, cg.ToEntity())
+	}
+	return cpEntities, nil
+}
+
+// ConsumeCredit incrementa o uso de forma atômica, somente se ainda houver saldo.
+func (r *gormClientPackageRepository) ConsumeCredit(id uuid.UUID) (bool, error) {
+	result := r.db.Model(&ClientPackageGormModel{}).
+		Where("id = ? AND used_credits < total_credits", id).
+		Update("used_credits", gorm.Expr("used_credits + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// -----------------------------------------------------------------------------
+// PackageCreditUsageGormModel
+// -----------------------------------------------------------------------------
+
+// PackageCreditUsageGormModel representa o consumo de um crédito para o GORM.
+type PackageCreditUsageGormModel struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ClientPackageID uuid.UUID `gorm:"type:uuid;not null;index"`
+	AppointmentID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	UsedAt          time.Time `gorm:"not null"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (PackageCreditUsageGormModel) TableName() string {
+	return "package_credit_usages"
+}
+
+// ToEntity converte um PackageCreditUsageGormModel para uma entidade PackageCreditUsage.
+func (m *PackageCreditUsageGormModel) ToEntity() *entity.PackageCreditUsage {
+	return &entity.PackageCreditUsage{
+		ID:              m.ID,
+		ClientPackageID: m.ClientPackageID,
+		AppointmentID:   m.AppointmentID,
+		UsedAt:          m.UsedAt,
+	}
+}
+
+// PackageCreditUsageFromEntity converte uma entidade PackageCreditUsage para o modelo GORM.
+func PackageCreditUsageFromEntity(e *entity.PackageCreditUsage) *PackageCreditUsageGormModel {
+	return &PackageCreditUsageGormModel{
+		ID:              e.ID,
+		ClientPackageID: e.ClientPackageID,
+		AppointmentID:   e.AppointmentID,
+		UsedAt:          e.UsedAt,
+	}
+}
+
+type gormPackageCreditUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPackageCreditUsageRepository cria uma nova instância do repositório de consumos de crédito.
+func NewGormPackageCreditUsageRepository(db *gorm.DB) repository.PackageCreditUsageRepository {
+	return &gormPackageCreditUsageRepository{db: db}
+}
+
+func (r *gormPackageCreditUsageRepository) Create(usageEntity *entity.PackageCreditUsage) error {
+	usageGorm := PackageCreditUsageFromEntity(usageEntity)
+	if err := r.db.Create(usageGorm).Error; err != nil {
+		return err
+	}
+	usageEntity.ID = usageGorm.ID
+	return nil
+}
+
+func (r *gormPackageCreditUsageRepository) FindByAppointmentID(appointmentID uuid.UUID) (*entity.PackageCreditUsage, error) {
+	var usageGorm PackageCreditUsageGormModel
+	result := r.db.Where("appointment_id = ?", appointmentID).First(&usageGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return usageGorm.ToEntity(), nil
+}
+
+func (r *gormPackageCreditUsageRepository) FindByClientPackageID(clientPackageID uuid.UUID) ([]*entity.PackageCreditUsage, error) {
+	var usagesGorm []PackageCreditUsageGormModel
+	if err := r.db.Where("client_package_id = ?", clientPackageID).Order("used_at asc").Find(&usagesGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var usageEntities []*entity.PackageCreditUsage
+	for _, ug := range usagesGorm {
+		usageEntities = append(usageEntities, ug.ToEntity())
+	}
+	return usageEntities, nil
+}
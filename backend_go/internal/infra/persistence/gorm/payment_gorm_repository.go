@@ -0,0 +1,295 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentGormModel representa o modelo de pagamento para o GORM.
+type PaymentGormModel struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index"`
+	AppointmentID    *uuid.UUID `gorm:"type:uuid;index"`
+	Amount           float64    `gorm:"not null"`
+	Method           string     `gorm:"size:20;not null"`
+	Status           string     `gorm:"size:20;not null;default:'PENDING'"`
+	Provider         string     `gorm:"size:50;index:idx_payment_provider_charge"`
+	ProviderChargeID string     `gorm:"size:255;index:idx_payment_provider_charge"`
+	PaymentURL       string     `gorm:"type:text"`
+	GiftCardID       *uuid.UUID `gorm:"type:uuid;index"`
+	CheckoutID       *uuid.UUID `gorm:"type:uuid;index"`
+	SaleID           *uuid.UUID `gorm:"type:uuid;index"`
+	PaidAt           *time.Time
+	CreatedAt        time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (PaymentGormModel) TableName() string {
+	return "payments"
+}
+
+// ToEntity converte um PaymentGormModel para uma entidade Payment.
+func (m *PaymentGormModel) ToEntity() *entity.Payment {
+	return &entity.Payment{
+		ID:               m.ID,
+		UserID:           m.UserID,
+		AppointmentID:    m.AppointmentID,
+		Amount:           m.Amount,
+		Method:           entity.PaymentMethod(m.Method),
+		Status:           entity.PaymentStatus(m.Status),
+		Provider:         m.Provider,
+		ProviderChargeID: m.ProviderChargeID,
+		PaymentURL:       m.PaymentURL,
+		GiftCardID:       m.GiftCardID,
+		CheckoutID:       m.CheckoutID,
+		SaleID:           m.SaleID,
+		PaidAt:           m.PaidAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// PaymentFromEntity converte uma entidade Payment para PaymentGormModel.
+func PaymentFromEntity(e *entity.Payment) *PaymentGormModel {
+	return &PaymentGormModel{
+		ID:               e.ID,
+		UserID:           e.UserID,
+		AppointmentID:    e.AppointmentID,
+		Amount:           e.Amount,
+		Method:           string(e.Method),
+		Status:           string(e.Status),
+		Provider:         e.Provider,
+		ProviderChargeID: e.ProviderChargeID,
+		PaymentURL:       e.PaymentURL,
+		GiftCardID:       e.GiftCardID,
+		CheckoutID:       e.CheckoutID,
+		SaleID:           e.SaleID,
+		PaidAt:           e.PaidAt,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+// PaymentWebhookEventGormModel representa um evento de webhook já recebido.
+type PaymentWebhookEventGormModel struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Provider         string     `gorm:"size:50;not null;uniqueIndex:idx_webhook_provider_event"`
+	EventID          string     `gorm:"size:255;not null;uniqueIndex:idx_webhook_provider_event"`
+	EventType        string     `gorm:"size:100"`
+	ProviderChargeID string     `gorm:"size:255"`
+	PaymentID        *uuid.UUID `gorm:"type:uuid;index"`
+	Status           string     `gorm:"size:20;not null"`
+	Error            string     `gorm:"type:text"`
+	Payload          string     `gorm:"type:text"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (PaymentWebhookEventGormModel) TableName() string {
+	return "payment_webhook_events"
+}
+
+// ToEntity converte um PaymentWebhookEventGormModel para uma entidade PaymentWebhookEvent.
+func (m *PaymentWebhookEventGormModel) ToEntity() *entity.PaymentWebhookEvent {
+	return &entity.PaymentWebhookEvent{
+		ID:               m.ID,
+		Provider:         m.Provider,
+		EventID:          m.EventID,
+		EventType:        m.EventType,
+		ProviderChargeID: m.ProviderChargeID,
+		PaymentID:        m.PaymentID,
+		Status:           m.Status,
+		Error:            m.Error,
+		Payload:          m.Payload,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// PaymentWebhookEventFromEntity converte uma entidade PaymentWebhookEvent para o modelo GORM.
+func PaymentWebhookEventFromEntity(e *entity.PaymentWebhookEvent) *PaymentWebhookEventGormModel {
+	return &PaymentWebhookEventGormModel{
+		ID:               e.ID,
+		Provider:         e.Provider,
+		EventID:          e.EventID,
+		EventType:        e.EventType,
+		ProviderChargeID: e.ProviderChargeID,
+		PaymentID:        e.PaymentID,
+		Status:           e.Status,
+		Error:            e.Error,
+		Payload:          e.Payload,
+		CreatedAt:        e.CreatedAt,
+	}
+}
+
+// gormPaymentRepository implementa a interface PaymentRepository usando GORM.
+type gormPaymentRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPaymentRepository cria uma nova instância de gormPaymentRepository.
+func NewGormPaymentRepository(db *gorm.DB) repository.PaymentRepository {
+	return &gormPaymentRepository{db: db}
+}
+
+// Create cria um novo pagamento no banco de dados.
+func (r *gormPaymentRepository) Create(paymentEntity *entity.Payment) error {
+	paymentGorm := PaymentFromEntity(paymentEntity)
+	result := r.db.Create(paymentGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	paymentEntity.ID = paymentGorm.ID
+	paymentEntity.CreatedAt = paymentGorm.CreatedAt
+	paymentEntity.UpdatedAt = paymentGorm.UpdatedAt
+	return nil
+}
+
+// FindByID busca um pagamento pelo seu ID.
+func (r *gormPaymentRepository) FindByID(id uuid.UUID) (*entity.Payment, error) {
+	var paymentGorm PaymentGormModel
+	result := r.db.First(&paymentGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return paymentGorm.ToEntity(), nil
+}
+
+// FindByAppointmentID lista os pagamentos de um agendamento.
+func (r *gormPaymentRepository) FindByAppointmentID(appointmentID uuid.UUID) ([]*entity.Payment, error) {
+	var paymentsGorm []PaymentGormModel
+	result := r.db.Where("appointment_id = ?", appointmentID).Order("created_at asc").Find(&paymentsGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var paymentEntities []*entity.Payment
+	for _, pg := range paymentsGorm {
+		paymentEntities = append(paymentEntities, pg.ToEntity())
+	}
+	return paymentEntities, nil
+}
+
+// FindByProviderChargeID busca o pagamento correspondente a uma cobrança do provedor.
+func (r *gormPaymentRepository) FindByProviderChargeID(provider, chargeID string) (*entity.Payment, error) {
+	var paymentGorm PaymentGormModel
+	result := r.db.Where("provider = ? AND provider_charge_id = ?", provider, chargeID).First(&paymentGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return paymentGorm.ToEntity(), nil
+}
+
+// LockByProviderChargeID busca um pagamento pelo ID da cobrança com SELECT ... FOR UPDATE.
+func (r *gormPaymentRepository) LockByProviderChargeID(provider, chargeID string) (*entity.Payment, error) {
+	var paymentGorm PaymentGormModel
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("provider = ? AND provider_charge_id = ?", provider, chargeID).First(&paymentGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return paymentGorm.ToEntity(), nil
+}
+
+// Update atualiza um pagamento existente.
+func (r *gormPaymentRepository) Update(paymentEntity *entity.Payment) error {
+	if paymentEntity.ID == uuid.Nil {
+		return errors.New("ID do pagamento não pode ser nulo para atualização")
+	}
+	paymentGorm := PaymentFromEntity(paymentEntity)
+	result := r.db.Model(&PaymentGormModel{}).Where("id = ?", paymentGorm.ID).Updates(paymentGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("pagamento não encontrado para atualização ou nenhum dado alterado")
+	}
+	return nil
+}
+
+// SumPaidByMethod soma os pagamentos confirmados em uma forma de pagamento no intervalo [from, to).
+func (r *gormPaymentRepository) SumPaidByMethod(userID uuid.UUID, method entity.PaymentMethod, from, to time.Time) (float64, error) {
+	var total float64
+	err := r.db.Model(&PaymentGormModel{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND method = ? AND status = ? AND paid_at >= ? AND paid_at < ?",
+			userID, string(method), string(entity.PaymentStatusPaid), from, to).
+		Scan(&total).Error
+	return total, err
+}
+
+// gormPaymentWebhookEventRepository implementa PaymentWebhookEventRepository usando GORM.
+type gormPaymentWebhookEventRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPaymentWebhookEventRepository cria uma nova instância de gormPaymentWebhookEventRepository.
+func NewGormPaymentWebhookEventRepository(db *gorm.DB) repository.PaymentWebhookEventRepository {
+	return &gormPaymentWebhookEventRepository{db: db}
+}
+
+// Create registra um evento de webhook. Falha se o par provedor/evento já existir.
+func (r *gormPaymentWebhookEventRepository) Create(eventEntity *entity.PaymentWebhookEvent) error {
+	eventGorm := PaymentWebhookEventFromEntity(eventEntity)
+	result := r.db.Create(eventGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	eventEntity.ID = eventGorm.ID
+	eventEntity.CreatedAt = eventGorm.CreatedAt
+	return nil
+}
+
+// CreateIfAbsent registra um evento de webhook, ignorando o par provedor/evento já existente.
+// Em uma entrega concorrente, o INSERT aguarda a transação da outra entrega terminar.
+func (r *gormPaymentWebhookEventRepository) CreateIfAbsent(eventEntity *entity.PaymentWebhookEvent) (bool, error) {
+	eventGorm := PaymentWebhookEventFromEntity(eventEntity)
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(eventGorm)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	eventEntity.ID = eventGorm.ID
+	eventEntity.CreatedAt = eventGorm.CreatedAt
+	return true, nil
+}
+
+// Update atualiza o resultado do processamento de um evento de webhook.
+func (r *gormPaymentWebhookEventRepository) Update(eventEntity *entity.PaymentWebhookEvent) error {
+	return r.db.Model(&PaymentWebhookEventGormModel{}).Where("id = ?", eventEntity.ID).Updates(map[string]any{
+		"status":     eventEntity.Status,
+		"error":      eventEntity.Error,
+		"payment_id": eventEntity.PaymentID,
+	}).Error
+}
+
+// FindByProviderEventID busca um evento já recebido pelo ID atribuído pelo provedor.
+func (r *gormPaymentWebhookEventRepository) FindByProviderEventID(provider, eventID string) (*entity.PaymentWebhookEvent, error) {
+	var eventGorm PaymentWebhookEventGormModel
+	result := r.db.Where("provider = ? AND event_id = ?", provider, eventID).First(&eventGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return eventGorm.ToEntity(), nil
+}
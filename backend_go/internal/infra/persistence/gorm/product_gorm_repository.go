@@ -0,0 +1,376 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// ProductGormModel
+// -----------------------------------------------------------------------------
+
+// ProductGormModel representa um produto para o GORM.
+type ProductGormModel struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID        uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name          string         `gorm:"size:150;not null"`
+	SKU           string         `gorm:"size:60;index"`
+	Description   string         `gorm:"type:text"`
+	CostPrice     float64        `gorm:"not null;default:0"`
+	SalePrice     float64        `gorm:"not null;default:0"`
+	StockQuantity int            `gorm:"not null;default:0"`
+	MinStock      int            `gorm:"not null;default:0"`
+	Active        bool           `gorm:"not null"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (ProductGormModel) TableName() string {
+	return "products"
+}
+
+// ToEntity converte um ProductGormModel para uma entidade Product.
+func (m *ProductGormModel) ToEntity() *entity.Product {
+	return &entity.Product{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		Name:          m.Name,
+		SKU:           m.SKU,
+		Description:   m.Description,
+		CostPrice:     m.CostPrice,
+		SalePrice:     m.SalePrice,
+		StockQuantity: m.StockQuantity,
+		MinStock:      m.MinStock,
+		Active:        m.Active,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// ProductFromEntity converte uma entidade Product para o modelo GORM.
+func ProductFromEntity(e *entity.Product) *ProductGormModel {
+	return &ProductGormModel{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		Name:          e.Name,
+		SKU:           e.SKU,
+		Description:   e.Description,
+		CostPrice:     e.CostPrice,
+		SalePrice:     e.SalePrice,
+		StockQuantity: e.StockQuantity,
+		MinStock:      e.MinStock,
+		Active:        e.Active,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// StockMovementGormModel
+// -----------------------------------------------------------------------------
+
+// StockMovementGormModel representa uma movimentação de estoque para o GORM.
+type StockMovementGormModel struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ProductID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Type          string     `gorm:"size:20;not null"`
+	Quantity      int        `gorm:"not null"`
+	UnitPrice     float64    `gorm:"not null;default:0"`
+	StockAfter    int        `gorm:"not null"`
+	AppointmentID *uuid.UUID `gorm:"type:uuid;index"`
+	CheckoutID    *uuid.UUID `gorm:"type:uuid;index"`
+	SaleID        *uuid.UUID `gorm:"type:uuid;index"`
+	Notes         string     `gorm:"type:text"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (StockMovementGormModel) TableName() string {
+	return "stock_movements"
+}
+
+// ToEntity converte um StockMovementGormModel para uma entidade StockMovement.
+func (m *StockMovementGormModel) ToEntity() *entity.StockMovement {
+	return &entity.StockMovement{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		ProductID:     m.ProductID,
+		Type:          entity.StockMovementType(m.Type),
+		Quantity:      m.Quantity,
+		UnitPrice:     m.UnitPrice,
+		StockAfter:    m.StockAfter,
+		AppointmentID: m.AppointmentID,
+		CheckoutID:    m.CheckoutID,
+		SaleID:        m.SaleID,
+		Notes:         m.Notes,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// StockMovementFromEntity converte uma entidade StockMovement para o modelo GORM.
+func StockMovementFromEntity(e *entity.StockMovement) *StockMovementGormModel {
+	return &StockMovementGormModel{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		ProductID:     e.ProductID,
+		Type:          string(e.Type),
+		Quantity:      e.Quantity,
+		UnitPrice:     e.UnitPrice,
+		StockAfter:    e.StockAfter,
+		AppointmentID: e.AppointmentID,
+		CheckoutID:    e.CheckoutID,
+		SaleID:        e.SaleID,
+		Notes:         e.Notes,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+// applyStockMovement atualiza o estoque e grava a movimentação usando a conexão
+// informada, para que possa participar de transações de outros repositórios.
+func applyStockMovement(tx *gorm.DB, movement *entity.StockMovement) error {
+	result := tx.Model(&ProductGormModel{}).
+		Where("id = ? AND stock_quantity + ? >= 0", movement.ProductID, movement.Quantity).
+		Update("stock_quantity", gorm.Expr("stock_quantity + ?", movement.Quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("estoque insuficiente ou produto não encontrado")
+	}
+	// A linha fica bloqueada pelo UPDATE até o fim da transação, então a leitura é consistente
+	if err := tx.Model(&ProductGormModel{}).Where("id = ?", movement.ProductID).Select("stock_quantity").Scan(&movement.StockAfter).Error; err != nil {
+		return err
+	}
+
+	movementGorm := StockMovementFromEntity(movement)
+	if err := tx.Create(movementGorm).Error; err != nil {
+		return err
+	}
+	movement.ID = movementGorm.ID
+	movement.CreatedAt = movementGorm.CreatedAt
+	return nil
+}
+
+type gormProductRepository struct {
+	db *gorm.DB
+}
+
+// NewGormProductRepository cria uma nova instância do repositório de produtos.
+func NewGormProductRepository(db *gorm.DB) repository.ProductRepository {
+	return &gormProductRepository{db: db}
+}
+
+func (r *gormProductRepository) Create(productEntity *entity.Product) error {
+	productGorm := ProductFromEntity(productEntity)
+	if err := r.db.Create(productGorm).Error; err != nil {
+		return err
+	}
+	productEntity.ID = productGorm.ID
+	productEntity.CreatedAt = productGorm.CreatedAt
+	productEntity.UpdatedAt = productGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormProductRepository) FindByID(id uuid.UUID) (*entity.Product, error) {
+	var productGorm ProductGormModel
+	result := r.db.First(&productGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return productGorm.ToEntity(), nil
+}
+
+func (r *gormProductRepository) FindByUserID(userID uuid.UUID) ([]*entity.Product, error) {
+	return r.find(r.db.Where("user_id = ?", userID))
+}
+
+func (r *gormProductRepository) FindBySKU(userID uuid.UUID, sku string) (*entity.Product, error) {
+	var productGorm ProductGormModel
+	result := r.db.Where("user_id = ? AND sku = ?", userID, sku).First(&productGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return productGorm.ToEntity(), nil
+}
+
+func (r *gormProductRepository) FindLowStock(userID uuid.UUID) ([]*entity.Product, error) {
+	return r.find(r.db.Where("user_id = ? AND active = ? AND min_stock > 0 AND stock_quantity <= min_stock", userID, true))
+}
+
+// find executa a consulta de produtos ordenando pelo nome.
+func (r *gormProductRepository) find(query *gorm.DB) ([]*entity.Product, error) {
+	var productsGorm []ProductGormModel
+	if err := query.Order("name asc").Find(&productsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var productEntities []*entity.Product
+	for _, pg := range productsGorm {
+		productEntities = append(productEntities, pg.ToEntity())
+	}
+	return productEntities, nil
+}
+
+func (r *gormProductRepository) Update(productEntity *entity.Product) error {
+	if productEntity.ID == uuid.Nil {
+		return errors.New("ID do produto não pode ser nulo para atualização")
+	}
+	productGorm := ProductFromEntity(productEntity)
+	// O estoque é omitido para não sobrescrever movimentações concorrentes
+	result := r.db.Model(&ProductGormModel{}).Where("id = ?", productGorm.ID).Select("*").Omit("StockQuantity", "CreatedAt", "DeletedAt").Updates(productGorm)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("produto não encontrado para atualização")
+	}
+	return nil
+}
+
+func (r *gormProductRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do produto não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&ProductGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("produto não encontrado para deleção")
+	}
+	return nil
+}
+
+func (r *gormProductRepository) ApplyMovement(movement *entity.StockMovement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return applyStockMovement(tx, movement)
+	})
+}
+
+func (r *gormProductRepository) FindMovementsByProductID(productID uuid.UUID) ([]*entity.StockMovement, error) {
+	var movementsGorm []StockMovementGormModel
+	if err := r.db.Where("product_id = ?", productID).Order("created_at desc").Find(&movementsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var movementEntities []*entity.StockMovement
+	for _, mg := range movementsGorm {
+		movementEntities = append(movementEntities, mg.ToEntity())
+	}
+	return movementEntities, nil
+}
+
+// -----------------------------------------------------------------------------
+// LowStockAlertGormModel
+// -----------------------------------------------------------------------------
+
+// LowStockAlertGormModel representa um alerta de estoque baixo para o GORM.
+type LowStockAlertGormModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	ProductID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	StockQuantity int       `gorm:"not null"`
+	MinStock      int       `gorm:"not null"`
+	ResolvedAt    *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (LowStockAlertGormModel) TableName() string {
+	return "low_stock_alerts"
+}
+
+// ToEntity converte um LowStockAlertGormModel para uma entidade LowStockAlert.
+func (m *LowStockAlertGormModel) ToEntity() *entity.LowStockAlert {
+	return &entity.LowStockAlert{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		ProductID:     m.ProductID,
+		StockQuantity: m.StockQuantity,
+		MinStock:      m.MinStock,
+		ResolvedAt:    m.ResolvedAt,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// LowStockAlertFromEntity converte uma entidade LowStockAlert para o modelo GORM.
+func LowStockAlertFromEntity(e *entity.LowStockAlert) *LowStockAlertGormModel {
+	return &LowStockAlertGormModel{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		ProductID:     e.ProductID,
+		StockQuantity: e.StockQuantity,
+		MinStock:      e.MinStock,
+		ResolvedAt:    e.ResolvedAt,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+type gormLowStockAlertRepository struct {
+	db *gorm.DB
+}
+
+// NewGormLowStockAlertRepository cria uma nova instância do repositório de alertas de estoque.
+func NewGormLowStockAlertRepository(db *gorm.DB) repository.LowStockAlertRepository {
+	return &gormLowStockAlertRepository{db: db}
+}
+
+func (r *gormLowStockAlertRepository) Create(alertEntity *entity.LowStockAlert) error {
+	alertGorm := LowStockAlertFromEntity(alertEntity)
+	if err := r.db.Create(alertGorm).Error; err != nil {
+		return err
+	}
+	alertEntity.ID = alertGorm.ID
+	alertEntity.CreatedAt = alertGorm.CreatedAt
+	return nil
+}
+
+func (r *gormLowStockAlertRepository) FindOpenByProductID(productID uuid.UUID) (*entity.LowStockAlert, error) {
+	var alertGorm LowStockAlertGormModel
+	result := r.db.Where("product_id = ? AND resolved_at IS NULL", productID).First(&alertGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return alertGorm.ToEntity(), nil
+}
+
+func (r *gormLowStockAlertRepository) FindByUserID(userID uuid.UUID, openOnly bool) ([]*entity.LowStockAlert, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if openOnly {
+		query = query.Where("resolved_at IS NULL")
+	}
+
+	var alertsGorm []LowStockAlertGormModel
+	if err := query.Order("created_at desc").Find(&alertsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var alertEntities []*entity.LowStockAlert
+	for _, ag := range alertsGorm {
+		alertEntities = append(alertEntities, ag.ToEntity())
+	}
+	return alertEntities, nil
+}
+
+func (r *gormLowStockAlertRepository) ResolveByProductID(productID uuid.UUID) error {
+	return r.db.Model(&LowStockAlertGormModel{}).
+		Where("product_id = ? AND resolved_at IS NULL", productID).
+		Update("resolved_at", time.Now()).Error
+}
@@ -0,0 +1,215 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// -----------------------------------------------------------------------------
+// PushDeviceGormModel
+// -----------------------------------------------------------------------------
+
+// PushDeviceGormModel representa um dispositivo de push para o GORM.
+type PushDeviceGormModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Token      string    `gorm:"size:512;not null;uniqueIndex"`
+	Platform   string    `gorm:"size:20;not null"`
+	DeviceName string    `gorm:"size:100"`
+	AppVersion string    `gorm:"size:50"`
+	LastSeenAt time.Time `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (PushDeviceGormModel) TableName() string {
+	return "push_devices"
+}
+
+// ToEntity converte um PushDeviceGormModel para uma entidade PushDevice.
+func (m *PushDeviceGormModel) ToEntity() *entity.PushDevice {
+	return &entity.PushDevice{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Token:      m.Token,
+		Platform:   entity.PushPlatform(m.Platform),
+		DeviceName: m.DeviceName,
+		AppVersion: m.AppVersion,
+		LastSeenAt: m.LastSeenAt,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// PushDeviceFromEntity converte uma entidade PushDevice para o modelo GORM.
+func PushDeviceFromEntity(e *entity.PushDevice) *PushDeviceGormModel {
+	return &PushDeviceGormModel{
+		ID:         e.ID,
+		UserID:     e.UserID,
+		Token:      e.Token,
+		Platform:   string(e.Platform),
+		DeviceName: e.DeviceName,
+		AppVersion: e.AppVersion,
+		LastSeenAt: e.LastSeenAt,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
+
+type gormPushDeviceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPushDeviceRepository cria uma nova instância do repositório de dispositivos de push.
+func NewGormPushDeviceRepository(db *gorm.DB) repository.PushDeviceRepository {
+	return &gormPushDeviceRepository{db: db}
+}
+
+func (r *gormPushDeviceRepository) Save(device *entity.PushDevice) error {
+	var existing PushDeviceGormModel
+	result := r.db.Where("token = ?", device.Token).First(&existing)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return result.Error
+	}
+
+	deviceGorm := PushDeviceFromEntity(device)
+	if result.Error == nil {
+		deviceGorm.ID = existing.ID
+		deviceGorm.CreatedAt = existing.CreatedAt
+	} else if deviceGorm.ID == uuid.Nil {
+		deviceGorm.ID = uuid.New()
+	}
+	if err := r.db.Save(deviceGorm).Error; err != nil {
+		return err
+	}
+	device.ID = deviceGorm.ID
+	device.CreatedAt = deviceGorm.CreatedAt
+	device.UpdatedAt = deviceGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormPushDeviceRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do dispositivo não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&PushDeviceGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("dispositivo não encontrado para deleção")
+	}
+	return nil
+}
+
+func (r *gormPushDeviceRepository) DeleteByToken(token string) error {
+	return r.db.Delete(&PushDeviceGormModel{}, "token = ?", token).Error
+}
+
+func (r *gormPushDeviceRepository) FindByID(id uuid.UUID) (*entity.PushDevice, error) {
+	var deviceGorm PushDeviceGormModel
+	result := r.db.First(&deviceGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return deviceGorm.ToEntity(), nil
+}
+
+func (r *gormPushDeviceRepository) FindByToken(token string) (*entity.PushDevice, error) {
+	var deviceGorm PushDeviceGormModel
+	result := r.db.First(&deviceGorm, "token = ?", token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return deviceGorm.ToEntity(), nil
+}
+
+func (r *gormPushDeviceRepository) FindByUserID(userID uuid.UUID) ([]*entity.PushDevice, error) {
+	var devicesGorm []PushDeviceGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("last_seen_at desc").Find(&devicesGorm).Error; err != nil {
+		return nil, err
+	}
+	var devices []*entity.PushDevice
+	for _, dg := range devicesGorm {
+		devices = append(devices, dg.ToEntity())
+	}
+	return devices, nil
+}
+
+// -----------------------------------------------------------------------------
+// PushPreferenceGormModel
+// -----------------------------------------------------------------------------
+
+// PushPreferenceGormModel representa uma preferência de push para o GORM.
+type PushPreferenceGormModel struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventType string    `gorm:"size:50;primaryKey"`
+	Enabled   bool      `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (PushPreferenceGormModel) TableName() string {
+	return "push_preferences"
+}
+
+// ToEntity converte um PushPreferenceGormModel para uma entidade PushPreference.
+func (m *PushPreferenceGormModel) ToEntity() *entity.PushPreference {
+	return &entity.PushPreference{
+		UserID:    m.UserID,
+		EventType: entity.DomainEventType(m.EventType),
+		Enabled:   m.Enabled,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+type gormPushPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPushPreferenceRepository cria uma nova instância do repositório de preferências de push.
+func NewGormPushPreferenceRepository(db *gorm.DB) repository.PushPreferenceRepository {
+	return &gormPushPreferenceRepository{db: db}
+}
+
+func (r *gormPushPreferenceRepository) FindByUserID(userID uuid.UUID) ([]*entity.PushPreference, error) {
+	var preferencesGorm []PushPreferenceGormModel
+	if err := r.db.Where("user_id = ?", userID).Find(&preferencesGorm).Error; err != nil {
+		return nil, err
+	}
+	var preferences []*entity.PushPreference
+	for _, pg := range preferencesGorm {
+		preferences = append(preferences, pg.ToEntity())
+	}
+	return preferences, nil
+}
+
+func (r *gormPushPreferenceRepository) Save(preference *entity.PushPreference) error {
+	preferenceGorm := &PushPreferenceGormModel{
+		UserID:    preference.UserID,
+		EventType: string(preference.EventType),
+		Enabled:   preference.Enabled,
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(preferenceGorm).Error
+	if err != nil {
+		return err
+	}
+	preference.UpdatedAt = preferenceGorm.UpdatedAt
+	return nil
+}
@@ -0,0 +1,311 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// QuoteGormModel
+// -----------------------------------------------------------------------------
+
+// QuoteGormModel representa um orçamento para o GORM.
+type QuoteGormModel struct {
+	ID             uuid.UUID            `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID         uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_quote_user_number"`
+	Number         int                  `gorm:"not null;uniqueIndex:idx_quote_user_number"`
+	CustomerID     *uuid.UUID           `gorm:"type:uuid;index"`
+	ClientName     string               `gorm:"size:255"`
+	ClientEmail    string               `gorm:"size:255"`
+	ClientPhone    string               `gorm:"size:50"`
+	Title          string               `gorm:"size:255;not null"`
+	Notes          string               `gorm:"type:text"`
+	Items          []QuoteItemGormModel `gorm:"foreignKey:QuoteID"`
+	ValidUntil     time.Time            `gorm:"not null"`
+	Status         string               `gorm:"size:20;not null;index"`
+	PublicToken    string               `gorm:"size:64;not null;uniqueIndex"`
+	SentAt         *time.Time
+	RespondedAt    *time.Time
+	RejectReason   string `gorm:"type:text"`
+	ConvertedAt    *time.Time
+	AppointmentIDs string         `gorm:"type:text"` // UUIDs separados por vírgula
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (QuoteGormModel) TableName() string {
+	return "quotes"
+}
+
+// ToEntity converte um QuoteGormModel para uma entidade Quote.
+func (m *QuoteGormModel) ToEntity() *entity.Quote {
+	items := make([]entity.QuoteItem, len(m.Items))
+	for i, ig := range m.Items {
+		items[i] = ig.ToEntity()
+	}
+	return &entity.Quote{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		Number:         m.Number,
+		CustomerID:     m.CustomerID,
+		ClientName:     m.ClientName,
+		ClientEmail:    m.ClientEmail,
+		ClientPhone:    m.ClientPhone,
+		Title:          m.Title,
+		Notes:          m.Notes,
+		Items:          items,
+		ValidUntil:     m.ValidUntil,
+		Status:         entity.QuoteStatus(m.Status),
+		PublicToken:    m.PublicToken,
+		SentAt:         m.SentAt,
+		RespondedAt:    m.RespondedAt,
+		RejectReason:   m.RejectReason,
+		ConvertedAt:    m.ConvertedAt,
+		AppointmentIDs: splitUUIDs(m.AppointmentIDs),
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// QuoteFromEntity converte uma entidade Quote para o modelo GORM, sem os itens.
+func QuoteFromEntity(e *entity.Quote) *QuoteGormModel {
+	return &QuoteGormModel{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		Number:         e.Number,
+		CustomerID:     e.CustomerID,
+		ClientName:     e.ClientName,
+		ClientEmail:    e.ClientEmail,
+		ClientPhone:    e.ClientPhone,
+		Title:          e.Title,
+		Notes:          e.Notes,
+		ValidUntil:     e.ValidUntil,
+		Status:         string(e.Status),
+		PublicToken:    e.PublicToken,
+		SentAt:         e.SentAt,
+		RespondedAt:    e.RespondedAt,
+		RejectReason:   e.RejectReason,
+		ConvertedAt:    e.ConvertedAt,
+		AppointmentIDs: joinUUIDs(e.AppointmentIDs),
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+// QuoteItemGormModel representa uma linha de orçamento para o GORM.
+type QuoteItemGormModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	QuoteID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ServiceID   *uuid.UUID `gorm:"type:uuid"`
+	Description string     `gorm:"size:255;not null"`
+	Quantity    int        `gorm:"not null"`
+	UnitPrice   float64    `gorm:"not null"`
+	Position    int        `gorm:"not null"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (QuoteItemGormModel) TableName() string {
+	return "quote_items"
+}
+
+// ToEntity converte um QuoteItemGormModel para uma entidade QuoteItem.
+func (m *QuoteItemGormModel) ToEntity() entity.QuoteItem {
+	return entity.QuoteItem{
+		ID:          m.ID,
+		QuoteID:     m.QuoteID,
+		ServiceID:   m.ServiceID,
+		Description: m.Description,
+		Quantity:    m.Quantity,
+		UnitPrice:   m.UnitPrice,
+		Position:    m.Position,
+	}
+}
+
+// quoteItemsFromEntity converte os itens de um orçamento para o modelo GORM,
+// numerando as posições na ordem recebida.
+func quoteItemsFromEntity(e *entity.Quote) []QuoteItemGormModel {
+	items := make([]QuoteItemGormModel, len(e.Items))
+	for i, item := range e.Items {
+		if item.ID == uuid.Nil {
+			item.ID = uuid.New()
+		}
+		items[i] = QuoteItemGormModel{
+			ID:          item.ID,
+			QuoteID:     e.ID,
+			ServiceID:   item.ServiceID,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Position:    i,
+		}
+	}
+	return items
+}
+
+type gormQuoteRepository struct {
+	db *gorm.DB
+}
+
+// NewGormQuoteRepository cria uma nova instância do repositório de orçamentos.
+func NewGormQuoteRepository(db *gorm.DB) repository.QuoteRepository {
+	return &gormQuoteRepository{db: db}
+}
+
+// withItems carrega os itens do orçamento na ordem de exibição.
+func (r *gormQuoteRepository) withItems() *gorm.DB {
+	return r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	})
+}
+
+func (r *gormQuoteRepository) Create(quoteEntity *entity.Quote) error {
+	quoteGorm := QuoteFromEntity(quoteEntity)
+	items := quoteItemsFromEntity(quoteEntity)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Create(quoteGorm).Error; err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			return tx.Create(&items).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	quoteEntity.ID = quoteGorm.ID
+	quoteEntity.CreatedAt = quoteGorm.CreatedAt
+	quoteEntity.UpdatedAt = quoteGorm.UpdatedAt
+	for i := range items {
+		quoteEntity.Items[i] = items[i].ToEntity()
+	}
+	return nil
+}
+
+func (r *gormQuoteRepository) FindByID(id uuid.UUID) (*entity.Quote, error) {
+	var quoteGorm QuoteGormModel
+	result := r.withItems().First(&quoteGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return quoteGorm.ToEntity(), nil
+}
+
+func (r *gormQuoteRepository) FindByPublicToken(token string) (*entity.Quote, error) {
+	var quoteGorm QuoteGormModel
+	result := r.withItems().Where("public_token = ?", token).First(&quoteGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return quoteGorm.ToEntity(), nil
+}
+
+func (r *gormQuoteRepository) FindByUserID(userID uuid.UUID, status entity.QuoteStatus) ([]*entity.Quote, error) {
+	query := r.withItems().Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var quotesGorm []QuoteGormModel
+	if err := query.Order("number desc").Find(&quotesGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var quoteEntities []*entity.Quote
+	for _, qg := range quotesGorm {
+		quoteEntities = append(quoteEntities, qg.ToEntity())
+	}
+	return quoteEntities, nil
+}
+
+func (r *gormQuoteRepository) Update(quoteEntity *entity.Quote) error {
+	if quoteEntity.ID == uuid.Nil {
+		return errors.New("ID do orçamento não pode ser nulo para atualização")
+	}
+	quoteGorm := QuoteFromEntity(quoteEntity)
+	items := quoteItemsFromEntity(quoteEntity)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Select("*") para permitir limpar campos opcionais como o motivo da recusa
+		result := tx.Model(&QuoteGormModel{}).Where("id = ?", quoteGorm.ID).Select("*").Omit("Items", "CreatedAt", "DeletedAt").Updates(quoteGorm)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("orçamento não encontrado para atualização")
+		}
+		if err := tx.Where("quote_id = ?", quoteGorm.ID).Delete(&QuoteItemGormModel{}).Error; err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			return tx.Create(&items).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		quoteEntity.Items[i] = items[i].ToEntity()
+	}
+	return nil
+}
+
+func (r *gormQuoteRepository) MarkConverted(quoteEntity *entity.Quote) (bool, error) {
+	result := r.db.Model(&QuoteGormModel{}).
+		Where("id = ? AND status = ? AND converted_at IS NULL", quoteEntity.ID, string(entity.QuoteStatusAccepted)).
+		Updates(map[string]any{
+			"converted_at":    quoteEntity.ConvertedAt,
+			"appointment_ids": joinUUIDs(quoteEntity.AppointmentIDs),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *gormQuoteRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID do orçamento não pode ser nulo para deleção")
+	}
+	result := r.db.Delete(&QuoteGormModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("orçamento não encontrado para deleção")
+	}
+	return nil
+}
+
+func (r *gormQuoteRepository) NextNumber(userID uuid.UUID) (int, error) {
+	var maxNumber int
+	// Unscoped para não reutilizar números de orçamentos excluídos
+	err := r.db.Unscoped().Model(&QuoteGormModel{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&maxNumber).Error
+	if err != nil {
+		return 0, err
+	}
+	return maxNumber + 1, nil
+}
+
+func (r *gormQuoteRepository) ExpireSentBefore(date time.Time) (int64, error) {
+	result := r.db.Model(&QuoteGormModel{}).
+		Where("status = ? AND valid_until < ?", string(entity.QuoteStatusSent), date).
+		Update("status", string(entity.QuoteStatusExpired))
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,285 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// -----------------------------------------------------------------------------
+// ReminderRuleGormModel
+// -----------------------------------------------------------------------------
+
+// ReminderRuleGormModel representa uma regra de lembrete para o GORM.
+type ReminderRuleGormModel struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reminder_rule_user_offset_channel"`
+	OffsetMinutes int       `gorm:"not null;uniqueIndex:idx_reminder_rule_user_offset_channel"`
+	Channel       string    `gorm:"size:20;not null;uniqueIndex:idx_reminder_rule_user_offset_channel"`
+	Subject       string    `gorm:"size:255"`
+	Template      string    `gorm:"type:text"`
+	Active        bool      `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (ReminderRuleGormModel) TableName() string {
+	return "reminder_rules"
+}
+
+// ToEntity converte um ReminderRuleGormModel para uma entidade ReminderRule.
+func (m *ReminderRuleGormModel) ToEntity() *entity.ReminderRule {
+	return &entity.ReminderRule{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		OffsetMinutes: m.OffsetMinutes,
+		Channel:       entity.ReminderChannel(m.Channel),
+		Subject:       m.Subject,
+		Template:      m.Template,
+		Active:        m.Active,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// ReminderRuleFromEntity converte uma entidade ReminderRule para o modelo GORM.
+func ReminderRuleFromEntity(e *entity.ReminderRule) *ReminderRuleGormModel {
+	return &ReminderRuleGormModel{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		OffsetMinutes: e.OffsetMinutes,
+		Channel:       string(e.Channel),
+		Subject:       e.Subject,
+		Template:      e.Template,
+		Active:        e.Active,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+type gormReminderRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewGormReminderRuleRepository cria uma nova instância do repositório de regras de lembrete.
+func NewGormReminderRuleRepository(db *gorm.DB) repository.ReminderRuleRepository {
+	return &gormReminderRuleRepository{db: db}
+}
+
+func (r *gormReminderRuleRepository) FindByUserID(userID uuid.UUID) ([]*entity.ReminderRule, error) {
+	var rulesGorm []ReminderRuleGormModel
+	result := r.db.Where("user_id = ?", userID).Order("offset_minutes desc, channel asc").Find(&rulesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var rules []*entity.ReminderRule
+	for _, rg := range rulesGorm {
+		rules = append(rules, rg.ToEntity())
+	}
+	return rules, nil
+}
+
+func (r *gormReminderRuleRepository) ReplaceForUser(userID uuid.UUID, rules []*entity.ReminderRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&ReminderRuleGormModel{}).Error; err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			ruleGorm := ReminderRuleFromEntity(rule)
+			if err := tx.Create(ruleGorm).Error; err != nil {
+				return err
+			}
+			rule.ID = ruleGorm.ID
+			rule.CreatedAt = ruleGorm.CreatedAt
+			rule.UpdatedAt = ruleGorm.UpdatedAt
+		}
+		return nil
+	})
+}
+
+// -----------------------------------------------------------------------------
+// ReminderGormModel
+// -----------------------------------------------------------------------------
+
+// ReminderGormModel representa um lembrete agendado para o GORM.
+type ReminderGormModel struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID            uuid.UUID `gorm:"type:uuid;not null;index"`
+	AppointmentID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Channel           string    `gorm:"size:20;not null"`
+	OffsetMinutes     int       `gorm:"not null"`
+	Recipient         string    `gorm:"size:255;not null"`
+	ScheduledFor      time.Time `gorm:"not null;index:idx_reminder_due,priority:2"`
+	Status            string    `gorm:"size:20;not null;index:idx_reminder_due,priority:1"`
+	Attempts          int       `gorm:"not null;default:0"`
+	Subject           string    `gorm:"size:255"`
+	Message           string    `gorm:"type:text"`
+	ProviderMessageID string    `gorm:"size:255;index"`
+	LastError         string    `gorm:"type:text"`
+	SentAt            *time.Time
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (ReminderGormModel) TableName() string {
+	return "reminders"
+}
+
+// ToEntity converte um ReminderGormModel para uma entidade Reminder.
+func (m *ReminderGormModel) ToEntity() *entity.Reminder {
+	return &entity.Reminder{
+		ID:                m.ID,
+		UserID:            m.UserID,
+		AppointmentID:     m.AppointmentID,
+		Channel:           entity.ReminderChannel(m.Channel),
+		OffsetMinutes:     m.OffsetMinutes,
+		Recipient:         m.Recipient,
+		ScheduledFor:      m.ScheduledFor,
+		Status:            entity.ReminderStatus(m.Status),
+		Attempts:          m.Attempts,
+		Subject:           m.Subject,
+		Message:           m.Message,
+		ProviderMessageID: m.ProviderMessageID,
+		LastError:         m.LastError,
+		SentAt:            m.SentAt,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+}
+
+// ReminderFromEntity converte uma entidade Reminder para o modelo GORM.
+func ReminderFromEntity(e *entity.Reminder) *ReminderGormModel {
+	return &ReminderGormModel{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		AppointmentID:     e.AppointmentID,
+		Channel:           string(e.Channel),
+		OffsetMinutes:     e.OffsetMinutes,
+		Recipient:         e.Recipient,
+		ScheduledFor:      e.ScheduledFor,
+		Status:            string(e.Status),
+		Attempts:          e.Attempts,
+		Subject:           e.Subject,
+		Message:           e.Message,
+		ProviderMessageID: e.ProviderMessageID,
+		LastError:         e.LastError,
+		SentAt:            e.SentAt,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
+
+type gormReminderRepository struct {
+	db *gorm.DB
+}
+
+// NewGormReminderRepository cria uma nova instância do repositório de lembretes.
+func NewGormReminderRepository(db *gorm.DB) repository.ReminderRepository {
+	return &gormReminderRepository{db: db}
+}
+
+func (r *gormReminderRepository) Create(reminder *entity.Reminder) error {
+	reminderGorm := ReminderFromEntity(reminder)
+	if err := r.db.Create(reminderGorm).Error; err != nil {
+		return err
+	}
+	reminder.ID = reminderGorm.ID
+	reminder.CreatedAt = reminderGorm.CreatedAt
+	reminder.UpdatedAt = reminderGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormReminderRepository) Update(reminder *entity.Reminder) error {
+	reminderGorm := ReminderFromEntity(reminder)
+	if err := r.db.Save(reminderGorm).Error; err != nil {
+		return err
+	}
+	reminder.UpdatedAt = reminderGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormReminderRepository) FindByAppointmentID(appointmentID uuid.UUID) ([]*entity.Reminder, error) {
+	var remindersGorm []ReminderGormModel
+	result := r.db.Where("appointment_id = ?", appointmentID).Order("scheduled_for asc").Find(&remindersGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toReminderEntities(remindersGorm), nil
+}
+
+func (r *gormReminderRepository) FindByProviderMessageID(providerMessageID string) (*entity.Reminder, error) {
+	var reminderGorm ReminderGormModel
+	result := r.db.First(&reminderGorm, "provider_message_id = ?", providerMessageID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return reminderGorm.ToEntity(), nil
+}
+
+func (r *gormReminderRepository) FindSentByPhoneSuffix(channel entity.ReminderChannel, phoneSuffix string) ([]*entity.Reminder, error) {
+	var remindersGorm []ReminderGormModel
+	result := r.db.Where("channel = ? AND status = ? AND regexp_replace(recipient, '[^0-9]', '', 'g') LIKE ?",
+		string(channel), string(entity.ReminderStatusSent), "%"+phoneSuffix).
+		Order("scheduled_for desc").
+		Find(&remindersGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toReminderEntities(remindersGorm), nil
+}
+
+func (r *gormReminderRepository) FindByUserID(userID uuid.UUID, appointmentID *uuid.UUID, status entity.ReminderStatus) ([]*entity.Reminder, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if appointmentID != nil {
+		query = query.Where("appointment_id = ?", *appointmentID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var remindersGorm []ReminderGormModel
+	if err := query.Order("scheduled_for desc").Find(&remindersGorm).Error; err != nil {
+		return nil, err
+	}
+	return toReminderEntities(remindersGorm), nil
+}
+
+func (r *gormReminderRepository) FindDue(now time.Time, limit int) ([]*entity.Reminder, error) {
+	var remindersGorm []ReminderGormModel
+	result := r.db.Where("status = ? AND scheduled_for <= ?", string(entity.ReminderStatusScheduled), now).
+		Order("scheduled_for asc").
+		Limit(limit).
+		Find(&remindersGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toReminderEntities(remindersGorm), nil
+}
+
+func (r *gormReminderRepository) Claim(id uuid.UUID, now, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&ReminderGormModel{}).
+		Where("id = ? AND status = ? AND scheduled_for <= ?", id, string(entity.ReminderStatusScheduled), now).
+		Update("scheduled_for", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func toReminderEntities(remindersGorm []ReminderGormModel) []*entity.Reminder {
+	var reminders []*entity.Reminder
+	for _, rg := range remindersGorm {
+		reminders = append(reminders, rg.ToEntity())
+	}
+	return reminders
+}
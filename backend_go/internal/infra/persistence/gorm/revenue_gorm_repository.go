@@ -0,0 +1,95 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// gormRevenueRepository calcula agregados de faturamento diretamente no banco.
+type gormRevenueRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRevenueRepository cria uma nova instância do repositório de faturamento.
+func NewGormRevenueRepository(db *gorm.DB) repository.RevenueRepository {
+	return &gormRevenueRepository{db: db}
+}
+
+// SumRevenue soma o preço dos agendamentos concluídos e as entradas avulsas no intervalo [from, to).
+// Vendas de vale-presente ficam de fora: a receita é reconhecida no atendimento pago com o vale.
+func (r *gormRevenueRepository) SumRevenue(userID uuid.UUID, from, to time.Time) (float64, error) {
+	var appointmentsTotal float64
+	err := r.db.Model(&AppointmentGormModel{}).
+		Select("COALESCE(SUM(price), 0)").
+		Where("user_id = ? AND status = ? AND start_time >= ? AND start_time < ?",
+			userID, string(entity.AppointmentStatusCompleted), from, to).
+		Scan(&appointmentsTotal).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var entriesTotal float64
+	err = r.db.Model(&FinancialEntryGormModel{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND type = ? AND appointment_id IS NULL AND gift_card_id IS NULL AND date >= ? AND date < ?",
+			userID, string(entity.FinancialEntryTypeIncome), from, to).
+		Scan(&entriesTotal).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return appointmentsTotal + entriesTotal, nil
+}
+
+// revenueBucketRow recebe o resultado das consultas agregadas de RevenueByMonth.
+type revenueBucketRow struct {
+	Month    int
+	Kind     string
+	Invoiced bool
+	Amount   float64
+}
+
+// RevenueByMonth agrega o faturamento no intervalo [from, to) por mês, natureza e nota fiscal.
+// Entradas sem natureza informada são consideradas prestação de serviços.
+func (r *gormRevenueRepository) RevenueByMonth(userID uuid.UUID, from, to time.Time) ([]repository.RevenueBucket, error) {
+	var appointmentRows []revenueBucketRow
+	err := r.db.Model(&AppointmentGormModel{}).
+		Select("CAST(EXTRACT(MONTH FROM start_time) AS INTEGER) AS month, invoiced, COALESCE(SUM(price), 0) AS amount").
+		Where("user_id = ? AND status = ? AND start_time >= ? AND start_time < ?",
+			userID, string(entity.AppointmentStatusCompleted), from, to).
+		Group("month, invoiced").
+		Scan(&appointmentRows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var entryRows []revenueBucketRow
+	err = r.db.Model(&FinancialEntryGormModel{}).
+		Select("CAST(EXTRACT(MONTH FROM date) AS INTEGER) AS month, revenue_kind AS kind, invoiced, COALESCE(SUM(amount), 0) AS amount").
+		Where("user_id = ? AND type = ? AND appointment_id IS NULL AND gift_card_id IS NULL AND date >= ? AND date < ?",
+			userID, string(entity.FinancialEntryTypeIncome), from, to).
+		Group("month, revenue_kind, invoiced").
+		Scan(&entryRows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]repository.RevenueBucket, 0, len(appointmentRows)+len(entryRows))
+	for _, row := range append(appointmentRows, entryRows...) {
+		kind := entity.RevenueKind(row.Kind)
+		if kind == "" {
+			kind = entity.RevenueKindServices
+		}
+		buckets = append(buckets, repository.RevenueBucket{
+			Month:    time.Month(row.Month),
+			Kind:     kind,
+			Invoiced: row.Invoiced,
+			Amount:   row.Amount,
+		})
+	}
+	return buckets, nil
+}
@@ -0,0 +1,376 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SaleGormModel representa uma venda de balcão para o GORM.
+type SaleGormModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_sale_user_number"`
+	Number         int        `gorm:"not null;uniqueIndex:idx_sale_user_number"`
+	ClientID       *uuid.UUID `gorm:"type:uuid;index"`
+	ProfessionalID *uuid.UUID `gorm:"type:uuid;index"`
+	CashRegisterID *uuid.UUID `gorm:"type:uuid;index"`
+	Total          float64    `gorm:"not null"`
+	Notes          string     `gorm:"type:text"`
+	SoldAt         time.Time  `gorm:"not null;index"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (SaleGormModel) TableName() string {
+	return "sales"
+}
+
+// ToEntity converte um SaleGormModel para uma entidade Sale (sem itens e pagamentos).
+func (m *SaleGormModel) ToEntity() *entity.Sale {
+	return &entity.Sale{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		Number:         m.Number,
+		ClientID:       m.ClientID,
+		ProfessionalID: m.ProfessionalID,
+		CashRegisterID: m.CashRegisterID,
+		Total:          m.Total,
+		Notes:          m.Notes,
+		SoldAt:         m.SoldAt,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// SaleFromEntity converte uma entidade Sale para SaleGormModel.
+func SaleFromEntity(e *entity.Sale) *SaleGormModel {
+	return &SaleGormModel{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		Number:         e.Number,
+		ClientID:       e.ClientID,
+		ProfessionalID: e.ProfessionalID,
+		CashRegisterID: e.CashRegisterID,
+		Total:          e.Total,
+		Notes:          e.Notes,
+		SoldAt:         e.SoldAt,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+// SaleItemGormModel representa um item de venda de balcão para o GORM.
+type SaleItemGormModel struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	SaleID          uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Kind            string     `gorm:"size:20;not null"`
+	ProductID       *uuid.UUID `gorm:"type:uuid;index"`
+	ServiceID       *uuid.UUID `gorm:"type:uuid"`
+	PackageID       *uuid.UUID `gorm:"type:uuid"`
+	ClientPackageID *uuid.UUID `gorm:"type:uuid"`
+	Description     string     `gorm:"size:255;not null"`
+	Quantity        int        `gorm:"not null"`
+	UnitPrice       float64    `gorm:"not null"`
+	Position        int        `gorm:"not null;default:0"` // Ordem do item na venda
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (SaleItemGormModel) TableName() string {
+	return "sale_items"
+}
+
+// ToEntity converte um SaleItemGormModel para uma entidade SaleItem.
+func (m *SaleItemGormModel) ToEntity() entity.SaleItem {
+	return entity.SaleItem{
+		ID:              m.ID,
+		SaleID:          m.SaleID,
+		Kind:            entity.SaleItemKind(m.Kind),
+		ProductID:       m.ProductID,
+		ServiceID:       m.ServiceID,
+		PackageID:       m.PackageID,
+		ClientPackageID: m.ClientPackageID,
+		Description:     m.Description,
+		Quantity:        m.Quantity,
+		UnitPrice:       m.UnitPrice,
+	}
+}
+
+// SaleItemFromEntity converte uma entidade SaleItem para o modelo GORM.
+func SaleItemFromEntity(e entity.SaleItem, position int) *SaleItemGormModel {
+	return &SaleItemGormModel{
+		ID:              e.ID,
+		SaleID:          e.SaleID,
+		Kind:            string(e.Kind),
+		ProductID:       e.ProductID,
+		ServiceID:       e.ServiceID,
+		PackageID:       e.PackageID,
+		ClientPackageID: e.ClientPackageID,
+		Description:     e.Description,
+		Quantity:        e.Quantity,
+		UnitPrice:       e.UnitPrice,
+		Position:        position,
+	}
+}
+
+type gormSaleRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSaleRepository cria uma nova instância do repositório de vendas de balcão.
+func NewGormSaleRepository(db *gorm.DB) repository.SaleRepository {
+	return &gormSaleRepository{db: db}
+}
+
+func (r *gormSaleRepository) Create(saleEntity *entity.Sale, clientPackages []*entity.ClientPackage, movements []*entity.StockMovement, entries []*entity.FinancialEntry, events []*entity.DomainEvent) error {
+	saleGorm := SaleFromEntity(saleEntity)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(saleGorm).Error; err != nil {
+			return err
+		}
+		for _, cp := range clientPackages {
+			if err := tx.Create(ClientPackageFromEntity(cp)).Error; err != nil {
+				return err
+			}
+		}
+		for i, item := range saleEntity.Items {
+			if err := tx.Create(SaleItemFromEntity(item, i)).Error; err != nil {
+				return err
+			}
+		}
+		for _, m := range movements {
+			if err := applyStockMovement(tx, m); err != nil {
+				return err
+			}
+		}
+		for _, p := range saleEntity.Payments {
+			if err := tx.Create(PaymentFromEntity(p)).Error; err != nil {
+				return err
+			}
+		}
+		for _, e := range entries {
+			if err := tx.Create(FinancialEntryFromEntity(e)).Error; err != nil {
+				return err
+			}
+		}
+		return appendDomainEvents(tx, events)
+	})
+	if err != nil {
+		return err
+	}
+	saleEntity.CreatedAt = saleGorm.CreatedAt
+	return nil
+}
+
+func (r *gormSaleRepository) FindByID(id uuid.UUID) (*entity.Sale, error) {
+	var saleGorm SaleGormModel
+	result := r.db.First(&saleGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	sales, err := r.loadDetails([]SaleGormModel{saleGorm})
+	if err != nil {
+		return nil, err
+	}
+	return sales[0], nil
+}
+
+func (r *gormSaleRepository) FindByUserID(userID uuid.UUID, from, to time.Time) ([]*entity.Sale, error) {
+	var salesGorm []SaleGormModel
+	result := r.db.Where("user_id = ? AND sold_at >= ? AND sold_at < ?", userID, from, to).
+		Order("sold_at desc").Find(&salesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.loadDetails(salesGorm)
+}
+
+// loadDetails carrega os itens e pagamentos das vendas informadas.
+func (r *gormSaleRepository) loadDetails(salesGorm []SaleGormModel) ([]*entity.Sale, error) {
+	if len(salesGorm) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, len(salesGorm))
+	for i, sg := range salesGorm {
+		ids[i] = sg.ID
+	}
+
+	var itemsGorm []SaleItemGormModel
+	if err := r.db.Where("sale_id IN ?", ids).Order("position asc").Find(&itemsGorm).Error; err != nil {
+		return nil, err
+	}
+	var paymentsGorm []PaymentGormModel
+	if err := r.db.Where("sale_id IN ?", ids).Order("created_at asc").Find(&paymentsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	sales := make([]*entity.Sale, len(salesGorm))
+	byID := make(map[uuid.UUID]*entity.Sale, len(salesGorm))
+	for i := range salesGorm {
+		sales[i] = salesGorm[i].ToEntity()
+		byID[sales[i].ID] = sales[i]
+	}
+	for _, ig := range itemsGorm {
+		sale := byID[ig.SaleID]
+		sale.Items = append(sale.Items, ig.ToEntity())
+	}
+	for _, pg := range paymentsGorm {
+		sale := byID[*pg.SaleID]
+		sale.Payments = append(sale.Payments, pg.ToEntity())
+	}
+	return sales, nil
+}
+
+func (r *gormSaleRepository) NextNumber(userID uuid.UUID) (int, error) {
+	var maxNumber int
+	err := r.db.Model(&SaleGormModel{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&maxNumber).Error
+	if err != nil {
+		return 0, err
+	}
+	return maxNumber + 1, nil
+}
+
+// CashRegisterGormModel representa um caixa para o GORM.
+type CashRegisterGormModel struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_cash_register_open,where:status = 'OPEN'"`
+	Status         string     `gorm:"size:20;not null;default:'OPEN'"`
+	OpeningAmount  float64    `gorm:"not null;default:0"`
+	CashReceived   float64    `gorm:"not null;default:0"`
+	ExpectedAmount float64    `gorm:"not null;default:0"`
+	CountedAmount  *float64
+	Difference     *float64
+	OpenedAt       time.Time `gorm:"not null"`
+	ClosedAt       *time.Time
+	Notes          string    `gorm:"type:text"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (CashRegisterGormModel) TableName() string {
+	return "cash_registers"
+}
+
+// ToEntity converte um CashRegisterGormModel para uma entidade CashRegister.
+func (m *CashRegisterGormModel) ToEntity() *entity.CashRegister {
+	return &entity.CashRegister{
+		ID:             m.ID,
+		UserID:         m.UserID,
+		Status:         entity.CashRegisterStatus(m.Status),
+		OpeningAmount:  m.OpeningAmount,
+		CashReceived:   m.CashReceived,
+		ExpectedAmount: m.ExpectedAmount,
+		CountedAmount:  m.CountedAmount,
+		Difference:     m.Difference,
+		OpenedAt:       m.OpenedAt,
+		ClosedAt:       m.ClosedAt,
+		Notes:          m.Notes,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// CashRegisterFromEntity converte uma entidade CashRegister para CashRegisterGormModel.
+func CashRegisterFromEntity(e *entity.CashRegister) *CashRegisterGormModel {
+	return &CashRegisterGormModel{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		Status:         string(e.Status),
+		OpeningAmount:  e.OpeningAmount,
+		CashReceived:   e.CashReceived,
+		ExpectedAmount: e.ExpectedAmount,
+		CountedAmount:  e.CountedAmount,
+		Difference:     e.Difference,
+		OpenedAt:       e.OpenedAt,
+		ClosedAt:       e.ClosedAt,
+		Notes:          e.Notes,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+type gormCashRegisterRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCashRegisterRepository cria uma nova instância do repositório de caixas.
+func NewGormCashRegisterRepository(db *gorm.DB) repository.CashRegisterRepository {
+	return &gormCashRegisterRepository{db: db}
+}
+
+func (r *gormCashRegisterRepository) Create(registerEntity *entity.CashRegister) error {
+	registerGorm := CashRegisterFromEntity(registerEntity)
+	if err := r.db.Create(registerGorm).Error; err != nil {
+		return err
+	}
+	registerEntity.ID = registerGorm.ID
+	registerEntity.CreatedAt = registerGorm.CreatedAt
+	registerEntity.UpdatedAt = registerGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormCashRegisterRepository) FindByID(id uuid.UUID) (*entity.CashRegister, error) {
+	var registerGorm CashRegisterGormModel
+	result := r.db.First(&registerGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return registerGorm.ToEntity(), nil
+}
+
+func (r *gormCashRegisterRepository) FindOpenByUserID(userID uuid.UUID) (*entity.CashRegister, error) {
+	var registerGorm CashRegisterGormModel
+	result := r.db.Where("user_id = ? AND status = ?", userID, string(entity.CashRegisterStatusOpen)).First(&registerGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return registerGorm.ToEntity(), nil
+}
+
+func (r *gormCashRegisterRepository) FindByUserID(userID uuid.UUID) ([]*entity.CashRegister, error) {
+	var registersGorm []CashRegisterGormModel
+	result := r.db.Where("user_id = ?", userID).Order("opened_at desc").Find(&registersGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var registers []*entity.CashRegister
+	for _, rg := range registersGorm {
+		registers = append(registers, rg.ToEntity())
+	}
+	return registers, nil
+}
+
+func (r *gormCashRegisterRepository) Close(registerEntity *entity.CashRegister) (bool, error) {
+	// Fecha apenas se ainda estiver aberto, evitando fechamentos em dobro
+	result := r.db.Model(&CashRegisterGormModel{}).
+		Where("id = ? AND status = ?", registerEntity.ID, string(entity.CashRegisterStatusOpen)).
+		Updates(map[string]interface{}{
+			"status":          string(entity.CashRegisterStatusClosed),
+			"cash_received":   registerEntity.CashReceived,
+			"expected_amount": registerEntity.ExpectedAmount,
+			"counted_amount":  registerEntity.CountedAmount,
+			"difference":      registerEntity.Difference,
+			"closed_at":       registerEntity.ClosedAt,
+			"notes":           registerEntity.Notes,
+			"updated_at":      time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
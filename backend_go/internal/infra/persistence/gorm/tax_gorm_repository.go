@@ -0,0 +1,207 @@
+package gorm
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaxProfileGormModel representa o perfil tributário para o GORM.
+type TaxProfileGormModel struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID             uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Regime             string    `gorm:"size:30;not null"`
+	AnnualRevenueLimit float64   `gorm:"not null;default:0"`
+	AlertThresholds    string    `gorm:"size:100"` // Percentuais separados por vírgula (ex: "70,90,100")
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (TaxProfileGormModel) TableName() string {
+	return "tax_profiles"
+}
+
+// ToEntity converte um TaxProfileGormModel para uma entidade TaxProfile.
+func (m *TaxProfileGormModel) ToEntity() *entity.TaxProfile {
+	var thresholds []int
+	for _, part := range strings.Split(m.AlertThresholds, ",") {
+		if value, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			thresholds = append(thresholds, value)
+		}
+	}
+	return &entity.TaxProfile{
+		ID:                 m.ID,
+		UserID:             m.UserID,
+		Regime:             entity.TaxRegime(m.Regime),
+		AnnualRevenueLimit: m.AnnualRevenueLimit,
+		AlertThresholds:    thresholds,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// TaxProfileFromEntity converte uma entidade TaxProfile para o modelo GORM.
+func TaxProfileFromEntity(e *entity.TaxProfile) *TaxProfileGormModel {
+	parts := make([]string, len(e.AlertThresholds))
+	for i, threshold := range e.AlertThresholds {
+		parts[i] = strconv.Itoa(threshold)
+	}
+	return &TaxProfileGormModel{
+		ID:                 e.ID,
+		UserID:             e.UserID,
+		Regime:             string(e.Regime),
+		AnnualRevenueLimit: e.AnnualRevenueLimit,
+		AlertThresholds:    strings.Join(parts, ","),
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+type gormTaxProfileRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTaxProfileRepository cria uma nova instância do repositório de perfis tributários.
+func NewGormTaxProfileRepository(db *gorm.DB) repository.TaxProfileRepository {
+	return &gormTaxProfileRepository{db: db}
+}
+
+// Save cria o perfil do usuário ou atualiza o existente.
+func (r *gormTaxProfileRepository) Save(profileEntity *entity.TaxProfile) error {
+	var existing TaxProfileGormModel
+	result := r.db.Where("user_id = ?", profileEntity.UserID).First(&existing)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return result.Error
+	}
+
+	profileGorm := TaxProfileFromEntity(profileEntity)
+	if result.Error == nil {
+		profileGorm.ID = existing.ID
+		profileGorm.CreatedAt = existing.CreatedAt
+	}
+	if err := r.db.Save(profileGorm).Error; err != nil {
+		return err
+	}
+	profileEntity.ID = profileGorm.ID
+	profileEntity.CreatedAt = profileGorm.CreatedAt
+	profileEntity.UpdatedAt = profileGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormTaxProfileRepository) FindByUserID(userID uuid.UUID) (*entity.TaxProfile, error) {
+	var profileGorm TaxProfileGormModel
+	result := r.db.Where("user_id = ?", userID).First(&profileGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return profileGorm.ToEntity(), nil
+}
+
+func (r *gormTaxProfileRepository) FindAll() ([]*entity.TaxProfile, error) {
+	var profilesGorm []TaxProfileGormModel
+	if err := r.db.Find(&profilesGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var profileEntities []*entity.TaxProfile
+	for _, pg := range profilesGorm {
+		profileEntities = append(profileEntities, pg.ToEntity())
+	}
+	return profileEntities, nil
+}
+
+// RevenueLimitAlertGormModel representa um alerta de teto de faturamento para o GORM.
+type RevenueLimitAlertGormModel struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_revenue_alert_user_year_threshold"`
+	Year      int       `gorm:"not null;uniqueIndex:idx_revenue_alert_user_year_threshold"`
+	Threshold int       `gorm:"not null;uniqueIndex:idx_revenue_alert_user_year_threshold"`
+	Revenue   float64   `gorm:"not null"`
+	Limit     float64   `gorm:"column:revenue_limit;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (RevenueLimitAlertGormModel) TableName() string {
+	return "revenue_limit_alerts"
+}
+
+// ToEntity converte um RevenueLimitAlertGormModel para uma entidade RevenueLimitAlert.
+func (m *RevenueLimitAlertGormModel) ToEntity() *entity.RevenueLimitAlert {
+	return &entity.RevenueLimitAlert{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Year:      m.Year,
+		Threshold: m.Threshold,
+		Revenue:   m.Revenue,
+		Limit:     m.Limit,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// RevenueLimitAlertFromEntity converte uma entidade RevenueLimitAlert para o modelo GORM.
+func RevenueLimitAlertFromEntity(e *entity.RevenueLimitAlert) *RevenueLimitAlertGormModel {
+	return &RevenueLimitAlertGormModel{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Year:      e.Year,
+		Threshold: e.Threshold,
+		Revenue:   e.Revenue,
+		Limit:     e.Limit,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+type gormRevenueLimitAlertRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRevenueLimitAlertRepository cria uma nova instância do repositório de alertas de teto.
+func NewGormRevenueLimitAlertRepository(db *gorm.DB) repository.RevenueLimitAlertRepository {
+	return &gormRevenueLimitAlertRepository{db: db}
+}
+
+func (r *gormRevenueLimitAlertRepository) Create(alertEntity *entity.RevenueLimitAlert) error {
+	alertGorm := RevenueLimitAlertFromEntity(alertEntity)
+	if err := r.db.Create(alertGorm).Error; err != nil {
+		return err
+	}
+	alertEntity.ID = alertGorm.ID
+	alertEntity.CreatedAt = alertGorm.CreatedAt
+	return nil
+}
+
+func (r *gormRevenueLimitAlertRepository) FindByUserID(userID uuid.UUID) ([]*entity.RevenueLimitAlert, error) {
+	var alertsGorm []RevenueLimitAlertGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("created_at desc").Find(&alertsGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var alertEntities []*entity.RevenueLimitAlert
+	for _, ag := range alertsGorm {
+		alertEntities = append(alertEntities, ag.ToEntity())
+	}
+	return alertEntities, nil
+}
+
+func (r *gormRevenueLimitAlertRepository) FindByUserYearThreshold(userID uuid.UUID, year, threshold int) (*entity.RevenueLimitAlert, error) {
+	var alertGorm RevenueLimitAlertGormModel
+	result := r.db.Where("user_id = ? AND year = ? AND threshold = ?", userID, year, threshold).First(&alertGorm)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return alertGorm.ToEntity(), nil
+}
@@ -0,0 +1,122 @@
+package gorm
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"gorm.io/gorm"
+)
+
+// gormTransaction expõe os repositórios ligados à transação em andamento.
+type gormTransaction struct {
+	tx *gorm.DB
+}
+
+func (t *gormTransaction) Appointments() repository.AppointmentRepository {
+	return NewGormAppointmentRepository(t.tx)
+}
+
+func (t *gormTransaction) Clients() repository.ClientRepository {
+	return NewGormClientRepository(t.tx)
+}
+
+func (t *gormTransaction) Payments() repository.PaymentRepository {
+	return NewGormPaymentRepository(t.tx)
+}
+
+func (t *gormTransaction) PaymentWebhookEvents() repository.PaymentWebhookEventRepository {
+	return NewGormPaymentWebhookEventRepository(t.tx)
+}
+
+func (t *gormTransaction) FinancialEntries() repository.FinancialEntryRepository {
+	return NewGormFinancialEntryRepository(t.tx)
+}
+
+func (t *gormTransaction) RecurringExpenses() repository.RecurringExpenseRepository {
+	return NewGormRecurringExpenseRepository(t.tx)
+}
+
+func (t *gormTransaction) ClientPackages() repository.ClientPackageRepository {
+	return NewGormClientPackageRepository(t.tx)
+}
+
+func (t *gormTransaction) PackageCreditUsages() repository.PackageCreditUsageRepository {
+	return NewGormPackageCreditUsageRepository(t.tx)
+}
+
+func (t *gormTransaction) Commissions() repository.CommissionRepository {
+	return NewGormCommissionRepository(t.tx)
+}
+
+func (t *gormTransaction) MembershipSubscriptions() repository.MembershipSubscriptionRepository {
+	return NewGormMembershipSubscriptionRepository(t.tx)
+}
+
+func (t *gormTransaction) MembershipCycles() repository.MembershipCycleRepository {
+	return NewGormMembershipCycleRepository(t.tx)
+}
+
+func (t *gormTransaction) MembershipUsages() repository.MembershipUsageRepository {
+	return NewGormMembershipUsageRepository(t.tx)
+}
+
+func (t *gormTransaction) Checkouts() repository.CheckoutRepository {
+	return NewGormCheckoutRepository(t.tx)
+}
+
+func (t *gormTransaction) Coupons() repository.CouponRepository {
+	return NewGormCouponRepository(t.tx)
+}
+
+func (t *gormTransaction) GiftCards() repository.GiftCardRepository {
+	return NewGormGiftCardRepository(t.tx)
+}
+
+func (t *gormTransaction) GiftCardTransactions() repository.GiftCardTransactionRepository {
+	return NewGormGiftCardTransactionRepository(t.tx)
+}
+
+func (t *gormTransaction) Quotes() repository.QuoteRepository {
+	return NewGormQuoteRepository(t.tx)
+}
+
+func (t *gormTransaction) IncomeForecasts() repository.IncomeForecastRepository {
+	return NewGormIncomeForecastRepository(t.tx)
+}
+
+func (t *gormTransaction) CouponRedemptions() repository.CouponRedemptionRepository {
+	return NewGormCouponRedemptionRepository(t.tx)
+}
+
+func (t *gormTransaction) Events() repository.DomainEventRepository {
+	return NewGormDomainEventRepository(t.tx)
+}
+
+// GormUnitOfWork implementa repository.UnitOfWork com as transações do GORM.
+type GormUnitOfWork struct {
+	db          *gorm.DB
+	afterCommit []func()
+}
+
+// NewGormUnitOfWork cria uma nova instância de GormUnitOfWork.
+func NewGormUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// AfterCommit registra uma função chamada depois de cada transação confirmada (ex: para
+// acordar o distribuidor de eventos sem esperar o próximo ciclo).
+func (u *GormUnitOfWork) AfterCommit(fn func()) {
+	u.afterCommit = append(u.afterCommit, fn)
+}
+
+// Do executa fn em uma transação.
+func (u *GormUnitOfWork) Do(fn func(tx repository.Transaction) error) error {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&gormTransaction{tx: tx})
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range u.afterCommit {
+		hook()
+	}
+	return nil
+}
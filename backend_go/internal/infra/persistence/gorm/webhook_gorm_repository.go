@@ -0,0 +1,412 @@
+package gorm
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// -----------------------------------------------------------------------------
+// WebhookSubscriptionGormModel
+// -----------------------------------------------------------------------------
+
+// WebhookSubscriptionGormModel representa uma assinatura de webhook para o GORM.
+type WebhookSubscriptionGormModel struct {
+	ID                  uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID              uuid.UUID `gorm:"type:uuid;not null;index"`
+	URL                 string    `gorm:"size:2048;not null"`
+	Description         string    `gorm:"size:255"`
+	EventTypes          string    `gorm:"type:text"` // Tipos separados por vírgula; vazio: todos
+	Secret              string    `gorm:"size:100;not null"`
+	Active              bool      `gorm:"not null"`
+	ConsecutiveFailures int       `gorm:"not null;default:0"`
+	DisabledAt          *time.Time
+	DisabledReason      string    `gorm:"size:255"`
+	CreatedAt           time.Time `gorm:"autoCreateTime"`
+	UpdatedAt           time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (WebhookSubscriptionGormModel) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// ToEntity converte um WebhookSubscriptionGormModel para uma entidade WebhookSubscription.
+func (m *WebhookSubscriptionGormModel) ToEntity() *entity.WebhookSubscription {
+	var eventTypes []entity.DomainEventType
+	for _, part := range strings.Split(m.EventTypes, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			eventTypes = append(eventTypes, entity.DomainEventType(part))
+		}
+	}
+	return &entity.WebhookSubscription{
+		ID:                  m.ID,
+		UserID:              m.UserID,
+		URL:                 m.URL,
+		Description:         m.Description,
+		EventTypes:          eventTypes,
+		Secret:              m.Secret,
+		Active:              m.Active,
+		ConsecutiveFailures: m.ConsecutiveFailures,
+		DisabledAt:          m.DisabledAt,
+		DisabledReason:      m.DisabledReason,
+		CreatedAt:           m.CreatedAt,
+		UpdatedAt:           m.UpdatedAt,
+	}
+}
+
+// WebhookSubscriptionFromEntity converte uma entidade WebhookSubscription para o modelo GORM.
+func WebhookSubscriptionFromEntity(e *entity.WebhookSubscription) *WebhookSubscriptionGormModel {
+	parts := make([]string, len(e.EventTypes))
+	for i, t := range e.EventTypes {
+		parts[i] = string(t)
+	}
+	return &WebhookSubscriptionGormModel{
+		ID:                  e.ID,
+		UserID:              e.UserID,
+		URL:                 e.URL,
+		Description:         e.Description,
+		EventTypes:          strings.Join(parts, ","),
+		Secret:              e.Secret,
+		Active:              e.Active,
+		ConsecutiveFailures: e.ConsecutiveFailures,
+		DisabledAt:          e.DisabledAt,
+		DisabledReason:      e.DisabledReason,
+		CreatedAt:           e.CreatedAt,
+		UpdatedAt:           e.UpdatedAt,
+	}
+}
+
+type gormWebhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWebhookSubscriptionRepository cria uma nova instância do repositório de assinaturas de webhook.
+func NewGormWebhookSubscriptionRepository(db *gorm.DB) repository.WebhookSubscriptionRepository {
+	return &gormWebhookSubscriptionRepository{db: db}
+}
+
+func (r *gormWebhookSubscriptionRepository) Create(subscription *entity.WebhookSubscription) error {
+	subscriptionGorm := WebhookSubscriptionFromEntity(subscription)
+	if err := r.db.Create(subscriptionGorm).Error; err != nil {
+		return err
+	}
+	subscription.ID = subscriptionGorm.ID
+	subscription.CreatedAt = subscriptionGorm.CreatedAt
+	subscription.UpdatedAt = subscriptionGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormWebhookSubscriptionRepository) Update(subscription *entity.WebhookSubscription) error {
+	subscriptionGorm := WebhookSubscriptionFromEntity(subscription)
+	if err := r.db.Save(subscriptionGorm).Error; err != nil {
+		return err
+	}
+	subscription.UpdatedAt = subscriptionGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormWebhookSubscriptionRepository) Delete(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID da assinatura não pode ser nulo para deleção")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		deliveryIDs := tx.Model(&WebhookDeliveryGormModel{}).Select("id").Where("subscription_id = ?", id)
+		if err := tx.Where("delivery_id IN (?)", deliveryIDs).Delete(&WebhookAttemptGormModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("subscription_id = ?", id).Delete(&WebhookDeliveryGormModel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&WebhookSubscriptionGormModel{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("assinatura não encontrada para deleção")
+		}
+		return nil
+	})
+}
+
+func (r *gormWebhookSubscriptionRepository) FindByID(id uuid.UUID) (*entity.WebhookSubscription, error) {
+	var subscriptionGorm WebhookSubscriptionGormModel
+	result := r.db.First(&subscriptionGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return subscriptionGorm.ToEntity(), nil
+}
+
+func (r *gormWebhookSubscriptionRepository) FindByUserID(userID uuid.UUID) ([]*entity.WebhookSubscription, error) {
+	var subscriptionsGorm []WebhookSubscriptionGormModel
+	if err := r.db.Where("user_id = ?", userID).Order("created_at asc").Find(&subscriptionsGorm).Error; err != nil {
+		return nil, err
+	}
+	return toWebhookSubscriptionEntities(subscriptionsGorm), nil
+}
+
+func (r *gormWebhookSubscriptionRepository) FindActiveByUserID(userID uuid.UUID) ([]*entity.WebhookSubscription, error) {
+	var subscriptionsGorm []WebhookSubscriptionGormModel
+	if err := r.db.Where("user_id = ? AND active = ?", userID, true).Order("created_at asc").Find(&subscriptionsGorm).Error; err != nil {
+		return nil, err
+	}
+	return toWebhookSubscriptionEntities(subscriptionsGorm), nil
+}
+
+func toWebhookSubscriptionEntities(subscriptionsGorm []WebhookSubscriptionGormModel) []*entity.WebhookSubscription {
+	var subscriptions []*entity.WebhookSubscription
+	for _, sg := range subscriptionsGorm {
+		subscriptions = append(subscriptions, sg.ToEntity())
+	}
+	return subscriptions
+}
+
+// -----------------------------------------------------------------------------
+// WebhookDeliveryGormModel
+// -----------------------------------------------------------------------------
+
+// WebhookDeliveryGormModel representa o envio de um evento a uma assinatura para o GORM.
+// O índice único parcial garante um único envio original por evento e assinatura, mesmo
+// que o evento seja entregue mais de uma vez pelo distribuidor.
+type WebhookDeliveryGormModel struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_webhook_delivery_event,priority:1,where:redelivery_of IS NULL"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	EventID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_event,priority:2,where:redelivery_of IS NULL"`
+	EventType      string    `gorm:"size:50;not null"`
+	Body           string    `gorm:"type:text;not null"`
+	Status         string    `gorm:"size:20;not null;index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int       `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time `gorm:"not null;index:idx_webhook_delivery_due,priority:2"`
+	LastStatusCode int       `gorm:"not null;default:0"`
+	LastError      string    `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	RedeliveryOf   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (WebhookDeliveryGormModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+// ToEntity converte um WebhookDeliveryGormModel para uma entidade WebhookDelivery.
+func (m *WebhookDeliveryGormModel) ToEntity() *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		UserID:         m.UserID,
+		EventID:        m.EventID,
+		EventType:      entity.DomainEventType(m.EventType),
+		Body:           m.Body,
+		Status:         entity.WebhookDeliveryStatus(m.Status),
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastStatusCode: m.LastStatusCode,
+		LastError:      m.LastError,
+		DeliveredAt:    m.DeliveredAt,
+		RedeliveryOf:   m.RedeliveryOf,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// WebhookDeliveryFromEntity converte uma entidade WebhookDelivery para o modelo GORM.
+func WebhookDeliveryFromEntity(e *entity.WebhookDelivery) *WebhookDeliveryGormModel {
+	return &WebhookDeliveryGormModel{
+		ID:             e.ID,
+		SubscriptionID: e.SubscriptionID,
+		UserID:         e.UserID,
+		EventID:        e.EventID,
+		EventType:      string(e.EventType),
+		Body:           e.Body,
+		Status:         string(e.Status),
+		Attempts:       e.Attempts,
+		NextAttemptAt:  e.NextAttemptAt,
+		LastStatusCode: e.LastStatusCode,
+		LastError:      e.LastError,
+		DeliveredAt:    e.DeliveredAt,
+		RedeliveryOf:   e.RedeliveryOf,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+// WebhookAttemptGormModel representa uma tentativa de envio de webhook para o GORM.
+type WebhookAttemptGormModel struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	DeliveryID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	AttemptedAt  time.Time `gorm:"not null"`
+	StatusCode   int       `gorm:"not null;default:0"`
+	ResponseBody string    `gorm:"type:text"`
+	Error        string    `gorm:"type:text"`
+	DurationMs   int64     `gorm:"not null;default:0"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (WebhookAttemptGormModel) TableName() string {
+	return "webhook_attempts"
+}
+
+// ToEntity converte um WebhookAttemptGormModel para uma entidade WebhookAttempt.
+func (m *WebhookAttemptGormModel) ToEntity() *entity.WebhookAttempt {
+	return &entity.WebhookAttempt{
+		ID:           m.ID,
+		DeliveryID:   m.DeliveryID,
+		AttemptedAt:  m.AttemptedAt,
+		StatusCode:   m.StatusCode,
+		ResponseBody: m.ResponseBody,
+		Error:        m.Error,
+		DurationMs:   m.DurationMs,
+	}
+}
+
+// WebhookAttemptFromEntity converte uma entidade WebhookAttempt para o modelo GORM.
+func WebhookAttemptFromEntity(e *entity.WebhookAttempt) *WebhookAttemptGormModel {
+	return &WebhookAttemptGormModel{
+		ID:           e.ID,
+		DeliveryID:   e.DeliveryID,
+		AttemptedAt:  e.AttemptedAt,
+		StatusCode:   e.StatusCode,
+		ResponseBody: e.ResponseBody,
+		Error:        e.Error,
+		DurationMs:   e.DurationMs,
+	}
+}
+
+type gormWebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWebhookDeliveryRepository cria uma nova instância do repositório de envios de webhook.
+func NewGormWebhookDeliveryRepository(db *gorm.DB) repository.WebhookDeliveryRepository {
+	return &gormWebhookDeliveryRepository{db: db}
+}
+
+func (r *gormWebhookDeliveryRepository) CreateIfAbsent(delivery *entity.WebhookDelivery) (bool, error) {
+	deliveryGorm := WebhookDeliveryFromEntity(delivery)
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(deliveryGorm)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	delivery.ID = deliveryGorm.ID
+	delivery.CreatedAt = deliveryGorm.CreatedAt
+	delivery.UpdatedAt = deliveryGorm.UpdatedAt
+	return true, nil
+}
+
+func (r *gormWebhookDeliveryRepository) Create(delivery *entity.WebhookDelivery) error {
+	deliveryGorm := WebhookDeliveryFromEntity(delivery)
+	if err := r.db.Create(deliveryGorm).Error; err != nil {
+		return err
+	}
+	delivery.ID = deliveryGorm.ID
+	delivery.CreatedAt = deliveryGorm.CreatedAt
+	delivery.UpdatedAt = deliveryGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormWebhookDeliveryRepository) Update(delivery *entity.WebhookDelivery) error {
+	deliveryGorm := WebhookDeliveryFromEntity(delivery)
+	if err := r.db.Save(deliveryGorm).Error; err != nil {
+		return err
+	}
+	delivery.UpdatedAt = deliveryGorm.UpdatedAt
+	return nil
+}
+
+func (r *gormWebhookDeliveryRepository) FindByID(id uuid.UUID) (*entity.WebhookDelivery, error) {
+	var deliveryGorm WebhookDeliveryGormModel
+	result := r.db.First(&deliveryGorm, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return deliveryGorm.ToEntity(), nil
+}
+
+func (r *gormWebhookDeliveryRepository) FindBySubscriptionID(subscriptionID uuid.UUID, status entity.WebhookDeliveryStatus, limit int) ([]*entity.WebhookDelivery, error) {
+	query := r.db.Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var deliveriesGorm []WebhookDeliveryGormModel
+	if err := query.Order("created_at desc").Limit(limit).Find(&deliveriesGorm).Error; err != nil {
+		return nil, err
+	}
+	return toWebhookDeliveryEntities(deliveriesGorm), nil
+}
+
+func (r *gormWebhookDeliveryRepository) FindDue(now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveriesGorm []WebhookDeliveryGormModel
+	result := r.db.Where("status = ? AND next_attempt_at <= ?", string(entity.WebhookDeliveryStatusPending), now).
+		Order("next_attempt_at asc").
+		Limit(limit).
+		Find(&deliveriesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toWebhookDeliveryEntities(deliveriesGorm), nil
+}
+
+func (r *gormWebhookDeliveryRepository) Claim(id uuid.UUID, now, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&WebhookDeliveryGormModel{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, string(entity.WebhookDeliveryStatusPending), now).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormWebhookDeliveryRepository) FailPendingBySubscriptionID(subscriptionID uuid.UUID, reason string) error {
+	return r.db.Model(&WebhookDeliveryGormModel{}).
+		Where("subscription_id = ? AND status = ?", subscriptionID, string(entity.WebhookDeliveryStatusPending)).
+		Updates(map[string]any{"status": string(entity.WebhookDeliveryStatusFailed), "last_error": reason}).Error
+}
+
+func (r *gormWebhookDeliveryRepository) CreateAttempt(attempt *entity.WebhookAttempt) error {
+	attemptGorm := WebhookAttemptFromEntity(attempt)
+	if err := r.db.Create(attemptGorm).Error; err != nil {
+		return err
+	}
+	attempt.ID = attemptGorm.ID
+	return nil
+}
+
+func (r *gormWebhookDeliveryRepository) FindAttempts(deliveryID uuid.UUID) ([]*entity.WebhookAttempt, error) {
+	var attemptsGorm []WebhookAttemptGormModel
+	if err := r.db.Where("delivery_id = ?", deliveryID).Order("attempted_at asc").Find(&attemptsGorm).Error; err != nil {
+		return nil, err
+	}
+	var attempts []*entity.WebhookAttempt
+	for _, ag := range attemptsGorm {
+		attempts = append(attempts, ag.ToEntity())
+	}
+	return attempts, nil
+}
+
+func toWebhookDeliveryEntities(deliveriesGorm []WebhookDeliveryGormModel) []*entity.WebhookDelivery {
+	var deliveries []*entity.WebhookDelivery
+	for _, dg := range deliveriesGorm {
+		deliveries = append(deliveries, dg.ToEntity())
+	}
+	return deliveries
+}
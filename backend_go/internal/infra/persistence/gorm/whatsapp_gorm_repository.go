@@ -0,0 +1,144 @@
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WhatsAppMessageGormModel representa uma mensagem de WhatsApp para o GORM.
+type WhatsAppMessageGormModel struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID            *uuid.UUID `gorm:"type:uuid;index"`
+	AppointmentID     *uuid.UUID `gorm:"type:uuid;index"`
+	Direction         string     `gorm:"size:10;not null"`
+	Kind              string     `gorm:"size:30;not null"`
+	Phone             string     `gorm:"size:20;not null;index"`
+	ProviderMessageID string     `gorm:"size:255;index:idx_whatsapp_provider_message,unique,where:provider_message_id <> ''"`
+	Body              string     `gorm:"type:text"`
+	Action            string     `gorm:"size:20"`
+	Error             string     `gorm:"type:text"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName define o nome da tabela no banco de dados.
+func (WhatsAppMessageGormModel) TableName() string {
+	return "whatsapp_messages"
+}
+
+// ToEntity converte um WhatsAppMessageGormModel para uma entidade WhatsAppMessage.
+func (m *WhatsAppMessageGormModel) ToEntity() *entity.WhatsAppMessage {
+	return &entity.WhatsAppMessage{
+		ID:                m.ID,
+		UserID:            m.UserID,
+		AppointmentID:     m.AppointmentID,
+		Direction:         entity.WhatsAppDirection(m.Direction),
+		Kind:              entity.WhatsAppMessageKind(m.Kind),
+		Phone:             m.Phone,
+		ProviderMessageID: m.ProviderMessageID,
+		Body:              m.Body,
+		Action:            entity.WhatsAppReplyAction(m.Action),
+		Error:             m.Error,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+// WhatsAppMessageFromEntity converte uma entidade WhatsAppMessage para o modelo GORM.
+func WhatsAppMessageFromEntity(e *entity.WhatsAppMessage) *WhatsAppMessageGormModel {
+	return &WhatsAppMessageGormModel{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		AppointmentID:     e.AppointmentID,
+		Direction:         string(e.Direction),
+		Kind:              string(e.Kind),
+		Phone:             e.Phone,
+		ProviderMessageID: e.ProviderMessageID,
+		Body:              e.Body,
+		Action:            string(e.Action),
+		Error:             e.Error,
+		CreatedAt:         e.CreatedAt,
+	}
+}
+
+type gormWhatsAppMessageRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWhatsAppMessageRepository cria uma nova instância do repositório de mensagens de WhatsApp.
+func NewGormWhatsAppMessageRepository(db *gorm.DB) repository.WhatsAppMessageRepository {
+	return &gormWhatsAppMessageRepository{db: db}
+}
+
+func (r *gormWhatsAppMessageRepository) Create(message *entity.WhatsAppMessage) error {
+	messageGorm := WhatsAppMessageFromEntity(message)
+	if err := r.db.Create(messageGorm).Error; err != nil {
+		return err
+	}
+	message.ID = messageGorm.ID
+	message.CreatedAt = messageGorm.CreatedAt
+	return nil
+}
+
+func (r *gormWhatsAppMessageRepository) FindByProviderMessageID(providerMessageID string) (*entity.WhatsAppMessage, error) {
+	var messageGorm WhatsAppMessageGormModel
+	result := r.db.First(&messageGorm, "provider_message_id = ?", providerMessageID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Indica não encontrado
+		}
+		return nil, result.Error
+	}
+	return messageGorm.ToEntity(), nil
+}
+
+func (r *gormWhatsAppMessageRepository) ExistsForAppointment(appointmentID uuid.UUID, kind entity.WhatsAppMessageKind) (bool, error) {
+	var count int64
+	err := r.db.Model(&WhatsAppMessageGormModel{}).
+		Where("appointment_id = ? AND kind = ?", appointmentID, string(kind)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormWhatsAppMessageRepository) FindOutboundByPhones(phones []string, kinds []entity.WhatsAppMessageKind) ([]*entity.WhatsAppMessage, error) {
+	kindValues := make([]string, len(kinds))
+	for i, kind := range kinds {
+		kindValues[i] = string(kind)
+	}
+
+	var messagesGorm []WhatsAppMessageGormModel
+	result := r.db.Where("direction = ? AND phone IN ? AND kind IN ? AND appointment_id IS NOT NULL AND error = ''",
+		string(entity.WhatsAppDirectionOutbound), phones, kindValues).
+		Order("created_at desc").
+		Find(&messagesGorm)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var messages []*entity.WhatsAppMessage
+	for _, mg := range messagesGorm {
+		messages = append(messages, mg.ToEntity())
+	}
+	return messages, nil
+}
+
+func (r *gormWhatsAppMessageRepository) FindByUserID(userID uuid.UUID, appointmentID *uuid.UUID) ([]*entity.WhatsAppMessage, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if appointmentID != nil {
+		query = query.Where("appointment_id = ?", *appointmentID)
+	}
+
+	var messagesGorm []WhatsAppMessageGormModel
+	if err := query.Order("created_at desc").Find(&messagesGorm).Error; err != nil {
+		return nil, err
+	}
+
+	var messages []*entity.WhatsAppMessage
+	for _, mg := range messagesGorm {
+		messages = append(messages, mg.ToEntity())
+	}
+	return messages, nil
+}
@@ -0,0 +1,148 @@
+package push
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// FCMSenderName é o nome do provedor do Firebase Cloud Messaging.
+const FCMSenderName = "FCM"
+
+// FCMConfig contém os dados de acesso à API HTTP v1 do Firebase Cloud Messaging.
+type FCMConfig struct {
+	BaseURL     string // Ex: https://fcm.googleapis.com ou o endereço do servidor fake
+	ProjectID   string
+	AccessToken string // Token OAuth 2.0 da conta de serviço com escopo firebase.messaging
+	Timeout     time.Duration
+}
+
+// FCMSender envia notificações pela API HTTP v1 do Firebase Cloud Messaging.
+type FCMSender struct {
+	cfg    FCMConfig
+	client *http.Client
+}
+
+// NewFCMSender cria um FCMSender.
+func NewFCMSender(cfg FCMConfig) *FCMSender {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &FCMSender{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// Name retorna o identificador do provedor.
+func (s *FCMSender) Name() string {
+	return FCMSenderName
+}
+
+// fcmSendRequest é o corpo de envio da API HTTP v1.
+type fcmSendRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification *fcmNotification  `json:"notification,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      *fcmAndroidConfig `json:"android,omitempty"`
+	APNS         *fcmAPNSConfig    `json:"apns,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmAndroidConfig struct {
+	Priority string `json:"priority"`
+}
+
+type fcmAPNSConfig struct {
+	Payload map[string]any `json:"payload"`
+}
+
+// fcmSendResponse é a resposta de envio da API HTTP v1.
+type fcmSendResponse struct {
+	Name  string    `json:"name"` // projects/{projeto}/messages/{id}
+	Error *fcmError `json:"error"`
+}
+
+type fcmError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+	Details []struct {
+		ErrorCode string `json:"errorCode"`
+	} `json:"details"`
+}
+
+// Send envia a notificação ao dispositivo.
+func (s *FCMSender) Send(msg Message) (string, error) {
+	if strings.TrimSpace(msg.Token) == "" {
+		return "", ErrInvalidToken
+	}
+
+	message := fcmMessage{
+		Token:        msg.Token,
+		Notification: &fcmNotification{Title: msg.Title, Body: msg.Body},
+		Data:         msg.Data,
+	}
+	switch msg.Platform {
+	case "ANDROID":
+		message.Android = &fcmAndroidConfig{Priority: "high"}
+	case "IOS":
+		message.APNS = &fcmAPNSConfig{Payload: map[string]any{"aps": map[string]any{"sound": "default"}}}
+	}
+
+	body, err := json.Marshal(fcmSendRequest{Message: message})
+	if err != nil {
+		return "", err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/projects/%s/messages:send", s.cfg.BaseURL, s.cfg.ProjectID), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+s.cfg.AccessToken)
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("falha ao chamar o FCM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out fcmSendResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out); err != nil {
+		return "", fmt.Errorf("resposta inválida do FCM (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode >= 300 || out.Error != nil {
+		if out.Error != nil {
+			if out.Error.unregistered() {
+				return "", ErrInvalidToken
+			}
+			return "", fmt.Errorf("FCM recusou a notificação (%s): %s", out.Error.Status, out.Error.Message)
+		}
+		return "", fmt.Errorf("FCM retornou HTTP %d", resp.StatusCode)
+	}
+	if out.Name == "" {
+		return "", errors.New("FCM não retornou o ID da mensagem")
+	}
+	return out.Name, nil
+}
+
+// unregistered indica se o erro se refere a um token que não existe mais.
+func (e *fcmError) unregistered() bool {
+	for _, d := range e.Details {
+		if d.ErrorCode == "UNREGISTERED" {
+			return true
+		}
+	}
+	return e.Status == "NOT_FOUND"
+}
@@ -0,0 +1,176 @@
+package push
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeMaxMessages limita as notificações guardadas em memória pelo servidor fake; acima
+// dele, as mais antigas são descartadas.
+const fakeMaxMessages = 1000
+
+// FakeFCMMessage é uma notificação recebida pelo servidor fake, como o dispositivo a veria.
+type FakeFCMMessage struct {
+	ID       string            `json:"id"`
+	Token    string            `json:"token"`
+	Title    string            `json:"title"`
+	Body     string            `json:"body"`
+	Data     map[string]string `json:"data,omitempty"`
+	Platform string            `json:"platform,omitempty"` // Inferida das opções android/apns
+	SentAt   time.Time         `json:"sentAt"`
+}
+
+// fakeUnregisterRequest é o corpo aceito por POST /unregister.
+type fakeUnregisterRequest struct {
+	Token string `json:"token"`
+}
+
+// FCMFakeServer simula localmente a API HTTP v1 do FCM para que o envio de push possa
+// ser testado offline, sem um projeto do Firebase. Todas as rotas exigem o token de
+// acesso no cabeçalho Authorization, como a API real:
+//
+//	POST /v1/projects/{projectID}/messages:send  recebe os envios do FCMSender
+//	GET  /messages?token=                        lista as notificações enviadas
+//	POST /unregister                             simula a desinstalação do app (token passa a ser recusado)
+type FCMFakeServer struct {
+	projectID    string
+	accessToken  string
+	mu           sync.Mutex
+	sent         []FakeFCMMessage
+	unregistered map[string]bool
+	mux          *http.ServeMux
+}
+
+// NewFCMFakeServer cria um FCMFakeServer que exige o token informado em todas as
+// requisições e o projeto informado nos envios.
+func NewFCMFakeServer(projectID, accessToken string) *FCMFakeServer {
+	s := &FCMFakeServer{
+		projectID:    projectID,
+		accessToken:  accessToken,
+		unregistered: make(map[string]bool),
+		mux:          http.NewServeMux(),
+	}
+	s.mux.HandleFunc("POST /v1/projects/{projectID}/messages:send", s.handleSend)
+	s.mux.HandleFunc("GET /messages", s.handleList)
+	s.mux.HandleFunc("POST /unregister", s.handleUnregister)
+	return s
+}
+
+// ServeHTTP implementa http.Handler.
+func (s *FCMFakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// fakeError responde no formato de erro das APIs do Google; errorCode, se informado,
+// vai nos detalhes como o FcmError da API real.
+func fakeError(w http.ResponseWriter, code int, status, message, errorCode string) {
+	body := map[string]any{"code": code, "message": message, "status": status}
+	if errorCode != "" {
+		body["details"] = []map[string]string{{
+			"@type":     "type.googleapis.com/google.firebase.fcm.v1.FcmError",
+			"errorCode": errorCode,
+		}}
+	}
+	writeFakeJSON(w, code, map[string]any{"error": body})
+}
+
+// authorized confere o token de acesso da requisição e responde 401 quando ele não confere.
+func (s *FCMFakeServer) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("Authorization") != "Bearer "+s.accessToken {
+		fakeError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "token de acesso inválido", "")
+		return false
+	}
+	return true
+}
+
+func (s *FCMFakeServer) handleSend(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+	if r.PathValue("projectID") != s.projectID {
+		fakeError(w, http.StatusForbidden, "PERMISSION_DENIED", "projeto não autorizado", "SENDER_ID_MISMATCH")
+		return
+	}
+	var req fcmSendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		fakeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "corpo inválido: "+err.Error(), "INVALID_ARGUMENT")
+		return
+	}
+	token := strings.TrimSpace(req.Message.Token)
+	if token == "" {
+		fakeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "message.token é obrigatório", "INVALID_ARGUMENT")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.unregistered[token] {
+		fakeError(w, http.StatusNotFound, "NOT_FOUND", "Requested entity was not found.", "UNREGISTERED")
+		return
+	}
+
+	msg := FakeFCMMessage{
+		ID:     "projects/" + s.projectID + "/messages/fake-" + uuid.NewString(),
+		Token:  token,
+		Data:   req.Message.Data,
+		SentAt: time.Now(),
+	}
+	if req.Message.Notification != nil {
+		msg.Title = req.Message.Notification.Title
+		msg.Body = req.Message.Notification.Body
+	}
+	switch {
+	case req.Message.Android != nil:
+		msg.Platform = "ANDROID"
+	case req.Message.APNS != nil:
+		msg.Platform = "IOS"
+	}
+	if len(s.sent) >= fakeMaxMessages {
+		s.sent = s.sent[len(s.sent)-fakeMaxMessages+1:]
+	}
+	s.sent = append(s.sent, msg)
+
+	writeFakeJSON(w, http.StatusOK, map[string]string{"name": msg.ID})
+}
+
+func (s *FCMFakeServer) handleList(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+	token := r.URL.Query().Get("token")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := make([]FakeFCMMessage, 0, len(s.sent))
+	for _, m := range s.sent {
+		if token == "" || m.Token == token {
+			messages = append(messages, m)
+		}
+	}
+	writeFakeJSON(w, http.StatusOK, messages)
+}
+
+func (s *FCMFakeServer) handleUnregister(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+	var req fakeUnregisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Token) == "" {
+		fakeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "token é obrigatório", "")
+		return
+	}
+	s.mu.Lock()
+	s.unregistered[strings.TrimSpace(req.Token)] = true
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
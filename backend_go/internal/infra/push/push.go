@@ -0,0 +1,27 @@
+// Package push define o contrato dos provedores de notificações push enviadas ao app
+// dos usuários (equipe do negócio).
+package push
+
+import "errors"
+
+// ErrInvalidToken indica que o provedor não reconhece mais o token do dispositivo (ex: app
+// desinstalado). O dispositivo deve ser removido do cadastro.
+var ErrInvalidToken = errors.New("token do dispositivo inválido ou expirado")
+
+// Message é uma notificação a ser enviada a um dispositivo.
+type Message struct {
+	Token    string
+	Platform string // ANDROID, IOS ou WEB; usado para as opções específicas de cada plataforma
+	Title    string
+	Body     string
+	Data     map[string]string // Dados lidos pelo app ao abrir a notificação (ex: tipo e ID do evento)
+}
+
+// Sender define o contrato de um provedor de notificações push.
+type Sender interface {
+	// Name retorna o identificador do provedor (ex: FCM).
+	Name() string
+	// Send envia a notificação e retorna o ID atribuído pelo provedor. Retorna
+	// ErrInvalidToken quando o token não é mais válido.
+	Send(msg Message) (string, error)
+}
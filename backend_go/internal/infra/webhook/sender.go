@@ -0,0 +1,191 @@
+// Package webhook envia os eventos da aplicação às URLs cadastradas pelos usuários,
+// com o corpo assinado por HMAC-SHA256.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress indica que a URL cadastrada aponta para um endereço de rede interna.
+var ErrPrivateAddress = errors.New("endereço de rede interna não permitido")
+
+const (
+	// EventHeader traz o tipo do evento (ex: appointment.created).
+	EventHeader = "X-Bizly-Event"
+	// DeliveryHeader traz o ID da entrega; muda a cada reenvio manual.
+	DeliveryHeader = "X-Bizly-Delivery"
+	// TimestampHeader traz o instante do envio em segundos Unix.
+	TimestampHeader = "X-Bizly-Timestamp"
+	// SignatureHeader traz a assinatura no formato "t=<timestamp>,v1=<hex>", onde
+	// v1 = HMAC-SHA256(segredo, "<timestamp>.<corpo>").
+	SignatureHeader = "X-Bizly-Signature"
+
+	// maxResponseBody é quanto da resposta do destino é guardado no histórico de entregas.
+	maxResponseBody = 2048
+)
+
+// Request é um envio de webhook.
+type Request struct {
+	URL        string
+	Secret     string
+	EventType  string
+	DeliveryID string
+	Body       []byte
+}
+
+// Response é o resultado de um envio que chegou ao destino.
+type Response struct {
+	StatusCode int
+	Body       string // Truncado em maxResponseBody bytes
+	Duration   time.Duration
+}
+
+// Success indica se o destino aceitou o evento (HTTP 2xx).
+func (r *Response) Success() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Sender faz as requisições HTTP dos webhooks.
+type Sender struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewSender cria um Sender com o timeout informado (10s, se zero). Como as URLs são
+// cadastradas pelos usuários, as conexões passam pelas restrições de NewHTTPClient.
+func NewSender(timeout time.Duration, allowPrivate bool) *Sender {
+	return &Sender{
+		client:    NewHTTPClient(timeout, allowPrivate),
+		userAgent: "Bizly-Webhooks/1.0",
+	}
+}
+
+// NewHTTPClient cria um cliente HTTP para acessar URLs cadastradas pelos usuários (ex:
+// webhooks, servidores CalDAV), com o timeout informado (10s, se zero). Conexões a
+// endereços internos (loopback, redes privadas, link-local) são recusadas, a menos que
+// allowPrivate seja true (ex: em desenvolvimento). A verificação é feita no IP já
+// resolvido, mesmo que o DNS do domínio mude após o cadastro.
+func NewHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = rejectPrivateAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		// Redirecionamentos não são seguidos: a URL cadastrada deve ser a final.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Send envia o corpo assinado via POST. Retorna erro apenas quando não houve resposta
+// (ex: DNS, conexão recusada, timeout); respostas não 2xx vêm em Response.
+func (s *Sender) Send(req Request) (*Response, error) {
+	timestamp := time.Now().Unix()
+	httpReq, err := http.NewRequest(http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", s.userAgent)
+	httpReq.Header.Set(EventHeader, req.EventType)
+	httpReq.Header.Set(DeliveryHeader, req.DeliveryID)
+	httpReq.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(SignatureHeader, SignatureHeaderValue(req.Secret, timestamp, req.Body))
+
+	start := time.Now()
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Duration:   time.Since(start),
+	}, nil
+}
+
+// rejectPrivateAddress recusa conexões a IPs que não sejam públicos.
+func rejectPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+	return nil
+}
+
+// Sign calcula a assinatura HMAC-SHA256 (hex) de "<timestamp>.<corpo>". Incluir o
+// timestamp permite ao destino rejeitar reenvios antigos de uma mesma requisição.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeaderValue monta o valor do cabeçalho SignatureHeader.
+func SignatureHeaderValue(secret string, timestamp int64, body []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, Sign(secret, timestamp, body))
+}
+
+// Verify confere o cabeçalho SignatureHeader de um webhook recebido, rejeitando
+// timestamps com diferença maior que tolerance em relação a now. É o procedimento que
+// os destinos devem seguir; fica aqui como referência e para ferramentas de teste.
+func Verify(secret string, header string, body []byte, now time.Time, tolerance time.Duration) bool {
+	var timestamp int64
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if secret == "" || timestamp == 0 || len(signatures) == 0 {
+		return false
+	}
+	if d := now.Sub(time.Unix(timestamp, 0)); d > tolerance || d < -tolerance {
+		return false
+	}
+	expected := Sign(secret, timestamp, body)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+			return true
+		}
+	}
+	return false
+}
@@ -10,6 +10,9 @@ import (
 type AppointmentRepository interface {
 	Create(appointment *entity.Appointment) error
 	FindByID(id uuid.UUID) (*entity.Appointment, error)
+	// LockByID busca o agendamento bloqueando-o até o fim da transação (ex: para que
+	// conclusões concorrentes não apliquem os efeitos da conclusão duas vezes).
+	LockByID(id uuid.UUID) (*entity.Appointment, error)
 	FindByUserID(userID uuid.UUID, startTimeFilter, endTimeFilter *time.Time) ([]*entity.Appointment, error) // Lista agendamentos de um usuário, com filtros de data opcionais
 	Update(appointment *entity.Appointment) error
 	Delete(id uuid.UUID) error // Pode ser um soft delete ou hard delete
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// CalendarFeedRepository define a interface para o armazenamento dos feeds de calendário.
+type CalendarFeedRepository interface {
+	FindByUserID(userID uuid.UUID) (*entity.CalendarFeed, error)
+	FindByToken(token string) (*entity.CalendarFeed, error)
+	// Save cria o feed do usuário ou atualiza o existente.
+	Save(feed *entity.CalendarFeed) error
+}
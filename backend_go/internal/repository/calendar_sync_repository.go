@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// CalendarConnectionRepository define a interface para o armazenamento das conexões com
+// calendários externos.
+type CalendarConnectionRepository interface {
+	FindByUserID(userID uuid.UUID) (*entity.CalendarConnection, error)
+	FindEnabled() ([]*entity.CalendarConnection, error)
+	// Save cria a conexão do usuário ou atualiza a existente.
+	Save(connection *entity.CalendarConnection) error
+	// Delete remove a conexão do usuário com seus blocos ocupados e vínculos de eventos.
+	Delete(userID uuid.UUID) error
+}
+
+// CalendarBusyBlockRepository define a interface para o armazenamento dos períodos
+// ocupados importados dos calendários externos.
+type CalendarBusyBlockRepository interface {
+	// ReplaceForConnection substitui os blocos da conexão pelos informados.
+	ReplaceForConnection(connectionID uuid.UUID, blocks []*entity.CalendarBusyBlock) error
+	// FindOverlapping lista os blocos do usuário que se sobrepõem ao período [start, end).
+	FindOverlapping(userID uuid.UUID, start, end time.Time) ([]*entity.CalendarBusyBlock, error)
+}
+
+// CalendarEventLinkRepository define a interface para o armazenamento dos vínculos entre
+// agendamentos e eventos publicados nos calendários externos.
+type CalendarEventLinkRepository interface {
+	Find(connectionID, appointmentID uuid.UUID) (*entity.CalendarEventLink, error)
+	FindByConnectionID(connectionID uuid.UUID) ([]*entity.CalendarEventLink, error)
+	// Save cria o vínculo ou atualiza o existente.
+	Save(link *entity.CalendarEventLink) error
+	Delete(connectionID, appointmentID uuid.UUID) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// ServiceRepository define a interface para o armazenamento do catálogo de serviços.
+type ServiceRepository interface {
+	Create(service *entity.Service) error
+	FindByID(id uuid.UUID) (*entity.Service, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.Service, error)
+	Update(service *entity.Service) error
+	Delete(id uuid.UUID) error
+}
+
+// ProfessionalRepository define a interface para o armazenamento dos profissionais da equipe.
+type ProfessionalRepository interface {
+	Create(professional *entity.Professional) error
+	FindByID(id uuid.UUID) (*entity.Professional, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.Professional, error)
+	Update(professional *entity.Professional) error
+	Delete(id uuid.UUID) error
+}
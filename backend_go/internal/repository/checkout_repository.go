@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// CheckoutRepository define a interface para o armazenamento dos fechamentos de atendimento.
+type CheckoutRepository interface {
+	// Complete grava o fechamento com seus produtos e pagamentos, as saídas de estoque, os
+	// lançamentos no livro-caixa, as comissões da gorjeta e dos produtos e os eventos de domínio
+	// e conclui o agendamento (gravando o preço de appointment, que as políticas de
+	// conclusão podem ter alterado, e incrementando a revisão), tudo em uma única transação.
+	// Retorna false, sem gravar nada, se o agendamento já estiver concluído ou cancelado;
+	// caso contrário, atualiza status, revisão e UpdatedAt de appointment.
+	Complete(checkout *entity.Checkout, appointment *entity.Appointment, movements []*entity.StockMovement, entries []*entity.FinancialEntry, commissions []*entity.Commission, events []*entity.DomainEvent) (bool, error)
+	FindByAppointmentID(appointmentID uuid.UUID) (*entity.Checkout, error)
+}
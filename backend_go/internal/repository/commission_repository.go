@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// CommissionRuleRepository define a interface para o armazenamento das regras de comissão.
+type CommissionRuleRepository interface {
+	Create(rule *entity.CommissionRule) error
+	FindByID(id uuid.UUID) (*entity.CommissionRule, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.CommissionRule, error)
+	Update(rule *entity.CommissionRule) error
+	Delete(id uuid.UUID) error
+}
+
+// CommissionRepository define a interface para o armazenamento das comissões apuradas.
+type CommissionRepository interface {
+	Create(commission *entity.Commission) error
+	FindByAppointmentID(appointmentID uuid.UUID) (*entity.Commission, error)                         // Comissão de serviço do atendimento
+	FindByProfessionalID(professionalID uuid.UUID, from, to time.Time) ([]*entity.Commission, error) // Intervalo [from, to) sobre EarnedAt
+	MarkPaid(ids []uuid.UUID, payoutID uuid.UUID) error
+}
+
+// CommissionPayoutRepository define a interface para o armazenamento dos repasses de comissão.
+type CommissionPayoutRepository interface {
+	Create(payout *entity.CommissionPayout) error
+	FindByProfessionalID(professionalID uuid.UUID) ([]*entity.CommissionPayout, error)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// CouponRepository define a interface para o armazenamento dos cupons de desconto.
+type CouponRepository interface {
+	Create(coupon *entity.Coupon) error
+	FindByID(id uuid.UUID) (*entity.Coupon, error)
+	// LockByID busca o cupom bloqueando a linha até o fim da transação, para que os
+	// limites de uso sejam verificados e registrados sem concorrência.
+	LockByID(id uuid.UUID) (*entity.Coupon, error)
+	FindByCode(userID uuid.UUID, code string) (*entity.Coupon, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.Coupon, error)
+	Update(coupon *entity.Coupon) error
+	Delete(id uuid.UUID) error
+}
+
+// CouponRedemptionRepository define a interface para o armazenamento dos usos de cupons.
+// As contagens e listagens ignoram agendamentos cancelados ou excluídos.
+type CouponRedemptionRepository interface {
+	Create(redemption *entity.CouponRedemption) error
+	FindByCouponID(couponID uuid.UUID) ([]*entity.CouponRedemption, error)
+	CountByCouponID(couponID uuid.UUID) (int64, error)
+	// CountByCustomer conta os usos do cliente, identificados pelo cadastro ou pelo email.
+	CountByCustomer(couponID uuid.UUID, customerID *uuid.UUID, clientEmail string) (int64, error)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// DashboardRepository calcula os indicadores do painel com agregações no banco,
+// sem carregar os agendamentos. Todos os intervalos são [from, to) sobre o início
+// do agendamento ou a data do lançamento.
+type DashboardRepository interface {
+	// CountAppointmentsByStatus conta os agendamentos do intervalo por status.
+	CountAppointmentsByStatus(userID uuid.UUID, from, to time.Time) (map[entity.AppointmentStatus]int, error)
+	// CompletedAppointmentsRevenue soma o preço e conta os agendamentos concluídos.
+	CompletedAppointmentsRevenue(userID uuid.UUID, from, to time.Time) (total float64, count int, err error)
+	// RevenueSeries agrega o faturamento (agendamentos concluídos e entradas avulsas) por
+	// dia, semana ou mês, conforme unit ("day", "week" ou "month").
+	RevenueSeries(userID uuid.UUID, from, to time.Time, unit string) ([]RevenuePoint, error)
+	// TopServices lista os serviços com maior faturamento em agendamentos concluídos.
+	TopServices(userID uuid.UUID, from, to time.Time, limit int) ([]RankingRow, error)
+	// TopClients lista os clientes com maior faturamento em agendamentos concluídos.
+	TopClients(userID uuid.UUID, from, to time.Time, limit int) ([]RankingRow, error)
+	// BookedMinutes soma a duração dos agendamentos não cancelados.
+	BookedMinutes(userID uuid.UUID, from, to time.Time) (float64, error)
+}
+
+// RevenuePoint é o faturamento de um período da série (dia, semana ou mês).
+type RevenuePoint struct {
+	PeriodStart time.Time
+	Amount      float64
+}
+
+// RankingRow é uma linha dos rankings de serviços e clientes. ID é nil para
+// serviços e clientes informados só pelo nome, sem cadastro.
+type RankingRow struct {
+	ID           *uuid.UUID
+	Name         string
+	Appointments int
+	Revenue      float64
+}
+
+// WorkingHoursRepository define a interface para o armazenamento do horário de funcionamento.
+type WorkingHoursRepository interface {
+	FindByUserID(userID uuid.UUID) ([]*entity.WorkingHours, error)
+	// ReplaceForUser substitui todo o horário semanal do usuário pelo informado.
+	ReplaceForUser(userID uuid.UUID, hours []*entity.WorkingHours) error
+}
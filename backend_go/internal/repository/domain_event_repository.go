@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// DomainEventRepository define a interface da outbox de eventos de domínio. Os eventos
+// devem ser gravados pela Transaction da alteração de estado que os originou.
+type DomainEventRepository interface {
+	Append(events ...*entity.DomainEvent) error
+	FindByID(id uuid.UUID) (*entity.DomainEvent, error)
+	// FindPending lista os eventos ainda não distribuídos, na ordem em que ocorreram.
+	FindPending(limit int) ([]*entity.DomainEvent, error)
+	// MarkDispatched cria as entregas do evento aos assinantes e o marca como distribuído
+	// em uma única transação. Retorna false, sem gravar nada, se o evento já foi distribuído.
+	MarkDispatched(event *entity.DomainEvent, deliveries []*entity.EventDelivery) (bool, error)
+	// FindByUserID lista os eventos do usuário, dos mais recentes para os mais antigos;
+	// eventType vazio não filtra.
+	FindByUserID(userID uuid.UUID, eventType entity.DomainEventType, limit int) ([]*entity.DomainEvent, error)
+	// FindByUserIDAfter lista os eventos do usuário, dos tipos informados, ocorridos depois
+	// de after (com desempate pelo ID), na ordem em que ocorreram.
+	FindByUserIDAfter(userID uuid.UUID, types []entity.DomainEventType, after *entity.DomainEvent, limit int) ([]*entity.DomainEvent, error)
+}
+
+// EventDeliveryRepository define a interface para o acompanhamento das entregas de eventos.
+type EventDeliveryRepository interface {
+	Update(delivery *entity.EventDelivery) error
+	FindByID(id uuid.UUID) (*entity.EventDelivery, error)
+	// FindDue lista as entregas pendentes com próxima tentativa até now, das mais antigas
+	// para as mais novas.
+	FindDue(now time.Time, limit int) ([]*entity.EventDelivery, error)
+	// Claim reserva atomicamente uma entrega pendente, adiando a próxima tentativa para
+	// leaseUntil. Retorna false se outro processo já a reservou.
+	Claim(id uuid.UUID, now, leaseUntil time.Time) (bool, error)
+	// FindByUserID lista as entregas do usuário, das mais recentes para as mais antigas;
+	// status vazio não filtra.
+	FindByUserID(userID uuid.UUID, status entity.EventDeliveryStatus) ([]*entity.EventDelivery, error)
+}
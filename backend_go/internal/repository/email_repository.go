@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// EmailSettingsRepository define a interface para o armazenamento das configurações de e-mail.
+type EmailSettingsRepository interface {
+	FindByUserID(userID uuid.UUID) (*entity.EmailSettings, error)
+	// Save cria as configurações do usuário ou atualiza as existentes.
+	Save(settings *entity.EmailSettings) error
+}
+
+// EmailOutboxRepository define a interface para a fila de envio de e-mails.
+type EmailOutboxRepository interface {
+	Create(email *entity.OutboundEmail) error
+	Update(email *entity.OutboundEmail) error
+	FindByID(id uuid.UUID) (*entity.OutboundEmail, error)
+	// FindByUserID lista os e-mails do usuário, dos mais recentes para os mais antigos;
+	// appointmentID nil e status vazio não filtram.
+	FindByUserID(userID uuid.UUID, appointmentID *uuid.UUID, status entity.EmailStatus) ([]*entity.OutboundEmail, error)
+	// FindDue lista os e-mails pendentes com próxima tentativa até now, dos mais antigos
+	// para os mais novos.
+	FindDue(now time.Time, limit int) ([]*entity.OutboundEmail, error)
+	// Claim reserva atomicamente um e-mail pendente para envio, adiando a próxima
+	// tentativa para leaseUntil. Retorna false se outro processo já o reservou.
+	Claim(id uuid.UUID, now, leaseUntil time.Time) (bool, error)
+}
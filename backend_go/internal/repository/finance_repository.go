@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// FinancialEntryFilter contém os filtros opcionais para listar lançamentos.
+type FinancialEntryFilter struct {
+	Type       *entity.FinancialEntryType
+	CategoryID *uuid.UUID
+	From       *time.Time // Inclusivo
+	To         *time.Time // Exclusivo
+}
+
+// FinancialEntryRepository define a interface para o armazenamento de lançamentos financeiros.
+type FinancialEntryRepository interface {
+	Create(entry *entity.FinancialEntry) error
+	FindByID(id uuid.UUID) (*entity.FinancialEntry, error)
+	FindByUserID(userID uuid.UUID, filter FinancialEntryFilter) ([]*entity.FinancialEntry, error)
+	Update(entry *entity.FinancialEntry) error
+	Delete(id uuid.UUID) error
+	// SumExpensesByCategory retorna o total de saídas por categoria no intervalo [from, to).
+	SumExpensesByCategory(userID uuid.UUID, from, to time.Time) (map[uuid.UUID]float64, error)
+}
+
+// ExpenseCategoryRepository define a interface para o armazenamento de categorias de despesa.
+type ExpenseCategoryRepository interface {
+	Create(category *entity.ExpenseCategory) error
+	FindByID(id uuid.UUID) (*entity.ExpenseCategory, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.ExpenseCategory, error)
+	Update(category *entity.ExpenseCategory) error
+	Delete(id uuid.UUID) error
+}
+
+// RecurringExpenseRepository define a interface para o armazenamento de despesas recorrentes.
+type RecurringExpenseRepository interface {
+	Create(expense *entity.RecurringExpense) error
+	FindByID(id uuid.UUID) (*entity.RecurringExpense, error)
+	LockByID(id uuid.UUID) (*entity.RecurringExpense, error) // Bloqueia a despesa até o fim da transação
+	FindByUserID(userID uuid.UUID) ([]*entity.RecurringExpense, error)
+	FindDue(until time.Time) ([]*entity.RecurringExpense, error) // Ativas com NextDueDate <= until, de todos os usuários
+	Update(expense *entity.RecurringExpense) error
+	Delete(id uuid.UUID) error
+}
+
+// BudgetAlertRepository define a interface para o armazenamento de alertas de orçamento.
+type BudgetAlertRepository interface {
+	Create(alert *entity.BudgetAlert) error
+	FindByUserID(userID uuid.UUID) ([]*entity.BudgetAlert, error)
+	FindByCategoryAndMonth(categoryID uuid.UUID, month string) (*entity.BudgetAlert, error)
+}
+
+// IncomeForecastRepository define a interface para o armazenamento das receitas previstas.
+type IncomeForecastRepository interface {
+	Create(forecast *entity.IncomeForecast) error
+	// FindOpenByUserIDAndPeriod lista as previsões com data esperada no período [from, to)
+	// cujo agendamento, se houver, ainda está pendente ou confirmado.
+	FindOpenByUserIDAndPeriod(userID uuid.UUID, from, to time.Time) ([]*entity.IncomeForecast, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// GiftCardRepository define a interface para o armazenamento dos vales-presente.
+type GiftCardRepository interface {
+	Create(giftCard *entity.GiftCard) error
+	FindByID(id uuid.UUID) (*entity.GiftCard, error)
+	FindByCode(userID uuid.UUID, code string) (*entity.GiftCard, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.GiftCard, error)
+	Update(giftCard *entity.GiftCard) error
+	// Debit desconta o valor do saldo de forma atômica, somente se houver saldo suficiente.
+	// Retorna false quando o saldo não é suficiente.
+	Debit(id uuid.UUID, amount float64) (bool, error)
+	// Credit devolve o valor ao saldo de forma atômica, somente se o vale estiver ativo.
+	// Retorna false quando o vale foi cancelado.
+	Credit(id uuid.UUID, amount float64) (bool, error)
+	// Cancel cancela o vale e zera o saldo, somente se ele ainda estiver ativo com o saldo
+	// informado. Retorna false quando o vale já foi cancelado ou movimentado por outra operação.
+	Cancel(id uuid.UUID, balance float64) (bool, error)
+}
+
+// GiftCardTransactionRepository define a interface para o armazenamento das movimentações dos vales.
+type GiftCardTransactionRepository interface {
+	Create(transaction *entity.GiftCardTransaction) error
+	FindByGiftCardID(giftCardID uuid.UUID) ([]*entity.GiftCardTransaction, error)
+}
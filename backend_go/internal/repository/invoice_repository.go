@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// InvoiceRepository define a interface para o armazenamento das NFS-e.
+type InvoiceRepository interface {
+	Create(invoice *entity.Invoice) error
+	FindByID(id uuid.UUID) (*entity.Invoice, error)
+	// FindByUserID lista as notas do usuário; status vazio não filtra.
+	FindByUserID(userID uuid.UUID, status entity.InvoiceStatus) ([]*entity.Invoice, error)
+	// FindActiveByAppointmentID retorna a nota pendente ou emitida do agendamento, se houver.
+	FindActiveByAppointmentID(appointmentID uuid.UUID) (*entity.Invoice, error)
+	Update(invoice *entity.Invoice) error
+}
+
+// InvoiceSettingsRepository define a interface para o armazenamento dos dados fiscais do prestador.
+type InvoiceSettingsRepository interface {
+	FindByUserID(userID uuid.UUID) (*entity.InvoiceSettings, error)
+	// Save cria ou atualiza os dados fiscais do usuário, sem alterar a numeração do RPS.
+	Save(settings *entity.InvoiceSettings) error
+	// ReserveRPSNumber reserva atomicamente o próximo número de RPS do usuário.
+	ReserveRPSNumber(userID uuid.UUID) (int, error)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// MembershipPlanRepository define a interface para o armazenamento dos planos de assinatura.
+type MembershipPlanRepository interface {
+	Create(plan *entity.MembershipPlan) error
+	FindByID(id uuid.UUID) (*entity.MembershipPlan, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.MembershipPlan, error)
+	Update(plan *entity.MembershipPlan) error
+	Delete(id uuid.UUID) error
+}
+
+// MembershipSubscriptionRepository define a interface para o armazenamento das assinaturas.
+type MembershipSubscriptionRepository interface {
+	Create(subscription *entity.MembershipSubscription) error
+	FindByID(id uuid.UUID) (*entity.MembershipSubscription, error)
+	// LockByID busca a assinatura bloqueando a linha até o fim da transação, para que a
+	// renovação e os usos incluídos do ciclo sejam registrados sem concorrência.
+	LockByID(id uuid.UUID) (*entity.MembershipSubscription, error)
+	FindByCustomerID(customerID uuid.UUID) ([]*entity.MembershipSubscription, error)
+	CountByPlanID(planID uuid.UUID) (int64, error)
+	// FindDueForRenewal retorna as assinaturas ativas cujo ciclo terminou até now, de todos os usuários.
+	FindDueForRenewal(now time.Time) ([]*entity.MembershipSubscription, error)
+	Update(subscription *entity.MembershipSubscription) error
+}
+
+// MembershipCycleRepository define a interface para o armazenamento dos ciclos de cobrança.
+type MembershipCycleRepository interface {
+	Create(cycle *entity.MembershipCycle) error
+	FindBySubscriptionID(subscriptionID uuid.UUID) ([]*entity.MembershipCycle, error)
+}
+
+// MembershipUsageRepository define a interface para o armazenamento dos usos da assinatura.
+type MembershipUsageRepository interface {
+	Create(usage *entity.MembershipUsage) error
+	// FindByCycleID retorna os usos do ciclo, ignorando agendamentos cancelados ou excluídos.
+	FindByCycleID(cycleID uuid.UUID) ([]*entity.MembershipUsage, error)
+}
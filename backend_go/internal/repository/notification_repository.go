@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// NotificationRepository define a interface para o armazenamento dos avisos da caixa de entrada.
+type NotificationRepository interface {
+	// CreateIfAbsent grava o aviso, a menos que o evento já tenha gerado um aviso ao
+	// usuário. Retorna false se já existia.
+	CreateIfAbsent(notification *entity.Notification) (bool, error)
+	FindByID(id uuid.UUID) (*entity.Notification, error)
+	// FindByUserID lista os avisos do usuário, dos mais recentes para os mais antigos;
+	// unreadOnly restringe aos não lidos.
+	FindByUserID(userID uuid.UUID, unreadOnly bool, offset, limit int) ([]*entity.Notification, error)
+	CountByUserID(userID uuid.UUID, unreadOnly bool) (int64, error)
+	// MarkRead marca o aviso como lido em readAt, se ainda não lido.
+	MarkRead(id uuid.UUID, readAt time.Time) error
+	// MarkAllRead marca como lidos todos os avisos não lidos do usuário. Retorna o número
+	// de avisos marcados.
+	MarkAllRead(userID uuid.UUID, readAt time.Time) (int64, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// ServicePackageRepository define a interface para o armazenamento dos pacotes à venda.
+type ServicePackageRepository interface {
+	Create(pkg *entity.ServicePackage) error
+	FindByID(id uuid.UUID) (*entity.ServicePackage, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.ServicePackage, error)
+	Update(pkg *entity.ServicePackage) error
+	Delete(id uuid.UUID) error
+}
+
+// ClientPackageRepository define a interface para o armazenamento dos pacotes vendidos.
+type ClientPackageRepository interface {
+	Create(clientPackage *entity.ClientPackage) error
+	FindByID(id uuid.UUID) (*entity.ClientPackage, error)
+	FindByCustomerID(customerID uuid.UUID) ([]*entity.ClientPackage, error) // Ordenados pelo vencimento mais próximo
+	// ConsumeCredit incrementa o uso em um crédito se ainda houver saldo.
+	// Retorna false se o pacote não tinha mais créditos.
+	ConsumeCredit(id uuid.UUID) (bool, error)
+}
+
+// PackageCreditUsageRepository define a interface para o armazenamento dos consumos de crédito.
+type PackageCreditUsageRepository interface {
+	Create(usage *entity.PackageCreditUsage) error
+	FindByAppointmentID(appointmentID uuid.UUID) (*entity.PackageCreditUsage, error)
+	FindByClientPackageID(clientPackageID uuid.UUID) ([]*entity.PackageCreditUsage, error)
+}
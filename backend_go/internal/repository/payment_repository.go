@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// PaymentRepository define a interface para interações com o armazenamento de pagamentos.
+type PaymentRepository interface {
+	Create(payment *entity.Payment) error
+	FindByID(id uuid.UUID) (*entity.Payment, error)
+	FindByAppointmentID(appointmentID uuid.UUID) ([]*entity.Payment, error)
+	FindByProviderChargeID(provider, chargeID string) (*entity.Payment, error)
+	// LockByProviderChargeID busca o pagamento bloqueando-o até o fim da transação, para
+	// que eventos concorrentes do provedor sejam aplicados um de cada vez.
+	LockByProviderChargeID(provider, chargeID string) (*entity.Payment, error)
+	Update(payment *entity.Payment) error
+	// SumPaidByMethod soma os pagamentos confirmados do usuário em uma forma de pagamento,
+	// no intervalo [from, to) sobre PaidAt.
+	SumPaidByMethod(userID uuid.UUID, method entity.PaymentMethod, from, to time.Time) (float64, error)
+}
+
+// PaymentWebhookEventRepository armazena os eventos de webhook recebidos dos provedores.
+type PaymentWebhookEventRepository interface {
+	Create(event *entity.PaymentWebhookEvent) error
+	// CreateIfAbsent registra o evento se o par provedor/evento ainda não existir.
+	// Retorna false, sem erro, para entregas repetidas.
+	CreateIfAbsent(event *entity.PaymentWebhookEvent) (bool, error)
+	Update(event *entity.PaymentWebhookEvent) error
+	FindByProviderEventID(provider, eventID string) (*entity.PaymentWebhookEvent, error)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// ProductRepository define a interface para o armazenamento de produtos e do estoque.
+type ProductRepository interface {
+	Create(product *entity.Product) error
+	FindByID(id uuid.UUID) (*entity.Product, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.Product, error)
+	FindBySKU(userID uuid.UUID, sku string) (*entity.Product, error)
+	FindLowStock(userID uuid.UUID) ([]*entity.Product, error) // Produtos ativos com estoque no mínimo ou abaixo
+	Update(product *entity.Product) error                     // Não altera o estoque; use ApplyMovement
+	Delete(id uuid.UUID) error
+	// ApplyMovement registra a movimentação e atualiza o estoque do produto de forma
+	// atômica, preenchendo StockAfter. Saídas que deixariam o estoque negativo falham.
+	ApplyMovement(movement *entity.StockMovement) error
+	FindMovementsByProductID(productID uuid.UUID) ([]*entity.StockMovement, error)
+}
+
+// LowStockAlertRepository define a interface para o armazenamento dos alertas de estoque baixo.
+type LowStockAlertRepository interface {
+	Create(alert *entity.LowStockAlert) error
+	FindOpenByProductID(productID uuid.UUID) (*entity.LowStockAlert, error)
+	FindByUserID(userID uuid.UUID, openOnly bool) ([]*entity.LowStockAlert, error)
+	ResolveByProductID(productID uuid.UUID) error
+}
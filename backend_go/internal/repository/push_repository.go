@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// PushDeviceRepository define a interface para o armazenamento dos dispositivos de push.
+type PushDeviceRepository interface {
+	// Save grava o dispositivo pelo token: cria-o ou atualiza o registro existente, mesmo
+	// que de outro usuário.
+	Save(device *entity.PushDevice) error
+	Delete(id uuid.UUID) error
+	DeleteByToken(token string) error
+	FindByID(id uuid.UUID) (*entity.PushDevice, error)
+	FindByToken(token string) (*entity.PushDevice, error)
+	// FindByUserID lista os dispositivos do usuário, dos usados mais recentemente para os
+	// mais antigos.
+	FindByUserID(userID uuid.UUID) ([]*entity.PushDevice, error)
+}
+
+// PushPreferenceRepository define a interface para o armazenamento das preferências de push.
+type PushPreferenceRepository interface {
+	FindByUserID(userID uuid.UUID) ([]*entity.PushPreference, error)
+	// Save cria ou atualiza a preferência do usuário para o tipo de evento.
+	Save(preference *entity.PushPreference) error
+}
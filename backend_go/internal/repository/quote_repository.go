@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// QuoteRepository define a interface para o armazenamento dos orçamentos e seus itens.
+type QuoteRepository interface {
+	// Create salva o orçamento com seus itens.
+	Create(quote *entity.Quote) error
+	FindByID(id uuid.UUID) (*entity.Quote, error)
+	FindByPublicToken(token string) (*entity.Quote, error)
+	// FindByUserID lista os orçamentos do usuário; status vazio não filtra.
+	FindByUserID(userID uuid.UUID, status entity.QuoteStatus) ([]*entity.Quote, error)
+	// Update salva o orçamento e substitui os itens pelos informados.
+	Update(quote *entity.Quote) error
+	Delete(id uuid.UUID) error
+	// MarkConverted grava a data de conversão e os agendamentos gerados se o orçamento
+	// estiver aceito e ainda não convertido. Retorna false caso contrário.
+	MarkConverted(quote *entity.Quote) (bool, error)
+	// NextNumber retorna o próximo número sequencial de orçamento do usuário.
+	NextNumber(userID uuid.UUID) (int, error)
+	// ExpireSentBefore marca como EXPIRED os orçamentos enviados com validade anterior à data.
+	ExpireSentBefore(date time.Time) (int64, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// ReminderRuleRepository define a interface para o armazenamento das regras de lembrete.
+type ReminderRuleRepository interface {
+	FindByUserID(userID uuid.UUID) ([]*entity.ReminderRule, error)
+	// ReplaceForUser substitui todas as regras do usuário pelas informadas.
+	ReplaceForUser(userID uuid.UUID, rules []*entity.ReminderRule) error
+}
+
+// ReminderRepository define a interface para o armazenamento dos lembretes agendados.
+type ReminderRepository interface {
+	Create(reminder *entity.Reminder) error
+	Update(reminder *entity.Reminder) error
+	FindByAppointmentID(appointmentID uuid.UUID) ([]*entity.Reminder, error)
+	// FindByProviderMessageID busca o lembrete pelo ID da mensagem atribuído pelo canal.
+	FindByProviderMessageID(providerMessageID string) (*entity.Reminder, error)
+	// FindSentByPhoneSuffix lista os lembretes enviados pelo canal cujo destinatário termina
+	// com os dígitos informados, ignorando a formatação.
+	FindSentByPhoneSuffix(channel entity.ReminderChannel, phoneSuffix string) ([]*entity.Reminder, error)
+	// FindByUserID lista os lembretes do usuário, dos mais recentes para os mais antigos;
+	// appointmentID nil e status vazio não filtram.
+	FindByUserID(userID uuid.UUID, appointmentID *uuid.UUID, status entity.ReminderStatus) ([]*entity.Reminder, error)
+	// FindDue lista os lembretes agendados com horário de envio até now, dos mais antigos
+	// para os mais novos.
+	FindDue(now time.Time, limit int) ([]*entity.Reminder, error)
+	// Claim reserva atomicamente um lembrete agendado para envio, adiando o horário de
+	// envio para leaseUntil. Retorna false se outro processo já o reservou.
+	Claim(id uuid.UUID, now, leaseUntil time.Time) (bool, error)
+}
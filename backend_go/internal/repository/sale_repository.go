@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// SaleRepository define a interface para o armazenamento das vendas de balcão.
+type SaleRepository interface {
+	// Create grava a venda com seus itens e pagamentos, os pacotes vendidos aos clientes,
+	// as saídas de estoque, os lançamentos no livro-caixa e os eventos de domínio em uma
+	// única transação.
+	Create(sale *entity.Sale, clientPackages []*entity.ClientPackage, movements []*entity.StockMovement, entries []*entity.FinancialEntry, events []*entity.DomainEvent) error
+	FindByID(id uuid.UUID) (*entity.Sale, error)
+	// FindByUserID lista as vendas do usuário no intervalo [from, to) sobre SoldAt.
+	FindByUserID(userID uuid.UUID, from, to time.Time) ([]*entity.Sale, error)
+	// NextNumber retorna o próximo número sequencial de venda do usuário.
+	NextNumber(userID uuid.UUID) (int, error)
+}
+
+// CashRegisterRepository define a interface para o armazenamento dos caixas.
+type CashRegisterRepository interface {
+	// Create abre um caixa. Falha se o usuário já tiver um caixa aberto.
+	Create(register *entity.CashRegister) error
+	FindByID(id uuid.UUID) (*entity.CashRegister, error)
+	FindOpenByUserID(userID uuid.UUID) (*entity.CashRegister, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.CashRegister, error)
+	// Close grava o fechamento do caixa. Retorna false, sem gravar nada, se o caixa já
+	// estiver fechado.
+	Close(register *entity.CashRegister) (bool, error)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// TaxProfileRepository define a interface para o armazenamento do perfil tributário.
+type TaxProfileRepository interface {
+	Save(profile *entity.TaxProfile) error // Cria ou atualiza o perfil do usuário
+	FindByUserID(userID uuid.UUID) (*entity.TaxProfile, error)
+	FindAll() ([]*entity.TaxProfile, error)
+}
+
+// RevenueLimitAlertRepository define a interface para o armazenamento dos alertas de teto.
+type RevenueLimitAlertRepository interface {
+	Create(alert *entity.RevenueLimitAlert) error
+	FindByUserID(userID uuid.UUID) ([]*entity.RevenueLimitAlert, error)
+	FindByUserYearThreshold(userID uuid.UUID, year, threshold int) (*entity.RevenueLimitAlert, error)
+}
+
+// RevenueRepository agrega o faturamento bruto do usuário.
+// O faturamento considera o preço dos agendamentos concluídos mais as entradas
+// do livro-caixa que não estão vinculadas a agendamentos (evitando contar duas vezes
+// o recebimento de um atendimento).
+type RevenueRepository interface {
+	SumRevenue(userID uuid.UUID, from, to time.Time) (float64, error) // Intervalo [from, to)
+	// RevenueByMonth agrega o faturamento do intervalo por mês, natureza e emissão de nota.
+	RevenueByMonth(userID uuid.UUID, from, to time.Time) ([]RevenueBucket, error)
+}
+
+// RevenueBucket é o faturamento de um mês para uma natureza de receita e situação fiscal.
+// Agendamentos concluídos são sempre prestação de serviços.
+type RevenueBucket struct {
+	Month    time.Month
+	Kind     entity.RevenueKind
+	Invoiced bool
+	Amount   float64
+}
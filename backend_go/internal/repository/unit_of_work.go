@@ -0,0 +1,32 @@
+package repository
+
+// Transaction dá acesso aos repositórios que participam de uma mesma transação.
+type Transaction interface {
+	Appointments() AppointmentRepository
+	Clients() ClientRepository
+	Payments() PaymentRepository
+	PaymentWebhookEvents() PaymentWebhookEventRepository
+	FinancialEntries() FinancialEntryRepository
+	RecurringExpenses() RecurringExpenseRepository
+	ClientPackages() ClientPackageRepository
+	PackageCreditUsages() PackageCreditUsageRepository
+	Commissions() CommissionRepository
+	MembershipSubscriptions() MembershipSubscriptionRepository
+	MembershipCycles() MembershipCycleRepository
+	MembershipUsages() MembershipUsageRepository
+	Checkouts() CheckoutRepository
+	Coupons() CouponRepository
+	GiftCards() GiftCardRepository
+	GiftCardTransactions() GiftCardTransactionRepository
+	Quotes() QuoteRepository
+	IncomeForecasts() IncomeForecastRepository
+	CouponRedemptions() CouponRedemptionRepository
+	Events() DomainEventRepository
+}
+
+// UnitOfWork executa alterações em vários repositórios atomicamente: ou todas são
+// gravadas, ou nenhuma.
+type UnitOfWork interface {
+	// Do executa fn em uma transação, confirmada se fn retornar nil e desfeita caso contrário.
+	Do(fn func(tx Transaction) error) error
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// WebhookSubscriptionRepository define a interface para o armazenamento das assinaturas de webhook.
+type WebhookSubscriptionRepository interface {
+	Create(subscription *entity.WebhookSubscription) error
+	Update(subscription *entity.WebhookSubscription) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*entity.WebhookSubscription, error)
+	FindByUserID(userID uuid.UUID) ([]*entity.WebhookSubscription, error)
+	// FindActiveByUserID lista as assinaturas ativas do usuário.
+	FindActiveByUserID(userID uuid.UUID) ([]*entity.WebhookSubscription, error)
+}
+
+// WebhookDeliveryRepository define a interface para o armazenamento dos envios de webhook.
+type WebhookDeliveryRepository interface {
+	// CreateIfAbsent grava o envio, a menos que a assinatura já tenha um envio original
+	// (não reenviado) do mesmo evento. Retorna false se já existia.
+	CreateIfAbsent(delivery *entity.WebhookDelivery) (bool, error)
+	Create(delivery *entity.WebhookDelivery) error
+	Update(delivery *entity.WebhookDelivery) error
+	FindByID(id uuid.UUID) (*entity.WebhookDelivery, error)
+	// FindBySubscriptionID lista os envios da assinatura, dos mais recentes para os mais
+	// antigos; status vazio não filtra.
+	FindBySubscriptionID(subscriptionID uuid.UUID, status entity.WebhookDeliveryStatus, limit int) ([]*entity.WebhookDelivery, error)
+	// FindDue lista os envios pendentes com próxima tentativa até now, dos mais antigos
+	// para os mais novos.
+	FindDue(now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	// Claim reserva atomicamente um envio pendente, adiando a próxima tentativa para
+	// leaseUntil. Retorna false se outro processo já o reservou.
+	Claim(id uuid.UUID, now, leaseUntil time.Time) (bool, error)
+	// FailPendingBySubscriptionID marca como FAILED os envios pendentes da assinatura.
+	FailPendingBySubscriptionID(subscriptionID uuid.UUID, reason string) error
+	CreateAttempt(attempt *entity.WebhookAttempt) error
+	// FindAttempts lista as tentativas do envio, da primeira para a última.
+	FindAttempts(deliveryID uuid.UUID) ([]*entity.WebhookAttempt, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/google/uuid"
+)
+
+// WhatsAppMessageRepository define a interface para o armazenamento das mensagens de WhatsApp.
+type WhatsAppMessageRepository interface {
+	Create(message *entity.WhatsAppMessage) error
+	// FindByProviderMessageID busca a mensagem pelo ID atribuído pelo provedor.
+	FindByProviderMessageID(providerMessageID string) (*entity.WhatsAppMessage, error)
+	// ExistsForAppointment indica se já há mensagem do tipo informado para o agendamento.
+	ExistsForAppointment(appointmentID uuid.UUID, kind entity.WhatsAppMessageKind) (bool, error)
+	// FindOutboundByPhones lista as mensagens enviadas com sucesso, dos tipos informados e
+	// ligadas a um agendamento, para qualquer um dos telefones (já normalizados).
+	FindOutboundByPhones(phones []string, kinds []entity.WhatsAppMessageKind) ([]*entity.WhatsAppMessage, error)
+	// FindByUserID lista as mensagens do usuário, das mais recentes para as mais antigas;
+	// appointmentID nil não filtra.
+	FindByUserID(userID uuid.UUID, appointmentID *uuid.UUID) ([]*entity.WhatsAppMessage, error)
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrTooManyAgendaStreams indica que o usuário atingiu o limite de conexões abertas.
+var ErrTooManyAgendaStreams = errors.New("limite de conexões de atualização da agenda atingido")
+
+const (
+	// AgendaStreamSubscriberName identifica a agenda em tempo real como assinante do EventDispatcher.
+	AgendaStreamSubscriberName = "agenda-stream"
+
+	// agendaStreamBuffer é quantos eventos aguardam envio em cada conexão; uma conexão
+	// que fica para trás é encerrada e o cliente retoma pelo último evento recebido.
+	agendaStreamBuffer = 64
+	// maxAgendaStreamsPerUser limita as conexões abertas de cada usuário (abas e aparelhos).
+	maxAgendaStreamsPerUser = 20
+	// maxAgendaStreamBacklog é o número máximo de eventos reenviados na retomada; acima
+	// disso o cliente deve recarregar a agenda.
+	maxAgendaStreamBacklog = 500
+)
+
+// AgendaStreamEventTypes são os eventos enviados às conexões da agenda em tempo real.
+var AgendaStreamEventTypes = []entity.DomainEventType{
+	entity.DomainEventAppointmentCreated,
+	entity.DomainEventAppointmentUpdated,
+	entity.DomainEventAppointmentRescheduled,
+	entity.DomainEventAppointmentConfirmed,
+	entity.DomainEventAppointmentCancelled,
+	entity.DomainEventAppointmentCompleted,
+	entity.DomainEventAppointmentDeleted,
+}
+
+// AgendaStream é uma conexão aberta da agenda em tempo real. Events é fechado quando a
+// conexão é encerrada pelo hub (ex: cliente lento); Close deve ser chamado ao terminar.
+type AgendaStream struct {
+	userID  uuid.UUID
+	events  chan *entity.DomainEvent
+	closed  bool // Protegido pelo mutex do AgendaStreamUseCase
+	release func(*AgendaStream)
+}
+
+// Events retorna o canal com os eventos da agenda do usuário.
+func (s *AgendaStream) Events() <-chan *entity.DomainEvent {
+	return s.events
+}
+
+// Close encerra a conexão e libera seus recursos. Pode ser chamado mais de uma vez.
+func (s *AgendaStream) Close() {
+	s.release(s)
+}
+
+// AgendaStreamUseCase distribui as alterações de agendamento às conexões abertas do
+// dono do negócio (ex: o app do profissional enquanto a recepção agenda). Recebe os
+// eventos do EventDispatcher e os repassa sem bloquear: cada conexão tem seu próprio
+// buffer, e as que não acompanham são encerradas para não atrasar as demais.
+//
+// As conexões ficam em memória; com mais de uma instância da API, cada evento chega
+// apenas às conexões da instância que o processou.
+type AgendaStreamUseCase struct {
+	eventRepo repository.DomainEventRepository
+	mu        sync.RWMutex
+	streams   map[uuid.UUID]map[*AgendaStream]struct{}
+}
+
+// NewAgendaStreamUseCase cria uma nova instância de AgendaStreamUseCase.
+func NewAgendaStreamUseCase(eventRepo repository.DomainEventRepository) *AgendaStreamUseCase {
+	return &AgendaStreamUseCase{
+		eventRepo: eventRepo,
+		streams:   make(map[uuid.UUID]map[*AgendaStream]struct{}),
+	}
+}
+
+// Open abre uma conexão para os eventos da agenda do usuário.
+func (uc *AgendaStreamUseCase) Open(userID uuid.UUID) (*AgendaStream, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if len(uc.streams[userID]) >= maxAgendaStreamsPerUser {
+		return nil, ErrTooManyAgendaStreams
+	}
+	stream := &AgendaStream{
+		userID:  userID,
+		events:  make(chan *entity.DomainEvent, agendaStreamBuffer),
+		release: uc.release,
+	}
+	if uc.streams[userID] == nil {
+		uc.streams[userID] = make(map[*AgendaStream]struct{})
+	}
+	uc.streams[userID][stream] = struct{}{}
+	return stream, nil
+}
+
+// release remove a conexão do hub e fecha seu canal, se ainda aberto.
+func (uc *AgendaStreamUseCase) release(stream *AgendaStream) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.closeLocked(stream)
+}
+
+func (uc *AgendaStreamUseCase) closeLocked(stream *AgendaStream) {
+	if stream.closed {
+		return
+	}
+	stream.closed = true
+	close(stream.events)
+	delete(uc.streams[stream.userID], stream)
+	if len(uc.streams[stream.userID]) == 0 {
+		delete(uc.streams, stream.userID)
+	}
+}
+
+// Backlog retorna os eventos da agenda ocorridos depois de lastEventID, para retomar uma
+// conexão interrompida. Retorna reset = true quando a retomada não é possível (evento
+// desconhecido ou eventos demais) e o cliente deve recarregar a agenda.
+func (uc *AgendaStreamUseCase) Backlog(userID, lastEventID uuid.UUID) ([]*entity.DomainEvent, bool, error) {
+	last, err := uc.eventRepo.FindByID(lastEventID)
+	if err != nil {
+		return nil, false, errors.New("erro ao buscar evento: " + err.Error())
+	}
+	if last == nil || last.UserID != userID {
+		return nil, true, nil
+	}
+	events, err := uc.eventRepo.FindByUserIDAfter(userID, AgendaStreamEventTypes, last, maxAgendaStreamBacklog+1)
+	if err != nil {
+		return nil, false, errors.New("erro ao buscar eventos: " + err.Error())
+	}
+	if len(events) > maxAgendaStreamBacklog {
+		return nil, true, nil
+	}
+	return events, false, nil
+}
+
+// SubscriberName identifica a agenda em tempo real no EventDispatcher.
+func (uc *AgendaStreamUseCase) SubscriberName() string {
+	return AgendaStreamSubscriberName
+}
+
+// HandleEvent repassa o evento às conexões abertas do dono. Nunca falha: conexões que
+// perderem eventos os recuperam ao retomar pelo último evento recebido.
+func (uc *AgendaStreamUseCase) HandleEvent(event *entity.DomainEvent) error {
+	uc.mu.RLock()
+	var slow []*AgendaStream
+	for stream := range uc.streams[event.UserID] {
+		select {
+		case stream.events <- event:
+		default:
+			slow = append(slow, stream)
+		}
+	}
+	uc.mu.RUnlock()
+
+	if len(slow) > 0 {
+		uc.mu.Lock()
+		for _, stream := range slow {
+			uc.closeLocked(stream)
+		}
+		uc.mu.Unlock()
+	}
+	return nil
+}
@@ -2,26 +2,175 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
 	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
-	// "log" // Para debug
 )
 
+// AppointmentCompletionListener é notificado quando um agendamento passa para COMPLETED.
+type AppointmentCompletionListener interface {
+	OnAppointmentCompleted(appointment *entity.Appointment)
+}
+
+// AppointmentCompletionPolicy aplica os efeitos da conclusão de um agendamento que
+// movimentam valores ou créditos (ex: comissão do profissional, consumo de pacote) na
+// transação que conclui o agendamento: um erro desfaz a conclusão. Pode alterar o preço
+// do agendamento, que é gravado em seguida.
+type AppointmentCompletionPolicy interface {
+	CompleteAppointment(tx repository.Transaction, appointment *entity.Appointment) error
+}
+
+// AppointmentChangeListener é notificado sempre que um agendamento é criado, alterado,
+// cancelado ou excluído (ex: para agendar ou cancelar lembretes). previous é o estado
+// anterior à alteração e é nil na criação.
+type AppointmentChangeListener interface {
+	OnAppointmentChanged(previous, appointment *entity.Appointment)
+	OnAppointmentDeleted(appointment *entity.Appointment)
+}
+
+// AppointmentPricingPolicy pode ajustar o preço de um agendamento antes de ele ser salvo
+// (ex: benefícios da assinatura do cliente). Só é aplicada quando o preço não foi informado,
+// na transação que cria o agendamento; um erro desfaz a criação.
+type AppointmentPricingPolicy interface {
+	PriceAppointment(tx repository.Transaction, appointment *entity.Appointment) error
+}
+
+// ErrScheduleConflict indica que o horário do agendamento não está disponível.
+var ErrScheduleConflict = errors.New("horário indisponível")
+
+// ErrInvalidStatusTransition indica que o agendamento não pode passar para o status pedido
+// (ex: concluir um agendamento cancelado ou já concluído por outra operação).
+var ErrInvalidStatusTransition = errors.New("mudança de status inválida")
+
+// AppointmentAvailabilityCheck verifica se o horário do agendamento está livre (ex: sem
+// compromissos no calendário externo do profissional). Conflitos devem envolver
+// ErrScheduleConflict.
+type AppointmentAvailabilityCheck interface {
+	CheckAvailability(appointment *entity.Appointment) error
+}
+
+// AppointmentCouponRedeemer valida um cupom de desconto e o aplica ao preço do agendamento,
+// registrando o uso na transação que cria o agendamento. Erros de validação devem envolver
+// ErrInvalidCoupon.
+type AppointmentCouponRedeemer interface {
+	RedeemCoupon(tx repository.Transaction, code string, appointment *entity.Appointment) error
+}
+
 // AppointmentUseCase encapsula a lógica de negócios relacionada a agendamentos.
 type AppointmentUseCase struct {
-	appointmentRepo repository.AppointmentRepository
-	userRepo        repository.UserRepository // Para verificar se o UserID existe, se necessário
+	appointmentRepo     repository.AppointmentRepository
+	uow                 repository.UnitOfWork
+	userRepo            repository.UserRepository // Para verificar se o UserID existe, se necessário
+	serviceRepo         repository.ServiceRepository
+	professionalRepo    repository.ProfessionalRepository
+	clientRepo          repository.ClientRepository
+	completionPolicies  []AppointmentCompletionPolicy
+	completionListeners []AppointmentCompletionListener
+	changeListeners     []AppointmentChangeListener
+	pricingPolicies     []AppointmentPricingPolicy
+	couponRedeemer      AppointmentCouponRedeemer
+	availabilityChecks  []AppointmentAvailabilityCheck
 }
 
 // NewAppointmentUseCase cria uma nova instância de AppointmentUseCase.
-func NewAppointmentUseCase(appRepo repository.AppointmentRepository, userRepo repository.UserRepository) *AppointmentUseCase {
+func NewAppointmentUseCase(appRepo repository.AppointmentRepository, userRepo repository.UserRepository, serviceRepo repository.ServiceRepository, professionalRepo repository.ProfessionalRepository, clientRepo repository.ClientRepository, uow repository.UnitOfWork) *AppointmentUseCase {
 	return &AppointmentUseCase{
-		appointmentRepo: appRepo,
-		userRepo:        userRepo,
+		appointmentRepo:  appRepo,
+		uow:              uow,
+		userRepo:         userRepo,
+		serviceRepo:      serviceRepo,
+		professionalRepo: professionalRepo,
+		clientRepo:       clientRepo,
+	}
+}
+
+// AddCompletionPolicy registra uma política aplicada, na ordem de registro, na transação
+// em que um agendamento é concluído.
+func (uc *AppointmentUseCase) AddCompletionPolicy(policy AppointmentCompletionPolicy) {
+	uc.completionPolicies = append(uc.completionPolicies, policy)
+}
+
+// applyCompletionPolicies aplica as políticas de conclusão na transação quando o agendamento
+// passa para COMPLETED. O agendamento é bloqueado antes; se ele estiver cancelado ou uma
+// operação concorrente já o concluiu, retorna ErrInvalidStatusTransition.
+func (uc *AppointmentUseCase) applyCompletionPolicies(tx repository.Transaction, previous, appointment *entity.Appointment) error {
+	if previous == nil || previous.Status == entity.AppointmentStatusCompleted || appointment.Status != entity.AppointmentStatusCompleted {
+		return nil
+	}
+	current, err := tx.Appointments().LockByID(appointment.ID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errors.New("agendamento não encontrado")
+	}
+	switch current.Status {
+	case entity.AppointmentStatusCancelled:
+		return fmt.Errorf("%w: agendamento cancelado não pode ser concluído", ErrInvalidStatusTransition)
+	case entity.AppointmentStatusCompleted:
+		return fmt.Errorf("%w: agendamento já foi concluído por outra operação", ErrInvalidStatusTransition)
+	}
+	for _, policy := range uc.completionPolicies {
+		if err := policy.CompleteAppointment(tx, appointment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddCompletionListener registra um listener chamado quando um agendamento é concluído.
+func (uc *AppointmentUseCase) AddCompletionListener(listener AppointmentCompletionListener) {
+	uc.completionListeners = append(uc.completionListeners, listener)
+}
+
+// notifyCompleted avisa os listeners de que o agendamento foi concluído.
+func (uc *AppointmentUseCase) notifyCompleted(appointment *entity.Appointment) {
+	for _, listener := range uc.completionListeners {
+		listener.OnAppointmentCompleted(appointment)
+	}
+}
+
+// AddChangeListener registra um listener chamado quando um agendamento é criado,
+// alterado, cancelado ou excluído.
+func (uc *AppointmentUseCase) AddChangeListener(listener AppointmentChangeListener) {
+	uc.changeListeners = append(uc.changeListeners, listener)
+}
+
+// notifyChanged avisa os listeners de que o agendamento foi criado ou alterado.
+func (uc *AppointmentUseCase) notifyChanged(previous, appointment *entity.Appointment) {
+	for _, listener := range uc.changeListeners {
+		listener.OnAppointmentChanged(previous, appointment)
+	}
+}
+
+// AddPricingPolicy registra uma política de preço aplicada na criação de agendamentos.
+func (uc *AppointmentUseCase) AddPricingPolicy(policy AppointmentPricingPolicy) {
+	uc.pricingPolicies = append(uc.pricingPolicies, policy)
+}
+
+// AddAvailabilityCheck registra uma verificação de disponibilidade aplicada quando um
+// agendamento é criado ou tem o horário alterado.
+func (uc *AppointmentUseCase) AddAvailabilityCheck(check AppointmentAvailabilityCheck) {
+	uc.availabilityChecks = append(uc.availabilityChecks, check)
+}
+
+// checkAvailability aplica as verificações de disponibilidade ao agendamento.
+func (uc *AppointmentUseCase) checkAvailability(appointment *entity.Appointment) error {
+	for _, check := range uc.availabilityChecks {
+		if err := check.CheckAvailability(appointment); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// SetCouponRedeemer define o responsável por aplicar cupons de desconto na criação de agendamentos.
+func (uc *AppointmentUseCase) SetCouponRedeemer(redeemer AppointmentCouponRedeemer) {
+	uc.couponRedeemer = redeemer
 }
 
 // CreateAppointmentInputDTO define os dados necessários para criar um agendamento.
@@ -29,24 +178,67 @@ func NewAppointmentUseCase(appRepo repository.AppointmentRepository, userRepo re
 type CreateAppointmentInputDTO struct {
 	UserID            uuid.UUID // ID do usuário (profissional) que está criando o agendamento
 	ClientID          *uuid.UUID
+	CustomerID        *uuid.UUID // Cliente do cadastro; se informado, preenche nome e contato vazios
 	ClientName        string
 	ClientEmail       string
 	ClientPhone       string
 	ServiceDescription string
+	ServiceID         *uuid.UUID // Se informado, preenche descrição e preço vazios a partir do catálogo
+	ProfessionalID    *uuid.UUID
 	StartTime         time.Time
 	EndTime           time.Time
 	Notes             string
-	Price             float64
+	Price             float64 // Se zero, usa o preço do catálogo e as políticas de preço
+	CouponCode        string  // Opcional: cupom de desconto aplicado sobre o preço final
 	// Status inicial é geralmente PENDING, não precisa ser input
 }
 
 // CreateAppointment cria um novo agendamento.
 func (uc *AppointmentUseCase) CreateAppointment(input CreateAppointmentInputDTO) (*entity.Appointment, error) {
+	appointment, err := uc.prepareAppointment(input)
+	if err != nil {
+		return nil, err
+	}
+	if input.CouponCode != "" && uc.couponRedeemer == nil {
+		return nil, errors.New("cupons de desconto não estão disponíveis")
+	}
+
+	// Os usos da assinatura e do cupom são registrados na mesma transação, para serem
+	// desfeitos se a criação falhar.
+	var couponErr error
+	err = uc.uow.Do(func(tx repository.Transaction) error {
+		if err := uc.applyPricingPolicies(tx, appointment, input.Price != 0); err != nil {
+			return err
+		}
+		if input.CouponCode != "" {
+			if couponErr = uc.couponRedeemer.RedeemCoupon(tx, input.CouponCode, appointment); couponErr != nil {
+				return couponErr
+			}
+		}
+		appointment.DiscountAmount = math.Round((appointment.OriginalPrice-appointment.Price)*100) / 100
+		return uc.writeAppointment(tx, nil, appointment)
+	})
+	if couponErr != nil {
+		return nil, couponErr
+	}
+	if err != nil {
+		// log.Printf("Erro ao criar agendamento no repositório: %v", err)
+		return nil, errors.New("falha ao salvar agendamento: " + err.Error())
+	}
+	uc.notifyChanged(nil, appointment)
+
+	return appointment, nil
+}
+
+// prepareAppointment valida os dados, verifica a disponibilidade do horário e monta o
+// agendamento com o preço do catálogo, sem gravá-lo. As políticas de preço são aplicadas
+// depois, na transação que grava o agendamento (ver applyPricingPolicies).
+func (uc *AppointmentUseCase) prepareAppointment(input CreateAppointmentInputDTO) (*entity.Appointment, error) {
 	// Validações de negócio:
 	// - UserID existe? (uc.userRepo.FindByID(input.UserID))
 	// - ClientID existe, se fornecido? (uc.userRepo.FindByID(*input.ClientID)))
 	// - StartTime é antes de EndTime?
-	// - Não há conflitos de horário para este UserID? (Lógica mais complexa)
+	// - Não há conflitos de horário para este UserID? (ver checkAvailability)
 	// - Outras validações...
 
 	if input.UserID == uuid.Nil {
@@ -63,16 +255,53 @@ func (uc *AppointmentUseCase) CreateAppointment(input CreateAppointmentInputDTO)
 	// if err != nil || professional == nil {
 	// 	return nil, errors.New("profissional (usuário) não encontrado")
 	// }
-
+	if input.ServiceID != nil {
+		service, err := uc.findService(input.UserID, *input.ServiceID)
+		if err != nil {
+			return nil, err
+		}
+		if input.ServiceDescription == "" {
+			input.ServiceDescription = service.Name
+		}
+		if input.Price == 0 {
+			input.Price = service.Price
+		}
+	}
+	if input.ServiceDescription == "" {
+		return nil, errors.New("descrição do serviço é obrigatória")
+	}
+	if input.ProfessionalID != nil {
+		if err := uc.validateProfessional(input.UserID, *input.ProfessionalID); err != nil {
+			return nil, err
+		}
+	}
+	if input.CustomerID != nil {
+		customer, err := uc.findCustomer(input.UserID, *input.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if input.ClientName == "" {
+			input.ClientName = customer.Name
+		}
+		if input.ClientEmail == "" {
+			input.ClientEmail = customer.Email
+		}
+		if input.ClientPhone == "" {
+			input.ClientPhone = customer.Phone
+		}
+	}
 
 	appointment := &entity.Appointment{
 		ID:                uuid.New(), // Gerar novo UUID para o agendamento
 		UserID:            input.UserID,
 		ClientID:          input.ClientID,
+		CustomerID:        input.CustomerID,
 		ClientName:        input.ClientName,
 		ClientEmail:       input.ClientEmail,
 		ClientPhone:       input.ClientPhone,
 		ServiceDescription: input.ServiceDescription,
+		ServiceID:         input.ServiceID,
+		ProfessionalID:    input.ProfessionalID,
 		StartTime:         input.StartTime,
 		EndTime:           input.EndTime,
 		Status:            entity.AppointmentStatusPending, // Status inicial
@@ -81,15 +310,29 @@ func (uc *AppointmentUseCase) CreateAppointment(input CreateAppointmentInputDTO)
 		// CreatedAt e UpdatedAt serão preenchidos pelo GORM/repo
 	}
 
-	err := uc.appointmentRepo.Create(appointment)
-	if err != nil {
-		// log.Printf("Erro ao criar agendamento no repositório: %v", err)
-		return nil, errors.New("falha ao salvar agendamento: " + err.Error())
+	// Verificado antes dos descontos, para não registrar o uso do cupom em vão.
+	if err := uc.checkAvailability(appointment); err != nil {
+		return nil, err
 	}
 
+	appointment.OriginalPrice = appointment.Price
 	return appointment, nil
 }
 
+// applyPricingPolicies aplica as políticas de preço ao agendamento na transação tx,
+// quando o preço não foi informado, e recalcula o desconto.
+func (uc *AppointmentUseCase) applyPricingPolicies(tx repository.Transaction, appointment *entity.Appointment, priceInformed bool) error {
+	if !priceInformed {
+		for _, policy := range uc.pricingPolicies {
+			if err := policy.PriceAppointment(tx, appointment); err != nil {
+				return err
+			}
+		}
+	}
+	appointment.DiscountAmount = math.Round((appointment.OriginalPrice-appointment.Price)*100) / 100
+	return nil
+}
+
 // GetAppointmentByID busca um agendamento pelo seu ID.
 // Verifica se o userID fornecido (do token) tem permissão para ver este agendamento.
 func (uc *AppointmentUseCase) GetAppointmentByID(appointmentID, requestingUserID uuid.UUID) (*entity.Appointment, error) {
@@ -132,6 +375,10 @@ type UpdateAppointmentInputDTO struct {
 	Status            *entity.AppointmentStatus
 	Notes             *string
 	Price             *float64
+	Invoiced          *bool
+	ServiceID         *uuid.UUID
+	ProfessionalID    *uuid.UUID
+	CustomerID        *uuid.UUID
 }
 
 // UpdateAppointment atualiza um agendamento existente.
@@ -142,6 +389,9 @@ func (uc *AppointmentUseCase) UpdateAppointment(appointmentID, requestingUserID
 		return nil, err // Erro já tratado por GetAppointmentByID (não encontrado ou não autorizado)
 	}
 
+	previous := *existingAppointment
+	wasCompleted := existingAppointment.Status == entity.AppointmentStatusCompleted
+
 	// Aplicar atualizações da input para a entidade existente
 	updated := false
 	if input.ClientID != nil {
@@ -181,7 +431,34 @@ func (uc *AppointmentUseCase) UpdateAppointment(appointmentID, requestingUserID
 		updated = true
 	}
 	if input.Price != nil {
+		// Preço ajustado manualmente substitui o preço com desconto; o desconto registrado é mantido
 		existingAppointment.Price = *input.Price
+		existingAppointment.OriginalPrice = existingAppointment.Price + existingAppointment.DiscountAmount
+		updated = true
+	}
+	if input.Invoiced != nil {
+		existingAppointment.Invoiced = *input.Invoiced
+		updated = true
+	}
+	if input.ServiceID != nil {
+		if _, err := uc.findService(requestingUserID, *input.ServiceID); err != nil {
+			return nil, err
+		}
+		existingAppointment.ServiceID = input.ServiceID
+		updated = true
+	}
+	if input.ProfessionalID != nil {
+		if err := uc.validateProfessional(requestingUserID, *input.ProfessionalID); err != nil {
+			return nil, err
+		}
+		existingAppointment.ProfessionalID = input.ProfessionalID
+		updated = true
+	}
+	if input.CustomerID != nil {
+		if _, err := uc.findCustomer(requestingUserID, *input.CustomerID); err != nil {
+			return nil, err
+		}
+		existingAppointment.CustomerID = input.CustomerID
 		updated = true
 	}
 
@@ -194,17 +471,99 @@ func (uc *AppointmentUseCase) UpdateAppointment(appointmentID, requestingUserID
 		// log.Println("Nenhum campo fornecido para atualização do agendamento.")
 		return existingAppointment, nil // Ou retorne um erro "nada para atualizar"
 	}
+	timeChanged := !previous.StartTime.Equal(existingAppointment.StartTime) || !previous.EndTime.Equal(existingAppointment.EndTime)
+	if timeChanged && reminderAppointmentActive(existingAppointment) {
+		if err := uc.checkAvailability(existingAppointment); err != nil {
+			return nil, err
+		}
+	}
 	// existingAppointment.UpdatedAt será atualizado pelo GORM
 
-	err = uc.appointmentRepo.Update(existingAppointment)
+	err = uc.saveAppointment(&previous, existingAppointment)
+	if errors.Is(err, ErrInvalidStatusTransition) {
+		return nil, err
+	}
 	if err != nil {
 		// log.Printf("Erro ao atualizar agendamento %s no repositório: %v", appointmentID, err)
 		return nil, errors.New("falha ao atualizar agendamento: " + err.Error())
 	}
 
+	if !wasCompleted && existingAppointment.Status == entity.AppointmentStatusCompleted {
+		uc.notifyCompleted(existingAppointment)
+	}
+	uc.notifyChanged(&previous, existingAppointment)
+
 	return existingAppointment, nil
 }
 
+// saveAppointment grava o agendamento (criando-o quando previous é nil) e os eventos de
+// domínio da alteração na mesma transação, aplicando antes as políticas de conclusão se
+// ele foi concluído. Cada alteração incrementa a revisão do agendamento, para que os
+// calendários externos substituam a versão anterior.
+func (uc *AppointmentUseCase) saveAppointment(previous, appointment *entity.Appointment) error {
+	if previous != nil {
+		appointment.Sequence = previous.Sequence + 1
+	}
+	return uc.uow.Do(func(tx repository.Transaction) error {
+		return uc.writeAppointment(tx, previous, appointment)
+	})
+}
+
+// writeAppointment grava o agendamento e os eventos dele na transação informada.
+func (uc *AppointmentUseCase) writeAppointment(tx repository.Transaction, previous, appointment *entity.Appointment) error {
+	if err := uc.applyCompletionPolicies(tx, previous, appointment); err != nil {
+		return err
+	}
+	events, err := appointmentEvents(previous, appointment)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		if err := tx.Appointments().Create(appointment); err != nil {
+			return err
+		}
+	} else if err := tx.Appointments().Update(appointment); err != nil {
+		return err
+	}
+	return tx.Events().Append(events...)
+}
+
+// findService busca um serviço do catálogo verificando se pertence ao usuário.
+func (uc *AppointmentUseCase) findService(userID, serviceID uuid.UUID) (*entity.Service, error) {
+	service, err := uc.serviceRepo.FindByID(serviceID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar serviço: " + err.Error())
+	}
+	if service == nil || service.UserID != userID {
+		return nil, errors.New("serviço não encontrado")
+	}
+	return service, nil
+}
+
+// findCustomer busca um cliente do cadastro verificando se pertence ao usuário.
+func (uc *AppointmentUseCase) findCustomer(userID, customerID uuid.UUID) (*entity.Client, error) {
+	customer, err := uc.clientRepo.FindByID(customerID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar cliente: " + err.Error())
+	}
+	if customer == nil || customer.UserID != userID {
+		return nil, errors.New("cliente não encontrado")
+	}
+	return customer, nil
+}
+
+// validateProfessional garante que o profissional existe e pertence ao usuário.
+func (uc *AppointmentUseCase) validateProfessional(userID, professionalID uuid.UUID) error {
+	professional, err := uc.professionalRepo.FindByID(professionalID)
+	if err != nil {
+		return errors.New("erro ao buscar profissional: " + err.Error())
+	}
+	if professional == nil || professional.UserID != userID {
+		return errors.New("profissional não encontrado")
+	}
+	return nil
+}
+
 // CancelAppointment cancela um agendamento (exemplo de mudança de status).
 // Verifica se o userID fornecido (do token) tem permissão.
 func (uc *AppointmentUseCase) CancelAppointment(appointmentID, requestingUserID uuid.UUID) (*entity.Appointment, error) {
@@ -218,13 +577,15 @@ func (uc *AppointmentUseCase) CancelAppointment(appointmentID, requestingUserID
 		return nil, errors.New("agendamento não pode ser cancelado no status atual: " + string(appointment.Status))
 	}
 
+	previous := *appointment
 	appointment.Status = entity.AppointmentStatusCancelled
 	// appointment.UpdatedAt será atualizado pelo GORM
 
-	err = uc.appointmentRepo.Update(appointment) // Reutiliza o método Update do repo
+	err = uc.saveAppointment(&previous, appointment)
 	if err != nil {
 		return nil, errors.New("falha ao cancelar agendamento: " + err.Error())
 	}
+	uc.notifyChanged(&previous, appointment)
 
 	return appointment, nil
 }
@@ -232,10 +593,26 @@ func (uc *AppointmentUseCase) CancelAppointment(appointmentID, requestingUserID
 // DeleteAppointment exclui um agendamento.
 // Verifica se o userID fornecido (do token) tem permissão.
 func (uc *AppointmentUseCase) DeleteAppointment(appointmentID, requestingUserID uuid.UUID) error {
-	_, err := uc.GetAppointmentByID(appointmentID, requestingUserID) // Reutiliza a verificação de permissão
+	appointment, err := uc.GetAppointmentByID(appointmentID, requestingUserID) // Reutiliza a verificação de permissão
 	if err != nil {
 		return err // Erro já tratado por GetAppointmentByID (não encontrado ou não autorizado)
 	}
 
-	return uc.appointmentRepo.Delete(appointmentID)
+	event, err := entity.NewDomainEvent(appointment.UserID, entity.DomainEventAppointmentDeleted, appointment.ID, appointmentEventPayload(nil, appointment))
+	if err != nil {
+		return err
+	}
+	err = uc.uow.Do(func(tx repository.Transaction) error {
+		if err := tx.Appointments().Delete(appointmentID); err != nil {
+			return err
+		}
+		return tx.Events().Append(event)
+	})
+	if err != nil {
+		return err
+	}
+	for _, listener := range uc.changeListeners {
+		listener.OnAppointmentDeleted(appointment)
+	}
+	return nil
 }
\ No newline at end of file
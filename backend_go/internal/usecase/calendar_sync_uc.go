@@ -0,0 +1,578 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/caldav"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/ical"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCalendarConnection indica que os dados da conexão com o calendário externo
+// são inválidos ou foram recusados pelo servidor.
+var ErrInvalidCalendarConnection = errors.New("conexão de calendário inválida")
+
+const (
+	// CalendarSyncSubscriberName identifica a sincronização de calendário como assinante
+	// do EventDispatcher.
+	CalendarSyncSubscriberName = "calendar-sync"
+
+	// calendarSyncPastDays e calendarSyncFutureDays delimitam o período sincronizado.
+	calendarSyncPastDays   = 1
+	calendarSyncFutureDays = 90
+	// maxBusyBlockSummaryLength é o tamanho máximo do título guardado dos compromissos externos.
+	maxBusyBlockSummaryLength = 255
+
+	calendarAppointmentUIDPrefix = "appointment-"
+	calendarAppointmentUIDSuffix = "@bizly"
+)
+
+// CalendarSyncEventTypes são os eventos publicados imediatamente no calendário externo;
+// os demais são levados na sincronização periódica.
+var CalendarSyncEventTypes = []entity.DomainEventType{
+	entity.DomainEventAppointmentCreated,
+	entity.DomainEventAppointmentUpdated,
+	entity.DomainEventAppointmentRescheduled,
+	entity.DomainEventAppointmentConfirmed,
+	entity.DomainEventAppointmentCancelled,
+	entity.DomainEventAppointmentCompleted,
+	entity.DomainEventAppointmentDeleted,
+}
+
+// CalendarSyncUseCase sincroniza a agenda com o calendário externo do usuário via CalDAV:
+// publica os agendamentos (com UID estável, derivado do ID) e importa os demais eventos
+// do calendário como períodos ocupados, que bloqueiam novos agendamentos.
+//
+// Alterações de horário feitas no calendário externo em eventos de agendamentos são
+// trazidas para a agenda; as demais alterações (título, exclusão, cancelamento) são
+// desfeitas na publicação seguinte, já que a agenda é a fonte dos agendamentos. Se o
+// agendamento foi alterado dos dois lados desde a última sincronização, prevalece a
+// alteração mais recente.
+type CalendarSyncUseCase struct {
+	connectionRepo  repository.CalendarConnectionRepository
+	busyRepo        repository.CalendarBusyBlockRepository
+	linkRepo        repository.CalendarEventLinkRepository
+	appointmentRepo repository.AppointmentRepository
+	userRepo        repository.UserRepository
+	appointments    *AppointmentUseCase
+	httpClient      *http.Client
+	locks           sync.Map // Um *sync.Mutex por usuário: sincronizações não se sobrepõem
+}
+
+// NewCalendarSyncUseCase cria uma nova instância de CalendarSyncUseCase. httpClient é
+// usado nas requisições aos servidores CalDAV e deve restringir os destinos, já que os
+// endereços são informados pelos usuários.
+func NewCalendarSyncUseCase(
+	connectionRepo repository.CalendarConnectionRepository,
+	busyRepo repository.CalendarBusyBlockRepository,
+	linkRepo repository.CalendarEventLinkRepository,
+	appointmentRepo repository.AppointmentRepository,
+	userRepo repository.UserRepository,
+	appointments *AppointmentUseCase,
+	httpClient *http.Client,
+) *CalendarSyncUseCase {
+	return &CalendarSyncUseCase{
+		connectionRepo:  connectionRepo,
+		busyRepo:        busyRepo,
+		linkRepo:        linkRepo,
+		appointmentRepo: appointmentRepo,
+		userRepo:        userRepo,
+		appointments:    appointments,
+		httpClient:      httpClient,
+	}
+}
+
+// ConnectCalendarInputDTO define os dados de acesso ao calendário externo.
+type ConnectCalendarInputDTO struct {
+	CalendarURL string
+	Username    string
+	Password    string // Se vazio ao atualizar a conexão, mantém a senha atual
+}
+
+// CalendarSyncResult resume uma sincronização.
+type CalendarSyncResult struct {
+	BusyBlocks int // Períodos ocupados importados do calendário externo
+	Published  int // Agendamentos publicados ou atualizados no calendário externo
+	Imported   int // Agendamentos remarcados por alterações feitas no calendário externo
+	Conflicts  int // Agendamentos alterados dos dois lados desde a última sincronização
+	Removed    int // Eventos de agendamentos excluídos removidos do calendário externo
+}
+
+// remoteAppointmentEvent é o evento de um agendamento lido do calendário externo.
+type remoteAppointmentEvent struct {
+	href  string
+	etag  string
+	event ical.Event
+}
+
+// GetConnection retorna a conexão de calendário do usuário.
+func (uc *CalendarSyncUseCase) GetConnection(userID uuid.UUID) (*entity.CalendarConnection, error) {
+	connection, err := uc.connectionRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar conexão de calendário: " + err.Error())
+	}
+	if connection == nil {
+		return nil, errors.New("conexão de calendário não encontrada")
+	}
+	return connection, nil
+}
+
+// Connect valida o acesso ao calendário, grava a conexão e faz a primeira sincronização.
+// Trocar de calendário descarta os vínculos com os eventos publicados no anterior. Falhas
+// da sincronização não impedem a conexão: ficam registradas em LastError.
+func (uc *CalendarSyncUseCase) Connect(userID uuid.UUID, input ConnectCalendarInputDTO) (*entity.CalendarConnection, *CalendarSyncResult, error) {
+	existing, err := uc.connectionRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, nil, errors.New("erro ao buscar conexão de calendário: " + err.Error())
+	}
+
+	connection := &entity.CalendarConnection{
+		UserID:      userID,
+		CalendarURL: strings.TrimSpace(input.CalendarURL),
+		Username:    strings.TrimSpace(input.Username),
+		Password:    input.Password,
+		Enabled:     true,
+	}
+	if connection.Password == "" && existing != nil {
+		connection.Password = existing.Password
+	}
+	if connection.CalendarURL == "" || connection.Username == "" || connection.Password == "" {
+		return nil, nil, fmt.Errorf("%w: endereço, usuário e senha são obrigatórios", ErrInvalidCalendarConnection)
+	}
+	if !strings.HasSuffix(connection.CalendarURL, "/") {
+		connection.CalendarURL += "/"
+	}
+
+	client, err := uc.client(connection)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	if _, err := client.ListEvents(now, now.Add(time.Hour)); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidCalendarConnection, err)
+	}
+
+	unlock := uc.lock(userID)
+	if existing != nil && existing.CalendarURL != connection.CalendarURL {
+		if err := uc.connectionRepo.Delete(userID); err != nil {
+			unlock()
+			return nil, nil, errors.New("falha ao trocar calendário: " + err.Error())
+		}
+	}
+	err = uc.connectionRepo.Save(connection)
+	unlock()
+	if err != nil {
+		return nil, nil, errors.New("falha ao salvar conexão de calendário: " + err.Error())
+	}
+
+	result, _ := uc.syncConnection(connection, now)
+	return connection, result, nil
+}
+
+// Disconnect remove a conexão com os períodos ocupados importados. Os eventos já
+// publicados permanecem no calendário externo.
+func (uc *CalendarSyncUseCase) Disconnect(userID uuid.UUID) error {
+	if _, err := uc.GetConnection(userID); err != nil {
+		return err
+	}
+	unlock := uc.lock(userID)
+	defer unlock()
+	if err := uc.connectionRepo.Delete(userID); err != nil {
+		return errors.New("falha ao remover conexão de calendário: " + err.Error())
+	}
+	return nil
+}
+
+// SyncNow sincroniza imediatamente o calendário do usuário.
+func (uc *CalendarSyncUseCase) SyncNow(userID uuid.UUID) (*CalendarSyncResult, error) {
+	connection, err := uc.GetConnection(userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.syncConnection(connection, time.Now())
+}
+
+// SyncAll sincroniza todas as conexões habilitadas. Retorna quantas foram sincronizadas
+// e quantas falharam.
+func (uc *CalendarSyncUseCase) SyncAll(now time.Time) (int, int, error) {
+	connections, err := uc.connectionRepo.FindEnabled()
+	if err != nil {
+		return 0, 0, errors.New("erro ao buscar conexões de calendário: " + err.Error())
+	}
+	synced, failed := 0, 0
+	for _, connection := range connections {
+		if _, err := uc.syncConnection(connection, now); err != nil {
+			failed++
+		} else {
+			synced++
+		}
+	}
+	return synced, failed, nil
+}
+
+// ListBusyBlocks lista os períodos ocupados importados que se sobrepõem a [start, end).
+func (uc *CalendarSyncUseCase) ListBusyBlocks(userID uuid.UUID, start, end time.Time) ([]*entity.CalendarBusyBlock, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: o fim do período deve ser após o início", ErrInvalidCalendarConnection)
+	}
+	blocks, err := uc.busyRepo.FindOverlapping(userID, start, end)
+	if err != nil {
+		return nil, errors.New("erro ao buscar períodos ocupados: " + err.Error())
+	}
+	return blocks, nil
+}
+
+// CheckAvailability recusa agendamentos que se sobrepõem a compromissos do calendário
+// externo.
+func (uc *CalendarSyncUseCase) CheckAvailability(appointment *entity.Appointment) error {
+	blocks, err := uc.busyRepo.FindOverlapping(appointment.UserID, appointment.StartTime, appointment.EndTime)
+	if err != nil {
+		return errors.New("erro ao verificar disponibilidade: " + err.Error())
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	block := blocks[0]
+	start, end := block.StartTime.In(time.Local), block.EndTime.In(time.Local)
+	return fmt.Errorf("%w: compromisso no calendário externo em %s, das %s às %s",
+		ErrScheduleConflict, start.Format("02/01"), start.Format("15:04"), end.Format("15:04"))
+}
+
+// SubscriberName identifica a sincronização de calendário no EventDispatcher.
+func (uc *CalendarSyncUseCase) SubscriberName() string {
+	return CalendarSyncSubscriberName
+}
+
+// HandleEvent publica a alteração do agendamento no calendário externo, se o usuário
+// tiver um conectado. Conflitos com alterações feitas no calendário são resolvidos como
+// na sincronização periódica.
+func (uc *CalendarSyncUseCase) HandleEvent(event *entity.DomainEvent) error {
+	connection, err := uc.connectionRepo.FindByUserID(event.UserID)
+	if err != nil {
+		return err
+	}
+	if connection == nil || !connection.Enabled {
+		return nil
+	}
+	client, err := uc.client(connection)
+	if err != nil {
+		return err
+	}
+
+	unlock := uc.lock(event.UserID)
+	defer unlock()
+	link, err := uc.linkRepo.Find(connection.ID, event.AggregateID)
+	if err != nil {
+		return err
+	}
+	appointment, err := uc.appointmentRepo.FindByID(event.AggregateID)
+	if err != nil {
+		return err
+	}
+	if appointment == nil {
+		if link == nil {
+			return nil
+		}
+		return uc.unpublish(client, link)
+	}
+
+	var remote *remoteAppointmentEvent
+	if link != nil {
+		if remote, err = uc.fetchRemote(client, link.Href, appointment.ID); err != nil {
+			return err
+		}
+	}
+	_, err = uc.syncAppointment(client, connection, appointment, link, remote, uc.businessName(appointment.UserID), time.Now())
+	return err
+}
+
+// syncConnection sincroniza a conexão e registra o resultado nela.
+func (uc *CalendarSyncUseCase) syncConnection(connection *entity.CalendarConnection, now time.Time) (*CalendarSyncResult, error) {
+	unlock := uc.lock(connection.UserID)
+	defer unlock()
+
+	result := &CalendarSyncResult{}
+	client, err := uc.client(connection)
+	if err == nil {
+		err = uc.runSync(client, connection, now, result)
+	}
+	connection.LastSyncAt = &now
+	connection.LastError = ""
+	if err != nil {
+		connection.LastError = err.Error()
+		log.Printf("Erro ao sincronizar calendário do usuário %s: %v", connection.UserID, err)
+	}
+	if saveErr := uc.connectionRepo.Save(connection); saveErr != nil {
+		log.Printf("Erro ao registrar sincronização do calendário do usuário %s: %v", connection.UserID, saveErr)
+	}
+	return result, err
+}
+
+// runSync importa os períodos ocupados, publica os agendamentos do período e remove do
+// calendário externo os eventos de agendamentos excluídos.
+func (uc *CalendarSyncUseCase) runSync(client *caldav.Client, connection *entity.CalendarConnection, now time.Time, result *CalendarSyncResult) error {
+	from := now.AddDate(0, 0, -calendarSyncPastDays)
+	to := now.AddDate(0, 0, calendarSyncFutureDays)
+	objects, err := client.ListEvents(from, to)
+	if err != nil {
+		return err
+	}
+
+	remote := make(map[uuid.UUID]*remoteAppointmentEvent)
+	var blocks []*entity.CalendarBusyBlock
+	for _, object := range objects {
+		events, err := ical.Parse(object.Data)
+		if err != nil {
+			// Um evento ilegível não impede a sincronização dos demais.
+			log.Printf("Evento %s ignorado na sincronização do calendário: %v", object.Href, err)
+			continue
+		}
+		for _, event := range events {
+			if appointmentID, ok := appointmentIDFromUID(event.UID); ok {
+				remote[appointmentID] = &remoteAppointmentEvent{href: object.Href, etag: object.ETag, event: event}
+				continue
+			}
+			if event.Status == ical.StatusCancelled || event.Transparent || !event.End.After(event.Start) {
+				continue
+			}
+			externalUID := event.UID
+			if !event.RecurrenceID.IsZero() {
+				externalUID += "/" + event.RecurrenceID.UTC().Format(time.RFC3339)
+			}
+			summary := event.Summary
+			if len([]rune(summary)) > maxBusyBlockSummaryLength {
+				summary = string([]rune(summary)[:maxBusyBlockSummaryLength])
+			}
+			blocks = append(blocks, &entity.CalendarBusyBlock{
+				UserID:       connection.UserID,
+				ConnectionID: connection.ID,
+				ExternalUID:  externalUID,
+				Summary:      summary,
+				StartTime:    event.Start,
+				EndTime:      event.End,
+			})
+		}
+	}
+	if err := uc.busyRepo.ReplaceForConnection(connection.ID, blocks); err != nil {
+		return errors.New("falha ao salvar períodos ocupados: " + err.Error())
+	}
+	result.BusyBlocks = len(blocks)
+
+	appointments, err := uc.appointmentRepo.FindByUserID(connection.UserID, &from, &to)
+	if err != nil {
+		return errors.New("erro ao buscar agendamentos: " + err.Error())
+	}
+	links, err := uc.linkRepo.FindByConnectionID(connection.ID)
+	if err != nil {
+		return errors.New("erro ao buscar eventos publicados: " + err.Error())
+	}
+	linksByAppointment := make(map[uuid.UUID]*entity.CalendarEventLink, len(links))
+	for _, link := range links {
+		linksByAppointment[link.AppointmentID] = link
+	}
+
+	businessName := uc.businessName(connection.UserID)
+	var failures []error
+	for _, appointment := range appointments {
+		link := linksByAppointment[appointment.ID]
+		delete(linksByAppointment, appointment.ID)
+		outcome, err := uc.syncAppointment(client, connection, appointment, link, remote[appointment.ID], businessName, now)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("agendamento %s: %w", appointment.ID, err))
+			continue
+		}
+		if outcome.published {
+			result.Published++
+		}
+		if outcome.imported {
+			result.Imported++
+		}
+		if outcome.conflict {
+			result.Conflicts++
+		}
+	}
+
+	// Os vínculos restantes são de agendamentos fora do período ou excluídos.
+	for appointmentID, link := range linksByAppointment {
+		appointment, err := uc.appointmentRepo.FindByID(appointmentID)
+		if err != nil || appointment != nil {
+			continue
+		}
+		if err := uc.unpublish(client, link); err != nil {
+			failures = append(failures, fmt.Errorf("agendamento %s: %w", appointmentID, err))
+			continue
+		}
+		result.Removed++
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d agendamento(s) não sincronizado(s): %w", len(failures), errors.Join(failures...))
+	}
+	return nil
+}
+
+// appointmentSyncOutcome é o resultado da sincronização de um agendamento.
+type appointmentSyncOutcome struct {
+	published bool
+	imported  bool
+	conflict  bool
+}
+
+// syncAppointment compara o agendamento, a versão publicada (link, nil se nunca
+// publicado) e o evento atual no calendário externo (remote, nil se não existe) e
+// publica ou importa o que mudou.
+func (uc *CalendarSyncUseCase) syncAppointment(client *caldav.Client, connection *entity.CalendarConnection, appointment *entity.Appointment, link *entity.CalendarEventLink, remote *remoteAppointmentEvent, businessName string, now time.Time) (appointmentSyncOutcome, error) {
+	var outcome appointmentSyncOutcome
+	if link == nil || remote == nil {
+		// Nunca publicado, ou removido do calendário externo: publica (de novo).
+		href := client.EventHref(appointmentUID(appointment.ID))
+		etag := ""
+		if remote != nil {
+			href, etag = remote.href, remote.etag
+		} else if link != nil {
+			href = link.Href
+		}
+		outcome.published = true
+		return outcome, uc.publish(client, connection, appointment, href, etag, businessName, now)
+	}
+
+	localChanged := appointment.Sequence != link.Sequence
+	remoteChanged := remote.etag != link.ETag
+	if !localChanged && !remoteChanged {
+		return outcome, nil
+	}
+	if remoteChanged {
+		outcome.conflict = localChanged
+		remoteMoved := !remote.event.Start.Equal(link.StartTime) || !remote.event.End.Equal(link.EndTime)
+		remoteNewer := !localChanged || remote.event.LastModified.After(appointment.UpdatedAt)
+		if remoteMoved && remoteNewer {
+			start, end := remote.event.Start, remote.event.End
+			updated, err := uc.appointments.UpdateAppointment(appointment.ID, appointment.UserID, UpdateAppointmentInputDTO{
+				StartTime: &start,
+				EndTime:   &end,
+			})
+			if err != nil {
+				// A remarcação foi recusada (ex: horário ocupado): prevalece a agenda.
+				log.Printf("Remarcação do agendamento %s pelo calendário externo recusada: %v", appointment.ID, err)
+			} else {
+				appointment = updated
+				outcome.imported = true
+			}
+		}
+	}
+	outcome.published = true
+	return outcome, uc.publish(client, connection, appointment, remote.href, remote.etag, businessName, now)
+}
+
+// publish grava o evento do agendamento no calendário externo, condicionado à ETag
+// informada (ou à inexistência do evento, com etag vazia), e registra o vínculo.
+func (uc *CalendarSyncUseCase) publish(client *caldav.Client, connection *entity.CalendarConnection, appointment *entity.Appointment, href, etag, businessName string, now time.Time) error {
+	// Os recursos do CalDAV não podem ter METHOD (RFC 4791, seção 4.1).
+	calendar := ical.Calendar{Events: []ical.Event{appointmentCalendarEvent(appointment, businessName)}}
+	data := calendar.Encode(now)
+
+	newETag, err := client.PutEvent(href, data, etag)
+	if errors.Is(err, caldav.ErrPreconditionFailed) && etag == "" {
+		// O evento já existe fora do período sincronizado (ex: vínculo perdido): é substituído.
+		var existing *caldav.Object
+		if existing, err = client.GetEvent(href); err == nil {
+			newETag, err = client.PutEvent(href, data, existing.ETag)
+		}
+	}
+	if err != nil {
+		// Com ErrPreconditionFailed, o evento mudou no servidor após a leitura; a próxima
+		// sincronização resolve o conflito.
+		return err
+	}
+
+	return uc.linkRepo.Save(&entity.CalendarEventLink{
+		ConnectionID:  connection.ID,
+		AppointmentID: appointment.ID,
+		Href:          href,
+		ETag:          newETag,
+		Sequence:      appointment.Sequence,
+		StartTime:     appointment.StartTime,
+		EndTime:       appointment.EndTime,
+		SyncedAt:      now,
+	})
+}
+
+// unpublish remove do calendário externo o evento de um agendamento excluído.
+func (uc *CalendarSyncUseCase) unpublish(client *caldav.Client, link *entity.CalendarEventLink) error {
+	if err := client.DeleteEvent(link.Href, ""); err != nil {
+		return err
+	}
+	return uc.linkRepo.Delete(link.ConnectionID, link.AppointmentID)
+}
+
+// fetchRemote lê o evento publicado do agendamento; retorna nil se ele não existe mais.
+func (uc *CalendarSyncUseCase) fetchRemote(client *caldav.Client, href string, appointmentID uuid.UUID) (*remoteAppointmentEvent, error) {
+	object, err := client.GetEvent(href)
+	if errors.Is(err, caldav.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	events, err := ical.Parse(object.Data)
+	if err != nil {
+		return nil, err
+	}
+	uid := appointmentUID(appointmentID)
+	for _, event := range events {
+		if event.UID == uid {
+			return &remoteAppointmentEvent{href: object.Href, etag: object.ETag, event: event}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (uc *CalendarSyncUseCase) client(connection *entity.CalendarConnection) (*caldav.Client, error) {
+	client, err := caldav.NewClient(uc.httpClient, caldav.Config{
+		CalendarURL: connection.CalendarURL,
+		Username:    connection.Username,
+		Password:    connection.Password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCalendarConnection, err)
+	}
+	return client, nil
+}
+
+// lock serializa as sincronizações do usuário e retorna a função que libera o bloqueio.
+func (uc *CalendarSyncUseCase) lock(userID uuid.UUID) func() {
+	mu, _ := uc.locks.LoadOrStore(userID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+func (uc *CalendarSyncUseCase) businessName(userID uuid.UUID) string {
+	user, err := uc.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.Name
+}
+
+// appointmentUID é o UID do evento do agendamento, o mesmo no feed, nos anexos dos
+// e-mails e no calendário externo.
+func appointmentUID(appointmentID uuid.UUID) string {
+	return calendarAppointmentUIDPrefix + appointmentID.String() + calendarAppointmentUIDSuffix
+}
+
+// appointmentIDFromUID identifica os eventos publicados pela aplicação.
+func appointmentIDFromUID(uid string) (uuid.UUID, bool) {
+	if !strings.HasPrefix(uid, calendarAppointmentUIDPrefix) || !strings.HasSuffix(uid, calendarAppointmentUIDSuffix) {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(strings.TrimSuffix(strings.TrimPrefix(uid, calendarAppointmentUIDPrefix), calendarAppointmentUIDSuffix))
+	return id, err == nil
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/ical"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// calendarFeedPastDays é quantos dias de agendamentos passados o feed inclui; os
+	// futuros entram todos.
+	calendarFeedPastDays = 90
+	// calendarFeedRefreshInterval é o intervalo de atualização sugerido aos calendários.
+	calendarFeedRefreshInterval = 15 * time.Minute
+)
+
+// CalendarUseCase publica a agenda do usuário no formato iCalendar: o feed secreto
+// assinado pelo calendário do celular e os arquivos .ics de cada agendamento.
+type CalendarUseCase struct {
+	feedRepo        repository.CalendarFeedRepository
+	appointmentRepo repository.AppointmentRepository
+	userRepo        repository.UserRepository
+	publicBaseURL   string
+}
+
+// NewCalendarUseCase cria uma nova instância de CalendarUseCase. publicBaseURL é o
+// endereço público da API usado para montar a URL do feed.
+func NewCalendarUseCase(
+	feedRepo repository.CalendarFeedRepository,
+	appointmentRepo repository.AppointmentRepository,
+	userRepo repository.UserRepository,
+	publicBaseURL string,
+) *CalendarUseCase {
+	return &CalendarUseCase{
+		feedRepo:        feedRepo,
+		appointmentRepo: appointmentRepo,
+		userRepo:        userRepo,
+		publicBaseURL:   strings.TrimRight(publicBaseURL, "/"),
+	}
+}
+
+// GetFeed retorna o feed de calendário do usuário, criando-o no primeiro acesso.
+func (uc *CalendarUseCase) GetFeed(userID uuid.UUID) (*entity.CalendarFeed, error) {
+	feed, err := uc.feedRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar feed de calendário: " + err.Error())
+	}
+	if feed != nil {
+		return feed, nil
+	}
+
+	token, err := generateCalendarFeedToken()
+	if err != nil {
+		return nil, errors.New("falha ao gerar link do calendário: " + err.Error())
+	}
+	feed = &entity.CalendarFeed{UserID: userID, Token: token}
+	if err := uc.feedRepo.Save(feed); err != nil {
+		return nil, errors.New("falha ao salvar feed de calendário: " + err.Error())
+	}
+	return feed, nil
+}
+
+// RotateToken troca o token do feed. A URL anterior deixa de funcionar imediatamente e
+// os calendários que a assinavam precisam ser reconfigurados com a nova.
+func (uc *CalendarUseCase) RotateToken(userID uuid.UUID) (*entity.CalendarFeed, error) {
+	feed, err := uc.GetFeed(userID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := generateCalendarFeedToken()
+	if err != nil {
+		return nil, errors.New("falha ao gerar link do calendário: " + err.Error())
+	}
+	now := time.Now()
+	feed.Token = token
+	feed.RotatedAt = &now
+	if err := uc.feedRepo.Save(feed); err != nil {
+		return nil, errors.New("falha ao salvar feed de calendário: " + err.Error())
+	}
+	return feed, nil
+}
+
+// FeedURL retorna a URL pública do feed, no formato aceito pelos calendários.
+func (uc *CalendarUseCase) FeedURL(feed *entity.CalendarFeed) string {
+	return uc.publicBaseURL + "/api/v1/public/calendar/" + feed.Token + ".ics"
+}
+
+// RenderFeed gera o calendário do feed identificado pelo token, com os agendamentos dos
+// últimos calendarFeedPastDays dias em diante. Os cancelados permanecem no feed com
+// STATUS:CANCELLED, para que sejam removidos dos calendários que já os importaram.
+func (uc *CalendarUseCase) RenderFeed(token string) ([]byte, error) {
+	feed, err := uc.feedRepo.FindByToken(token)
+	if err != nil {
+		return nil, errors.New("erro ao buscar feed de calendário: " + err.Error())
+	}
+	if feed == nil {
+		return nil, errors.New("feed não encontrado")
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -calendarFeedPastDays)
+	appointments, err := uc.appointmentRepo.FindByUserID(feed.UserID, &from, nil)
+	if err != nil {
+		return nil, errors.New("erro ao buscar agendamentos: " + err.Error())
+	}
+
+	businessName := uc.businessName(feed.UserID)
+	calendar := ical.Calendar{
+		Name:            strings.TrimSpace("Agenda " + businessName),
+		RefreshInterval: calendarFeedRefreshInterval,
+	}
+	for _, appointment := range appointments {
+		calendar.Events = append(calendar.Events, appointmentCalendarEvent(appointment, businessName))
+	}
+	return calendar.Encode(now), nil
+}
+
+// AppointmentICS gera o arquivo .ics de um agendamento do usuário.
+func (uc *CalendarUseCase) AppointmentICS(appointmentID, requestingUserID uuid.UUID) ([]byte, error) {
+	appointment, err := uc.appointmentRepo.FindByID(appointmentID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar agendamento: " + err.Error())
+	}
+	if appointment == nil || appointment.UserID != requestingUserID {
+		return nil, errors.New("agendamento não encontrado")
+	}
+	event := appointmentCalendarEvent(appointment, uc.businessName(appointment.UserID))
+	return singleEventICS(event, time.Now()), nil
+}
+
+func (uc *CalendarUseCase) businessName(userID uuid.UUID) string {
+	user, err := uc.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.Name
+}
+
+// singleEventICS gera o calendário com um único evento, usado nos downloads e nos
+// anexos dos e-mails.
+func singleEventICS(event ical.Event, now time.Time) []byte {
+	calendar := ical.Calendar{
+		Method: ical.MethodPublish,
+		Events: []ical.Event{event},
+	}
+	return calendar.Encode(now)
+}
+
+// appointmentCalendarEvent converte o agendamento no evento da agenda do negócio, com o
+// nome e o telefone do cliente e as observações. O UID é derivado do ID do agendamento,
+// para que as versões seguintes substituam o mesmo evento.
+func appointmentCalendarEvent(appointment *entity.Appointment, businessName string) ical.Event {
+	status := ical.StatusConfirmed
+	switch appointment.Status {
+	case entity.AppointmentStatusPending:
+		status = ical.StatusTentative
+	case entity.AppointmentStatusCancelled, entity.AppointmentStatusNoShow:
+		status = ical.StatusCancelled
+	}
+
+	summary := appointment.ServiceDescription
+	if appointment.ClientName != "" {
+		summary += " - " + appointment.ClientName
+	}
+	var description []string
+	if appointment.ClientPhone != "" {
+		description = append(description, "Telefone: "+appointment.ClientPhone)
+	}
+	if appointment.Notes != "" {
+		description = append(description, appointment.Notes)
+	}
+
+	return ical.Event{
+		UID:          appointmentUID(appointment.ID),
+		Sequence:     appointment.Sequence,
+		Start:        appointment.StartTime,
+		End:          appointment.EndTime,
+		Summary:      summary,
+		Description:  strings.Join(description, "\n"),
+		Location:     businessName,
+		Status:       status,
+		Created:      appointment.CreatedAt,
+		LastModified: appointment.UpdatedAt,
+	}
+}
+
+func generateCalendarFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// CatalogUseCase encapsula o cadastro do catálogo de serviços e dos profissionais da equipe.
+type CatalogUseCase struct {
+	serviceRepo      repository.ServiceRepository
+	professionalRepo repository.ProfessionalRepository
+}
+
+// NewCatalogUseCase cria uma nova instância de CatalogUseCase.
+func NewCatalogUseCase(serviceRepo repository.ServiceRepository, professionalRepo repository.ProfessionalRepository) *CatalogUseCase {
+	return &CatalogUseCase{
+		serviceRepo:      serviceRepo,
+		professionalRepo: professionalRepo,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Serviços
+// -----------------------------------------------------------------------------
+
+// CreateServiceInputDTO define os dados para cadastrar um serviço.
+type CreateServiceInputDTO struct {
+	UserID          uuid.UUID
+	Name            string
+	Description     string
+	Price           float64
+	DurationMinutes int
+}
+
+// CreateService cadastra um serviço no catálogo do usuário.
+func (uc *CatalogUseCase) CreateService(input CreateServiceInputDTO) (*entity.Service, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if input.Name == "" {
+		return nil, errors.New("nome do serviço é obrigatório")
+	}
+	if input.Price < 0 {
+		return nil, errors.New("preço do serviço não pode ser negativo")
+	}
+	if input.DurationMinutes < 0 {
+		return nil, errors.New("duração do serviço não pode ser negativa")
+	}
+
+	service := &entity.Service{
+		ID:              uuid.New(),
+		UserID:          input.UserID,
+		Name:            input.Name,
+		Description:     input.Description,
+		Price:           input.Price,
+		DurationMinutes: input.DurationMinutes,
+		Active:          true,
+	}
+	if err := uc.serviceRepo.Create(service); err != nil {
+		return nil, errors.New("falha ao salvar serviço: " + err.Error())
+	}
+	return service, nil
+}
+
+// GetServiceByID busca um serviço verificando se pertence ao usuário.
+func (uc *CatalogUseCase) GetServiceByID(serviceID, requestingUserID uuid.UUID) (*entity.Service, error) {
+	service, err := uc.serviceRepo.FindByID(serviceID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar serviço: " + err.Error())
+	}
+	if service == nil || service.UserID != requestingUserID {
+		return nil, errors.New("serviço não encontrado")
+	}
+	return service, nil
+}
+
+// ListServices lista o catálogo de serviços do usuário.
+func (uc *CatalogUseCase) ListServices(userID uuid.UUID) ([]*entity.Service, error) {
+	return uc.serviceRepo.FindByUserID(userID)
+}
+
+// UpdateServiceInputDTO define os dados para atualizar um serviço.
+type UpdateServiceInputDTO struct {
+	Name            *string
+	Description     *string
+	Price           *float64
+	DurationMinutes *int
+	Active          *bool
+}
+
+// UpdateService atualiza um serviço do catálogo. Agendamentos existentes mantêm o preço combinado.
+func (uc *CatalogUseCase) UpdateService(serviceID, requestingUserID uuid.UUID, input UpdateServiceInputDTO) (*entity.Service, error) {
+	service, err := uc.GetServiceByID(serviceID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if *input.Name == "" {
+			return nil, errors.New("nome do serviço é obrigatório")
+		}
+		service.Name = *input.Name
+	}
+	if input.Description != nil {
+		service.Description = *input.Description
+	}
+	if input.Price != nil {
+		if *input.Price < 0 {
+			return nil, errors.New("preço do serviço não pode ser negativo")
+		}
+		service.Price = *input.Price
+	}
+	if input.DurationMinutes != nil {
+		if *input.DurationMinutes < 0 {
+			return nil, errors.New("duração do serviço não pode ser negativa")
+		}
+		service.DurationMinutes = *input.DurationMinutes
+	}
+	if input.Active != nil {
+		service.Active = *input.Active
+	}
+
+	if err := uc.serviceRepo.Update(service); err != nil {
+		return nil, errors.New("falha ao atualizar serviço: " + err.Error())
+	}
+	return service, nil
+}
+
+// DeleteService exclui um serviço do catálogo.
+func (uc *CatalogUseCase) DeleteService(serviceID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetServiceByID(serviceID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.serviceRepo.Delete(serviceID)
+}
+
+// -----------------------------------------------------------------------------
+// Profissionais
+// -----------------------------------------------------------------------------
+
+// CreateProfessionalInputDTO define os dados para cadastrar um profissional.
+type CreateProfessionalInputDTO struct {
+	UserID uuid.UUID
+	Name   string
+	Email  string
+	Phone  string
+}
+
+// CreateProfessional cadastra um profissional na equipe do usuário.
+func (uc *CatalogUseCase) CreateProfessional(input CreateProfessionalInputDTO) (*entity.Professional, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if input.Name == "" {
+		return nil, errors.New("nome do profissional é obrigatório")
+	}
+
+	professional := &entity.Professional{
+		ID:     uuid.New(),
+		UserID: input.UserID,
+		Name:   input.Name,
+		Email:  input.Email,
+		Phone:  input.Phone,
+		Active: true,
+	}
+	if err := uc.professionalRepo.Create(professional); err != nil {
+		return nil, errors.New("falha ao salvar profissional: " + err.Error())
+	}
+	return professional, nil
+}
+
+// GetProfessionalByID busca um profissional verificando se pertence ao usuário.
+func (uc *CatalogUseCase) GetProfessionalByID(professionalID, requestingUserID uuid.UUID) (*entity.Professional, error) {
+	professional, err := uc.professionalRepo.FindByID(professionalID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar profissional: " + err.Error())
+	}
+	if professional == nil || professional.UserID != requestingUserID {
+		return nil, errors.New("profissional não encontrado")
+	}
+	return professional, nil
+}
+
+// ListProfessionals lista os profissionais da equipe do usuário.
+func (uc *CatalogUseCase) ListProfessionals(userID uuid.UUID) ([]*entity.Professional, error) {
+	return uc.professionalRepo.FindByUserID(userID)
+}
+
+// UpdateProfessionalInputDTO define os dados para atualizar um profissional.
+type UpdateProfessionalInputDTO struct {
+	Name   *string
+	Email  *string
+	Phone  *string
+	Active *bool
+}
+
+// UpdateProfessional atualiza os dados de um profissional.
+func (uc *CatalogUseCase) UpdateProfessional(professionalID, requestingUserID uuid.UUID, input UpdateProfessionalInputDTO) (*entity.Professional, error) {
+	professional, err := uc.GetProfessionalByID(professionalID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if *input.Name == "" {
+			return nil, errors.New("nome do profissional é obrigatório")
+		}
+		professional.Name = *input.Name
+	}
+	if input.Email != nil {
+		professional.Email = *input.Email
+	}
+	if input.Phone != nil {
+		professional.Phone = *input.Phone
+	}
+	if input.Active != nil {
+		professional.Active = *input.Active
+	}
+
+	if err := uc.professionalRepo.Update(professional); err != nil {
+		return nil, errors.New("falha ao atualizar profissional: " + err.Error())
+	}
+	return professional, nil
+}
+
+// DeleteProfessional exclui um profissional. Comissões e repasses já registrados são mantidos.
+func (uc *CatalogUseCase) DeleteProfessional(professionalID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetProfessionalByID(professionalID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.professionalRepo.Delete(professionalID)
+}
@@ -0,0 +1,468 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCheckout indica que as formas de pagamento ou a gorjeta do fechamento são inválidas.
+var ErrInvalidCheckout = errors.New("fechamento inválido")
+
+// ErrCheckoutStatus indica que o agendamento não pode mais ser fechado.
+var ErrCheckoutStatus = errors.New("agendamento não pode ser fechado")
+
+// errCheckoutCreditChanged desfaz a transação do fechamento quando a cobertura do serviço
+// por pacote mudou depois de calculado o valor devido (ex: crédito consumido em paralelo).
+var errCheckoutCreditChanged = errors.New("saldo do pacote do cliente mudou durante o fechamento; tente novamente")
+
+// errCheckoutConcluded desfaz a transação do fechamento quando outra operação concluiu ou
+// cancelou o agendamento antes.
+var errCheckoutConcluded = errors.New("agendamento foi finalizado por outra operação")
+
+// CheckoutUseCase encapsula o fechamento de atendimentos no balcão, com venda de
+// produtos, pagamento dividido em várias formas e gorjeta para o profissional.
+type CheckoutUseCase struct {
+	checkoutRepo    repository.CheckoutRepository
+	appointmentRepo repository.AppointmentRepository
+	paymentRepo     repository.PaymentRepository
+	productRepo     repository.ProductRepository
+	giftCards       *GiftCardUseCase
+	appointments    *AppointmentUseCase
+	products        *ProductUseCase
+	commissions     *CommissionUseCase
+	packages        *PackageUseCase
+	uow             repository.UnitOfWork
+}
+
+// NewCheckoutUseCase cria uma nova instância de CheckoutUseCase.
+func NewCheckoutUseCase(
+	checkoutRepo repository.CheckoutRepository,
+	appointmentRepo repository.AppointmentRepository,
+	paymentRepo repository.PaymentRepository,
+	productRepo repository.ProductRepository,
+	giftCards *GiftCardUseCase,
+	appointments *AppointmentUseCase,
+	products *ProductUseCase,
+	commissions *CommissionUseCase,
+	packages *PackageUseCase,
+	uow repository.UnitOfWork,
+) *CheckoutUseCase {
+	return &CheckoutUseCase{
+		checkoutRepo:    checkoutRepo,
+		appointmentRepo: appointmentRepo,
+		paymentRepo:     paymentRepo,
+		productRepo:     productRepo,
+		giftCards:       giftCards,
+		appointments:    appointments,
+		products:        products,
+		commissions:     commissions,
+		packages:        packages,
+		uow:             uow,
+	}
+}
+
+// CheckoutPaymentInputDTO define uma forma de pagamento usada no fechamento.
+type CheckoutPaymentInputDTO struct {
+	Method       entity.PaymentMethod
+	Amount       float64
+	GiftCardCode string // Obrigatório quando Method é GIFT_CARD
+}
+
+// CheckoutProductInputDTO define um produto vendido no fechamento.
+type CheckoutProductInputDTO struct {
+	ProductID uuid.UUID
+	Quantity  int
+	UnitPrice float64 // Se zero, usa o preço de venda cadastrado
+}
+
+// CheckoutInputDTO define os dados para fechar um atendimento.
+type CheckoutInputDTO struct {
+	UserID        uuid.UUID
+	AppointmentID uuid.UUID
+	Products      []CheckoutProductInputDTO
+	Payments      []CheckoutPaymentInputDTO
+	TipAmount     float64
+}
+
+// Checkout fecha um atendimento: registra os produtos vendidos (baixando o estoque),
+// as formas de pagamento recebidas no balcão, destina a gorjeta e a comissão dos
+// produtos ao profissional do agendamento e conclui o agendamento, tudo de forma
+// atômica. A soma dos pagamentos deve cobrir exatamente o valor em aberto (preço menos
+// pagamentos já confirmados, ou zero se o serviço for coberto por um pacote do
+// cliente), os produtos e a gorjeta. PIX e cartão são registrados como já recebidos
+// (maquininha ou QR Code do balcão).
+func (uc *CheckoutUseCase) Checkout(input CheckoutInputDTO) (*entity.Checkout, error) {
+	appointment, err := uc.appointmentRepo.FindByID(input.AppointmentID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar agendamento: " + err.Error())
+	}
+	if appointment == nil || appointment.UserID != input.UserID {
+		return nil, errors.New("agendamento não encontrado")
+	}
+	if appointment.Status == entity.AppointmentStatusCompleted || appointment.Status == entity.AppointmentStatusCancelled {
+		return nil, fmt.Errorf("%w: agendamento já está %s", ErrCheckoutStatus, strings.ToLower(string(appointment.Status)))
+	}
+	existing, err := uc.checkoutRepo.FindByAppointmentID(appointment.ID)
+	if err != nil {
+		return nil, errors.New("erro ao verificar fechamento do agendamento: " + err.Error())
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: agendamento já possui fechamento", ErrCheckoutStatus)
+	}
+
+	tip := roundCents(input.TipAmount)
+	if tip < 0 {
+		return nil, fmt.Errorf("%w: gorjeta não pode ser negativa", ErrInvalidCheckout)
+	}
+	if tip > 0 && appointment.ProfessionalID == nil {
+		return nil, fmt.Errorf("%w: atribua um profissional ao agendamento para registrar a gorjeta", ErrInvalidCheckout)
+	}
+
+	previouslyPaid, err := uc.paidAmount(appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+	// O crédito do pacote é consumido na conclusão e zera o preço: o serviço não é cobrado.
+	servicePrice := appointment.Price
+	covered, err := uc.packages.CoversAppointment(appointment)
+	if err != nil {
+		return nil, err
+	}
+	if covered {
+		servicePrice = 0
+	}
+	due := math.Max(roundCents(servicePrice-previouslyPaid), 0)
+
+	checkoutID := uuid.New()
+	lines, productsAmount, err := uc.buildProductLines(appointment.UserID, checkoutID, input.Products)
+	if err != nil {
+		return nil, err
+	}
+
+	var total, giftCardTotal float64
+	for _, line := range input.Payments {
+		if line.Amount <= 0 {
+			return nil, fmt.Errorf("%w: valor de cada pagamento deve ser maior que zero", ErrInvalidCheckout)
+		}
+		switch line.Method {
+		case entity.PaymentMethodPix, entity.PaymentMethodCard, entity.PaymentMethodCash:
+		case entity.PaymentMethodGiftCard:
+			if line.GiftCardCode == "" {
+				return nil, fmt.Errorf("%w: informe o código do vale", ErrInvalidGiftCard)
+			}
+			giftCardTotal += line.Amount
+		default:
+			return nil, fmt.Errorf("%w: forma de pagamento inválida: %s", ErrInvalidCheckout, line.Method)
+		}
+		total += line.Amount
+	}
+	total = roundCents(total)
+	totalDue := roundCents(due + productsAmount + tip)
+	if math.Abs(total-totalDue) > 0.009 {
+		return nil, fmt.Errorf("%w: pagamentos somam %s, mas o total devido com produtos e gorjeta é %s",
+			ErrInvalidCheckout, formatBRL(total), formatBRL(totalDue))
+	}
+	// O valor do vale já entrou no caixa na venda e vira faturamento pelo preço do
+	// atendimento; por isso ele não pode pagar produtos.
+	if roundCents(giftCardTotal) > roundCents(due+tip)+0.009 {
+		return nil, fmt.Errorf("%w: vale-presente pode pagar apenas o atendimento e a gorjeta (até %s)",
+			ErrInvalidGiftCard, formatBRL(due+tip))
+	}
+
+	now := time.Now()
+	checkout := &entity.Checkout{
+		ID:             checkoutID,
+		UserID:         appointment.UserID,
+		AppointmentID:  appointment.ID,
+		ProfessionalID: appointment.ProfessionalID,
+		ServiceAmount:  servicePrice,
+		ProductsAmount: productsAmount,
+		PreviouslyPaid: previouslyPaid,
+		TipAmount:      tip,
+		TotalPaid:      total,
+		Products:       lines,
+		CompletedAt:    now,
+	}
+
+	// Os vales são debitados na transação do fechamento; se ele falhar, o débito é desfeito.
+	giftCardCodes := make(map[*entity.Payment]string)
+	var entries []*entity.FinancialEntry
+	for _, line := range input.Payments {
+		p := &entity.Payment{
+			ID:            uuid.New(),
+			UserID:        appointment.UserID,
+			AppointmentID: &appointment.ID,
+			Amount:        roundCents(line.Amount),
+			Method:        line.Method,
+			Status:        entity.PaymentStatusPaid,
+			PaidAt:        &now,
+			CheckoutID:    &checkout.ID,
+		}
+		if line.Method == entity.PaymentMethodGiftCard {
+			giftCardCodes[p] = line.GiftCardCode
+		} else {
+			// O valor do vale já entrou no caixa na venda; as demais formas são lançadas aqui.
+			entries = append(entries, &entity.FinancialEntry{
+				ID:            uuid.New(),
+				UserID:        appointment.UserID,
+				Type:          entity.FinancialEntryTypeIncome,
+				Amount:        p.Amount,
+				Description:   fmt.Sprintf("Fechamento (%s): %s", line.Method, appointment.ServiceDescription),
+				Date:          now,
+				AppointmentID: &appointment.ID,
+				PaymentID:     &p.ID,
+				RevenueKind:   entity.RevenueKindServices,
+			})
+		}
+		checkout.Payments = append(checkout.Payments, p)
+	}
+
+	entries = allocateProductRevenue(entries, productsAmount)
+	var movements []*entity.StockMovement
+	if productsAmount > 0 {
+		names := make([]string, len(lines))
+		for i, line := range lines {
+			names[i] = fmt.Sprintf("%d x %s", line.Quantity, line.ProductName)
+		}
+		entries = append(entries, &entity.FinancialEntry{
+			ID:          uuid.New(),
+			UserID:      appointment.UserID,
+			Type:        entity.FinancialEntryTypeIncome,
+			Amount:      productsAmount,
+			Description: "Venda de produtos: " + strings.Join(names, ", "),
+			Date:        now,
+			RevenueKind: entity.RevenueKindCommerceIndustry,
+		})
+	}
+	for _, line := range lines {
+		movements = append(movements, &entity.StockMovement{
+			UserID:        appointment.UserID,
+			ProductID:     line.ProductID,
+			Type:          entity.StockMovementTypeSale,
+			Quantity:      -line.Quantity,
+			UnitPrice:     line.UnitPrice,
+			AppointmentID: &appointment.ID,
+			CheckoutID:    &checkout.ID,
+			Notes:         "Venda no fechamento do atendimento",
+		})
+	}
+
+	commissions, err := uc.productCommissions(appointment, lines, now)
+	if err != nil {
+		return nil, err
+	}
+	if tip > 0 {
+		tipCommission := &entity.Commission{
+			ID:             uuid.New(),
+			UserID:         appointment.UserID,
+			ProfessionalID: *appointment.ProfessionalID,
+			AppointmentID:  &appointment.ID,
+			Source:         entity.CommissionSourceTip,
+			Description:    "Gorjeta: " + appointment.ServiceDescription,
+			BaseAmount:     tip,
+			Amount:         tip,
+			Status:         entity.CommissionStatusPending,
+			EarnedAt:       now,
+		}
+		checkout.TipCommissionID = &tipCommission.ID
+		commissions = append(commissions, tipCommission)
+	}
+
+	// A comissão do serviço e o consumo de pacote são aplicados na transação do fechamento,
+	// como em qualquer conclusão: se algo falhar, nada é gravado.
+	previous := *appointment
+	appointment.Status = entity.AppointmentStatusCompleted
+	err = uc.uow.Do(func(tx repository.Transaction) error {
+		if err := uc.appointments.applyCompletionPolicies(tx, &previous, appointment); err != nil {
+			return err
+		}
+		if roundCents(appointment.Price) != roundCents(servicePrice) {
+			return errCheckoutCreditChanged
+		}
+		for _, p := range checkout.Payments {
+			code, ok := giftCardCodes[p]
+			if !ok {
+				continue
+			}
+			giftCard, err := uc.giftCards.RedeemGiftCard(tx, appointment.UserID, code, p.Amount, p.ID)
+			if err != nil {
+				return err
+			}
+			p.GiftCardID = &giftCard.ID
+		}
+		events, err := appointmentEvents(&previous, appointment)
+		if err != nil {
+			return err
+		}
+		paymentEvents, err := paidPaymentEvents(checkout.Payments)
+		if err != nil {
+			return err
+		}
+		completed, err := tx.Checkouts().Complete(checkout, appointment, movements, entries, commissions, append(events, paymentEvents...))
+		if err != nil {
+			return err
+		}
+		if !completed {
+			return errCheckoutConcluded
+		}
+		return nil
+	})
+	if errors.Is(err, errCheckoutConcluded) || errors.Is(err, errCheckoutCreditChanged) || errors.Is(err, ErrInvalidStatusTransition) {
+		return nil, fmt.Errorf("%w: %v", ErrCheckoutStatus, err)
+	}
+	if errors.Is(err, ErrInvalidGiftCard) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.New("falha ao salvar fechamento: " + err.Error())
+	}
+
+	// Emissão de nota e avisos seguem o fluxo normal de conclusão.
+	uc.appointments.notifyCompleted(appointment)
+	uc.appointments.notifyChanged(&previous, appointment)
+	uc.refreshLowStock(lines)
+	return checkout, nil
+}
+
+// buildProductLines valida os produtos vendidos e monta as linhas do fechamento com o
+// preço de venda vigente. Retorna também o total dos produtos.
+func (uc *CheckoutUseCase) buildProductLines(userID, checkoutID uuid.UUID, inputs []CheckoutProductInputDTO) ([]entity.CheckoutProduct, float64, error) {
+	var lines []entity.CheckoutProduct
+	var total float64
+	requested := make(map[uuid.UUID]int)
+	for _, input := range inputs {
+		if input.Quantity <= 0 {
+			return nil, 0, fmt.Errorf("%w: quantidade de cada produto deve ser maior que zero", ErrInvalidCheckout)
+		}
+		if input.UnitPrice < 0 {
+			return nil, 0, fmt.Errorf("%w: preço do produto não pode ser negativo", ErrInvalidCheckout)
+		}
+		product, err := uc.productRepo.FindByID(input.ProductID)
+		if err != nil {
+			return nil, 0, errors.New("erro ao buscar produto: " + err.Error())
+		}
+		if product == nil || product.UserID != userID {
+			return nil, 0, errors.New("produto não encontrado")
+		}
+		if !product.Active {
+			return nil, 0, fmt.Errorf("%w: produto %s está inativo", ErrInvalidCheckout, product.Name)
+		}
+		requested[product.ID] += input.Quantity
+		if requested[product.ID] > product.StockQuantity {
+			return nil, 0, fmt.Errorf("%w: estoque insuficiente de %s (disponível: %d)", ErrInvalidCheckout, product.Name, product.StockQuantity)
+		}
+
+		unitPrice := input.UnitPrice
+		if unitPrice == 0 {
+			unitPrice = product.SalePrice
+		}
+		line := entity.CheckoutProduct{
+			ID:          uuid.New(),
+			CheckoutID:  checkoutID,
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Quantity:    input.Quantity,
+			UnitPrice:   roundCents(unitPrice),
+		}
+		lines = append(lines, line)
+		total += line.Total()
+	}
+	return lines, roundCents(total), nil
+}
+
+// allocateProductRevenue desconta o valor dos produtos dos lançamentos dos pagamentos,
+// que ficam vinculados ao atendimento e não contam como faturamento. Os produtos ganham
+// um lançamento próprio de comércio, sem vínculo com o atendimento.
+func allocateProductRevenue(entries []*entity.FinancialEntry, productsAmount float64) []*entity.FinancialEntry {
+	remaining := productsAmount
+	var kept []*entity.FinancialEntry
+	for _, entry := range entries {
+		take := math.Min(entry.Amount, remaining)
+		entry.Amount = roundCents(entry.Amount - take)
+		remaining = roundCents(remaining - take)
+		if entry.Amount > 0 {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// productCommissions apura as comissões de produto do profissional do atendimento,
+// gravadas junto com o fechamento.
+func (uc *CheckoutUseCase) productCommissions(appointment *entity.Appointment, lines []entity.CheckoutProduct, soldAt time.Time) ([]*entity.Commission, error) {
+	if appointment.ProfessionalID == nil {
+		return nil, nil
+	}
+	var commissions []*entity.Commission
+	for _, line := range lines {
+		if line.Total() <= 0 {
+			continue
+		}
+		commission, err := uc.commissions.BuildProductCommission(ProductCommissionInputDTO{
+			UserID:         appointment.UserID,
+			ProfessionalID: *appointment.ProfessionalID,
+			Description:    fmt.Sprintf("%d x %s", line.Quantity, line.ProductName),
+			SaleAmount:     line.Total(),
+			SoldAt:         soldAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if commission != nil {
+			commissions = append(commissions, commission)
+		}
+	}
+	return commissions, nil
+}
+
+// refreshLowStock reavalia os alertas de estoque dos produtos vendidos.
+func (uc *CheckoutUseCase) refreshLowStock(lines []entity.CheckoutProduct) {
+	if len(lines) == 0 {
+		return
+	}
+	productIDs := make([]uuid.UUID, len(lines))
+	for i, line := range lines {
+		productIDs[i] = line.ProductID
+	}
+	uc.products.RefreshLowStock(productIDs)
+}
+
+// GetCheckout busca o fechamento de um agendamento do usuário.
+func (uc *CheckoutUseCase) GetCheckout(appointmentID, requestingUserID uuid.UUID) (*entity.Checkout, error) {
+	checkout, err := uc.checkoutRepo.FindByAppointmentID(appointmentID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar fechamento: " + err.Error())
+	}
+	if checkout == nil || checkout.UserID != requestingUserID {
+		return nil, errors.New("fechamento não encontrado")
+	}
+	return checkout, nil
+}
+
+// paidAmount soma os pagamentos já confirmados do agendamento. Cobranças pendentes
+// não são descontadas do valor em aberto.
+func (uc *CheckoutUseCase) paidAmount(appointmentID uuid.UUID) (float64, error) {
+	payments, err := uc.paymentRepo.FindByAppointmentID(appointmentID)
+	if err != nil {
+		return 0, errors.New("erro ao buscar pagamentos do agendamento: " + err.Error())
+	}
+	var paid float64
+	for _, p := range payments {
+		if p.Status == entity.PaymentStatusPaid {
+			paid += p.Amount
+		}
+	}
+	return roundCents(paid), nil
+}
+
+// roundCents arredonda um valor monetário para centavos.
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -11,13 +11,15 @@ import (
 type ClientUseCase struct {
 	clientRepo repository.ClientRepository
 	userRepo   repository.UserRepository // Para verificar se o UserID existe, se necessário
+	uow        repository.UnitOfWork
 }
 
 // NewClientUseCase cria uma nova instância de ClientUseCase.
-func NewClientUseCase(clientRepo repository.ClientRepository, userRepo repository.UserRepository) *ClientUseCase {
+func NewClientUseCase(clientRepo repository.ClientRepository, userRepo repository.UserRepository, uow repository.UnitOfWork) *ClientUseCase {
 	return &ClientUseCase{
 		clientRepo: clientRepo,
 		userRepo:   userRepo,
+		uow:        uow,
 	}
 }
 
@@ -54,7 +56,9 @@ func (uc *ClientUseCase) CreateClient(input CreateClientInputDTO) (*entity.Clien
 		Notes:  input.Notes,
 	}
 
-	err := uc.clientRepo.Create(client)
+	err := uc.saveClient(entity.DomainEventClientCreated, client, func(clients repository.ClientRepository) error {
+		return clients.Create(client)
+	})
 	if err != nil {
 		return nil, errors.New("falha ao salvar cliente: " + err.Error())
 	}
@@ -125,7 +129,9 @@ func (uc *ClientUseCase) UpdateClient(clientID, requestingUserID uuid.UUID, inpu
 		return existingClient, nil // Nada para atualizar
 	}
 
-	err = uc.clientRepo.Update(existingClient)
+	err = uc.saveClient(entity.DomainEventClientUpdated, existingClient, func(clients repository.ClientRepository) error {
+		return clients.Update(existingClient)
+	})
 	if err != nil {
 		return nil, errors.New("falha ao atualizar cliente: " + err.Error())
 	}
@@ -135,10 +141,26 @@ func (uc *ClientUseCase) UpdateClient(clientID, requestingUserID uuid.UUID, inpu
 
 // DeleteClient exclui um cliente.
 func (uc *ClientUseCase) DeleteClient(clientID, requestingUserID uuid.UUID) error {
-	_, err := uc.GetClientByID(clientID, requestingUserID) // Reutiliza a verificação de permissão
+	client, err := uc.GetClientByID(clientID, requestingUserID) // Reutiliza a verificação de permissão
 	if err != nil {
 		return err
 	}
 
-	return uc.clientRepo.Delete(clientID)
+	return uc.saveClient(entity.DomainEventClientDeleted, client, func(clients repository.ClientRepository) error {
+		return clients.Delete(clientID)
+	})
+}
+
+// saveClient executa a alteração do cliente e grava o evento de domínio na mesma transação.
+func (uc *ClientUseCase) saveClient(eventType entity.DomainEventType, client *entity.Client, change func(clients repository.ClientRepository) error) error {
+	event, err := clientEvent(eventType, client)
+	if err != nil {
+		return err
+	}
+	return uc.uow.Do(func(tx repository.Transaction) error {
+		if err := change(tx.Clients()); err != nil {
+			return err
+		}
+		return tx.Events().Append(event)
+	})
 }
@@ -0,0 +1,483 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// CommissionUseCase encapsula as regras de comissão, a apuração das comissões
+// dos profissionais e o repasse (pagamento) em lote.
+type CommissionUseCase struct {
+	ruleRepo         repository.CommissionRuleRepository
+	commissionRepo   repository.CommissionRepository
+	payoutRepo       repository.CommissionPayoutRepository
+	professionalRepo repository.ProfessionalRepository
+	serviceRepo      repository.ServiceRepository
+	entryRepo        repository.FinancialEntryRepository
+}
+
+// NewCommissionUseCase cria uma nova instância de CommissionUseCase.
+func NewCommissionUseCase(
+	ruleRepo repository.CommissionRuleRepository,
+	commissionRepo repository.CommissionRepository,
+	payoutRepo repository.CommissionPayoutRepository,
+	professionalRepo repository.ProfessionalRepository,
+	serviceRepo repository.ServiceRepository,
+	entryRepo repository.FinancialEntryRepository,
+) *CommissionUseCase {
+	return &CommissionUseCase{
+		ruleRepo:         ruleRepo,
+		commissionRepo:   commissionRepo,
+		payoutRepo:       payoutRepo,
+		professionalRepo: professionalRepo,
+		serviceRepo:      serviceRepo,
+		entryRepo:        entryRepo,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Regras
+// -----------------------------------------------------------------------------
+
+// CreateCommissionRuleInputDTO define os dados para cadastrar uma regra de comissão.
+type CreateCommissionRuleInputDTO struct {
+	UserID         uuid.UUID
+	ProfessionalID *uuid.UUID
+	ServiceID      *uuid.UUID
+	Source         entity.CommissionSource
+	Type           entity.CommissionType
+	Value          float64
+}
+
+// CreateRule cadastra uma regra de comissão.
+func (uc *CommissionUseCase) CreateRule(input CreateCommissionRuleInputDTO) (*entity.CommissionRule, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	rule := &entity.CommissionRule{
+		ID:             uuid.New(),
+		UserID:         input.UserID,
+		ProfessionalID: input.ProfessionalID,
+		ServiceID:      input.ServiceID,
+		Source:         input.Source,
+		Type:           input.Type,
+		Value:          input.Value,
+	}
+	if err := uc.validateRule(rule); err != nil {
+		return nil, err
+	}
+	if err := uc.ruleRepo.Create(rule); err != nil {
+		return nil, errors.New("falha ao salvar regra de comissão: " + err.Error())
+	}
+	return rule, nil
+}
+
+// GetRuleByID busca uma regra verificando se pertence ao usuário.
+func (uc *CommissionUseCase) GetRuleByID(ruleID, requestingUserID uuid.UUID) (*entity.CommissionRule, error) {
+	rule, err := uc.ruleRepo.FindByID(ruleID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar regra de comissão: " + err.Error())
+	}
+	if rule == nil || rule.UserID != requestingUserID {
+		return nil, errors.New("regra de comissão não encontrada")
+	}
+	return rule, nil
+}
+
+// ListRules lista as regras de comissão do usuário.
+func (uc *CommissionUseCase) ListRules(userID uuid.UUID) ([]*entity.CommissionRule, error) {
+	return uc.ruleRepo.FindByUserID(userID)
+}
+
+// UpdateCommissionRuleInputDTO define os dados para atualizar uma regra de comissão.
+// Alterações valem apenas para comissões apuradas a partir de então.
+type UpdateCommissionRuleInputDTO struct {
+	ProfessionalID    *uuid.UUID
+	ClearProfessional bool // A regra passa a valer para todos os profissionais
+	ServiceID         *uuid.UUID
+	ClearService      bool // A regra passa a valer para todos os serviços
+	Type              *entity.CommissionType
+	Value             *float64
+}
+
+// UpdateRule atualiza uma regra de comissão.
+func (uc *CommissionUseCase) UpdateRule(ruleID, requestingUserID uuid.UUID, input UpdateCommissionRuleInputDTO) (*entity.CommissionRule, error) {
+	rule, err := uc.GetRuleByID(ruleID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ClearProfessional {
+		rule.ProfessionalID = nil
+	} else if input.ProfessionalID != nil {
+		rule.ProfessionalID = input.ProfessionalID
+	}
+	if input.ClearService {
+		rule.ServiceID = nil
+	} else if input.ServiceID != nil {
+		rule.ServiceID = input.ServiceID
+	}
+	if input.Type != nil {
+		rule.Type = *input.Type
+	}
+	if input.Value != nil {
+		rule.Value = *input.Value
+	}
+	if err := uc.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Update(rule); err != nil {
+		return nil, errors.New("falha ao atualizar regra de comissão: " + err.Error())
+	}
+	return rule, nil
+}
+
+// DeleteRule exclui uma regra de comissão. Comissões já apuradas são mantidas.
+func (uc *CommissionUseCase) DeleteRule(ruleID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetRuleByID(ruleID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.ruleRepo.Delete(ruleID)
+}
+
+// validateRule valida os campos da regra e a posse do profissional e do serviço.
+func (uc *CommissionUseCase) validateRule(rule *entity.CommissionRule) error {
+	switch rule.Source {
+	case entity.CommissionSourceService:
+	case entity.CommissionSourceProduct:
+		if rule.ServiceID != nil {
+			return errors.New("regras de produto não podem ser vinculadas a um serviço")
+		}
+	default:
+		return errors.New("origem da comissão inválida: " + string(rule.Source))
+	}
+
+	switch rule.Type {
+	case entity.CommissionTypePercentage:
+		if rule.Value <= 0 || rule.Value > 100 {
+			return errors.New("percentual de comissão deve estar entre 0 e 100")
+		}
+	case entity.CommissionTypeFixed:
+		if rule.Value <= 0 {
+			return errors.New("valor fixo de comissão deve ser maior que zero")
+		}
+	default:
+		return errors.New("tipo de comissão inválido: " + string(rule.Type))
+	}
+
+	if rule.ProfessionalID != nil {
+		if _, err := uc.findProfessional(*rule.ProfessionalID, rule.UserID); err != nil {
+			return err
+		}
+	}
+	if rule.ServiceID != nil {
+		service, err := uc.serviceRepo.FindByID(*rule.ServiceID)
+		if err != nil {
+			return errors.New("erro ao buscar serviço: " + err.Error())
+		}
+		if service == nil || service.UserID != rule.UserID {
+			return errors.New("serviço não encontrado")
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Apuração
+// -----------------------------------------------------------------------------
+
+// CompleteAppointment apura a comissão do profissional de um atendimento concluído.
+// Implementa AppointmentCompletionPolicy: a comissão é gravada na transação que conclui
+// o agendamento, sobre o preço do serviço antes de um eventual consumo de pacote.
+func (uc *CommissionUseCase) CompleteAppointment(tx repository.Transaction, appointment *entity.Appointment) error {
+	if appointment.ProfessionalID == nil || appointment.Price <= 0 {
+		return nil
+	}
+
+	existing, err := tx.Commissions().FindByAppointmentID(appointment.ID)
+	if err != nil {
+		return errors.New("erro ao verificar comissão do agendamento: " + err.Error())
+	}
+	if existing != nil {
+		return nil // Agendamento reaberto e concluído novamente: a comissão já foi apurada
+	}
+
+	_, err = uc.recordCommission(tx.Commissions(), appointment.UserID, *appointment.ProfessionalID, entity.CommissionSourceService,
+		appointment.ServiceID, &appointment.ID, appointment.ServiceDescription, appointment.Price, appointment.StartTime)
+	return err
+}
+
+// ProductCommissionInputDTO define os dados de uma venda de produto comissionada.
+type ProductCommissionInputDTO struct {
+	UserID         uuid.UUID
+	ProfessionalID uuid.UUID
+	Description    string
+	SaleAmount     float64
+	SoldAt         time.Time
+}
+
+// RecordProductCommission apura a comissão de um profissional sobre a venda de um produto.
+// Retorna (nil, nil) quando nenhuma regra de produto se aplica ao profissional.
+func (uc *CommissionUseCase) RecordProductCommission(input ProductCommissionInputDTO) (*entity.Commission, error) {
+	commission, err := uc.BuildProductCommission(input)
+	if err != nil || commission == nil {
+		return nil, err
+	}
+	if err := uc.commissionRepo.Create(commission); err != nil {
+		return nil, errors.New("falha ao salvar comissão: " + err.Error())
+	}
+	return commission, nil
+}
+
+// BuildProductCommission apura a comissão de um profissional sobre a venda de um produto
+// sem gravá-la, para que seja gravada na transação da venda.
+// Retorna (nil, nil) quando nenhuma regra de produto se aplica ao profissional.
+func (uc *CommissionUseCase) BuildProductCommission(input ProductCommissionInputDTO) (*entity.Commission, error) {
+	if input.SaleAmount <= 0 {
+		return nil, errors.New("valor da venda deve ser maior que zero")
+	}
+	if _, err := uc.findProfessional(input.ProfessionalID, input.UserID); err != nil {
+		return nil, err
+	}
+	soldAt := input.SoldAt
+	if soldAt.IsZero() {
+		soldAt = time.Now()
+	}
+	return uc.buildCommission(input.UserID, input.ProfessionalID, entity.CommissionSourceProduct,
+		nil, nil, input.Description, input.SaleAmount, soldAt)
+}
+
+// ServiceSaleCommissionInputDTO define os dados de um serviço avulso vendido no balcão.
+type ServiceSaleCommissionInputDTO struct {
+	UserID         uuid.UUID
+	ProfessionalID uuid.UUID
+	ServiceID      *uuid.UUID // Serviço do catálogo, se houver, para regras específicas do serviço
+	Description    string
+	SaleAmount     float64
+	SoldAt         time.Time
+}
+
+// RecordServiceSaleCommission apura a comissão de um profissional sobre um serviço
+// vendido no balcão, sem agendamento. Usa as mesmas regras dos atendimentos.
+// Retorna (nil, nil) quando nenhuma regra de serviço se aplica ao profissional.
+func (uc *CommissionUseCase) RecordServiceSaleCommission(input ServiceSaleCommissionInputDTO) (*entity.Commission, error) {
+	if input.SaleAmount <= 0 {
+		return nil, errors.New("valor da venda deve ser maior que zero")
+	}
+	if _, err := uc.findProfessional(input.ProfessionalID, input.UserID); err != nil {
+		return nil, err
+	}
+	soldAt := input.SoldAt
+	if soldAt.IsZero() {
+		soldAt = time.Now()
+	}
+	return uc.recordCommission(uc.commissionRepo, input.UserID, input.ProfessionalID, entity.CommissionSourceService,
+		input.ServiceID, nil, input.Description, input.SaleAmount, soldAt)
+}
+
+// recordCommission aplica a regra mais específica e registra a comissão.
+// Retorna (nil, nil) quando nenhuma regra se aplica.
+func (uc *CommissionUseCase) recordCommission(commissionRepo repository.CommissionRepository, userID, professionalID uuid.UUID, source entity.CommissionSource,
+	serviceID, appointmentID *uuid.UUID, description string, baseAmount float64, earnedAt time.Time) (*entity.Commission, error) {
+	commission, err := uc.buildCommission(userID, professionalID, source, serviceID, appointmentID, description, baseAmount, earnedAt)
+	if err != nil || commission == nil {
+		return nil, err
+	}
+	if err := commissionRepo.Create(commission); err != nil {
+		return nil, errors.New("falha ao salvar comissão: " + err.Error())
+	}
+	return commission, nil
+}
+
+// buildCommission aplica a regra mais específica e monta a comissão, sem gravá-la.
+// Retorna (nil, nil) quando nenhuma regra se aplica.
+func (uc *CommissionUseCase) buildCommission(userID, professionalID uuid.UUID, source entity.CommissionSource,
+	serviceID, appointmentID *uuid.UUID, description string, baseAmount float64, earnedAt time.Time) (*entity.Commission, error) {
+	rules, err := uc.ruleRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar regras de comissão: " + err.Error())
+	}
+	rule := matchCommissionRule(rules, source, professionalID, serviceID)
+	if rule == nil {
+		return nil, nil
+	}
+
+	amount := rule.Value
+	if rule.Type == entity.CommissionTypePercentage {
+		amount = math.Round(baseAmount*rule.Value) / 100
+	}
+
+	commission := &entity.Commission{
+		ID:             uuid.New(),
+		UserID:         userID,
+		ProfessionalID: professionalID,
+		RuleID:         &rule.ID,
+		AppointmentID:  appointmentID,
+		Source:         source,
+		Description:    description,
+		BaseAmount:     baseAmount,
+		Amount:         amount,
+		Status:         entity.CommissionStatusPending,
+		EarnedAt:       earnedAt,
+	}
+	return commission, nil
+}
+
+// matchCommissionRule retorna a regra mais específica para o profissional e o serviço.
+// Em caso de empate vale a regra cadastrada por último.
+func matchCommissionRule(rules []*entity.CommissionRule, source entity.CommissionSource, professionalID uuid.UUID, serviceID *uuid.UUID) *entity.CommissionRule {
+	var best *entity.CommissionRule
+	bestScore := -1
+	for _, rule := range rules {
+		if rule.Source != source {
+			continue
+		}
+		score := 0
+		if rule.ProfessionalID != nil {
+			if *rule.ProfessionalID != professionalID {
+				continue
+			}
+			score += 2
+		}
+		if rule.ServiceID != nil {
+			if serviceID == nil || *rule.ServiceID != *serviceID {
+				continue
+			}
+			score++
+		}
+		if score >= bestScore {
+			best, bestScore = rule, score
+		}
+	}
+	return best
+}
+
+// -----------------------------------------------------------------------------
+// Extrato e repasse
+// -----------------------------------------------------------------------------
+
+// CommissionStatement é o extrato de comissões de um profissional em um período.
+type CommissionStatement struct {
+	Professional *entity.Professional
+	From         time.Time
+	To           time.Time // Exclusivo
+	Commissions  []*entity.Commission
+	TotalEarned  float64
+	TotalPending float64
+	TotalPaid    float64
+	TotalTips    float64 // Gorjetas recebidas no período, já incluídas em TotalEarned
+}
+
+// GetStatement monta o extrato de comissões do profissional no intervalo [from, to).
+func (uc *CommissionUseCase) GetStatement(professionalID, requestingUserID uuid.UUID, from, to time.Time) (*CommissionStatement, error) {
+	if !to.After(from) {
+		return nil, errors.New("data final deve ser posterior à data inicial")
+	}
+	professional, err := uc.findProfessional(professionalID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	commissions, err := uc.commissionRepo.FindByProfessionalID(professionalID, from, to)
+	if err != nil {
+		return nil, errors.New("erro ao buscar comissões: " + err.Error())
+	}
+
+	statement := &CommissionStatement{
+		Professional: professional,
+		From:         from,
+		To:           to,
+		Commissions:  commissions,
+	}
+	for _, c := range commissions {
+		statement.TotalEarned += c.Amount
+		if c.Source == entity.CommissionSourceTip {
+			statement.TotalTips += c.Amount
+		}
+		if c.Status == entity.CommissionStatusPaid {
+			statement.TotalPaid += c.Amount
+		} else {
+			statement.TotalPending += c.Amount
+		}
+	}
+	return statement, nil
+}
+
+// PayCommissions marca como pagas as comissões pendentes do profissional no intervalo
+// [from, to) e lança o total como saída no livro-caixa.
+func (uc *CommissionUseCase) PayCommissions(professionalID, requestingUserID uuid.UUID, from, to time.Time) (*entity.CommissionPayout, error) {
+	statement, err := uc.GetStatement(professionalID, requestingUserID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingIDs []uuid.UUID
+	for _, c := range statement.Commissions {
+		if c.Status == entity.CommissionStatusPending {
+			pendingIDs = append(pendingIDs, c.ID)
+		}
+	}
+	if len(pendingIDs) == 0 {
+		return nil, errors.New("nenhuma comissão pendente no período")
+	}
+
+	paidAt := time.Now()
+	entry := &entity.FinancialEntry{
+		ID:     uuid.New(),
+		UserID: requestingUserID,
+		Type:   entity.FinancialEntryTypeExpense,
+		Amount: math.Round(statement.TotalPending*100) / 100,
+		Description: fmt.Sprintf("Comissões de %s (%s a %s)", statement.Professional.Name,
+			from.Format("02/01/2006"), to.AddDate(0, 0, -1).Format("02/01/2006")),
+		Date: paidAt,
+	}
+	if err := uc.entryRepo.Create(entry); err != nil {
+		return nil, errors.New("falha ao lançar repasse no livro-caixa: " + err.Error())
+	}
+
+	payout := &entity.CommissionPayout{
+		ID:               uuid.New(),
+		UserID:           requestingUserID,
+		ProfessionalID:   professionalID,
+		PeriodStart:      from,
+		PeriodEnd:        to,
+		Amount:           entry.Amount,
+		CommissionCount:  len(pendingIDs),
+		FinancialEntryID: &entry.ID,
+		PaidAt:           paidAt,
+	}
+	if err := uc.payoutRepo.Create(payout); err != nil {
+		return nil, errors.New("falha ao salvar repasse: " + err.Error())
+	}
+	if err := uc.commissionRepo.MarkPaid(pendingIDs, payout.ID); err != nil {
+		return nil, errors.New("falha ao marcar comissões como pagas: " + err.Error())
+	}
+	return payout, nil
+}
+
+// ListPayouts lista os repasses de comissão de um profissional.
+func (uc *CommissionUseCase) ListPayouts(professionalID, requestingUserID uuid.UUID) ([]*entity.CommissionPayout, error) {
+	if _, err := uc.findProfessional(professionalID, requestingUserID); err != nil {
+		return nil, err
+	}
+	return uc.payoutRepo.FindByProfessionalID(professionalID)
+}
+
+// findProfessional busca um profissional verificando se pertence ao usuário.
+func (uc *CommissionUseCase) findProfessional(professionalID, userID uuid.UUID) (*entity.Professional, error) {
+	professional, err := uc.professionalRepo.FindByID(professionalID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar profissional: " + err.Error())
+	}
+	if professional == nil || professional.UserID != userID {
+		return nil, errors.New("profissional não encontrado")
+	}
+	return professional, nil
+}
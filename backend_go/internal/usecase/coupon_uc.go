@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCoupon indica que o cupom não pode ser aplicado ao agendamento.
+var ErrInvalidCoupon = errors.New("cupom inválido")
+
+// CouponUseCase encapsula o cadastro de cupons de desconto e a aplicação deles nos agendamentos.
+type CouponUseCase struct {
+	couponRepo     repository.CouponRepository
+	redemptionRepo repository.CouponRedemptionRepository
+	serviceRepo    repository.ServiceRepository
+}
+
+// NewCouponUseCase cria uma nova instância de CouponUseCase.
+func NewCouponUseCase(couponRepo repository.CouponRepository, redemptionRepo repository.CouponRedemptionRepository, serviceRepo repository.ServiceRepository) *CouponUseCase {
+	return &CouponUseCase{
+		couponRepo:     couponRepo,
+		redemptionRepo: redemptionRepo,
+		serviceRepo:    serviceRepo,
+	}
+}
+
+// CreateCouponInputDTO define os dados para cadastrar um cupom.
+type CreateCouponInputDTO struct {
+	UserID             uuid.UUID
+	Code               string
+	Description        string
+	DiscountType       entity.CouponDiscountType
+	Value              float64
+	ValidFrom          *time.Time
+	ValidUntil         *time.Time
+	MaxUses            int
+	MaxUsesPerCustomer int
+	ServiceIDs         []uuid.UUID
+}
+
+// CreateCoupon cadastra um cupom de desconto. O código é normalizado para maiúsculas.
+func (uc *CouponUseCase) CreateCoupon(input CreateCouponInputDTO) (*entity.Coupon, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	coupon := &entity.Coupon{
+		ID:                 uuid.New(),
+		UserID:             input.UserID,
+		Code:               normalizeCouponCode(input.Code),
+		Description:        input.Description,
+		DiscountType:       input.DiscountType,
+		Value:              input.Value,
+		ValidFrom:          input.ValidFrom,
+		ValidUntil:         input.ValidUntil,
+		MaxUses:            input.MaxUses,
+		MaxUsesPerCustomer: input.MaxUsesPerCustomer,
+		ServiceIDs:         input.ServiceIDs,
+		Active:             true,
+	}
+	if err := uc.validateCoupon(coupon); err != nil {
+		return nil, err
+	}
+	if err := uc.couponRepo.Create(coupon); err != nil {
+		return nil, errors.New("falha ao salvar cupom: " + err.Error())
+	}
+	return coupon, nil
+}
+
+// GetCouponByID busca um cupom verificando se pertence ao usuário.
+func (uc *CouponUseCase) GetCouponByID(couponID, requestingUserID uuid.UUID) (*entity.Coupon, error) {
+	coupon, err := uc.couponRepo.FindByID(couponID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar cupom: " + err.Error())
+	}
+	if coupon == nil || coupon.UserID != requestingUserID {
+		return nil, errors.New("cupom não encontrado")
+	}
+	return coupon, nil
+}
+
+// ListCoupons lista os cupons do usuário.
+func (uc *CouponUseCase) ListCoupons(userID uuid.UUID) ([]*entity.Coupon, error) {
+	return uc.couponRepo.FindByUserID(userID)
+}
+
+// UpdateCouponInputDTO define os dados para atualizar um cupom.
+type UpdateCouponInputDTO struct {
+	Description        *string
+	DiscountType       *entity.CouponDiscountType
+	Value              *float64
+	ValidFrom          *time.Time
+	ClearValidFrom     bool
+	ValidUntil         *time.Time
+	ClearValidUntil    bool
+	MaxUses            *int
+	MaxUsesPerCustomer *int
+	ServiceIDs         []uuid.UUID // Se não nil, substitui a lista de serviços; vazio libera todos
+	Active             *bool
+}
+
+// UpdateCoupon atualiza um cupom. O código não pode ser alterado.
+func (uc *CouponUseCase) UpdateCoupon(couponID, requestingUserID uuid.UUID, input UpdateCouponInputDTO) (*entity.Coupon, error) {
+	coupon, err := uc.GetCouponByID(couponID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Description != nil {
+		coupon.Description = *input.Description
+	}
+	if input.DiscountType != nil {
+		coupon.DiscountType = *input.DiscountType
+	}
+	if input.Value != nil {
+		coupon.Value = *input.Value
+	}
+	if input.ClearValidFrom {
+		coupon.ValidFrom = nil
+	} else if input.ValidFrom != nil {
+		coupon.ValidFrom = input.ValidFrom
+	}
+	if input.ClearValidUntil {
+		coupon.ValidUntil = nil
+	} else if input.ValidUntil != nil {
+		coupon.ValidUntil = input.ValidUntil
+	}
+	if input.MaxUses != nil {
+		coupon.MaxUses = *input.MaxUses
+	}
+	if input.MaxUsesPerCustomer != nil {
+		coupon.MaxUsesPerCustomer = *input.MaxUsesPerCustomer
+	}
+	if input.ServiceIDs != nil {
+		coupon.ServiceIDs = input.ServiceIDs
+	}
+	if input.Active != nil {
+		coupon.Active = *input.Active
+	}
+
+	if err := uc.validateCoupon(coupon); err != nil {
+		return nil, err
+	}
+	if err := uc.couponRepo.Update(coupon); err != nil {
+		return nil, errors.New("falha ao atualizar cupom: " + err.Error())
+	}
+	return coupon, nil
+}
+
+// DeleteCoupon exclui um cupom. Agendamentos que já usaram o cupom mantêm o desconto.
+func (uc *CouponUseCase) DeleteCoupon(couponID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetCouponByID(couponID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.couponRepo.Delete(couponID)
+}
+
+// ListRedemptions lista os usos de um cupom em agendamentos não cancelados.
+func (uc *CouponUseCase) ListRedemptions(couponID, requestingUserID uuid.UUID) ([]*entity.CouponRedemption, error) {
+	if _, err := uc.GetCouponByID(couponID, requestingUserID); err != nil {
+		return nil, err
+	}
+	return uc.redemptionRepo.FindByCouponID(couponID)
+}
+
+// validateCoupon valida os campos do cupom, a unicidade do código e os serviços restritos.
+func (uc *CouponUseCase) validateCoupon(coupon *entity.Coupon) error {
+	if coupon.Code == "" {
+		return errors.New("código do cupom é obrigatório")
+	}
+	for _, r := range coupon.Code {
+		if !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return errors.New("código do cupom deve conter apenas letras, números, hífen e sublinhado")
+		}
+	}
+	switch coupon.DiscountType {
+	case entity.CouponDiscountTypePercentage:
+		if coupon.Value <= 0 || coupon.Value > 100 {
+			return errors.New("percentual de desconto deve estar entre 0 e 100")
+		}
+	case entity.CouponDiscountTypeFixed:
+		if coupon.Value <= 0 {
+			return errors.New("valor do desconto deve ser maior que zero")
+		}
+	default:
+		return errors.New("tipo de desconto inválido: " + string(coupon.DiscountType))
+	}
+	if coupon.ValidFrom != nil && coupon.ValidUntil != nil && coupon.ValidUntil.Before(*coupon.ValidFrom) {
+		return errors.New("fim da validade deve ser posterior ao início")
+	}
+	if coupon.MaxUses < 0 || coupon.MaxUsesPerCustomer < 0 {
+		return errors.New("limites de uso não podem ser negativos")
+	}
+
+	existing, err := uc.couponRepo.FindByCode(coupon.UserID, coupon.Code)
+	if err != nil {
+		return errors.New("erro ao verificar código do cupom: " + err.Error())
+	}
+	if existing != nil && existing.ID != coupon.ID {
+		return errors.New("já existe um cupom com este código")
+	}
+
+	for _, serviceID := range coupon.ServiceIDs {
+		service, err := uc.serviceRepo.FindByID(serviceID)
+		if err != nil {
+			return errors.New("erro ao buscar serviço: " + err.Error())
+		}
+		if service == nil || service.UserID != coupon.UserID {
+			return errors.New("serviço não encontrado")
+		}
+	}
+	return nil
+}
+
+// RedeemCoupon valida o cupom para o agendamento, aplica o desconto sobre o preço
+// e registra o uso na transação que cria o agendamento; se a criação falhar, o uso
+// é desfeito junto. O cupom fica bloqueado até o fim da transação, de modo que
+// agendamentos simultâneos não ultrapassam os limites de uso.
+// Implementa AppointmentCouponRedeemer.
+func (uc *CouponUseCase) RedeemCoupon(tx repository.Transaction, code string, appointment *entity.Appointment) error {
+	coupon, err := tx.Coupons().FindByCode(appointment.UserID, normalizeCouponCode(code))
+	if err != nil {
+		return errors.New("erro ao buscar cupom: " + err.Error())
+	}
+	if coupon != nil {
+		if coupon, err = tx.Coupons().LockByID(coupon.ID); err != nil {
+			return errors.New("erro ao buscar cupom: " + err.Error())
+		}
+	}
+	if coupon == nil || !coupon.Active {
+		return fmt.Errorf("%w: código não encontrado", ErrInvalidCoupon)
+	}
+	if !coupon.IsValidAt(time.Now()) {
+		return fmt.Errorf("%w: fora do período de validade", ErrInvalidCoupon)
+	}
+	if !coupon.AppliesToService(appointment.ServiceID) {
+		return fmt.Errorf("%w: não vale para este serviço", ErrInvalidCoupon)
+	}
+	if appointment.Price <= 0 {
+		return fmt.Errorf("%w: agendamento sem valor a descontar", ErrInvalidCoupon)
+	}
+
+	if coupon.MaxUses > 0 {
+		used, err := tx.CouponRedemptions().CountByCouponID(coupon.ID)
+		if err != nil {
+			return errors.New("erro ao verificar usos do cupom: " + err.Error())
+		}
+		if used >= int64(coupon.MaxUses) {
+			return fmt.Errorf("%w: limite de usos atingido", ErrInvalidCoupon)
+		}
+	}
+	if coupon.MaxUsesPerCustomer > 0 {
+		if appointment.CustomerID == nil && appointment.ClientEmail == "" {
+			return fmt.Errorf("%w: informe o cliente ou o email para usar este cupom", ErrInvalidCoupon)
+		}
+		used, err := tx.CouponRedemptions().CountByCustomer(coupon.ID, appointment.CustomerID, appointment.ClientEmail)
+		if err != nil {
+			return errors.New("erro ao verificar usos do cupom: " + err.Error())
+		}
+		if used >= int64(coupon.MaxUsesPerCustomer) {
+			return fmt.Errorf("%w: limite de usos por cliente atingido", ErrInvalidCoupon)
+		}
+	}
+
+	discount := coupon.Value
+	if coupon.DiscountType == entity.CouponDiscountTypePercentage {
+		discount = math.Round(appointment.Price*coupon.Value) / 100
+	}
+	discount = math.Min(discount, appointment.Price)
+
+	redemption := &entity.CouponRedemption{
+		ID:             uuid.New(),
+		CouponID:       coupon.ID,
+		AppointmentID:  appointment.ID,
+		CustomerID:     appointment.CustomerID,
+		ClientEmail:    appointment.ClientEmail,
+		DiscountAmount: discount,
+	}
+	if err := tx.CouponRedemptions().Create(redemption); err != nil {
+		return errors.New("falha ao registrar uso do cupom: " + err.Error())
+	}
+
+	appointment.Price = math.Round((appointment.Price-discount)*100) / 100
+	appointment.CouponID = &coupon.ID
+	return nil
+}
+
+// normalizeCouponCode remove espaços e converte o código para maiúsculas.
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
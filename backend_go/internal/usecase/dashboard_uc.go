@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidDashboard indica que os parâmetros do painel ou o horário de funcionamento são inválidos.
+var ErrInvalidDashboard = errors.New("parâmetros do painel inválidos")
+
+// DashboardUseCase calcula os indicadores do painel (faturamento, agendamentos,
+// ocupação e rankings) a partir de agregações no banco.
+type DashboardUseCase struct {
+	dashboardRepo    repository.DashboardRepository
+	revenueRepo      repository.RevenueRepository
+	workingHoursRepo repository.WorkingHoursRepository
+	serviceRepo      repository.ServiceRepository
+	clientRepo       repository.ClientRepository
+	professionalRepo repository.ProfessionalRepository
+}
+
+// NewDashboardUseCase cria uma nova instância de DashboardUseCase.
+func NewDashboardUseCase(
+	dashboardRepo repository.DashboardRepository,
+	revenueRepo repository.RevenueRepository,
+	workingHoursRepo repository.WorkingHoursRepository,
+	serviceRepo repository.ServiceRepository,
+	clientRepo repository.ClientRepository,
+	professionalRepo repository.ProfessionalRepository,
+) *DashboardUseCase {
+	return &DashboardUseCase{
+		dashboardRepo:    dashboardRepo,
+		revenueRepo:      revenueRepo,
+		workingHoursRepo: workingHoursRepo,
+		serviceRepo:      serviceRepo,
+		clientRepo:       clientRepo,
+		professionalRepo: professionalRepo,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Indicadores
+// -----------------------------------------------------------------------------
+
+// DashboardKPIs são os indicadores de um período [From, To). Taxas em percentual.
+type DashboardKPIs struct {
+	From                  time.Time
+	To                    time.Time
+	Revenue               float64 // Faturamento bruto (mesma regra do relatório anual)
+	AppointmentsByStatus  map[entity.AppointmentStatus]int
+	TotalAppointments     int
+	CompletedAppointments int
+	NoShowRate            float64 // NO_SHOW sobre o total de agendamentos
+	CancellationRate      float64 // CANCELLED sobre o total de agendamentos
+	AverageTicket         float64 // Preço médio dos atendimentos concluídos
+	BookedMinutes         float64 // Duração dos agendamentos não cancelados
+	AvailableMinutes      float64 // Horário de funcionamento x profissionais ativos
+	OccupancyRate         float64 // BookedMinutes sobre AvailableMinutes; zero sem horário cadastrado
+}
+
+// DashboardChanges compara o período atual com o anterior. Variações de valores são
+// percentuais (nil quando o período anterior é zero); variações de taxas são em
+// pontos percentuais.
+type DashboardChanges struct {
+	Revenue          *float64
+	Appointments     *float64
+	AverageTicket    *float64
+	NoShowRate       float64
+	CancellationRate float64
+	OccupancyRate    float64
+}
+
+// DashboardSummary reúne os indicadores do período, os do período anterior de mesma
+// duração e a comparação entre eles.
+type DashboardSummary struct {
+	Current  *DashboardKPIs
+	Previous *DashboardKPIs
+	Changes  DashboardChanges
+}
+
+// GetSummary calcula os indicadores do intervalo [from, to) e os compara com o período
+// imediatamente anterior de mesma duração.
+func (uc *DashboardUseCase) GetSummary(userID uuid.UUID, from, to time.Time) (*DashboardSummary, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: data final deve ser posterior à inicial", ErrInvalidDashboard)
+	}
+	capacity, err := uc.dailyCapacity(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.computeKPIs(userID, from, to, capacity)
+	if err != nil {
+		return nil, err
+	}
+	previousFrom := from.Add(-to.Sub(from))
+	previous, err := uc.computeKPIs(userID, previousFrom, from, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DashboardSummary{
+		Current:  current,
+		Previous: previous,
+		Changes: DashboardChanges{
+			Revenue:          percentChange(current.Revenue, previous.Revenue),
+			Appointments:     percentChange(float64(current.TotalAppointments), float64(previous.TotalAppointments)),
+			AverageTicket:    percentChange(current.AverageTicket, previous.AverageTicket),
+			NoShowRate:       roundCents(current.NoShowRate - previous.NoShowRate),
+			CancellationRate: roundCents(current.CancellationRate - previous.CancellationRate),
+			OccupancyRate:    roundCents(current.OccupancyRate - previous.OccupancyRate),
+		},
+	}, nil
+}
+
+func (uc *DashboardUseCase) computeKPIs(userID uuid.UUID, from, to time.Time, capacity map[time.Weekday]float64) (*DashboardKPIs, error) {
+	kpis := &DashboardKPIs{From: from, To: to}
+
+	revenue, err := uc.revenueRepo.SumRevenue(userID, from, to)
+	if err != nil {
+		return nil, errors.New("erro ao calcular faturamento: " + err.Error())
+	}
+	kpis.Revenue = roundCents(revenue)
+
+	kpis.AppointmentsByStatus, err = uc.dashboardRepo.CountAppointmentsByStatus(userID, from, to)
+	if err != nil {
+		return nil, errors.New("erro ao contar agendamentos: " + err.Error())
+	}
+	for _, count := range kpis.AppointmentsByStatus {
+		kpis.TotalAppointments += count
+	}
+	if kpis.TotalAppointments > 0 {
+		total := float64(kpis.TotalAppointments)
+		kpis.NoShowRate = roundCents(float64(kpis.AppointmentsByStatus[entity.AppointmentStatusNoShow]) * 100 / total)
+		kpis.CancellationRate = roundCents(float64(kpis.AppointmentsByStatus[entity.AppointmentStatusCancelled]) * 100 / total)
+	}
+
+	completedTotal, completedCount, err := uc.dashboardRepo.CompletedAppointmentsRevenue(userID, from, to)
+	if err != nil {
+		return nil, errors.New("erro ao calcular ticket médio: " + err.Error())
+	}
+	kpis.CompletedAppointments = completedCount
+	if completedCount > 0 {
+		kpis.AverageTicket = roundCents(completedTotal / float64(completedCount))
+	}
+
+	booked, err := uc.dashboardRepo.BookedMinutes(userID, from, to)
+	if err != nil {
+		return nil, errors.New("erro ao calcular ocupação: " + err.Error())
+	}
+	kpis.BookedMinutes = booked
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		kpis.AvailableMinutes += capacity[day.Weekday()]
+	}
+	if kpis.AvailableMinutes > 0 {
+		kpis.OccupancyRate = roundCents(booked * 100 / kpis.AvailableMinutes)
+	}
+	return kpis, nil
+}
+
+// dailyCapacity retorna, para cada dia da semana, os minutos disponíveis para
+// atendimento: o horário de funcionamento multiplicado pelos profissionais ativos
+// (ao menos um, para negócios sem equipe cadastrada).
+func (uc *DashboardUseCase) dailyCapacity(userID uuid.UUID) (map[time.Weekday]float64, error) {
+	hours, err := uc.workingHoursRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar horário de funcionamento: " + err.Error())
+	}
+	professionals, err := uc.professionalRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar profissionais: " + err.Error())
+	}
+	staff := 0
+	for _, p := range professionals {
+		if p.Active {
+			staff++
+		}
+	}
+	if staff == 0 {
+		staff = 1
+	}
+
+	capacity := make(map[time.Weekday]float64, len(hours))
+	for _, h := range hours {
+		capacity[h.Weekday] += float64(h.Minutes() * staff)
+	}
+	return capacity, nil
+}
+
+// percentChange retorna a variação percentual de previous para current, ou nil se
+// previous for zero.
+func percentChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := roundCents((current - previous) * 100 / previous)
+	return &change
+}
+
+// -----------------------------------------------------------------------------
+// Séries e rankings
+// -----------------------------------------------------------------------------
+
+// GetRevenueSeries agrega o faturamento do intervalo [from, to) por dia, semana ou mês.
+// Períodos sem faturamento aparecem com valor zero.
+func (uc *DashboardUseCase) GetRevenueSeries(userID uuid.UUID, from, to time.Time, groupBy string) ([]repository.RevenuePoint, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: data final deve ser posterior à inicial", ErrInvalidDashboard)
+	}
+	var step func(time.Time) time.Time
+	var truncate func(time.Time) time.Time
+	switch groupBy {
+	case "day":
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+		truncate = startOfDay
+	case "week":
+		// Semanas começam na segunda-feira, como no date_trunc do PostgreSQL
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+		truncate = func(t time.Time) time.Time {
+			day := startOfDay(t)
+			return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		}
+	case "month":
+		step = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+		truncate = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()) }
+	default:
+		return nil, fmt.Errorf("%w: agrupamento deve ser day, week ou month", ErrInvalidDashboard)
+	}
+
+	points, err := uc.dashboardRepo.RevenueSeries(userID, from, to, groupBy)
+	if err != nil {
+		return nil, errors.New("erro ao calcular série de faturamento: " + err.Error())
+	}
+	amounts := make(map[string]float64, len(points))
+	for _, p := range points {
+		amounts[truncate(p.PeriodStart.In(from.Location())).Format("2006-01-02")] += p.Amount
+	}
+
+	var series []repository.RevenuePoint
+	for period := truncate(from); period.Before(to); period = step(period) {
+		series = append(series, repository.RevenuePoint{
+			PeriodStart: period,
+			Amount:      roundCents(amounts[period.Format("2006-01-02")]),
+		})
+	}
+	return series, nil
+}
+
+// GetTopServices lista os serviços com maior faturamento no intervalo [from, to).
+func (uc *DashboardUseCase) GetTopServices(userID uuid.UUID, from, to time.Time, limit int) ([]repository.RankingRow, error) {
+	if err := validateRanking(from, to, limit); err != nil {
+		return nil, err
+	}
+	rows, err := uc.dashboardRepo.TopServices(userID, from, to, limit)
+	if err != nil {
+		return nil, errors.New("erro ao calcular serviços mais vendidos: " + err.Error())
+	}
+	for i := range rows {
+		if rows[i].ID == nil {
+			continue
+		}
+		rows[i].Name = "Serviço removido"
+		if service, err := uc.serviceRepo.FindByID(*rows[i].ID); err == nil && service != nil {
+			rows[i].Name = service.Name
+		}
+	}
+	sortRanking(rows)
+	return rows, nil
+}
+
+// GetTopClients lista os clientes com maior faturamento no intervalo [from, to).
+func (uc *DashboardUseCase) GetTopClients(userID uuid.UUID, from, to time.Time, limit int) ([]repository.RankingRow, error) {
+	if err := validateRanking(from, to, limit); err != nil {
+		return nil, err
+	}
+	rows, err := uc.dashboardRepo.TopClients(userID, from, to, limit)
+	if err != nil {
+		return nil, errors.New("erro ao calcular melhores clientes: " + err.Error())
+	}
+	for i := range rows {
+		if rows[i].ID == nil {
+			continue
+		}
+		rows[i].Name = "Cliente removido"
+		if client, err := uc.clientRepo.FindByID(*rows[i].ID); err == nil && client != nil {
+			rows[i].Name = client.Name
+		}
+	}
+	sortRanking(rows)
+	return rows, nil
+}
+
+func validateRanking(from, to time.Time, limit int) error {
+	if !to.After(from) {
+		return fmt.Errorf("%w: data final deve ser posterior à inicial", ErrInvalidDashboard)
+	}
+	if limit < 1 || limit > 50 {
+		return fmt.Errorf("%w: limite deve estar entre 1 e 50", ErrInvalidDashboard)
+	}
+	return nil
+}
+
+// sortRanking garante a ordem por faturamento e, no empate, por quantidade e nome.
+func sortRanking(rows []repository.RankingRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Revenue != rows[j].Revenue {
+			return rows[i].Revenue > rows[j].Revenue
+		}
+		if rows[i].Appointments != rows[j].Appointments {
+			return rows[i].Appointments > rows[j].Appointments
+		}
+		return rows[i].Name < rows[j].Name
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Horário de funcionamento
+// -----------------------------------------------------------------------------
+
+// GetWorkingHours retorna o horário de funcionamento semanal do usuário.
+func (uc *DashboardUseCase) GetWorkingHours(userID uuid.UUID) ([]*entity.WorkingHours, error) {
+	return uc.workingHoursRepo.FindByUserID(userID)
+}
+
+// SaveWorkingHours substitui o horário de funcionamento semanal. Cada dia da semana
+// aparece no máximo uma vez; dias omitidos ficam fechados.
+func (uc *DashboardUseCase) SaveWorkingHours(userID uuid.UUID, hours []*entity.WorkingHours) ([]*entity.WorkingHours, error) {
+	seen := make(map[time.Weekday]bool, len(hours))
+	for _, h := range hours {
+		if h.Weekday < time.Sunday || h.Weekday > time.Saturday {
+			return nil, fmt.Errorf("%w: dia da semana inválido", ErrInvalidDashboard)
+		}
+		if seen[h.Weekday] {
+			return nil, fmt.Errorf("%w: dia da semana repetido", ErrInvalidDashboard)
+		}
+		seen[h.Weekday] = true
+		if h.OpenMinute < 0 || h.CloseMinute > 24*60 || h.CloseMinute <= h.OpenMinute {
+			return nil, fmt.Errorf("%w: horário de fechamento deve ser posterior ao de abertura", ErrInvalidDashboard)
+		}
+		h.ID = uuid.New()
+		h.UserID = userID
+	}
+	if err := uc.workingHoursRepo.ReplaceForUser(userID, hours); err != nil {
+		return nil, errors.New("falha ao salvar horário de funcionamento: " + err.Error())
+	}
+	sort.Slice(hours, func(i, j int) bool { return hours[i].Weekday < hours[j].Weekday })
+	return hours, nil
+}
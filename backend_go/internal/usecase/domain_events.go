@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+)
+
+// appointmentEvents monta os eventos de domínio de uma alteração de agendamento a partir
+// do estado anterior (nil na criação). Uma mudança de status gera o evento do novo status
+// e, se o horário também mudou, appointment.rescheduled; alterações sem mudança de status
+// ou horário geram appointment.updated.
+func appointmentEvents(previous, appointment *entity.Appointment) ([]*entity.DomainEvent, error) {
+	var types []entity.DomainEventType
+	if previous == nil {
+		types = append(types, entity.DomainEventAppointmentCreated)
+	} else {
+		if previous.Status != appointment.Status {
+			switch appointment.Status {
+			case entity.AppointmentStatusConfirmed:
+				types = append(types, entity.DomainEventAppointmentConfirmed)
+			case entity.AppointmentStatusCancelled:
+				types = append(types, entity.DomainEventAppointmentCancelled)
+			case entity.AppointmentStatusCompleted:
+				types = append(types, entity.DomainEventAppointmentCompleted)
+			}
+		}
+		if !previous.StartTime.Equal(appointment.StartTime) || !previous.EndTime.Equal(appointment.EndTime) {
+			types = append(types, entity.DomainEventAppointmentRescheduled)
+		}
+		if len(types) == 0 {
+			types = append(types, entity.DomainEventAppointmentUpdated)
+		}
+	}
+
+	payload := appointmentEventPayload(previous, appointment)
+	var events []*entity.DomainEvent
+	for _, eventType := range types {
+		event, err := entity.NewDomainEvent(appointment.UserID, eventType, appointment.ID, payload)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// appointmentEventPayload monta os dados de um evento de agendamento.
+func appointmentEventPayload(previous, appointment *entity.Appointment) entity.AppointmentEventPayload {
+	payload := entity.AppointmentEventPayload{
+		AppointmentID:      appointment.ID,
+		CustomerID:         appointment.CustomerID,
+		ServiceID:          appointment.ServiceID,
+		ProfessionalID:     appointment.ProfessionalID,
+		ClientName:         appointment.ClientName,
+		ClientEmail:        appointment.ClientEmail,
+		ClientPhone:        appointment.ClientPhone,
+		ServiceDescription: appointment.ServiceDescription,
+		StartTime:          appointment.StartTime,
+		EndTime:            appointment.EndTime,
+		Status:             appointment.Status,
+		Price:              appointment.Price,
+	}
+	if previous != nil {
+		if !previous.StartTime.Equal(appointment.StartTime) || !previous.EndTime.Equal(appointment.EndTime) {
+			previousStart, previousEnd := previous.StartTime, previous.EndTime
+			payload.PreviousStartTime = &previousStart
+			payload.PreviousEndTime = &previousEnd
+		}
+		if previous.Status != appointment.Status {
+			payload.PreviousStatus = previous.Status
+		}
+	}
+	return payload
+}
+
+// clientEvent monta um evento de cliente.
+func clientEvent(eventType entity.DomainEventType, client *entity.Client) (*entity.DomainEvent, error) {
+	return entity.NewDomainEvent(client.UserID, eventType, client.ID, entity.ClientEventPayload{
+		ClientID: client.ID,
+		Name:     client.Name,
+		Email:    client.Email,
+		Phone:    client.Phone,
+	})
+}
+
+// paymentEvent monta um evento de pagamento.
+func paymentEvent(eventType entity.DomainEventType, p *entity.Payment) (*entity.DomainEvent, error) {
+	return entity.NewDomainEvent(p.UserID, eventType, p.ID, entity.PaymentEventPayload{
+		PaymentID:     p.ID,
+		AppointmentID: p.AppointmentID,
+		CheckoutID:    p.CheckoutID,
+		SaleID:        p.SaleID,
+		Amount:        p.Amount,
+		Method:        p.Method,
+		Provider:      p.Provider,
+		Status:        p.Status,
+		PaidAt:        p.PaidAt,
+	})
+}
+
+// paidPaymentEvents monta um evento payment.received para cada pagamento pago.
+func paidPaymentEvents(payments []*entity.Payment) ([]*entity.DomainEvent, error) {
+	var events []*entity.DomainEvent
+	for _, p := range payments {
+		if p.Status != entity.PaymentStatusPaid {
+			continue
+		}
+		event, err := paymentEvent(entity.DomainEventPaymentReceived, p)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+)
+
+// defaultEmailLocale é o idioma usado enquanto o usuário não configura outro.
+const defaultEmailLocale = "pt-BR"
+
+// emailEventText são os textos de um evento. Os campos aceitam os verbos de fmt na
+// ordem indicada.
+type emailEventText struct {
+	Subject string // serviço, data
+	Title   string
+	Intro   string // serviço, negócio
+	Closing string
+}
+
+// emailLocale reúne os textos e formatos de data de um idioma dos e-mails.
+type emailLocale struct {
+	DateFormat    string
+	TimeFormat    string
+	Greeting      string // nome do cliente
+	LabelService  string
+	LabelDate     string
+	LabelTime     string
+	LabelPrevious string
+	ContactHint   string
+	FooterNote    string // negócio
+	Events        map[entity.EmailEvent]emailEventText
+}
+
+// emailLocales são os idiomas disponíveis para os e-mails aos clientes.
+var emailLocales = map[string]emailLocale{
+	"pt-BR": {
+		DateFormat:    "02/01/2006",
+		TimeFormat:    "15:04",
+		Greeting:      "Olá, %s!",
+		LabelService:  "Serviço",
+		LabelDate:     "Data",
+		LabelTime:     "Horário",
+		LabelPrevious: "Horário anterior",
+		ContactHint:   "Se precisar alterar ou cancelar, basta responder a este e-mail.",
+		FooterNote:    "Você recebeu este e-mail porque tem um agendamento em %s.",
+		Events: map[entity.EmailEvent]emailEventText{
+			entity.EmailEventAppointmentCreated: {
+				Subject: "Agendamento confirmado: %s em %s",
+				Title:   "Seu horário está agendado",
+				Intro:   "Seu horário de %s em %s foi agendado. Confira os detalhes abaixo.",
+				Closing: "Até breve,",
+			},
+			entity.EmailEventAppointmentRescheduled: {
+				Subject: "Agendamento remarcado: %s em %s",
+				Title:   "Seu horário foi remarcado",
+				Intro:   "Seu horário de %s em %s mudou. Confira o novo horário abaixo.",
+				Closing: "Até breve,",
+			},
+			entity.EmailEventAppointmentCancelled: {
+				Subject: "Agendamento cancelado: %s em %s",
+				Title:   "Seu horário foi cancelado",
+				Intro:   "Seu horário de %s em %s foi cancelado.",
+				Closing: "Esperamos atendê-lo em breve,",
+			},
+		},
+	},
+	"en-US": {
+		DateFormat:    "01/02/2006",
+		TimeFormat:    "3:04 PM",
+		Greeting:      "Hi %s,",
+		LabelService:  "Service",
+		LabelDate:     "Date",
+		LabelTime:     "Time",
+		LabelPrevious: "Previous time",
+		ContactHint:   "If you need to reschedule or cancel, just reply to this email.",
+		FooterNote:    "You are receiving this email because you have an appointment at %s.",
+		Events: map[entity.EmailEvent]emailEventText{
+			entity.EmailEventAppointmentCreated: {
+				Subject: "Appointment confirmed: %s on %s",
+				Title:   "Your appointment is booked",
+				Intro:   "Your %s appointment at %s is booked. See the details below.",
+				Closing: "See you soon,",
+			},
+			entity.EmailEventAppointmentRescheduled: {
+				Subject: "Appointment rescheduled: %s on %s",
+				Title:   "Your appointment was rescheduled",
+				Intro:   "Your %s appointment at %s has changed. See the new time below.",
+				Closing: "See you soon,",
+			},
+			entity.EmailEventAppointmentCancelled: {
+				Subject: "Appointment cancelled: %s on %s",
+				Title:   "Your appointment was cancelled",
+				Intro:   "Your %s appointment at %s was cancelled.",
+				Closing: "We hope to see you soon,",
+			},
+		},
+	},
+	"es-ES": {
+		DateFormat:    "02/01/2006",
+		TimeFormat:    "15:04",
+		Greeting:      "¡Hola, %s!",
+		LabelService:  "Servicio",
+		LabelDate:     "Fecha",
+		LabelTime:     "Hora",
+		LabelPrevious: "Hora anterior",
+		ContactHint:   "Si necesitas cambiar o cancelar, responde a este correo.",
+		FooterNote:    "Recibiste este correo porque tienes una cita en %s.",
+		Events: map[entity.EmailEvent]emailEventText{
+			entity.EmailEventAppointmentCreated: {
+				Subject: "Cita confirmada: %s el %s",
+				Title:   "Tu cita está reservada",
+				Intro:   "Tu cita de %s en %s está reservada. Consulta los detalles abajo.",
+				Closing: "¡Hasta pronto!",
+			},
+			entity.EmailEventAppointmentRescheduled: {
+				Subject: "Cita reprogramada: %s el %s",
+				Title:   "Tu cita fue reprogramada",
+				Intro:   "Tu cita de %s en %s cambió. Consulta el nuevo horario abajo.",
+				Closing: "¡Hasta pronto!",
+			},
+			entity.EmailEventAppointmentCancelled: {
+				Subject: "Cita cancelada: %s el %s",
+				Title:   "Tu cita fue cancelada",
+				Intro:   "Tu cita de %s en %s fue cancelada.",
+				Closing: "Esperamos verte pronto,",
+			},
+		},
+	},
+}
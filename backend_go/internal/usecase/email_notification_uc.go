@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/ical"
+	mailer "github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/mail"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidEmailSettings indica que as configurações de e-mail informadas são inválidas.
+var ErrInvalidEmailSettings = errors.New("configuração de e-mail inválida")
+
+// ErrEmailStatus indica que a operação não é permitida no status atual do e-mail.
+var ErrEmailStatus = errors.New("operação não permitida para o status do e-mail")
+
+const (
+	// emailMaxAttempts é o número máximo de tentativas de envio de um e-mail da fila.
+	emailMaxAttempts = 5
+	// emailRetryDelay é o intervalo base entre as tentativas; cresce com o quadrado
+	// do número de tentativas (1, 4, 9, 16 minutos).
+	emailRetryDelay = time.Minute
+	// emailSendLease é por quanto tempo um e-mail reservado para envio fica fora da fila;
+	// se o processo cair durante o envio, ele volta a ser tentado depois desse prazo.
+	emailSendLease = 10 * time.Minute
+	// emailDispatchBatch é o número máximo de e-mails enviados por execução.
+	emailDispatchBatch = 50
+
+	defaultEmailPrimaryColor = "#4F46E5"
+)
+
+var emailColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// EmailNotificationUseCase avisa os clientes por e-mail quando um agendamento é criado,
+// remarcado ou cancelado. Os e-mails passam por uma fila (outbox) persistida, de onde
+// são enviados e, em caso de falha, tentados novamente.
+type EmailNotificationUseCase struct {
+	settingsRepo repository.EmailSettingsRepository
+	outboxRepo   repository.EmailOutboxRepository
+	userRepo     repository.UserRepository
+	mailer       mailer.Mailer
+}
+
+// NewEmailNotificationUseCase cria uma nova instância de EmailNotificationUseCase.
+func NewEmailNotificationUseCase(
+	settingsRepo repository.EmailSettingsRepository,
+	outboxRepo repository.EmailOutboxRepository,
+	userRepo repository.UserRepository,
+	m mailer.Mailer,
+) *EmailNotificationUseCase {
+	return &EmailNotificationUseCase{
+		settingsRepo: settingsRepo,
+		outboxRepo:   outboxRepo,
+		userRepo:     userRepo,
+		mailer:       m,
+	}
+}
+
+// GetSettings retorna as configurações de e-mail do usuário ou, se ele ainda não
+// configurou, as padrão (envio habilitado, em português).
+func (uc *EmailNotificationUseCase) GetSettings(userID uuid.UUID) (*entity.EmailSettings, error) {
+	settings, err := uc.settingsRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar configurações de e-mail: " + err.Error())
+	}
+	if settings == nil {
+		settings = &entity.EmailSettings{
+			UserID:       userID,
+			Enabled:      true,
+			Locale:       defaultEmailLocale,
+			PrimaryColor: defaultEmailPrimaryColor,
+		}
+	}
+	return settings, nil
+}
+
+// SaveEmailSettingsInputDTO define os dados das configurações de e-mail.
+type SaveEmailSettingsInputDTO struct {
+	Enabled      bool
+	Locale       string
+	SenderName   string
+	ReplyTo      string
+	LogoURL      string
+	PrimaryColor string
+	FooterText   string
+}
+
+// SaveSettings cria ou atualiza as configurações de e-mail do usuário.
+func (uc *EmailNotificationUseCase) SaveSettings(userID uuid.UUID, input SaveEmailSettingsInputDTO) (*entity.EmailSettings, error) {
+	settings := &entity.EmailSettings{
+		UserID:       userID,
+		Enabled:      input.Enabled,
+		Locale:       strings.TrimSpace(input.Locale),
+		SenderName:   strings.TrimSpace(input.SenderName),
+		ReplyTo:      strings.TrimSpace(input.ReplyTo),
+		LogoURL:      strings.TrimSpace(input.LogoURL),
+		PrimaryColor: strings.TrimSpace(input.PrimaryColor),
+		FooterText:   strings.TrimSpace(input.FooterText),
+	}
+	if settings.Locale == "" {
+		settings.Locale = defaultEmailLocale
+	}
+	if _, ok := emailLocales[settings.Locale]; !ok {
+		return nil, fmt.Errorf("%w: idioma não suportado: %s (use pt-BR, en-US ou es-ES)", ErrInvalidEmailSettings, settings.Locale)
+	}
+	if settings.PrimaryColor == "" {
+		settings.PrimaryColor = defaultEmailPrimaryColor
+	}
+	if !emailColorPattern.MatchString(settings.PrimaryColor) {
+		return nil, fmt.Errorf("%w: cor deve estar no formato #RRGGBB", ErrInvalidEmailSettings)
+	}
+	if settings.ReplyTo != "" {
+		if _, err := mail.ParseAddress(settings.ReplyTo); err != nil {
+			return nil, fmt.Errorf("%w: e-mail de resposta inválido", ErrInvalidEmailSettings)
+		}
+	}
+	if settings.LogoURL != "" {
+		parsed, err := url.Parse(settings.LogoURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: URL do logotipo deve ser http(s)", ErrInvalidEmailSettings)
+		}
+	}
+	if len(settings.SenderName) > 100 {
+		return nil, fmt.Errorf("%w: nome do remetente deve ter no máximo 100 caracteres", ErrInvalidEmailSettings)
+	}
+
+	if err := uc.settingsRepo.Save(settings); err != nil {
+		return nil, errors.New("falha ao salvar configurações de e-mail: " + err.Error())
+	}
+	return settings, nil
+}
+
+// RenderedEmail é um e-mail renderizado, usado na pré-visualização.
+type RenderedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// PreviewEmail renderiza o e-mail do evento com as configurações atuais do usuário e um
+// agendamento de exemplo. locale vazio usa o idioma configurado.
+func (uc *EmailNotificationUseCase) PreviewEmail(userID uuid.UUID, event entity.EmailEvent, locale string) (*RenderedEmail, error) {
+	settings, err := uc.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	if locale != "" {
+		if _, ok := emailLocales[locale]; !ok {
+			return nil, fmt.Errorf("%w: idioma não suportado: %s", ErrInvalidEmailSettings, locale)
+		}
+		settings.Locale = locale
+	}
+
+	start := startOfDay(time.Now()).AddDate(0, 0, 1).Add(14 * time.Hour)
+	sample := &entity.Appointment{
+		UserID:             userID,
+		ClientName:         "Maria Silva",
+		ServiceDescription: "Corte de cabelo",
+		StartTime:          start,
+		EndTime:            start.Add(time.Hour),
+	}
+	var previous *entity.Appointment
+	if event == entity.EmailEventAppointmentRescheduled {
+		moved := *sample
+		moved.StartTime = start.Add(-24 * time.Hour)
+		previous = &moved
+	}
+	subject, htmlBody, textBody, err := uc.render(event, settings, uc.businessName(userID), previous, sample)
+	if err != nil {
+		return nil, err
+	}
+	return &RenderedEmail{Subject: subject, HTML: htmlBody, Text: textBody}, nil
+}
+
+// ListOutbox lista os e-mails do usuário; appointmentID nil e status vazio não filtram.
+func (uc *EmailNotificationUseCase) ListOutbox(userID uuid.UUID, appointmentID *uuid.UUID, status entity.EmailStatus) ([]*entity.OutboundEmail, error) {
+	return uc.outboxRepo.FindByUserID(userID, appointmentID, status)
+}
+
+// RetryEmail recoloca na fila um e-mail que falhou definitivamente, zerando as tentativas.
+func (uc *EmailNotificationUseCase) RetryEmail(emailID, requestingUserID uuid.UUID) (*entity.OutboundEmail, error) {
+	email, err := uc.outboxRepo.FindByID(emailID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar e-mail: " + err.Error())
+	}
+	if email == nil || email.UserID != requestingUserID {
+		return nil, errors.New("e-mail não encontrado")
+	}
+	if email.Status != entity.EmailStatusFailed {
+		return nil, fmt.Errorf("%w: apenas e-mails com falha podem ser reenviados", ErrEmailStatus)
+	}
+
+	email.Status = entity.EmailStatusPending
+	email.Attempts = 0
+	email.NextAttemptAt = time.Now()
+	if err := uc.outboxRepo.Update(email); err != nil {
+		return nil, errors.New("falha ao reenfileirar e-mail: " + err.Error())
+	}
+	queued := *email // O envio ocorre em paralelo à resposta; não compartilha a entidade
+	go uc.deliver(&queued, time.Now())
+	return email, nil
+}
+
+// OnAppointmentChanged enfileira o e-mail ao cliente quando um agendamento futuro é
+// criado, remarcado (novo horário de início) ou cancelado.
+func (uc *EmailNotificationUseCase) OnAppointmentChanged(previous, appointment *entity.Appointment) {
+	event, ok := appointmentEmailEvent(previous, appointment)
+	if !ok || strings.TrimSpace(appointment.ClientEmail) == "" || !appointment.StartTime.After(time.Now()) {
+		return
+	}
+	if err := uc.enqueue(event, previous, appointment); err != nil {
+		log.Printf("Erro ao enfileirar e-mail do agendamento %s: %v", appointment.ID, err)
+	}
+}
+
+// OnAppointmentDeleted não envia e-mail: a exclusão é uma correção do cadastro, e não um
+// cancelamento combinado com o cliente.
+func (uc *EmailNotificationUseCase) OnAppointmentDeleted(appointment *entity.Appointment) {}
+
+// appointmentEmailEvent identifica o evento a ser comunicado ao cliente, se houver.
+func appointmentEmailEvent(previous, appointment *entity.Appointment) (entity.EmailEvent, bool) {
+	if previous == nil {
+		return entity.EmailEventAppointmentCreated, reminderAppointmentActive(appointment)
+	}
+	if appointment.Status == entity.AppointmentStatusCancelled && previous.Status != entity.AppointmentStatusCancelled {
+		return entity.EmailEventAppointmentCancelled, true
+	}
+	if reminderAppointmentActive(previous) && reminderAppointmentActive(appointment) && !previous.StartTime.Equal(appointment.StartTime) {
+		return entity.EmailEventAppointmentRescheduled, true
+	}
+	return "", false
+}
+
+func (uc *EmailNotificationUseCase) enqueue(event entity.EmailEvent, previous, appointment *entity.Appointment) error {
+	settings, err := uc.GetSettings(appointment.UserID)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled {
+		return nil
+	}
+
+	businessName := uc.businessName(appointment.UserID)
+	subject, htmlBody, textBody, err := uc.render(event, settings, businessName, previous, appointment)
+	if err != nil {
+		return err
+	}
+	fromName := settings.SenderName
+	if fromName == "" {
+		fromName = businessName
+	}
+
+	appointmentID := appointment.ID
+	now := time.Now()
+	email := &entity.OutboundEmail{
+		ID:            uuid.New(),
+		UserID:        appointment.UserID,
+		AppointmentID: &appointmentID,
+		Event:         event,
+		Recipient:     strings.TrimSpace(appointment.ClientEmail),
+		FromName:      fromName,
+		ReplyTo:       settings.ReplyTo,
+		Subject:       subject,
+		HTMLBody:      htmlBody,
+		TextBody:      textBody,
+		ICSAttachment: string(clientAppointmentICS(appointment, businessName, now)),
+		Status:        entity.EmailStatusPending,
+		NextAttemptAt: now,
+	}
+	if err := uc.outboxRepo.Create(email); err != nil {
+		return err
+	}
+	// Tenta enviar imediatamente, fora da requisição; se falhar, o DispatchOutbox
+	// periódico tenta novamente.
+	go uc.deliver(email, now)
+	return nil
+}
+
+// clientAppointmentICS gera o convite .ics anexado ao e-mail do cliente. Tem o mesmo
+// UID e SEQUENCE do evento do feed, mas sem os dados internos do agendamento; no
+// cancelamento, o STATUS:CANCELLED remove o evento do calendário do cliente.
+func clientAppointmentICS(appointment *entity.Appointment, businessName string, now time.Time) []byte {
+	event := appointmentCalendarEvent(appointment, businessName)
+	event.Summary = strings.TrimSuffix(appointment.ServiceDescription+" - "+businessName, " - ")
+	event.Description = ""
+	return singleEventICS(event, now)
+}
+
+func (uc *EmailNotificationUseCase) businessName(userID uuid.UUID) string {
+	user, err := uc.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.Name
+}
+
+// render monta o assunto e as versões HTML e texto do e-mail do evento, no idioma e com
+// a identidade visual configurados.
+func (uc *EmailNotificationUseCase) render(event entity.EmailEvent, settings *entity.EmailSettings, businessName string, previous, appointment *entity.Appointment) (string, string, string, error) {
+	locale, ok := emailLocales[settings.Locale]
+	if !ok {
+		locale = emailLocales[defaultEmailLocale]
+	}
+	text, ok := locale.Events[event]
+	if !ok {
+		return "", "", "", fmt.Errorf("%w: evento desconhecido: %s", ErrInvalidEmailSettings, event)
+	}
+
+	start := appointment.StartTime.In(time.Local)
+	date := start.Format(locale.DateFormat)
+	details := []mailer.Detail{
+		{Label: locale.LabelService, Value: appointment.ServiceDescription},
+		{Label: locale.LabelDate, Value: date},
+		{Label: locale.LabelTime, Value: start.Format(locale.TimeFormat)},
+	}
+	if event == entity.EmailEventAppointmentRescheduled && previous != nil {
+		before := previous.StartTime.In(time.Local)
+		details = append(details, mailer.Detail{
+			Label: locale.LabelPrevious,
+			Value: before.Format(locale.DateFormat) + " " + before.Format(locale.TimeFormat),
+		})
+	}
+	paragraphs := []string{fmt.Sprintf(text.Intro, appointment.ServiceDescription, businessName)}
+	if event != entity.EmailEventAppointmentCancelled {
+		paragraphs = append(paragraphs, locale.ContactHint)
+	}
+
+	subject := fmt.Sprintf(text.Subject, appointment.ServiceDescription, date)
+	htmlBody, textBody, err := mailer.Render(mailer.Content{
+		Lang: settings.Locale,
+		Branding: mailer.Branding{
+			BusinessName: businessName,
+			LogoURL:      settings.LogoURL,
+			PrimaryColor: settings.PrimaryColor,
+			FooterText:   settings.FooterText,
+		},
+		Preheader:  paragraphs[0],
+		Title:      text.Title,
+		Greeting:   fmt.Sprintf(locale.Greeting, appointment.ClientName),
+		Paragraphs: paragraphs,
+		Details:    details,
+		Closing:    text.Closing,
+		FooterNote: fmt.Sprintf(locale.FooterNote, businessName),
+	})
+	if err != nil {
+		return "", "", "", fmt.Errorf("falha ao renderizar e-mail: %w", err)
+	}
+	return subject, htmlBody, textBody, nil
+}
+
+// DispatchOutbox envia os e-mails pendentes da fila cujo horário de tentativa chegou.
+// Retorna quantos foram enviados e quantos falharam definitivamente.
+func (uc *EmailNotificationUseCase) DispatchOutbox(now time.Time) (int, int, error) {
+	due, err := uc.outboxRepo.FindDue(now, emailDispatchBatch)
+	if err != nil {
+		return 0, 0, errors.New("erro ao buscar e-mails pendentes: " + err.Error())
+	}
+
+	sent, failed := 0, 0
+	for _, email := range due {
+		if uc.deliver(email, now) {
+			sent++
+		} else if email.Status == entity.EmailStatusFailed {
+			failed++
+		}
+	}
+	return sent, failed, nil
+}
+
+// deliver reserva o e-mail, envia e registra o resultado. Falhas temporárias são
+// reagendadas com intervalo crescente até emailMaxAttempts. Retorna true se foi enviado.
+func (uc *EmailNotificationUseCase) deliver(email *entity.OutboundEmail, now time.Time) bool {
+	claimed, err := uc.outboxRepo.Claim(email.ID, now, now.Add(emailSendLease))
+	if err != nil {
+		log.Printf("Erro ao reservar e-mail %s para envio: %v", email.ID, err)
+		return false
+	}
+	if !claimed {
+		return false // Já enviado ou em envio por outra execução
+	}
+
+	message := mailer.Email{
+		FromName: email.FromName,
+		To:       email.Recipient,
+		ReplyTo:  email.ReplyTo,
+		Subject:  email.Subject,
+		HTML:     email.HTMLBody,
+		Text:     email.TextBody,
+	}
+	if email.ICSAttachment != "" {
+		message.Attachments = []mailer.Attachment{{
+			Filename:    "agendamento.ics",
+			ContentType: ical.ContentType,
+			Data:        []byte(email.ICSAttachment),
+		}}
+	}
+	messageID, err := uc.mailer.Send(message)
+
+	email.Attempts++
+	if err == nil {
+		sentAt := time.Now()
+		email.Status = entity.EmailStatusSent
+		email.SentAt = &sentAt
+		email.ProviderMessageID = messageID
+		email.LastError = ""
+	} else {
+		email.LastError = err.Error()
+		if mailer.IsPermanent(err) || email.Attempts >= emailMaxAttempts {
+			email.Status = entity.EmailStatusFailed
+		} else {
+			email.NextAttemptAt = now.Add(time.Duration(email.Attempts*email.Attempts) * emailRetryDelay)
+		}
+		log.Printf("Falha ao enviar e-mail %s (tentativa %d): %v", email.ID, email.Attempts, err)
+	}
+
+	if updateErr := uc.outboxRepo.Update(email); updateErr != nil {
+		log.Printf("Erro ao registrar envio do e-mail %s: %v", email.ID, updateErr)
+	}
+	return err == nil
+}
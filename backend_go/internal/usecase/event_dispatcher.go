@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrEventDeliveryStatus indica que a operação não é permitida no status atual da entrega.
+var ErrEventDeliveryStatus = errors.New("operação não permitida para o status da entrega")
+
+// errUnknownSubscriber indica que o assinante da entrega não está mais registrado.
+var errUnknownSubscriber = errors.New("assinante não registrado")
+
+const (
+	// eventMaxAttempts é o número máximo de tentativas de entrega de um evento a um
+	// assinante antes de a entrega ir para a dead letter (status DEAD).
+	eventMaxAttempts = 8
+	// eventRetryBaseDelay é o intervalo da primeira nova tentativa; dobra a cada falha
+	// até eventRetryMaxDelay.
+	eventRetryBaseDelay = 30 * time.Second
+	eventRetryMaxDelay  = time.Hour
+	// eventDeliveryLease é por quanto tempo uma entrega reservada fica fora da fila; se o
+	// processo cair durante a entrega, ela volta a ser tentada depois desse prazo.
+	eventDeliveryLease = 5 * time.Minute
+	// eventDispatchBatch é o número máximo de eventos e de entregas processados por execução.
+	eventDispatchBatch = 100
+	// defaultEventListLimit é o número de eventos retornados na listagem sem limite informado.
+	defaultEventListLimit = 50
+)
+
+// EventSubscriber recebe os eventos de domínio distribuídos pelo EventDispatcher. A entrega
+// é "pelo menos uma vez": HandleEvent pode receber o mesmo evento mais de uma vez e deve
+// ser idempotente. Um erro faz o evento ser entregue novamente mais tarde.
+type EventSubscriber interface {
+	// SubscriberName identifica o assinante nas entregas gravadas; não deve mudar entre versões.
+	SubscriberName() string
+	HandleEvent(event *entity.DomainEvent) error
+}
+
+type eventSubscription struct {
+	subscriber EventSubscriber
+	types      map[entity.DomainEventType]bool // Vazio: todos os tipos
+}
+
+func (s eventSubscription) accepts(eventType entity.DomainEventType) bool {
+	return len(s.types) == 0 || s.types[eventType]
+}
+
+// EventDispatcher distribui os eventos gravados na outbox aos assinantes. Cada evento gera
+// uma entrega por assinante interessado, tentada novamente com intervalo crescente em caso
+// de falha até eventMaxAttempts.
+type EventDispatcher struct {
+	eventRepo     repository.DomainEventRepository
+	deliveryRepo  repository.EventDeliveryRepository
+	mu            sync.RWMutex
+	subscriptions []eventSubscription
+	wake          chan struct{}
+}
+
+// NewEventDispatcher cria uma nova instância de EventDispatcher.
+func NewEventDispatcher(eventRepo repository.DomainEventRepository, deliveryRepo repository.EventDeliveryRepository) *EventDispatcher {
+	return &EventDispatcher{
+		eventRepo:    eventRepo,
+		deliveryRepo: deliveryRepo,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Subscribe registra um assinante para os tipos de evento informados (todos, se nenhum).
+func (d *EventDispatcher) Subscribe(subscriber EventSubscriber, types ...entity.DomainEventType) {
+	subscription := eventSubscription{subscriber: subscriber, types: make(map[entity.DomainEventType]bool)}
+	for _, t := range types {
+		subscription.types[t] = true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions = append(d.subscriptions, subscription)
+}
+
+// Wake antecipa a próxima execução do Run (ex: logo após uma transação com novos eventos).
+func (d *EventDispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default: // Já há uma execução pendente
+	}
+}
+
+// Run processa a outbox a cada interval ou quando Wake é chamado. Não retorna.
+func (d *EventDispatcher) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		delivered, dead, err := d.Dispatch(time.Now())
+		if err != nil {
+			log.Printf("Erro ao distribuir eventos de domínio: %v", err)
+		} else if delivered > 0 || dead > 0 {
+			log.Printf("Eventos de domínio: %d entrega(s) concluída(s), %d na dead letter", delivered, dead)
+		}
+		select {
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// Dispatch cria as entregas dos eventos pendentes e entrega as que estão vencidas.
+// Retorna o número de entregas concluídas e o de entregas que foram para a dead letter.
+func (d *EventDispatcher) Dispatch(now time.Time) (int, int, error) {
+	if err := d.fanOut(); err != nil {
+		return 0, 0, err
+	}
+
+	due, err := d.deliveryRepo.FindDue(now, eventDispatchBatch)
+	if err != nil {
+		return 0, 0, errors.New("erro ao buscar entregas pendentes: " + err.Error())
+	}
+	delivered, dead := 0, 0
+	for _, delivery := range due {
+		if d.deliver(delivery, now) {
+			delivered++
+		} else if delivery.Status == entity.EventDeliveryStatusDead {
+			dead++
+		}
+	}
+	return delivered, dead, nil
+}
+
+// fanOut cria, para cada evento pendente, uma entrega por assinante interessado.
+func (d *EventDispatcher) fanOut() error {
+	events, err := d.eventRepo.FindPending(eventDispatchBatch)
+	if err != nil {
+		return errors.New("erro ao buscar eventos pendentes: " + err.Error())
+	}
+
+	d.mu.RLock()
+	subscriptions := d.subscriptions
+	d.mu.RUnlock()
+
+	for _, event := range events {
+		var deliveries []*entity.EventDelivery
+		for _, s := range subscriptions {
+			if !s.accepts(event.Type) {
+				continue
+			}
+			deliveries = append(deliveries, &entity.EventDelivery{
+				ID:            uuid.New(),
+				EventID:       event.ID,
+				UserID:        event.UserID,
+				EventType:     event.Type,
+				Subscriber:    s.subscriber.SubscriberName(),
+				Status:        entity.EventDeliveryStatusPending,
+				NextAttemptAt: event.OccurredAt,
+			})
+		}
+		if _, err := d.eventRepo.MarkDispatched(event, deliveries); err != nil {
+			return fmt.Errorf("erro ao distribuir evento %s: %v", event.ID, err)
+		}
+	}
+	return nil
+}
+
+// deliver reserva a entrega, chama o assinante e registra o resultado. Retorna true se o
+// assinante processou o evento.
+func (d *EventDispatcher) deliver(delivery *entity.EventDelivery, now time.Time) bool {
+	claimed, err := d.deliveryRepo.Claim(delivery.ID, now, now.Add(eventDeliveryLease))
+	if err != nil {
+		log.Printf("Erro ao reservar entrega %s: %v", delivery.ID, err)
+		return false
+	}
+	if !claimed {
+		return false // Já entregue ou em entrega por outra execução
+	}
+
+	delivery.Attempts++
+	err = d.handle(delivery)
+	if err == nil {
+		deliveredAt := time.Now()
+		delivery.Status = entity.EventDeliveryStatusDelivered
+		delivery.DeliveredAt = &deliveredAt
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = err.Error()
+		if errors.Is(err, errUnknownSubscriber) || delivery.Attempts >= eventMaxAttempts {
+			delivery.Status = entity.EventDeliveryStatusDead
+		} else {
+			delivery.NextAttemptAt = now.Add(exponentialDelay(eventRetryBaseDelay, eventRetryMaxDelay, delivery.Attempts))
+		}
+		log.Printf("Falha ao entregar evento %s a %s (tentativa %d): %v", delivery.EventID, delivery.Subscriber, delivery.Attempts, err)
+	}
+
+	if updateErr := d.deliveryRepo.Update(delivery); updateErr != nil {
+		log.Printf("Erro ao registrar entrega %s: %v", delivery.ID, updateErr)
+	}
+	return err == nil
+}
+
+// handle busca o evento e o entrega ao assinante, convertendo um panic em erro para que
+// um assinante com defeito não derrube o distribuidor.
+func (d *EventDispatcher) handle(delivery *entity.EventDelivery) (err error) {
+	subscriber := d.findSubscriber(delivery.Subscriber)
+	if subscriber == nil {
+		return fmt.Errorf("%w: %s", errUnknownSubscriber, delivery.Subscriber)
+	}
+	event, err := d.eventRepo.FindByID(delivery.EventID)
+	if err != nil {
+		return errors.New("erro ao buscar evento: " + err.Error())
+	}
+	if event == nil {
+		return errors.New("evento não encontrado")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic no assinante: %v", r)
+		}
+	}()
+	return subscriber.HandleEvent(event)
+}
+
+func (d *EventDispatcher) findSubscriber(name string) EventSubscriber {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, s := range d.subscriptions {
+		if s.subscriber.SubscriberName() == name {
+			return s.subscriber
+		}
+	}
+	return nil
+}
+
+// exponentialDelay calcula o intervalo até a próxima tentativa após attempts falhas:
+// base dobrando a cada falha, limitado a max.
+func exponentialDelay(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// ListEvents lista os eventos do usuário, dos mais recentes para os mais antigos.
+// limit zero usa defaultEventListLimit.
+func (d *EventDispatcher) ListEvents(userID uuid.UUID, eventType entity.DomainEventType, limit int) ([]*entity.DomainEvent, error) {
+	if limit <= 0 {
+		limit = defaultEventListLimit
+	}
+	return d.eventRepo.FindByUserID(userID, eventType, limit)
+}
+
+// ListDeliveries lista as entregas de eventos do usuário.
+func (d *EventDispatcher) ListDeliveries(userID uuid.UUID, status entity.EventDeliveryStatus) ([]*entity.EventDelivery, error) {
+	return d.deliveryRepo.FindByUserID(userID, status)
+}
+
+// RetryDelivery recoloca na fila uma entrega que foi para a dead letter, zerando as tentativas.
+func (d *EventDispatcher) RetryDelivery(deliveryID, requestingUserID uuid.UUID) (*entity.EventDelivery, error) {
+	delivery, err := d.deliveryRepo.FindByID(deliveryID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar entrega: " + err.Error())
+	}
+	if delivery == nil || delivery.UserID != requestingUserID {
+		return nil, errors.New("entrega não encontrada")
+	}
+	if delivery.Status != entity.EventDeliveryStatusDead {
+		return nil, fmt.Errorf("%w: apenas entregas na dead letter podem ser reenviadas", ErrEventDeliveryStatus)
+	}
+
+	delivery.Status = entity.EventDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := d.deliveryRepo.Update(delivery); err != nil {
+		return nil, errors.New("falha ao reenfileirar entrega: " + err.Error())
+	}
+	d.Wake()
+	return delivery, nil
+}
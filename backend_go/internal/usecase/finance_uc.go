@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// FinanceUseCase encapsula a lógica do livro-caixa: lançamentos, categorias de despesa,
+// despesas recorrentes, orçamentos mensais e previsão de receitas.
+type FinanceUseCase struct {
+	entryRepo     repository.FinancialEntryRepository
+	categoryRepo  repository.ExpenseCategoryRepository
+	recurringRepo repository.RecurringExpenseRepository
+	alertRepo     repository.BudgetAlertRepository
+	forecastRepo  repository.IncomeForecastRepository
+	uow           repository.UnitOfWork
+}
+
+// NewFinanceUseCase cria uma nova instância de FinanceUseCase.
+func NewFinanceUseCase(
+	entryRepo repository.FinancialEntryRepository,
+	categoryRepo repository.ExpenseCategoryRepository,
+	recurringRepo repository.RecurringExpenseRepository,
+	alertRepo repository.BudgetAlertRepository,
+	forecastRepo repository.IncomeForecastRepository,
+	uow repository.UnitOfWork,
+) *FinanceUseCase {
+	return &FinanceUseCase{
+		entryRepo:     entryRepo,
+		categoryRepo:  categoryRepo,
+		recurringRepo: recurringRepo,
+		alertRepo:     alertRepo,
+		forecastRepo:  forecastRepo,
+		uow:           uow,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Lançamentos
+// -----------------------------------------------------------------------------
+
+// CreateFinancialEntryInputDTO define os dados para criar um lançamento manual.
+type CreateFinancialEntryInputDTO struct {
+	UserID        uuid.UUID
+	Type          entity.FinancialEntryType
+	Amount        float64
+	Description   string
+	Date          time.Time // Se zero, usa a data atual
+	CategoryID    *uuid.UUID
+	AppointmentID *uuid.UUID
+	RevenueKind   entity.RevenueKind // Apenas para entradas; se vazio, usa SERVICES
+	Invoiced      bool
+}
+
+// CreateEntry cria um lançamento e, se for uma despesa categorizada, verifica o orçamento do mês.
+func (uc *FinanceUseCase) CreateEntry(input CreateFinancialEntryInputDTO) (*entity.FinancialEntry, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if input.Type != entity.FinancialEntryTypeIncome && input.Type != entity.FinancialEntryTypeExpense {
+		return nil, errors.New("tipo de lançamento inválido: " + string(input.Type))
+	}
+	if input.Amount <= 0 {
+		return nil, errors.New("valor do lançamento deve ser maior que zero")
+	}
+	if err := uc.validateCategory(input.UserID, input.Type, input.CategoryID); err != nil {
+		return nil, err
+	}
+	revenueKind, err := validateRevenueKind(input.Type, input.RevenueKind)
+	if err != nil {
+		return nil, err
+	}
+
+	date := input.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	entry := &entity.FinancialEntry{
+		ID:            uuid.New(),
+		UserID:        input.UserID,
+		Type:          input.Type,
+		Amount:        input.Amount,
+		Description:   input.Description,
+		Date:          date,
+		CategoryID:    input.CategoryID,
+		AppointmentID: input.AppointmentID,
+		RevenueKind:   revenueKind,
+		Invoiced:      input.Invoiced,
+	}
+	if err := uc.entryRepo.Create(entry); err != nil {
+		return nil, errors.New("falha ao salvar lançamento: " + err.Error())
+	}
+
+	uc.checkBudget(entry)
+	return entry, nil
+}
+
+// GetEntryByID busca um lançamento verificando se pertence ao usuário.
+func (uc *FinanceUseCase) GetEntryByID(entryID, requestingUserID uuid.UUID) (*entity.FinancialEntry, error) {
+	entry, err := uc.entryRepo.FindByID(entryID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar lançamento: " + err.Error())
+	}
+	if entry == nil || entry.UserID != requestingUserID {
+		return nil, errors.New("lançamento não encontrado")
+	}
+	return entry, nil
+}
+
+// ListEntries lista os lançamentos do usuário com filtros opcionais.
+func (uc *FinanceUseCase) ListEntries(userID uuid.UUID, filter repository.FinancialEntryFilter) ([]*entity.FinancialEntry, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório para listar lançamentos")
+	}
+	return uc.entryRepo.FindByUserID(userID, filter)
+}
+
+// UpdateFinancialEntryInputDTO define os dados para atualizar um lançamento.
+type UpdateFinancialEntryInputDTO struct {
+	Amount        *float64
+	Description   *string
+	Date          *time.Time
+	CategoryID    *uuid.UUID
+	ClearCategory bool // Remove a categoria do lançamento
+	RevenueKind   *entity.RevenueKind
+	Invoiced      *bool
+}
+
+// UpdateEntry atualiza um lançamento existente.
+func (uc *FinanceUseCase) UpdateEntry(entryID, requestingUserID uuid.UUID, input UpdateFinancialEntryInputDTO) (*entity.FinancialEntry, error) {
+	entry, err := uc.GetEntryByID(entryID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Amount != nil {
+		if *input.Amount <= 0 {
+			return nil, errors.New("valor do lançamento deve ser maior que zero")
+		}
+		entry.Amount = *input.Amount
+	}
+	if input.Description != nil {
+		entry.Description = *input.Description
+	}
+	if input.Date != nil {
+		entry.Date = *input.Date
+	}
+	if input.ClearCategory {
+		entry.CategoryID = nil
+	} else if input.CategoryID != nil {
+		if err := uc.validateCategory(requestingUserID, entry.Type, input.CategoryID); err != nil {
+			return nil, err
+		}
+		entry.CategoryID = input.CategoryID
+	}
+	if input.RevenueKind != nil {
+		revenueKind, err := validateRevenueKind(entry.Type, *input.RevenueKind)
+		if err != nil {
+			return nil, err
+		}
+		entry.RevenueKind = revenueKind
+	}
+	if input.Invoiced != nil {
+		entry.Invoiced = *input.Invoiced
+	}
+
+	if err := uc.entryRepo.Update(entry); err != nil {
+		return nil, errors.New("falha ao atualizar lançamento: " + err.Error())
+	}
+
+	uc.checkBudget(entry)
+	return entry, nil
+}
+
+// DeleteEntry exclui um lançamento.
+func (uc *FinanceUseCase) DeleteEntry(entryID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetEntryByID(entryID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.entryRepo.Delete(entryID)
+}
+
+// validateRevenueKind valida a natureza da receita de uma entrada, usando serviços
+// como padrão. Saídas não têm natureza de receita.
+func validateRevenueKind(entryType entity.FinancialEntryType, kind entity.RevenueKind) (entity.RevenueKind, error) {
+	if entryType != entity.FinancialEntryTypeIncome {
+		if kind != "" {
+			return "", errors.New("natureza da receita só pode ser usada em entradas")
+		}
+		return "", nil
+	}
+	switch kind {
+	case "":
+		return entity.RevenueKindServices, nil
+	case entity.RevenueKindServices, entity.RevenueKindCommerceIndustry:
+		return kind, nil
+	}
+	return "", errors.New("natureza da receita inválida: " + string(kind))
+}
+
+// validateCategory garante que a categoria existe, pertence ao usuário e só é usada em despesas.
+func (uc *FinanceUseCase) validateCategory(userID uuid.UUID, entryType entity.FinancialEntryType, categoryID *uuid.UUID) error {
+	if categoryID == nil {
+		return nil
+	}
+	if entryType != entity.FinancialEntryTypeExpense {
+		return errors.New("categorias de despesa só podem ser usadas em saídas")
+	}
+	category, err := uc.categoryRepo.FindByID(*categoryID)
+	if err != nil {
+		return errors.New("erro ao buscar categoria: " + err.Error())
+	}
+	if category == nil || category.UserID != userID {
+		return errors.New("categoria não encontrada")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Categorias de despesa
+// -----------------------------------------------------------------------------
+
+// CreateCategory cria uma categoria de despesa para o usuário.
+func (uc *FinanceUseCase) CreateCategory(userID uuid.UUID, name string, monthlyBudget float64) (*entity.ExpenseCategory, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if name == "" {
+		return nil, errors.New("nome da categoria é obrigatório")
+	}
+	if monthlyBudget < 0 {
+		return nil, errors.New("orçamento mensal não pode ser negativo")
+	}
+
+	category := &entity.ExpenseCategory{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Name:          name,
+		MonthlyBudget: monthlyBudget,
+	}
+	if err := uc.categoryRepo.Create(category); err != nil {
+		return nil, errors.New("falha ao salvar categoria: " + err.Error())
+	}
+	return category, nil
+}
+
+// GetCategoryByID busca uma categoria verificando se pertence ao usuário.
+func (uc *FinanceUseCase) GetCategoryByID(categoryID, requestingUserID uuid.UUID) (*entity.ExpenseCategory, error) {
+	category, err := uc.categoryRepo.FindByID(categoryID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar categoria: " + err.Error())
+	}
+	if category == nil || category.UserID != requestingUserID {
+		return nil, errors.New("categoria não encontrada")
+	}
+	return category, nil
+}
+
+// ListCategories lista as categorias de despesa do usuário.
+func (uc *FinanceUseCase) ListCategories(userID uuid.UUID) ([]*entity.ExpenseCategory, error) {
+	return uc.categoryRepo.FindByUserID(userID)
+}
+
+// UpdateCategory atualiza o nome e/ou o orçamento mensal de uma categoria.
+func (uc *FinanceUseCase) UpdateCategory(categoryID, requestingUserID uuid.UUID, name *string, monthlyBudget *float64) (*entity.ExpenseCategory, error) {
+	category, err := uc.GetCategoryByID(categoryID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if name != nil {
+		if *name == "" {
+			return nil, errors.New("nome da categoria é obrigatório")
+		}
+		category.Name = *name
+	}
+	if monthlyBudget != nil {
+		if *monthlyBudget < 0 {
+			return nil, errors.New("orçamento mensal não pode ser negativo")
+		}
+		category.MonthlyBudget = *monthlyBudget
+	}
+
+	if err := uc.categoryRepo.Update(category); err != nil {
+		return nil, errors.New("falha ao atualizar categoria: " + err.Error())
+	}
+	return category, nil
+}
+
+// DeleteCategory exclui uma categoria de despesa.
+func (uc *FinanceUseCase) DeleteCategory(categoryID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetCategoryByID(categoryID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.categoryRepo.Delete(categoryID)
+}
+
+// -----------------------------------------------------------------------------
+// Orçamentos
+// -----------------------------------------------------------------------------
+
+// BudgetStatus resume o consumo do orçamento de uma categoria em um mês.
+type BudgetStatus struct {
+	Category  *entity.ExpenseCategory
+	Month     string
+	Budget    float64
+	Spent     float64
+	Remaining float64
+	Exceeded  bool
+}
+
+// GetBudgetStatus retorna o consumo de cada categoria com orçamento no mês informado.
+func (uc *FinanceUseCase) GetBudgetStatus(userID uuid.UUID, month time.Time) ([]BudgetStatus, error) {
+	categories, err := uc.categoryRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar categorias: " + err.Error())
+	}
+
+	from, to := monthRange(month)
+	totals, err := uc.entryRepo.SumExpensesByCategory(userID, from, to)
+	if err != nil {
+		return nil, errors.New("erro ao calcular despesas do mês: " + err.Error())
+	}
+
+	statuses := make([]BudgetStatus, 0, len(categories))
+	for _, category := range categories {
+		if category.MonthlyBudget <= 0 {
+			continue
+		}
+		spent := totals[category.ID]
+		statuses = append(statuses, BudgetStatus{
+			Category:  category,
+			Month:     from.Format("2006-01"),
+			Budget:    category.MonthlyBudget,
+			Spent:     spent,
+			Remaining: category.MonthlyBudget - spent,
+			Exceeded:  spent > category.MonthlyBudget,
+		})
+	}
+	return statuses, nil
+}
+
+// ListBudgetAlerts lista os alertas de orçamento excedido do usuário, do mais recente ao mais antigo.
+func (uc *FinanceUseCase) ListBudgetAlerts(userID uuid.UUID) ([]*entity.BudgetAlert, error) {
+	return uc.alertRepo.FindByUserID(userID)
+}
+
+// checkBudget gera um alerta na primeira vez em que as despesas da categoria
+// ultrapassam o orçamento no mês do lançamento. Falhas são apenas registradas em log
+// para não impedir o lançamento em si.
+func (uc *FinanceUseCase) checkBudget(entry *entity.FinancialEntry) {
+	if entry.Type != entity.FinancialEntryTypeExpense || entry.CategoryID == nil {
+		return
+	}
+
+	category, err := uc.categoryRepo.FindByID(*entry.CategoryID)
+	if err != nil || category == nil || category.MonthlyBudget <= 0 {
+		return
+	}
+
+	from, to := monthRange(entry.Date)
+	month := from.Format("2006-01")
+
+	existing, err := uc.alertRepo.FindByCategoryAndMonth(category.ID, month)
+	if err != nil {
+		log.Printf("Erro ao verificar alerta de orçamento da categoria %s: %v", category.ID, err)
+		return
+	}
+	if existing != nil {
+		return
+	}
+
+	totals, err := uc.entryRepo.SumExpensesByCategory(entry.UserID, from, to)
+	if err != nil {
+		log.Printf("Erro ao calcular despesas da categoria %s: %v", category.ID, err)
+		return
+	}
+	spent := totals[category.ID]
+	if spent <= category.MonthlyBudget {
+		return
+	}
+
+	alert := &entity.BudgetAlert{
+		ID:         uuid.New(),
+		UserID:     entry.UserID,
+		CategoryID: category.ID,
+		Month:      month,
+		Budget:     category.MonthlyBudget,
+		Spent:      spent,
+	}
+	if err := uc.alertRepo.Create(alert); err != nil {
+		log.Printf("Falha ao salvar alerta de orçamento da categoria %s: %v", category.ID, err)
+		return
+	}
+	log.Printf("Orçamento da categoria '%s' excedido em %s: gasto %.2f de %.2f", category.Name, month, spent, category.MonthlyBudget)
+}
+
+// monthRange retorna o primeiro instante do mês de t e o primeiro instante do mês seguinte.
+func monthRange(t time.Time) (time.Time, time.Time) {
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return from, from.AddDate(0, 1, 0)
+}
+
+// -----------------------------------------------------------------------------
+// Previsão de receitas
+// -----------------------------------------------------------------------------
+
+// IncomeForecastSummary agrupa as receitas previstas de um período.
+type IncomeForecastSummary struct {
+	From      time.Time
+	To        time.Time
+	Total     float64
+	Forecasts []*entity.IncomeForecast
+}
+
+// GetIncomeForecast retorna as receitas previstas no período [from, to) que ainda
+// não foram realizadas nem canceladas.
+func (uc *FinanceUseCase) GetIncomeForecast(userID uuid.UUID, from, to time.Time) (*IncomeForecastSummary, error) {
+	if !to.After(from) {
+		return nil, errors.New("data final deve ser após a data inicial")
+	}
+	forecasts, err := uc.forecastRepo.FindOpenByUserIDAndPeriod(userID, from, to)
+	if err != nil {
+		return nil, errors.New("erro ao buscar previsão de receitas: " + err.Error())
+	}
+
+	summary := &IncomeForecastSummary{From: from, To: to, Forecasts: forecasts}
+	for _, f := range forecasts {
+		summary.Total += f.Amount
+	}
+	summary.Total = math.Round(summary.Total*100) / 100
+	return summary, nil
+}
@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidGiftCard indica que o vale-presente não pode ser usado no pagamento.
+var ErrInvalidGiftCard = errors.New("vale-presente inválido")
+
+// errGiftCardChanged desfaz o cancelamento quando o vale foi cancelado ou movimentado
+// por outra operação entre a leitura e a gravação.
+var errGiftCardChanged = errors.New("vale-presente alterado por outra operação")
+
+// giftCardCodeAlphabet omite caracteres fáceis de confundir (0/O, 1/I/L).
+const giftCardCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// GiftCardUseCase encapsula a emissão de vales-presente e o controle do saldo.
+type GiftCardUseCase struct {
+	giftCardRepo    repository.GiftCardRepository
+	transactionRepo repository.GiftCardTransactionRepository
+	uow             repository.UnitOfWork
+}
+
+// NewGiftCardUseCase cria uma nova instância de GiftCardUseCase.
+func NewGiftCardUseCase(
+	giftCardRepo repository.GiftCardRepository,
+	transactionRepo repository.GiftCardTransactionRepository,
+	uow repository.UnitOfWork,
+) *GiftCardUseCase {
+	return &GiftCardUseCase{
+		giftCardRepo:    giftCardRepo,
+		transactionRepo: transactionRepo,
+		uow:             uow,
+	}
+}
+
+// IssueGiftCardInputDTO define os dados para emitir um vale-presente.
+type IssueGiftCardInputDTO struct {
+	UserID         uuid.UUID
+	Amount         float64
+	PurchaserName  string
+	RecipientName  string
+	RecipientEmail string
+	Message        string
+	ExpiresAt      *time.Time
+}
+
+// IssueGiftCard emite um vale-presente com código único e lança a venda no livro-caixa.
+// A venda entra no caixa, mas só conta como faturamento quando o vale é usado em um atendimento.
+// O vale, a movimentação de emissão e o lançamento são gravados na mesma transação.
+func (uc *GiftCardUseCase) IssueGiftCard(input IssueGiftCardInputDTO) (*entity.GiftCard, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if input.Amount <= 0 {
+		return nil, errors.New("valor do vale-presente deve ser maior que zero")
+	}
+	now := time.Now()
+	if input.ExpiresAt != nil && input.ExpiresAt.Before(now) {
+		return nil, errors.New("validade do vale-presente deve ser uma data futura")
+	}
+
+	code, err := uc.generateUniqueCode(input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := math.Round(input.Amount*100) / 100
+	giftCard := &entity.GiftCard{
+		ID:             uuid.New(),
+		UserID:         input.UserID,
+		Code:           code,
+		InitialAmount:  amount,
+		Balance:        amount,
+		PurchaserName:  input.PurchaserName,
+		RecipientName:  input.RecipientName,
+		RecipientEmail: input.RecipientEmail,
+		Message:        input.Message,
+		Status:         entity.GiftCardStatusActive,
+		IssuedAt:       now,
+		ExpiresAt:      input.ExpiresAt,
+	}
+	entry := &entity.FinancialEntry{
+		ID:          uuid.New(),
+		UserID:      giftCard.UserID,
+		Type:        entity.FinancialEntryTypeIncome,
+		Amount:      amount,
+		Description: "Venda de vale-presente " + giftCard.Code,
+		Date:        now,
+		GiftCardID:  &giftCard.ID,
+		RevenueKind: entity.RevenueKindServices,
+	}
+	err = uc.uow.Do(func(tx repository.Transaction) error {
+		if err := tx.GiftCards().Create(giftCard); err != nil {
+			return err
+		}
+		issue := newGiftCardTransaction(giftCard, entity.GiftCardTransactionTypeIssue, amount, nil, "Emissão do vale-presente")
+		if err := tx.GiftCardTransactions().Create(issue); err != nil {
+			return err
+		}
+		return tx.FinancialEntries().Create(entry)
+	})
+	if err != nil {
+		return nil, errors.New("falha ao salvar vale-presente: " + err.Error())
+	}
+	return giftCard, nil
+}
+
+// GetGiftCardByID busca um vale-presente verificando se pertence ao usuário.
+func (uc *GiftCardUseCase) GetGiftCardByID(giftCardID, requestingUserID uuid.UUID) (*entity.GiftCard, error) {
+	giftCard, err := uc.giftCardRepo.FindByID(giftCardID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar vale-presente: " + err.Error())
+	}
+	if giftCard == nil || giftCard.UserID != requestingUserID {
+		return nil, errors.New("vale-presente não encontrado")
+	}
+	return giftCard, nil
+}
+
+// GetGiftCardByCode busca um vale-presente do usuário pelo código (ex: para consultar o saldo).
+func (uc *GiftCardUseCase) GetGiftCardByCode(code string, requestingUserID uuid.UUID) (*entity.GiftCard, error) {
+	giftCard, err := uc.giftCardRepo.FindByCode(requestingUserID, normalizeGiftCardCode(code))
+	if err != nil {
+		return nil, errors.New("erro ao buscar vale-presente: " + err.Error())
+	}
+	if giftCard == nil {
+		return nil, errors.New("vale-presente não encontrado")
+	}
+	return giftCard, nil
+}
+
+// ListGiftCards lista os vales-presente emitidos pelo usuário.
+func (uc *GiftCardUseCase) ListGiftCards(userID uuid.UUID) ([]*entity.GiftCard, error) {
+	return uc.giftCardRepo.FindByUserID(userID)
+}
+
+// ListTransactions lista as movimentações de um vale-presente em ordem cronológica.
+func (uc *GiftCardUseCase) ListTransactions(giftCardID, requestingUserID uuid.UUID) ([]*entity.GiftCardTransaction, error) {
+	if _, err := uc.GetGiftCardByID(giftCardID, requestingUserID); err != nil {
+		return nil, err
+	}
+	return uc.transactionRepo.FindByGiftCardID(giftCardID)
+}
+
+// CancelGiftCard cancela um vale-presente e zera o saldo restante.
+// A devolução do valor ao comprador, se houver, deve ser lançada à parte no livro-caixa.
+// O cancelamento só é gravado se o saldo lido não tiver sido movimentado nesse meio-tempo.
+func (uc *GiftCardUseCase) CancelGiftCard(giftCardID, requestingUserID uuid.UUID) (*entity.GiftCard, error) {
+	giftCard, err := uc.GetGiftCardByID(giftCardID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if giftCard.Status == entity.GiftCardStatusCancelled {
+		return nil, errors.New("vale-presente já está cancelado")
+	}
+
+	remaining := giftCard.Balance
+	giftCard.Status = entity.GiftCardStatusCancelled
+	giftCard.Balance = 0
+	err = uc.uow.Do(func(tx repository.Transaction) error {
+		cancelled, err := tx.GiftCards().Cancel(giftCard.ID, remaining)
+		if err != nil {
+			return err
+		}
+		if !cancelled {
+			return errGiftCardChanged
+		}
+		cancel := newGiftCardTransaction(giftCard, entity.GiftCardTransactionTypeCancel, -remaining, nil, "Cancelamento do vale-presente")
+		return tx.GiftCardTransactions().Create(cancel)
+	})
+	if errors.Is(err, errGiftCardChanged) {
+		return nil, errors.New("vale-presente foi cancelado ou usado em outra operação; consulte o saldo e tente novamente")
+	}
+	if err != nil {
+		return nil, errors.New("falha ao cancelar vale-presente: " + err.Error())
+	}
+	return giftCard, nil
+}
+
+// RedeemGiftCard debita o valor de um pagamento do saldo do vale-presente e registra a
+// movimentação na transação que grava o pagamento; se ela for desfeita, o débito também é.
+// Erros de validação envolvem ErrInvalidGiftCard.
+func (uc *GiftCardUseCase) RedeemGiftCard(tx repository.Transaction, userID uuid.UUID, code string, amount float64, paymentID uuid.UUID) (*entity.GiftCard, error) {
+	giftCard, err := tx.GiftCards().FindByCode(userID, normalizeGiftCardCode(code))
+	if err != nil {
+		return nil, errors.New("erro ao buscar vale-presente: " + err.Error())
+	}
+	if giftCard == nil {
+		return nil, fmt.Errorf("%w: código não encontrado", ErrInvalidGiftCard)
+	}
+	if giftCard.Status != entity.GiftCardStatusActive {
+		return nil, fmt.Errorf("%w: vale cancelado", ErrInvalidGiftCard)
+	}
+	if giftCard.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("%w: vale vencido", ErrInvalidGiftCard)
+	}
+
+	debited, err := tx.GiftCards().Debit(giftCard.ID, amount)
+	if err != nil {
+		return nil, errors.New("falha ao debitar vale-presente: " + err.Error())
+	}
+	if !debited {
+		return nil, fmt.Errorf("%w: saldo insuficiente (saldo: R$ %s)", ErrInvalidGiftCard, formatBRL(giftCard.Balance))
+	}
+
+	giftCard.Balance = math.Round((giftCard.Balance-amount)*100) / 100
+	redeem := newGiftCardTransaction(giftCard, entity.GiftCardTransactionTypeRedeem, -amount, &paymentID, "Pagamento de atendimento")
+	if err := tx.GiftCardTransactions().Create(redeem); err != nil {
+		return nil, errors.New("falha ao registrar movimentação do vale-presente: " + err.Error())
+	}
+	return giftCard, nil
+}
+
+// newGiftCardTransaction monta a movimentação com o saldo atual do vale.
+func newGiftCardTransaction(giftCard *entity.GiftCard, transactionType entity.GiftCardTransactionType,
+	amount float64, paymentID *uuid.UUID, description string) *entity.GiftCardTransaction {
+	return &entity.GiftCardTransaction{
+		ID:           uuid.New(),
+		GiftCardID:   giftCard.ID,
+		Type:         transactionType,
+		Amount:       amount,
+		BalanceAfter: giftCard.Balance,
+		PaymentID:    paymentID,
+		Description:  description,
+	}
+}
+
+// generateUniqueCode gera um código no formato VP-XXXX-XXXX que ainda não existe para o usuário.
+func (uc *GiftCardUseCase) generateUniqueCode(userID uuid.UUID) (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := randomGiftCardCode()
+		if err != nil {
+			return "", errors.New("falha ao gerar código do vale-presente: " + err.Error())
+		}
+		existing, err := uc.giftCardRepo.FindByCode(userID, code)
+		if err != nil {
+			return "", errors.New("erro ao verificar código do vale-presente: " + err.Error())
+		}
+		if existing == nil {
+			return code, nil
+		}
+	}
+	return "", errors.New("não foi possível gerar um código único para o vale-presente")
+}
+
+// randomGiftCardCode sorteia um código com 8 caracteres do alfabeto sem ambiguidades.
+func randomGiftCardCode() (string, error) {
+	var b strings.Builder
+	b.WriteString("VP-")
+	max := big.NewInt(int64(len(giftCardCodeAlphabet)))
+	for i := 0; i < 8; i++ {
+		if i == 4 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(giftCardCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// normalizeGiftCardCode remove espaços e converte o código para maiúsculas.
+func normalizeGiftCardCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
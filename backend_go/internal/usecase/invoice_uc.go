@@ -0,0 +1,403 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/nfse"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidInvoice indica que os dados fiscais ou da nota são inválidos.
+var ErrInvalidInvoice = errors.New("dados da nota fiscal inválidos")
+
+// ErrInvoiceStatus indica que a operação não é permitida no status atual da nota.
+var ErrInvoiceStatus = errors.New("operação não permitida para o status da nota")
+
+// InvoiceUseCase encapsula a emissão de NFS-e a partir de atendimentos concluídos,
+// usando o provedor configurado (prefeitura ou agregador).
+type InvoiceUseCase struct {
+	invoiceRepo     repository.InvoiceRepository
+	settingsRepo    repository.InvoiceSettingsRepository
+	appointmentRepo repository.AppointmentRepository
+	taxProfileRepo  repository.TaxProfileRepository
+	providers       *nfse.Registry
+}
+
+// NewInvoiceUseCase cria uma nova instância de InvoiceUseCase.
+func NewInvoiceUseCase(
+	invoiceRepo repository.InvoiceRepository,
+	settingsRepo repository.InvoiceSettingsRepository,
+	appointmentRepo repository.AppointmentRepository,
+	taxProfileRepo repository.TaxProfileRepository,
+	providers *nfse.Registry,
+) *InvoiceUseCase {
+	return &InvoiceUseCase{
+		invoiceRepo:     invoiceRepo,
+		settingsRepo:    settingsRepo,
+		appointmentRepo: appointmentRepo,
+		taxProfileRepo:  taxProfileRepo,
+		providers:       providers,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Dados fiscais
+// -----------------------------------------------------------------------------
+
+// GetSettings retorna os dados fiscais do usuário.
+func (uc *InvoiceUseCase) GetSettings(userID uuid.UUID) (*entity.InvoiceSettings, error) {
+	settings, err := uc.settingsRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar dados fiscais: " + err.Error())
+	}
+	if settings == nil {
+		return nil, errors.New("dados fiscais não configurados")
+	}
+	return settings, nil
+}
+
+// SaveInvoiceSettingsInputDTO define os dados fiscais do prestador.
+type SaveInvoiceSettingsInputDTO struct {
+	UserID                uuid.UUID
+	Document              string
+	MunicipalRegistration string
+	MunicipalityCode      string
+	ServiceItemCode       string
+	ISSRate               float64
+	RPSSeries             string // Se vazio, usa "1"
+	NextRPSNumber         int    // Opcional: continua a numeração de outro sistema; nunca retrocede
+	AutoIssue             bool
+}
+
+// SaveSettings cria ou atualiza os dados fiscais do usuário.
+func (uc *InvoiceUseCase) SaveSettings(input SaveInvoiceSettingsInputDTO) (*entity.InvoiceSettings, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	document := nfse.OnlyDigits(input.Document)
+	if len(document) != 11 && len(document) != 14 {
+		return nil, fmt.Errorf("%w: CPF/CNPJ do prestador deve ter 11 ou 14 dígitos", ErrInvalidInvoice)
+	}
+	municipalityCode := nfse.OnlyDigits(input.MunicipalityCode)
+	if len(municipalityCode) != 7 {
+		return nil, fmt.Errorf("%w: código IBGE do município deve ter 7 dígitos", ErrInvalidInvoice)
+	}
+	if strings.TrimSpace(input.ServiceItemCode) == "" {
+		return nil, fmt.Errorf("%w: item da lista de serviços é obrigatório", ErrInvalidInvoice)
+	}
+	if input.ISSRate < 0 || input.ISSRate > 5 {
+		return nil, fmt.Errorf("%w: alíquota do ISS deve estar entre 0%% e 5%%", ErrInvalidInvoice)
+	}
+	series := strings.TrimSpace(input.RPSSeries)
+	if series == "" {
+		series = "1"
+	}
+
+	settings := &entity.InvoiceSettings{
+		UserID:                input.UserID,
+		Document:              document,
+		MunicipalRegistration: strings.TrimSpace(input.MunicipalRegistration),
+		MunicipalityCode:      municipalityCode,
+		ServiceItemCode:       strings.TrimSpace(input.ServiceItemCode),
+		ISSRate:               input.ISSRate,
+		RPSSeries:             series,
+		NextRPSNumber:         input.NextRPSNumber,
+		AutoIssue:             input.AutoIssue,
+	}
+	if err := uc.settingsRepo.Save(settings); err != nil {
+		return nil, errors.New("falha ao salvar dados fiscais: " + err.Error())
+	}
+	return settings, nil
+}
+
+// -----------------------------------------------------------------------------
+// Notas
+// -----------------------------------------------------------------------------
+
+// IssueInvoiceInputDTO define os dados para emitir a NFS-e de um atendimento.
+type IssueInvoiceInputDTO struct {
+	UserID        uuid.UUID
+	AppointmentID uuid.UUID
+	TakerDocument string // Opcional: CPF/CNPJ do tomador
+	Description   string // Se vazio, usa a descrição do serviço do agendamento
+}
+
+// IssueInvoice emite a NFS-e de um atendimento concluído. Se o provedor recusar a nota,
+// ela é salva com status ERROR e pode ser reenviada com RetryInvoice.
+func (uc *InvoiceUseCase) IssueInvoice(input IssueInvoiceInputDTO) (*entity.Invoice, error) {
+	appointment, err := uc.appointmentRepo.FindByID(input.AppointmentID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar agendamento: " + err.Error())
+	}
+	if appointment == nil || appointment.UserID != input.UserID {
+		return nil, errors.New("agendamento não encontrado")
+	}
+	return uc.issueForAppointment(appointment, input.TakerDocument, input.Description)
+}
+
+// OnAppointmentCompleted emite a nota automaticamente se o usuário habilitou a emissão
+// automática. Atendimentos sem valor (ex: cobertos por pacote) não geram nota.
+func (uc *InvoiceUseCase) OnAppointmentCompleted(appointment *entity.Appointment) {
+	if appointment.Price <= 0 {
+		return
+	}
+	settings, err := uc.settingsRepo.FindByUserID(appointment.UserID)
+	if err != nil {
+		log.Printf("Falha ao buscar dados fiscais do usuário %s: %v", appointment.UserID, err)
+		return
+	}
+	if settings == nil || !settings.AutoIssue {
+		return
+	}
+	invoice, err := uc.issueForAppointment(appointment, "", "")
+	if err != nil {
+		log.Printf("Falha ao emitir NFS-e do agendamento %s: %v", appointment.ID, err)
+		return
+	}
+	if invoice.Status == entity.InvoiceStatusError {
+		log.Printf("NFS-e do agendamento %s recusada: %s", appointment.ID, invoice.ErrorMessage)
+	}
+}
+
+// issueForAppointment reserva um número de RPS, cria a nota e a envia ao provedor padrão.
+func (uc *InvoiceUseCase) issueForAppointment(appointment *entity.Appointment, takerDocument, description string) (*entity.Invoice, error) {
+	if appointment.Status != entity.AppointmentStatusCompleted {
+		return nil, fmt.Errorf("%w: apenas atendimentos concluídos podem ter nota emitida", ErrInvoiceStatus)
+	}
+	if appointment.Price <= 0 {
+		return nil, fmt.Errorf("%w: atendimento sem valor a faturar", ErrInvalidInvoice)
+	}
+	takerDocument = nfse.OnlyDigits(takerDocument)
+	if takerDocument != "" && len(takerDocument) != 11 && len(takerDocument) != 14 {
+		return nil, fmt.Errorf("%w: CPF/CNPJ do tomador deve ter 11 ou 14 dígitos", ErrInvalidInvoice)
+	}
+	existing, err := uc.invoiceRepo.FindActiveByAppointmentID(appointment.ID)
+	if err != nil {
+		return nil, errors.New("erro ao verificar notas do agendamento: " + err.Error())
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: o agendamento já possui nota fiscal", ErrInvoiceStatus)
+	}
+
+	settings, err := uc.GetSettings(appointment.UserID)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := uc.providers.Default()
+	if err != nil {
+		return nil, errors.New("provedor de NFS-e indisponível: " + err.Error())
+	}
+	rpsNumber, err := uc.settingsRepo.ReserveRPSNumber(appointment.UserID)
+	if err != nil {
+		return nil, errors.New("falha ao reservar número do RPS: " + err.Error())
+	}
+
+	if description == "" {
+		description = appointment.ServiceDescription
+	}
+	invoice := &entity.Invoice{
+		ID:                 uuid.New(),
+		UserID:             appointment.UserID,
+		AppointmentID:      appointment.ID,
+		Provider:           provider.Name(),
+		Status:             entity.InvoiceStatusPending,
+		RPSNumber:          rpsNumber,
+		RPSSeries:          settings.RPSSeries,
+		Amount:             appointment.Price,
+		ServiceDescription: description,
+		TakerName:          appointment.ClientName,
+		TakerDocument:      takerDocument,
+		TakerEmail:         appointment.ClientEmail,
+	}
+	if err := uc.invoiceRepo.Create(invoice); err != nil {
+		return nil, errors.New("falha ao salvar nota: " + err.Error())
+	}
+
+	uc.send(invoice, appointment, settings, provider)
+	return invoice, nil
+}
+
+// RetryInvoice reenvia uma nota recusada ou sem retorno, com o mesmo número de RPS e
+// os dados fiscais atuais (ex: após corrigir a inscrição municipal).
+func (uc *InvoiceUseCase) RetryInvoice(invoiceID, requestingUserID uuid.UUID) (*entity.Invoice, error) {
+	invoice, err := uc.GetInvoiceByID(invoiceID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != entity.InvoiceStatusError && invoice.Status != entity.InvoiceStatusPending {
+		return nil, fmt.Errorf("%w: apenas notas com erro ou pendentes podem ser reenviadas", ErrInvoiceStatus)
+	}
+
+	appointment, err := uc.appointmentRepo.FindByID(invoice.AppointmentID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar agendamento: " + err.Error())
+	}
+	if appointment == nil {
+		return nil, errors.New("agendamento não encontrado")
+	}
+	settings, err := uc.GetSettings(invoice.UserID)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := uc.providers.Get(invoice.Provider)
+	if err != nil {
+		return nil, errors.New("provedor de NFS-e indisponível: " + err.Error())
+	}
+
+	invoice.Status = entity.InvoiceStatusPending
+	uc.send(invoice, appointment, settings, provider)
+	return invoice, nil
+}
+
+// send monta o RPS, envia ao provedor e registra o resultado na nota. Quando a nota é
+// emitida, o atendimento passa a contar como faturamento com nota fiscal.
+func (uc *InvoiceUseCase) send(invoice *entity.Invoice, appointment *entity.Appointment, settings *entity.InvoiceSettings, provider nfse.Provider) {
+	now := time.Now()
+	doc := &nfse.Document{
+		RPSNumber:  invoice.RPSNumber,
+		RPSSeries:  invoice.RPSSeries,
+		IssueDate:  now,
+		Competence: appointment.StartTime,
+		Provider: nfse.Party{
+			Document:              settings.Document,
+			MunicipalRegistration: settings.MunicipalRegistration,
+		},
+		Taker: nfse.Party{
+			Document: invoice.TakerDocument,
+			Name:     invoice.TakerName,
+			Email:    invoice.TakerEmail,
+			Phone:    appointment.ClientPhone,
+		},
+		ServiceAmount:    invoice.Amount,
+		ISSRate:          settings.ISSRate,
+		ServiceItemCode:  settings.ServiceItemCode,
+		MunicipalityCode: settings.MunicipalityCode,
+		Description:      invoice.ServiceDescription,
+		SimplesNacional:  uc.isSimplesNacional(invoice.UserID),
+	}
+	invoice.ISSRate = doc.ISSRate
+	invoice.ISSAmount = doc.ISSAmount()
+
+	xml, err := nfse.BuildGerarNfseXML(doc)
+	var result *nfse.IssueResult
+	if err == nil {
+		invoice.RequestXML = string(xml)
+		result, err = provider.Issue(doc, xml)
+	}
+
+	if err != nil {
+		invoice.Status = entity.InvoiceStatusError
+		invoice.ErrorCode = "COMUNICACAO"
+		invoice.ErrorMessage = err.Error()
+		var rejection *nfse.RejectionError
+		if errors.As(err, &rejection) {
+			invoice.ErrorCode = rejection.Code
+			invoice.ErrorMessage = rejection.Message
+		}
+	} else {
+		invoice.Status = entity.InvoiceStatusIssued
+		invoice.Number = result.Number
+		invoice.VerificationCode = result.VerificationCode
+		invoice.IssuedAt = &result.IssuedAt
+		invoice.ErrorCode = ""
+		invoice.ErrorMessage = ""
+	}
+	if err := uc.invoiceRepo.Update(invoice); err != nil {
+		log.Printf("Falha ao salvar retorno da NFS-e %s: %v", invoice.ID, err)
+	}
+
+	if invoice.Status == entity.InvoiceStatusIssued && !appointment.Invoiced {
+		appointment.Invoiced = true
+		if err := uc.appointmentRepo.Update(appointment); err != nil {
+			log.Printf("Falha ao marcar agendamento %s como faturado: %v", appointment.ID, err)
+		}
+	}
+}
+
+// CancelInvoiceInputDTO define os dados para cancelar uma NFS-e.
+type CancelInvoiceInputDTO struct {
+	CancelCode string // Código ABRASF; se vazio, usa 1 (erro na emissão)
+	Reason     string
+}
+
+// CancelInvoice cancela uma NFS-e emitida no provedor e desmarca o atendimento como faturado.
+func (uc *InvoiceUseCase) CancelInvoice(invoiceID, requestingUserID uuid.UUID, input CancelInvoiceInputDTO) (*entity.Invoice, error) {
+	invoice, err := uc.GetInvoiceByID(invoiceID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != entity.InvoiceStatusIssued {
+		return nil, fmt.Errorf("%w: apenas notas emitidas podem ser canceladas", ErrInvoiceStatus)
+	}
+	cancelCode := input.CancelCode
+	if cancelCode == "" {
+		cancelCode = "1"
+	}
+
+	provider, err := uc.providers.Get(invoice.Provider)
+	if err != nil {
+		return nil, errors.New("provedor de NFS-e indisponível: " + err.Error())
+	}
+	err = provider.Cancel(nfse.CancelRequest{
+		Number:           invoice.Number,
+		VerificationCode: invoice.VerificationCode,
+		CancelCode:       cancelCode,
+		Reason:           input.Reason,
+	})
+	if err != nil {
+		var rejection *nfse.RejectionError
+		if errors.As(err, &rejection) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidInvoice, rejection.Error())
+		}
+		return nil, errors.New("falha ao cancelar nota no provedor: " + err.Error())
+	}
+
+	now := time.Now()
+	invoice.Status = entity.InvoiceStatusCancelled
+	invoice.CancelledAt = &now
+	invoice.CancelReason = input.Reason
+	if err := uc.invoiceRepo.Update(invoice); err != nil {
+		return nil, errors.New("falha ao salvar cancelamento da nota: " + err.Error())
+	}
+
+	appointment, err := uc.appointmentRepo.FindByID(invoice.AppointmentID)
+	if err == nil && appointment != nil && appointment.Invoiced {
+		appointment.Invoiced = false
+		if err := uc.appointmentRepo.Update(appointment); err != nil {
+			log.Printf("Falha ao desmarcar agendamento %s como faturado: %v", appointment.ID, err)
+		}
+	}
+	return invoice, nil
+}
+
+// GetInvoiceByID busca uma nota verificando se pertence ao usuário.
+func (uc *InvoiceUseCase) GetInvoiceByID(invoiceID, requestingUserID uuid.UUID) (*entity.Invoice, error) {
+	invoice, err := uc.invoiceRepo.FindByID(invoiceID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar nota: " + err.Error())
+	}
+	if invoice == nil || invoice.UserID != requestingUserID {
+		return nil, errors.New("nota não encontrada")
+	}
+	return invoice, nil
+}
+
+// ListInvoices lista as notas do usuário, opcionalmente filtradas por status.
+func (uc *InvoiceUseCase) ListInvoices(userID uuid.UUID, status entity.InvoiceStatus) ([]*entity.Invoice, error) {
+	return uc.invoiceRepo.FindByUserID(userID, status)
+}
+
+// isSimplesNacional indica se o prestador é optante pelo Simples Nacional (MEI incluso).
+func (uc *InvoiceUseCase) isSimplesNacional(userID uuid.UUID) bool {
+	profile, err := uc.taxProfileRepo.FindByUserID(userID)
+	if err != nil || profile == nil {
+		return false
+	}
+	return profile.Regime == entity.TaxRegimeMEI || profile.Regime == entity.TaxRegimeSimplesNacional
+}
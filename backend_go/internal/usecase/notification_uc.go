@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// NotificationSubscriberName identifica a caixa de entrada como assinante do EventDispatcher.
+	NotificationSubscriberName = "notifications"
+
+	// defaultNotificationPageSize é o tamanho da página sem tamanho informado.
+	defaultNotificationPageSize = 20
+	// maxNotificationPageSize é o maior tamanho de página aceito.
+	maxNotificationPageSize = 100
+)
+
+// NotificationUseCase mantém a caixa de entrada de avisos do app. Recebe os eventos do
+// EventDispatcher e grava um aviso para o dono de cada evento.
+type NotificationUseCase struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewNotificationUseCase cria uma nova instância de NotificationUseCase.
+func NewNotificationUseCase(notificationRepo repository.NotificationRepository) *NotificationUseCase {
+	return &NotificationUseCase{notificationRepo: notificationRepo}
+}
+
+// NotificationPage é uma página da caixa de entrada.
+type NotificationPage struct {
+	Items       []*entity.Notification
+	Page        int
+	PageSize    int
+	Total       int64 // Total de avisos que atendem ao filtro
+	UnreadCount int64 // Total de avisos não lidos, independente do filtro
+}
+
+// ListNotifications lista os avisos do usuário, dos mais recentes para os mais antigos.
+// page começa em 1; pageSize zero usa defaultNotificationPageSize.
+func (uc *NotificationUseCase) ListNotifications(userID uuid.UUID, unreadOnly bool, page, pageSize int) (*NotificationPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultNotificationPageSize
+	}
+	if pageSize > maxNotificationPageSize {
+		pageSize = maxNotificationPageSize
+	}
+
+	items, err := uc.notificationRepo.FindByUserID(userID, unreadOnly, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.New("erro ao listar avisos: " + err.Error())
+	}
+	unread, err := uc.notificationRepo.CountByUserID(userID, true)
+	if err != nil {
+		return nil, errors.New("erro ao contar avisos: " + err.Error())
+	}
+	total := unread
+	if !unreadOnly {
+		if total, err = uc.notificationRepo.CountByUserID(userID, false); err != nil {
+			return nil, errors.New("erro ao contar avisos: " + err.Error())
+		}
+	}
+	return &NotificationPage{
+		Items:       items,
+		Page:        page,
+		PageSize:    pageSize,
+		Total:       total,
+		UnreadCount: unread,
+	}, nil
+}
+
+// UnreadCount retorna o número de avisos não lidos do usuário.
+func (uc *NotificationUseCase) UnreadCount(userID uuid.UUID) (int64, error) {
+	return uc.notificationRepo.CountByUserID(userID, true)
+}
+
+// MarkRead marca o aviso como lido. Marcar de novo um aviso lido não altera a data de leitura.
+func (uc *NotificationUseCase) MarkRead(notificationID, requestingUserID uuid.UUID) (*entity.Notification, error) {
+	notification, err := uc.notificationRepo.FindByID(notificationID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar aviso: " + err.Error())
+	}
+	if notification == nil || notification.UserID != requestingUserID {
+		return nil, errors.New("aviso não encontrado")
+	}
+	if notification.IsRead() {
+		return notification, nil
+	}
+
+	readAt := time.Now()
+	if err := uc.notificationRepo.MarkRead(notificationID, readAt); err != nil {
+		return nil, errors.New("falha ao marcar aviso como lido: " + err.Error())
+	}
+	notification.ReadAt = &readAt
+	return notification, nil
+}
+
+// MarkAllRead marca como lidos todos os avisos do usuário. Retorna o número de avisos marcados.
+func (uc *NotificationUseCase) MarkAllRead(userID uuid.UUID) (int64, error) {
+	return uc.notificationRepo.MarkAllRead(userID, time.Now())
+}
+
+// SubscriberName identifica a caixa de entrada no EventDispatcher.
+func (uc *NotificationUseCase) SubscriberName() string {
+	return NotificationSubscriberName
+}
+
+// HandleEvent grava o aviso do evento na caixa de entrada do dono. É idempotente: um
+// evento entregue de novo não duplica o aviso.
+func (uc *NotificationUseCase) HandleEvent(event *entity.DomainEvent) error {
+	description, err := describeEvent(event)
+	if err != nil {
+		return err
+	}
+	_, err = uc.notificationRepo.CreateIfAbsent(&entity.Notification{
+		ID:            uuid.New(),
+		UserID:        event.UserID,
+		EventID:       event.ID,
+		Type:          event.Type,
+		Title:         description.Title,
+		Body:          description.Body,
+		AppointmentID: description.AppointmentID,
+		ClientID:      description.ClientID,
+		CreatedAt:     event.OccurredAt,
+	})
+	if err != nil {
+		return errors.New("falha ao gravar aviso: " + err.Error())
+	}
+	return nil
+}
+
+// eventDescription é o texto de um evento para os avisos ao usuário (caixa de entrada
+// e push), com os registros relacionados que o app pode abrir.
+type eventDescription struct {
+	Title         string
+	Body          string
+	AppointmentID *uuid.UUID
+	ClientID      *uuid.UUID
+}
+
+// describeEvent monta o título, o texto e os vínculos do aviso de um evento. Registros
+// excluídos não são vinculados.
+func describeEvent(event *entity.DomainEvent) (*eventDescription, error) {
+	switch event.Type {
+	case entity.DomainEventAppointmentCreated, entity.DomainEventAppointmentUpdated,
+		entity.DomainEventAppointmentRescheduled, entity.DomainEventAppointmentConfirmed,
+		entity.DomainEventAppointmentCancelled, entity.DomainEventAppointmentCompleted,
+		entity.DomainEventAppointmentDeleted:
+		var payload entity.AppointmentEventPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, fmt.Errorf("payload do evento %s inválido: %w", event.ID, err)
+		}
+		start := payload.StartTime.In(time.Local)
+		when := fmt.Sprintf("%s às %s", start.Format("02/01"), start.Format("15:04"))
+		summary := payload.ClientName
+		if payload.ServiceDescription != "" {
+			summary += " - " + payload.ServiceDescription
+		}
+		description := &eventDescription{Body: summary + " em " + when, AppointmentID: &payload.AppointmentID}
+		switch event.Type {
+		case entity.DomainEventAppointmentCreated:
+			description.Title = "Novo agendamento"
+		case entity.DomainEventAppointmentRescheduled:
+			description.Title = "Agendamento remarcado"
+			description.Body = summary + " para " + when
+		case entity.DomainEventAppointmentConfirmed:
+			description.Title = "Agendamento confirmado"
+		case entity.DomainEventAppointmentCancelled:
+			description.Title = "Agendamento cancelado"
+		case entity.DomainEventAppointmentCompleted:
+			description.Title = "Atendimento concluído"
+			description.Body = summary
+		case entity.DomainEventAppointmentDeleted:
+			description.Title = "Agendamento excluído"
+			description.AppointmentID = nil
+		default:
+			description.Title = "Agendamento alterado"
+		}
+		return description, nil
+	case entity.DomainEventClientCreated, entity.DomainEventClientUpdated, entity.DomainEventClientDeleted:
+		var payload entity.ClientEventPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, fmt.Errorf("payload do evento %s inválido: %w", event.ID, err)
+		}
+		description := &eventDescription{Body: payload.Name, ClientID: &payload.ClientID}
+		switch event.Type {
+		case entity.DomainEventClientCreated:
+			description.Title = "Novo cliente"
+		case entity.DomainEventClientDeleted:
+			description.Title = "Cliente excluído"
+			description.ClientID = nil
+		default:
+			description.Title = "Cliente atualizado"
+		}
+		return description, nil
+	case entity.DomainEventPaymentReceived, entity.DomainEventPaymentRefunded:
+		var payload entity.PaymentEventPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return nil, fmt.Errorf("payload do evento %s inválido: %w", event.ID, err)
+		}
+		amount := "R$ " + formatBRL(payload.Amount)
+		if event.Type == entity.DomainEventPaymentRefunded {
+			return &eventDescription{Title: "Pagamento estornado", Body: amount, AppointmentID: payload.AppointmentID}, nil
+		}
+		return &eventDescription{
+			Title:         "Pagamento recebido",
+			Body:          fmt.Sprintf("%s via %s", amount, payload.Method),
+			AppointmentID: payload.AppointmentID,
+		}, nil
+	}
+	return nil, fmt.Errorf("tipo de evento sem aviso: %s", event.Type)
+}
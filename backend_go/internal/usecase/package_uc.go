@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// PackageUseCase encapsula os pacotes de sessões pré-pagas: cadastro, venda a
+// clientes e consumo dos créditos nos atendimentos concluídos.
+type PackageUseCase struct {
+	packageRepo       repository.ServicePackageRepository
+	clientPackageRepo repository.ClientPackageRepository
+	usageRepo         repository.PackageCreditUsageRepository
+	serviceRepo       repository.ServiceRepository
+	clientRepo        repository.ClientRepository
+	paymentRepo       repository.PaymentRepository
+	uow               repository.UnitOfWork
+}
+
+// NewPackageUseCase cria uma nova instância de PackageUseCase.
+func NewPackageUseCase(
+	packageRepo repository.ServicePackageRepository,
+	clientPackageRepo repository.ClientPackageRepository,
+	usageRepo repository.PackageCreditUsageRepository,
+	serviceRepo repository.ServiceRepository,
+	clientRepo repository.ClientRepository,
+	paymentRepo repository.PaymentRepository,
+	uow repository.UnitOfWork,
+) *PackageUseCase {
+	return &PackageUseCase{
+		packageRepo:       packageRepo,
+		clientPackageRepo: clientPackageRepo,
+		usageRepo:         usageRepo,
+		serviceRepo:       serviceRepo,
+		clientRepo:        clientRepo,
+		paymentRepo:       paymentRepo,
+		uow:               uow,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Pacotes
+// -----------------------------------------------------------------------------
+
+// CreatePackageInputDTO define os dados para cadastrar um pacote de sessões.
+type CreatePackageInputDTO struct {
+	UserID       uuid.UUID
+	Name         string
+	Description  string
+	Price        float64
+	Credits      int
+	ValidityDays int
+	ServiceIDs   []uuid.UUID
+}
+
+// CreatePackage cadastra um pacote de sessões à venda.
+func (uc *PackageUseCase) CreatePackage(input CreatePackageInputDTO) (*entity.ServicePackage, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	pkg := &entity.ServicePackage{
+		ID:           uuid.New(),
+		UserID:       input.UserID,
+		Name:         input.Name,
+		Description:  input.Description,
+		Price:        input.Price,
+		Credits:      input.Credits,
+		ValidityDays: input.ValidityDays,
+		ServiceIDs:   input.ServiceIDs,
+		Active:       true,
+	}
+	if err := uc.validatePackage(pkg); err != nil {
+		return nil, err
+	}
+	if err := uc.packageRepo.Create(pkg); err != nil {
+		return nil, errors.New("falha ao salvar pacote: " + err.Error())
+	}
+	return pkg, nil
+}
+
+// GetPackageByID busca um pacote verificando se pertence ao usuário.
+func (uc *PackageUseCase) GetPackageByID(packageID, requestingUserID uuid.UUID) (*entity.ServicePackage, error) {
+	pkg, err := uc.packageRepo.FindByID(packageID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar pacote: " + err.Error())
+	}
+	if pkg == nil || pkg.UserID != requestingUserID {
+		return nil, errors.New("pacote não encontrado")
+	}
+	return pkg, nil
+}
+
+// ListPackages lista os pacotes cadastrados pelo usuário.
+func (uc *PackageUseCase) ListPackages(userID uuid.UUID) ([]*entity.ServicePackage, error) {
+	return uc.packageRepo.FindByUserID(userID)
+}
+
+// UpdatePackageInputDTO define os dados para atualizar um pacote.
+// Pacotes já vendidos mantêm as condições da venda.
+type UpdatePackageInputDTO struct {
+	Name         *string
+	Description  *string
+	Price        *float64
+	Credits      *int
+	ValidityDays *int
+	ServiceIDs   []uuid.UUID // Se não nil, substitui a lista de serviços cobertos
+	Active       *bool
+}
+
+// UpdatePackage atualiza um pacote de sessões.
+func (uc *PackageUseCase) UpdatePackage(packageID, requestingUserID uuid.UUID, input UpdatePackageInputDTO) (*entity.ServicePackage, error) {
+	pkg, err := uc.GetPackageByID(packageID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		pkg.Name = *input.Name
+	}
+	if input.Description != nil {
+		pkg.Description = *input.Description
+	}
+	if input.Price != nil {
+		pkg.Price = *input.Price
+	}
+	if input.Credits != nil {
+		pkg.Credits = *input.Credits
+	}
+	if input.ValidityDays != nil {
+		pkg.ValidityDays = *input.ValidityDays
+	}
+	if input.ServiceIDs != nil {
+		pkg.ServiceIDs = input.ServiceIDs
+	}
+	if input.Active != nil {
+		pkg.Active = *input.Active
+	}
+
+	if err := uc.validatePackage(pkg); err != nil {
+		return nil, err
+	}
+	if err := uc.packageRepo.Update(pkg); err != nil {
+		return nil, errors.New("falha ao atualizar pacote: " + err.Error())
+	}
+	return pkg, nil
+}
+
+// DeletePackage exclui um pacote. Pacotes já vendidos continuam válidos.
+func (uc *PackageUseCase) DeletePackage(packageID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetPackageByID(packageID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.packageRepo.Delete(packageID)
+}
+
+// validatePackage valida os campos do pacote e se os serviços cobertos pertencem ao usuário.
+func (uc *PackageUseCase) validatePackage(pkg *entity.ServicePackage) error {
+	if pkg.Name == "" {
+		return errors.New("nome do pacote é obrigatório")
+	}
+	if pkg.Price < 0 {
+		return errors.New("preço do pacote não pode ser negativo")
+	}
+	if pkg.Credits <= 0 {
+		return errors.New("quantidade de sessões deve ser maior que zero")
+	}
+	if pkg.ValidityDays < 0 {
+		return errors.New("validade do pacote não pode ser negativa")
+	}
+	if len(pkg.ServiceIDs) == 0 {
+		return errors.New("informe ao menos um serviço coberto pelo pacote")
+	}
+	for _, serviceID := range pkg.ServiceIDs {
+		service, err := uc.serviceRepo.FindByID(serviceID)
+		if err != nil {
+			return errors.New("erro ao buscar serviço: " + err.Error())
+		}
+		if service == nil || service.UserID != pkg.UserID {
+			return errors.New("serviço não encontrado")
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Venda e saldo
+// -----------------------------------------------------------------------------
+
+// SellPackageInputDTO define os dados da venda de um pacote a um cliente.
+type SellPackageInputDTO struct {
+	UserID      uuid.UUID
+	CustomerID  uuid.UUID
+	PackageID   uuid.UUID
+	Price       *float64  // Se nil, usa o preço do pacote
+	PurchasedAt time.Time // Se zero, usa o momento atual
+}
+
+// SellPackage vende um pacote a um cliente e lança o valor como receita no livro-caixa,
+// na mesma transação. A receita é reconhecida na venda; por isso os atendimentos
+// cobertos pelo pacote não contam novamente como faturamento.
+func (uc *PackageUseCase) SellPackage(input SellPackageInputDTO) (*entity.ClientPackage, error) {
+	clientPackage, entry, err := uc.preparePackageSale(input)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.uow.Do(func(tx repository.Transaction) error {
+		if err := tx.ClientPackages().Create(clientPackage); err != nil {
+			return err
+		}
+		if entry != nil {
+			return tx.FinancialEntries().Create(entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New("falha ao salvar venda do pacote: " + err.Error())
+	}
+	return clientPackage, nil
+}
+
+// preparePackageSale valida a venda e monta o pacote do cliente e o lançamento da
+// receita (nil quando o pacote sai sem custo), sem gravá-los. Usado também pelas
+// vendas de balcão, que gravam tudo na mesma transação.
+func (uc *PackageUseCase) preparePackageSale(input SellPackageInputDTO) (*entity.ClientPackage, *entity.FinancialEntry, error) {
+	if _, err := uc.findCustomer(input.CustomerID, input.UserID); err != nil {
+		return nil, nil, err
+	}
+	pkg, err := uc.GetPackageByID(input.PackageID, input.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !pkg.Active {
+		return nil, nil, errors.New("pacote inativo não pode ser vendido")
+	}
+
+	price := pkg.Price
+	if input.Price != nil {
+		if *input.Price < 0 {
+			return nil, nil, errors.New("preço do pacote não pode ser negativo")
+		}
+		price = *input.Price
+	}
+	purchasedAt := input.PurchasedAt
+	if purchasedAt.IsZero() {
+		purchasedAt = time.Now()
+	}
+
+	clientPackage := &entity.ClientPackage{
+		ID:           uuid.New(),
+		UserID:       input.UserID,
+		PackageID:    pkg.ID,
+		CustomerID:   input.CustomerID,
+		Name:         pkg.Name,
+		ServiceIDs:   pkg.ServiceIDs,
+		TotalCredits: pkg.Credits,
+		Price:        price,
+		PurchasedAt:  purchasedAt,
+	}
+	if pkg.ValidityDays > 0 {
+		expiresAt := purchasedAt.AddDate(0, 0, pkg.ValidityDays)
+		clientPackage.ExpiresAt = &expiresAt
+	}
+
+	var entry *entity.FinancialEntry
+	if price > 0 {
+		entry = &entity.FinancialEntry{
+			ID:          uuid.New(),
+			UserID:      input.UserID,
+			Type:        entity.FinancialEntryTypeIncome,
+			Amount:      price,
+			Description: "Venda de pacote: " + pkg.Name,
+			Date:        purchasedAt,
+			RevenueKind: entity.RevenueKindServices,
+		}
+	}
+	return clientPackage, entry, nil
+}
+
+// ListClientPackages lista os pacotes comprados por um cliente, com o saldo de sessões.
+func (uc *PackageUseCase) ListClientPackages(customerID, requestingUserID uuid.UUID) ([]*entity.ClientPackage, error) {
+	if _, err := uc.findCustomer(customerID, requestingUserID); err != nil {
+		return nil, err
+	}
+	return uc.clientPackageRepo.FindByCustomerID(customerID)
+}
+
+// findCustomer busca um cliente do cadastro verificando se pertence ao usuário.
+func (uc *PackageUseCase) findCustomer(customerID, userID uuid.UUID) (*entity.Client, error) {
+	customer, err := uc.clientRepo.FindByID(customerID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar cliente: " + err.Error())
+	}
+	if customer == nil || customer.UserID != userID {
+		return nil, errors.New("cliente não encontrado")
+	}
+	return customer, nil
+}
+
+// -----------------------------------------------------------------------------
+// Consumo de créditos
+// -----------------------------------------------------------------------------
+
+// CompleteAppointment consome um crédito de pacote do cliente quando o serviço concluído
+// é coberto por um pacote válido, usando primeiro o que vence antes. O preço do
+// agendamento é zerado porque a receita já foi lançada na venda do pacote.
+// Implementa AppointmentCompletionPolicy: o consumo é gravado na transação que conclui
+// o agendamento, com o novo preço.
+func (uc *PackageUseCase) CompleteAppointment(tx repository.Transaction, appointment *entity.Appointment) error {
+	clientPackages, err := creditPackages(tx.ClientPackages(), tx.PackageCreditUsages(), tx.Payments(), appointment)
+	if err != nil {
+		return err
+	}
+
+	for _, clientPackage := range clientPackages {
+		consumed, err := tx.ClientPackages().ConsumeCredit(clientPackage.ID)
+		if err != nil {
+			return errors.New("falha ao consumir crédito do pacote: " + err.Error())
+		}
+		if !consumed {
+			continue // Saldo consumido por outro atendimento em paralelo
+		}
+
+		usage := &entity.PackageCreditUsage{
+			ID:              uuid.New(),
+			ClientPackageID: clientPackage.ID,
+			AppointmentID:   appointment.ID,
+			UsedAt:          appointment.StartTime,
+		}
+		if err := tx.PackageCreditUsages().Create(usage); err != nil {
+			return errors.New("falha ao registrar consumo do pacote: " + err.Error())
+		}
+		appointment.Price = 0
+		return nil
+	}
+	return nil
+}
+
+// CoversAppointment indica se a conclusão do atendimento consumirá um crédito de pacote
+// (zerando o preço), para que o fechamento não cobre o serviço do cliente.
+func (uc *PackageUseCase) CoversAppointment(appointment *entity.Appointment) (bool, error) {
+	clientPackages, err := creditPackages(uc.clientPackageRepo, uc.usageRepo, uc.paymentRepo, appointment)
+	return len(clientPackages) > 0, err
+}
+
+// creditPackages lista, na ordem de consumo, os pacotes do cliente que podem cobrir o
+// atendimento. Não há consumo em atendimentos sem cliente cadastrado, sem serviço do
+// catálogo ou sem custo (ex: incluídos na assinatura do cliente), nos que já consumiram
+// um crédito (reabertos e concluídos novamente) e nos já pagos, para que o valor recebido
+// não deixe de ser faturado com o preço zerado.
+func creditPackages(clientPackageRepo repository.ClientPackageRepository, usageRepo repository.PackageCreditUsageRepository,
+	paymentRepo repository.PaymentRepository, appointment *entity.Appointment) ([]*entity.ClientPackage, error) {
+	if appointment.CustomerID == nil || appointment.ServiceID == nil || appointment.Price <= 0 {
+		return nil, nil
+	}
+
+	existing, err := usageRepo.FindByAppointmentID(appointment.ID)
+	if err != nil {
+		return nil, errors.New("erro ao verificar consumo de pacote: " + err.Error())
+	}
+	if existing != nil {
+		return nil, nil
+	}
+	payments, err := paymentRepo.FindByAppointmentID(appointment.ID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar pagamentos do agendamento: " + err.Error())
+	}
+	for _, p := range payments {
+		if p.Status == entity.PaymentStatusPaid {
+			return nil, nil
+		}
+	}
+
+	clientPackages, err := clientPackageRepo.FindByCustomerID(*appointment.CustomerID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar pacotes do cliente: " + err.Error())
+	}
+	var covering []*entity.ClientPackage
+	for _, clientPackage := range clientPackages {
+		if clientPackage.UserID == appointment.UserID && clientPackage.CanCover(*appointment.ServiceID, appointment.StartTime) {
+			covering = append(covering, clientPackage)
+		}
+	}
+	return covering, nil
+}
@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/payment"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// errPaymentAmountMismatch indica que o valor informado pelo provedor difere do cobrado.
+var errPaymentAmountMismatch = errors.New("valor pago diverge do valor cobrado")
+
+// PaymentUseCase encapsula a lógica de cobranças e da conciliação via webhook.
+type PaymentUseCase struct {
+	paymentRepo     repository.PaymentRepository
+	appointmentRepo repository.AppointmentRepository
+	providers       *payment.Registry
+	giftCards       *GiftCardUseCase
+	uow             repository.UnitOfWork
+}
+
+// NewPaymentUseCase cria uma nova instância de PaymentUseCase.
+func NewPaymentUseCase(
+	paymentRepo repository.PaymentRepository,
+	appointmentRepo repository.AppointmentRepository,
+	providers *payment.Registry,
+	giftCards *GiftCardUseCase,
+	uow repository.UnitOfWork,
+) *PaymentUseCase {
+	return &PaymentUseCase{
+		paymentRepo:     paymentRepo,
+		appointmentRepo: appointmentRepo,
+		providers:       providers,
+		giftCards:       giftCards,
+		uow:             uow,
+	}
+}
+
+// CreatePaymentInputDTO define os dados para criar uma cobrança de um agendamento.
+type CreatePaymentInputDTO struct {
+	UserID        uuid.UUID
+	AppointmentID uuid.UUID
+	Amount        float64 // Se zero, usa o preço do agendamento
+	Method        entity.PaymentMethod
+	GiftCardCode  string // Obrigatório quando Method é GIFT_CARD
+}
+
+// CreatePayment cria uma cobrança para um agendamento do usuário.
+// Pagamentos em dinheiro e com vale-presente são registrados como pagos; PIX e
+// cartão geram uma cobrança no provedor padrão e ficam pendentes até a confirmação
+// via webhook. O pagamento pendente é gravado antes da cobrança ser criada, para que
+// um webhook recebido logo em seguida encontre o pagamento. O vale-presente pode
+// cobrir apenas parte do valor, com o restante pago em outro pagamento.
+func (uc *PaymentUseCase) CreatePayment(input CreatePaymentInputDTO) (*entity.Payment, error) {
+	appointment, err := uc.appointmentRepo.FindByID(input.AppointmentID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar agendamento: " + err.Error())
+	}
+	if appointment == nil || appointment.UserID != input.UserID {
+		return nil, errors.New("agendamento não encontrado")
+	}
+	if appointment.Status == entity.AppointmentStatusCancelled {
+		return nil, errors.New("não é possível cobrar um agendamento cancelado")
+	}
+
+	amount := input.Amount
+	if amount == 0 {
+		amount = appointment.Price
+	}
+	if amount <= 0 {
+		return nil, errors.New("valor do pagamento deve ser maior que zero")
+	}
+
+	p := &entity.Payment{
+		ID:            uuid.New(),
+		UserID:        input.UserID,
+		AppointmentID: &appointment.ID,
+		Amount:        amount,
+		Method:        input.Method,
+		Status:        entity.PaymentStatusPending,
+	}
+
+	switch input.Method {
+	case entity.PaymentMethodCash:
+		now := time.Now()
+		p.Status = entity.PaymentStatusPaid
+		p.PaidAt = &now
+	case entity.PaymentMethodPix, entity.PaymentMethodCard:
+		provider, err := uc.providers.Default()
+		if err != nil {
+			return nil, err
+		}
+		p.Provider = provider.Name()
+		if err := uc.savePayment(p, true, "", nil); err != nil {
+			return nil, errors.New("falha ao salvar pagamento: " + err.Error())
+		}
+		charge, err := provider.CreateCharge(payment.ChargeInput{
+			PaymentID:   p.ID,
+			Amount:      amount,
+			Method:      string(input.Method),
+			Description: appointment.ServiceDescription,
+		})
+		if err != nil {
+			p.Status = entity.PaymentStatusFailed
+			if updateErr := uc.savePayment(p, false, entity.PaymentStatusPending, nil); updateErr != nil {
+				log.Printf("Erro ao registrar falha da cobrança do pagamento %s: %v", p.ID, updateErr)
+			}
+			return nil, errors.New("falha ao criar cobrança no provedor: " + err.Error())
+		}
+		p.ProviderChargeID = charge.ID
+		p.PaymentURL = charge.PaymentURL
+		if err := uc.savePayment(p, false, entity.PaymentStatusPending, nil); err != nil {
+			return nil, errors.New("falha ao salvar cobrança do pagamento: " + err.Error())
+		}
+		return p, nil
+	case entity.PaymentMethodGiftCard:
+		if input.GiftCardCode == "" {
+			return nil, fmt.Errorf("%w: informe o código do vale", ErrInvalidGiftCard)
+		}
+		now := time.Now()
+		p.Status = entity.PaymentStatusPaid
+		p.PaidAt = &now
+		// O débito do vale e o pagamento são gravados juntos: se um falhar, nada é gravado.
+		err := uc.uow.Do(func(tx repository.Transaction) error {
+			giftCard, err := uc.giftCards.RedeemGiftCard(tx, input.UserID, input.GiftCardCode, amount, p.ID)
+			if err != nil {
+				return err
+			}
+			p.GiftCardID = &giftCard.ID
+			return uc.writePayment(tx, p, true, "", nil)
+		})
+		if errors.Is(err, ErrInvalidGiftCard) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, errors.New("falha ao salvar pagamento: " + err.Error())
+		}
+		// O valor já entrou no caixa na venda do vale; não há novo lançamento no livro-caixa.
+		return p, nil
+	default:
+		return nil, errors.New("forma de pagamento inválida: " + string(input.Method))
+	}
+
+	var entry *entity.FinancialEntry
+	if p.Status == entity.PaymentStatusPaid {
+		entry = ledgerEntry(p, entity.FinancialEntryTypeIncome, "Pagamento: "+appointment.ServiceDescription)
+	}
+	if err := uc.savePayment(p, true, "", entry); err != nil {
+		return nil, errors.New("falha ao salvar pagamento: " + err.Error())
+	}
+	return p, nil
+}
+
+// GetPaymentByID busca um pagamento verificando se pertence ao usuário.
+func (uc *PaymentUseCase) GetPaymentByID(paymentID, requestingUserID uuid.UUID) (*entity.Payment, error) {
+	p, err := uc.paymentRepo.FindByID(paymentID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar pagamento: " + err.Error())
+	}
+	if p == nil || p.UserID != requestingUserID {
+		return nil, errors.New("pagamento não encontrado")
+	}
+	return p, nil
+}
+
+// ListAppointmentPayments lista os pagamentos de um agendamento do usuário.
+func (uc *PaymentUseCase) ListAppointmentPayments(appointmentID, requestingUserID uuid.UUID) ([]*entity.Payment, error) {
+	appointment, err := uc.appointmentRepo.FindByID(appointmentID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar agendamento: " + err.Error())
+	}
+	if appointment == nil || appointment.UserID != requestingUserID {
+		return nil, errors.New("agendamento não encontrado")
+	}
+	return uc.paymentRepo.FindByAppointmentID(appointmentID)
+}
+
+// HandleWebhook processa um webhook recebido de um provedor de pagamento.
+// O processamento é idempotente: o evento é registrado pelo ID do provedor na mesma
+// transação em que é aplicado ao pagamento e ao livro-caixa, e entregas repetidas (mesmo
+// concorrentes) são ignoradas. Retorna payment.ErrProviderNotFound ou
+// payment.ErrInvalidSignature para que o handler responda com o status adequado.
+func (uc *PaymentUseCase) HandleWebhook(providerName string, headers http.Header, body []byte) error {
+	provider, err := uc.providers.Get(providerName)
+	if err != nil {
+		return err
+	}
+
+	event, err := provider.ParseWebhook(headers, body)
+	if err != nil {
+		return err
+	}
+
+	record := &entity.PaymentWebhookEvent{
+		ID:               uuid.New(),
+		Provider:         providerName,
+		EventID:          event.ID,
+		EventType:        event.Type,
+		ProviderChargeID: event.ChargeID,
+		Status:           entity.WebhookEventStatusProcessed,
+		Payload:          string(body),
+	}
+
+	duplicate := false
+	err = uc.uow.Do(func(tx repository.Transaction) error {
+		created, err := tx.PaymentWebhookEvents().CreateIfAbsent(record)
+		if err != nil {
+			return errors.New("erro ao registrar evento de webhook: " + err.Error())
+		}
+		if !created {
+			duplicate = true
+			return nil
+		}
+
+		p, applied, err := uc.reconcile(tx, providerName, event)
+		switch {
+		case errors.Is(err, errPaymentAmountMismatch):
+			// Divergência de valor não se resolve com uma nova entrega: registra para análise manual.
+			record.Status = entity.WebhookEventStatusFailed
+			record.Error = err.Error()
+			log.Printf("Falha ao conciliar webhook %s/%s: %v", providerName, event.ID, err)
+		case err != nil:
+			// Erro transitório: desfaz também o registro do evento para que o provedor reenvie.
+			return err
+		case !applied:
+			record.Status = entity.WebhookEventStatusIgnored
+		}
+		if p != nil {
+			record.PaymentID = &p.ID
+		}
+		return tx.PaymentWebhookEvents().Update(record)
+	})
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		log.Printf("Webhook %s/%s já processado, ignorando entrega duplicada", providerName, event.ID)
+	}
+	return nil
+}
+
+// reconcile aplica o evento ao pagamento correspondente, bloqueado na transação.
+// Retorna applied false quando o evento não altera o pagamento (entrega fora de ordem,
+// como a confirmação após o estorno). Eventos de cobranças ainda desconhecidas retornam
+// erro, para que o provedor reenvie depois que a cobrança for gravada no pagamento.
+func (uc *PaymentUseCase) reconcile(tx repository.Transaction, providerName string, event *payment.WebhookEvent) (*entity.Payment, bool, error) {
+	p, err := tx.Payments().LockByProviderChargeID(providerName, event.ChargeID)
+	if err != nil {
+		return nil, false, errors.New("erro ao buscar pagamento: " + err.Error())
+	}
+	if p == nil {
+		return nil, false, fmt.Errorf("nenhum pagamento com a cobrança %s", event.ChargeID)
+	}
+
+	previousStatus := p.Status
+	var entry *entity.FinancialEntry
+	switch event.Type {
+	case payment.EventTypePaymentPaid:
+		if p.Status == entity.PaymentStatusPaid || p.Status == entity.PaymentStatusRefunded {
+			return p, false, nil
+		}
+		if event.Amount > 0 && math.Abs(event.Amount-p.Amount) > 0.009 {
+			return p, false, errPaymentAmountMismatch
+		}
+		paidAt := event.OccurredAt
+		if paidAt.IsZero() {
+			paidAt = time.Now()
+		}
+		p.Status = entity.PaymentStatusPaid
+		p.PaidAt = &paidAt
+		entry = ledgerEntry(p, entity.FinancialEntryTypeIncome, "Pagamento confirmado via "+providerName)
+	case payment.EventTypePaymentFailed:
+		if p.Status != entity.PaymentStatusPending {
+			return p, false, nil
+		}
+		p.Status = entity.PaymentStatusFailed
+	case payment.EventTypePaymentRefunded:
+		if p.Status == entity.PaymentStatusRefunded {
+			return p, false, nil
+		}
+		if p.Status == entity.PaymentStatusPaid {
+			entry = ledgerEntry(p, entity.FinancialEntryTypeExpense, "Estorno de pagamento via "+providerName)
+		}
+		p.Status = entity.PaymentStatusRefunded
+	default:
+		return nil, false, nil
+	}
+
+	if err := uc.writePayment(tx, p, false, previousStatus, entry); err != nil {
+		return p, false, errors.New("falha ao atualizar pagamento: " + err.Error())
+	}
+	return p, true, nil
+}
+
+// savePayment grava o pagamento e o lançamento no livro-caixa (se informado) em uma transação.
+func (uc *PaymentUseCase) savePayment(p *entity.Payment, create bool, previousStatus entity.PaymentStatus, entry *entity.FinancialEntry) error {
+	return uc.uow.Do(func(tx repository.Transaction) error {
+		return uc.writePayment(tx, p, create, previousStatus, entry)
+	})
+}
+
+// writePayment cria ou atualiza o pagamento na transação e, se ele passou a pago ou
+// estornado, grava o evento de domínio correspondente.
+func (uc *PaymentUseCase) writePayment(tx repository.Transaction, p *entity.Payment, create bool, previousStatus entity.PaymentStatus, entry *entity.FinancialEntry) error {
+	var events []*entity.DomainEvent
+	if p.Status != previousStatus {
+		var eventType entity.DomainEventType
+		switch p.Status {
+		case entity.PaymentStatusPaid:
+			eventType = entity.DomainEventPaymentReceived
+		case entity.PaymentStatusRefunded:
+			eventType = entity.DomainEventPaymentRefunded
+		}
+		if eventType != "" {
+			event, err := paymentEvent(eventType, p)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+	}
+	if create {
+		if err := tx.Payments().Create(p); err != nil {
+			return err
+		}
+	} else if err := tx.Payments().Update(p); err != nil {
+		return err
+	}
+	if entry != nil {
+		if err := tx.FinancialEntries().Create(entry); err != nil {
+			return errors.New("falha ao lançar pagamento no livro-caixa: " + err.Error())
+		}
+	}
+	return tx.Events().Append(events...)
+}
+
+// ledgerEntry monta o lançamento no livro-caixa da entrada (ou do estorno) de um pagamento.
+func ledgerEntry(p *entity.Payment, entryType entity.FinancialEntryType, description string) *entity.FinancialEntry {
+	date := time.Now()
+	if p.PaidAt != nil && entryType == entity.FinancialEntryTypeIncome {
+		date = *p.PaidAt
+	}
+	entry := &entity.FinancialEntry{
+		ID:            uuid.New(),
+		UserID:        p.UserID,
+		Type:          entryType,
+		Amount:        p.Amount,
+		Description:   description,
+		Date:          date,
+		AppointmentID: p.AppointmentID,
+		PaymentID:     &p.ID,
+	}
+	if entryType == entity.FinancialEntryTypeIncome {
+		entry.RevenueKind = entity.RevenueKindServices
+	}
+	return entry
+}
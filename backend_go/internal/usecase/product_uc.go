@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ProductUseCase encapsula o cadastro de produtos, as movimentações de estoque e os
+// alertas de reposição.
+type ProductUseCase struct {
+	productRepo repository.ProductRepository
+	alertRepo   repository.LowStockAlertRepository
+	entryRepo   repository.FinancialEntryRepository
+}
+
+// NewProductUseCase cria uma nova instância de ProductUseCase.
+func NewProductUseCase(productRepo repository.ProductRepository, alertRepo repository.LowStockAlertRepository, entryRepo repository.FinancialEntryRepository) *ProductUseCase {
+	return &ProductUseCase{
+		productRepo: productRepo,
+		alertRepo:   alertRepo,
+		entryRepo:   entryRepo,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Produtos
+// -----------------------------------------------------------------------------
+
+// CreateProductInputDTO define os dados para cadastrar um produto.
+type CreateProductInputDTO struct {
+	UserID       uuid.UUID
+	Name         string
+	SKU          string
+	Description  string
+	CostPrice    float64
+	SalePrice    float64
+	InitialStock int // Registrado como ajuste de estoque, sem lançamento no livro-caixa
+	MinStock     int
+}
+
+// CreateProduct cadastra um produto. O estoque inicial, se informado, é registrado
+// como uma movimentação de ajuste.
+func (uc *ProductUseCase) CreateProduct(input CreateProductInputDTO) (*entity.Product, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if input.InitialStock < 0 {
+		return nil, errors.New("estoque inicial não pode ser negativo")
+	}
+	product := &entity.Product{
+		ID:          uuid.New(),
+		UserID:      input.UserID,
+		Name:        strings.TrimSpace(input.Name),
+		SKU:         normalizeSKU(input.SKU),
+		Description: input.Description,
+		CostPrice:   input.CostPrice,
+		SalePrice:   input.SalePrice,
+		MinStock:    input.MinStock,
+		Active:      true,
+	}
+	if err := uc.validateProduct(product); err != nil {
+		return nil, err
+	}
+	if err := uc.productRepo.Create(product); err != nil {
+		return nil, errors.New("falha ao salvar produto: " + err.Error())
+	}
+
+	if input.InitialStock > 0 {
+		movement := &entity.StockMovement{
+			UserID:    product.UserID,
+			ProductID: product.ID,
+			Type:      entity.StockMovementTypeAdjustment,
+			Quantity:  input.InitialStock,
+			UnitPrice: product.CostPrice,
+			Notes:     "Estoque inicial",
+		}
+		if err := uc.productRepo.ApplyMovement(movement); err != nil {
+			return nil, errors.New("falha ao registrar estoque inicial: " + err.Error())
+		}
+		product.StockQuantity = movement.StockAfter
+	}
+	uc.checkLowStock(product)
+	return product, nil
+}
+
+// GetProductByID busca um produto verificando se pertence ao usuário.
+func (uc *ProductUseCase) GetProductByID(productID, requestingUserID uuid.UUID) (*entity.Product, error) {
+	product, err := uc.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar produto: " + err.Error())
+	}
+	if product == nil || product.UserID != requestingUserID {
+		return nil, errors.New("produto não encontrado")
+	}
+	return product, nil
+}
+
+// ListProducts lista os produtos do usuário.
+func (uc *ProductUseCase) ListProducts(userID uuid.UUID) ([]*entity.Product, error) {
+	return uc.productRepo.FindByUserID(userID)
+}
+
+// ListLowStockProducts lista os produtos ativos com estoque no mínimo ou abaixo dele.
+func (uc *ProductUseCase) ListLowStockProducts(userID uuid.UUID) ([]*entity.Product, error) {
+	return uc.productRepo.FindLowStock(userID)
+}
+
+// UpdateProductInputDTO define os dados para atualizar um produto. O estoque só muda
+// por movimentações.
+type UpdateProductInputDTO struct {
+	Name        *string
+	SKU         *string
+	Description *string
+	CostPrice   *float64
+	SalePrice   *float64
+	MinStock    *int
+	Active      *bool
+}
+
+// UpdateProduct atualiza um produto. Alterar o estoque mínimo reavalia o alerta de reposição.
+func (uc *ProductUseCase) UpdateProduct(productID, requestingUserID uuid.UUID, input UpdateProductInputDTO) (*entity.Product, error) {
+	product, err := uc.GetProductByID(productID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		product.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.SKU != nil {
+		product.SKU = normalizeSKU(*input.SKU)
+	}
+	if input.Description != nil {
+		product.Description = *input.Description
+	}
+	if input.CostPrice != nil {
+		product.CostPrice = *input.CostPrice
+	}
+	if input.SalePrice != nil {
+		product.SalePrice = *input.SalePrice
+	}
+	if input.MinStock != nil {
+		product.MinStock = *input.MinStock
+	}
+	if input.Active != nil {
+		product.Active = *input.Active
+	}
+
+	if err := uc.validateProduct(product); err != nil {
+		return nil, err
+	}
+	if err := uc.productRepo.Update(product); err != nil {
+		return nil, errors.New("falha ao atualizar produto: " + err.Error())
+	}
+	uc.checkLowStock(product)
+	return product, nil
+}
+
+// DeleteProduct exclui um produto. O histórico de movimentações é mantido.
+func (uc *ProductUseCase) DeleteProduct(productID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetProductByID(productID, requestingUserID); err != nil {
+		return err
+	}
+	if err := uc.productRepo.Delete(productID); err != nil {
+		return err
+	}
+	if err := uc.alertRepo.ResolveByProductID(productID); err != nil {
+		log.Printf("Falha ao encerrar alertas de estoque do produto %s: %v", productID, err)
+	}
+	return nil
+}
+
+// validateProduct valida os campos do produto e a unicidade do SKU.
+func (uc *ProductUseCase) validateProduct(product *entity.Product) error {
+	if product.Name == "" {
+		return errors.New("nome do produto é obrigatório")
+	}
+	if product.CostPrice < 0 || product.SalePrice < 0 {
+		return errors.New("preços do produto não podem ser negativos")
+	}
+	if product.MinStock < 0 {
+		return errors.New("estoque mínimo não pode ser negativo")
+	}
+	if product.SKU == "" {
+		return nil
+	}
+	existing, err := uc.productRepo.FindBySKU(product.UserID, product.SKU)
+	if err != nil {
+		return errors.New("erro ao verificar SKU do produto: " + err.Error())
+	}
+	if existing != nil && existing.ID != product.ID {
+		return errors.New("já existe um produto com este SKU")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Estoque
+// -----------------------------------------------------------------------------
+
+// RecordStockMovementInputDTO define os dados de uma movimentação manual de estoque.
+type RecordStockMovementInputDTO struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+	Type      entity.StockMovementType // PURCHASE, ADJUSTMENT ou LOSS; vendas vêm do fechamento
+	Quantity  int                      // Positivo; em ADJUSTMENT pode ser negativo
+	UnitCost  float64                  // Custo unitário da compra; se zero, usa o custo cadastrado
+	Notes     string
+}
+
+// RecordStockMovement registra uma compra, ajuste ou perda de estoque. Compras com custo
+// atualizam o custo do produto e são lançadas como despesa no livro-caixa.
+func (uc *ProductUseCase) RecordStockMovement(input RecordStockMovementInputDTO) (*entity.StockMovement, error) {
+	product, err := uc.GetProductByID(input.ProductID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := input.Quantity
+	unitPrice := product.CostPrice
+	switch input.Type {
+	case entity.StockMovementTypePurchase:
+		if quantity <= 0 {
+			return nil, errors.New("quantidade comprada deve ser maior que zero")
+		}
+		if input.UnitCost < 0 {
+			return nil, errors.New("custo unitário não pode ser negativo")
+		}
+		if input.UnitCost > 0 {
+			unitPrice = input.UnitCost
+		}
+	case entity.StockMovementTypeLoss:
+		if quantity <= 0 {
+			return nil, errors.New("quantidade perdida deve ser maior que zero")
+		}
+		quantity = -quantity
+	case entity.StockMovementTypeAdjustment:
+		if quantity == 0 {
+			return nil, errors.New("quantidade do ajuste não pode ser zero")
+		}
+	default:
+		return nil, errors.New("tipo de movimentação inválido: " + string(input.Type))
+	}
+	if quantity < 0 && product.StockQuantity+quantity < 0 {
+		return nil, fmt.Errorf("estoque insuficiente: há %d unidade(s) de %s", product.StockQuantity, product.Name)
+	}
+
+	movement := &entity.StockMovement{
+		UserID:    product.UserID,
+		ProductID: product.ID,
+		Type:      input.Type,
+		Quantity:  quantity,
+		UnitPrice: unitPrice,
+		Notes:     input.Notes,
+	}
+	if err := uc.productRepo.ApplyMovement(movement); err != nil {
+		return nil, errors.New("falha ao registrar movimentação: " + err.Error())
+	}
+	product.StockQuantity = movement.StockAfter
+
+	if input.Type == entity.StockMovementTypePurchase && unitPrice > 0 {
+		if unitPrice != product.CostPrice {
+			product.CostPrice = unitPrice
+			if err := uc.productRepo.Update(product); err != nil {
+				log.Printf("Falha ao atualizar custo do produto %s: %v", product.ID, err)
+			}
+		}
+		entry := &entity.FinancialEntry{
+			ID:          uuid.New(),
+			UserID:      product.UserID,
+			Type:        entity.FinancialEntryTypeExpense,
+			Amount:      roundCents(unitPrice * float64(quantity)),
+			Description: fmt.Sprintf("Compra de estoque: %d x %s", quantity, product.Name),
+			Date:        time.Now(),
+		}
+		if err := uc.entryRepo.Create(entry); err != nil {
+			log.Printf("Falha ao lançar compra do produto %s no livro-caixa: %v", product.ID, err)
+		}
+	}
+
+	uc.checkLowStock(product)
+	return movement, nil
+}
+
+// ListStockMovements lista as movimentações de um produto, da mais recente à mais antiga.
+func (uc *ProductUseCase) ListStockMovements(productID, requestingUserID uuid.UUID) ([]*entity.StockMovement, error) {
+	if _, err := uc.GetProductByID(productID, requestingUserID); err != nil {
+		return nil, err
+	}
+	return uc.productRepo.FindMovementsByProductID(productID)
+}
+
+// ListLowStockAlerts lista os alertas de reposição do usuário, do mais recente ao mais antigo.
+func (uc *ProductUseCase) ListLowStockAlerts(userID uuid.UUID, openOnly bool) ([]*entity.LowStockAlert, error) {
+	return uc.alertRepo.FindByUserID(userID, openOnly)
+}
+
+// RefreshLowStock reavalia o alerta de reposição dos produtos após uma venda.
+func (uc *ProductUseCase) RefreshLowStock(productIDs []uuid.UUID) {
+	for _, productID := range productIDs {
+		product, err := uc.productRepo.FindByID(productID)
+		if err != nil || product == nil {
+			continue
+		}
+		uc.checkLowStock(product)
+	}
+}
+
+// checkLowStock abre um alerta quando o estoque atinge o mínimo e encerra o alerta
+// aberto quando o estoque volta a ficar acima dele. Falhas são apenas registradas em log.
+func (uc *ProductUseCase) checkLowStock(product *entity.Product) {
+	open, err := uc.alertRepo.FindOpenByProductID(product.ID)
+	if err != nil {
+		log.Printf("Erro ao verificar alerta de estoque do produto %s: %v", product.ID, err)
+		return
+	}
+
+	if !product.IsLowStock() || !product.Active {
+		if open != nil {
+			if err := uc.alertRepo.ResolveByProductID(product.ID); err != nil {
+				log.Printf("Falha ao encerrar alerta de estoque do produto %s: %v", product.ID, err)
+			}
+		}
+		return
+	}
+	if open != nil {
+		return
+	}
+
+	alert := &entity.LowStockAlert{
+		ID:            uuid.New(),
+		UserID:        product.UserID,
+		ProductID:     product.ID,
+		StockQuantity: product.StockQuantity,
+		MinStock:      product.MinStock,
+	}
+	if err := uc.alertRepo.Create(alert); err != nil {
+		log.Printf("Falha ao salvar alerta de estoque do produto %s: %v", product.ID, err)
+		return
+	}
+	log.Printf("Estoque do produto '%s' atingiu o mínimo: %d de %d", product.Name, product.StockQuantity, product.MinStock)
+}
+
+// normalizeSKU remove espaços e converte o SKU para maiúsculas.
+func normalizeSKU(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/push"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPushDevice indica que os dados do dispositivo informados são inválidos.
+var ErrInvalidPushDevice = errors.New("dispositivo inválido")
+
+// ErrInvalidPushPreference indica que as preferências de notificação informadas são inválidas.
+var ErrInvalidPushPreference = errors.New("preferência de notificação inválida")
+
+const (
+	// PushSubscriberName identifica as notificações push como assinante do EventDispatcher.
+	PushSubscriberName = "push"
+
+	// maxPushDevicesPerUser limita os dispositivos de cada usuário; ao registrar além do
+	// limite, os usados há mais tempo são removidos.
+	maxPushDevicesPerUser = 20
+	// maxPushTokenLength é o tamanho máximo aceito para o token do dispositivo.
+	maxPushTokenLength = 512
+)
+
+// pushDefaultEventTypes são os eventos notificados a quem ainda não alterou as
+// preferências: a chegada e o cancelamento de agendamentos.
+var pushDefaultEventTypes = map[entity.DomainEventType]bool{
+	entity.DomainEventAppointmentCreated:   true,
+	entity.DomainEventAppointmentCancelled: true,
+}
+
+// PushUseCase gerencia os dispositivos do app que recebem notificações push e as
+// preferências de cada usuário. Recebe os eventos do EventDispatcher e notifica os
+// dispositivos do dono do evento, conforme as preferências.
+type PushUseCase struct {
+	deviceRepo     repository.PushDeviceRepository
+	preferenceRepo repository.PushPreferenceRepository
+	sender         push.Sender
+}
+
+// NewPushUseCase cria uma nova instância de PushUseCase.
+func NewPushUseCase(
+	deviceRepo repository.PushDeviceRepository,
+	preferenceRepo repository.PushPreferenceRepository,
+	sender push.Sender,
+) *PushUseCase {
+	return &PushUseCase{
+		deviceRepo:     deviceRepo,
+		preferenceRepo: preferenceRepo,
+		sender:         sender,
+	}
+}
+
+// RegisterPushDeviceInputDTO define os dados para registrar um dispositivo.
+type RegisterPushDeviceInputDTO struct {
+	Token      string
+	Platform   string
+	DeviceName string
+	AppVersion string
+}
+
+// RegisterDevice registra o token do dispositivo para o usuário. O app deve chamá-lo a
+// cada login e sempre que o provedor renovar o token; registrar de novo um token
+// existente apenas atualiza os dados (e o dono, se outro usuário entrou no aparelho).
+func (uc *PushUseCase) RegisterDevice(userID uuid.UUID, input RegisterPushDeviceInputDTO) (*entity.PushDevice, error) {
+	token := strings.TrimSpace(input.Token)
+	if token == "" || len(token) > maxPushTokenLength {
+		return nil, fmt.Errorf("%w: token deve ter entre 1 e %d caracteres", ErrInvalidPushDevice, maxPushTokenLength)
+	}
+	platform := entity.PushPlatform(strings.ToUpper(strings.TrimSpace(input.Platform)))
+	switch platform {
+	case entity.PushPlatformAndroid, entity.PushPlatformIOS, entity.PushPlatformWeb:
+	default:
+		return nil, fmt.Errorf("%w: plataforma deve ser ANDROID, IOS ou WEB", ErrInvalidPushDevice)
+	}
+	deviceName := strings.TrimSpace(input.DeviceName)
+	if len(deviceName) > 100 {
+		return nil, fmt.Errorf("%w: nome do dispositivo deve ter no máximo 100 caracteres", ErrInvalidPushDevice)
+	}
+	appVersion := strings.TrimSpace(input.AppVersion)
+	if len(appVersion) > 50 {
+		return nil, fmt.Errorf("%w: versão do app deve ter no máximo 50 caracteres", ErrInvalidPushDevice)
+	}
+
+	device := &entity.PushDevice{
+		UserID:     userID,
+		Token:      token,
+		Platform:   platform,
+		DeviceName: deviceName,
+		AppVersion: appVersion,
+		LastSeenAt: time.Now(),
+	}
+	if err := uc.deviceRepo.Save(device); err != nil {
+		return nil, errors.New("falha ao registrar dispositivo: " + err.Error())
+	}
+	uc.pruneDevices(userID)
+	return device, nil
+}
+
+// pruneDevices remove os dispositivos usados há mais tempo além de maxPushDevicesPerUser,
+// que se acumulam quando o app é reinstalado sem cancelar o registro.
+func (uc *PushUseCase) pruneDevices(userID uuid.UUID) {
+	devices, err := uc.deviceRepo.FindByUserID(userID)
+	if err != nil {
+		log.Printf("Erro ao listar dispositivos do usuário %s: %v", userID, err)
+		return
+	}
+	for i := maxPushDevicesPerUser; i < len(devices); i++ {
+		if err := uc.deviceRepo.Delete(devices[i].ID); err != nil {
+			log.Printf("Erro ao remover dispositivo %s: %v", devices[i].ID, err)
+		}
+	}
+}
+
+// UnregisterDevice cancela o registro do token (ex: no logout). Não falha se o token já
+// não estiver registrado.
+func (uc *PushUseCase) UnregisterDevice(userID uuid.UUID, token string) error {
+	device, err := uc.deviceRepo.FindByToken(strings.TrimSpace(token))
+	if err != nil {
+		return errors.New("erro ao buscar dispositivo: " + err.Error())
+	}
+	if device == nil {
+		return nil
+	}
+	if device.UserID != userID {
+		return errors.New("dispositivo não encontrado")
+	}
+	return uc.deviceRepo.Delete(device.ID)
+}
+
+// DeleteDevice remove um dispositivo do usuário pelo ID (ex: aparelho perdido).
+func (uc *PushUseCase) DeleteDevice(deviceID, requestingUserID uuid.UUID) error {
+	device, err := uc.deviceRepo.FindByID(deviceID)
+	if err != nil {
+		return errors.New("erro ao buscar dispositivo: " + err.Error())
+	}
+	if device == nil || device.UserID != requestingUserID {
+		return errors.New("dispositivo não encontrado")
+	}
+	return uc.deviceRepo.Delete(deviceID)
+}
+
+// ListDevices lista os dispositivos registrados do usuário.
+func (uc *PushUseCase) ListDevices(userID uuid.UUID) ([]*entity.PushDevice, error) {
+	return uc.deviceRepo.FindByUserID(userID)
+}
+
+// GetPreferences retorna a preferência do usuário para cada tipo de evento, com o
+// padrão da aplicação para os tipos que ele não alterou.
+func (uc *PushUseCase) GetPreferences(userID uuid.UUID) ([]*entity.PushPreference, error) {
+	saved, err := uc.preferenceRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar preferências: " + err.Error())
+	}
+	byType := make(map[entity.DomainEventType]*entity.PushPreference, len(saved))
+	for _, p := range saved {
+		byType[p.EventType] = p
+	}
+
+	preferences := make([]*entity.PushPreference, len(entity.DomainEventTypes))
+	for i, eventType := range entity.DomainEventTypes {
+		if p, ok := byType[eventType]; ok {
+			preferences[i] = p
+		} else {
+			preferences[i] = &entity.PushPreference{UserID: userID, EventType: eventType, Enabled: pushDefaultEventTypes[eventType]}
+		}
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences altera as preferências dos tipos de evento informados; os demais
+// permanecem como estão. Retorna todas as preferências do usuário.
+func (uc *PushUseCase) UpdatePreferences(userID uuid.UUID, enabled map[string]bool) ([]*entity.PushPreference, error) {
+	if len(enabled) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um tipo de evento", ErrInvalidPushPreference)
+	}
+	preferences := make([]*entity.PushPreference, 0, len(enabled))
+	for value, on := range enabled {
+		eventType := entity.DomainEventType(strings.ToLower(strings.TrimSpace(value)))
+		if !eventType.IsValid() {
+			return nil, fmt.Errorf("%w: tipo de evento desconhecido: %s", ErrInvalidPushPreference, value)
+		}
+		preferences = append(preferences, &entity.PushPreference{UserID: userID, EventType: eventType, Enabled: on})
+	}
+	for _, p := range preferences {
+		if err := uc.preferenceRepo.Save(p); err != nil {
+			return nil, errors.New("falha ao salvar preferências: " + err.Error())
+		}
+	}
+	return uc.GetPreferences(userID)
+}
+
+// SendTest envia uma notificação de teste a todos os dispositivos do usuário. Retorna o
+// número de dispositivos notificados.
+func (uc *PushUseCase) SendTest(userID uuid.UUID) (int, error) {
+	devices, err := uc.deviceRepo.FindByUserID(userID)
+	if err != nil {
+		return 0, errors.New("erro ao buscar dispositivos: " + err.Error())
+	}
+	if len(devices) == 0 {
+		return 0, fmt.Errorf("%w: nenhum dispositivo registrado", ErrInvalidPushDevice)
+	}
+	sent, err := uc.notify(devices, "Notificações ativadas", "Você receberá aqui os avisos do seu negócio.", map[string]string{"type": "test"})
+	if sent == 0 && err != nil {
+		return 0, err
+	}
+	return sent, nil
+}
+
+// SubscriberName identifica as notificações push no EventDispatcher.
+func (uc *PushUseCase) SubscriberName() string {
+	return PushSubscriberName
+}
+
+// HandleEvent notifica os dispositivos do dono do evento, se ele tiver habilitado o tipo
+// do evento. Retorna erro (para nova tentativa do EventDispatcher) apenas se nenhum
+// dispositivo pôde ser notificado, para não repetir a notificação nos que a receberam.
+func (uc *PushUseCase) HandleEvent(event *entity.DomainEvent) error {
+	enabled, err := uc.isEnabled(event.UserID, event.Type)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	description, err := describeEvent(event)
+	if err != nil {
+		return err
+	}
+	devices, err := uc.deviceRepo.FindByUserID(event.UserID)
+	if err != nil {
+		return errors.New("erro ao buscar dispositivos: " + err.Error())
+	}
+	if len(devices) == 0 {
+		return nil
+	}
+
+	data := map[string]string{
+		"type":        string(event.Type),
+		"eventId":     event.ID.String(),
+		"aggregateId": event.AggregateID.String(),
+	}
+	sent, err := uc.notify(devices, description.Title, description.Body, data)
+	if sent == 0 && err != nil {
+		return err
+	}
+	return nil
+}
+
+// isEnabled indica se o usuário recebe notificações do tipo de evento.
+func (uc *PushUseCase) isEnabled(userID uuid.UUID, eventType entity.DomainEventType) (bool, error) {
+	preferences, err := uc.preferenceRepo.FindByUserID(userID)
+	if err != nil {
+		return false, errors.New("erro ao buscar preferências: " + err.Error())
+	}
+	for _, p := range preferences {
+		if p.EventType == eventType {
+			return p.Enabled, nil
+		}
+	}
+	return pushDefaultEventTypes[eventType], nil
+}
+
+// notify envia a notificação a cada dispositivo, removendo os de token inválido. Retorna
+// o número de envios aceitos e o último erro de envio, se houver.
+func (uc *PushUseCase) notify(devices []*entity.PushDevice, title, body string, data map[string]string) (int, error) {
+	sent := 0
+	var lastErr error
+	for _, device := range devices {
+		_, err := uc.sender.Send(push.Message{
+			Token:    device.Token,
+			Platform: string(device.Platform),
+			Title:    title,
+			Body:     body,
+			Data:     data,
+		})
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, push.ErrInvalidToken):
+			log.Printf("Token do dispositivo %s recusado pelo %s; removendo o registro", device.ID, uc.sender.Name())
+			if err := uc.deviceRepo.Delete(device.ID); err != nil {
+				log.Printf("Erro ao remover dispositivo %s: %v", device.ID, err)
+			}
+		default:
+			log.Printf("Falha ao enviar push ao dispositivo %s: %v", device.ID, err)
+			lastErr = err
+		}
+	}
+	return sent, lastErr
+}
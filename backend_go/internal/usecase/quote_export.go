@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/pdf"
+)
+
+// QuotePDF exporta o orçamento em PDF, com os dados do cliente, os itens e o total.
+func QuotePDF(quote *entity.Quote, businessName string) []byte {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	const left, right = 40.0, 555.0
+	const bottom = pdf.PageHeight - 60
+	page.Text(left, 50, 16, true, fmt.Sprintf("Orçamento nº %d", quote.Number))
+	page.Text(left, 70, 11, false, quote.Title)
+	if businessName != "" {
+		page.TextRight(right, 50, 10, true, businessName)
+	}
+	page.TextRight(right, 70, 8, false, "Emitido em "+quote.CreatedAt.Format("02/01/2006"))
+	page.TextRight(right, 82, 8, false, "Válido até "+quote.ValidUntil.Format("02/01/2006"))
+
+	y := 110.0
+	page.Text(left, y, 10, true, "Cliente: "+quote.ClientName)
+	if quote.ClientEmail != "" {
+		y += 14
+		page.Text(left, y, 9, false, quote.ClientEmail)
+	}
+	if quote.ClientPhone != "" {
+		y += 14
+		page.Text(left, y, 9, false, quote.ClientPhone)
+	}
+
+	// Cabeçalho da tabela: valores alinhados pela borda direita de cada coluna.
+	const qtyX, unitX = 380.0, 465.0
+	header := func() {
+		page.Text(left, y, 9, true, "Descrição")
+		page.TextRight(qtyX, y, 9, true, "Qtd.")
+		page.TextRight(unitX, y, 9, true, "Valor unit.")
+		page.TextRight(right, y, 9, true, "Total")
+		page.Line(left, y+5, right, y+5)
+	}
+	y += 35
+	header()
+	for _, item := range quote.Items {
+		y += 18
+		if y > bottom {
+			page = doc.AddPage()
+			y = 50
+			header()
+			y += 18
+		}
+		page.Text(left, y, 9, false, item.Description)
+		page.TextRight(qtyX, y, 9, false, fmt.Sprintf("%d", item.Quantity))
+		page.TextRight(unitX, y, 9, false, formatBRL(item.UnitPrice))
+		page.TextRight(right, y, 9, false, formatBRL(item.Total()))
+	}
+	page.Line(left, y+6, right, y+6)
+	y += 22
+	page.Text(left, y, 11, true, "Total")
+	page.TextRight(right, y, 11, true, "R$ "+formatBRL(quote.Total()))
+
+	if quote.Notes != "" {
+		y += 35
+		if y > bottom {
+			page = doc.AddPage()
+			y = 50
+		}
+		page.Text(left, y, 10, true, "Observações")
+		for _, line := range strings.Split(quote.Notes, "\n") {
+			y += 14
+			if y > bottom {
+				page = doc.AddPage()
+				y = 50
+			}
+			page.Text(left, y, 9, false, strings.TrimRight(line, "\r"))
+		}
+	}
+
+	return doc.Bytes()
+}
@@ -0,0 +1,659 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidQuote indica que os dados do orçamento ou da conversão são inválidos.
+var ErrInvalidQuote = errors.New("orçamento inválido")
+
+// ErrQuoteStatus indica que a operação não é permitida no status atual do orçamento.
+var ErrQuoteStatus = errors.New("operação não permitida para o status do orçamento")
+
+// errQuoteConverted desfaz a conversão quando o orçamento deixou de estar aceito ou já
+// foi convertido por outra operação.
+var errQuoteConverted = errors.New("orçamento já convertido em agendamentos")
+
+// defaultQuoteValidityDays é a validade usada quando o orçamento não informa uma data.
+const defaultQuoteValidityDays = 15
+
+// QuoteUseCase encapsula o ciclo de vida dos orçamentos: elaboração, envio, aceite ou
+// recusa pelo link público e conversão em agendamentos.
+type QuoteUseCase struct {
+	quoteRepo     repository.QuoteRepository
+	serviceRepo   repository.ServiceRepository
+	clientRepo    repository.ClientRepository
+	userRepo      repository.UserRepository
+	appointments  *AppointmentUseCase
+	uow           repository.UnitOfWork
+	publicBaseURL string
+}
+
+// NewQuoteUseCase cria uma nova instância de QuoteUseCase. publicBaseURL é o endereço
+// público da API usado para montar os links de aceite enviados aos clientes.
+func NewQuoteUseCase(
+	quoteRepo repository.QuoteRepository,
+	serviceRepo repository.ServiceRepository,
+	clientRepo repository.ClientRepository,
+	userRepo repository.UserRepository,
+	appointments *AppointmentUseCase,
+	uow repository.UnitOfWork,
+	publicBaseURL string,
+) *QuoteUseCase {
+	return &QuoteUseCase{
+		quoteRepo:     quoteRepo,
+		serviceRepo:   serviceRepo,
+		clientRepo:    clientRepo,
+		userRepo:      userRepo,
+		appointments:  appointments,
+		uow:           uow,
+		publicBaseURL: strings.TrimRight(publicBaseURL, "/"),
+	}
+}
+
+// QuoteItemInputDTO define uma linha do orçamento.
+type QuoteItemInputDTO struct {
+	ServiceID   *uuid.UUID // Se informado, preenche descrição e preço vazios a partir do catálogo
+	Description string
+	Quantity    int // Se zero, usa 1
+	UnitPrice   float64
+}
+
+// CreateQuoteInputDTO define os dados para criar um orçamento.
+type CreateQuoteInputDTO struct {
+	UserID      uuid.UUID
+	CustomerID  *uuid.UUID // Cliente do cadastro; se informado, preenche nome e contato vazios
+	ClientName  string
+	ClientEmail string
+	ClientPhone string
+	Title       string
+	Notes       string
+	ValidUntil  time.Time // Se zero, vale por 15 dias
+	Items       []QuoteItemInputDTO
+}
+
+// CreateQuote cria um orçamento em rascunho com numeração sequencial e token de link público.
+func (uc *QuoteUseCase) CreateQuote(input CreateQuoteInputDTO) (*entity.Quote, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if strings.TrimSpace(input.Title) == "" {
+		return nil, fmt.Errorf("%w: título é obrigatório", ErrInvalidQuote)
+	}
+
+	now := time.Now()
+	validUntil := input.ValidUntil
+	if validUntil.IsZero() {
+		validUntil = now.AddDate(0, 0, defaultQuoteValidityDays)
+	}
+	validUntil = startOfDay(validUntil)
+	if validUntil.Before(startOfDay(now)) {
+		return nil, fmt.Errorf("%w: validade deve ser hoje ou uma data futura", ErrInvalidQuote)
+	}
+
+	items, err := uc.buildItems(input.UserID, input.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &entity.Quote{
+		ID:          uuid.New(),
+		UserID:      input.UserID,
+		CustomerID:  input.CustomerID,
+		ClientName:  input.ClientName,
+		ClientEmail: input.ClientEmail,
+		ClientPhone: input.ClientPhone,
+		Title:       input.Title,
+		Notes:       input.Notes,
+		Items:       items,
+		ValidUntil:  validUntil,
+		Status:      entity.QuoteStatusDraft,
+	}
+	if err := uc.fillCustomer(quote); err != nil {
+		return nil, err
+	}
+
+	quote.Number, err = uc.quoteRepo.NextNumber(input.UserID)
+	if err != nil {
+		return nil, errors.New("falha ao numerar orçamento: " + err.Error())
+	}
+	quote.PublicToken, err = generateQuoteToken()
+	if err != nil {
+		return nil, errors.New("falha ao gerar link do orçamento: " + err.Error())
+	}
+	if err := uc.quoteRepo.Create(quote); err != nil {
+		return nil, errors.New("falha ao salvar orçamento: " + err.Error())
+	}
+	return quote, nil
+}
+
+// GetQuoteByID busca um orçamento verificando se pertence ao usuário.
+func (uc *QuoteUseCase) GetQuoteByID(quoteID, requestingUserID uuid.UUID) (*entity.Quote, error) {
+	quote, err := uc.quoteRepo.FindByID(quoteID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar orçamento: " + err.Error())
+	}
+	if quote == nil || quote.UserID != requestingUserID {
+		return nil, errors.New("orçamento não encontrado")
+	}
+	uc.refreshExpiry(quote)
+	return quote, nil
+}
+
+// ListQuotes lista os orçamentos do usuário, opcionalmente filtrados por status.
+func (uc *QuoteUseCase) ListQuotes(userID uuid.UUID, status entity.QuoteStatus) ([]*entity.Quote, error) {
+	return uc.quoteRepo.FindByUserID(userID, status)
+}
+
+// UpdateQuoteInputDTO define os dados para atualizar um orçamento.
+// Campos nulos não são alterados; Items nulo mantém os itens atuais.
+type UpdateQuoteInputDTO struct {
+	CustomerID    *uuid.UUID
+	ClearCustomer bool
+	ClientName    *string
+	ClientEmail   *string
+	ClientPhone   *string
+	Title         *string
+	Notes         *string
+	ValidUntil    *time.Time
+	Items         []QuoteItemInputDTO
+}
+
+// UpdateQuote atualiza um orçamento ainda não respondido (rascunho ou enviado).
+func (uc *QuoteUseCase) UpdateQuote(quoteID, requestingUserID uuid.UUID, input UpdateQuoteInputDTO) (*entity.Quote, error) {
+	quote, err := uc.GetQuoteByID(quoteID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != entity.QuoteStatusDraft && quote.Status != entity.QuoteStatusSent {
+		return nil, fmt.Errorf("%w: apenas orçamentos em rascunho ou enviados podem ser alterados", ErrQuoteStatus)
+	}
+
+	if input.ClearCustomer {
+		quote.CustomerID = nil
+	} else if input.CustomerID != nil {
+		quote.CustomerID = input.CustomerID
+	}
+	if input.ClientName != nil {
+		quote.ClientName = *input.ClientName
+	}
+	if input.ClientEmail != nil {
+		quote.ClientEmail = *input.ClientEmail
+	}
+	if input.ClientPhone != nil {
+		quote.ClientPhone = *input.ClientPhone
+	}
+	if input.Title != nil {
+		if strings.TrimSpace(*input.Title) == "" {
+			return nil, fmt.Errorf("%w: título é obrigatório", ErrInvalidQuote)
+		}
+		quote.Title = *input.Title
+	}
+	if input.Notes != nil {
+		quote.Notes = *input.Notes
+	}
+	if input.ValidUntil != nil {
+		validUntil := startOfDay(*input.ValidUntil)
+		if validUntil.Before(startOfDay(time.Now())) {
+			return nil, fmt.Errorf("%w: validade deve ser hoje ou uma data futura", ErrInvalidQuote)
+		}
+		quote.ValidUntil = validUntil
+	}
+	if input.Items != nil {
+		items, err := uc.buildItems(quote.UserID, input.Items)
+		if err != nil {
+			return nil, err
+		}
+		quote.Items = items
+	}
+	if err := uc.fillCustomer(quote); err != nil {
+		return nil, err
+	}
+
+	if err := uc.quoteRepo.Update(quote); err != nil {
+		return nil, errors.New("falha ao atualizar orçamento: " + err.Error())
+	}
+	return quote, nil
+}
+
+// DeleteQuote exclui um orçamento que ainda não foi convertido em agendamentos.
+func (uc *QuoteUseCase) DeleteQuote(quoteID, requestingUserID uuid.UUID) error {
+	quote, err := uc.GetQuoteByID(quoteID, requestingUserID)
+	if err != nil {
+		return err
+	}
+	if quote.ConvertedAt != nil {
+		return fmt.Errorf("%w: orçamento já convertido em agendamentos", ErrQuoteStatus)
+	}
+	if err := uc.quoteRepo.Delete(quoteID); err != nil {
+		return errors.New("falha ao excluir orçamento: " + err.Error())
+	}
+	return nil
+}
+
+// SendQuote marca o orçamento como enviado, liberando o link público para o cliente.
+// Pode ser chamado novamente para reenviar um orçamento já enviado.
+func (uc *QuoteUseCase) SendQuote(quoteID, requestingUserID uuid.UUID) (*entity.Quote, error) {
+	quote, err := uc.GetQuoteByID(quoteID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status != entity.QuoteStatusDraft && quote.Status != entity.QuoteStatusSent {
+		return nil, fmt.Errorf("%w: apenas orçamentos em rascunho podem ser enviados", ErrQuoteStatus)
+	}
+	if len(quote.Items) == 0 {
+		return nil, fmt.Errorf("%w: o orçamento não tem itens", ErrInvalidQuote)
+	}
+
+	now := time.Now()
+	quote.Status = entity.QuoteStatusSent
+	quote.SentAt = &now
+	if err := uc.quoteRepo.Update(quote); err != nil {
+		return nil, errors.New("falha ao enviar orçamento: " + err.Error())
+	}
+	return quote, nil
+}
+
+// AcceptQuote registra o aceite do orçamento informado pelo cliente fora do link público
+// (ex: por telefone).
+func (uc *QuoteUseCase) AcceptQuote(quoteID, requestingUserID uuid.UUID) (*entity.Quote, error) {
+	quote, err := uc.GetQuoteByID(quoteID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.respond(quote, entity.QuoteStatusAccepted, "")
+}
+
+// RejectQuote registra a recusa do orçamento informada pelo cliente fora do link público.
+func (uc *QuoteUseCase) RejectQuote(quoteID, requestingUserID uuid.UUID, reason string) (*entity.Quote, error) {
+	quote, err := uc.GetQuoteByID(quoteID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.respond(quote, entity.QuoteStatusRejected, reason)
+}
+
+// GetPublicQuote busca um orçamento pelo token do link público. Rascunhos não são expostos.
+func (uc *QuoteUseCase) GetPublicQuote(token string) (*entity.Quote, error) {
+	quote, err := uc.quoteRepo.FindByPublicToken(token)
+	if err != nil {
+		return nil, errors.New("erro ao buscar orçamento: " + err.Error())
+	}
+	if quote == nil || quote.Status == entity.QuoteStatusDraft {
+		return nil, errors.New("orçamento não encontrado")
+	}
+	uc.refreshExpiry(quote)
+	return quote, nil
+}
+
+// AcceptPublicQuote registra o aceite feito pelo cliente no link público.
+func (uc *QuoteUseCase) AcceptPublicQuote(token string) (*entity.Quote, error) {
+	quote, err := uc.GetPublicQuote(token)
+	if err != nil {
+		return nil, err
+	}
+	return uc.respond(quote, entity.QuoteStatusAccepted, "")
+}
+
+// RejectPublicQuote registra a recusa feita pelo cliente no link público.
+func (uc *QuoteUseCase) RejectPublicQuote(token, reason string) (*entity.Quote, error) {
+	quote, err := uc.GetPublicQuote(token)
+	if err != nil {
+		return nil, err
+	}
+	return uc.respond(quote, entity.QuoteStatusRejected, reason)
+}
+
+// respond aplica a resposta do cliente a um orçamento enviado. Repetir a mesma
+// resposta não gera erro, para tolerar cliques duplicados no link.
+func (uc *QuoteUseCase) respond(quote *entity.Quote, status entity.QuoteStatus, reason string) (*entity.Quote, error) {
+	if quote.Status == status {
+		return quote, nil
+	}
+	switch quote.Status {
+	case entity.QuoteStatusSent:
+	case entity.QuoteStatusExpired:
+		return nil, fmt.Errorf("%w: a validade do orçamento venceu", ErrQuoteStatus)
+	case entity.QuoteStatusDraft:
+		return nil, fmt.Errorf("%w: o orçamento ainda não foi enviado", ErrQuoteStatus)
+	default:
+		return nil, fmt.Errorf("%w: o orçamento já foi respondido", ErrQuoteStatus)
+	}
+
+	now := time.Now()
+	quote.Status = status
+	quote.RespondedAt = &now
+	quote.RejectReason = reason
+	if err := uc.quoteRepo.Update(quote); err != nil {
+		return nil, errors.New("falha ao registrar resposta do orçamento: " + err.Error())
+	}
+	return quote, nil
+}
+
+// QuoteAppointmentInputDTO define um agendamento a ser gerado na conversão do orçamento.
+type QuoteAppointmentInputDTO struct {
+	ItemIDs        []uuid.UUID // Itens atendidos; vazio, quando há um único agendamento, inclui todos
+	StartTime      time.Time
+	EndTime        time.Time // Se zero, usa a soma das durações dos serviços do catálogo
+	ProfessionalID *uuid.UUID
+}
+
+// ConvertQuote converte um orçamento aceito em agendamentos e registra a previsão de
+// receita de cada um, tudo na mesma transação. Cada item deve ser atendido por exatamente um agendamento, de
+// modo que a soma dos preços dos agendamentos é o total do orçamento.
+func (uc *QuoteUseCase) ConvertQuote(quoteID, requestingUserID uuid.UUID, slots []QuoteAppointmentInputDTO) (*entity.Quote, []*entity.Appointment, error) {
+	quote, err := uc.GetQuoteByID(quoteID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if quote.Status != entity.QuoteStatusAccepted {
+		return nil, nil, fmt.Errorf("%w: apenas orçamentos aceitos podem ser convertidos", ErrQuoteStatus)
+	}
+	if quote.ConvertedAt != nil {
+		return nil, nil, fmt.Errorf("%w: orçamento já convertido em agendamentos", ErrQuoteStatus)
+	}
+
+	plans, err := uc.planAppointments(quote, slots)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notes := fmt.Sprintf("Orçamento nº %d - %s", quote.Number, quote.Title)
+	appointments := make([]*entity.Appointment, 0, len(plans))
+	for _, plan := range plans {
+		appointment, err := uc.appointments.prepareAppointment(CreateAppointmentInputDTO{
+			UserID:             quote.UserID,
+			CustomerID:         quote.CustomerID,
+			ClientName:         quote.ClientName,
+			ClientEmail:        quote.ClientEmail,
+			ClientPhone:        quote.ClientPhone,
+			ServiceDescription: plan.description,
+			ServiceID:          plan.serviceID,
+			ProfessionalID:     plan.professionalID,
+			StartTime:          plan.startTime,
+			EndTime:            plan.endTime,
+			Notes:              notes,
+			Price:              plan.price,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	now := time.Now()
+	quote.ConvertedAt = &now
+	quote.AppointmentIDs = make([]uuid.UUID, len(appointments))
+	for i, appointment := range appointments {
+		quote.AppointmentIDs[i] = appointment.ID
+	}
+
+	// A marcação de convertido é feita primeiro e só vale para orçamentos ainda não
+	// convertidos: conversões simultâneas ou repetidas não duplicam os agendamentos.
+	err = uc.uow.Do(func(tx repository.Transaction) error {
+		claimed, err := tx.Quotes().MarkConverted(quote)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return errQuoteConverted
+		}
+		for i, appointment := range appointments {
+			if err := uc.appointments.applyPricingPolicies(tx, appointment, plans[i].price != 0); err != nil {
+				return err
+			}
+			if err := uc.appointments.writeAppointment(tx, nil, appointment); err != nil {
+				return err
+			}
+			forecast := &entity.IncomeForecast{
+				ID:            uuid.New(),
+				UserID:        quote.UserID,
+				QuoteID:       &quote.ID,
+				AppointmentID: &appointment.ID,
+				Description:   notes,
+				Amount:        appointment.Price,
+				ExpectedDate:  appointment.StartTime,
+			}
+			if err := tx.IncomeForecasts().Create(forecast); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errQuoteConverted) {
+		return nil, nil, fmt.Errorf("%w: %v", ErrQuoteStatus, err)
+	}
+	if err != nil {
+		return nil, nil, errors.New("falha ao converter orçamento: " + err.Error())
+	}
+
+	for _, appointment := range appointments {
+		uc.appointments.notifyChanged(nil, appointment)
+	}
+	return quote, appointments, nil
+}
+
+// ExpireOverdueQuotes marca como vencidos os orçamentos enviados cuja validade passou.
+func (uc *QuoteUseCase) ExpireOverdueQuotes(now time.Time) (int64, error) {
+	return uc.quoteRepo.ExpireSentBefore(startOfDay(now))
+}
+
+// PublicURL retorna o link público de visualização e aceite do orçamento.
+func (uc *QuoteUseCase) PublicURL(quote *entity.Quote) string {
+	return uc.publicBaseURL + "/api/v1/public/quotes/" + quote.PublicToken
+}
+
+// RenderQuotePDF gera o PDF do orçamento com o nome do negócio no cabeçalho.
+func (uc *QuoteUseCase) RenderQuotePDF(quote *entity.Quote) ([]byte, error) {
+	user, err := uc.userRepo.FindByID(quote.UserID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar dados do negócio: " + err.Error())
+	}
+	businessName := ""
+	if user != nil {
+		businessName = user.Name
+	}
+	return QuotePDF(quote, businessName), nil
+}
+
+// appointmentPlan é um agendamento já validado, pronto para ser criado na conversão.
+type appointmentPlan struct {
+	description    string
+	serviceID      *uuid.UUID
+	professionalID *uuid.UUID
+	startTime      time.Time
+	endTime        time.Time
+	price          float64
+}
+
+// planAppointments distribui os itens do orçamento entre os agendamentos informados
+// e calcula descrição, preço e horário de término de cada um.
+func (uc *QuoteUseCase) planAppointments(quote *entity.Quote, slots []QuoteAppointmentInputDTO) ([]appointmentPlan, error) {
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um agendamento", ErrInvalidQuote)
+	}
+	itemsByID := make(map[uuid.UUID]entity.QuoteItem, len(quote.Items))
+	for _, item := range quote.Items {
+		itemsByID[item.ID] = item
+	}
+	if len(slots) == 1 && len(slots[0].ItemIDs) == 0 {
+		for _, item := range quote.Items {
+			slots[0].ItemIDs = append(slots[0].ItemIDs, item.ID)
+		}
+	}
+
+	assigned := make(map[uuid.UUID]bool, len(quote.Items))
+	plans := make([]appointmentPlan, len(slots))
+	for i, slot := range slots {
+		if len(slot.ItemIDs) == 0 {
+			return nil, fmt.Errorf("%w: informe os itens do agendamento %d", ErrInvalidQuote, i+1)
+		}
+		if slot.StartTime.IsZero() {
+			return nil, fmt.Errorf("%w: informe o início do agendamento %d", ErrInvalidQuote, i+1)
+		}
+
+		var descriptions []string
+		price := 0.0
+		durationMinutes := 0
+		for _, itemID := range slot.ItemIDs {
+			item, ok := itemsByID[itemID]
+			if !ok {
+				return nil, fmt.Errorf("%w: item %s não pertence ao orçamento", ErrInvalidQuote, itemID)
+			}
+			if assigned[itemID] {
+				return nil, fmt.Errorf("%w: item %s informado em mais de um agendamento", ErrInvalidQuote, itemID)
+			}
+			assigned[itemID] = true
+
+			description := item.Description
+			if item.Quantity > 1 {
+				description = fmt.Sprintf("%dx %s", item.Quantity, item.Description)
+			}
+			descriptions = append(descriptions, description)
+			price += item.Total()
+			if item.ServiceID != nil {
+				service, err := uc.serviceRepo.FindByID(*item.ServiceID)
+				if err != nil {
+					return nil, errors.New("erro ao buscar serviço: " + err.Error())
+				}
+				if service != nil {
+					durationMinutes += service.DurationMinutes * item.Quantity
+				}
+			}
+		}
+
+		endTime := slot.EndTime
+		if endTime.IsZero() {
+			if durationMinutes == 0 {
+				return nil, fmt.Errorf("%w: informe o término do agendamento %d", ErrInvalidQuote, i+1)
+			}
+			endTime = slot.StartTime.Add(time.Duration(durationMinutes) * time.Minute)
+		}
+
+		plan := appointmentPlan{
+			description:    strings.Join(descriptions, "; "),
+			professionalID: slot.ProfessionalID,
+			startTime:      slot.StartTime,
+			endTime:        endTime,
+			price:          math.Round(price*100) / 100,
+		}
+		// Com preço zero o agendamento usaria o preço do catálogo, então o serviço só
+		// é vinculado quando o agendamento tem um único item cobrado.
+		if len(slot.ItemIDs) == 1 && plan.price > 0 {
+			plan.serviceID = itemsByID[slot.ItemIDs[0]].ServiceID
+		}
+		plans[i] = plan
+	}
+
+	if len(assigned) != len(quote.Items) {
+		return nil, fmt.Errorf("%w: todos os itens do orçamento devem ser distribuídos entre os agendamentos", ErrInvalidQuote)
+	}
+	return plans, nil
+}
+
+// buildItems valida as linhas do orçamento, completando descrição e preço a partir do catálogo.
+func (uc *QuoteUseCase) buildItems(userID uuid.UUID, inputs []QuoteItemInputDTO) ([]entity.QuoteItem, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um item", ErrInvalidQuote)
+	}
+	items := make([]entity.QuoteItem, len(inputs))
+	for i, input := range inputs {
+		item := entity.QuoteItem{
+			ID:          uuid.New(),
+			ServiceID:   input.ServiceID,
+			Description: strings.TrimSpace(input.Description),
+			Quantity:    input.Quantity,
+			UnitPrice:   input.UnitPrice,
+		}
+		if item.Quantity == 0 {
+			item.Quantity = 1
+		}
+		if item.ServiceID != nil {
+			service, err := uc.serviceRepo.FindByID(*item.ServiceID)
+			if err != nil {
+				return nil, errors.New("erro ao buscar serviço: " + err.Error())
+			}
+			if service == nil || service.UserID != userID {
+				return nil, errors.New("serviço não encontrado")
+			}
+			if item.Description == "" {
+				item.Description = service.Name
+			}
+			if item.UnitPrice == 0 {
+				item.UnitPrice = service.Price
+			}
+		}
+		if item.Description == "" {
+			return nil, fmt.Errorf("%w: descrição do item %d é obrigatória", ErrInvalidQuote, i+1)
+		}
+		if item.Quantity < 0 || item.UnitPrice < 0 {
+			return nil, fmt.Errorf("%w: quantidade e preço do item %d não podem ser negativos", ErrInvalidQuote, i+1)
+		}
+		item.UnitPrice = math.Round(item.UnitPrice*100) / 100
+		items[i] = item
+	}
+	return items, nil
+}
+
+// fillCustomer valida o cliente do cadastro e preenche nome e contato vazios.
+func (uc *QuoteUseCase) fillCustomer(quote *entity.Quote) error {
+	if quote.CustomerID != nil {
+		customer, err := uc.clientRepo.FindByID(*quote.CustomerID)
+		if err != nil {
+			return errors.New("erro ao buscar cliente: " + err.Error())
+		}
+		if customer == nil || customer.UserID != quote.UserID {
+			return errors.New("cliente não encontrado")
+		}
+		if quote.ClientName == "" {
+			quote.ClientName = customer.Name
+		}
+		if quote.ClientEmail == "" {
+			quote.ClientEmail = customer.Email
+		}
+		if quote.ClientPhone == "" {
+			quote.ClientPhone = customer.Phone
+		}
+	}
+	if strings.TrimSpace(quote.ClientName) == "" {
+		return fmt.Errorf("%w: nome do cliente é obrigatório", ErrInvalidQuote)
+	}
+	return nil
+}
+
+// refreshExpiry marca como vencido um orçamento enviado cuja validade passou, sem
+// esperar pela rotina periódica.
+func (uc *QuoteUseCase) refreshExpiry(quote *entity.Quote) {
+	if quote.Status != entity.QuoteStatusSent || !quote.IsExpired(time.Now()) {
+		return
+	}
+	quote.Status = entity.QuoteStatusExpired
+	if err := uc.quoteRepo.Update(quote); err != nil {
+		log.Printf("Falha ao marcar orçamento %s como vencido: %v", quote.ID, err)
+	}
+}
+
+// generateQuoteToken gera o token aleatório do link público do orçamento.
+func generateQuoteToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// startOfDay retorna a meia-noite do dia de t, no mesmo fuso.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
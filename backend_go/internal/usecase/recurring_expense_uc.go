@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// CreateRecurringExpenseInputDTO define os dados para cadastrar uma despesa recorrente.
+type CreateRecurringExpenseInputDTO struct {
+	UserID      uuid.UUID
+	CategoryID  *uuid.UUID
+	Description string
+	Amount      float64
+	DayOfMonth  int
+	StartDate   time.Time // Se zero, começa no mês atual
+	EndDate     *time.Time
+}
+
+// CreateRecurringExpense cadastra uma despesa mensal. Os lançamentos são gerados
+// pelo GenerateDueRecurringExpenses a cada vencimento.
+func (uc *FinanceUseCase) CreateRecurringExpense(input CreateRecurringExpenseInputDTO) (*entity.RecurringExpense, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if input.Description == "" {
+		return nil, errors.New("descrição da despesa é obrigatória")
+	}
+	if input.Amount <= 0 {
+		return nil, errors.New("valor da despesa deve ser maior que zero")
+	}
+	if input.DayOfMonth < 1 || input.DayOfMonth > 28 {
+		return nil, errors.New("dia do vencimento deve estar entre 1 e 28")
+	}
+	if err := uc.validateCategory(input.UserID, entity.FinancialEntryTypeExpense, input.CategoryID); err != nil {
+		return nil, err
+	}
+
+	startDate := input.StartDate
+	if startDate.IsZero() {
+		startDate = time.Now()
+	}
+	if input.EndDate != nil && input.EndDate.Before(startDate) {
+		return nil, errors.New("data final deve ser posterior à data inicial")
+	}
+
+	expense := &entity.RecurringExpense{
+		ID:          uuid.New(),
+		UserID:      input.UserID,
+		CategoryID:  input.CategoryID,
+		Description: input.Description,
+		Amount:      input.Amount,
+		DayOfMonth:  input.DayOfMonth,
+		StartDate:   startDate,
+		EndDate:     input.EndDate,
+		NextDueDate: firstDueDate(startDate, input.DayOfMonth),
+		Active:      true,
+	}
+	if err := uc.recurringRepo.Create(expense); err != nil {
+		return nil, errors.New("falha ao salvar despesa recorrente: " + err.Error())
+	}
+	return expense, nil
+}
+
+// GetRecurringExpenseByID busca uma despesa recorrente verificando se pertence ao usuário.
+func (uc *FinanceUseCase) GetRecurringExpenseByID(expenseID, requestingUserID uuid.UUID) (*entity.RecurringExpense, error) {
+	expense, err := uc.recurringRepo.FindByID(expenseID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar despesa recorrente: " + err.Error())
+	}
+	if expense == nil || expense.UserID != requestingUserID {
+		return nil, errors.New("despesa recorrente não encontrada")
+	}
+	return expense, nil
+}
+
+// ListRecurringExpenses lista as despesas recorrentes do usuário.
+func (uc *FinanceUseCase) ListRecurringExpenses(userID uuid.UUID) ([]*entity.RecurringExpense, error) {
+	return uc.recurringRepo.FindByUserID(userID)
+}
+
+// UpdateRecurringExpenseInputDTO define os dados para atualizar uma despesa recorrente.
+// Alterações valem a partir do próximo vencimento; lançamentos já gerados não mudam.
+type UpdateRecurringExpenseInputDTO struct {
+	CategoryID  *uuid.UUID
+	Description *string
+	Amount      *float64
+	DayOfMonth  *int
+	EndDate     *time.Time
+	Active      *bool
+}
+
+// UpdateRecurringExpense atualiza uma despesa recorrente.
+func (uc *FinanceUseCase) UpdateRecurringExpense(expenseID, requestingUserID uuid.UUID, input UpdateRecurringExpenseInputDTO) (*entity.RecurringExpense, error) {
+	expense, err := uc.GetRecurringExpenseByID(expenseID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.CategoryID != nil {
+		if err := uc.validateCategory(requestingUserID, entity.FinancialEntryTypeExpense, input.CategoryID); err != nil {
+			return nil, err
+		}
+		expense.CategoryID = input.CategoryID
+	}
+	if input.Description != nil {
+		expense.Description = *input.Description
+	}
+	if input.Amount != nil {
+		if *input.Amount <= 0 {
+			return nil, errors.New("valor da despesa deve ser maior que zero")
+		}
+		expense.Amount = *input.Amount
+	}
+	if input.DayOfMonth != nil {
+		if *input.DayOfMonth < 1 || *input.DayOfMonth > 28 {
+			return nil, errors.New("dia do vencimento deve estar entre 1 e 28")
+		}
+		expense.DayOfMonth = *input.DayOfMonth
+		next := expense.NextDueDate
+		expense.NextDueDate = time.Date(next.Year(), next.Month(), expense.DayOfMonth, 0, 0, 0, 0, next.Location())
+	}
+	if input.EndDate != nil {
+		expense.EndDate = input.EndDate
+	}
+	if input.Active != nil {
+		expense.Active = *input.Active
+	}
+
+	if err := uc.recurringRepo.Update(expense); err != nil {
+		return nil, errors.New("falha ao atualizar despesa recorrente: " + err.Error())
+	}
+	return expense, nil
+}
+
+// DeleteRecurringExpense exclui uma despesa recorrente. Lançamentos já gerados são mantidos.
+func (uc *FinanceUseCase) DeleteRecurringExpense(expenseID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetRecurringExpenseByID(expenseID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.recurringRepo.Delete(expenseID)
+}
+
+// GenerateDueRecurringExpenses cria os lançamentos de todas as despesas recorrentes
+// vencidas até now, de todos os usuários. Vencimentos atrasados (ex: servidor parado)
+// são lançados um a um. Os lançamentos e o próximo vencimento de cada despesa são
+// gravados na mesma transação, com a despesa bloqueada, para que execuções simultâneas
+// não lancem o mesmo vencimento duas vezes. Retorna a quantidade de lançamentos criados.
+func (uc *FinanceUseCase) GenerateDueRecurringExpenses(now time.Time) (int, error) {
+	dueExpenses, err := uc.recurringRepo.FindDue(now)
+	if err != nil {
+		return 0, errors.New("erro ao buscar despesas recorrentes vencidas: " + err.Error())
+	}
+
+	created := 0
+	for _, due := range dueExpenses {
+		var entries []*entity.FinancialEntry
+		err := uc.uow.Do(func(tx repository.Transaction) error {
+			entries = nil
+			expense, err := tx.RecurringExpenses().LockByID(due.ID)
+			if err != nil || expense == nil {
+				return err
+			}
+			changed := false
+			for expense.Active && !expense.NextDueDate.After(now) {
+				changed = true
+				if expense.EndDate != nil && expense.NextDueDate.After(*expense.EndDate) {
+					expense.Active = false
+					break
+				}
+
+				entry := &entity.FinancialEntry{
+					ID:                 uuid.New(),
+					UserID:             expense.UserID,
+					Type:               entity.FinancialEntryTypeExpense,
+					Amount:             expense.Amount,
+					Description:        expense.Description,
+					Date:               expense.NextDueDate,
+					CategoryID:         expense.CategoryID,
+					RecurringExpenseID: &expense.ID,
+				}
+				if err := tx.FinancialEntries().Create(entry); err != nil {
+					return err
+				}
+				entries = append(entries, entry)
+
+				expense.NextDueDate = expense.NextDueDate.AddDate(0, 1, 0)
+			}
+			if !changed {
+				return nil // Lançada por outra execução
+			}
+			return tx.RecurringExpenses().Update(expense)
+		})
+		if err != nil {
+			log.Printf("Falha ao gerar lançamentos da despesa recorrente %s: %v", due.ID, err)
+			continue
+		}
+		created += len(entries)
+		for _, entry := range entries {
+			uc.checkBudget(entry)
+		}
+	}
+	return created, nil
+}
+
+// firstDueDate retorna o primeiro vencimento no dia informado a partir de startDate.
+func firstDueDate(startDate time.Time, dayOfMonth int) time.Time {
+	due := time.Date(startDate.Year(), startDate.Month(), dayOfMonth, 0, 0, 0, 0, startDate.Location())
+	startDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	if due.Before(startDay) {
+		due = due.AddDate(0, 1, 0)
+	}
+	return due
+}
@@ -0,0 +1,442 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/messaging"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidReminderRule indica que as regras de lembrete informadas são inválidas.
+var ErrInvalidReminderRule = errors.New("regra de lembrete inválida")
+
+const (
+	// reminderMaxAttempts é o número máximo de tentativas de envio de um lembrete.
+	reminderMaxAttempts = 3
+	// reminderRetryDelay é o intervalo base entre as tentativas; cresce a cada falha.
+	reminderRetryDelay = 5 * time.Minute
+	// reminderSendLease é por quanto tempo um lembrete reservado para envio fica fora da
+	// fila; se o processo cair durante o envio, ele volta a ser tentado depois desse prazo.
+	reminderSendLease = 10 * time.Minute
+	// reminderDispatchBatch é o número máximo de lembretes enviados por execução.
+	reminderDispatchBatch = 100
+	// reminderMaxOffsetMinutes é a antecedência máxima de um lembrete (7 dias).
+	reminderMaxOffsetMinutes = 7 * 24 * 60
+
+	defaultReminderSubject  = "Lembrete: {{.Service}} em {{.Date}} às {{.Time}}"
+	defaultReminderTemplate = "Olá, {{.ClientName}}! Lembramos do seu horário de {{.Service}} em {{.BusinessName}} no dia {{.Date}} às {{.Time}}."
+)
+
+// defaultReminderOffsets são as antecedências usadas enquanto o usuário não configura
+// as próprias regras: 24 horas e 2 horas antes do início.
+var defaultReminderOffsets = []int{24 * 60, 2 * 60}
+
+// ReminderUseCase agenda e envia os lembretes de agendamento aos clientes.
+type ReminderUseCase struct {
+	reminderRepo    repository.ReminderRepository
+	ruleRepo        repository.ReminderRuleRepository
+	appointmentRepo repository.AppointmentRepository
+	userRepo        repository.UserRepository
+	channels        *messaging.Registry
+	defaultChannel  entity.ReminderChannel
+}
+
+// NewReminderUseCase cria uma nova instância de ReminderUseCase. defaultChannel é o
+// canal das regras padrão, usadas enquanto o usuário não configura as próprias.
+func NewReminderUseCase(
+	reminderRepo repository.ReminderRepository,
+	ruleRepo repository.ReminderRuleRepository,
+	appointmentRepo repository.AppointmentRepository,
+	userRepo repository.UserRepository,
+	channels *messaging.Registry,
+	defaultChannel string,
+) *ReminderUseCase {
+	channel := entity.ReminderChannel(strings.ToUpper(defaultChannel))
+	if !isValidReminderChannel(channel) {
+		log.Printf("Canal padrão de lembretes '%s' inválido; usando %s", defaultChannel, entity.ReminderChannelEmail)
+		channel = entity.ReminderChannelEmail
+	}
+	return &ReminderUseCase{
+		reminderRepo:    reminderRepo,
+		ruleRepo:        ruleRepo,
+		appointmentRepo: appointmentRepo,
+		userRepo:        userRepo,
+		channels:        channels,
+		defaultChannel:  channel,
+	}
+}
+
+func isValidReminderChannel(channel entity.ReminderChannel) bool {
+	switch channel {
+	case entity.ReminderChannelEmail, entity.ReminderChannelSMS, entity.ReminderChannelWhatsApp:
+		return true
+	}
+	return false
+}
+
+// GetRules retorna as regras de lembrete do usuário ou, se ele ainda não configurou
+// nenhuma, as regras padrão (sem ID).
+func (uc *ReminderUseCase) GetRules(userID uuid.UUID) ([]*entity.ReminderRule, error) {
+	rules, err := uc.ruleRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar regras de lembrete: " + err.Error())
+	}
+	if len(rules) > 0 {
+		return rules, nil
+	}
+	defaults := make([]*entity.ReminderRule, len(defaultReminderOffsets))
+	for i, offset := range defaultReminderOffsets {
+		defaults[i] = &entity.ReminderRule{
+			UserID:        userID,
+			OffsetMinutes: offset,
+			Channel:       uc.defaultChannel,
+			Active:        true,
+		}
+	}
+	return defaults, nil
+}
+
+// SaveReminderRuleInputDTO define os dados de uma regra de lembrete.
+type SaveReminderRuleInputDTO struct {
+	OffsetMinutes int
+	Channel       entity.ReminderChannel
+	Subject       string
+	Template      string
+	Active        bool
+}
+
+// SaveRules substitui as regras de lembrete do usuário e reagenda os lembretes dos
+// agendamentos futuros conforme as novas regras.
+func (uc *ReminderUseCase) SaveRules(userID uuid.UUID, inputs []SaveReminderRuleInputDTO) ([]*entity.ReminderRule, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos uma regra (use active = false para desativar)", ErrInvalidReminderRule)
+	}
+
+	seen := make(map[string]bool)
+	rules := make([]*entity.ReminderRule, len(inputs))
+	for i, input := range inputs {
+		channel := entity.ReminderChannel(strings.ToUpper(string(input.Channel)))
+		if !isValidReminderChannel(channel) {
+			return nil, fmt.Errorf("%w: canal inválido: %s", ErrInvalidReminderRule, input.Channel)
+		}
+		if input.OffsetMinutes <= 0 || input.OffsetMinutes > reminderMaxOffsetMinutes {
+			return nil, fmt.Errorf("%w: antecedência deve estar entre 1 e %d minutos", ErrInvalidReminderRule, reminderMaxOffsetMinutes)
+		}
+		key := reminderKey(input.OffsetMinutes, channel)
+		if seen[key] {
+			return nil, fmt.Errorf("%w: regra repetida para %d minutos via %s", ErrInvalidReminderRule, input.OffsetMinutes, channel)
+		}
+		seen[key] = true
+
+		rule := &entity.ReminderRule{
+			ID:            uuid.New(),
+			UserID:        userID,
+			OffsetMinutes: input.OffsetMinutes,
+			Channel:       channel,
+			Subject:       strings.TrimSpace(input.Subject),
+			Template:      strings.TrimSpace(input.Template),
+			Active:        input.Active,
+		}
+		// Valida os templates renderizando-os com dados de exemplo.
+		if _, _, err := renderReminder(rule, entity.ReminderTemplateData{}); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidReminderRule, err)
+		}
+		rules[i] = rule
+	}
+
+	if err := uc.ruleRepo.ReplaceForUser(userID, rules); err != nil {
+		return nil, errors.New("falha ao salvar regras de lembrete: " + err.Error())
+	}
+
+	now := time.Now()
+	upcoming, err := uc.appointmentRepo.FindByUserID(userID, &now, nil)
+	if err != nil {
+		log.Printf("Erro ao buscar agendamentos para reagendar lembretes do usuário %s: %v", userID, err)
+		return rules, nil
+	}
+	for _, appointment := range upcoming {
+		if err := uc.syncReminders(appointment, rules, now); err != nil {
+			log.Printf("Erro ao reagendar lembretes do agendamento %s: %v", appointment.ID, err)
+		}
+	}
+	return rules, nil
+}
+
+// ListReminders lista os lembretes do usuário; appointmentID nil e status vazio não filtram.
+func (uc *ReminderUseCase) ListReminders(userID uuid.UUID, appointmentID *uuid.UUID, status entity.ReminderStatus) ([]*entity.Reminder, error) {
+	return uc.reminderRepo.FindByUserID(userID, appointmentID, status)
+}
+
+// OnAppointmentChanged agenda, reagenda ou cancela os lembretes do agendamento
+// conforme o novo horário, status e contato do cliente.
+func (uc *ReminderUseCase) OnAppointmentChanged(previous, appointment *entity.Appointment) {
+	rules, err := uc.GetRules(appointment.UserID)
+	if err != nil {
+		log.Printf("Erro ao agendar lembretes do agendamento %s: %v", appointment.ID, err)
+		return
+	}
+	if err := uc.syncReminders(appointment, rules, time.Now()); err != nil {
+		log.Printf("Erro ao agendar lembretes do agendamento %s: %v", appointment.ID, err)
+	}
+}
+
+// OnAppointmentDeleted cancela os lembretes pendentes do agendamento excluído.
+func (uc *ReminderUseCase) OnAppointmentDeleted(appointment *entity.Appointment) {
+	if err := uc.syncReminders(appointment, nil, time.Now()); err != nil {
+		log.Printf("Erro ao cancelar lembretes do agendamento %s: %v", appointment.ID, err)
+	}
+}
+
+func reminderKey(offsetMinutes int, channel entity.ReminderChannel) string {
+	return fmt.Sprintf("%d/%s", offsetMinutes, channel)
+}
+
+// reminderRecipient retorna o contato do cliente usado pelo canal.
+func reminderRecipient(appointment *entity.Appointment, channel entity.ReminderChannel) string {
+	if channel == entity.ReminderChannelEmail {
+		return strings.TrimSpace(appointment.ClientEmail)
+	}
+	return strings.TrimSpace(appointment.ClientPhone)
+}
+
+// reminderAppointmentActive indica se o agendamento ainda deve ser lembrado.
+func reminderAppointmentActive(appointment *entity.Appointment) bool {
+	return appointment.Status == entity.AppointmentStatusPending || appointment.Status == entity.AppointmentStatusConfirmed
+}
+
+// syncReminders compara os lembretes agendados com os esperados pelas regras ativas:
+// mantém os que não mudaram, cancela os que deixaram de valer e cria os que faltam.
+// Lembretes já enviados não são repetidos, a menos que o horário do agendamento mude.
+// Regras cujo horário de envio já passou são ignoradas. rules nil cancela todos.
+func (uc *ReminderUseCase) syncReminders(appointment *entity.Appointment, rules []*entity.ReminderRule, now time.Time) error {
+	desired := make(map[string]*entity.Reminder)
+	if reminderAppointmentActive(appointment) {
+		for _, rule := range rules {
+			if !rule.Active {
+				continue
+			}
+			scheduledFor := appointment.StartTime.Add(-time.Duration(rule.OffsetMinutes) * time.Minute)
+			recipient := reminderRecipient(appointment, rule.Channel)
+			if !scheduledFor.After(now) || recipient == "" {
+				continue
+			}
+			desired[reminderKey(rule.OffsetMinutes, rule.Channel)] = &entity.Reminder{
+				ID:            uuid.New(),
+				UserID:        appointment.UserID,
+				AppointmentID: appointment.ID,
+				Channel:       rule.Channel,
+				OffsetMinutes: rule.OffsetMinutes,
+				Recipient:     recipient,
+				ScheduledFor:  scheduledFor,
+				Status:        entity.ReminderStatusScheduled,
+			}
+		}
+	}
+
+	existing, err := uc.reminderRepo.FindByAppointmentID(appointment.ID)
+	if err != nil {
+		return err
+	}
+	for _, reminder := range existing {
+		key := reminderKey(reminder.OffsetMinutes, reminder.Channel)
+		want, ok := desired[key]
+		switch reminder.Status {
+		case entity.ReminderStatusScheduled:
+			keep := ok && want.Recipient == reminder.Recipient && want.ScheduledFor.Equal(reminder.ScheduledFor)
+			// Um lembrete em nova tentativa já passou do horário original; segue enquanto
+			// o agendamento estiver ativo e a regra existir.
+			if reminder.Attempts > 0 && reminderAppointmentActive(appointment) && ruleExists(rules, reminder) {
+				keep = true
+			}
+			if keep {
+				delete(desired, key)
+				continue
+			}
+			reminder.Status = entity.ReminderStatusCancelled
+			if err := uc.reminderRepo.Update(reminder); err != nil {
+				return err
+			}
+		case entity.ReminderStatusSent, entity.ReminderStatusFailed:
+			if ok && want.ScheduledFor.Equal(reminder.ScheduledFor) {
+				delete(desired, key)
+			}
+		}
+	}
+
+	for _, reminder := range desired {
+		if err := uc.reminderRepo.Create(reminder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ruleExists indica se há uma regra ativa correspondente ao lembrete.
+func ruleExists(rules []*entity.ReminderRule, reminder *entity.Reminder) bool {
+	for _, rule := range rules {
+		if rule.Active && rule.OffsetMinutes == reminder.OffsetMinutes && rule.Channel == reminder.Channel {
+			return true
+		}
+	}
+	return false
+}
+
+// DispatchDueReminders envia os lembretes cujo horário chegou. Falhas de envio são
+// tentadas novamente com intervalo crescente até reminderMaxAttempts; lembretes de
+// agendamentos que deixaram de estar ativos são cancelados. Cada lembrete é reservado
+// antes do envio, para que execuções simultâneas (ou outras instâncias) não o enviem
+// duas vezes. Retorna quantos foram enviados e quantos falharam definitivamente.
+func (uc *ReminderUseCase) DispatchDueReminders(now time.Time) (int, int, error) {
+	due, err := uc.reminderRepo.FindDue(now, reminderDispatchBatch)
+	if err != nil {
+		return 0, 0, errors.New("erro ao buscar lembretes pendentes: " + err.Error())
+	}
+
+	rulesByUser := make(map[uuid.UUID]map[string]*entity.ReminderRule)
+	businessNames := make(map[uuid.UUID]string)
+	sent, failed := 0, 0
+	for _, reminder := range due {
+		claimed, err := uc.reminderRepo.Claim(reminder.ID, now, now.Add(reminderSendLease))
+		if err != nil {
+			log.Printf("Erro ao reservar lembrete %s para envio: %v", reminder.ID, err)
+			continue
+		}
+		if !claimed {
+			continue // Já enviado ou em envio por outra execução
+		}
+
+		appointment, err := uc.appointmentRepo.FindByID(reminder.AppointmentID)
+		if err != nil {
+			log.Printf("Erro ao buscar agendamento %s do lembrete %s: %v", reminder.AppointmentID, reminder.ID, err)
+			continue
+		}
+		if appointment == nil || !reminderAppointmentActive(appointment) || !appointment.StartTime.After(now) {
+			reminder.Status = entity.ReminderStatusCancelled
+			if err := uc.reminderRepo.Update(reminder); err != nil {
+				log.Printf("Erro ao cancelar lembrete %s: %v", reminder.ID, err)
+			}
+			continue
+		}
+
+		rules, ok := rulesByUser[reminder.UserID]
+		if !ok {
+			list, err := uc.GetRules(reminder.UserID)
+			if err != nil {
+				log.Printf("Erro ao buscar regras do lembrete %s: %v", reminder.ID, err)
+				continue
+			}
+			rules = make(map[string]*entity.ReminderRule, len(list))
+			for _, rule := range list {
+				rules[reminderKey(rule.OffsetMinutes, rule.Channel)] = rule
+			}
+			rulesByUser[reminder.UserID] = rules
+		}
+		rule, ok := rules[reminderKey(reminder.OffsetMinutes, reminder.Channel)]
+		if !ok {
+			// A regra foi removida depois do agendamento do lembrete; usa os templates padrão.
+			rule = &entity.ReminderRule{OffsetMinutes: reminder.OffsetMinutes, Channel: reminder.Channel}
+		}
+
+		businessName, ok := businessNames[reminder.UserID]
+		if !ok {
+			if user, err := uc.userRepo.FindByID(reminder.UserID); err == nil && user != nil {
+				businessName = user.Name
+			}
+			businessNames[reminder.UserID] = businessName
+		}
+
+		if uc.send(reminder, rule, appointment, businessName, now) {
+			sent++
+		} else if reminder.Status == entity.ReminderStatusFailed {
+			failed++
+		}
+	}
+	return sent, failed, nil
+}
+
+// send envia um lembrete e registra o resultado. Retorna true se foi entregue ao canal.
+func (uc *ReminderUseCase) send(reminder *entity.Reminder, rule *entity.ReminderRule, appointment *entity.Appointment, businessName string, now time.Time) bool {
+	data := appointmentTemplateData(appointment, businessName)
+	subject, body, err := renderReminder(rule, data)
+	if err == nil {
+		msg := messaging.Message{To: reminder.Recipient, Subject: subject, Body: body}
+		if reminder.Channel == entity.ReminderChannelWhatsApp {
+			// Fora da janela de atendimento o WhatsApp só entrega templates pré-aprovados.
+			msg.Body += "\n\n" + whatsAppReplyOptions
+			msg.Template = whatsAppTemplate(whatsAppReminderTemplate, data)
+		}
+		reminder.Subject = subject
+		reminder.Message = msg.Body
+		var channel messaging.Channel
+		channel, err = uc.channels.Get(string(reminder.Channel))
+		if err == nil {
+			var providerID string
+			providerID, err = channel.Send(msg)
+			reminder.ProviderMessageID = providerID
+		}
+	}
+
+	reminder.Attempts++
+	if err == nil {
+		sentAt := now
+		reminder.Status = entity.ReminderStatusSent
+		reminder.SentAt = &sentAt
+		reminder.LastError = ""
+	} else {
+		reminder.LastError = err.Error()
+		retryAt := now.Add(time.Duration(reminder.Attempts) * reminderRetryDelay)
+		if reminder.Attempts >= reminderMaxAttempts || !retryAt.Before(appointment.StartTime) {
+			reminder.Status = entity.ReminderStatusFailed
+		} else {
+			reminder.ScheduledFor = retryAt
+		}
+		log.Printf("Falha ao enviar lembrete %s (tentativa %d): %v", reminder.ID, reminder.Attempts, err)
+	}
+
+	if updateErr := uc.reminderRepo.Update(reminder); updateErr != nil {
+		log.Printf("Erro ao registrar envio do lembrete %s: %v", reminder.ID, updateErr)
+	}
+	return err == nil
+}
+
+// renderReminder monta o assunto e a mensagem do lembrete a partir dos templates da
+// regra ou dos padrões.
+func renderReminder(rule *entity.ReminderRule, data entity.ReminderTemplateData) (string, string, error) {
+	subjectTemplate, bodyTemplate := rule.Subject, rule.Template
+	if subjectTemplate == "" {
+		subjectTemplate = defaultReminderSubject
+	}
+	if bodyTemplate == "" {
+		bodyTemplate = defaultReminderTemplate
+	}
+
+	subject, err := executeReminderTemplate("assunto", subjectTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := executeReminderTemplate("mensagem", bodyTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+func executeReminderTemplate(name, text string, data entity.ReminderTemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("template de %s inválido: %v", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("template de %s inválido: %v", name, err)
+	}
+	return buf.String(), nil
+}
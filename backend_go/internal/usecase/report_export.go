@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/pdf"
+)
+
+// monthNames são os nomes dos meses usados nos relatórios exportados.
+var monthNames = []string{
+	"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
+	"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro",
+}
+
+// formatBRL formata um valor no padrão brasileiro (ex: 1.234,56).
+func formatBRL(value float64) string {
+	negative := value < 0
+	if negative {
+		value = -value
+	}
+	raw := fmt.Sprintf("%.2f", value)
+	intPart, decPart := raw[:len(raw)-3], raw[len(raw)-2:]
+
+	var b strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+	result := b.String() + "," + decPart
+	if negative {
+		result = "-" + result
+	}
+	return result
+}
+
+// AnnualRevenueReportCSV exporta o relatório anual em CSV separado por ponto e vírgula,
+// com valores no padrão brasileiro, para abrir diretamente em planilhas.
+func AnnualRevenueReportCSV(report *AnnualRevenueReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+
+	rows := [][]string{{
+		"Mês",
+		"Serviços com NF", "Serviços sem NF", "Total serviços",
+		"Comércio/Indústria com NF", "Comércio/Indústria sem NF", "Total comércio/indústria",
+		"Receita bruta",
+	}}
+	line := func(label string, m MonthlyRevenue) []string {
+		return []string{
+			label,
+			formatBRL(m.ServicesInvoiced), formatBRL(m.ServicesNotInvoiced), formatBRL(m.Services()),
+			formatBRL(m.CommerceInvoiced), formatBRL(m.CommerceNotInvoiced), formatBRL(m.Commerce()),
+			formatBRL(m.Total()),
+		}
+	}
+	for _, m := range report.Months {
+		rows = append(rows, line(monthNames[m.Month-1], m))
+	}
+	rows = append(rows, line(fmt.Sprintf("Total %d", report.Year), report.Totals))
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// AnnualRevenueReportPDF exporta o relatório anual em PDF, com a tabela mensal e o
+// resumo dos campos da DASN-SIMEI.
+func AnnualRevenueReportPDF(report *AnnualRevenueReport) []byte {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	const left, right = 40.0, 555.0
+	page.Text(left, 50, 16, true, fmt.Sprintf("Relatório Anual de Faturamento %d", report.Year))
+	page.Text(left, 70, 10, false, "Base para a Declaração Anual do MEI (DASN-SIMEI)")
+	page.Text(left, 90, 10, false, "Empresa: "+report.BusinessName)
+	if report.Regime != "" {
+		page.Text(left, 104, 10, false, "Regime: "+string(report.Regime))
+	}
+	page.TextRight(right, 90, 8, false, "Gerado em "+report.GeneratedAt.Format("02/01/2006 15:04"))
+
+	// Cabeçalho da tabela: a posição é a borda direita de cada coluna de valores.
+	columns := []struct {
+		title string
+		x     float64
+	}{
+		{"Serv. c/ NF", 160}, {"Serv. s/ NF", 225}, {"Com. c/ NF", 290},
+		{"Com. s/ NF", 355}, {"Serviços", 420}, {"Comércio", 487}, {"Total", right},
+	}
+	y := 135.0
+	page.Text(left, y, 9, true, "Mês")
+	for _, col := range columns {
+		page.TextRight(col.x, y, 9, true, col.title)
+	}
+	page.Line(left, y+5, right, y+5)
+
+	row := func(label string, m MonthlyRevenue, bold bool) {
+		values := []float64{
+			m.ServicesInvoiced, m.ServicesNotInvoiced, m.CommerceInvoiced,
+			m.CommerceNotInvoiced, m.Services(), m.Commerce(), m.Total(),
+		}
+		page.Text(left, y, 9, bold, label)
+		for i, col := range columns {
+			page.TextRight(col.x, y, 8, bold, formatBRL(values[i]))
+		}
+	}
+	for _, m := range report.Months {
+		y += 18
+		row(monthNames[m.Month-1], m, false)
+	}
+	page.Line(left, y+6, right, y+6)
+	y += 20
+	row("Total", report.Totals, true)
+
+	y += 45
+	page.Text(left, y, 12, true, "Valores para a declaração")
+	y += 22
+	page.Text(left, y, 10, false, "Receita bruta de comércio, indústria e transporte:")
+	page.TextRight(right, y, 10, true, "R$ "+formatBRL(report.Totals.Commerce()))
+	y += 16
+	page.Text(left, y, 10, false, "Receita bruta de prestação de serviços:")
+	page.TextRight(right, y, 10, true, "R$ "+formatBRL(report.Totals.Services()))
+	y += 16
+	page.Text(left, y, 10, false, "Receita bruta total:")
+	page.TextRight(right, y, 10, true, "R$ "+formatBRL(report.Totals.Total()))
+	y += 16
+	page.Text(left, y, 10, false, "Receita com nota fiscal emitida:")
+	page.TextRight(right, y, 10, false, "R$ "+formatBRL(report.Totals.ServicesInvoiced+report.Totals.CommerceInvoiced))
+	y += 16
+	page.Text(left, y, 10, false, "Receita sem nota fiscal emitida:")
+	page.TextRight(right, y, 10, false, "R$ "+formatBRL(report.Totals.ServicesNotInvoiced+report.Totals.CommerceNotInvoiced))
+
+	return doc.Bytes()
+}
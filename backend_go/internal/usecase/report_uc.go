@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ReportUseCase gera relatórios consolidados a partir do faturamento do usuário.
+type ReportUseCase struct {
+	revenueRepo repository.RevenueRepository
+	profileRepo repository.TaxProfileRepository
+	userRepo    repository.UserRepository
+}
+
+// NewReportUseCase cria uma nova instância de ReportUseCase.
+func NewReportUseCase(
+	revenueRepo repository.RevenueRepository,
+	profileRepo repository.TaxProfileRepository,
+	userRepo repository.UserRepository,
+) *ReportUseCase {
+	return &ReportUseCase{
+		revenueRepo: revenueRepo,
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// MonthlyRevenue é o faturamento de um mês separado por natureza e emissão de nota fiscal.
+type MonthlyRevenue struct {
+	Month               time.Month
+	ServicesInvoiced    float64
+	ServicesNotInvoiced float64
+	CommerceInvoiced    float64
+	CommerceNotInvoiced float64
+}
+
+// Services retorna a receita de prestação de serviços do mês.
+func (m MonthlyRevenue) Services() float64 {
+	return m.ServicesInvoiced + m.ServicesNotInvoiced
+}
+
+// Commerce retorna a receita de comércio e indústria do mês.
+func (m MonthlyRevenue) Commerce() float64 {
+	return m.CommerceInvoiced + m.CommerceNotInvoiced
+}
+
+// Total retorna a receita bruta do mês.
+func (m MonthlyRevenue) Total() float64 {
+	return m.Services() + m.Commerce()
+}
+
+func (m *MonthlyRevenue) add(bucket repository.RevenueBucket) {
+	switch {
+	case bucket.Kind == entity.RevenueKindCommerceIndustry && bucket.Invoiced:
+		m.CommerceInvoiced += bucket.Amount
+	case bucket.Kind == entity.RevenueKindCommerceIndustry:
+		m.CommerceNotInvoiced += bucket.Amount
+	case bucket.Invoiced:
+		m.ServicesInvoiced += bucket.Amount
+	default:
+		m.ServicesNotInvoiced += bucket.Amount
+	}
+}
+
+// AnnualRevenueReport reúne os valores exigidos pela declaração anual do MEI (DASN-SIMEI).
+type AnnualRevenueReport struct {
+	Year         int
+	BusinessName string
+	Regime       entity.TaxRegime // Vazio se o usuário não cadastrou o perfil tributário
+	Months       []MonthlyRevenue // Janeiro a dezembro
+	Totals       MonthlyRevenue   // Soma do ano (Month é zero)
+	GeneratedAt  time.Time
+}
+
+// GetAnnualRevenueReport agrega o faturamento do ano por mês, natureza da receita
+// (serviços ou comércio/indústria) e emissão de nota fiscal.
+func (uc *ReportUseCase) GetAnnualRevenueReport(userID uuid.UUID, year int) (*AnnualRevenueReport, error) {
+	user, err := uc.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar usuário: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.New("usuário não encontrado")
+	}
+
+	report := &AnnualRevenueReport{
+		Year:         year,
+		BusinessName: user.Name,
+		Months:       make([]MonthlyRevenue, 12),
+		GeneratedAt:  time.Now(),
+	}
+	profile, err := uc.profileRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar perfil tributário: " + err.Error())
+	}
+	if profile != nil {
+		report.Regime = profile.Regime
+	}
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	buckets, err := uc.revenueRepo.RevenueByMonth(userID, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, errors.New("erro ao calcular faturamento: " + err.Error())
+	}
+
+	for i := range report.Months {
+		report.Months[i].Month = time.Month(i + 1)
+	}
+	for _, bucket := range buckets {
+		if bucket.Month < time.January || bucket.Month > time.December {
+			continue
+		}
+		report.Months[bucket.Month-1].add(bucket)
+		report.Totals.add(bucket)
+	}
+	return report, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/pdf"
+)
+
+// paymentMethodLabels traduz as formas de pagamento para o recibo.
+var paymentMethodLabels = map[entity.PaymentMethod]string{
+	entity.PaymentMethodPix:      "PIX",
+	entity.PaymentMethodCard:     "Cartão",
+	entity.PaymentMethodCash:     "Dinheiro",
+	entity.PaymentMethodGiftCard: "Vale-presente",
+}
+
+// SaleReceiptPDF exporta o recibo de uma venda de balcão em PDF, com os itens, o total
+// e as formas de pagamento.
+func SaleReceiptPDF(sale *entity.Sale, businessName, clientName string) []byte {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	const left, right = 40.0, 555.0
+	const bottom = pdf.PageHeight - 60
+	page.Text(left, 50, 16, true, fmt.Sprintf("Recibo de venda nº %d", sale.Number))
+	if businessName != "" {
+		page.TextRight(right, 50, 10, true, businessName)
+	}
+	page.TextRight(right, 70, 8, false, "Emitido em "+sale.SoldAt.Format("02/01/2006 15:04"))
+
+	y := 70.0
+	if clientName != "" {
+		y = 90
+		page.Text(left, y, 10, true, "Cliente: "+clientName)
+	}
+
+	// Cabeçalho da tabela: valores alinhados pela borda direita de cada coluna.
+	const qtyX, unitX = 380.0, 465.0
+	header := func() {
+		page.Text(left, y, 9, true, "Descrição")
+		page.TextRight(qtyX, y, 9, true, "Qtd.")
+		page.TextRight(unitX, y, 9, true, "Valor unit.")
+		page.TextRight(right, y, 9, true, "Total")
+		page.Line(left, y+5, right, y+5)
+	}
+	y += 35
+	header()
+	for _, item := range sale.Items {
+		y += 18
+		if y > bottom {
+			page = doc.AddPage()
+			y = 50
+			header()
+			y += 18
+		}
+		page.Text(left, y, 9, false, item.Description)
+		page.TextRight(qtyX, y, 9, false, fmt.Sprintf("%d", item.Quantity))
+		page.TextRight(unitX, y, 9, false, formatBRL(item.UnitPrice))
+		page.TextRight(right, y, 9, false, formatBRL(item.Total()))
+	}
+	page.Line(left, y+6, right, y+6)
+	y += 22
+	page.Text(left, y, 11, true, "Total")
+	page.TextRight(right, y, 11, true, "R$ "+formatBRL(sale.Total))
+
+	if len(sale.Payments) > 0 {
+		y += 30
+		if y > bottom {
+			page = doc.AddPage()
+			y = 50
+		}
+		page.Text(left, y, 10, true, "Pagamento")
+		for _, p := range sale.Payments {
+			y += 14
+			if y > bottom {
+				page = doc.AddPage()
+				y = 50
+			}
+			label := paymentMethodLabels[p.Method]
+			if label == "" {
+				label = string(p.Method)
+			}
+			page.Text(left, y, 9, false, label)
+			page.TextRight(right, y, 9, false, "R$ "+formatBRL(p.Amount))
+		}
+	}
+
+	return doc.Bytes()
+}
@@ -0,0 +1,593 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidSale indica que os itens ou as formas de pagamento da venda são inválidos.
+var ErrInvalidSale = errors.New("venda inválida")
+
+// ErrCashRegisterStatus indica que a situação do caixa não permite a operação.
+var ErrCashRegisterStatus = errors.New("situação do caixa não permite a operação")
+
+// SaleUseCase encapsula as vendas de balcão (produtos, serviços avulsos e pacotes sem
+// agendamento), os recibos e a abertura e o fechamento do caixa do dia.
+type SaleUseCase struct {
+	saleRepo         repository.SaleRepository
+	cashRegisterRepo repository.CashRegisterRepository
+	paymentRepo      repository.PaymentRepository
+	productRepo      repository.ProductRepository
+	serviceRepo      repository.ServiceRepository
+	professionalRepo repository.ProfessionalRepository
+	clientRepo       repository.ClientRepository
+	userRepo         repository.UserRepository
+	packages         *PackageUseCase
+	products         *ProductUseCase
+	commissions      *CommissionUseCase
+}
+
+// NewSaleUseCase cria uma nova instância de SaleUseCase.
+func NewSaleUseCase(
+	saleRepo repository.SaleRepository,
+	cashRegisterRepo repository.CashRegisterRepository,
+	paymentRepo repository.PaymentRepository,
+	productRepo repository.ProductRepository,
+	serviceRepo repository.ServiceRepository,
+	professionalRepo repository.ProfessionalRepository,
+	clientRepo repository.ClientRepository,
+	userRepo repository.UserRepository,
+	packages *PackageUseCase,
+	products *ProductUseCase,
+	commissions *CommissionUseCase,
+) *SaleUseCase {
+	return &SaleUseCase{
+		saleRepo:         saleRepo,
+		cashRegisterRepo: cashRegisterRepo,
+		paymentRepo:      paymentRepo,
+		productRepo:      productRepo,
+		serviceRepo:      serviceRepo,
+		professionalRepo: professionalRepo,
+		clientRepo:       clientRepo,
+		userRepo:         userRepo,
+		packages:         packages,
+		products:         products,
+		commissions:      commissions,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Vendas
+// -----------------------------------------------------------------------------
+
+// SaleItemInputDTO define um item da venda de balcão.
+type SaleItemInputDTO struct {
+	Kind        entity.SaleItemKind
+	ProductID   *uuid.UUID // Obrigatório para PRODUCT
+	ServiceID   *uuid.UUID // Opcional para SERVICE; sem ele, Description e UnitPrice são obrigatórios
+	PackageID   *uuid.UUID // Obrigatório para PACKAGE
+	Description string     // Usada em serviços avulsos fora do catálogo
+	Quantity    int        // Se zero, 1
+	UnitPrice   *float64   // Se nil, usa o preço cadastrado
+}
+
+// SalePaymentInputDTO define uma forma de pagamento da venda.
+type SalePaymentInputDTO struct {
+	Method entity.PaymentMethod
+	Amount float64
+}
+
+// CreateSaleInputDTO define os dados de uma venda de balcão.
+type CreateSaleInputDTO struct {
+	UserID         uuid.UUID
+	ClientID       *uuid.UUID
+	ProfessionalID *uuid.UUID
+	Items          []SaleItemInputDTO
+	Payments       []SalePaymentInputDTO
+	Notes          string
+}
+
+// CreateSale registra uma venda de balcão: baixa o estoque dos produtos, gera os
+// pacotes vendidos ao cliente, registra os pagamentos como recebidos e lança a receita
+// no livro-caixa (produtos como comércio, serviços e pacotes como serviços), tudo de
+// forma atômica. A soma dos pagamentos deve ser igual ao total da venda. A venda fica
+// vinculada ao caixa aberto, se houver.
+func (uc *SaleUseCase) CreateSale(input CreateSaleInputDTO) (*entity.Sale, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	if len(input.Items) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um item", ErrInvalidSale)
+	}
+	if input.ClientID != nil {
+		client, err := uc.clientRepo.FindByID(*input.ClientID)
+		if err != nil {
+			return nil, errors.New("erro ao buscar cliente: " + err.Error())
+		}
+		if client == nil || client.UserID != input.UserID {
+			return nil, errors.New("cliente não encontrado")
+		}
+	}
+	if input.ProfessionalID != nil {
+		professional, err := uc.professionalRepo.FindByID(*input.ProfessionalID)
+		if err != nil {
+			return nil, errors.New("erro ao buscar profissional: " + err.Error())
+		}
+		if professional == nil || professional.UserID != input.UserID {
+			return nil, errors.New("profissional não encontrado")
+		}
+	}
+
+	now := time.Now()
+	sale := &entity.Sale{
+		ID:             uuid.New(),
+		UserID:         input.UserID,
+		ClientID:       input.ClientID,
+		ProfessionalID: input.ProfessionalID,
+		Notes:          strings.TrimSpace(input.Notes),
+		SoldAt:         now,
+	}
+
+	var clientPackages []*entity.ClientPackage
+	var packageEntries []*entity.FinancialEntry
+	requestedStock := make(map[uuid.UUID]int)
+	for _, itemInput := range input.Items {
+		item, err := uc.buildSaleItem(sale, itemInput, requestedStock)
+		if err != nil {
+			return nil, err
+		}
+		if item.Kind == entity.SaleItemKindPackage {
+			clientPackage, entry, err := uc.packages.preparePackageSale(SellPackageInputDTO{
+				UserID:      sale.UserID,
+				CustomerID:  *sale.ClientID,
+				PackageID:   *item.PackageID,
+				Price:       &item.UnitPrice,
+				PurchasedAt: now,
+			})
+			if err != nil {
+				return nil, err
+			}
+			item.ClientPackageID = &clientPackage.ID
+			item.Description = "Pacote: " + clientPackage.Name
+			clientPackages = append(clientPackages, clientPackage)
+			if entry != nil {
+				packageEntries = append(packageEntries, entry)
+			}
+		}
+		sale.Items = append(sale.Items, item)
+		sale.Total += item.Total()
+	}
+	sale.Total = roundCents(sale.Total)
+
+	var total float64
+	for _, line := range input.Payments {
+		if line.Amount <= 0 {
+			return nil, fmt.Errorf("%w: valor de cada pagamento deve ser maior que zero", ErrInvalidSale)
+		}
+		switch line.Method {
+		case entity.PaymentMethodPix, entity.PaymentMethodCard, entity.PaymentMethodCash:
+		case entity.PaymentMethodGiftCard:
+			return nil, fmt.Errorf("%w: vale-presente é aceito apenas no fechamento de atendimentos", ErrInvalidSale)
+		default:
+			return nil, fmt.Errorf("%w: forma de pagamento inválida: %s", ErrInvalidSale, line.Method)
+		}
+		total += line.Amount
+		sale.Payments = append(sale.Payments, &entity.Payment{
+			ID:     uuid.New(),
+			UserID: sale.UserID,
+			Amount: roundCents(line.Amount),
+			Method: line.Method,
+			Status: entity.PaymentStatusPaid,
+			PaidAt: &now,
+			SaleID: &sale.ID,
+		})
+	}
+	if math.Abs(roundCents(total)-sale.Total) > 0.009 {
+		return nil, fmt.Errorf("%w: pagamentos somam %s, mas o total da venda é %s",
+			ErrInvalidSale, formatBRL(total), formatBRL(sale.Total))
+	}
+
+	register, err := uc.cashRegisterRepo.FindOpenByUserID(sale.UserID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar caixa aberto: " + err.Error())
+	}
+	if register != nil {
+		sale.CashRegisterID = &register.ID
+	}
+	number, err := uc.saleRepo.NextNumber(sale.UserID)
+	if err != nil {
+		return nil, errors.New("erro ao gerar número da venda: " + err.Error())
+	}
+	sale.Number = number
+
+	entries := uc.buildSaleEntries(sale)
+	entries = append(entries, packageEntries...)
+	var movements []*entity.StockMovement
+	for _, item := range sale.Items {
+		if item.Kind != entity.SaleItemKindProduct {
+			continue
+		}
+		movements = append(movements, &entity.StockMovement{
+			UserID:    sale.UserID,
+			ProductID: *item.ProductID,
+			Type:      entity.StockMovementTypeSale,
+			Quantity:  -item.Quantity,
+			UnitPrice: item.UnitPrice,
+			SaleID:    &sale.ID,
+			Notes:     fmt.Sprintf("Venda nº %d", sale.Number),
+		})
+	}
+
+	events, err := paidPaymentEvents(sale.Payments)
+	if err != nil {
+		return nil, errors.New("falha ao montar eventos da venda: " + err.Error())
+	}
+	if err := uc.saleRepo.Create(sale, clientPackages, movements, entries, events); err != nil {
+		return nil, errors.New("falha ao salvar venda: " + err.Error())
+	}
+
+	uc.afterSale(sale)
+	return sale, nil
+}
+
+// buildSaleItem valida um item da venda e o monta com o preço cadastrado, quando o
+// preço não é informado. requestedStock acumula as quantidades por produto para
+// conferir o estoque quando o mesmo produto aparece em mais de uma linha.
+func (uc *SaleUseCase) buildSaleItem(sale *entity.Sale, input SaleItemInputDTO, requestedStock map[uuid.UUID]int) (entity.SaleItem, error) {
+	item := entity.SaleItem{
+		ID:       uuid.New(),
+		SaleID:   sale.ID,
+		Kind:     input.Kind,
+		Quantity: input.Quantity,
+	}
+	if item.Quantity == 0 {
+		item.Quantity = 1
+	}
+	if item.Quantity < 0 {
+		return item, fmt.Errorf("%w: quantidade de cada item deve ser maior que zero", ErrInvalidSale)
+	}
+	if input.UnitPrice != nil && *input.UnitPrice < 0 {
+		return item, fmt.Errorf("%w: preço do item não pode ser negativo", ErrInvalidSale)
+	}
+
+	var defaultPrice float64
+	switch input.Kind {
+	case entity.SaleItemKindProduct:
+		if input.ProductID == nil {
+			return item, fmt.Errorf("%w: informe o produto vendido", ErrInvalidSale)
+		}
+		product, err := uc.productRepo.FindByID(*input.ProductID)
+		if err != nil {
+			return item, errors.New("erro ao buscar produto: " + err.Error())
+		}
+		if product == nil || product.UserID != sale.UserID {
+			return item, errors.New("produto não encontrado")
+		}
+		if !product.Active {
+			return item, fmt.Errorf("%w: produto %s está inativo", ErrInvalidSale, product.Name)
+		}
+		requestedStock[product.ID] += item.Quantity
+		if requestedStock[product.ID] > product.StockQuantity {
+			return item, fmt.Errorf("%w: estoque insuficiente de %s (disponível: %d)", ErrInvalidSale, product.Name, product.StockQuantity)
+		}
+		item.ProductID = &product.ID
+		item.Description = product.Name
+		defaultPrice = product.SalePrice
+
+	case entity.SaleItemKindService:
+		if input.ServiceID != nil {
+			service, err := uc.serviceRepo.FindByID(*input.ServiceID)
+			if err != nil {
+				return item, errors.New("erro ao buscar serviço: " + err.Error())
+			}
+			if service == nil || service.UserID != sale.UserID {
+				return item, errors.New("serviço não encontrado")
+			}
+			if !service.Active {
+				return item, fmt.Errorf("%w: serviço %s está inativo", ErrInvalidSale, service.Name)
+			}
+			item.ServiceID = &service.ID
+			item.Description = service.Name
+			defaultPrice = service.Price
+		} else {
+			item.Description = strings.TrimSpace(input.Description)
+			if item.Description == "" || input.UnitPrice == nil {
+				return item, fmt.Errorf("%w: serviço fora do catálogo exige descrição e preço", ErrInvalidSale)
+			}
+		}
+
+	case entity.SaleItemKindPackage:
+		if input.PackageID == nil {
+			return item, fmt.Errorf("%w: informe o pacote vendido", ErrInvalidSale)
+		}
+		if sale.ClientID == nil {
+			return item, fmt.Errorf("%w: informe o cliente para vender pacotes", ErrInvalidSale)
+		}
+		if item.Quantity != 1 {
+			return item, fmt.Errorf("%w: cada pacote deve ser vendido em uma linha com quantidade 1", ErrInvalidSale)
+		}
+		pkg, err := uc.packages.GetPackageByID(*input.PackageID, sale.UserID)
+		if err != nil {
+			return item, err
+		}
+		item.PackageID = &pkg.ID
+		defaultPrice = pkg.Price
+
+	default:
+		return item, fmt.Errorf("%w: tipo de item inválido: %s", ErrInvalidSale, input.Kind)
+	}
+
+	item.UnitPrice = roundCents(defaultPrice)
+	if input.UnitPrice != nil {
+		item.UnitPrice = roundCents(*input.UnitPrice)
+	}
+	return item, nil
+}
+
+// buildSaleEntries monta os lançamentos de receita dos produtos e dos serviços avulsos.
+// Os pacotes têm lançamento próprio, igual ao da venda direta de pacote.
+func (uc *SaleUseCase) buildSaleEntries(sale *entity.Sale) []*entity.FinancialEntry {
+	var entries []*entity.FinancialEntry
+	kinds := []struct {
+		kind        entity.SaleItemKind
+		revenueKind entity.RevenueKind
+	}{
+		{entity.SaleItemKindProduct, entity.RevenueKindCommerceIndustry},
+		{entity.SaleItemKindService, entity.RevenueKindServices},
+	}
+	for _, k := range kinds {
+		amount := roundCents(sale.TotalByKind(k.kind))
+		if amount <= 0 {
+			continue
+		}
+		var names []string
+		for _, item := range sale.Items {
+			if item.Kind == k.kind {
+				names = append(names, fmt.Sprintf("%d x %s", item.Quantity, item.Description))
+			}
+		}
+		entries = append(entries, &entity.FinancialEntry{
+			ID:          uuid.New(),
+			UserID:      sale.UserID,
+			Type:        entity.FinancialEntryTypeIncome,
+			Amount:      amount,
+			Description: fmt.Sprintf("Venda nº %d: %s", sale.Number, strings.Join(names, ", ")),
+			Date:        sale.SoldAt,
+			RevenueKind: k.revenueKind,
+		})
+	}
+	return entries
+}
+
+// afterSale reavalia os alertas de estoque e apura as comissões do profissional da
+// venda sobre os produtos e serviços. Falhas são registradas em log.
+func (uc *SaleUseCase) afterSale(sale *entity.Sale) {
+	var productIDs []uuid.UUID
+	for _, item := range sale.Items {
+		if item.Kind == entity.SaleItemKindProduct {
+			productIDs = append(productIDs, *item.ProductID)
+		}
+	}
+	if len(productIDs) > 0 {
+		uc.products.RefreshLowStock(productIDs)
+	}
+
+	if sale.ProfessionalID == nil {
+		return
+	}
+	for _, item := range sale.Items {
+		if item.Total() <= 0 {
+			continue
+		}
+		description := fmt.Sprintf("Venda nº %d: %d x %s", sale.Number, item.Quantity, item.Description)
+		var err error
+		switch item.Kind {
+		case entity.SaleItemKindProduct:
+			_, err = uc.commissions.RecordProductCommission(ProductCommissionInputDTO{
+				UserID:         sale.UserID,
+				ProfessionalID: *sale.ProfessionalID,
+				Description:    description,
+				SaleAmount:     item.Total(),
+				SoldAt:         sale.SoldAt,
+			})
+		case entity.SaleItemKindService:
+			_, err = uc.commissions.RecordServiceSaleCommission(ServiceSaleCommissionInputDTO{
+				UserID:         sale.UserID,
+				ProfessionalID: *sale.ProfessionalID,
+				ServiceID:      item.ServiceID,
+				Description:    description,
+				SaleAmount:     item.Total(),
+				SoldAt:         sale.SoldAt,
+			})
+		}
+		if err != nil {
+			log.Printf("Falha ao apurar comissão do item %s da venda %s: %v", item.ID, sale.ID, err)
+		}
+	}
+}
+
+// GetSaleByID busca uma venda verificando se pertence ao usuário.
+func (uc *SaleUseCase) GetSaleByID(saleID, requestingUserID uuid.UUID) (*entity.Sale, error) {
+	sale, err := uc.saleRepo.FindByID(saleID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar venda: " + err.Error())
+	}
+	if sale == nil || sale.UserID != requestingUserID {
+		return nil, errors.New("venda não encontrada")
+	}
+	return sale, nil
+}
+
+// ListSales lista as vendas do usuário no intervalo [from, to).
+func (uc *SaleUseCase) ListSales(userID uuid.UUID, from, to time.Time) ([]*entity.Sale, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: data final deve ser posterior à inicial", ErrInvalidSale)
+	}
+	return uc.saleRepo.FindByUserID(userID, from, to)
+}
+
+// RenderSaleReceipt gera o recibo da venda em PDF, com o nome do negócio e do cliente.
+func (uc *SaleUseCase) RenderSaleReceipt(sale *entity.Sale) ([]byte, error) {
+	user, err := uc.userRepo.FindByID(sale.UserID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar dados do negócio: " + err.Error())
+	}
+	businessName := ""
+	if user != nil {
+		businessName = user.Name
+	}
+	clientName := ""
+	if sale.ClientID != nil {
+		client, err := uc.clientRepo.FindByID(*sale.ClientID)
+		if err != nil {
+			return nil, errors.New("erro ao buscar cliente: " + err.Error())
+		}
+		if client != nil {
+			clientName = client.Name
+		}
+	}
+	return SaleReceiptPDF(sale, businessName, clientName), nil
+}
+
+// -----------------------------------------------------------------------------
+// Caixa
+// -----------------------------------------------------------------------------
+
+// OpenCashRegister abre o caixa do dia com o fundo de troco informado. Só pode haver
+// um caixa aberto por vez.
+func (uc *SaleUseCase) OpenCashRegister(userID uuid.UUID, openingAmount float64, notes string) (*entity.CashRegister, error) {
+	if openingAmount < 0 {
+		return nil, fmt.Errorf("%w: fundo de troco não pode ser negativo", ErrInvalidSale)
+	}
+	open, err := uc.cashRegisterRepo.FindOpenByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar caixa aberto: " + err.Error())
+	}
+	if open != nil {
+		return nil, fmt.Errorf("%w: já existe um caixa aberto desde %s", ErrCashRegisterStatus, open.OpenedAt.Format("02/01/2006 15:04"))
+	}
+
+	register := &entity.CashRegister{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Status:         entity.CashRegisterStatusOpen,
+		OpeningAmount:  roundCents(openingAmount),
+		ExpectedAmount: roundCents(openingAmount),
+		OpenedAt:       time.Now(),
+		Notes:          strings.TrimSpace(notes),
+	}
+	if err := uc.cashRegisterRepo.Create(register); err != nil {
+		return nil, errors.New("falha ao abrir caixa: " + err.Error())
+	}
+	return register, nil
+}
+
+// GetCurrentCashRegister busca o caixa aberto do usuário, com o valor esperado na
+// gaveta até o momento.
+func (uc *SaleUseCase) GetCurrentCashRegister(userID uuid.UUID) (*entity.CashRegister, error) {
+	register, err := uc.cashRegisterRepo.FindOpenByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar caixa aberto: " + err.Error())
+	}
+	if register == nil {
+		return nil, errors.New("caixa não encontrado")
+	}
+	if err := uc.computeExpectedCash(register, time.Now()); err != nil {
+		return nil, err
+	}
+	return register, nil
+}
+
+// GetCashRegisterByID busca um caixa verificando se pertence ao usuário. Caixas ainda
+// abertos trazem o valor esperado na gaveta até o momento.
+func (uc *SaleUseCase) GetCashRegisterByID(registerID, requestingUserID uuid.UUID) (*entity.CashRegister, error) {
+	register, err := uc.findCashRegister(registerID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if register.Status == entity.CashRegisterStatusOpen {
+		if err := uc.computeExpectedCash(register, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return register, nil
+}
+
+// ListCashRegisters lista os caixas do usuário, do mais recente para o mais antigo.
+func (uc *SaleUseCase) ListCashRegisters(userID uuid.UUID) ([]*entity.CashRegister, error) {
+	return uc.cashRegisterRepo.FindByUserID(userID)
+}
+
+// CloseCashRegister fecha o caixa com o dinheiro contado na gaveta e registra a
+// diferença em relação ao esperado (fundo de troco mais os pagamentos em dinheiro
+// recebidos com o caixa aberto, de vendas, fechamentos de atendimento e cobranças).
+func (uc *SaleUseCase) CloseCashRegister(registerID, requestingUserID uuid.UUID, countedAmount float64, notes string) (*entity.CashRegister, error) {
+	if countedAmount < 0 {
+		return nil, fmt.Errorf("%w: valor contado não pode ser negativo", ErrInvalidSale)
+	}
+	register, err := uc.findCashRegister(registerID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if register.Status != entity.CashRegisterStatusOpen {
+		return nil, fmt.Errorf("%w: caixa já está fechado", ErrCashRegisterStatus)
+	}
+
+	now := time.Now()
+	if err := uc.computeExpectedCash(register, now); err != nil {
+		return nil, err
+	}
+	counted := roundCents(countedAmount)
+	difference := roundCents(counted - register.ExpectedAmount)
+	register.Status = entity.CashRegisterStatusClosed
+	register.CountedAmount = &counted
+	register.Difference = &difference
+	register.ClosedAt = &now
+	if notes = strings.TrimSpace(notes); notes != "" {
+		if register.Notes != "" {
+			register.Notes += "\n"
+		}
+		register.Notes += notes
+	}
+
+	closed, err := uc.cashRegisterRepo.Close(register)
+	if err != nil {
+		return nil, errors.New("falha ao fechar caixa: " + err.Error())
+	}
+	if !closed {
+		return nil, fmt.Errorf("%w: caixa foi fechado por outra operação", ErrCashRegisterStatus)
+	}
+	register.UpdatedAt = now
+	return register, nil
+}
+
+func (uc *SaleUseCase) findCashRegister(registerID, userID uuid.UUID) (*entity.CashRegister, error) {
+	register, err := uc.cashRegisterRepo.FindByID(registerID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar caixa: " + err.Error())
+	}
+	if register == nil || register.UserID != userID {
+		return nil, errors.New("caixa não encontrado")
+	}
+	return register, nil
+}
+
+// computeExpectedCash apura o dinheiro recebido desde a abertura do caixa até o momento
+// informado e o valor esperado na gaveta.
+func (uc *SaleUseCase) computeExpectedCash(register *entity.CashRegister, until time.Time) error {
+	received, err := uc.paymentRepo.SumPaidByMethod(register.UserID, entity.PaymentMethodCash, register.OpenedAt, until)
+	if err != nil {
+		return errors.New("erro ao apurar recebimentos em dinheiro: " + err.Error())
+	}
+	register.CashReceived = roundCents(received)
+	register.ExpectedAmount = roundCents(register.OpeningAmount + register.CashReceived)
+	return nil
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// TaxUseCase encapsula o perfil tributário e o acompanhamento do teto de faturamento.
+type TaxUseCase struct {
+	profileRepo repository.TaxProfileRepository
+	alertRepo   repository.RevenueLimitAlertRepository
+	revenueRepo repository.RevenueRepository
+}
+
+// NewTaxUseCase cria uma nova instância de TaxUseCase.
+func NewTaxUseCase(
+	profileRepo repository.TaxProfileRepository,
+	alertRepo repository.RevenueLimitAlertRepository,
+	revenueRepo repository.RevenueRepository,
+) *TaxUseCase {
+	return &TaxUseCase{
+		profileRepo: profileRepo,
+		alertRepo:   alertRepo,
+		revenueRepo: revenueRepo,
+	}
+}
+
+// SaveTaxProfileInputDTO define os dados para cadastrar ou atualizar o perfil tributário.
+type SaveTaxProfileInputDTO struct {
+	UserID             uuid.UUID
+	Regime             entity.TaxRegime
+	AnnualRevenueLimit *float64 // Se nil, usa o teto padrão do regime
+	AlertThresholds    []int    // Se vazio, usa entity.DefaultRevenueAlertThresholds
+}
+
+// GetProfile retorna o perfil tributário do usuário.
+func (uc *TaxUseCase) GetProfile(userID uuid.UUID) (*entity.TaxProfile, error) {
+	profile, err := uc.profileRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar perfil tributário: " + err.Error())
+	}
+	if profile == nil {
+		return nil, errors.New("perfil tributário não encontrado")
+	}
+	return profile, nil
+}
+
+// SaveProfile cadastra ou atualiza o perfil tributário do usuário.
+func (uc *TaxUseCase) SaveProfile(input SaveTaxProfileInputDTO) (*entity.TaxProfile, error) {
+	if input.UserID == uuid.Nil {
+		return nil, errors.New("ID do usuário é obrigatório")
+	}
+	switch input.Regime {
+	case entity.TaxRegimeMEI, entity.TaxRegimeSimplesNacional, entity.TaxRegimeAutonomous:
+	default:
+		return nil, errors.New("regime tributário inválido: " + string(input.Regime))
+	}
+
+	limit := entity.DefaultAnnualRevenueLimit(input.Regime)
+	if input.AnnualRevenueLimit != nil {
+		if *input.AnnualRevenueLimit < 0 {
+			return nil, errors.New("teto de faturamento não pode ser negativo")
+		}
+		limit = *input.AnnualRevenueLimit
+	}
+
+	thresholds := entity.DefaultRevenueAlertThresholds
+	if len(input.AlertThresholds) > 0 {
+		seen := make(map[int]bool)
+		thresholds = nil
+		for _, t := range input.AlertThresholds {
+			if t < 1 || t > 200 {
+				return nil, errors.New("percentuais de alerta devem estar entre 1 e 200")
+			}
+			if !seen[t] {
+				seen[t] = true
+				thresholds = append(thresholds, t)
+			}
+		}
+		sort.Ints(thresholds)
+	}
+
+	profile := &entity.TaxProfile{
+		UserID:             input.UserID,
+		Regime:             input.Regime,
+		AnnualRevenueLimit: limit,
+		AlertThresholds:    thresholds,
+	}
+	if err := uc.profileRepo.Save(profile); err != nil {
+		return nil, errors.New("falha ao salvar perfil tributário: " + err.Error())
+	}
+	return profile, nil
+}
+
+// RevenueLimitStatus resume o faturamento do ano em relação ao teto do regime.
+type RevenueLimitStatus struct {
+	Year              int
+	Regime            entity.TaxRegime
+	Limit             float64
+	Revenue           float64 // Faturamento acumulado no ano até a data de referência
+	Percent           float64 // Percentual do teto já utilizado
+	ProjectedRevenue  float64 // Projeção para o fim do ano mantido o ritmo atual
+	ProjectedPercent  float64
+	ReachedThresholds []int // Percentuais de alerta já atingidos
+	ProjectedToExceed bool  // A projeção ultrapassa o teto
+	ExceedsTolerance  bool  // MEI: faturamento acima do teto mais a tolerância de 20%
+	Warnings          []string
+}
+
+// GetRevenueLimitStatus calcula o faturamento do ano informado, a projeção para o
+// fim do ano e os avisos de teto. Para o ano corrente a projeção é linear em relação
+// aos dias já decorridos; para anos encerrados ela é igual ao faturamento realizado.
+func (uc *TaxUseCase) GetRevenueLimitStatus(userID uuid.UUID, year int, now time.Time) (*RevenueLimitStatus, error) {
+	profile, err := uc.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.revenueLimitStatus(profile, year, now)
+}
+
+// ListAlerts lista os alertas de teto de faturamento do usuário.
+func (uc *TaxUseCase) ListAlerts(userID uuid.UUID) ([]*entity.RevenueLimitAlert, error) {
+	return uc.alertRepo.FindByUserID(userID)
+}
+
+// CheckRevenueLimits verifica o faturamento do ano corrente de todos os perfis com
+// teto e gera um alerta para cada percentual atingido pela primeira vez no ano.
+// Retorna a quantidade de alertas criados.
+func (uc *TaxUseCase) CheckRevenueLimits(now time.Time) (int, error) {
+	profiles, err := uc.profileRepo.FindAll()
+	if err != nil {
+		return 0, errors.New("erro ao buscar perfis tributários: " + err.Error())
+	}
+
+	created := 0
+	for _, profile := range profiles {
+		if profile.AnnualRevenueLimit <= 0 {
+			continue
+		}
+		status, err := uc.revenueLimitStatus(profile, now.Year(), now)
+		if err != nil {
+			log.Printf("Falha ao calcular faturamento do usuário %s: %v", profile.UserID, err)
+			continue
+		}
+
+		for _, threshold := range status.ReachedThresholds {
+			existing, err := uc.alertRepo.FindByUserYearThreshold(profile.UserID, status.Year, threshold)
+			if err != nil {
+				log.Printf("Falha ao verificar alerta de teto do usuário %s: %v", profile.UserID, err)
+				continue
+			}
+			if existing != nil {
+				continue
+			}
+
+			alert := &entity.RevenueLimitAlert{
+				ID:        uuid.New(),
+				UserID:    profile.UserID,
+				Year:      status.Year,
+				Threshold: threshold,
+				Revenue:   status.Revenue,
+				Limit:     status.Limit,
+			}
+			if err := uc.alertRepo.Create(alert); err != nil {
+				log.Printf("Falha ao registrar alerta de teto do usuário %s: %v", profile.UserID, err)
+				continue
+			}
+			created++
+			log.Printf("Alerta de teto: usuário %s atingiu %d%% do teto de %d (R$ %.2f de R$ %.2f)",
+				profile.UserID, threshold, status.Year, status.Revenue, status.Limit)
+		}
+	}
+	return created, nil
+}
+
+func (uc *TaxUseCase) revenueLimitStatus(profile *entity.TaxProfile, year int, now time.Time) (*RevenueLimitStatus, error) {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location())
+	yearEnd := yearStart.AddDate(1, 0, 0)
+
+	revenue, err := uc.revenueRepo.SumRevenue(profile.UserID, yearStart, yearEnd)
+	if err != nil {
+		return nil, errors.New("erro ao calcular faturamento: " + err.Error())
+	}
+
+	status := &RevenueLimitStatus{
+		Year:             year,
+		Regime:           profile.Regime,
+		Limit:            profile.AnnualRevenueLimit,
+		Revenue:          revenue,
+		ProjectedRevenue: revenue,
+	}
+	if now.After(yearStart) && now.Before(yearEnd) {
+		elapsed := now.Sub(yearStart).Hours() / 24
+		total := yearEnd.Sub(yearStart).Hours() / 24
+		if elapsed >= 1 {
+			status.ProjectedRevenue = revenue / elapsed * total
+		}
+	}
+
+	if status.Limit <= 0 {
+		return status, nil
+	}
+
+	status.Percent = revenue / status.Limit * 100
+	status.ProjectedPercent = status.ProjectedRevenue / status.Limit * 100
+	for _, threshold := range profile.AlertThresholds {
+		if status.Percent >= float64(threshold) {
+			status.ReachedThresholds = append(status.ReachedThresholds, threshold)
+		}
+	}
+	if len(status.ReachedThresholds) > 0 {
+		reached := status.ReachedThresholds[len(status.ReachedThresholds)-1]
+		status.Warnings = append(status.Warnings, fmt.Sprintf("Faturamento atingiu %d%% do teto anual", reached))
+	}
+
+	if revenue > status.Limit {
+		status.Warnings = append(status.Warnings, "Faturamento ultrapassou o teto anual")
+	} else if status.ProjectedRevenue > status.Limit {
+		status.ProjectedToExceed = true
+		status.Warnings = append(status.Warnings, "No ritmo atual, o faturamento ultrapassará o teto até o fim do ano")
+	}
+
+	if profile.Regime == entity.TaxRegimeMEI && revenue > status.Limit*(1+entity.MEIExcessTolerance) {
+		status.ExceedsTolerance = true
+		status.Warnings = append(status.Warnings, "Excesso acima de 20% do teto: desenquadramento do MEI retroativo a janeiro")
+	}
+	return status, nil
+}
@@ -0,0 +1,514 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/webhook"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidWebhook indica que os dados da assinatura de webhook são inválidos.
+var ErrInvalidWebhook = errors.New("assinatura de webhook inválida")
+
+// ErrWebhookDeliveryStatus indica que a operação não é permitida no status atual do envio.
+var ErrWebhookDeliveryStatus = errors.New("operação não permitida para o status do envio")
+
+const (
+	// WebhookSubscriberName identifica os webhooks como assinante do EventDispatcher.
+	WebhookSubscriberName = "webhooks"
+
+	// webhookMaxAttempts é o número máximo de tentativas de um envio.
+	webhookMaxAttempts = 10
+	// webhookRetryBaseDelay é o intervalo da primeira nova tentativa; dobra a cada falha
+	// até webhookRetryMaxDelay (1, 2, 4 ... minutos, cerca de 8 horas e meia no total).
+	webhookRetryBaseDelay = time.Minute
+	webhookRetryMaxDelay  = 6 * time.Hour
+	// webhookSendLease é por quanto tempo um envio reservado fica fora da fila.
+	webhookSendLease = 2 * time.Minute
+	// webhookDispatchBatch é o número máximo de envios feitos por execução.
+	webhookDispatchBatch = 50
+	// webhookDisableAfterFailures é o número de tentativas com falha seguidas, em qualquer
+	// envio, após o qual a assinatura é desativada automaticamente.
+	webhookDisableAfterFailures = 20
+	// maxWebhooksPerUser limita as assinaturas de cada usuário.
+	maxWebhooksPerUser = 10
+	// minWebhookSecretLength é o tamanho mínimo de um segredo informado pelo usuário.
+	minWebhookSecretLength = 16
+	// defaultWebhookDeliveryLimit é o número de envios retornados na listagem sem limite informado.
+	defaultWebhookDeliveryLimit = 50
+)
+
+// WebhookUseCase gerencia as assinaturas de webhook dos usuários e envia a elas os
+// eventos de domínio. Recebe os eventos do EventDispatcher, cria um envio por assinatura
+// interessada e os entrega com novas tentativas em intervalos crescentes.
+type WebhookUseCase struct {
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	sender           *webhook.Sender
+}
+
+// NewWebhookUseCase cria uma nova instância de WebhookUseCase.
+func NewWebhookUseCase(
+	subscriptionRepo repository.WebhookSubscriptionRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	sender *webhook.Sender,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		sender:           sender,
+	}
+}
+
+// CreateWebhookInputDTO define os dados para cadastrar uma assinatura de webhook.
+type CreateWebhookInputDTO struct {
+	URL         string
+	Description string
+	EventTypes  []string // Vazio: todos os eventos
+	Secret      string   // Se vazio, é gerado
+}
+
+// UpdateWebhookInputDTO define os dados para alterar uma assinatura de webhook.
+type UpdateWebhookInputDTO struct {
+	URL         *string
+	Description *string
+	EventTypes  *[]string
+	Active      *bool // true reativa uma assinatura desativada automaticamente
+}
+
+// webhookEventBody é o JSON enviado aos destinos.
+type webhookEventBody struct {
+	ID         uuid.UUID       `json:"id"` // ID do evento; igual em novas tentativas e reenvios
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// CreateSubscription cadastra uma assinatura de webhook ativa.
+func (uc *WebhookUseCase) CreateSubscription(userID uuid.UUID, input CreateWebhookInputDTO) (*entity.WebhookSubscription, error) {
+	existing, err := uc.subscriptionRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar assinaturas: " + err.Error())
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, fmt.Errorf("%w: limite de %d assinaturas atingido", ErrInvalidWebhook, maxWebhooksPerUser)
+	}
+
+	webhookURL, err := validateWebhookURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := parseWebhookEventTypes(input.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	secret := strings.TrimSpace(input.Secret)
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, errors.New("falha ao gerar segredo: " + err.Error())
+		}
+	} else if len(secret) < minWebhookSecretLength {
+		return nil, fmt.Errorf("%w: segredo deve ter pelo menos %d caracteres", ErrInvalidWebhook, minWebhookSecretLength)
+	}
+
+	subscription := &entity.WebhookSubscription{
+		ID:          uuid.New(),
+		UserID:      userID,
+		URL:         webhookURL,
+		Description: strings.TrimSpace(input.Description),
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		Active:      true,
+	}
+	if err := uc.subscriptionRepo.Create(subscription); err != nil {
+		return nil, errors.New("falha ao salvar assinatura: " + err.Error())
+	}
+	return subscription, nil
+}
+
+// GetSubscription busca uma assinatura verificando se pertence ao usuário.
+func (uc *WebhookUseCase) GetSubscription(subscriptionID, requestingUserID uuid.UUID) (*entity.WebhookSubscription, error) {
+	subscription, err := uc.subscriptionRepo.FindByID(subscriptionID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar assinatura: " + err.Error())
+	}
+	if subscription == nil || subscription.UserID != requestingUserID {
+		return nil, errors.New("assinatura não encontrada")
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions lista as assinaturas de webhook do usuário.
+func (uc *WebhookUseCase) ListSubscriptions(userID uuid.UUID) ([]*entity.WebhookSubscription, error) {
+	return uc.subscriptionRepo.FindByUserID(userID)
+}
+
+// UpdateSubscription altera uma assinatura. Reativá-la zera o contador de falhas.
+func (uc *WebhookUseCase) UpdateSubscription(subscriptionID, requestingUserID uuid.UUID, input UpdateWebhookInputDTO) (*entity.WebhookSubscription, error) {
+	subscription, err := uc.GetSubscription(subscriptionID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		webhookURL, err := validateWebhookURL(*input.URL)
+		if err != nil {
+			return nil, err
+		}
+		subscription.URL = webhookURL
+	}
+	if input.Description != nil {
+		subscription.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.EventTypes != nil {
+		eventTypes, err := parseWebhookEventTypes(*input.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		subscription.EventTypes = eventTypes
+	}
+	if input.Active != nil && *input.Active != subscription.Active {
+		subscription.Active = *input.Active
+		subscription.ConsecutiveFailures = 0
+		subscription.DisabledAt = nil
+		subscription.DisabledReason = ""
+	}
+
+	if err := uc.subscriptionRepo.Update(subscription); err != nil {
+		return nil, errors.New("falha ao atualizar assinatura: " + err.Error())
+	}
+	if !subscription.Active {
+		if err := uc.deliveryRepo.FailPendingBySubscriptionID(subscription.ID, "assinatura desativada"); err != nil {
+			log.Printf("Falha ao cancelar envios pendentes da assinatura %s: %v", subscription.ID, err)
+		}
+	}
+	return subscription, nil
+}
+
+// RotateSecret gera um novo segredo para a assinatura. Os envios seguintes, inclusive
+// novas tentativas de envios antigos, passam a ser assinados com ele.
+func (uc *WebhookUseCase) RotateSecret(subscriptionID, requestingUserID uuid.UUID) (*entity.WebhookSubscription, error) {
+	subscription, err := uc.GetSubscription(subscriptionID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, errors.New("falha ao gerar segredo: " + err.Error())
+	}
+	subscription.Secret = secret
+	if err := uc.subscriptionRepo.Update(subscription); err != nil {
+		return nil, errors.New("falha ao atualizar assinatura: " + err.Error())
+	}
+	return subscription, nil
+}
+
+// DeleteSubscription exclui a assinatura com seu histórico de envios.
+func (uc *WebhookUseCase) DeleteSubscription(subscriptionID, requestingUserID uuid.UUID) error {
+	if _, err := uc.GetSubscription(subscriptionID, requestingUserID); err != nil {
+		return err
+	}
+	return uc.subscriptionRepo.Delete(subscriptionID)
+}
+
+// ListDeliveries lista os envios de uma assinatura do usuário, dos mais recentes para os
+// mais antigos. limit zero usa defaultWebhookDeliveryLimit.
+func (uc *WebhookUseCase) ListDeliveries(subscriptionID, requestingUserID uuid.UUID, status entity.WebhookDeliveryStatus, limit int) ([]*entity.WebhookDelivery, error) {
+	if _, err := uc.GetSubscription(subscriptionID, requestingUserID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryLimit
+	}
+	return uc.deliveryRepo.FindBySubscriptionID(subscriptionID, status, limit)
+}
+
+// GetDelivery busca um envio de uma assinatura do usuário com o histórico de tentativas.
+func (uc *WebhookUseCase) GetDelivery(subscriptionID, deliveryID, requestingUserID uuid.UUID) (*entity.WebhookDelivery, []*entity.WebhookAttempt, error) {
+	delivery, err := uc.findDelivery(subscriptionID, deliveryID, requestingUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	attempts, err := uc.deliveryRepo.FindAttempts(delivery.ID)
+	if err != nil {
+		return nil, nil, errors.New("erro ao buscar tentativas: " + err.Error())
+	}
+	return delivery, attempts, nil
+}
+
+func (uc *WebhookUseCase) findDelivery(subscriptionID, deliveryID, requestingUserID uuid.UUID) (*entity.WebhookDelivery, error) {
+	delivery, err := uc.deliveryRepo.FindByID(deliveryID)
+	if err != nil {
+		return nil, errors.New("erro ao buscar envio: " + err.Error())
+	}
+	if delivery == nil || delivery.UserID != requestingUserID || delivery.SubscriptionID != subscriptionID {
+		return nil, errors.New("envio não encontrado")
+	}
+	return delivery, nil
+}
+
+// Redeliver reenvia manualmente o conteúdo de um envio já concluído ou com falha. O
+// reenvio é um novo envio, com histórico próprio, enviado imediatamente.
+func (uc *WebhookUseCase) Redeliver(subscriptionID, deliveryID, requestingUserID uuid.UUID) (*entity.WebhookDelivery, error) {
+	original, err := uc.findDelivery(subscriptionID, deliveryID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if original.Status == entity.WebhookDeliveryStatusPending {
+		return nil, fmt.Errorf("%w: envio ainda está na fila", ErrWebhookDeliveryStatus)
+	}
+	subscription, err := uc.GetSubscription(original.SubscriptionID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.Active {
+		return nil, fmt.Errorf("%w: reative a assinatura antes de reenviar", ErrWebhookDeliveryStatus)
+	}
+
+	delivery := &entity.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: original.SubscriptionID,
+		UserID:         original.UserID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Body:           original.Body,
+		Status:         entity.WebhookDeliveryStatusPending,
+		NextAttemptAt:  time.Now(),
+		RedeliveryOf:   &original.ID,
+	}
+	if err := uc.deliveryRepo.Create(delivery); err != nil {
+		return nil, errors.New("falha ao criar reenvio: " + err.Error())
+	}
+	queued := *delivery // O envio ocorre em paralelo à resposta; não compartilha a entidade
+	go uc.deliver(&queued, time.Now())
+	return delivery, nil
+}
+
+// SubscriberName identifica os webhooks no EventDispatcher.
+func (uc *WebhookUseCase) SubscriberName() string {
+	return WebhookSubscriberName
+}
+
+// HandleEvent cria um envio do evento para cada assinatura ativa interessada do dono do
+// evento. É idempotente: um evento entregue de novo não duplica os envios.
+func (uc *WebhookUseCase) HandleEvent(event *entity.DomainEvent) error {
+	subscriptions, err := uc.subscriptionRepo.FindActiveByUserID(event.UserID)
+	if err != nil {
+		return errors.New("erro ao buscar assinaturas: " + err.Error())
+	}
+
+	body, err := json.Marshal(webhookEventBody{
+		ID:         event.ID,
+		Type:       string(event.Type),
+		OccurredAt: event.OccurredAt,
+		Data:       json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(event.Type) {
+			continue
+		}
+		delivery := &entity.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			UserID:         subscription.UserID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Body:           string(body),
+			Status:         entity.WebhookDeliveryStatusPending,
+			NextAttemptAt:  now,
+		}
+		created, err := uc.deliveryRepo.CreateIfAbsent(delivery)
+		if err != nil {
+			return errors.New("falha ao criar envio: " + err.Error())
+		}
+		if created {
+			queued := *delivery
+			go uc.deliver(&queued, now)
+		}
+	}
+	return nil
+}
+
+// DispatchDue faz os envios pendentes cujo horário de tentativa chegou. Retorna o número
+// de envios concluídos e o de envios que falharam definitivamente.
+func (uc *WebhookUseCase) DispatchDue(now time.Time) (int, int, error) {
+	due, err := uc.deliveryRepo.FindDue(now, webhookDispatchBatch)
+	if err != nil {
+		return 0, 0, errors.New("erro ao buscar envios pendentes: " + err.Error())
+	}
+
+	succeeded, failed := 0, 0
+	for _, delivery := range due {
+		if uc.deliver(delivery, now) {
+			succeeded++
+		} else if delivery.Status == entity.WebhookDeliveryStatusFailed {
+			failed++
+		}
+	}
+	return succeeded, failed, nil
+}
+
+// deliver reserva o envio, faz a requisição e registra a tentativa. Falhas são
+// reagendadas com intervalo crescente até webhookMaxAttempts. Retorna true se o destino
+// aceitou o evento.
+func (uc *WebhookUseCase) deliver(delivery *entity.WebhookDelivery, now time.Time) bool {
+	claimed, err := uc.deliveryRepo.Claim(delivery.ID, now, now.Add(webhookSendLease))
+	if err != nil {
+		log.Printf("Erro ao reservar envio de webhook %s: %v", delivery.ID, err)
+		return false
+	}
+	if !claimed {
+		return false // Já enviado ou em envio por outra execução
+	}
+
+	subscription, err := uc.subscriptionRepo.FindByID(delivery.SubscriptionID)
+	if err != nil {
+		log.Printf("Erro ao buscar assinatura do envio %s: %v", delivery.ID, err)
+		return false // A reserva expira e o envio é tentado novamente
+	}
+	if subscription == nil || !subscription.Active {
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+		delivery.LastError = "assinatura desativada"
+		if err := uc.deliveryRepo.Update(delivery); err != nil {
+			log.Printf("Erro ao registrar envio de webhook %s: %v", delivery.ID, err)
+		}
+		return false
+	}
+
+	attempt := &entity.WebhookAttempt{
+		ID:          uuid.New(),
+		DeliveryID:  delivery.ID,
+		AttemptedAt: time.Now(),
+	}
+	resp, sendErr := uc.sender.Send(webhook.Request{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventType:  string(delivery.EventType),
+		DeliveryID: delivery.ID.String(),
+		Body:       []byte(delivery.Body),
+	})
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	} else {
+		attempt.StatusCode = resp.StatusCode
+		attempt.ResponseBody = resp.Body
+		attempt.DurationMs = resp.Duration.Milliseconds()
+		if !resp.Success() {
+			attempt.Error = fmt.Sprintf("destino respondeu HTTP %d", resp.StatusCode)
+		}
+	}
+	if err := uc.deliveryRepo.CreateAttempt(attempt); err != nil {
+		log.Printf("Erro ao registrar tentativa do envio %s: %v", delivery.ID, err)
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = attempt.StatusCode
+	success := attempt.Error == ""
+	if success {
+		deliveredAt := time.Now()
+		delivery.Status = entity.WebhookDeliveryStatusSucceeded
+		delivery.DeliveredAt = &deliveredAt
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = attempt.Error
+		if delivery.Attempts >= webhookMaxAttempts {
+			delivery.Status = entity.WebhookDeliveryStatusFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(exponentialDelay(webhookRetryBaseDelay, webhookRetryMaxDelay, delivery.Attempts))
+		}
+		log.Printf("Falha no envio de webhook %s (tentativa %d): %s", delivery.ID, delivery.Attempts, attempt.Error)
+	}
+	if err := uc.deliveryRepo.Update(delivery); err != nil {
+		log.Printf("Erro ao registrar envio de webhook %s: %v", delivery.ID, err)
+	}
+
+	uc.recordOutcome(subscription, success)
+	return success
+}
+
+// recordOutcome atualiza o contador de falhas seguidas da assinatura e a desativa ao
+// atingir webhookDisableAfterFailures, encerrando os envios pendentes.
+func (uc *WebhookUseCase) recordOutcome(subscription *entity.WebhookSubscription, success bool) {
+	if success {
+		if subscription.ConsecutiveFailures == 0 {
+			return
+		}
+		subscription.ConsecutiveFailures = 0
+	} else {
+		subscription.ConsecutiveFailures++
+		if subscription.ConsecutiveFailures >= webhookDisableAfterFailures {
+			disabledAt := time.Now()
+			subscription.Active = false
+			subscription.DisabledAt = &disabledAt
+			subscription.DisabledReason = fmt.Sprintf("desativada após %d tentativas com falha seguidas", subscription.ConsecutiveFailures)
+		}
+	}
+
+	if err := uc.subscriptionRepo.Update(subscription); err != nil {
+		log.Printf("Erro ao atualizar assinatura de webhook %s: %v", subscription.ID, err)
+		return
+	}
+	if !subscription.Active {
+		log.Printf("Assinatura de webhook %s desativada: %s", subscription.ID, subscription.DisabledReason)
+		if err := uc.deliveryRepo.FailPendingBySubscriptionID(subscription.ID, "assinatura desativada"); err != nil {
+			log.Printf("Falha ao cancelar envios pendentes da assinatura %s: %v", subscription.ID, err)
+		}
+	}
+}
+
+// validateWebhookURL exige uma URL http(s) absoluta, sem credenciais.
+func validateWebhookURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w: URL deve começar com http:// ou https://", ErrInvalidWebhook)
+	}
+	if u.User != nil {
+		return "", fmt.Errorf("%w: URL não pode conter usuário e senha", ErrInvalidWebhook)
+	}
+	if len(raw) > 2048 {
+		return "", fmt.Errorf("%w: URL muito longa", ErrInvalidWebhook)
+	}
+	return raw, nil
+}
+
+// parseWebhookEventTypes valida os tipos de evento informados, removendo duplicados.
+func parseWebhookEventTypes(values []string) ([]entity.DomainEventType, error) {
+	var eventTypes []entity.DomainEventType
+	seen := make(map[entity.DomainEventType]bool)
+	for _, value := range values {
+		eventType := entity.DomainEventType(strings.ToLower(strings.TrimSpace(value)))
+		if !eventType.IsValid() {
+			return nil, fmt.Errorf("%w: tipo de evento desconhecido: %s", ErrInvalidWebhook, value)
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	return eventTypes, nil
+}
+
+// generateWebhookSecret gera um segredo aleatório para a assinatura HMAC.
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/entity"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/infra/messaging"
+	"github.com/RenanGroh/SaaS_microbusiness_project/backend_go/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// Templates pré-aprovados no WhatsApp Business. Parâmetros do corpo, na ordem:
+	// nome do cliente, serviço, nome do negócio, data e hora.
+	whatsAppConfirmationTemplate = "bizly_confirmacao_agendamento"
+	whatsAppReminderTemplate     = "bizly_lembrete_agendamento"
+	whatsAppTemplateLanguage     = "pt_BR"
+
+	// whatsAppReplyOptions é a instrução de resposta incluída nas mensagens que pedem confirmação.
+	whatsAppReplyOptions = "Responda 1 - Confirmar / 2 - Cancelar"
+	// whatsAppPhoneSuffixLength é a quantidade de dígitos finais usada para pré-selecionar
+	// os lembretes pelo telefone; a comparação final usa o número completo.
+	whatsAppPhoneSuffixLength = 8
+)
+
+// errWhatsAppReplyAmbiguous indica que a resposta sem mensagem de referência corresponde
+// a mais de um agendamento.
+var errWhatsAppReplyAmbiguous = errors.New("resposta corresponde a mais de um agendamento")
+
+// WhatsAppUseCase envia os pedidos de confirmação de agendamento pelo WhatsApp e aplica
+// as respostas dos clientes ("1 - Confirmar / 2 - Cancelar") recebidas via webhook.
+type WhatsAppUseCase struct {
+	messageRepo     repository.WhatsAppMessageRepository
+	reminderRepo    repository.ReminderRepository
+	appointmentRepo repository.AppointmentRepository
+	userRepo        repository.UserRepository
+	appointments    *AppointmentUseCase
+	channel         *messaging.WhatsAppChannel
+}
+
+// NewWhatsAppUseCase cria uma nova instância de WhatsAppUseCase.
+func NewWhatsAppUseCase(
+	messageRepo repository.WhatsAppMessageRepository,
+	reminderRepo repository.ReminderRepository,
+	appointmentRepo repository.AppointmentRepository,
+	userRepo repository.UserRepository,
+	appointments *AppointmentUseCase,
+	channel *messaging.WhatsAppChannel,
+) *WhatsAppUseCase {
+	return &WhatsAppUseCase{
+		messageRepo:     messageRepo,
+		reminderRepo:    reminderRepo,
+		appointmentRepo: appointmentRepo,
+		userRepo:        userRepo,
+		appointments:    appointments,
+		channel:         channel,
+	}
+}
+
+// whatsAppTemplate monta a referência ao template pré-aprovado com os dados do agendamento.
+func whatsAppTemplate(name string, data entity.ReminderTemplateData) *messaging.Template {
+	return &messaging.Template{
+		Name:     name,
+		Language: whatsAppTemplateLanguage,
+		Params:   []string{data.ClientName, data.Service, data.BusinessName, data.Date, data.Time},
+	}
+}
+
+// appointmentTemplateData reúne os campos usados nas mensagens sobre o agendamento.
+func appointmentTemplateData(appointment *entity.Appointment, businessName string) entity.ReminderTemplateData {
+	start := appointment.StartTime.In(time.Local)
+	return entity.ReminderTemplateData{
+		ClientName:   appointment.ClientName,
+		Service:      appointment.ServiceDescription,
+		BusinessName: businessName,
+		Date:         start.Format("02/01/2006"),
+		Time:         start.Format("15:04"),
+	}
+}
+
+func (uc *WhatsAppUseCase) businessName(userID uuid.UUID) string {
+	user, err := uc.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	return user.Name
+}
+
+// OnAppointmentChanged envia o pedido de confirmação na primeira vez em que um
+// agendamento futuro fica pendente com o telefone do cliente informado.
+func (uc *WhatsAppUseCase) OnAppointmentChanged(previous, appointment *entity.Appointment) {
+	if appointment.Status != entity.AppointmentStatusPending || !appointment.StartTime.After(time.Now()) {
+		return
+	}
+	phone := messaging.NormalizePhone(appointment.ClientPhone)
+	if phone == "" {
+		return
+	}
+	sent, err := uc.messageRepo.ExistsForAppointment(appointment.ID, entity.WhatsAppKindConfirmationRequest)
+	if err != nil {
+		log.Printf("Erro ao verificar pedido de confirmação do agendamento %s: %v", appointment.ID, err)
+		return
+	}
+	if sent {
+		return
+	}
+
+	data := appointmentTemplateData(appointment, uc.businessName(appointment.UserID))
+	body := fmt.Sprintf("Olá, %s! Seu horário de %s em %s está marcado para %s às %s. %s",
+		data.ClientName, data.Service, data.BusinessName, data.Date, data.Time, whatsAppReplyOptions)
+	uc.send(appointment, phone, entity.WhatsAppKindConfirmationRequest,
+		messaging.Message{To: phone, Body: body, Template: whatsAppTemplate(whatsAppConfirmationTemplate, data)})
+}
+
+// OnAppointmentDeleted não tem efeito: as mensagens já trocadas são mantidas no histórico.
+func (uc *WhatsAppUseCase) OnAppointmentDeleted(appointment *entity.Appointment) {}
+
+// send envia uma mensagem ao cliente e a registra no histórico, com o erro em caso de falha.
+func (uc *WhatsAppUseCase) send(appointment *entity.Appointment, phone string, kind entity.WhatsAppMessageKind, msg messaging.Message) {
+	record := &entity.WhatsAppMessage{
+		ID:        uuid.New(),
+		Direction: entity.WhatsAppDirectionOutbound,
+		Kind:      kind,
+		Phone:     phone,
+		Body:      msg.Body,
+	}
+	if appointment != nil {
+		record.UserID = &appointment.UserID
+		record.AppointmentID = &appointment.ID
+	}
+
+	providerID, err := uc.channel.Send(msg)
+	if err != nil {
+		log.Printf("Falha ao enviar mensagem de WhatsApp para %s: %v", phone, err)
+		record.Error = err.Error()
+	}
+	record.ProviderMessageID = providerID
+	if err := uc.messageRepo.Create(record); err != nil {
+		log.Printf("Erro ao registrar mensagem de WhatsApp para %s: %v", phone, err)
+	}
+}
+
+// HandleWebhook valida e processa um webhook de mensagens recebidas. Retorna erros que
+// envolvem messaging.ErrInvalidSignature ou messaging.ErrInvalidPayload para que o
+// handler responda com o status adequado. Mensagens já processadas são ignoradas.
+func (uc *WhatsAppUseCase) HandleWebhook(headers http.Header, body []byte) error {
+	messages, err := uc.channel.ParseWebhook(headers, body)
+	if err != nil {
+		return err
+	}
+	for _, inbound := range messages {
+		if err := uc.handleInbound(inbound); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *WhatsAppUseCase) handleInbound(inbound messaging.InboundMessage) error {
+	existing, err := uc.messageRepo.FindByProviderMessageID(inbound.ID)
+	if err != nil {
+		return errors.New("erro ao verificar mensagem recebida: " + err.Error())
+	}
+	if existing != nil {
+		return nil
+	}
+
+	action := parseWhatsAppReply(inbound.Text)
+	record := &entity.WhatsAppMessage{
+		ID:                uuid.New(),
+		Direction:         entity.WhatsAppDirectionInbound,
+		Kind:              entity.WhatsAppKindReply,
+		Phone:             inbound.From,
+		ProviderMessageID: inbound.ID,
+		Body:              inbound.Text,
+		Action:            action,
+	}
+
+	appointment, err := uc.resolveAppointment(inbound)
+	ambiguous := errors.Is(err, errWhatsAppReplyAmbiguous)
+	if err != nil && !ambiguous {
+		return err
+	}
+	var ack string
+	switch {
+	case ambiguous:
+		ack = "Encontramos mais de um agendamento para este número. Responda diretamente à mensagem do agendamento que deseja confirmar ou cancelar."
+	case appointment == nil:
+		ack = "Não encontramos um agendamento pendente para este número."
+	case action == entity.WhatsAppReplyUnknown:
+		ack = "Não entendemos sua resposta. " + whatsAppReplyOptions + "."
+	default:
+		ack, err = uc.applyReply(appointment, action)
+		if err != nil {
+			record.Error = err.Error()
+			ack = "Não foi possível atualizar seu agendamento. Por favor, entre em contato com o estabelecimento."
+		}
+	}
+	if appointment != nil {
+		record.UserID = &appointment.UserID
+		record.AppointmentID = &appointment.ID
+	}
+
+	if err := uc.messageRepo.Create(record); err != nil {
+		// Entrega simultânea da mesma mensagem: a outra já registrou e respondeu.
+		if again, findErr := uc.messageRepo.FindByProviderMessageID(inbound.ID); findErr == nil && again != nil {
+			return nil
+		}
+		return errors.New("falha ao registrar mensagem recebida: " + err.Error())
+	}
+
+	// A resposta vai dentro da janela de atendimento aberta pelo cliente, então dispensa template.
+	uc.send(appointment, inbound.From, entity.WhatsAppKindAck, messaging.Message{To: inbound.From, Body: ack})
+	return nil
+}
+
+// resolveAppointment identifica o agendamento a que a resposta se refere. Se o cliente
+// respondeu a uma mensagem enviada por nós (pedido de confirmação ou lembrete), vale o
+// agendamento dela. Sem essa referência, vale o agendamento pendente ou confirmado cujo
+// cliente tem o mesmo número e recebeu dele um pedido de confirmação ou lembrete pelo
+// WhatsApp; se houver mais de um, retorna errWhatsAppReplyAmbiguous em vez de adivinhar.
+func (uc *WhatsAppUseCase) resolveAppointment(inbound messaging.InboundMessage) (*entity.Appointment, error) {
+	now := time.Now()
+	if inbound.ContextID != "" {
+		var appointmentID *uuid.UUID
+		message, err := uc.messageRepo.FindByProviderMessageID(inbound.ContextID)
+		if err != nil {
+			return nil, errors.New("erro ao buscar mensagem respondida: " + err.Error())
+		}
+		if message != nil && message.Direction == entity.WhatsAppDirectionOutbound {
+			appointmentID = message.AppointmentID
+		} else if message == nil {
+			reminder, err := uc.reminderRepo.FindByProviderMessageID(inbound.ContextID)
+			if err != nil {
+				return nil, errors.New("erro ao buscar lembrete respondido: " + err.Error())
+			}
+			if reminder != nil {
+				appointmentID = &reminder.AppointmentID
+			}
+		}
+		if appointmentID != nil {
+			appointment, err := uc.appointmentRepo.FindByID(*appointmentID)
+			if err != nil {
+				return nil, errors.New("erro ao buscar agendamento: " + err.Error())
+			}
+			if appointment == nil || !reminderAppointmentActive(appointment) || !appointment.StartTime.After(now) {
+				return nil, nil
+			}
+			return appointment, nil
+		}
+	}
+
+	phones := messaging.PhoneVariants(inbound.From)
+	if len(phones) == 0 {
+		return nil, nil
+	}
+	isSender := func(phone string) bool {
+		normalized := messaging.NormalizePhone(phone)
+		for _, p := range phones {
+			if normalized == p {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Só são candidatos os agendamentos para os quais enviamos mensagens a este número.
+	var appointmentIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	addCandidate := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			appointmentIDs = append(appointmentIDs, id)
+		}
+	}
+	messages, err := uc.messageRepo.FindOutboundByPhones(phones, []entity.WhatsAppMessageKind{entity.WhatsAppKindConfirmationRequest})
+	if err != nil {
+		return nil, errors.New("erro ao buscar mensagens enviadas ao telefone: " + err.Error())
+	}
+	for _, m := range messages {
+		addCandidate(*m.AppointmentID)
+	}
+	phone := phones[0]
+	reminders, err := uc.reminderRepo.FindSentByPhoneSuffix(entity.ReminderChannelWhatsApp, phone[len(phone)-whatsAppPhoneSuffixLength:])
+	if err != nil {
+		return nil, errors.New("erro ao buscar lembretes enviados ao telefone: " + err.Error())
+	}
+	for _, r := range reminders {
+		if isSender(r.Recipient) {
+			addCandidate(r.AppointmentID)
+		}
+	}
+
+	var match *entity.Appointment
+	for _, id := range appointmentIDs {
+		appointment, err := uc.appointmentRepo.FindByID(id)
+		if err != nil {
+			return nil, errors.New("erro ao buscar agendamento: " + err.Error())
+		}
+		if appointment == nil || !reminderAppointmentActive(appointment) || !appointment.StartTime.After(now) || !isSender(appointment.ClientPhone) {
+			continue
+		}
+		if match != nil {
+			return nil, errWhatsAppReplyAmbiguous
+		}
+		match = appointment
+	}
+	return match, nil
+}
+
+// applyReply confirma ou cancela o agendamento pelo fluxo normal, para que lembretes e
+// demais listeners sejam atualizados. Retorna a mensagem de resposta ao cliente.
+func (uc *WhatsAppUseCase) applyReply(appointment *entity.Appointment, action entity.WhatsAppReplyAction) (string, error) {
+	data := appointmentTemplateData(appointment, "")
+	switch action {
+	case entity.WhatsAppReplyConfirm:
+		if appointment.Status == entity.AppointmentStatusPending {
+			confirmed := entity.AppointmentStatusConfirmed
+			if _, err := uc.appointments.UpdateAppointment(appointment.ID, appointment.UserID, UpdateAppointmentInputDTO{Status: &confirmed}); err != nil {
+				return "", err
+			}
+		}
+		return fmt.Sprintf("Obrigado! Seu horário de %s em %s às %s está confirmado.", data.Service, data.Date, data.Time), nil
+	case entity.WhatsAppReplyCancel:
+		if _, err := uc.appointments.CancelAppointment(appointment.ID, appointment.UserID); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Seu horário de %s em %s às %s foi cancelado. Se quiser remarcar, é só nos chamar.", data.Service, data.Date, data.Time), nil
+	}
+	return "", fmt.Errorf("ação de resposta inválida: %s", action)
+}
+
+// parseWhatsAppReply interpreta a resposta do cliente pela primeira palavra: "1" ou
+// "confirmar" confirmam; "2" ou "cancelar" cancelam. Aceita também os identificadores
+// dos botões de resposta rápida (CONFIRM, CANCEL).
+func parseWhatsAppReply(text string) entity.WhatsAppReplyAction {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return entity.WhatsAppReplyUnknown
+	}
+	switch words[0] {
+	case "1", "confirmar", "confirmo", "confirmado", "confirm", "sim":
+		return entity.WhatsAppReplyConfirm
+	case "2", "cancelar", "cancelo", "cancela", "cancel", "não", "nao":
+		return entity.WhatsAppReplyCancel
+	}
+	return entity.WhatsAppReplyUnknown
+}
+
+// ListMessages lista as mensagens de WhatsApp do usuário; appointmentID nil não filtra.
+func (uc *WhatsAppUseCase) ListMessages(userID uuid.UUID, appointmentID *uuid.UUID) ([]*entity.WhatsAppMessage, error) {
+	return uc.messageRepo.FindByUserID(userID, appointmentID)
+}